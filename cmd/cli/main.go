@@ -2,18 +2,28 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/golang-migrate/migrate"
 	"github.com/golang-migrate/migrate/database/postgres"
-	_ "github.com/golang-migrate/migrate/source/file"                //no lint
-	_ "github.com/jackc/pgx/stdlib"                                  //no lint
+	_ "github.com/golang-migrate/migrate/source/file" //no lint
+	_ "github.com/jackc/pgx/stdlib"                   //no lint
+	"github.com/rubengomes8/golang-personal-finances/internal/categorization"
 	_ "github.com/rubengomes8/golang-personal-finances/internal/env" //no lint
+	"github.com/rubengomes8/golang-personal-finances/internal/importer"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/card"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/expense"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/income"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/recurring"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/rule"
+	"github.com/rubengomes8/golang-personal-finances/internal/scheduler"
 	"github.com/rubengomes8/golang-personal-finances/internal/tools"
 	"github.com/urfave/cli"
 )
@@ -113,6 +123,118 @@ func rollbackDB(*cli.Context) error {
 	return nil
 }
 
+func parseStatement(c *cli.Context) ([]importer.Transaction, error) {
+	file, err := os.Open(c.String("file"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch c.String("format") {
+	case "csv":
+		profiles, err := importer.ProfilesFromFile(os.Getenv("IMPORT_PROFILES_FILEPATH"))
+		if err != nil {
+			return nil, err
+		}
+
+		profile, err := profiles.Get(c.String("profile"))
+		if err != nil {
+			return nil, fmt.Errorf("%v: %q", err, c.String("profile"))
+		}
+
+		return importer.ParseCSV(file, profile)
+	case "ofx":
+		return importer.ParseOFX(file)
+	case "camt053":
+		return importer.ParseCAMT053(file)
+	default:
+		return nil, fmt.Errorf("unknown import format %q", c.String("format"))
+	}
+}
+
+func importStatement(c *cli.Context) error {
+	userID := c.Int64("user")
+	if userID <= 0 {
+		return fmt.Errorf("--user must be the id of the user owning the imported rows, got %d", userID)
+	}
+
+	transactions, err := parseStatement(c)
+	if err != nil {
+		return err
+	}
+
+	db, err := tools.InitPostgres(os.Getenv("DB_LOCALHOST"))
+	if err != nil {
+		return err
+	}
+
+	cardDB := card.NewDatabase(db)
+	expCategoryDB := expense.NewCategoryDB(db)
+	expSubCategoryDB := expense.NewSubCategoryDB(db)
+	incCategoryDB := income.NewCategoryDB(db)
+
+	statementImporter := importer.NewImporter(
+		expense.NewDB(db, cardDB, expCategoryDB, expSubCategoryDB),
+		income.NewDB(db, cardDB, incCategoryDB),
+		cardDB,
+		expSubCategoryDB,
+		incCategoryDB,
+		categorization.NewCategorizer(rule.NewDB(db)),
+	)
+
+	result, err := statementImporter.Import(context.Background(), userID, transactions, importer.Target{
+		Card:           c.String("card"),
+		SubCategory:    c.String("subcategory"),
+		IncomeCategory: c.String("category"),
+	})
+	if err != nil {
+		if len(result.ExpenseIDs) > 0 || len(result.IncomeIDs) > 0 {
+			log.Printf("created expenses %v and incomes %v before the import failed", result.ExpenseIDs, result.IncomeIDs)
+		}
+		return err
+	}
+
+	log.Printf("imported %d expenses and %d incomes, skipped %d already imported transactions",
+		len(result.ExpenseIDs), len(result.IncomeIDs), len(result.SkippedReferences))
+
+	return nil
+}
+
+func runRecurring(c *cli.Context) error {
+	date := time.Now()
+	if c.String("date") != "" {
+		var err error
+		date, err = time.Parse("2006-01-02", c.String("date"))
+		if err != nil {
+			return fmt.Errorf("invalid date %q: %v", c.String("date"), err)
+		}
+	}
+
+	db, err := tools.InitPostgres(os.Getenv("DB_LOCALHOST"))
+	if err != nil {
+		return err
+	}
+
+	cardDB := card.NewDatabase(db)
+	incCategoryDB := income.NewCategoryDB(db)
+
+	runner := scheduler.NewRunner(
+		recurring.NewDB(db),
+		expense.NewDB(db, cardDB, expense.NewCategoryDB(db), expense.NewSubCategoryDB(db)),
+		income.NewDB(db, cardDB, incCategoryDB),
+	)
+
+	result, err := runner.Run(context.Background(), date)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("created %d expenses and %d incomes from recurring transactions",
+		len(result.ExpenseIDs), len(result.IncomeIDs))
+
+	return nil
+}
+
 func main() {
 	c := cli.NewApp()
 	c.Commands = []cli.Command{
@@ -131,6 +253,28 @@ func main() {
 			Usage:  "rollback",
 			Action: rollbackDB,
 		},
+		{
+			Name:   "import",
+			Usage:  "import a bank statement as expenses and incomes",
+			Action: importStatement,
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "file", Usage: "path of the bank statement"},
+				cli.StringFlag{Name: "format", Usage: "statement format: csv, ofx or camt053", Value: "csv"},
+				cli.StringFlag{Name: "profile", Usage: "bank profile name of csv statements", Value: "generic"},
+				cli.Int64Flag{Name: "user", Usage: "id of the user owning the imported rows", Required: true},
+				cli.StringFlag{Name: "card", Usage: "card the transactions belong to"},
+				cli.StringFlag{Name: "subcategory", Usage: "subcategory of the created expenses - picked by the categorization rules if missing"},
+				cli.StringFlag{Name: "category", Usage: "income category of the created incomes - picked by the categorization rules if missing"},
+			},
+		},
+		{
+			Name:   "run-recurring",
+			Usage:  "create the due occurrences of the recurring transactions",
+			Action: runRecurring,
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "date", Usage: "create the occurrences due up to this date (YYYY-MM-DD) - today if missing"},
+			},
+		},
 	}
 
 	err := c.Run(os.Args)
@@ -1,18 +1,48 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
 
+	"github.com/rubengomes8/golang-personal-finances/internal/attachments"
+	"github.com/rubengomes8/golang-personal-finances/internal/balances"
+	"github.com/rubengomes8/golang-personal-finances/internal/categorization"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/duplicates"
 	grpcHandlers "github.com/rubengomes8/golang-personal-finances/internal/grpc"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
+	attachmentspb "github.com/rubengomes8/golang-personal-finances/internal/pb/attachments"
+	balancespb "github.com/rubengomes8/golang-personal-finances/internal/pb/balances"
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/budgets"
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/cards"
 	"github.com/rubengomes8/golang-personal-finances/internal/pb/expenses"
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/expenses/categories"
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/expenses/subcategories"
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/incomes"
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/recurring"
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/rules"
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/transfers"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/attachment"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/balance"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/budget"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/card"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/expense"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/income"
+	recurringDatabase "github.com/rubengomes8/golang-personal-finances/internal/repository/database/recurring"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/rule"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/session"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/transfer"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/user"
+	"github.com/rubengomes8/golang-personal-finances/internal/scheduler"
+	"github.com/rubengomes8/golang-personal-finances/internal/summary"
 	"github.com/rubengomes8/golang-personal-finances/internal/tools"
 
 	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
+
+	incomeCategories "github.com/rubengomes8/golang-personal-finances/internal/pb/incomes/categories"
 )
 
 func main() {
@@ -28,12 +58,108 @@ func main() {
 	expCategoryDB := expense.NewCategoryDB(db)
 	expSubCategoryDB := expense.NewSubCategoryDB(db)
 	expensesDB := expense.NewDB(db, cardDB, expCategoryDB, expSubCategoryDB)
+	incCategoryDB := income.NewCategoryDB(db)
+	incomesDB := income.NewDB(db, cardDB, incCategoryDB)
+	ruleDB := rule.NewDB(db)
+	budgetDB := budget.NewDB(db)
+	recurringDB := recurringDatabase.NewDB(db)
+	transferDB := transfer.NewDB(db)
+	balanceDB := balance.NewDB(db)
+	attachmentDB := attachment.NewDB(db)
 
 	// HANDLERS / SERVICE
+	duplicatesDetector, err := duplicates.NewDetectorFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to set up duplicates detector: %v\n", err)
+	}
+	categorizer := categorization.NewCategorizer(ruleDB)
+
+	exchangeRates, err := currency.RatesFromFile(os.Getenv("EXCHANGE_RATES_FILEPATH"))
+	if err != nil {
+		log.Fatalf("Failed to load exchange rates: %v\n", err)
+	}
+
+	summarizer := summary.NewSummarizer(expensesDB, incomesDB, exchangeRates)
+
 	expensesHandlers, err := grpcHandlers.NewExpenses(expensesDB, expSubCategoryDB, cardDB)
 	if err != nil {
 		log.Fatalf("Failed to create the finances server: %v\n", err)
 	}
+	expensesHandlers.DuplicatesDetector = duplicatesDetector
+	expensesHandlers.Categorizer = categorizer
+	expensesHandlers.Rates = exchangeRates
+	expensesHandlers.Summarizer = summarizer
+
+	incomesHandlers, err := grpcHandlers.NewIncomes(incomesDB, incCategoryDB, cardDB)
+	if err != nil {
+		log.Fatalf("Failed to create the incomes server: %v\n", err)
+	}
+	incomesHandlers.DuplicatesDetector = duplicatesDetector
+	incomesHandlers.Categorizer = categorizer
+	incomesHandlers.Rates = exchangeRates
+	incomesHandlers.Summarizer = summarizer
+
+	cardsHandlers, err := grpcHandlers.NewCards(cardDB)
+	if err != nil {
+		log.Fatalf("Failed to create the cards server: %v\n", err)
+	}
+
+	expCategoriesHandlers, err := grpcHandlers.NewExpenseCategories(expCategoryDB)
+	if err != nil {
+		log.Fatalf("Failed to create the expense categories server: %v\n", err)
+	}
+
+	expSubCategoriesHandlers, err := grpcHandlers.NewExpenseSubCategories(expSubCategoryDB, expCategoryDB)
+	if err != nil {
+		log.Fatalf("Failed to create the expense subcategories server: %v\n", err)
+	}
+
+	incCategoriesHandlers, err := grpcHandlers.NewIncomeCategories(incCategoryDB)
+	if err != nil {
+		log.Fatalf("Failed to create the income categories server: %v\n", err)
+	}
+
+	rulesHandlers, err := grpcHandlers.NewCategorizationRules(ruleDB, expensesDB, incomesDB, cardDB, expSubCategoryDB, incCategoryDB)
+	if err != nil {
+		log.Fatalf("Failed to create the categorization rules server: %v\n", err)
+	}
+
+	budgetsHandlers, err := grpcHandlers.NewBudgets(budgetDB, expCategoryDB, expSubCategoryDB, exchangeRates)
+	if err != nil {
+		log.Fatalf("Failed to create the budgets server: %v\n", err)
+	}
+
+	recurringHandlers, err := grpcHandlers.NewRecurringTransactions(recurringDB, cardDB, expSubCategoryDB, incCategoryDB)
+	if err != nil {
+		log.Fatalf("Failed to create the recurring transactions server: %v\n", err)
+	}
+
+	transfersHandlers, err := grpcHandlers.NewTransfers(transferDB, cardDB)
+	if err != nil {
+		log.Fatalf("Failed to create the transfers server: %v\n", err)
+	}
+
+	balancesHandlers, err := grpcHandlers.NewBalances(balances.NewCalculator(cardDB, balanceDB, exchangeRates))
+	if err != nil {
+		log.Fatalf("Failed to create the balances server: %v\n", err)
+	}
+
+	attachmentsManager, err := attachments.NewManagerFromEnv(attachmentDB)
+	if err != nil {
+		log.Fatalf("Failed to set up attachments storage: %v\n", err)
+	}
+
+	attachmentsHandlers, err := grpcHandlers.NewAttachments(attachmentsManager)
+	if err != nil {
+		log.Fatalf("Failed to create the attachments server: %v\n", err)
+	}
+
+	// BACKGROUND WORKERS
+	recurringInterval, err := scheduler.IntervalFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to set up recurring transactions runner: %v\n", err)
+	}
+	go scheduler.NewRunner(recurringDB, expensesDB, incomesDB).Start(context.Background(), recurringInterval)
 
 	// TCP LISTERNER
 	listener, err := net.Listen("tcp", os.Getenv("GRPC_LISTENER_ADDR"))
@@ -41,9 +167,37 @@ func main() {
 		log.Fatalf("Failed to listen on: %v\n", err)
 	}
 
+	// AUTHENTICATION
+	keys, err := auth.KeysFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load the token keys: %v\n", err)
+	}
+	sessions := auth.NewSessions(session.NewDB(db), keys)
+
 	// GRPC SERVER
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcHandlers.AuthInterceptor(sessions),
+			grpcHandlers.AdminInterceptor(user.NewDB(db),
+				"/expense_categories.ExpenseCategoryService/MergeExpenseCategory",
+				"/expense_subcategories.ExpenseSubCategoryService/MergeExpenseSubCategory",
+				"/income_categories.Service/Merge",
+			),
+		),
+		grpc.StreamInterceptor(grpcHandlers.AuthStreamInterceptor(sessions)),
+	)
 	expenses.RegisterExpensesServiceServer(grpcServer, expensesHandlers)
+	incomes.RegisterServiceServer(grpcServer, incomesHandlers)
+	cards.RegisterCardServiceServer(grpcServer, cardsHandlers)
+	categories.RegisterExpenseCategoryServiceServer(grpcServer, expCategoriesHandlers)
+	subcategories.RegisterExpenseSubCategoryServiceServer(grpcServer, expSubCategoriesHandlers)
+	incomeCategories.RegisterServiceServer(grpcServer, incCategoriesHandlers)
+	rules.RegisterServiceServer(grpcServer, rulesHandlers)
+	budgets.RegisterServiceServer(grpcServer, budgetsHandlers)
+	recurring.RegisterServiceServer(grpcServer, recurringHandlers)
+	transfers.RegisterServiceServer(grpcServer, transfersHandlers)
+	balancespb.RegisterServiceServer(grpcServer, balancesHandlers)
+	attachmentspb.RegisterServiceServer(grpcServer, attachmentsHandlers)
 	if err = grpcServer.Serve(listener); err != nil {
 		log.Fatalf("Failed to serve: %v\n", err)
 	}
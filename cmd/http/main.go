@@ -1,18 +1,35 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rubengomes8/golang-personal-finances/internal/attachments"
+	"github.com/rubengomes8/golang-personal-finances/internal/balances"
+	"github.com/rubengomes8/golang-personal-finances/internal/categorization"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/duplicates"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
 	"github.com/rubengomes8/golang-personal-finances/internal/http/handlers"
 	"github.com/rubengomes8/golang-personal-finances/internal/http/routes"
+	"github.com/rubengomes8/golang-personal-finances/internal/importer"
 	"github.com/rubengomes8/golang-personal-finances/internal/instrumentation"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/attachment"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/balance"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/budget"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/card"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/expense"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/income"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/recurring"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/rule"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/session"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/transfer"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/database/user"
+	"github.com/rubengomes8/golang-personal-finances/internal/scheduler"
 	service "github.com/rubengomes8/golang-personal-finances/internal/service/incomes"
+	"github.com/rubengomes8/golang-personal-finances/internal/summary"
 	"github.com/rubengomes8/golang-personal-finances/internal/tools"
 
 	_ "github.com/lib/pq"                                            //no lint
@@ -92,24 +109,151 @@ func main() {
 		log.Fatalf("Failed to set up user repo with RED: %v\n", err)
 	}
 
+	sessionDB, err := session.NewSessionRepoWithRED(
+		session.NewSessionRepoWithLogs(session.NewDB(db)),
+		prometheusLabels,
+	)
+	if err != nil {
+		log.Fatalf("Failed to set up session repo with RED: %v\n", err)
+	}
+
+	ruleDB, err := rule.NewCategorizationRuleRepoWithRED(
+		rule.NewCategorizationRuleRepoWithLogs(rule.NewDB(db)),
+		prometheusLabels,
+	)
+	if err != nil {
+		log.Fatalf("Failed to set up categorization rule repo with RED: %v\n", err)
+	}
+
+	budgetDB, err := budget.NewBudgetRepoWithRED(
+		budget.NewBudgetRepoWithLogs(budget.NewDB(db)),
+		prometheusLabels,
+	)
+	if err != nil {
+		log.Fatalf("Failed to set up budget repo with RED: %v\n", err)
+	}
+
+	recurringDB, err := recurring.NewRecurringTransactionRepoWithRED(
+		recurring.NewRecurringTransactionRepoWithLogs(recurring.NewDB(db)),
+		prometheusLabels,
+	)
+	if err != nil {
+		log.Fatalf("Failed to set up recurring transaction repo with RED: %v\n", err)
+	}
+
+	transferDB, err := transfer.NewTransferRepoWithRED(
+		transfer.NewTransferRepoWithLogs(transfer.NewDB(db)),
+		prometheusLabels,
+	)
+	if err != nil {
+		log.Fatalf("Failed to set up transfer repo with RED: %v\n", err)
+	}
+
+	balanceDB, err := balance.NewBalanceRepoWithRED(
+		balance.NewBalanceRepoWithLogs(balance.NewDB(db)),
+		prometheusLabels,
+	)
+	if err != nil {
+		log.Fatalf("Failed to set up balance repo with RED: %v\n", err)
+	}
+
+	attachmentDB, err := attachment.NewAttachmentRepoWithRED(
+		attachment.NewAttachmentRepoWithLogs(attachment.NewDB(db)),
+		prometheusLabels,
+	)
+	if err != nil {
+		log.Fatalf("Failed to set up attachment repo with RED: %v\n", err)
+	}
+
 	// SERVICES
+	categorizer := categorization.NewCategorizer(ruleDB)
+
+	duplicatesDetector, err := duplicates.NewDetectorFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to set up duplicates detector: %v\n", err)
+	}
+
+	exchangeRates, err := currency.RatesFromFile(os.Getenv("EXCHANGE_RATES_FILEPATH"))
+	if err != nil {
+		log.Fatalf("Failed to load exchange rates: %v\n", err)
+	}
+
 	// incomes service factory is using configuration pattern
 	incomesService, err := service.NewIncomesWithConfiguration(
 		service.WithIncomesRepository(incomesDB),
 		service.WithCategoryRepository(incCategoryDB),
 		service.WithCardRepository(cardDB),
+		service.WithDuplicatesDetector(duplicatesDetector),
+		service.WithCategorizer(categorizer),
+		service.WithExchangeRates(exchangeRates),
 	)
 	if err != nil {
 		log.Fatalf("Failed to set up incomes service with configuration patterns: %v\n", err)
 	}
 
+	importProfiles, err := importer.ProfilesFromFile(os.Getenv("IMPORT_PROFILES_FILEPATH"))
+	if err != nil {
+		log.Fatalf("Failed to load import profiles: %v\n", err)
+	}
+	statementImporter := importer.NewImporter(expensesDB, incomesDB, cardDB, expSubCategoryDB, incCategoryDB, categorizer)
+
+	recurringInterval, err := scheduler.IntervalFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to set up recurring transactions runner: %v\n", err)
+	}
+	recurringRunner := scheduler.NewRunner(recurringDB, expensesDB, incomesDB)
+
+	attachmentsManager, err := attachments.NewManagerFromEnv(attachmentDB)
+	if err != nil {
+		log.Fatalf("Failed to set up attachments storage: %v\n", err)
+	}
+
+	// AUTHENTICATION
+	keys, err := auth.KeysFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load the token keys: %v\n", err)
+	}
+
+	passwordPolicy, err := auth.PasswordPolicyFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to set up the password policy: %v\n", err)
+	}
+
 	// HTTP HANDLERS
 	expensesHandlers := handlers.NewExpenses(expensesDB, expSubCategoryDB, cardDB)
+	expensesHandlers.DuplicatesDetector = duplicatesDetector
+	expensesHandlers.Categorizer = categorizer
+	expensesHandlers.Rates = exchangeRates
 	incomesHandlers := handlers.NewIncomes(incomesService)
-	authHandlers := handlers.NewAuth(userDB)
+	sessions := auth.NewSessions(sessionDB, keys)
+	authHandlers := handlers.NewAuth(
+		userDB,
+		sessions,
+		auth.NewLoginThrottle(auth.DefaultThrottleLimits),
+		auth.NewPasswords(userDB, sessions, passwordPolicy, auth.NotifierFromEnv()),
+		auth.NewTwoFactor(userDB, keys, os.Getenv("TOTP_ISSUER")),
+	)
+	importsHandlers := handlers.NewImports(statementImporter, importProfiles)
+	rulesHandlers := handlers.NewCategorizationRules(ruleDB, expensesDB, incomesDB, cardDB, expSubCategoryDB, incCategoryDB)
+	budgetsHandlers := handlers.NewBudgets(budgetDB, expCategoryDB, expSubCategoryDB, exchangeRates)
+	recurringHandlers := handlers.NewRecurringTransactions(recurringDB, cardDB, expSubCategoryDB, incCategoryDB)
+	summariesHandlers := handlers.NewSummaries(summary.NewSummarizer(expensesDB, incomesDB, exchangeRates))
+	transfersHandlers := handlers.NewTransfers(transferDB, cardDB)
+	balancesHandlers := handlers.NewBalances(balances.NewCalculator(cardDB, balanceDB, exchangeRates))
+	attachmentsHandlers := handlers.NewAttachments(attachmentsManager)
+	cardsHandlers := handlers.NewCards(cardDB)
+	expenseCategoriesHandlers := handlers.NewExpenseCategories(expCategoryDB)
+	expenseSubCategoriesHandlers := handlers.NewExpenseSubCategories(expSubCategoryDB, expCategoryDB)
+	incomeCategoriesHandlers := handlers.NewIncomeCategories(incCategoryDB)
+
+	// BACKGROUND WORKERS
+	go recurringRunner.Start(context.Background(), recurringInterval)
 
 	// HTTP ROUTER
-	r := routes.SetupRouter(expensesHandlers, incomesHandlers, authHandlers)
+	r, err := routes.SetupRouter(expensesHandlers, incomesHandlers, authHandlers, importsHandlers, rulesHandlers, budgetsHandlers, recurringHandlers, summariesHandlers, transfersHandlers, balancesHandlers, attachmentsHandlers, cardsHandlers, expenseCategoriesHandlers, expenseSubCategoriesHandlers, incomeCategoriesHandlers, routes.TrustedProxiesFromEnv())
+	if err != nil {
+		log.Fatalf("Failed to set up http router: %v\n", err)
+	}
 	err = r.Run()
 	if err != nil {
 		log.Fatalf("Could not run http router: %v\n", err)
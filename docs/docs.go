@@ -16,40 +16,35 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/expense": {
-            "post": {
+        "/v1/attachment/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to create an expense.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Endpoint to download the file of an attachment, with its content type and file name.\nThe ETag is the checksum of the content.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Expenses"
+                    "Attachments"
                 ],
-                "summary": "Creates a new expense.",
+                "summary": "Downloads an attachment by its id.",
                 "parameters": [
                     {
-                        "description": "Create expense request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
-                        }
+                        "type": "string",
+                        "description": "The attachment id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -58,6 +53,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -65,16 +66,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/expense/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to get an expense by id.",
+                "description": "Endpoint to delete an attachment by id, along with its file.",
                 "consumes": [
                     "application/json"
                 ],
@@ -82,40 +81,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Expenses"
+                    "Attachments"
                 ],
-                "summary": "Gets an expense by its id.",
+                "summary": "Deletes an attachment by its id.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The expense id",
+                        "description": "The attachment id",
                         "name": "id",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/balance/{card}/{date}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to update an expense.",
+                "description": "Endpoint to get the balance of a card as of a date: its opening balance plus its incomes and transfers in, minus\nits expenses and transfers out (with their fees), with the running balance after each of them. Movements in another\ncurrency are converted to the one of the card at the exchange rate of their date.",
                 "consumes": [
                     "application/json"
                 ],
@@ -123,30 +133,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Expenses"
+                    "Balances"
                 ],
-                "summary": "Updates an existing expense.",
+                "summary": "Gets the balance of a card as of a date.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The expense id",
-                        "name": "id",
+                        "description": "The card name",
+                        "name": "card",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Update expense request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
-                        }
+                        "type": "string",
+                        "description": "The date of the balance, YYYY-MM-DD",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Balance"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -154,6 +165,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -161,14 +178,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/budget": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to delete an expense by id.",
+                "description": "Endpoint to create a monthly budget of an expense category or of an expense subcategory.",
                 "consumes": [
                     "application/json"
                 ],
@@ -176,39 +195,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Expenses"
+                    "Budgets"
                 ],
-                "summary": "Deletes an expense by its id.",
+                "summary": "Creates a new budget.",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "The expense id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Create budget request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Budget"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.BudgetCreateResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/v1/expenses/card/{card}": {
+        "/v1/budget/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to get a list of expense by card.",
+                "description": "Endpoint to get a budget by id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -216,26 +246,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Expenses"
+                    "Budgets"
                 ],
-                "summary": "Gets a list of expenses by card.",
+                "summary": "Gets a budget by its id.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The card",
-                        "name": "category",
+                        "description": "The budget id",
+                        "name": "id",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
-                            }
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Budget"
                         }
                     },
                     "400": {
@@ -243,18 +270,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/v1/expenses/category/{category}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to get a list of expenses by category.",
+                "description": "Endpoint to update a budget.",
                 "consumes": [
                     "application/json"
                 ],
@@ -262,45 +293,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Expenses"
+                    "Budgets"
                 ],
-                "summary": "Gets a list of expenses by a category.",
+                "summary": "Updates an existing budget.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The expense category",
-                        "name": "category",
+                        "description": "The budget id",
+                        "name": "id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "description": "Update budget request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Budget"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
-                            }
-                        }
+                    "204": {
+                        "description": "No content"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/v1/expenses/dates/{min_date}/{max_date}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to get a list of expenses created on the provided range of dates.",
+                "description": "Endpoint to delete a budget by id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -308,52 +352,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Expenses"
+                    "Budgets"
                 ],
-                "summary": "Gets a list of expenses created on a range of dates.",
+                "summary": "Deletes a budget by its id.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The minimum date to consider",
-                        "name": "min_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "The maximum date to consider",
-                        "name": "max_date",
+                        "description": "The budget id",
+                        "name": "id",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/v1/expenses/subcategory/{sub_category}": {
+        "/v1/budgets": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to get a list of expenses by subcategory.",
+                "description": "Endpoint to get the budgets.",
                 "consumes": [
                     "application/json"
                 ],
@@ -361,30 +398,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Expenses"
-                ],
-                "summary": "Gets a list of expenses by subcategory.",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "The expense subcategory",
-                        "name": "category",
-                        "in": "query",
-                        "required": true
-                    }
+                    "Budgets"
                 ],
+                "summary": "Gets the budgets.",
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Budget"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
@@ -392,14 +420,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/income": {
-            "post": {
+        "/v1/budgets/report/{month}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to create an income.",
+                "description": "Endpoint to get the budgeted vs spent vs remaining amounts of the budgets that apply to a month,\ncomputed from the expenses of the month. Budgets with rollover add the unspent amounts of the previous months.",
                 "consumes": [
                     "application/json"
                 ],
@@ -407,25 +435,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Incomes"
+                    "Budgets"
                 ],
-                "summary": "Creates a new income.",
+                "summary": "Gets the budgets report of a month.",
                 "parameters": [
                     {
-                        "description": "Create income request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
-                        }
+                        "type": "string",
+                        "description": "The month (YYYY-MM)",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomeCreateResponse"
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.BudgetsReport"
                         }
                     },
                     "400": {
@@ -443,14 +469,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/income/{id}": {
-            "get": {
+        "/v1/card": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to get an income by its id.",
+                "description": "Endpoint to create a card, with an optional currency (EUR by default) and opening balance.",
                 "consumes": [
                     "application/json"
                 ],
@@ -458,13 +484,70 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Incomes"
+                    "Cards"
                 ],
-                "summary": "Gets an income by id.",
+                "summary": "Creates a new card.",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "The income id",
+                        "description": "Create card request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Card"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CardCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/card/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get a card by id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Gets a card by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The card id",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -474,7 +557,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Card"
                         }
                     },
                     "400": {
@@ -482,6 +565,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
                     }
                 }
             },
@@ -491,7 +586,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to update an income.",
+                "description": "Endpoint to rename a card or change its currency, which is kept if missing.\nThe opening balance is set with the opening balance endpoint.",
                 "consumes": [
                     "application/json"
                 ],
@@ -499,25 +594,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Incomes"
+                    "Cards"
                 ],
-                "summary": "Updates a new income.",
+                "summary": "Updates an existing card.",
                 "parameters": [
                     {
-                        "description": "Update income request",
+                        "type": "string",
+                        "description": "The card id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Update card request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Card"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "The income id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -529,6 +624,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
                     }
                 }
             },
@@ -538,7 +651,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to delete an income.",
+                "description": "Endpoint to delete a card by id. A card that still has expenses, incomes, transfers, rules,\nrecurring transactions or reconciliations can not be deleted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -546,13 +659,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Incomes"
+                    "Cards"
                 ],
-                "summary": "Deletes a new income.",
+                "summary": "Deletes a card by its id.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The income id",
+                        "description": "The card id",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -560,25 +673,43 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "No content"
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/v1/incomes/card/{card}": {
+        "/v1/cards": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to get a list of incomes by payment card.",
+                "description": "Endpoint to get the cards of the user, sorted by name.",
                 "consumes": [
                     "application/json"
                 ],
@@ -586,30 +717,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Incomes"
-                ],
-                "summary": "Gets a list of incomes by payment card.",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "The payment card",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "Cards"
                 ],
+                "summary": "Gets the cards.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Card"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
@@ -617,14 +739,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/incomes/category/{category}": {
+        "/v1/cash-flow/{min_date}/{max_date}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to get a list of incomes by its category.",
+                "description": "Endpoint to get the incomes, expenses and net cash flow (incomes minus expenses) by day, week or month\non the provided range of dates. Weeks start on Monday.\nWithout a reporting currency the cash flows of each currency are kept apart; with one, each value is converted to it\nat the exchange rate of its date.",
                 "consumes": [
                     "application/json"
                 ],
@@ -632,16 +754,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Incomes"
+                    "Summaries"
                 ],
-                "summary": "Gets a list of incomes by category.",
+                "summary": "Gets the cash flow by period on a range of dates.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The income category",
-                        "name": "id",
+                        "description": "The minimum date to consider",
+                        "name": "min_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The maximum date to consider",
+                        "name": "max_date",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "day, week or month (default)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The reporting currency, such as EUR",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -650,7 +791,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CashFlow"
                             }
                         }
                     },
@@ -659,18 +800,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/v1/incomes/dates/{min_date}/{max_date}": {
-            "get": {
+        "/v1/expense": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Endpoint to get a list of incomes created on the provided range of dates.",
+                "description": "Endpoint to create an expense.\nAn expense of the same card and value, close in date and with a similar description, is a likely duplicate\nand is only created if force is set.\nWithout a subcategory, the subcategory is picked by the first categorization rule matching the expense.\nAn expense can be split in lines of their own subcategory whose values add up to its value.\nTags are single words, stored lower cased.",
                 "consumes": [
                     "application/json"
                 ],
@@ -678,83 +825,4255 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Incomes"
+                    "Expenses"
                 ],
-                "summary": "Gets a list of incomes by payment card.",
+                "summary": "Creates a new expense.",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "The minimum date to consider",
-                        "name": "min_date",
-                        "in": "query",
-                        "required": true
+                        "description": "Create expense request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseDuplicatesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expense-categories": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "The maximum date to consider",
-                        "name": "max_date",
-                        "in": "query",
-                        "required": true
+                        "ApiKeyAuth": []
                     }
                 ],
+                "description": "Endpoint to get the expense categories, sorted by name.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expense categories"
+                ],
+                "summary": "Gets the expense categories.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCategory"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string"
-                }
-            }
         },
-        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest": {
-            "type": "object",
-            "properties": {
-                "card": {
-                    "type": "string"
-                },
-                "date": {
-                    "description": "Should be on this format YYYY-MM-DD",
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "sub_category": {
-                    "type": "string"
-                },
-                "value": {
-                    "type": "number"
-                }
+        "/v1/expense-category": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to create an expense category.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expense categories"
+                ],
+                "summary": "Creates a new expense category.",
+                "parameters": [
+                    {
+                        "description": "Create expense category request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategoryCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expense-category/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get an expense category by id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expense categories"
+                ],
+                "summary": "Gets an expense category by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The expense category id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to rename an expense category.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expense categories"
+                ],
+                "summary": "Updates an existing expense category.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The expense category id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Update expense category request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to delete an expense category by id. A category that still has subcategories is only deleted\nwith reassign_to, the id of the expense category its subcategories are moved to. A category with budgets\ncan not be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expense categories"
+                ],
+                "summary": "Deletes an expense category by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The expense category id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The id of the expense category to move the subcategories to",
+                        "name": "reassign_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expense-category/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to merge an expense category into another expense category: its subcategories and budgets\nare moved to it and the merged expense category is deleted, all at once.\nBudgets the user also has on the target are folded into them, adding up their month limits. On a dry run nothing\nchanges, and the response counts what would move.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expense categories"
+                ],
+                "summary": "Merges an expense category into another one.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The id of the expense category to merge",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge expense category request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategoryMergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategoryMergeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expense-subcategories": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get the expense subcategories, sorted by name.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expense subcategories"
+                ],
+                "summary": "Gets the expense subcategories.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseSubCategory"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expense-subcategory": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to create an expense subcategory of an existing expense category.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expense subcategories"
+                ],
+                "summary": "Creates a new expense subcategory.",
+                "parameters": [
+                    {
+                        "description": "Create expense subcategory request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseSubCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategoryCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expense-subcategory/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get an expense subcategory by id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expense subcategories"
+                ],
+                "summary": "Gets an expense subcategory by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The expense subcategory id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseSubCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to rename an expense subcategory or move it to another expense category.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expense subcategories"
+                ],
+                "summary": "Updates an existing expense subcategory.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The expense subcategory id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Update expense subcategory request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseSubCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to delete an expense subcategory by id. A subcategory that still has expenses is only deleted\nwith reassign_to, the id of the expense subcategory its expenses are moved to. A subcategory with budgets,\nrules or recurring transactions can not be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expense subcategories"
+                ],
+                "summary": "Deletes an expense subcategory by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The expense subcategory id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The id of the expense subcategory to move the expenses to",
+                        "name": "reassign_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expense-subcategory/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to merge an expense subcategory into another expense subcategory: its expenses, budgets, rules and recurring transactions\nare moved to it and the merged expense subcategory is deleted, all at once.\nBudgets the user also has on the target are folded into them, adding up their month limits. On a dry run nothing\nchanges, and the response counts what would move.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expense subcategories"
+                ],
+                "summary": "Merges an expense subcategory into another one.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The id of the expense subcategory to merge",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge expense subcategory request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategoryMergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategoryMergeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expense/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get an expense by id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expenses"
+                ],
+                "summary": "Gets an expense by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The expense id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to update an expense.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expenses"
+                ],
+                "summary": "Updates an existing expense.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The expense id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Update expense request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to delete an expense by id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expenses"
+                ],
+                "summary": "Deletes an expense by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The expense id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expense/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get the metadata of the files attached to an expense, oldest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Gets the attachments of an expense.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The expense id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Attachment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to attach a file, such as a receipt, to an expense. The content type is detected from the content\nwhen the file part does not set one.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Uploads a file to an expense.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The expense id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "The file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expenses/batch": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to create several expenses at once. Either all expenses are created or none is.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expenses"
+                ],
+                "summary": "Creates several expenses.",
+                "parameters": [
+                    {
+                        "description": "Create expenses request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpensesCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpensesCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.BatchErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.BatchErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expenses/card/{card}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get a list of expense by card.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expenses"
+                ],
+                "summary": "Gets a list of expenses by card.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The card",
+                        "name": "category",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the expenses with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expenses/category/{category}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get a list of expenses by category.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expenses"
+                ],
+                "summary": "Gets a list of expenses by a category.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The expense category",
+                        "name": "category",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the expenses with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expenses/dates/{min_date}/{max_date}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get a list of expenses created on the provided range of dates.\nWith a reporting currency, each value is converted to it at the exchange rate of the expense date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expenses"
+                ],
+                "summary": "Gets a list of expenses created on a range of dates.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The minimum date to consider",
+                        "name": "min_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The maximum date to consider",
+                        "name": "max_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The reporting currency, such as EUR, the values are converted to at the rate of each expense date",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the expenses with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expenses/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to search the expenses by any combination of dates, category, subcategory, card, values, description and tag,\nsorted by date or value. Dates and values are inclusive bounds. Pages are linked by cursors: the next_cursor\nof a page is the cursor of the next one, with the same filters and sort order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expenses"
+                ],
+                "summary": "Searches the expenses.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The minimum date to consider (YYYY-MM-DD)",
+                        "name": "min_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The maximum date to consider (YYYY-MM-DD)",
+                        "name": "max_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The subcategory",
+                        "name": "sub_category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The card",
+                        "name": "card",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The minimum value, such as 12.30",
+                        "name": "min_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The maximum value, such as 12.30",
+                        "name": "max_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text the description contains, case insensitive",
+                        "name": "description",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A tag the expense has",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "date (default) or value",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "desc (default) or asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The page size, 50 by default and up to 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpensesPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expenses/subcategory/{sub_category}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get a list of expenses by subcategory.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expenses"
+                ],
+                "summary": "Gets a list of expenses by subcategory.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The expense subcategory",
+                        "name": "category",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the expenses with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expenses/totals/{min_date}/{max_date}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to sum the expenses created on the provided range of dates by category, subcategory, card, tag, day, week or month.\nWithout a reporting currency the totals of each currency are kept apart; with one, each value is converted to it\nat the exchange rate of the expense date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Summaries"
+                ],
+                "summary": "Gets the totals of the expenses on a range of dates.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The minimum date to consider",
+                        "name": "min_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The maximum date to consider",
+                        "name": "max_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "category (default), subcategory, card, tag, day, week or month",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The reporting currency, such as EUR",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Total"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/import/camt053": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to import an ISO 20022 camt.053 statement. Debits are created as expenses and credits as incomes on the card.\nEntries whose AcctSvcrRef was already imported on the card are skipped.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Imports a camt.053 bank statement.",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "The camt.053 statement",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The card the transactions belong to",
+                        "name": "card",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The subcategory of the created expenses - picked by the categorization rules if missing",
+                        "name": "sub_category",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The income category of the created incomes - picked by the categorization rules if missing",
+                        "name": "category",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ImportErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/import/csv": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to import a bank CSV export. Debits are created as expenses and credits as incomes on the card.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Imports a bank CSV export.",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "The bank CSV export",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The bank profile name",
+                        "name": "profile",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The card the transactions belong to",
+                        "name": "card",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The subcategory of the created expenses - picked by the categorization rules if missing",
+                        "name": "sub_category",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The income category of the created incomes - picked by the categorization rules if missing",
+                        "name": "category",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ImportErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/import/ofx": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to import an OFX 1.x or 2.x statement. Debits are created as expenses and credits as incomes on the card.\nTransactions whose FITID was already imported on the card are skipped.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Imports an OFX bank statement.",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "The OFX statement",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The card the transactions belong to",
+                        "name": "card",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The subcategory of the created expenses - picked by the categorization rules if missing",
+                        "name": "sub_category",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The income category of the created incomes - picked by the categorization rules if missing",
+                        "name": "category",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ImportErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/income": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to create an income.\nAn income of the same card and value, close in date and with a similar description, is a likely duplicate\nand is only created if force is set.\nWithout a category, the category is picked by the first categorization rule matching the income.\nTags are single words, stored lower cased.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Incomes"
+                ],
+                "summary": "Creates a new income.",
+                "parameters": [
+                    {
+                        "description": "Create income request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomeCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomeDuplicatesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/income-categories": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get the income categories, sorted by name.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Income categories"
+                ],
+                "summary": "Gets the income categories.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomeCategory"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/income-category": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to create an income category.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Income categories"
+                ],
+                "summary": "Creates a new income category.",
+                "parameters": [
+                    {
+                        "description": "Create income category request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomeCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategoryCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/income-category/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get an income category by id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Income categories"
+                ],
+                "summary": "Gets an income category by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The income category id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomeCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to rename an income category.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Income categories"
+                ],
+                "summary": "Updates an existing income category.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The income category id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Update income category request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomeCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to delete an income category by id. A category that still has incomes is only deleted\nwith reassign_to, the id of the income category its incomes are moved to. A category with rules or\nrecurring transactions can not be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Income categories"
+                ],
+                "summary": "Deletes an income category by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The income category id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The id of the income category to move the incomes to",
+                        "name": "reassign_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/income-category/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to merge an income category into another income category: its incomes, rules and recurring transactions\nare moved to it and the merged income category is deleted, all at once.\nOn a dry run nothing changes, and the response counts what would move.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Income categories"
+                ],
+                "summary": "Merges an income category into another one.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The id of the income category to merge",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge income category request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategoryMergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategoryMergeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/income/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get an income by its id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Incomes"
+                ],
+                "summary": "Gets an income by id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The income id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to update an income.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Incomes"
+                ],
+                "summary": "Updates a new income.",
+                "parameters": [
+                    {
+                        "description": "Update income request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "The income id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to delete an income.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Incomes"
+                ],
+                "summary": "Deletes a new income.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The income id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/income/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get the metadata of the files attached to an income, oldest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Gets the attachments of an income.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The income id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Attachment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to attach a file, such as a payslip, to an income. The content type is detected from the content\nwhen the file part does not set one.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Uploads a file to an income.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The income id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "The file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/incomes/batch": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to create several incomes at once. Either all incomes are created or none is.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Incomes"
+                ],
+                "summary": "Creates several incomes.",
+                "parameters": [
+                    {
+                        "description": "Create incomes request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomesCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomesCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.BatchErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.BatchErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/incomes/card/{card}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get a list of incomes by payment card.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Incomes"
+                ],
+                "summary": "Gets a list of incomes by payment card.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The payment card",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the incomes with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/incomes/category/{category}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get a list of incomes by its category.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Incomes"
+                ],
+                "summary": "Gets a list of incomes by category.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The income category",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the incomes with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/incomes/dates/{min_date}/{max_date}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get a list of incomes created on the provided range of dates.\nWith a reporting currency, each value is converted to it at the exchange rate of the income date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Incomes"
+                ],
+                "summary": "Gets a list of incomes by payment card.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The minimum date to consider",
+                        "name": "min_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The maximum date to consider",
+                        "name": "max_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The reporting currency, such as EUR, the values are converted to at the rate of each income date",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the incomes with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/incomes/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to search the incomes by any combination of dates, category, card, values, description and tag,\nsorted by date or value. Dates and values are inclusive bounds. Pages are linked by cursors: the next_cursor\nof a page is the cursor of the next one, with the same filters and sort order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Incomes"
+                ],
+                "summary": "Searches the incomes.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The minimum date to consider (YYYY-MM-DD)",
+                        "name": "min_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The maximum date to consider (YYYY-MM-DD)",
+                        "name": "max_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The card",
+                        "name": "card",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The minimum value, such as 12.30",
+                        "name": "min_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The maximum value, such as 12.30",
+                        "name": "max_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text the description contains, case insensitive",
+                        "name": "description",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A tag the income has",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "date (default) or value",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "desc (default) or asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The page size, 50 by default and up to 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomesPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/incomes/totals/{min_date}/{max_date}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to sum the incomes created on the provided range of dates by category, card, day, week or month.\nWithout a reporting currency the totals of each currency are kept apart; with one, each value is converted to it\nat the exchange rate of the income date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Summaries"
+                ],
+                "summary": "Gets the totals of the incomes on a range of dates.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The minimum date to consider",
+                        "name": "min_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The maximum date to consider",
+                        "name": "max_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "category (default), card, day, week or month",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The reporting currency, such as EUR",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Total"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/opening-balance/{card}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to set the balance of a card on its opening date. Its balance only counts the movements from that date on,\nor every movement without an opening date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Balances"
+                ],
+                "summary": "Sets the opening balance of a card.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The card name",
+                        "name": "card",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Opening balance request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.OpeningBalance"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reconciliation/{card}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to mark the movements of a card matching a bank statement as cleared and check the statement balance of\na date against the cleared balance of the card. The difference is what the cleared movements do not explain,\nand the movements up to the date that are not cleared yet are listed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Balances"
+                ],
+                "summary": "Reconciles a card with a bank statement balance.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The card name",
+                        "name": "card",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Reconcile request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ReconcileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Reconciliation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reconciliations/{card}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get the past reconciliations of a card, the latest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Balances"
+                ],
+                "summary": "Gets the reconciliations of a card.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The card name",
+                        "name": "card",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Reconciliation"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/recurring-transaction": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to create a recurring transaction. Its expenses (with a subcategory) or incomes (with a category)\nare created by a background worker from its start date on, including past dates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurring transactions"
+                ],
+                "summary": "Creates a new recurring transaction.",
+                "parameters": [
+                    {
+                        "description": "Create recurring transaction request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.RecurringTransaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.RecurringTransactionCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/recurring-transaction/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get a recurring transaction by id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurring transactions"
+                ],
+                "summary": "Gets a recurring transaction by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The recurring transaction id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.RecurringTransaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to update a recurring transaction. Its next run is rescheduled from today on.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurring transactions"
+                ],
+                "summary": "Updates an existing recurring transaction.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The recurring transaction id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Update recurring transaction request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.RecurringTransaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to delete a recurring transaction by id. The expenses and incomes it created are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurring transactions"
+                ],
+                "summary": "Deletes a recurring transaction by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The recurring transaction id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/recurring-transactions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get the recurring transactions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurring transactions"
+                ],
+                "summary": "Gets the recurring transactions.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.RecurringTransaction"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rule": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to create a categorization rule. Expenses created without a subcategory and incomes created\nwithout a category get the ones of the first rule, by ascending priority, that matches them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categorization rules"
+                ],
+                "summary": "Creates a new categorization rule.",
+                "parameters": [
+                    {
+                        "description": "Create categorization rule request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategorizationRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategorizationRuleCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rule/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get a categorization rule by id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categorization rules"
+                ],
+                "summary": "Gets a categorization rule by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The categorization rule id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategorizationRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to update a categorization rule.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categorization rules"
+                ],
+                "summary": "Updates an existing categorization rule.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The categorization rule id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Update categorization rule request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategorizationRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to delete a categorization rule by id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categorization rules"
+                ],
+                "summary": "Deletes a categorization rule by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The categorization rule id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rules": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get the categorization rules, by ascending priority.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categorization rules"
+                ],
+                "summary": "Gets the categorization rules.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategorizationRule"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rules/dry-run": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to list the expenses (for a rule with a subcategory) or the incomes (for a rule with a category)\nof the provided range of dates that a categorization rule matches. Nothing is stored or changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categorization rules"
+                ],
+                "summary": "Tests a categorization rule against existing rows.",
+                "parameters": [
+                    {
+                        "description": "Dry run categorization rule request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategorizationRuleDryRunRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategorizationRuleDryRunResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to create a transfer. Transfers are neither expenses nor incomes, so they are left out of\ntheir totals and cash flow.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Creates a new transfer between two cards.",
+                "parameters": [
+                    {
+                        "description": "Create transfer request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Transfer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.TransferCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transfer/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get a transfer by id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Gets a transfer by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The transfer id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to update a transfer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Updates an existing transfer.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The transfer id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Update transfer request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Transfer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to delete a transfer by id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Deletes a transfer by its id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The transfer id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transfers/dates/{min_date}/{max_date}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint to get the transfers made on the provided range of dates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Gets the transfers made on a range of dates.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The minimum date to consider",
+                        "name": "min_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The maximum date to consider",
+                        "name": "max_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Transfer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.Attachment": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "description": "hex SHA-256 of the content",
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expense_id": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "income_id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "in bytes",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.Balance": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "string",
+                    "example": "987.70"
+                },
+                "card": {
+                    "type": "string"
+                },
+                "cleared_balance": {
+                    "description": "only counts the cleared movements",
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "movements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Movement"
+                    }
+                },
+                "opening_balance": {
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "opening_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.BatchErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.Budget": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "either a category",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "limit": {
+                    "type": "string",
+                    "example": "12.30"
+                },
+                "rollover": {
+                    "description": "unspent amounts are carried to the next month",
+                    "type": "boolean"
+                },
+                "start_month": {
+                    "description": "Should be on this format YYYY-MM, defaults to the current month",
+                    "type": "string"
+                },
+                "sub_category": {
+                    "description": "or a subcategory",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.BudgetCreateResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.BudgetStatus": {
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Budget"
+                },
+                "budgeted": {
+                    "description": "limit plus the rolled over amount",
+                    "type": "string",
+                    "example": "12.30"
+                },
+                "overspent": {
+                    "type": "boolean"
+                },
+                "remaining": {
+                    "type": "string",
+                    "example": "12.30"
+                },
+                "rolled_over": {
+                    "description": "unspent amount carried from the previous months",
+                    "type": "string",
+                    "example": "12.30"
+                },
+                "spent": {
+                    "type": "string",
+                    "example": "12.30"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.BudgetsReport": {
+            "type": "object",
+            "properties": {
+                "budgets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.BudgetStatus"
+                    }
+                },
+                "month": {
+                    "description": "YYYY-MM",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.Card": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "ISO 4217 code, EUR if missing on create and kept if missing on update",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opening_balance": {
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "opening_date": {
+                    "description": "Should be on this format YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.CardCreateResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.CashFlow": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "expenses": {
+                    "type": "string",
+                    "example": "12.30"
+                },
+                "incomes": {
+                    "type": "string",
+                    "example": "12.30"
+                },
+                "net": {
+                    "description": "incomes minus expenses",
+                    "type": "string",
+                    "example": "12.30"
+                },
+                "period": {
+                    "description": "day or first day of the week (YYYY-MM-DD), or month (YYYY-MM)",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.CategorizationRule": {
+            "type": "object",
+            "properties": {
+                "card": {
+                    "description": "any card if missing",
+                    "type": "string"
+                },
+                "category": {
+                    "description": "set on matching incomes",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "case insensitive substring, or regex, of the description",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_value": {
+                    "description": "no upper bound if missing",
+                    "type": "string",
+                    "example": "12.30"
+                },
+                "min_value": {
+                    "description": "no lower bound if missing",
+                    "type": "string",
+                    "example": "12.30"
+                },
+                "priority": {
+                    "description": "rules are tried by ascending priority",
+                    "type": "integer"
+                },
+                "regex": {
+                    "description": "description is a regex",
+                    "type": "boolean"
+                },
+                "sub_category": {
+                    "description": "set on matching expenses",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.CategorizationRuleCreateResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.CategorizationRuleDryRunRequest": {
+            "type": "object",
+            "properties": {
+                "max_date": {
+                    "description": "Should be on this format YYYY-MM-DD",
+                    "type": "string"
+                },
+                "min_date": {
+                    "description": "Should be on this format YYYY-MM-DD",
+                    "type": "string"
+                },
+                "rule": {
+                    "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.CategorizationRule"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.CategorizationRuleDryRunResponse": {
+            "type": "object",
+            "properties": {
+                "expenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
+                    }
+                },
+                "incomes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                    }
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.CategoryCreateResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.CategoryMergeRequest": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "description": "only count what would move",
+                    "type": "boolean"
+                },
+                "into": {
+                    "description": "the id of the category to merge into",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.CategoryMergeResponse": {
+            "type": "object",
+            "properties": {
+                "budgets": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "recurring_transactions": {
+                    "type": "integer"
+                },
+                "rules": {
+                    "type": "integer"
+                },
+                "sub_categories": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCategory": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest": {
+            "type": "object",
+            "properties": {
+                "card": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "ISO 4217 code, such as EUR - defaults to the currency of the card",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Should be on this format YYYY-MM-DD",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "force": {
+                    "description": "creates the expense even if it is a likely duplicate",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "splits": {
+                    "description": "lines adding up to the value - the expense takes the subcategory of the first one",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseSplit"
+                    }
+                },
+                "sub_category": {
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "single words, such as reimbursable - stored lower cased",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "value": {
+                    "type": "string",
+                    "example": "12.30"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
             }
         },
-        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateResponse": {
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseDuplicatesResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseSplit": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "sub_category": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "12.30"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseSubCategory": {
             "type": "object",
             "properties": {
+                "category": {
+                    "description": "the name of its expense category",
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpensesCreateRequest": {
+            "type": "object",
+            "properties": {
+                "expenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
+                    }
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpensesCreateResponse": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpensesPage": {
+            "type": "object",
+            "properties": {
+                "expenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.ExpenseCreateRequest"
+                    }
+                },
+                "next_cursor": {
+                    "description": "cursor of the next page, missing on the last page",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ImportErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "expense_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "income_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "index": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "expense_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "income_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "skipped_references": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -767,6 +5086,10 @@ const docTemplate = `{
                 "category": {
                     "type": "string"
                 },
+                "currency": {
+                    "description": "ISO 4217 code, such as EUR - defaults to the currency of the card",
+                    "type": "string"
+                },
                 "date": {
                     "description": "Should be on this format YYYY-MM-DD",
                     "type": "string"
@@ -774,11 +5097,34 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
+                "force": {
+                    "description": "creates the income even if it is a likely duplicate",
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "tags": {
+                    "description": "single words, such as bonus - stored lower cased",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "value": {
-                    "type": "number"
+                    "type": "string",
+                    "example": "12.30"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomeCategory": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
@@ -789,6 +5135,280 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomeDuplicatesResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomesCreateRequest": {
+            "type": "object",
+            "properties": {
+                "incomes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                    }
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomesCreateResponse": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.IncomesPage": {
+            "type": "object",
+            "properties": {
+                "incomes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Income"
+                    }
+                },
+                "next_cursor": {
+                    "description": "cursor of the next page, missing on the last page",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.Movement": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "in the currency of the card, positive in and negative out",
+                    "type": "string",
+                    "example": "-12.30"
+                },
+                "balance": {
+                    "type": "string",
+                    "example": "987.70"
+                },
+                "cleared": {
+                    "type": "boolean"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "the id of the expense, income or transfer",
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "expense, income, transfer_out or transfer_in",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.MovementRef": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "expense, income, transfer_out or transfer_in",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.OpeningBalance": {
+            "type": "object",
+            "properties": {
+                "opening_balance": {
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "opening_date": {
+                    "description": "Should be on this format YYYY-MM-DD, the balance counts every movement if missing",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.ReconcileRequest": {
+            "type": "object",
+            "properties": {
+                "cleared": {
+                    "description": "movements on or before the date to mark as cleared",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.MovementRef"
+                    }
+                },
+                "date": {
+                    "description": "Should be on this format YYYY-MM-DD",
+                    "type": "string"
+                },
+                "statement_balance": {
+                    "type": "string",
+                    "example": "987.70"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.Reconciliation": {
+            "type": "object",
+            "properties": {
+                "card": {
+                    "type": "string"
+                },
+                "cleared_balance": {
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "difference": {
+                    "description": "statement balance minus cleared balance",
+                    "type": "string",
+                    "example": "-12.30"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "statement_balance": {
+                    "type": "string",
+                    "example": "987.70"
+                },
+                "uncleared": {
+                    "description": "only set when reconciling",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rubengomes8_golang-personal-finances_internal_http_models.Movement"
+                    }
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.RecurringTransaction": {
+            "type": "object",
+            "properties": {
+                "card": {
+                    "type": "string"
+                },
+                "category": {
+                    "description": "set to create incomes",
+                    "type": "string"
+                },
+                "cron": {
+                    "description": "five fields cron expression of cron schedules",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "description": "Should be on this format YYYY-MM-DD, never ends if missing",
+                    "type": "string"
+                },
+                "frequency": {
+                    "description": "daily, weekly, monthly, yearly or cron",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "next_run": {
+                    "description": "read only - date of the next occurrence to create",
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "Should be on this format YYYY-MM-DD",
+                    "type": "string"
+                },
+                "sub_category": {
+                    "description": "set to create expenses",
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "12.30"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.RecurringTransactionCreateResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "next_run": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.Total": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "key": {
+                    "description": "category, subcategory or card name, day or first day of the week (YYYY-MM-DD), or month (YYYY-MM)",
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "12.30"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.Transfer": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "150.00"
+                },
+                "date": {
+                    "description": "Should be on this format YYYY-MM-DD",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "string",
+                    "example": "0.50"
+                },
+                "from_card": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "to_card": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rubengomes8_golang-personal-finances_internal_http_models.TransferCreateResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
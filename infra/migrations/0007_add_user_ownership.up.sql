@@ -0,0 +1,38 @@
+/* existing rows are owned by the first registered user */
+ALTER TABLE cards ADD COLUMN user_id INTEGER;
+UPDATE cards SET user_id = (SELECT MIN(id) FROM users);
+ALTER TABLE cards ALTER COLUMN user_id SET NOT NULL;
+ALTER TABLE cards ADD CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id);
+ALTER TABLE cards DROP CONSTRAINT cards_name_key;
+ALTER TABLE cards ADD CONSTRAINT cards_user_id_name_key UNIQUE (user_id, name);
+
+ALTER TABLE expenses ADD COLUMN user_id INTEGER;
+UPDATE expenses SET user_id = (SELECT MIN(id) FROM users);
+ALTER TABLE expenses ALTER COLUMN user_id SET NOT NULL;
+ALTER TABLE expenses ADD CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id);
+
+ALTER TABLE incomes ADD COLUMN user_id INTEGER;
+UPDATE incomes SET user_id = (SELECT MIN(id) FROM users);
+ALTER TABLE incomes ALTER COLUMN user_id SET NOT NULL;
+ALTER TABLE incomes ADD CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id);
+
+DROP VIEW IF EXISTS expenses_view;
+create view expenses_view as (
+	select 
+		e.id, e.value, e.date, e.description, es.category_id, ec.name as category_name, 
+        e.subcategory_id, es.name as subcategory_name, e.card_id, c.name as card_name, e.user_id 
+	from expenses e 
+	join cards c on e.card_id = c.id
+	join expense_subcategories es on e.subcategory_id = es.id
+	join expense_categories ec on ec.id = es.category_id
+);
+
+DROP VIEW IF EXISTS incomes_view;
+create view incomes_view as (
+	select 
+		i.id, i.value, i.date, i.description, i.category_id, 
+        ic.name as category_name, i.card_id, c.name as card_name, i.user_id 
+	from incomes i 
+	join cards c on i.card_id = c.id
+	join income_categories ic on i.category_id = ic.id
+);
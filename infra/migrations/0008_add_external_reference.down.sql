@@ -0,0 +1,5 @@
+ALTER TABLE incomes DROP CONSTRAINT IF EXISTS incomes_card_id_external_reference_key;
+ALTER TABLE incomes DROP COLUMN IF EXISTS external_reference;
+
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_card_id_external_reference_key;
+ALTER TABLE expenses DROP COLUMN IF EXISTS external_reference;
@@ -0,0 +1,6 @@
+/* bank reference of imported rows (OFX FITID / CAMT.053 AcctSvcrRef), unique per card so statements can be re-imported */
+ALTER TABLE expenses ADD COLUMN external_reference VARCHAR(255);
+ALTER TABLE expenses ADD CONSTRAINT expenses_card_id_external_reference_key UNIQUE (card_id, external_reference);
+
+ALTER TABLE incomes ADD COLUMN external_reference VARCHAR(255);
+ALTER TABLE incomes ADD CONSTRAINT incomes_card_id_external_reference_key UNIQUE (card_id, external_reference);
@@ -0,0 +1,20 @@
+/* rules that pick the expense subcategory or the income category of new rows created without one */
+CREATE TABLE categorization_rules (
+    id SERIAL PRIMARY KEY,
+    priority INTEGER NOT NULL DEFAULT 0,
+    description_pattern VARCHAR(255) NOT NULL DEFAULT '',
+    description_regex BOOLEAN NOT NULL DEFAULT FALSE,
+    min_value FLOAT,
+    max_value FLOAT,
+
+    card_id INTEGER,
+    subcategory_id INTEGER,
+    income_category_id INTEGER,
+    user_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_card FOREIGN KEY(card_id) REFERENCES cards(id),
+    CONSTRAINT fk_subcategory FOREIGN KEY(subcategory_id) REFERENCES expense_subcategories(id),
+    CONSTRAINT fk_income_category FOREIGN KEY(income_category_id) REFERENCES income_categories(id),
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id),
+    CONSTRAINT categorization_rules_one_target CHECK ((subcategory_id IS NULL) <> (income_category_id IS NULL))
+);
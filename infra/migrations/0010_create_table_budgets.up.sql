@@ -0,0 +1,19 @@
+/* monthly spending limits of an expense category or of an expense subcategory */
+CREATE TABLE budgets (
+    id SERIAL PRIMARY KEY,
+    category_id INTEGER,
+    subcategory_id INTEGER,
+    month_limit FLOAT NOT NULL,
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    start_month DATE NOT NULL,
+    user_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_category FOREIGN KEY(category_id) REFERENCES expense_categories(id),
+    CONSTRAINT fk_subcategory FOREIGN KEY(subcategory_id) REFERENCES expense_subcategories(id),
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id),
+    CONSTRAINT budgets_one_target CHECK ((category_id IS NULL) <> (subcategory_id IS NULL)),
+    CONSTRAINT budgets_month_limit_not_negative CHECK (month_limit >= 0)
+);
+
+CREATE UNIQUE INDEX budgets_user_id_category_id_key ON budgets (user_id, category_id) WHERE category_id IS NOT NULL;
+CREATE UNIQUE INDEX budgets_user_id_subcategory_id_key ON budgets (user_id, subcategory_id) WHERE subcategory_id IS NOT NULL;
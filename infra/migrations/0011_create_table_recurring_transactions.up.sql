@@ -0,0 +1,25 @@
+/* templates of expenses (subcategory set) or incomes (income category set) materialized on a schedule */
+CREATE TABLE recurring_transactions (
+    id SERIAL PRIMARY KEY,
+    value FLOAT NOT NULL,
+    description VARCHAR(50),
+    frequency VARCHAR(10) NOT NULL,
+    cron_expression VARCHAR(100) NOT NULL DEFAULT '',
+    start_date DATE NOT NULL,
+    end_date DATE,
+    next_run DATE NOT NULL,
+
+    card_id INTEGER NOT NULL,
+    subcategory_id INTEGER,
+    income_category_id INTEGER,
+    user_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_card FOREIGN KEY(card_id) REFERENCES cards(id),
+    CONSTRAINT fk_subcategory FOREIGN KEY(subcategory_id) REFERENCES expense_subcategories(id),
+    CONSTRAINT fk_income_category FOREIGN KEY(income_category_id) REFERENCES income_categories(id),
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id),
+    CONSTRAINT recurring_transactions_one_target CHECK ((subcategory_id IS NULL) <> (income_category_id IS NULL)),
+    CONSTRAINT recurring_transactions_frequency CHECK (frequency IN ('daily', 'weekly', 'monthly', 'yearly', 'cron'))
+);
+
+CREATE INDEX recurring_transactions_next_run_idx ON recurring_transactions (next_run);
@@ -0,0 +1,43 @@
+/* amounts are stored exactly, with 2 decimal places. Existing amounts are converted from their shortest decimal
+   representation, and the migration stops, rather than rounding, if any of them has more than 2 decimal places */
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM expenses WHERE value::NUMERIC <> ROUND(value::NUMERIC, 2))
+        OR EXISTS (SELECT 1 FROM incomes WHERE value::NUMERIC <> ROUND(value::NUMERIC, 2))
+        OR EXISTS (SELECT 1 FROM categorization_rules WHERE min_value::NUMERIC <> ROUND(min_value::NUMERIC, 2))
+        OR EXISTS (SELECT 1 FROM categorization_rules WHERE max_value::NUMERIC <> ROUND(max_value::NUMERIC, 2))
+        OR EXISTS (SELECT 1 FROM budgets WHERE month_limit::NUMERIC <> ROUND(month_limit::NUMERIC, 2))
+        OR EXISTS (SELECT 1 FROM recurring_transactions WHERE value::NUMERIC <> ROUND(value::NUMERIC, 2))
+    THEN
+        RAISE EXCEPTION 'found amounts with more than 2 decimal places, which can not be converted without loss';
+    END IF;
+END $$;
+
+DROP VIEW IF EXISTS expenses_view;
+DROP VIEW IF EXISTS incomes_view;
+
+ALTER TABLE expenses ALTER COLUMN value TYPE NUMERIC(14,2) USING value::NUMERIC;
+ALTER TABLE incomes ALTER COLUMN value TYPE NUMERIC(14,2) USING value::NUMERIC;
+ALTER TABLE categorization_rules ALTER COLUMN min_value TYPE NUMERIC(14,2) USING min_value::NUMERIC;
+ALTER TABLE categorization_rules ALTER COLUMN max_value TYPE NUMERIC(14,2) USING max_value::NUMERIC;
+ALTER TABLE budgets ALTER COLUMN month_limit TYPE NUMERIC(14,2) USING month_limit::NUMERIC;
+ALTER TABLE recurring_transactions ALTER COLUMN value TYPE NUMERIC(14,2) USING value::NUMERIC;
+
+create view expenses_view as (
+	select 
+		e.id, e.value, e.date, e.description, es.category_id, ec.name as category_name, 
+        e.subcategory_id, es.name as subcategory_name, e.card_id, c.name as card_name, e.user_id 
+	from expenses e 
+	join cards c on e.card_id = c.id
+	join expense_subcategories es on e.subcategory_id = es.id
+	join expense_categories ec on ec.id = es.category_id
+);
+
+create view incomes_view as (
+	select 
+		i.id, i.value, i.date, i.description, i.category_id, 
+        ic.name as category_name, i.card_id, c.name as card_name, i.user_id 
+	from incomes i 
+	join cards c on i.card_id = c.id
+	join income_categories ic on i.category_id = ic.id
+);
@@ -0,0 +1,25 @@
+DROP VIEW IF EXISTS expenses_view;
+DROP VIEW IF EXISTS incomes_view;
+
+ALTER TABLE cards DROP COLUMN IF EXISTS currency;
+ALTER TABLE expenses DROP COLUMN IF EXISTS currency;
+ALTER TABLE incomes DROP COLUMN IF EXISTS currency;
+
+create view expenses_view as (
+	select 
+		e.id, e.value, e.date, e.description, es.category_id, ec.name as category_name, 
+        e.subcategory_id, es.name as subcategory_name, e.card_id, c.name as card_name, e.user_id 
+	from expenses e 
+	join cards c on e.card_id = c.id
+	join expense_subcategories es on e.subcategory_id = es.id
+	join expense_categories ec on ec.id = es.category_id
+);
+
+create view incomes_view as (
+	select 
+		i.id, i.value, i.date, i.description, i.category_id, 
+        ic.name as category_name, i.card_id, c.name as card_name, i.user_id 
+	from incomes i 
+	join cards c on i.card_id = c.id
+	join income_categories ic on i.category_id = ic.id
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS expenses_user_id_date_id_idx;
+DROP INDEX IF EXISTS expenses_user_id_value_id_idx;
+DROP INDEX IF EXISTS incomes_user_id_date_id_idx;
+DROP INDEX IF EXISTS incomes_user_id_value_id_idx;
@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS expenses_user_id_date_id_idx ON expenses (user_id, date, id);
+CREATE INDEX IF NOT EXISTS expenses_user_id_value_id_idx ON expenses (user_id, value, id);
+CREATE INDEX IF NOT EXISTS incomes_user_id_date_id_idx ON incomes (user_id, date, id);
+CREATE INDEX IF NOT EXISTS incomes_user_id_value_id_idx ON incomes (user_id, value, id);
@@ -0,0 +1,22 @@
+/* money moved between two cards of a user, kept apart from expenses and incomes.
+   The amount is in the currency of the source card, which also pays the fee */
+CREATE TABLE transfers (
+    id SERIAL PRIMARY KEY,
+    amount NUMERIC(14, 2) NOT NULL,
+    fee NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    date DATE NOT NULL,
+    description VARCHAR(50),
+
+    from_card_id INTEGER NOT NULL,
+    to_card_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_from_card FOREIGN KEY(from_card_id) REFERENCES cards(id),
+    CONSTRAINT fk_to_card FOREIGN KEY(to_card_id) REFERENCES cards(id),
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id),
+    CONSTRAINT transfers_distinct_cards CHECK (from_card_id <> to_card_id),
+    CONSTRAINT transfers_amount_positive CHECK (amount > 0),
+    CONSTRAINT transfers_fee_not_negative CHECK (fee >= 0)
+);
+
+CREATE INDEX transfers_user_id_date_idx ON transfers (user_id, date, id);
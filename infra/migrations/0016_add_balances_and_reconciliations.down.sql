@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS expenses_card_id_date_idx;
+DROP INDEX IF EXISTS incomes_card_id_date_idx;
+DROP TABLE IF EXISTS reconciliations;
+
+ALTER TABLE cards DROP COLUMN IF EXISTS opening_balance;
+ALTER TABLE cards DROP COLUMN IF EXISTS opening_date;
+ALTER TABLE expenses DROP COLUMN IF EXISTS cleared;
+ALTER TABLE incomes DROP COLUMN IF EXISTS cleared;
+ALTER TABLE transfers DROP COLUMN IF EXISTS from_cleared;
+ALTER TABLE transfers DROP COLUMN IF EXISTS to_cleared;
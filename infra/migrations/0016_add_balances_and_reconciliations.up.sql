@@ -0,0 +1,26 @@
+/* cards have an opening balance on an opening date, their balance is computed from it.
+   Movements are cleared once reconciled against a bank statement; each side of a transfer is cleared on its own card */
+ALTER TABLE cards ADD COLUMN opening_balance NUMERIC(14, 2) NOT NULL DEFAULT 0;
+ALTER TABLE cards ADD COLUMN opening_date DATE;
+ALTER TABLE expenses ADD COLUMN cleared BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE incomes ADD COLUMN cleared BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE transfers ADD COLUMN from_cleared BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE transfers ADD COLUMN to_cleared BOOLEAN NOT NULL DEFAULT FALSE;
+
+/* bank statement balances of a card on a date, with the cleared balance they were reconciled against */
+CREATE TABLE reconciliations (
+    id SERIAL PRIMARY KEY,
+    date DATE NOT NULL,
+    statement_balance NUMERIC(14, 2) NOT NULL,
+    cleared_balance NUMERIC(14, 2) NOT NULL,
+
+    card_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_card FOREIGN KEY(card_id) REFERENCES cards(id),
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE INDEX expenses_card_id_date_idx ON expenses (card_id, date);
+CREATE INDEX incomes_card_id_date_idx ON incomes (card_id, date);
+CREATE INDEX reconciliations_card_id_date_idx ON reconciliations (card_id, date);
@@ -0,0 +1,3 @@
+DROP VIEW IF EXISTS expense_lines_view;
+DROP INDEX IF EXISTS expense_splits_expense_id_idx;
+DROP TABLE IF EXISTS expense_splits;
@@ -0,0 +1,29 @@
+/* an expense can be split in lines of their own subcategory, whose values add up to the value of the expense.
+   The expense keeps the subcategory of its first line */
+CREATE TABLE expense_splits (
+    id SERIAL PRIMARY KEY,
+    value NUMERIC(14, 2) NOT NULL,
+    note VARCHAR(50),
+
+    expense_id INTEGER NOT NULL,
+    subcategory_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_expense FOREIGN KEY(expense_id) REFERENCES expenses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_subcategory FOREIGN KEY(subcategory_id) REFERENCES expense_subcategories(id),
+    CONSTRAINT expense_splits_value_positive CHECK (value > 0)
+);
+
+CREATE INDEX expense_splits_expense_id_idx ON expense_splits (expense_id);
+
+/* one row per line of the expenses: the expense itself if it is not split, or each of its split lines */
+CREATE VIEW expense_lines_view AS (
+	SELECT 
+		e.id AS expense_id, COALESCE(s.value, e.value) AS value, e.currency, e.date, e.description, 
+		es.category_id, ec.name AS category_name, es.id AS subcategory_id, es.name AS subcategory_name, 
+		e.card_id, c.name AS card_name, e.user_id 
+	FROM expenses e 
+	LEFT JOIN expense_splits s ON s.expense_id = e.id
+	JOIN cards c ON e.card_id = c.id
+	JOIN expense_subcategories es ON es.id = COALESCE(s.subcategory_id, e.subcategory_id)
+	JOIN expense_categories ec ON ec.id = es.category_id
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS expense_tags_tag_id_idx;
+DROP INDEX IF EXISTS income_tags_tag_id_idx;
+DROP TABLE IF EXISTS expense_tags;
+DROP TABLE IF EXISTS income_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,31 @@
+/* free-form labels of a user, linked to any number of their expenses and incomes */
+CREATE TABLE tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+
+    user_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id),
+    CONSTRAINT tags_user_id_name_unique UNIQUE (user_id, name)
+);
+
+CREATE TABLE expense_tags (
+    expense_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+
+    PRIMARY KEY (expense_id, tag_id),
+    CONSTRAINT fk_expense FOREIGN KEY(expense_id) REFERENCES expenses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tag FOREIGN KEY(tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE TABLE income_tags (
+    income_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+
+    PRIMARY KEY (income_id, tag_id),
+    CONSTRAINT fk_income FOREIGN KEY(income_id) REFERENCES incomes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tag FOREIGN KEY(tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX expense_tags_tag_id_idx ON expense_tags (tag_id);
+CREATE INDEX income_tags_tag_id_idx ON income_tags (tag_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS attachments_expense_id_idx;
+DROP INDEX IF EXISTS attachments_income_id_idx;
+DROP TABLE IF EXISTS attachments;
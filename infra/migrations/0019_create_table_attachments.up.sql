@@ -0,0 +1,24 @@
+/* receipts, invoices and other files kept for an expense or an income. The content is on the blob storage
+   under the storage key; the table keeps its metadata. Deleting the expense or income deletes the metadata */
+CREATE TABLE attachments (
+    id SERIAL PRIMARY KEY,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    expense_id INTEGER,
+    income_id INTEGER,
+    user_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_expense FOREIGN KEY(expense_id) REFERENCES expenses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_income FOREIGN KEY(income_id) REFERENCES incomes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id),
+    CONSTRAINT attachments_one_transaction CHECK ((expense_id IS NULL) <> (income_id IS NULL)),
+    CONSTRAINT attachments_size_not_negative CHECK (size >= 0)
+);
+
+CREATE INDEX attachments_expense_id_idx ON attachments (expense_id);
+CREATE INDEX attachments_income_id_idx ON attachments (income_id);
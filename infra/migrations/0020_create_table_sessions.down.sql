@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS refresh_tokens_session_id_idx;
+DROP INDEX IF EXISTS sessions_user_id_idx;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS sessions;
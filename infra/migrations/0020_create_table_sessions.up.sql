@@ -0,0 +1,27 @@
+/* login sessions of the users. A session lasts until it expires or it is revoked, on logout or when one of its
+   refresh tokens is used twice. The access tokens carry the id of their session */
+CREATE TABLE sessions (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+
+    user_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+/* refresh tokens of the sessions, kept as their SHA-256 hash. Each one is used once, to get the next one */
+CREATE TABLE refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    used_at TIMESTAMP,
+
+    session_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_session FOREIGN KEY(session_id) REFERENCES sessions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX sessions_user_id_idx ON sessions (user_id);
+CREATE INDEX refresh_tokens_session_id_idx ON refresh_tokens (session_id);
@@ -0,0 +1,14 @@
+/* password reset tokens of the users, kept as their SHA-256 hash. Each one is used once, before it expires */
+CREATE TABLE password_resets (
+    id SERIAL PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+
+    user_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE INDEX password_resets_user_id_idx ON password_resets (user_id);
@@ -0,0 +1,19 @@
+/* TOTP two-factor authentication of the users. The secret is set on enrolment and 2FA is enabled once a code of it
+   is verified. totp_last_step is the time step of the last accepted code, so a code can not be replayed */
+ALTER TABLE users ADD COLUMN totp_secret VARCHAR(64);
+ALTER TABLE users ADD COLUMN totp_enabled_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN totp_last_step BIGINT NOT NULL DEFAULT 0;
+
+/* single use recovery codes of the users with 2FA enabled, kept as their SHA-256 hash */
+CREATE TABLE recovery_codes (
+    id SERIAL PRIMARY KEY,
+    code_hash CHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    used_at TIMESTAMP,
+
+    user_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE INDEX recovery_codes_user_id_idx ON recovery_codes (user_id);
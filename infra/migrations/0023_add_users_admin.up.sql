@@ -0,0 +1,2 @@
+/* categories are shared by every user, so only the admins may rename, delete, replace or merge them */
+ALTER TABLE users ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,291 @@
+package attachments
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const (
+	// DefaultDir is the directory of the local storage when ATTACHMENTS_DIR is not set
+	DefaultDir = "attachments"
+	// DefaultMaxSize is the largest attachment, in bytes, when ATTACHMENTS_MAX_SIZE is not set
+	DefaultMaxSize = 10 << 20
+	// maxFileNameLength is the longest file name the attachments table keeps
+	maxFileNameLength = 255
+	// maxContentTypeLength is the longest content type the attachments table keeps
+	maxContentTypeLength = 255
+	// sniffLength is the number of bytes the content type is detected from
+	sniffLength = 512
+)
+
+var (
+	// ErrInvalidAttachment is returned when an attachment can not be stored
+	ErrInvalidAttachment = errors.New("attachment is not valid")
+	// ErrTooLarge is returned when the content of an attachment is larger than the max size
+	ErrTooLarge = errors.New("attachment is too large")
+)
+
+// Owner is the expense or the income an attachment belongs to. Exactly one of the ids is set.
+type Owner struct {
+	ExpenseID int64
+	IncomeID  int64
+}
+
+// ExpenseOwner is the owner of the attachments of an expense
+func ExpenseOwner(expenseID int64) Owner {
+	return Owner{ExpenseID: expenseID}
+}
+
+// IncomeOwner is the owner of the attachments of an income
+func IncomeOwner(incomeID int64) Owner {
+	return Owner{IncomeID: incomeID}
+}
+
+func (o Owner) valid() bool {
+	return (o.ExpenseID > 0) != (o.IncomeID > 0)
+}
+
+// Manager keeps the attachments of the expenses and incomes:
+// the content goes to the storage and the metadata to the repository.
+type Manager struct {
+	Repository repository.AttachmentRepo
+	Storage    Storage
+	MaxSize    int64
+}
+
+// NewManager creates a Manager that accepts attachments up to the default max size
+func NewManager(repo repository.AttachmentRepo, storage Storage) Manager {
+	return Manager{
+		Repository: repo,
+		Storage:    storage,
+		MaxSize:    DefaultMaxSize,
+	}
+}
+
+// NewManagerFromEnv creates a Manager with a local storage, configured by the ATTACHMENTS_DIR and
+// ATTACHMENTS_MAX_SIZE (in bytes) env variables. Unset variables keep their defaults.
+func NewManagerFromEnv(repo repository.AttachmentRepo) (Manager, error) {
+
+	dir := os.Getenv("ATTACHMENTS_DIR")
+	if dir == "" {
+		dir = DefaultDir
+	}
+
+	storage, err := NewLocalStorage(dir)
+	if err != nil {
+		return Manager{}, err
+	}
+
+	manager := NewManager(repo, storage)
+
+	if maxSize := os.Getenv("ATTACHMENTS_MAX_SIZE"); maxSize != "" {
+		size, err := strconv.ParseInt(maxSize, 10, 64)
+		if err != nil || size <= 0 {
+			return Manager{}, fmt.Errorf("invalid ATTACHMENTS_MAX_SIZE %q", maxSize)
+		}
+		manager.MaxSize = size
+	}
+
+	return manager, nil
+}
+
+// Upload stores the content as an attachment of the owner. The content type is detected from the content
+// when it is not provided. It returns ErrTooLarge if the content is larger than the max size
+// and repository.ErrNotFound if the owner is not an expense or income of the user.
+func (m Manager) Upload(
+	ctx context.Context,
+	userID int64,
+	owner Owner,
+	fileName string,
+	contentType string,
+	content io.Reader,
+) (models.AttachmentTable, error) {
+
+	if !owner.valid() {
+		return models.AttachmentTable{}, fmt.Errorf("%w: it must belong to either an expense or an income", ErrInvalidAttachment)
+	}
+
+	fileName, err := cleanFileName(fileName)
+	if err != nil {
+		return models.AttachmentTable{}, err
+	}
+
+	buffered := bufio.NewReaderSize(content, sniffLength)
+	contentType, err = cleanContentType(contentType, buffered)
+	if err != nil {
+		return models.AttachmentTable{}, err
+	}
+
+	key, err := newStorageKey(userID)
+	if err != nil {
+		return models.AttachmentTable{}, err
+	}
+
+	// one byte more than the max size tells if the content is too large
+	hash := sha256.New()
+	counter := &countingWriter{}
+	limited := io.TeeReader(io.LimitReader(buffered, m.MaxSize+1), io.MultiWriter(hash, counter))
+
+	err = m.Storage.Put(ctx, key, limited)
+	if err != nil {
+		return models.AttachmentTable{}, fmt.Errorf("could not store attachment content: %w", err)
+	}
+
+	if counter.n > m.MaxSize {
+		m.deleteBlob(ctx, key)
+		return models.AttachmentTable{}, fmt.Errorf("%w: the max size is %d bytes", ErrTooLarge, m.MaxSize)
+	}
+
+	id, err := m.Repository.InsertAttachment(ctx, models.AttachmentTable{
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        counter.n,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+		ExpenseID:   owner.ExpenseID,
+		IncomeID:    owner.IncomeID,
+		UserID:      userID,
+	})
+	if err != nil {
+		m.deleteBlob(ctx, key)
+		return models.AttachmentTable{}, fmt.Errorf("could not insert attachment: %w", err)
+	}
+
+	return m.Repository.GetAttachmentByID(ctx, userID, id)
+}
+
+// List gets the attachments of the owner, oldest first
+func (m Manager) List(ctx context.Context, userID int64, owner Owner) ([]models.AttachmentTable, error) {
+
+	if !owner.valid() {
+		return nil, fmt.Errorf("%w: it must belong to either an expense or an income", ErrInvalidAttachment)
+	}
+
+	if owner.ExpenseID > 0 {
+		return m.Repository.GetAttachmentsByExpense(ctx, userID, owner.ExpenseID)
+	}
+
+	return m.Repository.GetAttachmentsByIncome(ctx, userID, owner.IncomeID)
+}
+
+// Open gets an attachment of the user and opens its content, which the caller must close
+func (m Manager) Open(ctx context.Context, userID int64, id int64) (models.AttachmentTable, io.ReadCloser, error) {
+
+	attachment, err := m.Repository.GetAttachmentByID(ctx, userID, id)
+	if err != nil {
+		return models.AttachmentTable{}, nil, err
+	}
+
+	content, err := m.Storage.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return models.AttachmentTable{}, nil, fmt.Errorf("could not open attachment content: %w", err)
+	}
+
+	return attachment, content, nil
+}
+
+// Delete deletes an attachment of the user and its content
+func (m Manager) Delete(ctx context.Context, userID int64, id int64) error {
+
+	attachment, err := m.Repository.GetAttachmentByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	err = m.Repository.DeleteAttachment(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	// once the metadata is gone the content is unreachable, so failing to delete it only leaves an orphan blob
+	m.deleteBlob(ctx, attachment.StorageKey)
+
+	return nil
+}
+
+func (m Manager) deleteBlob(ctx context.Context, key string) {
+	err := m.Storage.Delete(ctx, key)
+	if err != nil {
+		log.Printf("could not delete attachment blob %s: %v", key, err)
+	}
+}
+
+// cleanFileName keeps the base name of a file name, as clients may send a full path
+func cleanFileName(fileName string) (string, error) {
+
+	fileName = path.Base(strings.ReplaceAll(strings.TrimSpace(fileName), `\`, "/"))
+
+	if fileName == "." || fileName == "/" || fileName == ".." {
+		return "", fmt.Errorf("%w: the file name is missing", ErrInvalidAttachment)
+	}
+
+	if len(fileName) > maxFileNameLength {
+		return "", fmt.Errorf("%w: the file name is longer than %d bytes", ErrInvalidAttachment, maxFileNameLength)
+	}
+
+	return fileName, nil
+}
+
+// cleanContentType validates the content type, or detects it from the start of the content
+// when it is missing or generic
+func cleanContentType(contentType string, content *bufio.Reader) (string, error) {
+
+	contentType = strings.TrimSpace(contentType)
+
+	if contentType == "" || contentType == "application/octet-stream" {
+		start, err := content.Peek(sniffLength)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("could not read attachment content: %w", err)
+		}
+		contentType = http.DetectContentType(start)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid content type %q", ErrInvalidAttachment, contentType)
+	}
+
+	contentType = mime.FormatMediaType(mediaType, params)
+	if len(contentType) > maxContentTypeLength {
+		return "", fmt.Errorf("%w: the content type is too long", ErrInvalidAttachment)
+	}
+
+	return contentType, nil
+}
+
+// newStorageKey returns a random key under the directory of the user
+func newStorageKey(userID int64) (string, error) {
+
+	random := make([]byte, 16)
+	_, err := rand.Read(random)
+	if err != nil {
+		return "", fmt.Errorf("could not generate storage key: %v", err)
+	}
+
+	return fmt.Sprintf("%d/%s", userID, hex.EncodeToString(random)), nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
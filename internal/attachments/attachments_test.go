@@ -0,0 +1,143 @@
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestManager(t *testing.T) Manager {
+
+	storage, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	repo := cache.NewAttachment([]models.AttachmentTable{})
+
+	return NewManager(&repo, storage)
+}
+
+func TestManager_Upload(t *testing.T) {
+
+	manager := newTestManager(t)
+	content := []byte("%PDF-1.4 receipt")
+	checksum := sha256.Sum256(content)
+
+	attachment, err := manager.Upload(context.Background(), 1, ExpenseOwner(3), `C:\receipts\receipt.pdf`, "", bytes.NewReader(content))
+	assert.NoError(t, err)
+	assert.Equal(t, "receipt.pdf", attachment.FileName)
+	assert.Equal(t, "application/pdf", attachment.ContentType)
+	assert.Equal(t, int64(len(content)), attachment.Size)
+	assert.Equal(t, hex.EncodeToString(checksum[:]), attachment.Checksum)
+	assert.Equal(t, int64(3), attachment.ExpenseID)
+	assert.True(t, strings.HasPrefix(attachment.StorageKey, "1/"))
+
+	opened, reader, err := manager.Open(context.Background(), 1, attachment.ID)
+	assert.NoError(t, err)
+	defer reader.Close()
+	assert.Equal(t, attachment, opened)
+
+	stored, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, content, stored)
+
+	_, _, err = manager.Open(context.Background(), 2, attachment.ID)
+	assert.True(t, errors.Is(err, repository.ErrNotFound))
+}
+
+func TestManager_UploadInvalid(t *testing.T) {
+
+	manager := newTestManager(t)
+	manager.MaxSize = 4
+
+	tests := []struct {
+		name        string
+		owner       Owner
+		fileName    string
+		contentType string
+		content     string
+		wantErr     error
+	}{
+		{name: "No owner", owner: Owner{}, fileName: "a.txt", content: "a", wantErr: ErrInvalidAttachment},
+		{name: "Expense and income", owner: Owner{ExpenseID: 1, IncomeID: 1}, fileName: "a.txt", content: "a", wantErr: ErrInvalidAttachment},
+		{name: "No file name", owner: IncomeOwner(1), fileName: " ", content: "a", wantErr: ErrInvalidAttachment},
+		{name: "Invalid content type", owner: IncomeOwner(1), fileName: "a.txt", contentType: "text/", content: "a", wantErr: ErrInvalidAttachment},
+		{name: "Too large", owner: IncomeOwner(1), fileName: "a.txt", content: "abcde", wantErr: ErrTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := manager.Upload(context.Background(), 1, tt.owner, tt.fileName, tt.contentType, strings.NewReader(tt.content))
+			assert.True(t, errors.Is(err, tt.wantErr), err)
+		})
+	}
+
+	attachment, err := manager.Upload(context.Background(), 1, IncomeOwner(1), "a.txt", "text/plain; charset=utf-8", strings.NewReader("abcd"))
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", attachment.ContentType)
+}
+
+func TestManager_ListAndDelete(t *testing.T) {
+
+	manager := newTestManager(t)
+
+	first, err := manager.Upload(context.Background(), 1, IncomeOwner(2), "payslip.txt", "", strings.NewReader("January"))
+	assert.NoError(t, err)
+	second, err := manager.Upload(context.Background(), 1, IncomeOwner(2), "payslip.txt", "", strings.NewReader("February"))
+	assert.NoError(t, err)
+	_, err = manager.Upload(context.Background(), 1, ExpenseOwner(2), "receipt.txt", "", strings.NewReader("Pizza"))
+	assert.NoError(t, err)
+
+	attachments, err := manager.List(context.Background(), 1, IncomeOwner(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []models.AttachmentTable{first, second}, attachments)
+
+	err = manager.Delete(context.Background(), 1, first.ID)
+	assert.NoError(t, err)
+
+	_, err = manager.Storage.Get(context.Background(), first.StorageKey)
+	assert.True(t, errors.Is(err, ErrBlobNotFound))
+
+	attachments, err = manager.List(context.Background(), 1, IncomeOwner(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []models.AttachmentTable{second}, attachments)
+
+	err = manager.Delete(context.Background(), 1, first.ID)
+	assert.True(t, errors.Is(err, repository.ErrNotFound))
+}
+
+func TestLocalStorage_InvalidKey(t *testing.T) {
+
+	storage, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "../secret", "1/../../secret", "/etc/passwd", "1//a"} {
+		err = storage.Put(context.Background(), key, strings.NewReader("a"))
+		assert.Error(t, err, key)
+	}
+}
+
+func TestNewManagerFromEnv(t *testing.T) {
+
+	dir := t.TempDir()
+	t.Setenv("ATTACHMENTS_DIR", dir)
+	t.Setenv("ATTACHMENTS_MAX_SIZE", "1024")
+
+	manager, err := NewManagerFromEnv(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, LocalStorage{Dir: dir}, manager.Storage)
+	assert.Equal(t, int64(1024), manager.MaxSize)
+
+	t.Setenv("ATTACHMENTS_MAX_SIZE", "0")
+
+	_, err = NewManagerFromEnv(nil)
+	assert.Error(t, err)
+}
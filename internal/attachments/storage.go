@@ -0,0 +1,121 @@
+package attachments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// ErrBlobNotFound is returned when a storage has no content under a key
+var ErrBlobNotFound = errors.New("blob not found")
+
+// validKey matches the keys a storage accepts: slash separated segments of lowercase letters, digits and dashes
+var validKey = regexp.MustCompile(`^[a-z0-9-]+(/[a-z0-9-]+)*$`)
+
+// Storage keeps the content of the attachments under their storage keys
+type Storage interface {
+	Put(ctx context.Context, key string, content io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// LocalStorage is a Storage that keeps each content on a file of a directory of the local filesystem
+type LocalStorage struct {
+	Dir string
+}
+
+// NewLocalStorage creates a LocalStorage on the directory, creating it if it does not exist
+func NewLocalStorage(dir string) (LocalStorage, error) {
+
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return LocalStorage{}, fmt.Errorf("could not create attachments directory: %v", err)
+	}
+
+	return LocalStorage{
+		Dir: dir,
+	}, nil
+}
+
+// Put writes the content under the key. The content is written to a temporary file first,
+// so a failed write never leaves a partial content under the key.
+func (s LocalStorage) Put(ctx context.Context, key string, content io.Reader) error {
+
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return fmt.Errorf("could not create blob directory: %v", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("could not create blob file: %v", err)
+	}
+	defer os.Remove(file.Name()) // nolint
+
+	_, err = io.Copy(file, content)
+	if err != nil {
+		file.Close() // nolint
+		return fmt.Errorf("could not write blob: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("could not write blob: %v", err)
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+// Get opens the content under the key
+func (s LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open blob: %v", err)
+	}
+
+	return file, nil
+}
+
+// Delete deletes the content under the key. Deleting a missing content is not an error.
+func (s LocalStorage) Delete(ctx context.Context, key string) error {
+
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not delete blob: %v", err)
+	}
+
+	return nil
+}
+
+// path returns the file of a key, rejecting the keys that could point outside of the directory
+func (s LocalStorage) path(key string) (string, error) {
+
+	if !validKey.MatchString(key) {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+
+	return filepath.Join(s.Dir, filepath.FromSlash(key)), nil
+}
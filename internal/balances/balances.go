@@ -0,0 +1,263 @@
+package balances
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// ErrInvalidReconciliation is returned when a statement balance can not be reconciled
+var ErrInvalidReconciliation = errors.New("reconciliation is not valid")
+
+// Movement is a movement of a card, with its amount in the currency of the card,
+// and the running balance of the card after it
+type Movement struct {
+	models.CardMovement
+	Balance models.Money
+}
+
+// Balance is the balance of a card as of a date: its opening balance plus every movement since its opening date.
+// The cleared balance only counts the cleared movements.
+type Balance struct {
+	Card           models.CardTable
+	Date           time.Time
+	Balance        models.Money
+	ClearedBalance models.Money
+	Movements      []Movement
+}
+
+// Reconciliation is a bank statement balance of a card checked against the balance of its cleared movements.
+// The difference is the statement balance minus the cleared balance, which is zero once every movement is accounted for.
+type Reconciliation struct {
+	ID               int64
+	Card             models.CardTable
+	Date             time.Time
+	StatementBalance models.Money
+	ClearedBalance   models.Money
+	Difference       models.Money
+	Uncleared        []Movement
+}
+
+// Calculator computes the balances of the cards of a user and reconciles them with bank statements
+type Calculator struct {
+	CardRepository repository.CardRepo
+	Repository     repository.BalanceRepo
+	Rates          currency.Rates
+}
+
+// NewCalculator creates a new Calculator
+func NewCalculator(cardRepo repository.CardRepo, balanceRepo repository.BalanceRepo, rates currency.Rates) Calculator {
+	return Calculator{
+		CardRepository: cardRepo,
+		Repository:     balanceRepo,
+		Rates:          rates,
+	}
+}
+
+// SetOpeningBalance sets the balance of the card on its opening date.
+// A zero opening date counts every movement of the card.
+func (c Calculator) SetOpeningBalance(
+	ctx context.Context,
+	userID int64,
+	cardName string,
+	openingBalance models.Money,
+	openingDate time.Time,
+) error {
+
+	card, err := c.CardRepository.GetCardByName(ctx, userID, cardName)
+	if err != nil {
+		return fmt.Errorf("could not get card by name: %w", err)
+	}
+
+	card.OpeningBalance = openingBalance
+	card.OpeningDate = openingDate
+
+	_, err = c.CardRepository.UpdateCard(ctx, card)
+	if err != nil {
+		return fmt.Errorf("could not update card: %w", err)
+	}
+
+	return nil
+}
+
+// Balance returns the balance of the card as of the date, with the running balance after each movement
+func (c Calculator) Balance(ctx context.Context, userID int64, cardName string, date time.Time) (Balance, error) {
+
+	card, err := c.CardRepository.GetCardByName(ctx, userID, cardName)
+	if err != nil {
+		return Balance{}, fmt.Errorf("could not get card by name: %w", err)
+	}
+
+	movements, err := c.Repository.GetCardMovements(ctx, userID, card.ID, card.OpeningDate, date)
+	if err != nil {
+		return Balance{}, fmt.Errorf("could not get card movements: %v", err)
+	}
+
+	return Running(card, date, movements, c.Rates)
+}
+
+// Reconcile marks the movements of the card as cleared and checks the statement balance of the date
+// against the balance of the cleared movements. Only movements on or before the date can be cleared.
+func (c Calculator) Reconcile(
+	ctx context.Context,
+	userID int64,
+	cardName string,
+	date time.Time,
+	statementBalance models.Money,
+	cleared []models.MovementRef,
+) (Reconciliation, error) {
+
+	card, err := c.CardRepository.GetCardByName(ctx, userID, cardName)
+	if err != nil {
+		return Reconciliation{}, fmt.Errorf("could not get card by name: %w", err)
+	}
+
+	if date.Before(card.OpeningDate) {
+		return Reconciliation{}, fmt.Errorf("%w: the date is before the opening date of the card", ErrInvalidReconciliation)
+	}
+
+	movements, err := c.Repository.GetCardMovements(ctx, userID, card.ID, card.OpeningDate, date)
+	if err != nil {
+		return Reconciliation{}, fmt.Errorf("could not get card movements: %v", err)
+	}
+
+	toClear, err := Clear(movements, cleared)
+	if err != nil {
+		return Reconciliation{}, err
+	}
+
+	if len(toClear) > 0 {
+		err = c.Repository.ClearCardMovements(ctx, userID, card.ID, toClear)
+		if err != nil {
+			return Reconciliation{}, fmt.Errorf("could not clear card movements: %v", err)
+		}
+	}
+
+	balance, err := Running(card, date, movements, c.Rates)
+	if err != nil {
+		return Reconciliation{}, err
+	}
+
+	reconciliation := models.ReconciliationTable{
+		Date:             date,
+		StatementBalance: statementBalance,
+		ClearedBalance:   balance.ClearedBalance,
+		CardID:           card.ID,
+		UserID:           userID,
+	}
+
+	reconciliation.ID, err = c.Repository.InsertReconciliation(ctx, reconciliation)
+	if err != nil {
+		return Reconciliation{}, fmt.Errorf("could not insert reconciliation: %v", err)
+	}
+
+	result := toReconciliation(card, reconciliation)
+	for _, movement := range balance.Movements {
+		if !movement.Cleared {
+			result.Uncleared = append(result.Uncleared, movement)
+		}
+	}
+
+	return result, nil
+}
+
+// Reconciliations returns the past reconciliations of the card, the latest first
+func (c Calculator) Reconciliations(ctx context.Context, userID int64, cardName string) ([]Reconciliation, error) {
+
+	card, err := c.CardRepository.GetCardByName(ctx, userID, cardName)
+	if err != nil {
+		return []Reconciliation{}, fmt.Errorf("could not get card by name: %w", err)
+	}
+
+	records, err := c.Repository.GetReconciliations(ctx, userID, card.ID)
+	if err != nil {
+		return []Reconciliation{}, fmt.Errorf("could not get reconciliations: %v", err)
+	}
+
+	reconciliations := []Reconciliation{}
+	for _, record := range records {
+		reconciliations = append(reconciliations, toReconciliation(card, record))
+	}
+
+	return reconciliations, nil
+}
+
+// Running computes the balance of the card as of the date from its movements, sorted by date.
+// Movements in another currency than the one of the card are converted at the exchange rate of their date.
+func Running(card models.CardTable, date time.Time, movements []models.CardMovement, rates currency.Rates) (Balance, error) {
+
+	balance := Balance{
+		Card:           card,
+		Date:           date,
+		Balance:        card.OpeningBalance,
+		ClearedBalance: card.OpeningBalance,
+		Movements:      []Movement{},
+	}
+
+	for _, movement := range movements {
+
+		amount, err := rates.Convert(movement.Amount, movement.Currency, card.Currency, movement.Date)
+		if err != nil {
+			return Balance{}, err
+		}
+		movement.Amount = amount
+		movement.Currency = card.Currency
+
+		balance.Balance += amount
+		if movement.Cleared {
+			balance.ClearedBalance += amount
+		}
+
+		balance.Movements = append(balance.Movements, Movement{
+			CardMovement: movement,
+			Balance:      balance.Balance,
+		})
+	}
+
+	return balance, nil
+}
+
+// Clear marks the referenced movements as cleared and returns the references of the ones that were not cleared yet.
+// Every reference must be of one of the movements.
+func Clear(movements []models.CardMovement, refs []models.MovementRef) ([]models.MovementRef, error) {
+
+	indexes := make(map[models.MovementRef]int, len(movements))
+	for idx, movement := range movements {
+		indexes[movement.Ref()] = idx
+	}
+
+	toClear := []models.MovementRef{}
+	for _, ref := range refs {
+
+		idx, ok := indexes[ref]
+		if !ok {
+			return []models.MovementRef{}, fmt.Errorf(
+				"%w: %s %d is not a movement of the card on or before the date", ErrInvalidReconciliation, ref.Kind, ref.ID,
+			)
+		}
+
+		if !movements[idx].Cleared {
+			movements[idx].Cleared = true
+			toClear = append(toClear, ref)
+		}
+	}
+
+	return toClear, nil
+}
+
+func toReconciliation(card models.CardTable, record models.ReconciliationTable) Reconciliation {
+	return Reconciliation{
+		ID:               record.ID,
+		Card:             card,
+		Date:             record.Date,
+		StatementBalance: record.StatementBalance,
+		ClearedBalance:   record.ClearedBalance,
+		Difference:       record.StatementBalance - record.ClearedBalance,
+		Uncleared:        []Movement{},
+	}
+}
@@ -0,0 +1,112 @@
+package balances
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+var (
+	cgdCard = models.CardTable{
+		ID:             1,
+		Name:           "CGD",
+		Currency:       "EUR",
+		OpeningBalance: models.MustParseMoney("1000"),
+		OpeningDate:    date(2024, time.January, 1),
+	}
+
+	rent = models.CardMovement{
+		Kind: models.MovementExpense, ID: 1, Date: date(2024, time.January, 1), Amount: models.MustParseMoney("-500"), Currency: "EUR",
+	}
+	salary = models.CardMovement{
+		Kind: models.MovementIncome, ID: 1, Date: date(2024, time.January, 2), Amount: models.MustParseMoney("1500"), Currency: "EUR",
+	}
+	toFoodAllowance = models.CardMovement{
+		Kind: models.MovementTransferOut, ID: 1, Date: date(2024, time.January, 3), Amount: models.MustParseMoney("-150.50"), Currency: "EUR",
+	}
+	books = models.CardMovement{
+		Kind: models.MovementExpense, ID: 2, Date: date(2024, time.January, 4), Amount: models.MustParseMoney("-22"), Currency: "GBP",
+	}
+)
+
+func rates(t *testing.T) currency.Rates {
+	rates := currency.NewRates()
+	assert.NoError(t, rates.Add("GBP", date(2024, time.January, 4), "0.88"))
+	return rates
+}
+
+func TestRunning(t *testing.T) {
+
+	got, err := Running(cgdCard, date(2024, time.January, 31), []models.CardMovement{rent, salary, toFoodAllowance, books}, rates(t))
+	assert.NoError(t, err)
+
+	assert.Equal(t, models.MustParseMoney("1824.50"), got.Balance)
+	assert.Equal(t, models.MustParseMoney("1000"), got.ClearedBalance)
+
+	runningBalances := []string{}
+	for _, movement := range got.Movements {
+		runningBalances = append(runningBalances, movement.Balance.String())
+	}
+	assert.Equal(t, []string{"500.00", "2000.00", "1849.50", "1824.50"}, runningBalances)
+	assert.Equal(t, "EUR", got.Movements[3].Currency)
+
+	_, err = Running(cgdCard, date(2024, time.January, 31), []models.CardMovement{books}, currency.NewRates())
+	assert.True(t, errors.Is(err, currency.ErrNoRate))
+}
+
+func TestCalculator_Reconcile(t *testing.T) {
+
+	cardsCache := cache.NewCard([]models.CardTable{cgdCard})
+	balanceCache := cache.NewBalance(map[int64][]models.CardMovement{
+		1: {rent, salary, toFoodAllowance, books},
+	})
+
+	calculator := NewCalculator(cardsCache, &balanceCache, rates(t))
+
+	got, err := calculator.Reconcile(
+		context.Background(), 0, "CGD", date(2024, time.January, 3), models.MustParseMoney("1849.50"),
+		[]models.MovementRef{rent.Ref(), salary.Ref()},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, models.MustParseMoney("2000"), got.ClearedBalance)
+	assert.Equal(t, models.MustParseMoney("-150.50"), got.Difference)
+	assert.Equal(t, 1, len(got.Uncleared))
+	assert.Equal(t, toFoodAllowance.Ref(), got.Uncleared[0].Ref())
+
+	got, err = calculator.Reconcile(
+		context.Background(), 0, "CGD", date(2024, time.January, 3), models.MustParseMoney("1849.50"),
+		[]models.MovementRef{rent.Ref(), toFoodAllowance.Ref()},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, models.MustParseMoney("0"), got.Difference)
+	assert.Empty(t, got.Uncleared)
+
+	balance, err := calculator.Balance(context.Background(), 0, "CGD", date(2024, time.January, 31))
+	assert.NoError(t, err)
+	assert.Equal(t, models.MustParseMoney("1849.50"), balance.ClearedBalance)
+
+	reconciliations, err := calculator.Reconciliations(context.Background(), 0, "CGD")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(reconciliations))
+
+	_, err = calculator.Reconcile(
+		context.Background(), 0, "CGD", date(2024, time.January, 3), models.MustParseMoney("1849.50"),
+		[]models.MovementRef{books.Ref()},
+	)
+	assert.True(t, errors.Is(err, ErrInvalidReconciliation))
+
+	_, err = calculator.Reconcile(
+		context.Background(), 0, "CGD", date(2023, time.December, 31), models.MustParseMoney("1000"), nil,
+	)
+	assert.True(t, errors.Is(err, ErrInvalidReconciliation))
+}
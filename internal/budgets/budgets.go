@@ -0,0 +1,174 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// ErrInvalidBudget is returned when a budget can not be stored
+var ErrInvalidBudget = errors.New("budget is not valid")
+
+// Status is how a budget stands in a month.
+// The budgeted amount is the month limit plus the amount rolled over from the previous months.
+type Status struct {
+	Budget     models.BudgetView
+	Month      time.Time
+	RolledOver models.Money
+	Budgeted   models.Money
+	Spent      models.Money
+	Remaining  models.Money
+	Overspent  bool
+}
+
+// Reporter reports budgeted vs spent vs remaining amounts of the budgets of a user.
+// The budget limits are in the default currency, which the spending in other currencies is converted to.
+type Reporter struct {
+	Repository repository.BudgetRepo
+	Rates      currency.Rates
+}
+
+// NewReporter creates a new Reporter
+func NewReporter(repo repository.BudgetRepo, rates currency.Rates) Reporter {
+	return Reporter{
+		Repository: repo,
+		Rates:      rates,
+	}
+}
+
+// Report returns the status in the month of the budgets of the user that apply to it
+func (r Reporter) Report(ctx context.Context, userID int64, month time.Time) ([]Status, error) {
+
+	month = MonthStart(month)
+
+	budgets, err := r.Repository.GetBudgets(ctx, userID)
+	if err != nil {
+		return []Status{}, err
+	}
+
+	// rollover budgets need the spending of every month since they started
+	from := month
+	for _, budget := range budgets {
+		if budget.Rollover && budget.StartMonth.Before(from) {
+			from = MonthStart(budget.StartMonth)
+		}
+	}
+
+	spending, err := r.Repository.GetMonthlySpending(ctx, userID, from, month.AddDate(0, 1, 0))
+	if err != nil {
+		return []Status{}, err
+	}
+
+	spending, err = ConvertSpending(r.Rates, spending, models.DefaultCurrency)
+	if err != nil {
+		return []Status{}, err
+	}
+
+	return Report(budgets, spending, month), nil
+}
+
+// ConvertSpending converts the monthly spending to the provided currency at the rate of the last day of each month
+func ConvertSpending(rates currency.Rates, spending []models.MonthlySpending, to string) ([]models.MonthlySpending, error) {
+
+	converted := make([]models.MonthlySpending, 0, len(spending))
+	for _, monthSpending := range spending {
+
+		lastDay := MonthStart(monthSpending.Month).AddDate(0, 1, -1)
+		value, err := rates.Convert(monthSpending.Value, monthSpending.Currency, to, lastDay)
+		if err != nil {
+			return []models.MonthlySpending{}, err
+		}
+
+		monthSpending.Currency = to
+		monthSpending.Value = value
+		converted = append(converted, monthSpending)
+	}
+
+	return converted, nil
+}
+
+// Report returns the status in the month of the budgets that apply to it, given the monthly spending, in the currency
+// of the budgets, of every month since the rollover budgets started. Unspent amounts of rollover budgets are carried
+// to the next month; overspending is not.
+func Report(budgets []models.BudgetView, spending []models.MonthlySpending, month time.Time) []Status {
+
+	month = MonthStart(month)
+
+	statuses := []Status{}
+	for _, budget := range budgets {
+
+		startMonth := MonthStart(budget.StartMonth)
+		if startMonth.After(month) {
+			continue
+		}
+
+		var rolledOver models.Money
+		if budget.Rollover {
+			for m := startMonth; m.Before(month); m = m.AddDate(0, 1, 0) {
+				rolledOver = budget.MonthLimit + rolledOver - Spent(budget, spending, m)
+				if rolledOver < 0 {
+					rolledOver = 0
+				}
+			}
+		}
+
+		budgeted := budget.MonthLimit + rolledOver
+		spent := Spent(budget, spending, month)
+
+		statuses = append(statuses, Status{
+			Budget:     budget,
+			Month:      month,
+			RolledOver: rolledOver,
+			Budgeted:   budgeted,
+			Spent:      spent,
+			Remaining:  budgeted - spent,
+			Overspent:  spent > budgeted,
+		})
+	}
+
+	return statuses
+}
+
+// Spent sums the spending of the month on the category or subcategory of the budget. The spending must all be
+// in the currency of the budget, as converted by ConvertSpending.
+func Spent(budget models.BudgetView, spending []models.MonthlySpending, month time.Time) models.Money {
+
+	var spent models.Money
+	for _, monthSpending := range spending {
+
+		if !MonthStart(monthSpending.Month).Equal(month) {
+			continue
+		}
+
+		if (budget.CategoryID != 0 && monthSpending.CategoryID == budget.CategoryID) ||
+			(budget.SubCategoryID != 0 && monthSpending.SubCategoryID == budget.SubCategoryID) {
+			spent += monthSpending.Value
+		}
+	}
+
+	return spent
+}
+
+// MonthStart returns the first day of the month of the time, in UTC
+func MonthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Validate checks a budget before it is stored: it must be of exactly one of an expense category
+// or an expense subcategory and its month limit can not be negative.
+func Validate(budget models.BudgetTable) error {
+
+	if (budget.CategoryID == 0) == (budget.SubCategoryID == 0) {
+		return ErrInvalidBudget
+	}
+
+	if budget.MonthLimit < 0 {
+		return ErrInvalidBudget
+	}
+
+	return nil
+}
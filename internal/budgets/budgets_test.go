@@ -0,0 +1,132 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	january2020  = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	february2020 = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+	march2020    = time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	houseBudget = models.BudgetView{ID: 1, CategoryID: 1, Category: "House", MonthLimit: models.MustParseMoney("100"), StartMonth: january2020}
+	rentBudget  = models.BudgetView{ID: 2, SubCategoryID: 1, SubCategory: "Rent", MonthLimit: models.MustParseMoney("50"), Rollover: true, StartMonth: january2020}
+
+	spending = []models.MonthlySpending{
+		{Month: january2020, CategoryID: 1, SubCategoryID: 1, Currency: "EUR", Value: models.MustParseMoney("30")},
+		{Month: january2020, CategoryID: 1, SubCategoryID: 2, Currency: "EUR", Value: models.MustParseMoney("20")},
+		{Month: february2020, CategoryID: 1, SubCategoryID: 1, Currency: "EUR", Value: models.MustParseMoney("90")},
+		{Month: march2020, CategoryID: 1, SubCategoryID: 1, Currency: "EUR", Value: models.MustParseMoney("10")},
+		{Month: march2020, CategoryID: 1, SubCategoryID: 2, Currency: "EUR", Value: models.MustParseMoney("120")},
+	}
+)
+
+func TestReport(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		budget models.BudgetView
+		month  time.Time
+		want   []Status
+	}{
+		{
+			name:   "Category budget sums its subcategories",
+			budget: houseBudget,
+			month:  january2020,
+			want:   []Status{{Budget: houseBudget, Month: january2020, Budgeted: models.MustParseMoney("100"), Spent: models.MustParseMoney("50"), Remaining: models.MustParseMoney("50")}},
+		},
+		{
+			name:   "Overspent",
+			budget: houseBudget,
+			month:  march2020,
+			want:   []Status{{Budget: houseBudget, Month: march2020, Budgeted: models.MustParseMoney("100"), Spent: models.MustParseMoney("130"), Remaining: models.MustParseMoney("-30"), Overspent: true}},
+		},
+		{
+			name:   "Rollover of unspent amounts",
+			budget: rentBudget,
+			month:  february2020,
+			want:   []Status{{Budget: rentBudget, Month: february2020, RolledOver: models.MustParseMoney("20"), Budgeted: models.MustParseMoney("70"), Spent: models.MustParseMoney("90"), Remaining: models.MustParseMoney("-20"), Overspent: true}},
+		},
+		{
+			name:   "Overspending is not rolled over",
+			budget: rentBudget,
+			month:  march2020,
+			want:   []Status{{Budget: rentBudget, Month: march2020, Budgeted: models.MustParseMoney("50"), Spent: models.MustParseMoney("10"), Remaining: models.MustParseMoney("40")}},
+		},
+		{
+			name:   "Budget not started yet",
+			budget: models.BudgetView{ID: 3, CategoryID: 1, MonthLimit: models.MustParseMoney("100"), StartMonth: march2020},
+			month:  february2020,
+			want:   []Status{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Report([]models.BudgetView{tt.budget}, spending, tt.month)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReporter_Report(t *testing.T) {
+
+	budgetsCache := cache.NewBudget([]models.BudgetView{houseBudget, rentBudget}, spending)
+
+	got, err := NewReporter(&budgetsCache, currency.NewRates()).Report(context.Background(), 0, february2020.AddDate(0, 0, 14))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Status{
+		{Budget: houseBudget, Month: february2020, Budgeted: models.MustParseMoney("100"), Spent: models.MustParseMoney("90"), Remaining: models.MustParseMoney("10")},
+		{Budget: rentBudget, Month: february2020, RolledOver: models.MustParseMoney("20"), Budgeted: models.MustParseMoney("70"), Spent: models.MustParseMoney("90"), Remaining: models.MustParseMoney("-20"), Overspent: true},
+	}, got)
+}
+
+func TestReporter_ReportMixedCurrencies(t *testing.T) {
+
+	budgetsCache := cache.NewBudget([]models.BudgetView{houseBudget}, []models.MonthlySpending{
+		{Month: february2020, CategoryID: 1, SubCategoryID: 1, Currency: "EUR", Value: models.MustParseMoney("90")},
+		{Month: february2020, CategoryID: 1, SubCategoryID: 2, Currency: "GBP", Value: models.MustParseMoney("40")},
+	})
+
+	_, err := NewReporter(&budgetsCache, currency.NewRates()).Report(context.Background(), 0, february2020)
+	assert.True(t, errors.Is(err, currency.ErrNoRate), err)
+
+	rates := currency.NewRates()
+	assert.NoError(t, rates.Add("GBP", time.Date(2020, 2, 28, 0, 0, 0, 0, time.UTC), "0.8"))
+
+	got, err := NewReporter(&budgetsCache, rates).Report(context.Background(), 0, february2020)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Status{
+		{Budget: houseBudget, Month: february2020, Budgeted: models.MustParseMoney("100"), Spent: models.MustParseMoney("140"), Remaining: models.MustParseMoney("-40"), Overspent: true},
+	}, got)
+}
+
+func TestValidate(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		budget  models.BudgetTable
+		wantErr bool
+	}{
+		{name: "Category budget", budget: models.BudgetTable{CategoryID: 1, MonthLimit: models.MustParseMoney("100")}, wantErr: false},
+		{name: "Subcategory budget", budget: models.BudgetTable{SubCategoryID: 1}, wantErr: false},
+		{name: "No target", budget: models.BudgetTable{MonthLimit: models.MustParseMoney("100")}, wantErr: true},
+		{name: "Both targets", budget: models.BudgetTable{CategoryID: 1, SubCategoryID: 1, MonthLimit: models.MustParseMoney("100")}, wantErr: true},
+		{name: "Negative limit", budget: models.BudgetTable{CategoryID: 1, MonthLimit: models.MustParseMoney("-1")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.budget)
+			assert.Equal(t, tt.wantErr, errors.Is(err, ErrInvalidBudget))
+		})
+	}
+}
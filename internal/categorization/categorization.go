@@ -0,0 +1,202 @@
+package categorization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+var (
+	// ErrNoMatchingRule is returned when no categorization rule matches a row
+	ErrNoMatchingRule = errors.New("no categorization rule matches")
+	// ErrInvalidRule is returned when a categorization rule can not be stored
+	ErrInvalidRule = errors.New("categorization rule is not valid")
+	// ErrCouldNotGetRules is returned when the categorization rules of the user can not be loaded
+	ErrCouldNotGetRules = errors.New("could not get categorization rules")
+)
+
+// Categorizer picks the expense subcategory or the income category of rows created without one,
+// using the categorization rules of their owner
+type Categorizer struct {
+	Repository repository.CategorizationRuleRepo
+}
+
+// NewCategorizer creates a new Categorizer
+func NewCategorizer(repo repository.CategorizationRuleRepo) Categorizer {
+	return Categorizer{
+		Repository: repo,
+	}
+}
+
+// ExpenseSubCategoryID returns the subcategory of the first expense rule of the user that matches the expense
+func (c Categorizer) ExpenseSubCategoryID(ctx context.Context, userID int64, cardID int64, value models.Money, description string) (int64, error) {
+
+	rules, err := c.Rules(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrCouldNotGetRules, err)
+	}
+
+	subCategoryID, ok := ExpenseSubCategoryID(rules, cardID, value, description)
+	if !ok {
+		return 0, ErrNoMatchingRule
+	}
+
+	return subCategoryID, nil
+}
+
+// IncomeCategoryID returns the category of the first income rule of the user that matches the income
+func (c Categorizer) IncomeCategoryID(ctx context.Context, userID int64, cardID int64, value models.Money, description string) (int64, error) {
+
+	rules, err := c.Rules(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrCouldNotGetRules, err)
+	}
+
+	categoryID, ok := IncomeCategoryID(rules, cardID, value, description)
+	if !ok {
+		return 0, ErrNoMatchingRule
+	}
+
+	return categoryID, nil
+}
+
+// Rules gets the rules of the user in the order they are tried. Without a repository there are no rules.
+func (c Categorizer) Rules(ctx context.Context, userID int64) ([]models.CategorizationRuleView, error) {
+
+	if c.Repository == nil {
+		return []models.CategorizationRuleView{}, nil
+	}
+
+	return c.Repository.GetCategorizationRules(ctx, userID)
+}
+
+// RuleView returns a rule record as the view rows are matched against, so rules can be tested before they are stored
+func RuleView(rule models.CategorizationRuleTable) models.CategorizationRuleView {
+	return models.CategorizationRuleView{
+		ID:                 rule.ID,
+		Priority:           rule.Priority,
+		DescriptionPattern: rule.DescriptionPattern,
+		DescriptionRegex:   rule.DescriptionRegex,
+		MinValue:           rule.MinValue,
+		MaxValue:           rule.MaxValue,
+		CardID:             rule.CardID,
+		SubCategoryID:      rule.SubCategoryID,
+		IncomeCategoryID:   rule.IncomeCategoryID,
+		UserID:             rule.UserID,
+	}
+}
+
+// ExpenseSubCategoryID returns the subcategory of the first expense rule that matches the expense.
+// The rules must be in the order they are tried.
+func ExpenseSubCategoryID(rules []models.CategorizationRuleView, cardID int64, value models.Money, description string) (int64, bool) {
+
+	for _, rule := range rules {
+		if rule.SubCategoryID != 0 && Matches(rule, cardID, value, description) {
+			return rule.SubCategoryID, true
+		}
+	}
+
+	return 0, false
+}
+
+// IncomeCategoryID returns the category of the first income rule that matches the income.
+// The rules must be in the order they are tried.
+func IncomeCategoryID(rules []models.CategorizationRuleView, cardID int64, value models.Money, description string) (int64, bool) {
+
+	for _, rule := range rules {
+		if rule.IncomeCategoryID != 0 && Matches(rule, cardID, value, description) {
+			return rule.IncomeCategoryID, true
+		}
+	}
+
+	return 0, false
+}
+
+// MatchingExpenses returns the expenses that match the rule, to test it against existing rows
+func MatchingExpenses(rule models.CategorizationRuleView, expenses []models.ExpenseView) []models.ExpenseView {
+
+	matching := []models.ExpenseView{}
+	for _, expense := range expenses {
+		if Matches(rule, expense.CardID, expense.Value, expense.Description) {
+			matching = append(matching, expense)
+		}
+	}
+
+	return matching
+}
+
+// MatchingIncomes returns the incomes that match the rule, to test it against existing rows
+func MatchingIncomes(rule models.CategorizationRuleView, incomes []models.IncomeView) []models.IncomeView {
+
+	matching := []models.IncomeView{}
+	for _, income := range incomes {
+		if Matches(rule, income.CardID, income.Value, income.Description) {
+			matching = append(matching, income)
+		}
+	}
+
+	return matching
+}
+
+// Matches tells if a row of the card, value and description matches the rule.
+// Descriptions match a substring pattern ignoring case, or a regex pattern as is.
+// A rule with an invalid regex matches nothing.
+func Matches(rule models.CategorizationRuleView, cardID int64, value models.Money, description string) bool {
+
+	if rule.CardID != 0 && rule.CardID != cardID {
+		return false
+	}
+
+	if rule.MinValue != 0 && value < rule.MinValue {
+		return false
+	}
+
+	if rule.MaxValue != 0 && value > rule.MaxValue {
+		return false
+	}
+
+	if rule.DescriptionPattern == "" {
+		return true
+	}
+
+	if rule.DescriptionRegex {
+		expression, err := regexp.Compile(rule.DescriptionPattern)
+		if err != nil {
+			return false
+		}
+		return expression.MatchString(description)
+	}
+
+	return strings.Contains(strings.ToLower(description), strings.ToLower(rule.DescriptionPattern))
+}
+
+// Validate checks a rule before it is stored: it must assign exactly one of an expense subcategory
+// or an income category, its regex must compile and its value bounds must be a range.
+func Validate(rule models.CategorizationRuleTable) error {
+
+	if (rule.SubCategoryID == 0) == (rule.IncomeCategoryID == 0) {
+		return ErrInvalidRule
+	}
+
+	if rule.DescriptionRegex {
+		_, err := regexp.Compile(rule.DescriptionPattern)
+		if err != nil {
+			return ErrInvalidRule
+		}
+	}
+
+	if rule.MinValue < 0 || rule.MaxValue < 0 {
+		return ErrInvalidRule
+	}
+
+	if rule.MinValue != 0 && rule.MaxValue != 0 && rule.MinValue > rule.MaxValue {
+		return ErrInvalidRule
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package categorization
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatches(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		rule        models.CategorizationRuleView
+		cardID      int64
+		value       models.Money
+		description string
+		want        bool
+	}{
+		{name: "Empty rule", rule: models.CategorizationRuleView{}, cardID: 1, value: models.MustParseMoney("10"), description: "Anything", want: true},
+		{name: "Substring ignoring case", rule: models.CategorizationRuleView{DescriptionPattern: "market"}, description: "SUPERMARKET Lisbon", want: true},
+		{name: "Substring missing", rule: models.CategorizationRuleView{DescriptionPattern: "market"}, description: "Restaurant", want: false},
+		{name: "Regex", rule: models.CategorizationRuleView{DescriptionPattern: "^Uber( Eats)?$", DescriptionRegex: true}, description: "Uber Eats", want: true},
+		{name: "Regex is case sensitive", rule: models.CategorizationRuleView{DescriptionPattern: "^Uber$", DescriptionRegex: true}, description: "uber", want: false},
+		{name: "Invalid regex", rule: models.CategorizationRuleView{DescriptionPattern: "(", DescriptionRegex: true}, description: "(", want: false},
+		{name: "Within value range", rule: models.CategorizationRuleView{MinValue: models.MustParseMoney("10"), MaxValue: models.MustParseMoney("20")}, value: models.MustParseMoney("20"), want: true},
+		{name: "Below value range", rule: models.CategorizationRuleView{MinValue: models.MustParseMoney("10")}, value: models.MustParseMoney("9.99"), want: false},
+		{name: "Above value range", rule: models.CategorizationRuleView{MaxValue: models.MustParseMoney("20")}, value: models.MustParseMoney("20.01"), want: false},
+		{name: "Same card", rule: models.CategorizationRuleView{CardID: 1}, cardID: 1, want: true},
+		{name: "Other card", rule: models.CategorizationRuleView{CardID: 1}, cardID: 2, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Matches(tt.rule, tt.cardID, tt.value, tt.description))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		rule    models.CategorizationRuleTable
+		wantErr bool
+	}{
+		{name: "Expense rule", rule: models.CategorizationRuleTable{DescriptionPattern: "rent", SubCategoryID: 1}, wantErr: false},
+		{name: "Income rule", rule: models.CategorizationRuleTable{MinValue: models.MustParseMoney("1000"), IncomeCategoryID: 1}, wantErr: false},
+		{name: "No target", rule: models.CategorizationRuleTable{DescriptionPattern: "rent"}, wantErr: true},
+		{name: "Both targets", rule: models.CategorizationRuleTable{SubCategoryID: 1, IncomeCategoryID: 1}, wantErr: true},
+		{name: "Invalid regex", rule: models.CategorizationRuleTable{DescriptionPattern: "(", DescriptionRegex: true, SubCategoryID: 1}, wantErr: true},
+		{name: "Negative value", rule: models.CategorizationRuleTable{MinValue: models.MustParseMoney("-1"), SubCategoryID: 1}, wantErr: true},
+		{name: "Inverted value range", rule: models.CategorizationRuleTable{MinValue: models.MustParseMoney("20"), MaxValue: models.MustParseMoney("10"), SubCategoryID: 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.rule)
+			assert.Equal(t, tt.wantErr, errors.Is(err, ErrInvalidRule))
+		})
+	}
+}
+
+func TestCategorizer_ExpenseSubCategoryID(t *testing.T) {
+
+	rulesCache := cache.NewCategorizationRule([]models.CategorizationRuleView{
+		{ID: 1, Priority: 2, DescriptionPattern: "market", SubCategoryID: 1},
+		{ID: 2, Priority: 1, DescriptionPattern: "super", SubCategoryID: 2},
+		{ID: 3, Priority: 0, DescriptionPattern: "supermarket", IncomeCategoryID: 1},
+		{ID: 4, Priority: 0, DescriptionPattern: "supermarket", SubCategoryID: 3, UserID: 2},
+	})
+	categorizer := NewCategorizer(&rulesCache)
+
+	subCategoryID, err := categorizer.ExpenseSubCategoryID(context.Background(), 0, 1, 10, "Supermarket")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), subCategoryID)
+
+	subCategoryID, err = categorizer.ExpenseSubCategoryID(context.Background(), 0, 1, 10, "Flea market")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), subCategoryID)
+
+	_, err = categorizer.ExpenseSubCategoryID(context.Background(), 0, 1, 10, "Books")
+	assert.ErrorIs(t, err, ErrNoMatchingRule)
+
+	_, err = Categorizer{}.ExpenseSubCategoryID(context.Background(), 0, 1, 10, "Supermarket")
+	assert.ErrorIs(t, err, ErrNoMatchingRule)
+}
@@ -0,0 +1,195 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// Base is the currency the exchange rates are quoted against, as in the ECB reference rates
+const Base = models.DefaultCurrency
+
+var (
+	// ErrInvalidCurrency is returned when a currency is not an ISO 4217 code such as EUR
+	ErrInvalidCurrency = errors.New("currency must be a 3 letter ISO 4217 code, such as EUR")
+	// ErrNoRate is returned when there is no exchange rate for a currency on or before a date
+	ErrNoRate = errors.New("there is no exchange rate for the currency on or before the date")
+)
+
+// Normalize returns the upper case code of a currency and validates it
+func Normalize(code string) (string, error) {
+
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+	}
+
+	for _, letter := range code {
+		if letter < 'A' || letter > 'Z' {
+			return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+		}
+	}
+
+	return code, nil
+}
+
+type datedRate struct {
+	date time.Time
+	rate *big.Rat // units of the currency for one unit of Base
+}
+
+// Rates is a table of exchange rates against Base, by currency and day.
+// The zero value has no rates and only converts between equal currencies.
+type Rates struct {
+	rates map[string][]datedRate // sorted by date
+}
+
+// NewRates creates an empty exchange rates table
+func NewRates() Rates {
+	return Rates{
+		rates: map[string][]datedRate{},
+	}
+}
+
+// Add sets the rate of a currency on a day, as units of the currency for one unit of Base
+func (r *Rates) Add(code string, date time.Time, rate string) error {
+
+	code, err := Normalize(code)
+	if err != nil {
+		return err
+	}
+
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(rate))
+	if !ok || value.Sign() <= 0 {
+		return fmt.Errorf("invalid %s exchange rate %q", code, rate)
+	}
+
+	if r.rates == nil {
+		r.rates = map[string][]datedRate{}
+	}
+
+	day := dayOf(date)
+	dated := r.rates[code]
+	idx := sort.Search(len(dated), func(i int) bool { return !dated[i].date.Before(day) })
+	if idx < len(dated) && dated[idx].date.Equal(day) {
+		dated[idx].rate = value
+		return nil
+	}
+
+	dated = append(dated, datedRate{})
+	copy(dated[idx+1:], dated[idx:])
+	dated[idx] = datedRate{date: day, rate: value}
+	r.rates[code] = dated
+
+	return nil
+}
+
+// Len returns the number of currencies with rates, not counting Base
+func (r Rates) Len() int {
+	return len(r.rates)
+}
+
+// Rate returns the rate of a currency against Base on a day.
+// Rates are not published on weekends and holidays, so the latest rate on or before the day is used.
+func (r Rates) Rate(code string, date time.Time) (*big.Rat, error) {
+
+	if code == Base {
+		return big.NewRat(1, 1), nil
+	}
+
+	day := dayOf(date)
+	dated := r.rates[code]
+	idx := sort.Search(len(dated), func(i int) bool { return dated[i].date.After(day) })
+	if idx == 0 {
+		return nil, fmt.Errorf("%w: %s on %s", ErrNoRate, code, day.Format("2006-01-02"))
+	}
+
+	return dated[idx-1].rate, nil
+}
+
+// Convert converts an amount between currencies at the rates of the provided date,
+// rounding half away from zero to the cent
+func (r Rates) Convert(amount models.Money, from string, to string, date time.Time) (models.Money, error) {
+
+	if from == to {
+		return amount, nil
+	}
+
+	fromRate, err := r.Rate(from, date)
+	if err != nil {
+		return 0, err
+	}
+
+	toRate, err := r.Rate(to, date)
+	if err != nil {
+		return 0, err
+	}
+
+	converted := new(big.Rat).SetInt64(int64(amount))
+	converted.Mul(converted, toRate)
+	converted.Quo(converted, fromRate)
+
+	return models.Money(round(converted)), nil
+}
+
+// ConvertExpenses converts the value of each expense to the provided currency at the rate of its date
+func (r Rates) ConvertExpenses(expenses []models.ExpenseView, to string) ([]models.ExpenseView, error) {
+
+	converted := make([]models.ExpenseView, 0, len(expenses))
+	for _, exp := range expenses {
+		value, err := r.Convert(exp.Value, exp.Currency, to, exp.Date)
+		if err != nil {
+			return []models.ExpenseView{}, err
+		}
+
+		exp.Value = value
+		exp.Currency = to
+		converted = append(converted, exp)
+	}
+
+	return converted, nil
+}
+
+// ConvertIncomes converts the value of each income to the provided currency at the rate of its date
+func (r Rates) ConvertIncomes(incomes []models.IncomeView, to string) ([]models.IncomeView, error) {
+
+	converted := make([]models.IncomeView, 0, len(incomes))
+	for _, inc := range incomes {
+		value, err := r.Convert(inc.Value, inc.Currency, to, inc.Date)
+		if err != nil {
+			return []models.IncomeView{}, err
+		}
+
+		inc.Value = value
+		inc.Currency = to
+		converted = append(converted, inc)
+	}
+
+	return converted, nil
+}
+
+func dayOf(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// round rounds a rational number half away from zero to an integer
+func round(value *big.Rat) int64 {
+
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+
+	// |remainder| / denom >= 1/2
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(value.Denom()) >= 0 {
+		if value.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient.Int64()
+}
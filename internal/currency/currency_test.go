@@ -0,0 +1,100 @@
+package currency
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNormalize(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		code    string
+		want    string
+		wantErr bool
+	}{
+		{name: "Upper case", code: "EUR", want: "EUR"},
+		{name: "Lower case with spaces", code: " usd ", want: "USD"},
+		{name: "Too short", code: "EU", wantErr: true},
+		{name: "Too long", code: "EURO", wantErr: true},
+		{name: "Not letters", code: "E1R", wantErr: true},
+		{name: "Empty", code: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.code)
+			assert.Equal(t, tt.wantErr, errors.Is(err, ErrInvalidCurrency))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRates_Convert(t *testing.T) {
+
+	rates := NewRates()
+	assert.NoError(t, rates.Add("USD", date(2024, time.January, 5), "1.0921"))
+	assert.NoError(t, rates.Add("USD", date(2024, time.January, 2), "1.0956"))
+	assert.NoError(t, rates.Add("GBP", date(2024, time.January, 2), "0.86518"))
+	assert.Error(t, rates.Add("GBP", date(2024, time.January, 3), "-1"))
+
+	tests := []struct {
+		name    string
+		amount  string
+		from    string
+		to      string
+		date    time.Time
+		want    string
+		wantErr error
+	}{
+		{name: "Same currency without rates", amount: "12.34", from: "JPY", to: "JPY", date: date(2024, time.January, 2), want: "12.34"},
+		{name: "From base", amount: "100", from: "EUR", to: "USD", date: date(2024, time.January, 2), want: "109.56"},
+		{name: "To base", amount: "109.56", from: "USD", to: "EUR", date: date(2024, time.January, 2), want: "100.00"},
+		{name: "Between two currencies", amount: "100", from: "GBP", to: "USD", date: date(2024, time.January, 2), want: "126.63"},
+		{name: "Rounds half away from zero", amount: "-0.05", from: "EUR", to: "USD", date: date(2024, time.January, 2), want: "-0.05"},
+		{name: "Latest rate before a weekend day", amount: "100", from: "EUR", to: "USD", date: date(2024, time.January, 6), want: "109.21"},
+		{name: "Rate of the day between rates", amount: "100", from: "EUR", to: "USD", date: date(2024, time.January, 4).Add(23 * time.Hour), want: "109.56"},
+		{name: "No rate before the first day", amount: "100", from: "EUR", to: "USD", date: date(2024, time.January, 1), wantErr: ErrNoRate},
+		{name: "Unknown currency", amount: "100", from: "EUR", to: "JPY", date: date(2024, time.January, 2), wantErr: ErrNoRate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rates.Convert(models.MustParseMoney(tt.amount), tt.from, tt.to, tt.date)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestRates_ConvertExpenses(t *testing.T) {
+
+	rates := NewRates()
+	assert.NoError(t, rates.Add("USD", date(2024, time.January, 2), "1.0956"))
+
+	expenses := []models.ExpenseView{
+		{ID: 1, Value: models.MustParseMoney("100"), Currency: "EUR", Date: date(2024, time.January, 2)},
+		{ID: 2, Value: models.MustParseMoney("10"), Currency: "USD", Date: date(2024, time.January, 3)},
+	}
+
+	converted, err := rates.ConvertExpenses(expenses, "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ExpenseView{
+		{ID: 1, Value: models.MustParseMoney("109.56"), Currency: "USD", Date: date(2024, time.January, 2)},
+		{ID: 2, Value: models.MustParseMoney("10"), Currency: "USD", Date: date(2024, time.January, 3)},
+	}, converted)
+	assert.Equal(t, "EUR", expenses[0].Currency)
+
+	_, err = rates.ConvertExpenses(expenses, "GBP")
+	assert.ErrorIs(t, err, ErrNoRate)
+}
@@ -0,0 +1,125 @@
+package currency
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const ecbDateLayout = "2006-01-02"
+
+// ecbEnvelope is the eurofxref XML published by the ECB, with a Cube per day holding a Cube per currency
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// ParseECBXML reads exchange rates from an ECB eurofxref XML file, such as eurofxref-hist.xml
+func ParseECBXML(r io.Reader) (Rates, error) {
+
+	var envelope ecbEnvelope
+	err := xml.NewDecoder(r).Decode(&envelope)
+	if err != nil {
+		return Rates{}, fmt.Errorf("could not decode exchange rates xml: %v", err)
+	}
+
+	rates := NewRates()
+	for _, day := range envelope.Days {
+		date, err := time.Parse(ecbDateLayout, day.Time)
+		if err != nil {
+			return Rates{}, fmt.Errorf("invalid exchange rates date %q: %v", day.Time, err)
+		}
+
+		for _, rate := range day.Rates {
+			err := rates.Add(rate.Currency, date, rate.Rate)
+			if err != nil {
+				return Rates{}, fmt.Errorf("exchange rates of %s: %v", day.Time, err)
+			}
+		}
+	}
+
+	return rates, nil
+}
+
+// ParseECBCSV reads exchange rates from an ECB eurofxref CSV file, such as eurofxref-hist.csv,
+// with a Date column followed by a column per currency. Missing rates are written as N/A or left empty.
+func ParseECBCSV(r io.Reader) (Rates, error) {
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return Rates{}, fmt.Errorf("could not read exchange rates csv header: %v", err)
+	}
+
+	if len(header) == 0 || !strings.EqualFold(strings.TrimSpace(header[0]), "Date") {
+		return Rates{}, fmt.Errorf("exchange rates csv must start with a Date column")
+	}
+
+	rates := NewRates()
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Rates{}, fmt.Errorf("could not read exchange rates csv line %d: %v", line, err)
+		}
+
+		date, err := time.Parse(ecbDateLayout, strings.TrimSpace(record[0]))
+		if err != nil {
+			return Rates{}, fmt.Errorf("invalid exchange rates date on line %d: %v", line, err)
+		}
+
+		for idx := 1; idx < len(record) && idx < len(header); idx++ {
+			code, rate := strings.TrimSpace(header[idx]), strings.TrimSpace(record[idx])
+			if code == "" || rate == "" || rate == "N/A" {
+				continue
+			}
+
+			err := rates.Add(code, date, rate)
+			if err != nil {
+				return Rates{}, fmt.Errorf("exchange rates csv line %d: %v", line, err)
+			}
+		}
+	}
+
+	return rates, nil
+}
+
+// RatesFromFile loads the exchange rates of the ECB XML or CSV file on the provided path,
+// telling them apart by the file extension.
+// If path is empty there are no rates, and only amounts in the same currency can be reported.
+func RatesFromFile(path string) (Rates, error) {
+
+	if path == "" {
+		return NewRates(), nil
+	}
+
+	file, err := os.Open(path) // nolint
+	if err != nil {
+		return Rates{}, fmt.Errorf("could not open exchange rates file: %v", err)
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xml":
+		return ParseECBXML(file)
+	case ".csv":
+		return ParseECBCSV(file)
+	default:
+		return Rates{}, fmt.Errorf("exchange rates file must be a .xml or .csv file, got %q", path)
+	}
+}
@@ -0,0 +1,101 @@
+package currency
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ecbXML = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time="2024-01-03">
+			<Cube currency="USD" rate="1.0919"/>
+			<Cube currency="GBP" rate="0.86278"/>
+		</Cube>
+		<Cube time="2024-01-02">
+			<Cube currency="USD" rate="1.0956"/>
+			<Cube currency="GBP" rate="0.86518"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+const ecbCSV = `Date, USD, JPY, GBP, CYP,
+2024-01-03, 1.0919, 155.25, 0.86278, N/A,
+2024-01-02, 1.0956, 155.67, 0.86518, N/A,
+`
+
+func TestParseECBXML(t *testing.T) {
+
+	rates, err := ParseECBXML(strings.NewReader(ecbXML))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rates.Len())
+
+	rate, err := rates.Rate("USD", date(2024, time.January, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0956", rate.FloatString(4))
+
+	rate, err = rates.Rate("GBP", date(2024, time.January, 4))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.86278", rate.FloatString(5))
+
+	_, err = ParseECBXML(strings.NewReader(`<Envelope><Cube><Cube time="03-01-2024"></Cube></Cube></Envelope>`))
+	assert.Error(t, err)
+}
+
+func TestParseECBCSV(t *testing.T) {
+
+	rates, err := ParseECBCSV(strings.NewReader(ecbCSV))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rates.Len())
+
+	rate, err := rates.Rate("JPY", date(2024, time.January, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, "155.25", rate.FloatString(2))
+
+	_, err = rates.Rate("CYP", date(2024, time.January, 3))
+	assert.ErrorIs(t, err, ErrNoRate)
+
+	_, err = ParseECBCSV(strings.NewReader("Currency,USD\n2024-01-02,1.0956\n"))
+	assert.Error(t, err)
+
+	_, err = ParseECBCSV(strings.NewReader("Date,USD\n2024-01-02,abc\n"))
+	assert.Error(t, err)
+}
+
+func TestRatesFromFile(t *testing.T) {
+
+	rates, err := RatesFromFile("")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, rates.Len())
+
+	dir := t.TempDir()
+
+	xmlPath := filepath.Join(dir, "eurofxref.xml")
+	assert.NoError(t, os.WriteFile(xmlPath, []byte(ecbXML), 0o600))
+	rates, err = RatesFromFile(xmlPath)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rates.Len())
+
+	csvPath := filepath.Join(dir, "eurofxref.csv")
+	assert.NoError(t, os.WriteFile(csvPath, []byte(ecbCSV), 0o600))
+	rates, err = RatesFromFile(csvPath)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rates.Len())
+
+	jsonPath := filepath.Join(dir, "eurofxref.json")
+	assert.NoError(t, os.WriteFile(jsonPath, []byte("{}"), 0o600))
+	_, err = RatesFromFile(jsonPath)
+	assert.Error(t, err)
+
+	_, err = RatesFromFile(filepath.Join(dir, "missing.xml"))
+	assert.Error(t, err)
+}
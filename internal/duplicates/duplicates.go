@@ -0,0 +1,162 @@
+package duplicates
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const (
+	// DefaultWindowDays is how many days before or after a new row an existing row may be to be a likely duplicate
+	DefaultWindowDays = 3
+	// DefaultMinSimilarity is the minimum similarity, from 0 to 1, of the descriptions of likely duplicates
+	DefaultMinSimilarity = 0.6
+)
+
+// Detector finds likely duplicates of new expenses and incomes:
+// rows of the same card with the same value, within a date window and with similar descriptions
+type Detector struct {
+	WindowDays    int
+	MinSimilarity float64
+}
+
+// NewDetector creates a Detector with the default window and similarity
+func NewDetector() Detector {
+	return Detector{
+		WindowDays:    DefaultWindowDays,
+		MinSimilarity: DefaultMinSimilarity,
+	}
+}
+
+// NewDetectorFromEnv creates a Detector configured by the DUPLICATES_WINDOW_DAYS and DUPLICATES_MIN_SIMILARITY env variables.
+// Unset variables keep their defaults.
+func NewDetectorFromEnv() (Detector, error) {
+
+	detector := NewDetector()
+
+	if windowDays := os.Getenv("DUPLICATES_WINDOW_DAYS"); windowDays != "" {
+		days, err := strconv.Atoi(windowDays)
+		if err != nil || days < 0 {
+			return Detector{}, fmt.Errorf("invalid DUPLICATES_WINDOW_DAYS %q", windowDays)
+		}
+		detector.WindowDays = days
+	}
+
+	if minSimilarity := os.Getenv("DUPLICATES_MIN_SIMILARITY"); minSimilarity != "" {
+		similarity, err := strconv.ParseFloat(minSimilarity, 64)
+		if err != nil || similarity < 0 || similarity > 1 {
+			return Detector{}, fmt.Errorf("invalid DUPLICATES_MIN_SIMILARITY %q", minSimilarity)
+		}
+		detector.MinSimilarity = similarity
+	}
+
+	return detector, nil
+}
+
+// DateWindow returns the first and last dates a likely duplicate of a row on the provided date may have
+func (d Detector) DateWindow(date time.Time) (time.Time, time.Time) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	return day.AddDate(0, 0, -d.WindowDays), day.AddDate(0, 0, d.WindowDays+1).Add(-time.Nanosecond)
+}
+
+// Expenses returns the candidates that are likely duplicates of the expense
+func (d Detector) Expenses(candidates []models.ExpenseView, expense models.ExpenseTable) []models.ExpenseView {
+
+	minDate, maxDate := d.DateWindow(expense.Date)
+
+	duplicates := []models.ExpenseView{}
+	for _, candidate := range candidates {
+		if candidate.CardID == expense.CardID &&
+			candidate.Value == expense.Value &&
+			!candidate.Date.Before(minDate) && !candidate.Date.After(maxDate) &&
+			d.SimilarDescriptions(candidate.Description, expense.Description) {
+			duplicates = append(duplicates, candidate)
+		}
+	}
+
+	return duplicates
+}
+
+// Incomes returns the candidates that are likely duplicates of the income
+func (d Detector) Incomes(candidates []models.IncomeView, income models.IncomeTable) []models.IncomeView {
+
+	minDate, maxDate := d.DateWindow(income.Date)
+
+	duplicates := []models.IncomeView{}
+	for _, candidate := range candidates {
+		if candidate.CardID == income.CardID &&
+			candidate.Value == income.Value &&
+			!candidate.Date.Before(minDate) && !candidate.Date.After(maxDate) &&
+			d.SimilarDescriptions(candidate.Description, income.Description) {
+			duplicates = append(duplicates, candidate)
+		}
+	}
+
+	return duplicates
+}
+
+// SimilarDescriptions tells if two descriptions are alike, ignoring case, punctuation and spacing.
+// A missing description is similar to any other, as nothing tells the rows apart.
+func (d Detector) SimilarDescriptions(a, b string) bool {
+
+	a, b = normalize(a), normalize(b)
+	if a == "" || b == "" || strings.Contains(a, b) || strings.Contains(b, a) {
+		return true
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	maxLength := len(ra)
+	if len(rb) > maxLength {
+		maxLength = len(rb)
+	}
+
+	similarity := 1 - float64(levenshtein(ra, rb))/float64(maxLength)
+
+	return similarity >= d.MinSimilarity
+}
+
+func normalize(description string) string {
+	fields := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// levenshtein is the edit distance between a and b
+func levenshtein(a, b []rune) int {
+
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func min(values ...int) int {
+	minimum := values[0]
+	for _, value := range values[1:] {
+		if value < minimum {
+			minimum = value
+		}
+	}
+	return minimum
+}
@@ -0,0 +1,96 @@
+package duplicates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var firstFebruary2020 = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+
+func TestDetector_SimilarDescriptions(t *testing.T) {
+
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{name: "Equal ignoring case and punctuation", a: "House Rent.", b: "house   rent", want: true},
+		{name: "Contained", a: "Supermarket", b: "Supermarket Lisbon", want: true},
+		{name: "Missing description", a: "", b: "Supermarket", want: true},
+		{name: "Typo", a: "Restaurant", b: "Restaurnat", want: true},
+		{name: "Different", a: "Restaurant", b: "Books", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewDetector().SimilarDescriptions(tt.a, tt.b))
+		})
+	}
+}
+
+func TestDetector_Expenses(t *testing.T) {
+
+	rent := models.ExpenseView{
+		ID:          1,
+		Value:       models.MustParseMoney("500"),
+		Date:        firstFebruary2020,
+		CardID:      1,
+		Description: "House Rent",
+	}
+
+	tests := []struct {
+		name    string
+		expense models.ExpenseTable
+		want    []models.ExpenseView
+	}{
+		{
+			name:    "Within the window",
+			expense: models.ExpenseTable{Value: models.MustParseMoney("500"), Date: firstFebruary2020.AddDate(0, 0, 3), CardID: 1, Description: "rent"},
+			want:    []models.ExpenseView{rent},
+		},
+		{
+			name:    "Outside the window",
+			expense: models.ExpenseTable{Value: models.MustParseMoney("500"), Date: firstFebruary2020.AddDate(0, 0, 4), CardID: 1, Description: "rent"},
+			want:    []models.ExpenseView{},
+		},
+		{
+			name:    "Other card",
+			expense: models.ExpenseTable{Value: models.MustParseMoney("500"), Date: firstFebruary2020, CardID: 2, Description: "House Rent"},
+			want:    []models.ExpenseView{},
+		},
+		{
+			name:    "Other value",
+			expense: models.ExpenseTable{Value: models.MustParseMoney("501"), Date: firstFebruary2020, CardID: 1, Description: "House Rent"},
+			want:    []models.ExpenseView{},
+		},
+		{
+			name:    "Other description",
+			expense: models.ExpenseTable{Value: models.MustParseMoney("500"), Date: firstFebruary2020, CardID: 1, Description: "Car insurance"},
+			want:    []models.ExpenseView{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewDetector().Expenses([]models.ExpenseView{rent}, tt.expense)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewDetectorFromEnv(t *testing.T) {
+
+	t.Setenv("DUPLICATES_WINDOW_DAYS", "5")
+	t.Setenv("DUPLICATES_MIN_SIMILARITY", "0.8")
+
+	detector, err := NewDetectorFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, Detector{WindowDays: 5, MinSimilarity: 0.8}, detector)
+
+	t.Setenv("DUPLICATES_MIN_SIMILARITY", "2")
+
+	_, err = NewDetectorFromEnv()
+	assert.Error(t, err)
+}
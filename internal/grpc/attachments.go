@@ -0,0 +1,201 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/attachments"
+	attachmentspb "github.com/rubengomes8/golang-personal-finances/internal/pb/attachments"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// downloadChunkSize is the largest chunk of content sent on each message of a download
+const downloadChunkSize = 64 << 10
+
+// Attachments implements attachments ServiceServer methods
+type Attachments struct {
+	attachmentspb.ServiceServer
+	Manager attachments.Manager
+}
+
+// NewAttachments creates a new Attachments service
+func NewAttachments(manager attachments.Manager) (Attachments, error) {
+	return Attachments{
+		Manager: manager,
+	}, nil
+}
+
+// Upload stores the content streamed after the metadata as an attachment of an expense or income
+func (a Attachments) Upload(stream attachmentspb.Service_UploadServer) error {
+
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		log.Printf("grpc - could not receive attachment metadata: %v", err)
+		return status.Error(codes.InvalidArgument, "the first message must have the attachment metadata")
+	}
+
+	metadata := req.GetMetadata()
+	if metadata == nil {
+		return status.Error(codes.InvalidArgument, "the first message must have the attachment metadata")
+	}
+	log.Printf("Upload was invoked with %v\n", metadata)
+
+	attachment, err := a.Manager.Upload(
+		ctx,
+		userIDFromContext(ctx),
+		attachments.Owner{ExpenseID: metadata.GetExpenseId(), IncomeID: metadata.GetIncomeId()},
+		metadata.GetFileName(),
+		metadata.GetContentType(),
+		&uploadReader{stream: stream},
+	)
+	if err != nil {
+		log.Printf("grpc - could not upload attachment: %v", err)
+		return attachmentError(err, "could not upload attachment")
+	}
+
+	return stream.SendAndClose(attachmentTableToAttachment(attachment))
+}
+
+// List gets the attachments of an expense or income
+func (a Attachments) List(ctx context.Context, req *attachmentspb.ListRequest) (*attachmentspb.ListResponse, error) {
+	log.Printf("List was invoked with %v\n", req)
+
+	attachmentRecords, err := a.Manager.List(
+		ctx,
+		userIDFromContext(ctx),
+		attachments.Owner{ExpenseID: req.GetExpenseId(), IncomeID: req.GetIncomeId()},
+	)
+	if err != nil {
+		log.Printf("grpc - could not list attachments: %v", err)
+		return &attachmentspb.ListResponse{}, attachmentError(err, "could not list attachments")
+	}
+
+	var responseAttachments []*attachmentspb.Attachment
+	for _, attachment := range attachmentRecords {
+		responseAttachments = append(responseAttachments, attachmentTableToAttachment(attachment))
+	}
+
+	return &attachmentspb.ListResponse{
+		Attachments: responseAttachments,
+	}, nil
+}
+
+// Download streams the metadata of an attachment followed by its content
+func (a Attachments) Download(req *attachmentspb.DownloadRequest, stream attachmentspb.Service_DownloadServer) error {
+	log.Printf("Download was invoked with %v\n", req)
+
+	ctx := stream.Context()
+
+	attachment, content, err := a.Manager.Open(ctx, userIDFromContext(ctx), req.GetId())
+	if errors.Is(err, repository.ErrNotFound) {
+		return status.Error(codes.NotFound, "attachment with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not open attachment: %v", err)
+		return fmt.Errorf("could not download attachment")
+	}
+	defer content.Close()
+
+	err = stream.Send(&attachmentspb.DownloadResponse{
+		Data: &attachmentspb.DownloadResponse_Attachment{Attachment: attachmentTableToAttachment(attachment)},
+	})
+	if err != nil {
+		return err
+	}
+
+	chunk := make([]byte, downloadChunkSize)
+	for {
+		n, err := content.Read(chunk)
+		if n > 0 {
+			sendErr := stream.Send(&attachmentspb.DownloadResponse{
+				Data: &attachmentspb.DownloadResponse_Chunk{Chunk: chunk[:n]},
+			})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			log.Printf("grpc - could not read attachment content: %v", err)
+			return fmt.Errorf("could not download attachment")
+		}
+	}
+}
+
+// Delete deletes an attachment and its content
+func (a Attachments) Delete(ctx context.Context, req *attachmentspb.DeleteRequest) (*attachmentspb.DeleteResponse, error) {
+	log.Printf("Delete was invoked with %v\n", req)
+
+	err := a.Manager.Delete(ctx, userIDFromContext(ctx), req.GetId())
+	if errors.Is(err, repository.ErrNotFound) {
+		return &attachmentspb.DeleteResponse{}, status.Error(codes.NotFound, "attachment with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not delete attachment: %v", err)
+		return &attachmentspb.DeleteResponse{}, fmt.Errorf("could not delete attachment")
+	}
+
+	return &attachmentspb.DeleteResponse{}, nil
+}
+
+// uploadReader reads the content of an upload from the chunks of its stream
+type uploadReader struct {
+	stream attachmentspb.Service_UploadServer
+	chunk  []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+
+	for len(r.chunk) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if req.GetMetadata() != nil {
+			return 0, fmt.Errorf("%w: only the first message can have the metadata", attachments.ErrInvalidAttachment)
+		}
+		r.chunk = req.GetChunk()
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+
+	return n, nil
+}
+
+func attachmentError(err error, msg string) error {
+	switch {
+	case errors.Is(err, attachments.ErrInvalidAttachment):
+		return status.Error(codes.InvalidArgument, "attachment must set a file name, a valid content type and either an expense or an income")
+	case errors.Is(err, attachments.ErrTooLarge):
+		return status.Error(codes.InvalidArgument, "attachment is larger than the max size")
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Error(codes.NotFound, "expense or income with this id does not exist")
+	default:
+		return errors.New(msg)
+	}
+}
+
+func attachmentTableToAttachment(attachment models.AttachmentTable) *attachmentspb.Attachment {
+	return &attachmentspb.Attachment{
+		Id:          attachment.ID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Checksum:    attachment.Checksum,
+		CreatedAt:   timestamppb.New(attachment.CreatedAt),
+		ExpenseId:   attachment.ExpenseID,
+		IncomeId:    attachment.IncomeID,
+	}
+}
@@ -0,0 +1,154 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/attachments"
+	attachmentspb "github.com/rubengomes8/golang-personal-finances/internal/pb/attachments"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeUploadStream struct {
+	grpc.ServerStream
+	requests []*attachmentspb.UploadRequest
+	response *attachmentspb.Attachment
+}
+
+func (s *fakeUploadStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeUploadStream) Recv() (*attachmentspb.UploadRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *fakeUploadStream) SendAndClose(response *attachmentspb.Attachment) error {
+	s.response = response
+	return nil
+}
+
+type fakeDownloadStream struct {
+	grpc.ServerStream
+	responses []*attachmentspb.DownloadResponse
+}
+
+func (s *fakeDownloadStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeDownloadStream) Send(response *attachmentspb.DownloadResponse) error {
+	s.responses = append(s.responses, response)
+	return nil
+}
+
+func newTestAttachments(t *testing.T) Attachments {
+
+	storage, err := attachments.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	attachmentsCache := cache.NewAttachment([]models.AttachmentTable{})
+
+	handlers, err := NewAttachments(attachments.NewManager(&attachmentsCache, storage))
+	assert.NoError(t, err)
+
+	return handlers
+}
+
+func uploadChunk(chunk string) *attachmentspb.UploadRequest {
+	return &attachmentspb.UploadRequest{
+		Data: &attachmentspb.UploadRequest_Chunk{Chunk: []byte(chunk)},
+	}
+}
+
+func TestAttachments_UploadAndDownload(t *testing.T) {
+
+	handlers := newTestAttachments(t)
+
+	upload := &fakeUploadStream{
+		requests: []*attachmentspb.UploadRequest{
+			{Data: &attachmentspb.UploadRequest_Metadata{Metadata: &attachmentspb.UploadMetadata{ExpenseId: 1, FileName: "receipt.txt"}}},
+			uploadChunk("Pizza "),
+			uploadChunk("25.50"),
+		},
+	}
+
+	err := handlers.Upload(upload)
+	assert.NoError(t, err)
+	assert.Equal(t, "receipt.txt", upload.response.FileName)
+	assert.Equal(t, "text/plain; charset=utf-8", upload.response.ContentType)
+	assert.Equal(t, int64(11), upload.response.Size)
+
+	list, err := handlers.List(context.Background(), &attachmentspb.ListRequest{ExpenseId: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(list.Attachments))
+
+	download := &fakeDownloadStream{}
+	err = handlers.Download(&attachmentspb.DownloadRequest{Id: upload.response.Id}, download)
+	assert.NoError(t, err)
+	assert.Equal(t, upload.response.Checksum, download.responses[0].GetAttachment().Checksum)
+
+	content := bytes.Buffer{}
+	for _, response := range download.responses[1:] {
+		content.Write(response.GetChunk())
+	}
+	assert.Equal(t, "Pizza 25.50", content.String())
+
+	_, err = handlers.Delete(context.Background(), &attachmentspb.DeleteRequest{Id: upload.response.Id})
+	assert.NoError(t, err)
+
+	err = handlers.Download(&attachmentspb.DownloadRequest{Id: upload.response.Id}, &fakeDownloadStream{})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestAttachments_UploadInvalid(t *testing.T) {
+
+	handlers := newTestAttachments(t)
+
+	tests := []struct {
+		name     string
+		requests []*attachmentspb.UploadRequest
+		code     codes.Code
+	}{
+		{
+			name:     "ErrorMissingMetadata",
+			requests: []*attachmentspb.UploadRequest{uploadChunk("Pizza")},
+			code:     codes.InvalidArgument,
+		},
+		{
+			name: "ErrorMissingOwner",
+			requests: []*attachmentspb.UploadRequest{
+				{Data: &attachmentspb.UploadRequest_Metadata{Metadata: &attachmentspb.UploadMetadata{FileName: "receipt.txt"}}},
+				uploadChunk("Pizza"),
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "ErrorMetadataAfterContent",
+			requests: []*attachmentspb.UploadRequest{
+				{Data: &attachmentspb.UploadRequest_Metadata{Metadata: &attachmentspb.UploadMetadata{IncomeId: 1, FileName: "receipt.txt"}}},
+				uploadChunk("Pizza"),
+				{Data: &attachmentspb.UploadRequest_Metadata{Metadata: &attachmentspb.UploadMetadata{IncomeId: 1, FileName: "receipt.txt"}}},
+			},
+			code: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handlers.Upload(&fakeUploadStream{requests: tt.requests})
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
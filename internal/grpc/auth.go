@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const authorizationMetadataKey = "authorization"
+
+type userIDContextKey struct{}
+
+// AuthInterceptor validates the bearer token sent on the authorization metadata and its session
+// and stores the id of the authenticated user on the request context
+func AuthInterceptor(sessions auth.Sessions) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+
+		userID, err := sessions.Authenticate(ctx, extractToken(ctx))
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "unauthorized")
+		}
+
+		return handler(context.WithValue(ctx, userIDContextKey{}, userID), req)
+	}
+}
+
+// AdminInterceptor only lets the admins call the methods, given by their full names, such as
+// "/expense_categories.ExpenseCategoryService/MergeExpenseCategory". It must follow AuthInterceptor.
+func AdminInterceptor(userRepo repository.UserRepo, methods ...string) grpc.UnaryServerInterceptor {
+
+	adminMethods := map[string]bool{}
+	for _, method := range methods {
+		adminMethods[method] = true
+	}
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+
+		if !adminMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		err := auth.RequireAdmin(ctx, userRepo, userIDFromContext(ctx))
+		if errors.Is(err, auth.ErrNotAdmin) {
+			return nil, status.Error(codes.PermissionDenied, "forbidden")
+		}
+		if err != nil {
+			log.Printf("grpc - could not check if user is admin: %v", err)
+			return nil, status.Error(codes.Internal, "could not check permissions")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor is the AuthInterceptor of the streaming calls
+func AuthStreamInterceptor(sessions auth.Sessions) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+
+		userID, err := sessions.Authenticate(stream.Context(), extractToken(stream.Context()))
+		if err != nil {
+			return status.Error(codes.Unauthenticated, "unauthorized")
+		}
+
+		return handler(srv, authenticatedStream{
+			ServerStream: stream,
+			ctx:          context.WithValue(stream.Context(), userIDContextKey{}, userID),
+		})
+	}
+}
+
+// authenticatedStream is a server stream whose context carries the id of the authenticated user
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream with the id of the authenticated user
+func (s authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func extractToken(ctx context.Context) string {
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+
+	bearerToken := strings.Split(values[0], " ")
+	if len(bearerToken) == 2 {
+		return bearerToken[1]
+	}
+
+	return ""
+}
+
+// userIDFromContext returns the authenticated user id or zero, which owns no records
+func userIDFromContext(ctx context.Context) int64 {
+	userID, _ := ctx.Value(userIDContextKey{}).(int64)
+	return userID
+}
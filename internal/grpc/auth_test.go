@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminInterceptor(t *testing.T) {
+
+	userRepo := cache.NewUser()
+	adminID, err := userRepo.InsertUser(context.Background(), models.UserTable{Username: "admin", Admin: true})
+	assert.NoError(t, err)
+	userID, err := userRepo.InsertUser(context.Background(), models.UserTable{Username: "alice"})
+	assert.NoError(t, err)
+
+	const mergeMethod = "/expense_categories.ExpenseCategoryService/MergeExpenseCategory"
+	interceptor := AdminInterceptor(&userRepo, mergeMethod)
+
+	tests := []struct {
+		name     string
+		userID   int64
+		method   string
+		wantCode codes.Code
+	}{
+		{name: "Admin", userID: adminID, method: mergeMethod, wantCode: codes.OK},
+		{name: "Not an admin", userID: userID, method: mergeMethod, wantCode: codes.PermissionDenied},
+		{name: "Not an admin method", userID: userID, method: "/expense_categories.ExpenseCategoryService/CreateExpenseCategory", wantCode: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), userIDContextKey{}, tt.userID)
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					return nil, nil
+				},
+			)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/balances"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	balancespb "github.com/rubengomes8/golang-personal-finances/internal/pb/balances"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Balances implements balances ServiceServer methods
+type Balances struct {
+	balancespb.ServiceServer
+	Calculator balances.Calculator
+}
+
+// NewBalances creates a new Balances service
+func NewBalances(calculator balances.Calculator) (Balances, error) {
+	return Balances{
+		Calculator: calculator,
+	}, nil
+}
+
+// SetOpeningBalance sets the balance of a card on its opening date
+func (b Balances) SetOpeningBalance(
+	ctx context.Context,
+	req *balancespb.SetOpeningBalanceRequest,
+) (*balancespb.SetOpeningBalanceResponse, error) {
+	log.Printf("SetOpeningBalance was invoked with %v\n", req)
+
+	openingBalance, err := models.ParseMoney(req.GetOpeningBalance())
+	if err != nil {
+		return &balancespb.SetOpeningBalanceResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var openingDate time.Time
+	if req.GetOpeningDate() != nil {
+		openingDate = req.GetOpeningDate().AsTime()
+	}
+
+	err = b.Calculator.SetOpeningBalance(ctx, userIDFromContext(ctx), req.GetCard(), openingBalance, openingDate)
+	if err != nil {
+		log.Printf("grpc - could not set opening balance: %v", err)
+		return &balancespb.SetOpeningBalanceResponse{}, balanceError(err, "could not set opening balance")
+	}
+
+	return &balancespb.SetOpeningBalanceResponse{}, nil
+}
+
+// GetBalance gets the balance of a card as of a date, with the running balance after each of its movements
+func (b Balances) GetBalance(
+	ctx context.Context,
+	req *balancespb.GetBalanceRequest,
+) (*balancespb.GetBalanceResponse, error) {
+	log.Printf("GetBalance was invoked with %v\n", req)
+
+	if req.GetDate() == nil {
+		return &balancespb.GetBalanceResponse{}, status.Error(codes.InvalidArgument, "missing date")
+	}
+
+	balance, err := b.Calculator.Balance(ctx, userIDFromContext(ctx), req.GetCard(), req.GetDate().AsTime())
+	if err != nil {
+		log.Printf("grpc - could not get balance: %v", err)
+		return &balancespb.GetBalanceResponse{}, balanceError(err, "could not get balance")
+	}
+
+	return &balancespb.GetBalanceResponse{
+		Card:           balance.Card.Name,
+		Currency:       balance.Card.Currency,
+		Date:           timestamppb.New(balance.Date),
+		OpeningBalance: balance.Card.OpeningBalance.String(),
+		Balance:        balance.Balance.String(),
+		ClearedBalance: balance.ClearedBalance.String(),
+		Movements:      movementsToResponse(balance.Movements),
+	}, nil
+}
+
+// Reconcile marks movements of a card as cleared and checks a bank statement balance against its cleared balance
+func (b Balances) Reconcile(
+	ctx context.Context,
+	req *balancespb.ReconcileRequest,
+) (*balancespb.Reconciliation, error) {
+	log.Printf("Reconcile was invoked with %v\n", req)
+
+	if req.GetDate() == nil {
+		return &balancespb.Reconciliation{}, status.Error(codes.InvalidArgument, "missing date")
+	}
+
+	statementBalance, err := models.ParseMoney(req.GetStatementBalance())
+	if err != nil {
+		return &balancespb.Reconciliation{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	cleared := []models.MovementRef{}
+	for _, ref := range req.GetCleared() {
+		cleared = append(cleared, models.MovementRef{
+			Kind: ref.GetKind(),
+			ID:   ref.GetId(),
+		})
+	}
+
+	reconciliation, err := b.Calculator.Reconcile(
+		ctx,
+		userIDFromContext(ctx),
+		req.GetCard(),
+		req.GetDate().AsTime(),
+		statementBalance,
+		cleared,
+	)
+	if err != nil {
+		log.Printf("grpc - could not reconcile: %v", err)
+		return &balancespb.Reconciliation{}, balanceError(err, "could not reconcile")
+	}
+
+	return reconciliationToResponse(reconciliation), nil
+}
+
+// GetReconciliations gets the past reconciliations of a card, the latest first
+func (b Balances) GetReconciliations(
+	ctx context.Context,
+	req *balancespb.GetReconciliationsRequest,
+) (*balancespb.GetReconciliationsResponse, error) {
+	log.Printf("GetReconciliations was invoked with %v\n", req)
+
+	reconciliations, err := b.Calculator.Reconciliations(ctx, userIDFromContext(ctx), req.GetCard())
+	if err != nil {
+		log.Printf("grpc - could not get reconciliations: %v", err)
+		return &balancespb.GetReconciliationsResponse{}, balanceError(err, "could not get reconciliations")
+	}
+
+	var responseReconciliations []*balancespb.Reconciliation
+	for _, reconciliation := range reconciliations {
+		responseReconciliations = append(responseReconciliations, reconciliationToResponse(reconciliation))
+	}
+
+	return &balancespb.GetReconciliationsResponse{
+		Reconciliations: responseReconciliations,
+	}, nil
+}
+
+// balanceError returns a NotFound status for an unknown card, an InvalidArgument status for an invalid reconciliation
+// or a missing exchange rate, and an internal error otherwise
+func balanceError(err error, msg string) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return status.Error(codes.NotFound, "card with this name does not exist")
+	}
+	if errors.Is(err, balances.ErrInvalidReconciliation) || errors.Is(err, currency.ErrNoRate) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return errors.New(msg)
+}
+
+func movementsToResponse(movements []balances.Movement) []*balancespb.Movement {
+
+	var response []*balancespb.Movement
+	for _, movement := range movements {
+		response = append(response, &balancespb.Movement{
+			Kind:        movement.Kind,
+			Id:          movement.ID,
+			Date:        timestamppb.New(movement.Date),
+			Amount:      movement.Amount.String(),
+			Description: movement.Description,
+			Cleared:     movement.Cleared,
+			Balance:     movement.Balance.String(),
+		})
+	}
+
+	return response
+}
+
+func reconciliationToResponse(reconciliation balances.Reconciliation) *balancespb.Reconciliation {
+	return &balancespb.Reconciliation{
+		Id:               reconciliation.ID,
+		Card:             reconciliation.Card.Name,
+		Date:             timestamppb.New(reconciliation.Date),
+		StatementBalance: reconciliation.StatementBalance.String(),
+		ClearedBalance:   reconciliation.ClearedBalance.String(),
+		Difference:       reconciliation.Difference.String(),
+		Uncleared:        movementsToResponse(reconciliation.Uncleared),
+	}
+}
@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/balances"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	balancespb "github.com/rubengomes8/golang-personal-finances/internal/pb/balances"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBalances(t *testing.T) {
+
+	balanceCache := cache.NewBalance(map[int64][]models.CardMovement{
+		1: {
+			{Kind: models.MovementIncome, ID: 1, Date: firstFebruary2020ZeroHoursUTCTime, Amount: models.MustParseMoney("1500"), Currency: "EUR"},
+			{Kind: models.MovementTransferOut, ID: 1, Date: firstFebruary2020ZeroHoursUTCTime, Amount: models.MustParseMoney("-150.50"), Currency: "EUR"},
+		},
+	})
+
+	s := &Balances{
+		Calculator: balances.NewCalculator(
+			cache.NewCard([]models.CardTable{{ID: 1, Name: "CGD", Currency: "EUR"}}),
+			&balanceCache,
+			currency.NewRates(),
+		),
+	}
+
+	balance, err := s.GetBalance(context.Background(), &balancespb.GetBalanceRequest{
+		Card: "CGD",
+		Date: timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "1349.50", balance.Balance)
+	assert.Equal(t, "0.00", balance.ClearedBalance)
+	assert.Equal(t, 2, len(balance.Movements))
+
+	reconciliation, err := s.Reconcile(context.Background(), &balancespb.ReconcileRequest{
+		Card:             "CGD",
+		Date:             timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+		StatementBalance: "1349.50",
+		Cleared:          []*balancespb.MovementRef{{Kind: models.MovementIncome, Id: 1}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "1500.00", reconciliation.ClearedBalance)
+	assert.Equal(t, "-150.50", reconciliation.Difference)
+	assert.Equal(t, 1, len(reconciliation.Uncleared))
+
+	_, err = s.Reconcile(context.Background(), &balancespb.ReconcileRequest{
+		Card:             "CGD",
+		Date:             timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+		StatementBalance: "1349.50",
+		Cleared:          []*balancespb.MovementRef{{Kind: models.MovementExpense, Id: 1}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.GetBalance(context.Background(), &balancespb.GetBalanceRequest{
+		Card: "Unknown",
+		Date: timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,235 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/budgets"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	budgetspb "github.com/rubengomes8/golang-personal-finances/internal/pb/budgets"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Budgets implements budgets ServiceServer methods
+type Budgets struct {
+	budgetspb.ServiceServer
+	Repository            repository.BudgetRepo
+	CategoryRepository    repository.ExpenseCategoryRepo
+	SubCategoryRepository repository.ExpenseSubCategoryRepo
+	Reporter              budgets.Reporter
+}
+
+// NewBudgets creates a new Budgets service
+func NewBudgets(
+	budgetRepo repository.BudgetRepo,
+	expCatRepo repository.ExpenseCategoryRepo,
+	expSubCatRepo repository.ExpenseSubCategoryRepo,
+	rates currency.Rates,
+) (Budgets, error) {
+	return Budgets{
+		Repository:            budgetRepo,
+		CategoryRepository:    expCatRepo,
+		SubCategoryRepository: expSubCatRepo,
+		Reporter:              budgets.NewReporter(budgetRepo, rates),
+	}, nil
+}
+
+// Create creates a budget on the database
+func (b Budgets) Create(ctx context.Context, req *budgetspb.Budget) (*budgetspb.CreateResponse, error) {
+	log.Printf("Create was invoked with %v\n", req)
+
+	budgetRecord, err := b.toBudgetRecord(ctx, userIDFromContext(ctx), req)
+	if err != nil {
+		log.Printf("grpc - could not get budget record: %v", err)
+		return &budgetspb.CreateResponse{}, status.Error(codes.InvalidArgument, budgetErrorMsg(err))
+	}
+
+	id, err := b.Repository.InsertBudget(ctx, budgetRecord)
+	if err != nil {
+		log.Printf("grpc - could not insert budget: %v", err)
+		return &budgetspb.CreateResponse{}, fmt.Errorf("could not insert budget")
+	}
+
+	return &budgetspb.CreateResponse{
+		Id: id,
+	}, nil
+}
+
+// Update updates a budget on the database
+func (b Budgets) Update(ctx context.Context, req *budgetspb.Budget) (*budgetspb.UpdateResponse, error) {
+	log.Printf("Update was invoked with %v\n", req)
+
+	budgetRecord, err := b.toBudgetRecord(ctx, userIDFromContext(ctx), req)
+	if err != nil {
+		log.Printf("grpc - could not get budget record: %v", err)
+		return &budgetspb.UpdateResponse{}, status.Error(codes.InvalidArgument, budgetErrorMsg(err))
+	}
+
+	budgetRecord.ID = req.Id
+
+	id, err := b.Repository.UpdateBudget(ctx, budgetRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &budgetspb.UpdateResponse{}, status.Error(codes.NotFound, "budget with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not update budget: %v", err)
+		return &budgetspb.UpdateResponse{}, fmt.Errorf("could not update budget")
+	}
+
+	return &budgetspb.UpdateResponse{
+		Id: id,
+	}, nil
+}
+
+// Get gets a budget from the database that matches the id provided
+func (b Budgets) Get(ctx context.Context, req *budgetspb.GetRequest) (*budgetspb.Budget, error) {
+	log.Printf("Get was invoked with %v\n", req)
+
+	budgetView, err := b.Repository.GetBudgetByID(ctx, userIDFromContext(ctx), req.Id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &budgetspb.Budget{}, status.Error(codes.NotFound, "budget with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not get budget by id: %v", err)
+		return &budgetspb.Budget{}, fmt.Errorf("could not get budget by id")
+	}
+
+	return budgetViewToBudget(budgetView), nil
+}
+
+// GetSeveral gets the budgets from the database
+func (b Budgets) GetSeveral(ctx context.Context, req *budgetspb.GetSeveralRequest) (*budgetspb.GetSeveralResponse, error) {
+	log.Printf("GetSeveral was invoked with %v\n", req)
+
+	budgetViews, err := b.Repository.GetBudgets(ctx, userIDFromContext(ctx))
+	if err != nil {
+		log.Printf("grpc - could not get budgets: %v", err)
+		return &budgetspb.GetSeveralResponse{}, fmt.Errorf("could not get budgets")
+	}
+
+	var responseBudgets []*budgetspb.Budget
+	for _, budgetView := range budgetViews {
+		responseBudgets = append(responseBudgets, budgetViewToBudget(budgetView))
+	}
+
+	return &budgetspb.GetSeveralResponse{
+		Budgets: responseBudgets,
+	}, nil
+}
+
+// Delete deletes a budget from the database that matches the id provided
+func (b Budgets) Delete(ctx context.Context, req *budgetspb.DeleteRequest) (*budgetspb.DeleteResponse, error) {
+	log.Printf("Delete was invoked with %v\n", req)
+
+	err := b.Repository.DeleteBudget(ctx, userIDFromContext(ctx), req.Id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &budgetspb.DeleteResponse{}, status.Error(codes.NotFound, "budget with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not delete budget: %v", err)
+		return &budgetspb.DeleteResponse{}, fmt.Errorf("could not delete budget")
+	}
+
+	return &budgetspb.DeleteResponse{}, nil
+}
+
+// Report gets the budgeted, spent and remaining amounts of the budgets that apply to the month provided
+func (b Budgets) Report(ctx context.Context, req *budgetspb.ReportRequest) (*budgetspb.ReportResponse, error) {
+	log.Printf("Report was invoked with %v\n", req)
+
+	month := budgets.MonthStart(req.Month.AsTime())
+
+	statuses, err := b.Reporter.Report(ctx, userIDFromContext(ctx), month)
+	if errors.Is(err, currency.ErrNoRate) {
+		return &budgetspb.ReportResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		log.Printf("grpc - could not get budgets report: %v", err)
+		return &budgetspb.ReportResponse{}, fmt.Errorf("could not get budgets report")
+	}
+
+	var responseStatuses []*budgetspb.Status
+	for _, budgetStatus := range statuses {
+		responseStatuses = append(responseStatuses, &budgetspb.Status{
+			Budget:     budgetViewToBudget(budgetStatus.Budget),
+			RolledOver: budgetStatus.RolledOver.String(),
+			Budgeted:   budgetStatus.Budgeted.String(),
+			Spent:      budgetStatus.Spent.String(),
+			Remaining:  budgetStatus.Remaining.String(),
+			Overspent:  budgetStatus.Overspent,
+		})
+	}
+
+	return &budgetspb.ReportResponse{
+		Month:   timestamppb.New(month),
+		Budgets: responseStatuses,
+	}, nil
+}
+
+// toBudgetRecord resolves the category or subcategory name of a budget and validates it
+func (b Budgets) toBudgetRecord(ctx context.Context, userID int64, budget *budgetspb.Budget) (models.BudgetTable, error) {
+
+	limit, err := models.ParseMoney(budget.GetLimit())
+	if err != nil {
+		return models.BudgetTable{}, fmt.Errorf("%w: %v", budgets.ErrInvalidBudget, err)
+	}
+
+	budgetRecord := models.BudgetTable{
+		MonthLimit: limit,
+		Rollover:   budget.GetRollover(),
+		StartMonth: budgets.MonthStart(time.Now()),
+		UserID:     userID,
+	}
+
+	if budget.GetStartMonth() != nil {
+		budgetRecord.StartMonth = budgets.MonthStart(budget.GetStartMonth().AsTime())
+	}
+
+	if budget.GetCategory() != "" {
+		category, err := b.CategoryRepository.GetExpenseCategoryByName(ctx, budget.GetCategory())
+		if err != nil {
+			return models.BudgetTable{}, fmt.Errorf("could not get expense category by name: %v", err)
+		}
+		budgetRecord.CategoryID = category.ID
+	}
+
+	if budget.GetSubCategory() != "" {
+		subCategory, err := b.SubCategoryRepository.GetExpenseSubCategoryByName(ctx, budget.GetSubCategory())
+		if err != nil {
+			return models.BudgetTable{}, fmt.Errorf("could not get expense sub category by name: %v", err)
+		}
+		budgetRecord.SubCategoryID = subCategory.ID
+	}
+
+	err = budgets.Validate(budgetRecord)
+	if err != nil {
+		return models.BudgetTable{}, err
+	}
+
+	return budgetRecord, nil
+}
+
+func budgetErrorMsg(err error) string {
+	if errors.Is(err, budgets.ErrInvalidBudget) {
+		return "budget must set either a category or a sub_category and a decimal limit >= 0"
+	}
+	return "category or subcategory does not exist"
+}
+
+func budgetViewToBudget(budgetView models.BudgetView) *budgetspb.Budget {
+	return &budgetspb.Budget{
+		Id:          budgetView.ID,
+		Category:    budgetView.Category,
+		SubCategory: budgetView.SubCategory,
+		Limit:       budgetView.MonthLimit.String(),
+		Rollover:    budgetView.Rollover,
+		StartMonth:  timestamppb.New(budgetView.StartMonth),
+	}
+}
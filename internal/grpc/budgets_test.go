@@ -0,0 +1,144 @@
+package grpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	budgetspb "github.com/rubengomes8/golang-personal-finances/internal/pb/budgets"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBudgets_Create(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		req *budgetspb.Budget
+	}
+
+	type want struct {
+		response *budgetspb.CreateResponse
+		code     codes.Code
+	}
+
+	budgetsCache := cache.NewBudget([]models.BudgetView{}, []models.MonthlySpending{})
+
+	tests := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			args: args{
+				ctx: context.Background(),
+				req: &budgetspb.Budget{
+					Category:   "House",
+					Limit:      "500.00",
+					Rollover:   true,
+					StartMonth: timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+				},
+			},
+			want: want{
+				response: &budgetspb.CreateResponse{
+					Id: 1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorUnknownSubCategory",
+			args: args{
+				ctx: context.Background(),
+				req: &budgetspb.Budget{
+					SubCategory: "Unknown",
+					Limit:       "500.00",
+				},
+			},
+			want: want{
+				code: codes.InvalidArgument,
+			},
+			wantErr: true,
+		},
+		{
+			name: "ErrorCategoryAndSubCategory",
+			args: args{
+				ctx: context.Background(),
+				req: &budgetspb.Budget{
+					Category:    "House",
+					SubCategory: "Rent",
+					Limit:       "500.00",
+				},
+			},
+			want: want{
+				code: codes.InvalidArgument,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &Budgets{
+				Repository:            &budgetsCache,
+				CategoryRepository:    &categoriesCache,
+				SubCategoryRepository: &subCategoriesCache,
+			}
+
+			got, err := s.Create(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Budgets.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("Budgets.Create() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Equal(t, tt.want.code, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestBudgets_Report(t *testing.T) {
+
+	january2020 := firstFebruary2020ZeroHoursUTCTime.AddDate(0, -1, 0)
+
+	budgetsCache := cache.NewBudget(
+		[]models.BudgetView{
+			{ID: 1, SubCategoryID: 1, SubCategory: "Rent", MonthLimit: models.MustParseMoney("500"), Rollover: true, StartMonth: january2020},
+		},
+		[]models.MonthlySpending{
+			{Month: january2020, CategoryID: 1, SubCategoryID: 1, Currency: "EUR", Value: models.MustParseMoney("450")},
+			{Month: firstFebruary2020ZeroHoursUTCTime, CategoryID: 1, SubCategoryID: 1, Currency: "EUR", Value: models.MustParseMoney("520")},
+		},
+	)
+
+	budgetsHandlers, err := NewBudgets(&budgetsCache, &categoriesCache, &subCategoriesCache, currency.NewRates())
+	assert.NoError(t, err)
+
+	got, err := budgetsHandlers.Report(context.Background(), &budgetspb.ReportRequest{
+		Month: timestamppb.New(firstFebruary2020ZeroHoursUTCTime.Add(36 * time.Hour)),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, firstFebruary2020ZeroHoursUTCTime, got.Month.AsTime())
+	assert.Len(t, got.Budgets, 1)
+	assert.Equal(t, "Rent", got.Budgets[0].Budget.SubCategory)
+	assert.Equal(t, "50.00", got.Budgets[0].RolledOver)
+	assert.Equal(t, "550.00", got.Budgets[0].Budgeted)
+	assert.Equal(t, "520.00", got.Budgets[0].Spent)
+	assert.Equal(t, "30.00", got.Budgets[0].Remaining)
+	assert.False(t, got.Budgets[0].Overspent)
+}
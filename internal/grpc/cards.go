@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	cardspb "github.com/rubengomes8/golang-personal-finances/internal/pb/cards"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Cards implements CardServiceServer methods
+type Cards struct {
+	cardspb.CardServiceServer
+	CardRepository repository.CardRepo
+}
+
+// NewCards creates a new Cards service
+func NewCards(cardRepo repository.CardRepo) (Cards, error) {
+	return Cards{
+		CardRepository: cardRepo,
+	}, nil
+}
+
+// CreateCard creates a card on the database
+func (c Cards) CreateCard(
+	ctx context.Context,
+	req *cardspb.CardCreateRequest,
+) (*cardspb.CardCreateResponse, error) {
+
+	cardCurrency := models.DefaultCurrency
+	if req.Currency != "" {
+		var err error
+		cardCurrency, err = currency.Normalize(req.Currency)
+		if err != nil {
+			return &cardspb.CardCreateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	cardRecord := models.CardTable{
+		Name:     req.Name,
+		Currency: cardCurrency,
+		UserID:   userIDFromContext(ctx),
+	}
+
+	if req.OpeningBalance != "" {
+		openingBalance, err := models.ParseMoney(req.OpeningBalance)
+		if err != nil {
+			return &cardspb.CardCreateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+		cardRecord.OpeningBalance = openingBalance
+	}
+
+	if req.OpeningDate != nil {
+		cardRecord.OpeningDate = req.OpeningDate.AsTime()
+	}
+
+	id, err := c.CardRepository.InsertCard(ctx, cardRecord)
+	if err != nil {
+		log.Printf("grpc - could not insert card: %v", err)
+		return &cardspb.CardCreateResponse{}, fmt.Errorf("could not insert card")
+	}
+
+	return &cardspb.CardCreateResponse{
+		Id: id,
+	}, nil
+}
+
+// GetCardByName gets a card from the database that matches the name provided
+func (c Cards) GetCardByName(
+	ctx context.Context,
+	req *cardspb.CardGetRequestByName,
+) (*cardspb.CardGetResponse, error) {
+
+	card, err := c.CardRepository.GetCardByName(ctx, userIDFromContext(ctx), req.Name)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &cardspb.CardGetResponse{}, status.Error(codes.NotFound, "card with this name does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not get card by name: %v", err)
+		return &cardspb.CardGetResponse{}, fmt.Errorf("could not get card by name")
+	}
+
+	response := &cardspb.CardGetResponse{
+		Id:             card.ID,
+		Name:           card.Name,
+		Currency:       card.Currency,
+		OpeningBalance: card.OpeningBalance.String(),
+	}
+
+	if !card.OpeningDate.IsZero() {
+		response.OpeningDate = timestamppb.New(card.OpeningDate)
+	}
+
+	return response, nil
+}
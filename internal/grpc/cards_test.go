@@ -0,0 +1,182 @@
+package grpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	grpc "github.com/rubengomes8/golang-personal-finances/internal/pb/cards"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/mock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCards_CreateCard(t *testing.T) {
+
+	type fields struct {
+		CardRepository repository.CardRepo
+	}
+
+	type args struct {
+		ctx context.Context
+		req *grpc.CardCreateRequest
+	}
+
+	type want struct {
+		response *grpc.CardCreateResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			fields: fields{
+				CardRepository: &cardsCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.CardCreateRequest{
+					Name: "Revolut",
+				},
+			},
+			want: want{
+				response: &grpc.CardCreateResponse{
+					Id: 1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorInsertingCard",
+			fields: fields{
+				CardRepository: mock.NewCard(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.CardCreateRequest{
+					Name: "Revolut",
+				},
+			},
+			want: want{
+				errorMsg: "could not insert card",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &Cards{
+				CardRepository: tt.fields.CardRepository,
+			}
+
+			got, err := s.CreateCard(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Cards.CreateCard() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("Cards.CreateCard() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
+
+func TestCards_GetCardByName(t *testing.T) {
+
+	type fields struct {
+		CardRepository repository.CardRepo
+	}
+
+	type args struct {
+		ctx context.Context
+		req *grpc.CardGetRequestByName
+	}
+
+	type want struct {
+		response *grpc.CardGetResponse
+		code     codes.Code
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			fields: fields{
+				CardRepository: &cardsCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.CardGetRequestByName{
+					Name: "CGD",
+				},
+			},
+			want: want{
+				response: &grpc.CardGetResponse{
+					Id:             1,
+					Name:           "CGD",
+					OpeningBalance: "0.00",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorUnknownCard",
+			fields: fields{
+				CardRepository: &cardsCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.CardGetRequestByName{
+					Name: "Unknown",
+				},
+			},
+			want: want{
+				code: codes.NotFound,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &Cards{
+				CardRepository: tt.fields.CardRepository,
+			}
+
+			got, err := s.GetCardByName(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Cards.GetCardByName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("Cards.GetCardByName() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Equal(t, tt.want.code, status.Code(err))
+			}
+		})
+	}
+}
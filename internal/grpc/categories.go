@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mergeCategory merges the category with the id into the one with the target id, or only counts what would move on a dry run.
+// kind names the kind of category, such as expense category.
+func mergeCategory(
+	kind string,
+	id int64,
+	targetID int64,
+	getByID func(id int64) error,
+	merge func() (models.CategoryMerge, error),
+) (models.CategoryMerge, error) {
+
+	if targetID == id {
+		return models.CategoryMerge{}, status.Error(codes.InvalidArgument, fmt.Sprintf("into must be the id of another %s", kind))
+	}
+
+	err := getByID(targetID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.CategoryMerge{}, status.Error(codes.InvalidArgument, fmt.Sprintf("%s to merge into does not exist", kind))
+	}
+	if err != nil {
+		log.Printf("grpc - could not get %s by id: %v", kind, err)
+		return models.CategoryMerge{}, fmt.Errorf("could not merge %s", kind)
+	}
+
+	categoryMerge, err := merge()
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.CategoryMerge{}, status.Error(codes.NotFound, fmt.Sprintf("%s with this id does not exist", kind))
+	}
+	if err != nil {
+		log.Printf("grpc - could not merge %s: %v", kind, err)
+		return models.CategoryMerge{}, fmt.Errorf("could not merge %s", kind)
+	}
+
+	return categoryMerge, nil
+}
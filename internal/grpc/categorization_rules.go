@@ -0,0 +1,277 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/categorization"
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/expenses"
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/incomes"
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/rules"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CategorizationRules implements categorization rules ServiceServer methods
+type CategorizationRules struct {
+	rules.ServiceServer
+	Repository               repository.CategorizationRuleRepo
+	ExpensesRepository       repository.ExpenseRepo
+	IncomesRepository        repository.IncomeRepo
+	CardRepository           repository.CardRepo
+	SubCategoryRepository    repository.ExpenseSubCategoryRepo
+	IncomeCategoryRepository repository.IncomeCategoryRepo
+}
+
+// NewCategorizationRules creates a new CategorizationRules service
+func NewCategorizationRules(
+	ruleRepo repository.CategorizationRuleRepo,
+	expRepo repository.ExpenseRepo,
+	incRepo repository.IncomeRepo,
+	cardRepo repository.CardRepo,
+	expSubCatRepo repository.ExpenseSubCategoryRepo,
+	incCatRepo repository.IncomeCategoryRepo,
+) (CategorizationRules, error) {
+	return CategorizationRules{
+		Repository:               ruleRepo,
+		ExpensesRepository:       expRepo,
+		IncomesRepository:        incRepo,
+		CardRepository:           cardRepo,
+		SubCategoryRepository:    expSubCatRepo,
+		IncomeCategoryRepository: incCatRepo,
+	}, nil
+}
+
+// Create creates a categorization rule on the database
+func (r CategorizationRules) Create(ctx context.Context, req *rules.Rule) (*rules.CreateResponse, error) {
+	log.Printf("Create was invoked with %v\n", req)
+
+	ruleRecord, err := r.toRuleRecord(ctx, userIDFromContext(ctx), req)
+	if err != nil {
+		log.Printf("grpc - could not get categorization rule record: %v", err)
+		return &rules.CreateResponse{}, status.Error(codes.InvalidArgument, ruleErrorMsg(err))
+	}
+
+	id, err := r.Repository.InsertCategorizationRule(ctx, ruleRecord)
+	if err != nil {
+		log.Printf("grpc - could not insert categorization rule: %v", err)
+		return &rules.CreateResponse{}, fmt.Errorf("could not insert categorization rule")
+	}
+
+	return &rules.CreateResponse{
+		Id: id,
+	}, nil
+}
+
+// Update updates a categorization rule on the database
+func (r CategorizationRules) Update(ctx context.Context, req *rules.Rule) (*rules.UpdateResponse, error) {
+	log.Printf("Update was invoked with %v\n", req)
+
+	ruleRecord, err := r.toRuleRecord(ctx, userIDFromContext(ctx), req)
+	if err != nil {
+		log.Printf("grpc - could not get categorization rule record: %v", err)
+		return &rules.UpdateResponse{}, status.Error(codes.InvalidArgument, ruleErrorMsg(err))
+	}
+
+	ruleRecord.ID = req.Id
+
+	id, err := r.Repository.UpdateCategorizationRule(ctx, ruleRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &rules.UpdateResponse{}, status.Error(codes.NotFound, "categorization rule with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not update categorization rule: %v", err)
+		return &rules.UpdateResponse{}, fmt.Errorf("could not update categorization rule")
+	}
+
+	return &rules.UpdateResponse{
+		Id: id,
+	}, nil
+}
+
+// Get gets a categorization rule from the database that matches the id provided
+func (r CategorizationRules) Get(ctx context.Context, req *rules.GetRequest) (*rules.Rule, error) {
+	log.Printf("Get was invoked with %v\n", req)
+
+	ruleView, err := r.Repository.GetCategorizationRuleByID(ctx, userIDFromContext(ctx), req.Id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &rules.Rule{}, status.Error(codes.NotFound, "categorization rule with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not get categorization rule by id: %v", err)
+		return &rules.Rule{}, fmt.Errorf("could not get categorization rule by id")
+	}
+
+	return ruleViewToRule(ruleView), nil
+}
+
+// GetSeveral gets the categorization rules from the database, in the order they are tried
+func (r CategorizationRules) GetSeveral(ctx context.Context, req *rules.GetSeveralRequest) (*rules.GetSeveralResponse, error) {
+	log.Printf("GetSeveral was invoked with %v\n", req)
+
+	ruleViews, err := r.Repository.GetCategorizationRules(ctx, userIDFromContext(ctx))
+	if err != nil {
+		log.Printf("grpc - could not get categorization rules: %v", err)
+		return &rules.GetSeveralResponse{}, fmt.Errorf("could not get categorization rules")
+	}
+
+	var responseRules []*rules.Rule
+	for _, ruleView := range ruleViews {
+		responseRules = append(responseRules, ruleViewToRule(ruleView))
+	}
+
+	return &rules.GetSeveralResponse{
+		Rules: responseRules,
+	}, nil
+}
+
+// Delete deletes a categorization rule from the database that matches the id provided
+func (r CategorizationRules) Delete(ctx context.Context, req *rules.DeleteRequest) (*rules.DeleteResponse, error) {
+	log.Printf("Delete was invoked with %v\n", req)
+
+	err := r.Repository.DeleteCategorizationRule(ctx, userIDFromContext(ctx), req.Id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &rules.DeleteResponse{}, status.Error(codes.NotFound, "categorization rule with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not delete categorization rule: %v", err)
+		return &rules.DeleteResponse{}, fmt.Errorf("could not delete categorization rule")
+	}
+
+	return &rules.DeleteResponse{}, nil
+}
+
+// DryRun lists the expenses (for a rule with a subcategory) or the incomes (for a rule with a category)
+// in the provided dates interval that a categorization rule matches, without storing or changing anything
+func (r CategorizationRules) DryRun(ctx context.Context, req *rules.DryRunRequest) (*rules.DryRunResponse, error) {
+	log.Printf("DryRun was invoked with %v\n", req)
+
+	userID := userIDFromContext(ctx)
+
+	ruleRecord, err := r.toRuleRecord(ctx, userID, req.Rule)
+	if err != nil {
+		log.Printf("grpc - could not get categorization rule record: %v", err)
+		return &rules.DryRunResponse{}, status.Error(codes.InvalidArgument, ruleErrorMsg(err))
+	}
+
+	rule := categorization.RuleView(ruleRecord)
+
+	if rule.SubCategoryID != 0 {
+		expenseViewRecords, err := r.ExpensesRepository.GetExpensesByDates(ctx, userID, req.MinDate.AsTime(), req.MaxDate.AsTime())
+		if err != nil {
+			log.Printf("grpc - could not get expenses by dates to dry run categorization rule: %v", err)
+			return &rules.DryRunResponse{}, fmt.Errorf("could not get expenses by dates")
+		}
+
+		return &rules.DryRunResponse{
+			Expenses: expensesViewToExpensesGetResponse(categorization.MatchingExpenses(rule, expenseViewRecords)),
+			Incomes:  []*incomes.GetResponse{},
+		}, nil
+	}
+
+	incomeViewRecords, err := r.IncomesRepository.GetIncomesByDates(ctx, userID, req.MinDate.AsTime(), req.MaxDate.AsTime())
+	if err != nil {
+		log.Printf("grpc - could not get incomes by dates to dry run categorization rule: %v", err)
+		return &rules.DryRunResponse{}, fmt.Errorf("could not get incomes by dates")
+	}
+
+	return &rules.DryRunResponse{
+		Expenses: []*expenses.ExpenseGetResponse{},
+		Incomes:  incomeViewsToIncomesGetResponse(categorization.MatchingIncomes(rule, incomeViewRecords)),
+	}, nil
+}
+
+// toRuleRecord resolves the card and category names of a rule and validates it
+func (r CategorizationRules) toRuleRecord(ctx context.Context, userID int64, rule *rules.Rule) (models.CategorizationRuleTable, error) {
+
+	minValue, err := optionalMoney(rule.GetMinValue())
+	if err != nil {
+		return models.CategorizationRuleTable{}, fmt.Errorf("%w: %v", categorization.ErrInvalidRule, err)
+	}
+
+	maxValue, err := optionalMoney(rule.GetMaxValue())
+	if err != nil {
+		return models.CategorizationRuleTable{}, fmt.Errorf("%w: %v", categorization.ErrInvalidRule, err)
+	}
+
+	ruleRecord := models.CategorizationRuleTable{
+		Priority:           rule.GetPriority(),
+		DescriptionPattern: rule.GetDescription(),
+		DescriptionRegex:   rule.GetRegex(),
+		MinValue:           minValue,
+		MaxValue:           maxValue,
+		UserID:             userID,
+	}
+
+	if rule.GetCard() != "" {
+		card, err := r.CardRepository.GetCardByName(ctx, userID, rule.GetCard())
+		if err != nil {
+			return models.CategorizationRuleTable{}, fmt.Errorf("could not get card by name: %v", err)
+		}
+		ruleRecord.CardID = card.ID
+	}
+
+	if rule.GetSubCategory() != "" {
+		subCategory, err := r.SubCategoryRepository.GetExpenseSubCategoryByName(ctx, rule.GetSubCategory())
+		if err != nil {
+			return models.CategorizationRuleTable{}, fmt.Errorf("could not get expense sub category by name: %v", err)
+		}
+		ruleRecord.SubCategoryID = subCategory.ID
+	}
+
+	if rule.GetCategory() != "" {
+		category, err := r.IncomeCategoryRepository.GetIncomeCategoryByName(ctx, rule.GetCategory())
+		if err != nil {
+			return models.CategorizationRuleTable{}, fmt.Errorf("could not get income category by name: %v", err)
+		}
+		ruleRecord.IncomeCategoryID = category.ID
+	}
+
+	err = categorization.Validate(ruleRecord)
+	if err != nil {
+		return models.CategorizationRuleTable{}, err
+	}
+
+	return ruleRecord, nil
+}
+
+func ruleErrorMsg(err error) string {
+	if errors.Is(err, categorization.ErrInvalidRule) {
+		return "rule must set either a sub_category or a category, a valid regex and decimal min_value <= max_value"
+	}
+	return "card, subcategory or category does not exist"
+}
+
+func ruleViewToRule(ruleView models.CategorizationRuleView) *rules.Rule {
+
+	rule := &rules.Rule{
+		Id:          ruleView.ID,
+		Priority:    ruleView.Priority,
+		Description: ruleView.DescriptionPattern,
+		Regex:       ruleView.DescriptionRegex,
+		Card:        ruleView.Card,
+		SubCategory: ruleView.SubCategory,
+		Category:    ruleView.IncomeCategory,
+	}
+
+	if ruleView.MinValue != 0 {
+		rule.MinValue = ruleView.MinValue.String()
+	}
+	if ruleView.MaxValue != 0 {
+		rule.MaxValue = ruleView.MaxValue.String()
+	}
+
+	return rule
+}
+
+// optionalMoney parses an amount that may be missing, which is zero
+func optionalMoney(amount string) (models.Money, error) {
+	if amount == "" {
+		return 0, nil
+	}
+	return models.ParseMoney(amount)
+}
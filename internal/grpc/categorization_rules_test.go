@@ -0,0 +1,274 @@
+package grpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/expenses"
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/incomes"
+	grpc "github.com/rubengomes8/golang-personal-finances/internal/pb/rules"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var rentRuleView = models.CategorizationRuleView{
+	ID:                 1,
+	Priority:           1,
+	DescriptionPattern: "rent",
+	CardID:             1,
+	SubCategoryID:      1,
+	Card:               "CGD",
+	SubCategory:        "Rent",
+}
+
+func TestCategorizationRules_Create(t *testing.T) {
+
+	type fields struct {
+		Repository repository.CategorizationRuleRepo
+	}
+
+	type args struct {
+		ctx context.Context
+		req *grpc.Rule
+	}
+
+	type want struct {
+		response *grpc.CreateResponse
+		code     codes.Code
+	}
+
+	rulesCache := cache.NewCategorizationRule([]models.CategorizationRuleView{})
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			fields: fields{
+				Repository: &rulesCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.Rule{
+					Description: "^rent",
+					Regex:       true,
+					MaxValue:    "1000.00",
+					Card:        "CGD",
+					SubCategory: "Rent",
+				},
+			},
+			want: want{
+				response: &grpc.CreateResponse{
+					Id: 1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorUnknownSubCategory",
+			fields: fields{
+				Repository: &rulesCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.Rule{
+					Description: "rent",
+					SubCategory: "Unknown",
+				},
+			},
+			want: want{
+				code: codes.InvalidArgument,
+			},
+			wantErr: true,
+		},
+		{
+			name: "ErrorInvalidRegex",
+			fields: fields{
+				Repository: &rulesCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.Rule{
+					Description: "(rent",
+					Regex:       true,
+					SubCategory: "Rent",
+				},
+			},
+			want: want{
+				code: codes.InvalidArgument,
+			},
+			wantErr: true,
+		},
+		{
+			name: "ErrorMissingTarget",
+			fields: fields{
+				Repository: &rulesCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.Rule{
+					Description: "rent",
+				},
+			},
+			want: want{
+				code: codes.InvalidArgument,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &CategorizationRules{
+				Repository:            tt.fields.Repository,
+				CardRepository:        &cardsCache,
+				SubCategoryRepository: &subCategoriesCache,
+			}
+
+			got, err := s.Create(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CategorizationRules.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("CategorizationRules.Create() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Equal(t, tt.want.code, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestCategorizationRules_Get(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		req *grpc.GetRequest
+	}
+
+	type want struct {
+		response *grpc.Rule
+		code     codes.Code
+	}
+
+	rulesCache := cache.NewCategorizationRule([]models.CategorizationRuleView{rentRuleView})
+
+	tests := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.GetRequest{
+					Id: 1,
+				},
+			},
+			want: want{
+				response: &grpc.Rule{
+					Id:          1,
+					Priority:    1,
+					Description: "rent",
+					Card:        "CGD",
+					SubCategory: "Rent",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorNotFound",
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.GetRequest{
+					Id: 2,
+				},
+			},
+			want: want{
+				code: codes.NotFound,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &CategorizationRules{
+				Repository: &rulesCache,
+			}
+
+			got, err := s.Get(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CategorizationRules.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("CategorizationRules.Get() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Equal(t, tt.want.code, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestCategorizationRules_DryRun(t *testing.T) {
+
+	houseRentExpenseTable := houseRentExpenseTable
+	houseRentExpenseTable.Description = "House rent"
+
+	expensesCache := cache.NewExpense(
+		[]models.ExpenseTable{houseRentExpenseTable, restaurantExpenseTable},
+		cardsCache,
+		categoriesCache,
+		subCategoriesCache,
+	)
+
+	s := &CategorizationRules{
+		ExpensesRepository:    &expensesCache,
+		CardRepository:        &cardsCache,
+		SubCategoryRepository: &subCategoriesCache,
+	}
+
+	got, err := s.DryRun(context.Background(), &grpc.DryRunRequest{
+		Rule: &grpc.Rule{
+			Description: "RENT",
+			SubCategory: "Rent",
+		},
+		MinDate: timestamppb.New(firstFebruary2020ZeroHoursUTCTime.AddDate(0, 0, -1)),
+		MaxDate: timestamppb.New(firstFebruary2020ZeroHoursUTCTime.AddDate(0, 0, 1)),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*incomes.GetResponse{}, got.Incomes)
+	assert.Equal(t, []*expenses.ExpenseGetResponse{
+		{
+			Id:          1,
+			Value:       "10.00",
+			Date:        firstFebruary2020Unix,
+			Category:    "House",
+			SubCategory: "Rent",
+			Card:        "CGD",
+			Description: "House rent",
+		},
+	}, got.Expenses)
+}
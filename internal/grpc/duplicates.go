@@ -0,0 +1,24 @@
+package grpc
+
+import (
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// likelyDuplicateError is the AlreadyExists status returned when a new row is a likely duplicate.
+// The candidates are sent as the status details.
+func likelyDuplicateError(msg string, candidates []protoiface.MessageV1) error {
+
+	st := status.New(codes.AlreadyExists, msg)
+
+	detailed, err := st.WithDetails(candidates...)
+	if err != nil {
+		log.Printf("grpc - could not add likely duplicates to status details: %v", err)
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
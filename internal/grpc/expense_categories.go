@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	expcategoriespb "github.com/rubengomes8/golang-personal-finances/internal/pb/expenses/categories"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// ExpenseCategories implements ExpenseCategoryServiceServer methods
+type ExpenseCategories struct {
+	expcategoriespb.ExpenseCategoryServiceServer
+	CategoryRepository repository.ExpenseCategoryRepo
+}
+
+// NewExpenseCategories creates a new ExpenseCategories service
+func NewExpenseCategories(catRepo repository.ExpenseCategoryRepo) (ExpenseCategories, error) {
+	return ExpenseCategories{
+		CategoryRepository: catRepo,
+	}, nil
+}
+
+// CreateExpenseCategory creates an expense category on the database
+func (e ExpenseCategories) CreateExpenseCategory(
+	ctx context.Context,
+	req *expcategoriespb.ExpenseCategoryCreateRequest,
+) (*expcategoriespb.ExpenseCategoryCreateResponse, error) {
+
+	id, err := e.CategoryRepository.InsertExpenseCategory(ctx, models.ExpenseCategoryTable{
+		Name: req.Name,
+	})
+	if err != nil {
+		log.Printf("grpc - could not insert expense category: %v", err)
+		return &expcategoriespb.ExpenseCategoryCreateResponse{}, fmt.Errorf("could not insert expense category")
+	}
+
+	return &expcategoriespb.ExpenseCategoryCreateResponse{
+		Id: id,
+	}, nil
+}
+
+// GetExpenseCategoryByName gets an expense category from the database that matches the name provided
+func (e ExpenseCategories) GetExpenseCategoryByName(
+	ctx context.Context,
+	req *expcategoriespb.ExpenseCategoryGetRequestByName,
+) (*expcategoriespb.ExpenseCategoryGetResponse, error) {
+
+	category, err := e.CategoryRepository.GetExpenseCategoryByName(ctx, req.Name)
+	if err != nil {
+		log.Printf("grpc - could not get expense category by name: %v", err)
+		return &expcategoriespb.ExpenseCategoryGetResponse{}, fmt.Errorf("could not get expense category by name")
+	}
+
+	return &expcategoriespb.ExpenseCategoryGetResponse{
+		Id:   category.ID,
+		Name: category.Name,
+	}, nil
+}
+
+// MergeExpenseCategory merges an expense category into another one, moving its subcategories and budgets to it
+// and deleting it, or only counts what would move on a dry run
+func (e ExpenseCategories) MergeExpenseCategory(
+	ctx context.Context,
+	req *expcategoriespb.ExpenseCategoryMergeRequest,
+) (*expcategoriespb.ExpenseCategoryMergeResponse, error) {
+
+	merge, err := mergeCategory("expense category", req.Id, req.Into,
+		func(id int64) error {
+			_, err := e.CategoryRepository.GetExpenseCategoryByID(ctx, id)
+			return err
+		},
+		func() (models.CategoryMerge, error) {
+			return e.CategoryRepository.MergeExpenseCategory(ctx, req.Id, req.Into, req.DryRun)
+		},
+	)
+	if err != nil {
+		return &expcategoriespb.ExpenseCategoryMergeResponse{}, err
+	}
+
+	return &expcategoriespb.ExpenseCategoryMergeResponse{
+		DryRun:        req.DryRun,
+		Expenses:      merge.Transactions,
+		SubCategories: merge.SubCategories,
+		Budgets:       merge.Budgets,
+	}, nil
+}
@@ -0,0 +1,287 @@
+package grpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	grpc "github.com/rubengomes8/golang-personal-finances/internal/pb/expenses/categories"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpenseCategories_CreateExpenseCategory(t *testing.T) {
+
+	categoriesCache := cache.NewExpenseCategory(categories)
+
+	type fields struct {
+		CategoryRepository repository.ExpenseCategoryRepo
+	}
+
+	type args struct {
+		ctx context.Context
+		req *grpc.ExpenseCategoryCreateRequest
+	}
+
+	type want struct {
+		response *grpc.ExpenseCategoryCreateResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			fields: fields{
+				CategoryRepository: &categoriesCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseCategoryCreateRequest{
+					Name: "Health",
+				},
+			},
+			want: want{
+				response: &grpc.ExpenseCategoryCreateResponse{
+					Id: 1,
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &ExpenseCategories{
+				CategoryRepository: tt.fields.CategoryRepository,
+			}
+
+			got, err := s.CreateExpenseCategory(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpenseCategories.CreateExpenseCategory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("ExpenseCategories.CreateExpenseCategory() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
+
+func TestExpenseCategories_GetExpenseCategoryByName(t *testing.T) {
+
+	type fields struct {
+		CategoryRepository repository.ExpenseCategoryRepo
+	}
+
+	type args struct {
+		ctx context.Context
+		req *grpc.ExpenseCategoryGetRequestByName
+	}
+
+	type want struct {
+		response *grpc.ExpenseCategoryGetResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			fields: fields{
+				CategoryRepository: &categoriesCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseCategoryGetRequestByName{
+					Name: "House",
+				},
+			},
+			want: want{
+				response: &grpc.ExpenseCategoryGetResponse{
+					Id:   1,
+					Name: "House",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorUnknownCategory",
+			fields: fields{
+				CategoryRepository: &categoriesCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseCategoryGetRequestByName{
+					Name: "Unknown",
+				},
+			},
+			want: want{
+				errorMsg: "could not get expense category by name",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &ExpenseCategories{
+				CategoryRepository: tt.fields.CategoryRepository,
+			}
+
+			got, err := s.GetExpenseCategoryByName(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpenseCategories.GetExpenseCategoryByName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("ExpenseCategories.GetExpenseCategoryByName() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
+
+func TestExpenseCategories_MergeExpenseCategory(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		req *grpc.ExpenseCategoryMergeRequest
+	}
+
+	type want struct {
+		response *grpc.ExpenseCategoryMergeResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "SuccessDryRun",
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseCategoryMergeRequest{
+					Id:     2,
+					Into:   1,
+					DryRun: true,
+				},
+			},
+			want: want{
+				response: &grpc.ExpenseCategoryMergeResponse{
+					DryRun: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success",
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseCategoryMergeRequest{
+					Id:   2,
+					Into: 1,
+				},
+			},
+			want: want{
+				response: &grpc.ExpenseCategoryMergeResponse{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorMergeIntoItself",
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseCategoryMergeRequest{
+					Id:   1,
+					Into: 1,
+				},
+			},
+			want: want{
+				errorMsg: "into must be the id of another expense category",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ErrorUnknownTarget",
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseCategoryMergeRequest{
+					Id:   2,
+					Into: 3,
+				},
+			},
+			want: want{
+				errorMsg: "expense category to merge into does not exist",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ErrorUnknownCategory",
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseCategoryMergeRequest{
+					Id:   3,
+					Into: 1,
+				},
+			},
+			want: want{
+				errorMsg: "expense category with this id does not exist",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			categoriesCache := cache.NewExpenseCategory(append([]models.ExpenseCategoryTable{}, categories...))
+
+			s := &ExpenseCategories{
+				CategoryRepository: &categoriesCache,
+			}
+
+			got, err := s.MergeExpenseCategory(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpenseCategories.MergeExpenseCategory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("ExpenseCategories.MergeExpenseCategory() = %v, want %v", got, tt.want.response)
+				}
+				_, err = categoriesCache.GetExpenseCategoryByID(tt.args.ctx, tt.args.req.Id)
+				assert.Equal(t, tt.args.req.DryRun, err == nil)
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
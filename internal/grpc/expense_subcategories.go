@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/expenses/subcategories"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// ExpenseSubCategories implements ExpenseSubCategoryServiceServer methods
+type ExpenseSubCategories struct {
+	subcategories.ExpenseSubCategoryServiceServer
+	SubCategoryRepository repository.ExpenseSubCategoryRepo
+	CategoryRepository    repository.ExpenseCategoryRepo
+}
+
+// NewExpenseSubCategories creates a new ExpenseSubCategories service
+func NewExpenseSubCategories(
+	subCatRepo repository.ExpenseSubCategoryRepo,
+	catRepo repository.ExpenseCategoryRepo,
+) (ExpenseSubCategories, error) {
+	return ExpenseSubCategories{
+		SubCategoryRepository: subCatRepo,
+		CategoryRepository:    catRepo,
+	}, nil
+}
+
+// CreateExpenseSubCategory creates an expense subcategory under the category provided
+func (e ExpenseSubCategories) CreateExpenseSubCategory(
+	ctx context.Context,
+	req *subcategories.ExpenseSubCategoryCreateRequest,
+) (*subcategories.ExpenseSubCategoryCreateResponse, error) {
+
+	category, err := e.CategoryRepository.GetExpenseCategoryByName(ctx, req.Category)
+	if err != nil {
+		log.Printf("grpc - could not get expense category by name: %v", err)
+		return &subcategories.ExpenseSubCategoryCreateResponse{}, fmt.Errorf("could not get expense category by name")
+	}
+
+	id, err := e.SubCategoryRepository.InsertExpenseSubCategory(ctx, models.ExpenseSubCategoryTable{
+		Name:       req.Name,
+		CategoryID: category.ID,
+	})
+	if err != nil {
+		log.Printf("grpc - could not insert expense subcategory: %v", err)
+		return &subcategories.ExpenseSubCategoryCreateResponse{}, fmt.Errorf("could not insert expense subcategory")
+	}
+
+	return &subcategories.ExpenseSubCategoryCreateResponse{
+		Id: id,
+	}, nil
+}
+
+// GetExpenseSubCategoryByName gets an expense subcategory from the database that matches the name provided
+func (e ExpenseSubCategories) GetExpenseSubCategoryByName(
+	ctx context.Context,
+	req *subcategories.ExpenseSubCategoryGetRequestByName,
+) (*subcategories.ExpenseSubCategoryGetResponse, error) {
+
+	subCategory, err := e.SubCategoryRepository.GetExpenseSubCategoryByName(ctx, req.Name)
+	if err != nil {
+		log.Printf("grpc - could not get expense subcategory by name: %v", err)
+		return &subcategories.ExpenseSubCategoryGetResponse{}, fmt.Errorf("could not get expense subcategory by name")
+	}
+
+	return &subcategories.ExpenseSubCategoryGetResponse{
+		Id:         subCategory.ID,
+		Name:       subCategory.Name,
+		CategoryId: subCategory.CategoryID,
+	}, nil
+}
+
+// MergeExpenseSubCategory merges an expense subcategory into another one, moving its expenses, budgets, rules and
+// recurring transactions to it and deleting it, or only counts what would move on a dry run
+func (e ExpenseSubCategories) MergeExpenseSubCategory(
+	ctx context.Context,
+	req *subcategories.ExpenseSubCategoryMergeRequest,
+) (*subcategories.ExpenseSubCategoryMergeResponse, error) {
+
+	merge, err := mergeCategory("expense subcategory", req.Id, req.Into,
+		func(id int64) error {
+			_, err := e.SubCategoryRepository.GetExpenseSubCategoryByID(ctx, id)
+			return err
+		},
+		func() (models.CategoryMerge, error) {
+			return e.SubCategoryRepository.MergeExpenseSubCategory(ctx, req.Id, req.Into, req.DryRun)
+		},
+	)
+	if err != nil {
+		return &subcategories.ExpenseSubCategoryMergeResponse{}, err
+	}
+
+	return &subcategories.ExpenseSubCategoryMergeResponse{
+		DryRun:                req.DryRun,
+		Expenses:              merge.Transactions,
+		Budgets:               merge.Budgets,
+		Rules:                 merge.Rules,
+		RecurringTransactions: merge.RecurringTransactions,
+	}, nil
+}
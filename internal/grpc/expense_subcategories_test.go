@@ -0,0 +1,287 @@
+package grpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	grpc "github.com/rubengomes8/golang-personal-finances/internal/pb/expenses/subcategories"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpenseSubCategories_CreateExpenseSubCategory(t *testing.T) {
+
+	subCategoriesCache := cache.NewExpenseSubCategory(subCategories)
+
+	type fields struct {
+		SubCategoryRepository repository.ExpenseSubCategoryRepo
+		CategoryRepository    repository.ExpenseCategoryRepo
+	}
+
+	type args struct {
+		ctx context.Context
+		req *grpc.ExpenseSubCategoryCreateRequest
+	}
+
+	type want struct {
+		response *grpc.ExpenseSubCategoryCreateResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			fields: fields{
+				SubCategoryRepository: &subCategoriesCache,
+				CategoryRepository:    &categoriesCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseSubCategoryCreateRequest{
+					Name:     "Electricity",
+					Category: "House",
+				},
+			},
+			want: want{
+				response: &grpc.ExpenseSubCategoryCreateResponse{
+					Id: 1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorUnknownCategory",
+			fields: fields{
+				SubCategoryRepository: &subCategoriesCache,
+				CategoryRepository:    &categoriesCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseSubCategoryCreateRequest{
+					Name:     "Electricity",
+					Category: "Unknown",
+				},
+			},
+			want: want{
+				errorMsg: "could not get expense category by name",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &ExpenseSubCategories{
+				SubCategoryRepository: tt.fields.SubCategoryRepository,
+				CategoryRepository:    tt.fields.CategoryRepository,
+			}
+
+			got, err := s.CreateExpenseSubCategory(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpenseSubCategories.CreateExpenseSubCategory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("ExpenseSubCategories.CreateExpenseSubCategory() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
+
+func TestExpenseSubCategories_GetExpenseSubCategoryByName(t *testing.T) {
+
+	type fields struct {
+		SubCategoryRepository repository.ExpenseSubCategoryRepo
+		CategoryRepository    repository.ExpenseCategoryRepo
+	}
+
+	type args struct {
+		ctx context.Context
+		req *grpc.ExpenseSubCategoryGetRequestByName
+	}
+
+	type want struct {
+		response *grpc.ExpenseSubCategoryGetResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			fields: fields{
+				SubCategoryRepository: &subCategoriesCache,
+				CategoryRepository:    &categoriesCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseSubCategoryGetRequestByName{
+					Name: "Restaurants",
+				},
+			},
+			want: want{
+				response: &grpc.ExpenseSubCategoryGetResponse{
+					Id:         2,
+					Name:       "Restaurants",
+					CategoryId: 2,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorUnknownSubCategory",
+			fields: fields{
+				SubCategoryRepository: &subCategoriesCache,
+				CategoryRepository:    &categoriesCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseSubCategoryGetRequestByName{
+					Name: "Unknown",
+				},
+			},
+			want: want{
+				errorMsg: "could not get expense subcategory by name",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &ExpenseSubCategories{
+				SubCategoryRepository: tt.fields.SubCategoryRepository,
+				CategoryRepository:    tt.fields.CategoryRepository,
+			}
+
+			got, err := s.GetExpenseSubCategoryByName(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpenseSubCategories.GetExpenseSubCategoryByName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("ExpenseSubCategories.GetExpenseSubCategoryByName() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
+
+func TestExpenseSubCategories_MergeExpenseSubCategory(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		req *grpc.ExpenseSubCategoryMergeRequest
+	}
+
+	type want struct {
+		response *grpc.ExpenseSubCategoryMergeResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "SuccessDryRun",
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseSubCategoryMergeRequest{
+					Id:     2,
+					Into:   1,
+					DryRun: true,
+				},
+			},
+			want: want{
+				response: &grpc.ExpenseSubCategoryMergeResponse{
+					DryRun: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success",
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseSubCategoryMergeRequest{
+					Id:   2,
+					Into: 1,
+				},
+			},
+			want: want{
+				response: &grpc.ExpenseSubCategoryMergeResponse{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorUnknownTarget",
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseSubCategoryMergeRequest{
+					Id:   2,
+					Into: 3,
+				},
+			},
+			want: want{
+				errorMsg: "expense subcategory to merge into does not exist",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			subCategoriesCache := cache.NewExpenseSubCategory(append([]models.ExpenseSubCategoryTable{}, subCategories...))
+
+			s := &ExpenseSubCategories{
+				SubCategoryRepository: &subCategoriesCache,
+				CategoryRepository:    &categoriesCache,
+			}
+
+			got, err := s.MergeExpenseSubCategory(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpenseSubCategories.MergeExpenseSubCategory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("ExpenseSubCategories.MergeExpenseSubCategory() = %v, want %v", got, tt.want.response)
+				}
+				_, err = subCategoriesCache.GetExpenseSubCategoryByID(tt.args.ctx, tt.args.req.Id)
+				assert.Equal(t, tt.args.req.DryRun, err == nil)
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
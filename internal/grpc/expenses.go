@@ -2,13 +2,24 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/rubengomes8/golang-personal-finances/internal/categorization"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/duplicates"
 	"github.com/rubengomes8/golang-personal-finances/internal/pb/expenses"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/search"
+	"github.com/rubengomes8/golang-personal-finances/internal/splits"
+	"github.com/rubengomes8/golang-personal-finances/internal/summary"
+	"github.com/rubengomes8/golang-personal-finances/internal/tags"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
 )
 
 // Expenses implements ExpensesServiceServer methods
@@ -17,6 +28,10 @@ type Expenses struct {
 	ExpensesRepository            repository.ExpenseRepo
 	ExpensesSubCategoryRepository repository.ExpenseSubCategoryRepo
 	CardRepository                repository.CardRepo
+	DuplicatesDetector            duplicates.Detector
+	Categorizer                   categorization.Categorizer
+	Rates                         currency.Rates
+	Summarizer                    summary.Summarizer
 }
 
 // NewExpenses creates a new ExpensesService
@@ -29,26 +44,74 @@ func NewExpenses(
 		ExpensesRepository:            expRepo,
 		ExpensesSubCategoryRepository: expSubCatRepo,
 		CardRepository:                cardRepo,
+		DuplicatesDetector:            duplicates.NewDetector(),
 	}, nil
 }
 
-// CreateExpense creates an expense on the database
+// CreateExpense creates an expense on the database.
+// A likely duplicate of an existing expense is refused with an AlreadyExists status, listing the candidates
+// in its details, unless the request is forced.
 func (e Expenses) CreateExpense(
 	ctx context.Context,
 	req *expenses.ExpenseCreateRequest,
 ) (*expenses.ExpenseCreateResponse, error) {
 
-	expSubCategory, card, err := e.getExpenseSubcategoryAndCardIDByNames(ctx, req.SubCategory, req.Card)
+	userID := userIDFromContext(ctx)
+
+	value, err := models.ParseMoney(req.Value)
+	if err != nil {
+		return &expenses.ExpenseCreateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	expenseCurrency, err := optionalCurrency(req.Currency)
+	if err != nil {
+		return &expenses.ExpenseCreateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	expenseSplits, err := e.getExpenseSplits(ctx, value, req.Splits)
+	if err != nil {
+		return &expenses.ExpenseCreateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	expenseTags, err := tags.Normalize(req.Tags)
+	if err != nil {
+		return &expenses.ExpenseCreateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	subCategory := parentSubCategory(req.SubCategory, req.Splits)
+	expSubCategory, card, err := e.getExpenseSubcategoryAndCardIDByNames(ctx, userID, subCategory, req.Card, value, req.Description)
 	if err != nil {
 		return &expenses.ExpenseCreateResponse{}, fmt.Errorf("could not get expense subcategory and/or card by name: %w", err)
 	}
 
 	expenseRecord := models.ExpenseTable{
-		Value:         req.Value,
+		Value:         value,
+		Currency:      expenseCurrency,
 		Date:          unixToTime(req.Date),
 		SubCategoryID: expSubCategory.ID,
 		CardID:        card.ID,
 		Description:   req.Description,
+		UserID:        userID,
+		Splits:        expenseSplits,
+		Tags:          expenseTags,
+	}
+
+	if !req.Force {
+		minDate, maxDate := e.DuplicatesDetector.DateWindow(expenseRecord.Date)
+		candidates, err := e.ExpensesRepository.GetExpensesByCardAndValue(ctx, userID, card.ID, value, minDate, maxDate)
+		if err != nil {
+			return &expenses.ExpenseCreateResponse{}, fmt.Errorf("could not get expense duplicate candidates: %w", err)
+		}
+
+		likelyDuplicates := e.DuplicatesDetector.Expenses(candidates, expenseRecord)
+		if len(likelyDuplicates) > 0 {
+			details := []protoiface.MessageV1{}
+			for _, duplicate := range expensesViewToExpensesGetResponse(likelyDuplicates) {
+				details = append(details, duplicate)
+			}
+			return &expenses.ExpenseCreateResponse{}, likelyDuplicateError(
+				"expense is a likely duplicate - set force to create it anyway", details)
+		}
 	}
 
 	id, err := e.ExpensesRepository.InsertExpense(ctx, expenseRecord)
@@ -67,21 +130,51 @@ func (e Expenses) UpdateExpense(
 	req *expenses.ExpenseUpdateRequest,
 ) (*expenses.ExpenseUpdateResponse, error) {
 
-	expSubCategory, card, err := e.getExpenseSubcategoryAndCardIDByNames(ctx, req.SubCategory, req.Card)
+	userID := userIDFromContext(ctx)
+
+	value, err := models.ParseMoney(req.Value)
+	if err != nil {
+		return &expenses.ExpenseUpdateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	expenseCurrency, err := optionalCurrency(req.Currency)
+	if err != nil {
+		return &expenses.ExpenseUpdateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	expenseSplits, err := e.getExpenseSplits(ctx, value, req.Splits)
+	if err != nil {
+		return &expenses.ExpenseUpdateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	expenseTags, err := tags.Normalize(req.Tags)
+	if err != nil {
+		return &expenses.ExpenseUpdateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	subCategory := parentSubCategory(req.SubCategory, req.Splits)
+	expSubCategory, card, err := e.getExpenseSubcategoryAndCardIDByNames(ctx, userID, subCategory, req.Card, value, req.Description)
 	if err != nil {
 		return &expenses.ExpenseUpdateResponse{}, fmt.Errorf("could not get expense subcategory and/or card by name: %w", err)
 	}
 
 	expenseRecord := models.ExpenseTable{
 		ID:            req.Id,
-		Value:         req.Value,
+		Value:         value,
+		Currency:      expenseCurrency,
 		Date:          unixToTime(req.Date),
 		SubCategoryID: expSubCategory.ID,
 		CardID:        card.ID,
 		Description:   req.Description,
+		UserID:        userID,
+		Splits:        expenseSplits,
+		Tags:          expenseTags,
 	}
 
 	id, err := e.ExpensesRepository.UpdateExpense(ctx, expenseRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &expenses.ExpenseUpdateResponse{}, status.Error(codes.NotFound, "expense with this id does not exist")
+	}
 	if err != nil {
 		return &expenses.ExpenseUpdateResponse{}, fmt.Errorf("could not update expense: %w", err)
 	}
@@ -91,27 +184,97 @@ func (e Expenses) UpdateExpense(
 	}, nil
 }
 
-// CreateExpenses creates a bulk of expenses on the database
+// CreateExpenses creates a bulk of expenses on the database in a single transaction
 func (e Expenses) CreateExpenses(
 	ctx context.Context,
 	req *expenses.ExpensesCreateRequest,
 ) (*expenses.ExpensesCreateResponse, error) {
-	log.Printf("TODO - CreateExpenses was invoked with %v\n", req)
+
+	userID := userIDFromContext(ctx)
+
+	expenseRecords := make([]models.ExpenseTable, 0, len(req.Expenses))
+	for idx, exp := range req.Expenses {
+
+		value, err := models.ParseMoney(exp.Value)
+		if err != nil {
+			return &expenses.ExpensesCreateResponse{}, status.Errorf(codes.InvalidArgument, "expense %d: %v", idx, err)
+		}
+
+		expenseCurrency, err := optionalCurrency(exp.Currency)
+		if err != nil {
+			return &expenses.ExpensesCreateResponse{}, status.Errorf(codes.InvalidArgument, "expense %d: %v", idx, err)
+		}
+
+		expenseSplits, err := e.getExpenseSplits(ctx, value, exp.Splits)
+		if err != nil {
+			return &expenses.ExpensesCreateResponse{}, status.Errorf(codes.InvalidArgument, "expense %d: %v", idx, err)
+		}
+
+		expenseTags, err := tags.Normalize(exp.Tags)
+		if err != nil {
+			return &expenses.ExpensesCreateResponse{}, status.Errorf(codes.InvalidArgument, "expense %d: %v", idx, err)
+		}
+
+		subCategory := parentSubCategory(exp.SubCategory, exp.Splits)
+		expSubCategory, card, err := e.getExpenseSubcategoryAndCardIDByNames(ctx, userID, subCategory, exp.Card, value, exp.Description)
+		if err != nil {
+			log.Printf("grpc - could not get expense subcategory and/or card by name: %v", err)
+			return &expenses.ExpensesCreateResponse{}, status.Errorf(
+				codes.InvalidArgument,
+				"expense %d: could not get expense subcategory and/or card by name", idx,
+			)
+		}
+
+		expenseRecords = append(expenseRecords, models.ExpenseTable{
+			Value:         value,
+			Currency:      expenseCurrency,
+			Date:          unixToTime(exp.Date),
+			SubCategoryID: expSubCategory.ID,
+			CardID:        card.ID,
+			Description:   exp.Description,
+			UserID:        userID,
+			Splits:        expenseSplits,
+			Tags:          expenseTags,
+		})
+	}
+
+	ids, err := e.ExpensesRepository.InsertExpenses(ctx, expenseRecords)
+	if err != nil {
+		log.Printf("grpc - could not insert expenses: %v", err)
+		var batchErr repository.BatchItemError
+		if errors.As(err, &batchErr) {
+			return &expenses.ExpensesCreateResponse{}, status.Errorf(codes.Internal, "expense %d: could not insert expense", batchErr.Index)
+		}
+		return &expenses.ExpensesCreateResponse{}, status.Error(codes.Internal, "could not insert expenses")
+	}
+
+	responseIDs := make([]*expenses.ExpenseCreateResponse, 0, len(ids))
+	for _, id := range ids {
+		responseIDs = append(responseIDs, &expenses.ExpenseCreateResponse{Id: id})
+	}
 
 	return &expenses.ExpensesCreateResponse{
-		Ids: []*expenses.ExpenseCreateResponse{},
+		Ids: responseIDs,
 	}, nil
 }
 
-// GetExpensesByDate gets the expenses from the database that are in the provided dates interval
+// GetExpensesByDate gets the expenses from the database that are in the provided dates interval.
+// With a reporting currency, the value of each expense is converted to it at the exchange rate of the expense date.
+// With a tag, only the expenses with it are returned.
 func (e Expenses) GetExpensesByDate(
 	ctx context.Context,
 	req *expenses.ExpensesGetRequestByDate,
 ) (*expenses.ExpensesGetResponse, error) {
 	log.Printf("GetExpenseByDate was invoked with %v\n", req)
 
+	reportingCurrency, err := optionalCurrency(req.Currency)
+	if err != nil {
+		return &expenses.ExpensesGetResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	expenseViewRecords, err := e.ExpensesRepository.GetExpensesByDates(
 		ctx,
+		userIDFromContext(ctx),
 		unixToTime(req.MinDate),
 		unixToTime(req.MaxDate),
 	)
@@ -119,6 +282,15 @@ func (e Expenses) GetExpensesByDate(
 		return &expenses.ExpensesGetResponse{}, fmt.Errorf("could not get expenses by date: %w", err)
 	}
 
+	expenseViewRecords = tags.Expenses(expenseViewRecords, req.Tag)
+
+	if reportingCurrency != "" {
+		expenseViewRecords, err = e.Rates.ConvertExpenses(expenseViewRecords, reportingCurrency)
+		if err != nil {
+			return &expenses.ExpensesGetResponse{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
 	responseExpenses := expensesViewToExpensesGetResponse(expenseViewRecords)
 
 	return &expenses.ExpensesGetResponse{
@@ -126,26 +298,26 @@ func (e Expenses) GetExpensesByDate(
 	}, nil
 }
 
-// GetExpensesByCategory gets the expenses from the database that match the category provided
+// GetExpensesByCategory gets the expenses from the database that match the category provided, and the tag if there is one
 func (e Expenses) GetExpensesByCategory(
 	ctx context.Context,
 	req *expenses.ExpensesGetRequestByCategory,
 ) (*expenses.ExpensesGetResponse, error) {
 	log.Printf("GetExpenseByCategory was invoked with %v\n", req)
 
-	expenseViewRecords, err := e.ExpensesRepository.GetExpensesByCategory(ctx, req.Category)
+	expenseViewRecords, err := e.ExpensesRepository.GetExpensesByCategory(ctx, userIDFromContext(ctx), req.Category)
 	if err != nil {
 		return &expenses.ExpensesGetResponse{}, fmt.Errorf("could not get expenses by category: %w", err)
 	}
 
-	responseExpenses := expensesViewToExpensesGetResponse(expenseViewRecords)
+	responseExpenses := expensesViewToExpensesGetResponse(tags.Expenses(expenseViewRecords, req.Tag))
 
 	return &expenses.ExpensesGetResponse{
 		Expenses: responseExpenses,
 	}, nil
 }
 
-// GetExpensesBySubCategory gets the expenses from the database that match the subcategory provided
+// GetExpensesBySubCategory gets the expenses from the database that match the subcategory provided, and the tag if there is one
 func (e Expenses) GetExpensesBySubCategory(
 	ctx context.Context,
 	req *expenses.ExpensesGetRequestBySubCategory,
@@ -153,19 +325,19 @@ func (e Expenses) GetExpensesBySubCategory(
 
 	log.Printf("GetExpensesBySubCategory was invoked with %v\n", req)
 
-	expenseViewRecords, err := e.ExpensesRepository.GetExpensesBySubCategory(ctx, req.SubCategory)
+	expenseViewRecords, err := e.ExpensesRepository.GetExpensesBySubCategory(ctx, userIDFromContext(ctx), req.SubCategory)
 	if err != nil {
 		return &expenses.ExpensesGetResponse{}, fmt.Errorf("could not get expenses by subcategory: %v", err)
 	}
 
-	responseExpenses := expensesViewToExpensesGetResponse(expenseViewRecords)
+	responseExpenses := expensesViewToExpensesGetResponse(tags.Expenses(expenseViewRecords, req.Tag))
 
 	return &expenses.ExpensesGetResponse{
 		Expenses: responseExpenses,
 	}, nil
 }
 
-// GetExpensesByCard gets the expenses from the database that match the card provided
+// GetExpensesByCard gets the expenses from the database that match the card provided, and the tag if there is one
 func (e Expenses) GetExpensesByCard(
 	ctx context.Context,
 	req *expenses.ExpensesGetRequestByCard,
@@ -173,18 +345,104 @@ func (e Expenses) GetExpensesByCard(
 
 	log.Printf("GetExpensesByCard was invoked with %v\n", req)
 
-	expenseViewRecords, err := e.ExpensesRepository.GetExpensesByCard(ctx, req.Card)
+	expenseViewRecords, err := e.ExpensesRepository.GetExpensesByCard(ctx, userIDFromContext(ctx), req.Card)
 	if err != nil {
 		return &expenses.ExpensesGetResponse{}, fmt.Errorf("could not get expenses by card: %v", err)
 	}
 
-	responseExpenses := expensesViewToExpensesGetResponse(expenseViewRecords)
+	responseExpenses := expensesViewToExpensesGetResponse(tags.Expenses(expenseViewRecords, req.Tag))
 
 	return &expenses.ExpensesGetResponse{
 		Expenses: responseExpenses,
 	}, nil
 }
 
+// SearchExpenses gets a page of the expenses that match the dates, category, subcategory, card, values, description
+// and tag of the request, sorted by date or value
+func (e Expenses) SearchExpenses(
+	ctx context.Context,
+	req *expenses.ExpensesSearchRequest,
+) (*expenses.ExpensesSearchResponse, error) {
+
+	log.Printf("SearchExpenses was invoked with %v\n", req)
+
+	query := search.Query{
+		Category:    req.Category,
+		SubCategory: req.SubCategory,
+		Card:        req.Card,
+		MinValue:    req.MinValue,
+		MaxValue:    req.MaxValue,
+		Description: req.Description,
+		Tag:         req.Tag,
+		SortBy:      req.SortBy,
+		Order:       req.Order,
+		Limit:       int(req.Limit),
+		Cursor:      req.Cursor,
+	}
+	if req.MinDate != 0 {
+		query.MinDate = unixToTime(req.MinDate)
+	}
+	if req.MaxDate != 0 {
+		query.MaxDate = unixToTime(req.MaxDate)
+	}
+
+	page, err := search.Expenses(ctx, e.ExpensesRepository, userIDFromContext(ctx), query)
+	if errors.Is(err, search.ErrInvalidSearch) || errors.Is(err, search.ErrInvalidCursor) {
+		return &expenses.ExpensesSearchResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		log.Printf("grpc - could not search expenses %v", err)
+		return &expenses.ExpensesSearchResponse{}, fmt.Errorf("could not search expenses")
+	}
+
+	return &expenses.ExpensesSearchResponse{
+		Expenses:   expensesViewToExpensesGetResponse(page.Expenses),
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// GetExpensesTotals sums the expenses in the provided dates interval by category, subcategory, card, day, week or month.
+// Without a reporting currency, the totals of each currency are kept apart.
+func (e Expenses) GetExpensesTotals(
+	ctx context.Context,
+	req *expenses.ExpensesTotalsRequest,
+) (*expenses.ExpensesTotalsResponse, error) {
+
+	log.Printf("GetExpensesTotals was invoked with %v\n", req)
+
+	groupBy, err := summary.ParseGroupBy(req.GroupBy)
+	if err != nil {
+		return &expenses.ExpensesTotalsResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	totals, err := e.Summarizer.ExpenseTotals(
+		ctx,
+		userIDFromContext(ctx),
+		groupBy,
+		unixToTime(req.MinDate),
+		unixToTime(req.MaxDate),
+		req.Currency,
+	)
+	if err != nil {
+		log.Printf("grpc - could not get expenses totals %v", err)
+		return &expenses.ExpensesTotalsResponse{}, summaryError(err, "could not get expenses totals")
+	}
+
+	responseTotals := []*expenses.ExpensesTotal{}
+	for _, total := range totals {
+		responseTotals = append(responseTotals, &expenses.ExpensesTotal{
+			Key:      total.Key,
+			Currency: total.Currency,
+			Value:    total.Value.String(),
+			Count:    total.Count,
+		})
+	}
+
+	return &expenses.ExpensesTotalsResponse{
+		Totals: responseTotals,
+	}, nil
+}
+
 func unixToTime(unix int64) time.Time {
 	return time.Unix(unix, 0).UTC()
 }
@@ -193,28 +451,103 @@ func timeToUnix(date time.Time) int64 {
 	return date.UTC().Unix()
 }
 
+// optionalCurrency validates a currency that may be missing, which is left empty
+func optionalCurrency(code string) (string, error) {
+	if code == "" {
+		return "", nil
+	}
+	return currency.Normalize(code)
+}
+
+// summaryError returns an InvalidArgument status for an invalid grouping, period or currency, or a missing exchange rate
+func summaryError(err error, msg string) error {
+	if errors.Is(err, summary.ErrInvalidGroupBy) || errors.Is(err, summary.ErrInvalidPeriod) ||
+		errors.Is(err, currency.ErrInvalidCurrency) || errors.Is(err, currency.ErrNoRate) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return errors.New(msg)
+}
+
+// getExpenseSubcategoryAndCardIDByNames resolves the subcategory and card names of an expense.
+// Without a subcategory name, the subcategory is picked by the categorization rules.
 func (e Expenses) getExpenseSubcategoryAndCardIDByNames(
 	ctx context.Context,
+	userID int64,
 	subCategory, card string,
+	value models.Money,
+	description string,
 ) (models.ExpenseSubCategoryTable, models.CardTable, error) {
 
-	subCategoryModel, err := e.ExpensesSubCategoryRepository.GetExpenseSubCategoryByName(ctx, subCategory)
+	cardModel, err := e.CardRepository.GetCardByName(ctx, userID, card)
 	if err != nil {
 		return models.ExpenseSubCategoryTable{},
 			models.CardTable{},
-			fmt.Errorf("could not get expense sub category by name: %v", err)
+			fmt.Errorf("could not get expense card by name: %v", err)
+	}
+
+	if subCategory == "" {
+		subCategoryID, err := e.Categorizer.ExpenseSubCategoryID(ctx, userID, cardModel.ID, value, description)
+		if err != nil {
+			return models.ExpenseSubCategoryTable{},
+				models.CardTable{},
+				fmt.Errorf("could not categorize expense: %w", err)
+		}
+		return models.ExpenseSubCategoryTable{ID: subCategoryID}, cardModel, nil
 	}
 
-	cardModel, err := e.CardRepository.GetCardByName(ctx, card)
+	subCategoryModel, err := e.ExpensesSubCategoryRepository.GetExpenseSubCategoryByName(ctx, subCategory)
 	if err != nil {
 		return models.ExpenseSubCategoryTable{},
 			models.CardTable{},
-			fmt.Errorf("could not get expense card by name: %v", err)
+			fmt.Errorf("could not get expense sub category by name: %v", err)
 	}
 
 	return subCategoryModel, cardModel, nil
 }
 
+// getExpenseSplits parses the split lines of an expense, resolves their subcategory names and validates them
+func (e Expenses) getExpenseSplits(
+	ctx context.Context,
+	value models.Money,
+	lines []*expenses.ExpenseSplit,
+) ([]models.ExpenseSplitTable, error) {
+
+	var expenseSplits []models.ExpenseSplitTable
+	for idx, line := range lines {
+
+		lineValue, err := models.ParseMoney(line.Value)
+		if err != nil {
+			return []models.ExpenseSplitTable{}, fmt.Errorf("split line %d: %v", idx, err)
+		}
+
+		subCategoryModel, err := e.ExpensesSubCategoryRepository.GetExpenseSubCategoryByName(ctx, line.SubCategory)
+		if err != nil {
+			return []models.ExpenseSplitTable{}, fmt.Errorf("split line %d: could not get expense sub category by name: %v", idx, err)
+		}
+
+		expenseSplits = append(expenseSplits, models.ExpenseSplitTable{
+			SubCategoryID: subCategoryModel.ID,
+			Value:         lineValue,
+			Note:          line.Note,
+		})
+	}
+
+	err := splits.Validate(models.ExpenseTable{Value: value, Splits: expenseSplits})
+	if err != nil {
+		return []models.ExpenseSplitTable{}, err
+	}
+
+	return expenseSplits, nil
+}
+
+// parentSubCategory returns the subcategory of an expense, which is the one of its first line if it is split
+func parentSubCategory(subCategory string, lines []*expenses.ExpenseSplit) string {
+	if len(lines) > 0 {
+		return lines[0].SubCategory
+	}
+	return subCategory
+}
+
 func expensesViewToExpensesGetResponse(
 	expenseViewRecords []models.ExpenseView,
 ) []*expenses.ExpenseGetResponse {
@@ -227,12 +560,23 @@ func expensesViewToExpensesGetResponse(
 
 		responseExpense := expenses.ExpenseGetResponse{
 			Id:          exp.ID,
-			Value:       exp.Value,
+			Value:       exp.Value.String(),
 			Date:        unixDate,
 			Category:    exp.Category,
 			SubCategory: exp.SubCategory,
 			Card:        exp.Card,
 			Description: exp.Description,
+			Currency:    exp.Currency,
+			Tags:        exp.Tags,
+		}
+
+		for _, line := range exp.Splits {
+			responseExpense.Splits = append(responseExpense.Splits, &expenses.ExpenseSplit{
+				Value:       line.Value.String(),
+				SubCategory: line.SubCategory,
+				Note:        line.Note,
+				Category:    line.Category,
+			})
 		}
 
 		responseExpenses = append(responseExpenses, &responseExpense)
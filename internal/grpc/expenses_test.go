@@ -6,11 +6,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/duplicates"
 	grpc "github.com/rubengomes8/golang-personal-finances/internal/pb/expenses"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/summary"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -18,7 +23,7 @@ var (
 	firstFebruary2020Unix             = int64(1580515200)
 
 	houseRentGRPCExpenseCreateRequest = grpc.ExpenseCreateRequest{
-		Value:       10.0,
+		Value:       "10.00",
 		Date:        firstFebruary2020Unix,
 		Category:    "House",
 		SubCategory: "Rent",
@@ -32,7 +37,7 @@ var (
 
 	houseRentExpenseTable = models.ExpenseTable{
 		ID:            1,
-		Value:         10.0,
+		Value:         models.MustParseMoney("10"),
 		Date:          firstFebruary2020ZeroHoursUTCTime,
 		SubCategoryID: 1,
 		CardID:        1,
@@ -41,12 +46,22 @@ var (
 
 	restaurantExpenseTable = models.ExpenseTable{
 		ID:            2,
-		Value:         20.0,
+		Value:         models.MustParseMoney("20"),
 		Date:          firstFebruary2020ZeroHoursUTCTime,
 		SubCategoryID: 2,
 		CardID:        2,
 		Description:   "Test",
 	}
+
+	otherUserExpenseTable = models.ExpenseTable{
+		ID:            3,
+		Value:         models.MustParseMoney("30"),
+		Date:          firstFebruary2020ZeroHoursUTCTime,
+		SubCategoryID: 1,
+		CardID:        1,
+		Description:   "Other user",
+		UserID:        2,
+	}
 )
 
 // REPO
@@ -162,13 +177,60 @@ func TestExpenses_CreateExpense(t *testing.T) {
 			},
 			args: args{
 				ctx: context.Background(),
-				req: &houseRentGRPCExpenseCreateRequest,
+				req: &grpc.ExpenseCreateRequest{
+					Value:       "15.00",
+					Date:        firstFebruary2020Unix,
+					Category:    "House",
+					SubCategory: "Rent",
+					Card:        "CGD",
+					Description: "Test",
+				},
 			},
 			want: want{
 				response: &houseRentGRPCExpenseCreateResponse,
 			},
 			wantErr: false,
 		},
+		{
+			name: "SuccessForcedLikelyDuplicate",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+				CardRepository:                &cardsCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseCreateRequest{
+					Value:       houseRentGRPCExpenseCreateRequest.Value,
+					Date:        houseRentGRPCExpenseCreateRequest.Date,
+					Category:    houseRentGRPCExpenseCreateRequest.Category,
+					SubCategory: houseRentGRPCExpenseCreateRequest.SubCategory,
+					Card:        houseRentGRPCExpenseCreateRequest.Card,
+					Description: houseRentGRPCExpenseCreateRequest.Description,
+					Force:       true,
+				},
+			},
+			want: want{
+				response: &houseRentGRPCExpenseCreateResponse,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorLikelyDuplicate",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+				CardRepository:                &cardsCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &houseRentGRPCExpenseCreateRequest,
+			},
+			want: want{
+				errorMsg: "expense is a likely duplicate - set force to create it anyway",
+			},
+			wantErr: true,
+		},
 		{
 			name: "ErrorUnknownCard",
 			fields: fields{
@@ -179,7 +241,7 @@ func TestExpenses_CreateExpense(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				req: &grpc.ExpenseCreateRequest{
-					Value:       10.0,
+					Value:       "10.00",
 					Date:        firstFebruary2020Unix,
 					Category:    "House",
 					SubCategory: "Rent",
@@ -192,6 +254,29 @@ func TestExpenses_CreateExpense(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ErrorInvalidTag",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+				CardRepository:                &cardsCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseCreateRequest{
+					Value:       "10.00",
+					Date:        firstFebruary2020Unix,
+					SubCategory: "Rent",
+					Card:        "CGD",
+					Description: "Test",
+					Tags:        []string{"work,trip"},
+				},
+			},
+			want: want{
+				errorMsg: "tag is not valid",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -200,6 +285,7 @@ func TestExpenses_CreateExpense(t *testing.T) {
 				ExpensesRepository:            tt.fields.ExpensesRepository,
 				ExpensesSubCategoryRepository: tt.fields.ExpensesSubCategoryRepository,
 				CardRepository:                tt.fields.CardRepository,
+				DuplicatesDetector:            duplicates.NewDetector(),
 			}
 
 			got, err := s.CreateExpense(tt.args.ctx, tt.args.req)
@@ -221,11 +307,125 @@ func TestExpenses_CreateExpense(t *testing.T) {
 	}
 }
 
+func TestExpenses_CreateExpenses(t *testing.T) {
+
+	expenses := []models.ExpenseTable{
+		houseRentExpenseTable,
+		restaurantExpenseTable,
+	}
+	expensesCache := cache.NewExpense(expenses, cardsCache, categoriesCache, subCategoriesCache)
+
+	type fields struct {
+		ExpensesRepository            repository.ExpenseRepo
+		ExpensesSubCategoryRepository repository.ExpenseSubCategoryRepo
+		CardRepository                repository.CardRepo
+	}
+
+	type args struct {
+		ctx context.Context
+		req *grpc.ExpensesCreateRequest
+	}
+
+	type want struct {
+		response *grpc.ExpensesCreateResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+				CardRepository:                &cardsCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpensesCreateRequest{
+					Expenses: []*grpc.ExpenseCreateRequest{
+						&houseRentGRPCExpenseCreateRequest,
+						&houseRentGRPCExpenseCreateRequest,
+					},
+				},
+			},
+			want: want{
+				response: &grpc.ExpensesCreateResponse{
+					Ids: []*grpc.ExpenseCreateResponse{
+						{Id: 1},
+						{Id: 1},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorUnknownCardOnSecondExpense",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+				CardRepository:                &cardsCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpensesCreateRequest{
+					Expenses: []*grpc.ExpenseCreateRequest{
+						&houseRentGRPCExpenseCreateRequest,
+						{
+							Value:       "10.00",
+							Date:        firstFebruary2020Unix,
+							Category:    "House",
+							SubCategory: "Rent",
+							Card:        "Unknown",
+							Description: "Test",
+						},
+					},
+				},
+			},
+			want: want{
+				errorMsg: "expense 1: could not get expense subcategory and/or card by name",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &Expenses{
+				ExpensesRepository:            tt.fields.ExpensesRepository,
+				ExpensesSubCategoryRepository: tt.fields.ExpensesSubCategoryRepository,
+				CardRepository:                tt.fields.CardRepository,
+			}
+
+			got, err := s.CreateExpenses(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expenses.CreateExpenses() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("Expenses.CreateExpenses() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
+
 func TestExpenses_UpdateExpense(t *testing.T) {
 
 	expenses := []models.ExpenseTable{
 		houseRentExpenseTable,
 		restaurantExpenseTable,
+		otherUserExpenseTable,
 	}
 	expensesCache := cache.NewExpense(expenses, cardsCache, categoriesCache, subCategoriesCache)
 
@@ -263,7 +463,7 @@ func TestExpenses_UpdateExpense(t *testing.T) {
 				ctx: context.Background(),
 				req: &grpc.ExpenseUpdateRequest{
 					Id:          1,
-					Value:       200.0,
+					Value:       "200.00",
 					Date:        firstFebruary2020Unix,
 					Category:    "House",
 					SubCategory: "Rent",
@@ -278,6 +478,60 @@ func TestExpenses_UpdateExpense(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "SuccessSplit",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+				CardRepository:                &cardsCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseUpdateRequest{
+					Id:          2,
+					Value:       "80.00",
+					Date:        firstFebruary2020Unix,
+					Card:        "CGD",
+					Description: "Dinner and rent share",
+					Splits: []*grpc.ExpenseSplit{
+						{Value: "50.00", SubCategory: "Restaurants"},
+						{Value: "30.00", SubCategory: "Rent", Note: "Rent share"},
+					},
+				},
+			},
+			want: want{
+				response: &grpc.ExpenseUpdateResponse{
+					Id: 2,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorSplitDoesNotAddUp",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+				CardRepository:                &cardsCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseUpdateRequest{
+					Id:          2,
+					Value:       "80.00",
+					Date:        firstFebruary2020Unix,
+					Card:        "CGD",
+					Description: "Dinner and rent share",
+					Splits: []*grpc.ExpenseSplit{
+						{Value: "50.00", SubCategory: "Restaurants"},
+						{Value: "20.00", SubCategory: "Rent"},
+					},
+				},
+			},
+			want: want{
+				errorMsg: "split is not valid: the lines add up to 70.00 instead of 80.00",
+			},
+			wantErr: true,
+		},
 		{
 			name: "ErrorUnknownCard",
 			fields: fields{
@@ -288,7 +542,7 @@ func TestExpenses_UpdateExpense(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				req: &grpc.ExpenseUpdateRequest{
-					Value:       150.0,
+					Value:       "150.00",
 					Date:        firstFebruary2020Unix,
 					Category:    "House",
 					SubCategory: "Rent",
@@ -301,6 +555,30 @@ func TestExpenses_UpdateExpense(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ErrorExpenseOwnedByAnotherUser",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+				CardRepository:                &cardsCache,
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.ExpenseUpdateRequest{
+					Id:          3,
+					Value:       "150.00",
+					Date:        firstFebruary2020Unix,
+					Category:    "House",
+					SubCategory: "Rent",
+					Card:        "CGD",
+					Description: "Test",
+				},
+			},
+			want: want{
+				errorMsg: "expense with this id does not exist",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -379,7 +657,7 @@ func TestExpenses_GetExpensesByDate(t *testing.T) {
 					Expenses: []*grpc.ExpenseGetResponse{
 						{
 							Id:          houseRentExpenseTable.ID,
-							Value:       houseRentExpenseTable.Value,
+							Value:       houseRentExpenseTable.Value.String(),
 							Date:        firstFebruary2020Unix,
 							Category:    "House",
 							SubCategory: "Rent",
@@ -388,7 +666,7 @@ func TestExpenses_GetExpensesByDate(t *testing.T) {
 						},
 						{
 							Id:          restaurantExpenseTable.ID,
-							Value:       restaurantExpenseTable.Value,
+							Value:       restaurantExpenseTable.Value.String(),
 							Date:        firstFebruary2020Unix,
 							Category:    "Leisure",
 							SubCategory: "Restaurants",
@@ -429,6 +707,101 @@ func TestExpenses_GetExpensesByDate(t *testing.T) {
 	}
 }
 
+func TestExpenses_GetExpensesByDateInReportingCurrency(t *testing.T) {
+
+	cardsCache := cache.NewCard([]models.CardTable{
+		{ID: 1, Name: "CGD", Currency: "EUR"},
+		{ID: 2, Name: "Food allowance", Currency: "GBP"},
+	})
+	expensesCache := cache.NewExpense(
+		[]models.ExpenseTable{houseRentExpenseTable, restaurantExpenseTable},
+		cardsCache,
+		categoriesCache,
+		subCategoriesCache,
+	)
+
+	rates := currency.NewRates()
+	assert.NoError(t, rates.Add("USD", firstFebruary2020ZeroHoursUTCTime.AddDate(0, 0, -1), "1.1052"))
+	assert.NoError(t, rates.Add("GBP", firstFebruary2020ZeroHoursUTCTime.AddDate(0, 0, -1), "0.84183"))
+
+	s := &Expenses{
+		ExpensesRepository: &expensesCache,
+		Rates:              rates,
+	}
+
+	got, err := s.GetExpensesByDate(context.Background(), &grpc.ExpensesGetRequestByDate{
+		MinDate:  int64(1580515150),
+		MaxDate:  int64(1580515250),
+		Currency: "USD",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"11.05", "26.26"}, []string{got.Expenses[0].Value, got.Expenses[1].Value})
+	assert.Equal(t, []string{"USD", "USD"}, []string{got.Expenses[0].Currency, got.Expenses[1].Currency})
+
+	_, err = s.GetExpensesByDate(context.Background(), &grpc.ExpensesGetRequestByDate{Currency: "dollars"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.GetExpensesByDate(context.Background(), &grpc.ExpensesGetRequestByDate{
+		MinDate:  int64(1580515150),
+		MaxDate:  int64(1580515250),
+		Currency: "JPY",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestExpenses_GetExpensesTotals(t *testing.T) {
+
+	cardsCache := cache.NewCard([]models.CardTable{
+		{ID: 1, Name: "CGD", Currency: "EUR"},
+		{ID: 2, Name: "Food allowance", Currency: "GBP"},
+	})
+	expensesCache := cache.NewExpense(
+		[]models.ExpenseTable{houseRentExpenseTable, restaurantExpenseTable},
+		cardsCache,
+		categoriesCache,
+		subCategoriesCache,
+	)
+
+	rates := currency.NewRates()
+	assert.NoError(t, rates.Add("USD", firstFebruary2020ZeroHoursUTCTime.AddDate(0, 0, -1), "1.1052"))
+	assert.NoError(t, rates.Add("GBP", firstFebruary2020ZeroHoursUTCTime.AddDate(0, 0, -1), "0.84183"))
+
+	s := &Expenses{
+		Summarizer: summary.NewSummarizer(&expensesCache, nil, rates),
+	}
+
+	got, err := s.GetExpensesTotals(context.Background(), &grpc.ExpensesTotalsRequest{
+		MinDate: int64(1580515150),
+		MaxDate: int64(1580515250),
+		GroupBy: "card",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(got.Totals))
+	assert.Equal(t, []string{"CGD", "EUR", "10.00"}, []string{got.Totals[0].Key, got.Totals[0].Currency, got.Totals[0].Value})
+	assert.Equal(t, []string{"Food allowance", "GBP", "20.00"}, []string{got.Totals[1].Key, got.Totals[1].Currency, got.Totals[1].Value})
+
+	got, err = s.GetExpensesTotals(context.Background(), &grpc.ExpensesTotalsRequest{
+		MinDate:  int64(1580515150),
+		MaxDate:  int64(1580515250),
+		GroupBy:  "month",
+		Currency: "USD",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(got.Totals))
+	assert.Equal(t, []string{"2020-02", "USD", "37.31"}, []string{got.Totals[0].Key, got.Totals[0].Currency, got.Totals[0].Value})
+	assert.Equal(t, int64(2), got.Totals[0].Count)
+
+	_, err = s.GetExpensesTotals(context.Background(), &grpc.ExpensesTotalsRequest{GroupBy: "year"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.GetExpensesTotals(context.Background(), &grpc.ExpensesTotalsRequest{
+		MinDate:  int64(1580515150),
+		MaxDate:  int64(1580515250),
+		Currency: "JPY",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestExpenses_GetExpensesByCategory(t *testing.T) {
 
 	expenses := []models.ExpenseTable{
@@ -436,7 +809,7 @@ func TestExpenses_GetExpensesByCategory(t *testing.T) {
 		restaurantExpenseTable,
 		{
 			ID:            3,
-			Value:         200.0,
+			Value:         models.MustParseMoney("200"),
 			Date:          firstFebruary2020ZeroHoursUTCTime,
 			SubCategoryID: 1,
 			CardID:        1,
@@ -486,7 +859,7 @@ func TestExpenses_GetExpensesByCategory(t *testing.T) {
 					Expenses: []*grpc.ExpenseGetResponse{
 						{
 							Id:          houseRentExpenseTable.ID,
-							Value:       houseRentExpenseTable.Value,
+							Value:       houseRentExpenseTable.Value.String(),
 							Date:        firstFebruary2020Unix,
 							Category:    "House",
 							SubCategory: "Rent",
@@ -495,7 +868,7 @@ func TestExpenses_GetExpensesByCategory(t *testing.T) {
 						},
 						{
 							Id:          3,
-							Value:       200.0,
+							Value:       "200.00",
 							Date:        firstFebruary2020Unix,
 							Category:    "House",
 							SubCategory: "Rent",
@@ -561,7 +934,7 @@ func TestExpenses_GetExpensesBySubCategory(t *testing.T) {
 		restaurantExpenseTable,
 		{
 			ID:            3,
-			Value:         200.0,
+			Value:         models.MustParseMoney("200"),
 			Date:          firstFebruary2020ZeroHoursUTCTime,
 			SubCategoryID: 1,
 			CardID:        1,
@@ -611,7 +984,7 @@ func TestExpenses_GetExpensesBySubCategory(t *testing.T) {
 					Expenses: []*grpc.ExpenseGetResponse{
 						{
 							Id:          houseRentExpenseTable.ID,
-							Value:       houseRentExpenseTable.Value,
+							Value:       houseRentExpenseTable.Value.String(),
 							Date:        firstFebruary2020Unix,
 							Category:    "House",
 							SubCategory: "Rent",
@@ -620,7 +993,7 @@ func TestExpenses_GetExpensesBySubCategory(t *testing.T) {
 						},
 						{
 							Id:          3,
-							Value:       200.0,
+							Value:       "200.00",
 							Date:        firstFebruary2020Unix,
 							Category:    "House",
 							SubCategory: "Rent",
@@ -686,7 +1059,7 @@ func TestExpenses_GetExpensesByCard(t *testing.T) {
 		restaurantExpenseTable,
 		{
 			ID:            3,
-			Value:         200.0,
+			Value:         models.MustParseMoney("200"),
 			Date:          firstFebruary2020ZeroHoursUTCTime,
 			SubCategoryID: 1,
 			CardID:        1,
@@ -736,7 +1109,7 @@ func TestExpenses_GetExpensesByCard(t *testing.T) {
 					Expenses: []*grpc.ExpenseGetResponse{
 						{
 							Id:          houseRentExpenseTable.ID,
-							Value:       houseRentExpenseTable.Value,
+							Value:       houseRentExpenseTable.Value.String(),
 							Date:        firstFebruary2020Unix,
 							Category:    "House",
 							SubCategory: "Rent",
@@ -745,7 +1118,7 @@ func TestExpenses_GetExpensesByCard(t *testing.T) {
 						},
 						{
 							Id:          3,
-							Value:       200.0,
+							Value:       "200.00",
 							Date:        firstFebruary2020Unix,
 							Category:    "House",
 							SubCategory: "Rent",
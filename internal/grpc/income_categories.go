@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	inccategoriespb "github.com/rubengomes8/golang-personal-finances/internal/pb/incomes/categories"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// IncomeCategories implements income categories ServiceServer methods
+type IncomeCategories struct {
+	inccategoriespb.ServiceServer
+	CategoryRepository repository.IncomeCategoryRepo
+}
+
+// NewIncomeCategories creates a new IncomeCategories service
+func NewIncomeCategories(catRepo repository.IncomeCategoryRepo) (IncomeCategories, error) {
+	return IncomeCategories{
+		CategoryRepository: catRepo,
+	}, nil
+}
+
+// Create creates an income category on the database
+func (i IncomeCategories) Create(
+	ctx context.Context,
+	req *inccategoriespb.CreateRequest,
+) (*inccategoriespb.CreateResponse, error) {
+
+	id, err := i.CategoryRepository.InsertIncomeCategory(ctx, models.IncomeCategoryTable{
+		Name: req.Name,
+	})
+	if err != nil {
+		log.Printf("grpc - could not insert income category: %v", err)
+		return &inccategoriespb.CreateResponse{}, fmt.Errorf("could not insert income category")
+	}
+
+	return &inccategoriespb.CreateResponse{
+		Id: id,
+	}, nil
+}
+
+// GetByName gets an income category from the database that matches the name provided
+func (i IncomeCategories) GetByName(
+	ctx context.Context,
+	req *inccategoriespb.GetRequestByName,
+) (*inccategoriespb.GetResponse, error) {
+
+	category, err := i.CategoryRepository.GetIncomeCategoryByName(ctx, req.Name)
+	if err != nil {
+		log.Printf("grpc - could not get income category by name: %v", err)
+		return &inccategoriespb.GetResponse{}, fmt.Errorf("could not get income category by name")
+	}
+
+	return &inccategoriespb.GetResponse{
+		Id:   category.ID,
+		Name: category.Name,
+	}, nil
+}
+
+// Merge merges an income category into another one, moving its incomes, rules and recurring transactions to it
+// and deleting it, or only counts what would move on a dry run
+func (i IncomeCategories) Merge(
+	ctx context.Context,
+	req *inccategoriespb.MergeRequest,
+) (*inccategoriespb.MergeResponse, error) {
+
+	merge, err := mergeCategory("income category", req.Id, req.Into,
+		func(id int64) error {
+			_, err := i.CategoryRepository.GetIncomeCategoryByID(ctx, id)
+			return err
+		},
+		func() (models.CategoryMerge, error) {
+			return i.CategoryRepository.MergeIncomeCategory(ctx, req.Id, req.Into, req.DryRun)
+		},
+	)
+	if err != nil {
+		return &inccategoriespb.MergeResponse{}, err
+	}
+
+	return &inccategoriespb.MergeResponse{
+		DryRun:                req.DryRun,
+		Incomes:               merge.Transactions,
+		Rules:                 merge.Rules,
+		RecurringTransactions: merge.RecurringTransactions,
+	}, nil
+}
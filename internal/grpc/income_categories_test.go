@@ -0,0 +1,270 @@
+package grpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	grpc "github.com/rubengomes8/golang-personal-finances/internal/pb/incomes/categories"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncomeCategories_Create(t *testing.T) {
+
+	type fields struct {
+		CategoryRepository repository.IncomeCategoryRepo
+	}
+
+	type args struct {
+		ctx context.Context
+		req *grpc.CreateRequest
+	}
+
+	type want struct {
+		response *grpc.CreateResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			fields: fields{
+				CategoryRepository: mock.NewIncomeCategory(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.CreateRequest{
+					Name: mock.IncomeSalaryCategoryName,
+				},
+			},
+			want: want{
+				response: &grpc.CreateResponse{
+					Id: mock.IncomeSalaryCategory.ID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorInsertingCategory",
+			fields: fields{
+				CategoryRepository: mock.NewIncomeCategory(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.CreateRequest{
+					Name: "Unknown",
+				},
+			},
+			want: want{
+				errorMsg: "could not insert income category",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &IncomeCategories{
+				CategoryRepository: tt.fields.CategoryRepository,
+			}
+
+			got, err := s.Create(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IncomeCategories.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("IncomeCategories.Create() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
+
+func TestIncomeCategories_GetByName(t *testing.T) {
+
+	type fields struct {
+		CategoryRepository repository.IncomeCategoryRepo
+	}
+
+	type args struct {
+		ctx context.Context
+		req *grpc.GetRequestByName
+	}
+
+	type want struct {
+		response *grpc.GetResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			fields: fields{
+				CategoryRepository: mock.NewIncomeCategory(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.GetRequestByName{
+					Name: mock.IncomeSalaryCategoryName,
+				},
+			},
+			want: want{
+				response: &grpc.GetResponse{
+					Id:   mock.IncomeSalaryCategory.ID,
+					Name: mock.IncomeSalaryCategoryName,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorUnknownCategory",
+			fields: fields{
+				CategoryRepository: mock.NewIncomeCategory(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.GetRequestByName{
+					Name: "Unknown",
+				},
+			},
+			want: want{
+				errorMsg: "could not get income category by name",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &IncomeCategories{
+				CategoryRepository: tt.fields.CategoryRepository,
+			}
+
+			got, err := s.GetByName(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IncomeCategories.GetByName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("IncomeCategories.GetByName() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
+
+func TestIncomeCategories_Merge(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		req *grpc.MergeRequest
+	}
+
+	type want struct {
+		response *grpc.MergeResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.MergeRequest{
+					Id:     2,
+					Into:   mock.IncomeSalaryCategory.ID,
+					DryRun: true,
+				},
+			},
+			want: want{
+				response: &grpc.MergeResponse{
+					DryRun:  true,
+					Incomes: 1,
+					Rules:   1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorMergeIntoItself",
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.MergeRequest{
+					Id:   mock.IncomeSalaryCategory.ID,
+					Into: mock.IncomeSalaryCategory.ID,
+				},
+			},
+			want: want{
+				errorMsg: "into must be the id of another income category",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ErrorUnknownTarget",
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.MergeRequest{
+					Id:   mock.IncomeSalaryCategory.ID,
+					Into: 2,
+				},
+			},
+			want: want{
+				errorMsg: "could not merge income category",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &IncomeCategories{
+				CategoryRepository: mock.NewIncomeCategory(),
+			}
+
+			got, err := s.Merge(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IncomeCategories.Merge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("IncomeCategories.Merge() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
@@ -2,12 +2,22 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
+	"github.com/rubengomes8/golang-personal-finances/internal/categorization"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/duplicates"
 	"github.com/rubengomes8/golang-personal-finances/internal/pb/incomes"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/search"
+	"github.com/rubengomes8/golang-personal-finances/internal/summary"
+	"github.com/rubengomes8/golang-personal-finances/internal/tags"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -17,6 +27,10 @@ type Incomes struct {
 	Repository         repository.IncomeRepo
 	CategoryRepository repository.IncomeCategoryRepo
 	CardRepository     repository.CardRepo
+	DuplicatesDetector duplicates.Detector
+	Categorizer        categorization.Categorizer
+	Rates              currency.Rates
+	Summarizer         summary.Summarizer
 }
 
 // NewIncomes creates a new Incomes service
@@ -29,34 +43,76 @@ func NewIncomes(
 		Repository:         repo,
 		CategoryRepository: catRepo,
 		CardRepository:     cardRepo,
+		DuplicatesDetector: duplicates.NewDetector(),
 	}, nil
 }
 
-// CreateIncome creates an income on the database
-func (i *Incomes) CreateIncome(
+// Create creates an income on the database.
+// A likely duplicate of an existing income is refused with an AlreadyExists status, listing the candidates
+// in its details, unless the request is forced.
+func (i Incomes) Create(
 	ctx context.Context,
 	req *incomes.CreateRequest,
 ) (*incomes.CreateResponse, error) {
 
-	card, err := i.CardRepository.GetCardByName(ctx, req.Card)
+	userID := userIDFromContext(ctx)
+
+	value, err := models.ParseMoney(req.Value)
+	if err != nil {
+		return &incomes.CreateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	incomeCurrency, err := optionalCurrency(req.Currency)
+	if err != nil {
+		return &incomes.CreateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	incomeTags, err := tags.Normalize(req.Tags)
+	if err != nil {
+		return &incomes.CreateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	card, err := i.CardRepository.GetCardByName(ctx, userID, req.Card)
 	if err != nil {
 		log.Printf("grpc - could not get card by name: %v", err)
 		return &incomes.CreateResponse{}, fmt.Errorf("could not get income card by name")
 
 	}
 
-	category, err := i.CategoryRepository.GetIncomeCategoryByName(ctx, req.Category)
+	categoryID, err := i.getIncomeCategoryID(ctx, userID, card.ID, req.Category, value, req.Description)
 	if err != nil {
 		log.Printf("grpc - could not get income category by name: %v", err)
-		return &incomes.CreateResponse{}, fmt.Errorf("could not get income category by name")
+		return &incomes.CreateResponse{}, errors.New(incomeCategoryErrorMsg(err))
 	}
 
 	incomeRecord := models.IncomeTable{
-		Value:       req.Value,
+		Value:       value,
+		Currency:    incomeCurrency,
 		Date:        req.Date.AsTime(),
-		CategoryID:  category.ID,
+		CategoryID:  categoryID,
 		CardID:      card.ID,
 		Description: req.Description,
+		UserID:      userID,
+		Tags:        incomeTags,
+	}
+
+	if !req.Force {
+		minDate, maxDate := i.DuplicatesDetector.DateWindow(incomeRecord.Date)
+		candidates, err := i.Repository.GetIncomesByCardAndValue(ctx, userID, card.ID, value, minDate, maxDate)
+		if err != nil {
+			log.Printf("grpc - could not get income duplicate candidates: %v", err)
+			return &incomes.CreateResponse{}, fmt.Errorf("could not insert income")
+		}
+
+		likelyDuplicates := i.DuplicatesDetector.Incomes(candidates, incomeRecord)
+		if len(likelyDuplicates) > 0 {
+			details := []protoiface.MessageV1{}
+			for _, duplicate := range incomeViewsToIncomesGetResponse(likelyDuplicates) {
+				details = append(details, duplicate)
+			}
+			return &incomes.CreateResponse{}, likelyDuplicateError(
+				"income is a likely duplicate - set force to create it anyway", details)
+		}
 	}
 
 	id, err := i.Repository.InsertIncome(ctx, incomeRecord)
@@ -70,35 +126,128 @@ func (i *Incomes) CreateIncome(
 	}, nil
 }
 
-// UpdateIncome updates an income on the database
-func (i *Incomes) UpdateIncome(
+// CreateSeveral creates several incomes on the database in a single transaction
+func (i Incomes) CreateSeveral(
+	ctx context.Context,
+	req *incomes.CreateSeveralRequest,
+) (*incomes.CreateSeveralResponse, error) {
+
+	userID := userIDFromContext(ctx)
+
+	incomeRecords := make([]models.IncomeTable, 0, len(req.Incomes))
+	for idx, inc := range req.Incomes {
+
+		value, err := models.ParseMoney(inc.Value)
+		if err != nil {
+			return &incomes.CreateSeveralResponse{}, status.Errorf(codes.InvalidArgument, "income %d: %v", idx, err)
+		}
+
+		incomeCurrency, err := optionalCurrency(inc.Currency)
+		if err != nil {
+			return &incomes.CreateSeveralResponse{}, status.Errorf(codes.InvalidArgument, "income %d: %v", idx, err)
+		}
+
+		incomeTags, err := tags.Normalize(inc.Tags)
+		if err != nil {
+			return &incomes.CreateSeveralResponse{}, status.Errorf(codes.InvalidArgument, "income %d: %v", idx, err)
+		}
+
+		card, err := i.CardRepository.GetCardByName(ctx, userID, inc.Card)
+		if err != nil {
+			log.Printf("grpc - could not get card by name: %v", err)
+			return &incomes.CreateSeveralResponse{}, status.Errorf(codes.InvalidArgument, "income %d: could not get income card by name", idx)
+		}
+
+		categoryID, err := i.getIncomeCategoryID(ctx, userID, card.ID, inc.Category, value, inc.Description)
+		if err != nil {
+			log.Printf("grpc - could not get income category by name: %v", err)
+			return &incomes.CreateSeveralResponse{}, status.Errorf(codes.InvalidArgument, "income %d: %s", idx, incomeCategoryErrorMsg(err))
+		}
+
+		incomeRecords = append(incomeRecords, models.IncomeTable{
+			Value:       value,
+			Currency:    incomeCurrency,
+			Date:        inc.Date.AsTime(),
+			CategoryID:  categoryID,
+			CardID:      card.ID,
+			Description: inc.Description,
+			UserID:      userID,
+			Tags:        incomeTags,
+		})
+	}
+
+	ids, err := i.Repository.InsertIncomes(ctx, incomeRecords)
+	if err != nil {
+		log.Printf("grpc - could not insert incomes: %v", err)
+		var batchErr repository.BatchItemError
+		if errors.As(err, &batchErr) {
+			return &incomes.CreateSeveralResponse{}, status.Errorf(codes.Internal, "income %d: could not insert income", batchErr.Index)
+		}
+		return &incomes.CreateSeveralResponse{}, status.Error(codes.Internal, "could not insert incomes")
+	}
+
+	responseIDs := make([]*incomes.CreateResponse, 0, len(ids))
+	for _, id := range ids {
+		responseIDs = append(responseIDs, &incomes.CreateResponse{Id: id})
+	}
+
+	return &incomes.CreateSeveralResponse{
+		Ids: responseIDs,
+	}, nil
+}
+
+// Update updates an income on the database
+func (i Incomes) Update(
 	ctx context.Context,
 	req *incomes.UpdateRequest,
 ) (*incomes.UpdateResponse, error) {
 
-	card, err := i.CardRepository.GetCardByName(ctx, req.Card)
+	userID := userIDFromContext(ctx)
+
+	value, err := models.ParseMoney(req.Value)
+	if err != nil {
+		return &incomes.UpdateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	incomeCurrency, err := optionalCurrency(req.Currency)
+	if err != nil {
+		return &incomes.UpdateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	incomeTags, err := tags.Normalize(req.Tags)
+	if err != nil {
+		return &incomes.UpdateResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	card, err := i.CardRepository.GetCardByName(ctx, userID, req.Card)
 	if err != nil {
 		log.Printf("grpc - could not get card by name: %v", err)
 		return &incomes.UpdateResponse{}, fmt.Errorf("could not get income card by name: %w", err)
 
 	}
 
-	category, err := i.CategoryRepository.GetIncomeCategoryByName(ctx, req.Category)
+	categoryID, err := i.getIncomeCategoryID(ctx, userID, card.ID, req.Category, value, req.Description)
 	if err != nil {
 		log.Printf("grpc - could not get income category by name: %v", err)
-		return &incomes.UpdateResponse{}, fmt.Errorf("could not get income category by name: %w", err)
+		return &incomes.UpdateResponse{}, fmt.Errorf("%s: %w", incomeCategoryErrorMsg(err), err)
 	}
 
 	incomeRecord := models.IncomeTable{
 		ID:          req.Id,
-		Value:       req.Value,
+		Value:       value,
+		Currency:    incomeCurrency,
 		Date:        req.Date.AsTime(),
 		CardID:      card.ID,
-		CategoryID:  category.ID,
+		CategoryID:  categoryID,
 		Description: req.Description,
+		UserID:      userID,
+		Tags:        incomeTags,
 	}
 
 	id, err := i.Repository.UpdateIncome(ctx, incomeRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &incomes.UpdateResponse{}, status.Error(codes.NotFound, "income with this id does not exist")
+	}
 	if err != nil {
 		log.Printf("grpc - could not get update income: %v", err)
 		return &incomes.UpdateResponse{}, fmt.Errorf("could not update income: %w", err)
@@ -109,15 +258,23 @@ func (i *Incomes) UpdateIncome(
 	}, nil
 }
 
-// GetIncomesByDate gets the incomes from the database that are in the provided dates interval
-func (i *Incomes) GetIncomesByDate(
+// GetByDate gets the incomes from the database that are in the provided dates interval.
+// With a reporting currency, the value of each income is converted to it at the exchange rate of the income date.
+// With a tag, only the incomes with it are returned.
+func (i Incomes) GetByDate(
 	ctx context.Context,
 	req *incomes.GetRequestByDate,
 ) (*incomes.GetSeveralResponse, error) {
-	log.Printf("GetIncomesByDate was invoked with %v\n", req)
+	log.Printf("GetByDate was invoked with %v\n", req)
+
+	reportingCurrency, err := optionalCurrency(req.Currency)
+	if err != nil {
+		return &incomes.GetSeveralResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	incomeViewRecords, err := i.Repository.GetIncomesByDates(
 		ctx,
+		userIDFromContext(ctx),
 		req.MinDate.AsTime(),
 		req.MaxDate.AsTime(),
 	)
@@ -126,6 +283,15 @@ func (i *Incomes) GetIncomesByDate(
 		return &incomes.GetSeveralResponse{}, fmt.Errorf("could not get incomes by dates")
 	}
 
+	incomeViewRecords = tags.Incomes(incomeViewRecords, req.Tag)
+
+	if reportingCurrency != "" {
+		incomeViewRecords, err = i.Rates.ConvertIncomes(incomeViewRecords, reportingCurrency)
+		if err != nil {
+			return &incomes.GetSeveralResponse{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
 	responseIncomes := incomeViewsToIncomesGetResponse(incomeViewRecords)
 
 	return &incomes.GetSeveralResponse{
@@ -133,15 +299,16 @@ func (i *Incomes) GetIncomesByDate(
 	}, nil
 }
 
-// GetIncomesByCategory gets the incomes from the database that match the category provided
-func (i *Incomes) GetIncomesByCategory(
+// GetByCategory gets the incomes from the database that match the category provided, and the tag if there is one
+func (i Incomes) GetByCategory(
 	ctx context.Context,
 	req *incomes.GetRequestByCategory,
 ) (*incomes.GetSeveralResponse, error) {
-	log.Printf("GetIncomesByCategory was invoked with %v\n", req)
+	log.Printf("GetByCategory was invoked with %v\n", req)
 
 	incomeViewRecords, err := i.Repository.GetIncomesByCategory(
 		ctx,
+		userIDFromContext(ctx),
 		req.Category,
 	)
 	if err != nil {
@@ -149,22 +316,23 @@ func (i *Incomes) GetIncomesByCategory(
 		return &incomes.GetSeveralResponse{}, fmt.Errorf("could not get incomes by category")
 	}
 
-	responseIncomes := incomeViewsToIncomesGetResponse(incomeViewRecords)
+	responseIncomes := incomeViewsToIncomesGetResponse(tags.Incomes(incomeViewRecords, req.Tag))
 
 	return &incomes.GetSeveralResponse{
 		Incomes: responseIncomes,
 	}, nil
 }
 
-// GetIncomesByCard gets the incomes from the database that match the card provided
-func (i *Incomes) GetIncomesByCard(
+// GetByCard gets the incomes from the database that match the card provided, and the tag if there is one
+func (i Incomes) GetByCard(
 	ctx context.Context,
 	req *incomes.GetRequestByCard,
 ) (*incomes.GetSeveralResponse, error) {
-	log.Printf("GetIncomesByCard was invoked with %v\n", req)
+	log.Printf("GetByCard was invoked with %v\n", req)
 
 	incomeViewRecords, err := i.Repository.GetIncomesByCard(
 		ctx,
+		userIDFromContext(ctx),
 		req.Card,
 	)
 	if err != nil {
@@ -172,13 +340,168 @@ func (i *Incomes) GetIncomesByCard(
 		return &incomes.GetSeveralResponse{}, fmt.Errorf("could not get incomes by card")
 	}
 
-	responseIncomes := incomeViewsToIncomesGetResponse(incomeViewRecords)
+	responseIncomes := incomeViewsToIncomesGetResponse(tags.Incomes(incomeViewRecords, req.Tag))
 
 	return &incomes.GetSeveralResponse{
 		Incomes: responseIncomes,
 	}, nil
 }
 
+// Search gets a page of the incomes that match the dates, category, card, values, description and tag of the request,
+// sorted by date or value
+func (i Incomes) Search(
+	ctx context.Context,
+	req *incomes.SearchRequest,
+) (*incomes.SearchResponse, error) {
+	log.Printf("Search was invoked with %v\n", req)
+
+	query := search.Query{
+		Category:    req.Category,
+		Card:        req.Card,
+		MinValue:    req.MinValue,
+		MaxValue:    req.MaxValue,
+		Description: req.Description,
+		Tag:         req.Tag,
+		SortBy:      req.SortBy,
+		Order:       req.Order,
+		Limit:       int(req.Limit),
+		Cursor:      req.Cursor,
+	}
+	if req.MinDate != nil {
+		query.MinDate = req.MinDate.AsTime()
+	}
+	if req.MaxDate != nil {
+		query.MaxDate = req.MaxDate.AsTime()
+	}
+
+	page, err := search.Incomes(ctx, i.Repository, userIDFromContext(ctx), query)
+	if errors.Is(err, search.ErrInvalidSearch) || errors.Is(err, search.ErrInvalidCursor) {
+		return &incomes.SearchResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		log.Printf("grpc - could not search incomes %v", err)
+		return &incomes.SearchResponse{}, fmt.Errorf("could not search incomes")
+	}
+
+	return &incomes.SearchResponse{
+		Incomes:    incomeViewsToIncomesGetResponse(page.Incomes),
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// GetTotals sums the incomes in the provided dates interval by category, card, day, week or month.
+// Without a reporting currency, the totals of each currency are kept apart.
+func (i Incomes) GetTotals(
+	ctx context.Context,
+	req *incomes.TotalsRequest,
+) (*incomes.TotalsResponse, error) {
+	log.Printf("GetTotals was invoked with %v\n", req)
+
+	groupBy, err := summary.ParseGroupBy(req.GroupBy)
+	if err != nil {
+		return &incomes.TotalsResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	totals, err := i.Summarizer.IncomeTotals(
+		ctx,
+		userIDFromContext(ctx),
+		groupBy,
+		req.MinDate.AsTime(),
+		req.MaxDate.AsTime(),
+		req.Currency,
+	)
+	if err != nil {
+		log.Printf("grpc - could not get incomes totals %v", err)
+		return &incomes.TotalsResponse{}, summaryError(err, "could not get incomes totals")
+	}
+
+	responseTotals := []*incomes.Total{}
+	for _, total := range totals {
+		responseTotals = append(responseTotals, &incomes.Total{
+			Key:      total.Key,
+			Currency: total.Currency,
+			Value:    total.Value.String(),
+			Count:    total.Count,
+		})
+	}
+
+	return &incomes.TotalsResponse{
+		Totals: responseTotals,
+	}, nil
+}
+
+// GetCashFlow gets the incomes, expenses and net cash flow by day, week or month in the provided dates interval.
+// Without a reporting currency, the cash flows of each currency are kept apart.
+func (i Incomes) GetCashFlow(
+	ctx context.Context,
+	req *incomes.CashFlowRequest,
+) (*incomes.CashFlowResponse, error) {
+	log.Printf("GetCashFlow was invoked with %v\n", req)
+
+	period, err := summary.ParsePeriod(req.Period)
+	if err != nil {
+		return &incomes.CashFlowResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	cashFlows, err := i.Summarizer.CashFlow(
+		ctx,
+		userIDFromContext(ctx),
+		period,
+		req.MinDate.AsTime(),
+		req.MaxDate.AsTime(),
+		req.Currency,
+	)
+	if err != nil {
+		log.Printf("grpc - could not get cash flow %v", err)
+		return &incomes.CashFlowResponse{}, summaryError(err, "could not get cash flow")
+	}
+
+	responsePeriods := []*incomes.CashFlow{}
+	for _, cashFlow := range cashFlows {
+		responsePeriods = append(responsePeriods, &incomes.CashFlow{
+			Period:   cashFlow.Period,
+			Currency: cashFlow.Currency,
+			Incomes:  cashFlow.Incomes.String(),
+			Expenses: cashFlow.Expenses.String(),
+			Net:      cashFlow.Net.String(),
+		})
+	}
+
+	return &incomes.CashFlowResponse{
+		Periods: responsePeriods,
+	}, nil
+}
+
+// getIncomeCategoryID resolves the category name of an income.
+// Without a category name, the category is picked by the categorization rules.
+func (i Incomes) getIncomeCategoryID(
+	ctx context.Context,
+	userID int64,
+	cardID int64,
+	category string,
+	value models.Money,
+	description string,
+) (int64, error) {
+
+	if category == "" {
+		return i.Categorizer.IncomeCategoryID(ctx, userID, cardID, value, description)
+	}
+
+	categoryModel, err := i.CategoryRepository.GetIncomeCategoryByName(ctx, category)
+	if err != nil {
+		return 0, err
+	}
+
+	return categoryModel.ID, nil
+}
+
+func incomeCategoryErrorMsg(err error) string {
+	if errors.Is(err, categorization.ErrNoMatchingRule) {
+		return "category is missing and no categorization rule matches the income"
+	}
+	return "could not get income category by name"
+}
+
 func incomeViewsToIncomesGetResponse(
 	incomeViewRecords []models.IncomeView,
 ) []*incomes.GetResponse {
@@ -189,11 +512,13 @@ func incomeViewsToIncomesGetResponse(
 
 		responseIncome := incomes.GetResponse{
 			Id:          inc.ID,
-			Value:       inc.Value,
+			Value:       inc.Value.String(),
 			Date:        timestamppb.New(inc.Date),
 			Category:    inc.Category,
 			Card:        inc.Card,
 			Description: inc.Description,
+			Currency:    inc.Currency,
+			Tags:        inc.Tags,
 		}
 
 		responseIncomes = append(responseIncomes, &responseIncome)
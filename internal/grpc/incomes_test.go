@@ -0,0 +1,608 @@
+package grpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/duplicates"
+	grpc "github.com/rubengomes8/golang-personal-finances/internal/pb/incomes"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/mock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	salaryGRPCIncomeGetResponse = grpc.GetResponse{
+		Id:          mock.IncomeSalaryView.ID,
+		Value:       mock.IncomeSalaryView.Value.String(),
+		Date:        timestamppb.New(mock.IncomeSalaryView.Date),
+		Category:    mock.IncomeSalaryView.Category,
+		Card:        mock.IncomeSalaryView.Card,
+		Description: mock.IncomeSalaryView.Description,
+	}
+)
+
+type incomesFields struct {
+	Repository         repository.IncomeRepo
+	CategoryRepository repository.IncomeCategoryRepo
+	CardRepository     repository.CardRepo
+}
+
+var incomesMockFields = incomesFields{
+	Repository:         mock.NewIncome(),
+	CategoryRepository: mock.NewIncomeCategory(),
+	CardRepository:     mock.NewCard(),
+}
+
+func TestIncomes_Create(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		req *grpc.CreateRequest
+	}
+
+	type want struct {
+		response *grpc.CreateResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		fields  incomesFields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name:   "Success",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.CreateRequest{
+					Value:       mock.IncomeSalary.Value.String(),
+					Date:        timestamppb.New(mock.IncomeSalaryDate),
+					Category:    mock.IncomeSalaryCategoryName,
+					Card:        mock.IncomeSalaryCard.Name,
+					Description: mock.IncomeSalary.Description,
+				},
+			},
+			want: want{
+				response: &grpc.CreateResponse{
+					Id: mock.IncomeSalary.ID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "SuccessForcedLikelyDuplicate",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.CreateRequest{
+					Value:       mock.IncomeBonusView.Value.String(),
+					Date:        timestamppb.New(mock.IncomeBonusDate),
+					Category:    mock.IncomeSalaryCategoryName,
+					Card:        mock.IncomeSalaryCard.Name,
+					Description: mock.IncomeSalary.Description,
+					Force:       true,
+				},
+			},
+			want: want{
+				response: &grpc.CreateResponse{
+					Id: mock.IncomeSalary.ID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "ErrorLikelyDuplicate",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.CreateRequest{
+					Value:       mock.IncomeBonusView.Value.String(),
+					Date:        timestamppb.New(mock.IncomeBonusDate),
+					Category:    mock.IncomeSalaryCategoryName,
+					Card:        mock.IncomeSalaryCard.Name,
+					Description: mock.IncomeSalary.Description,
+				},
+			},
+			want: want{
+				errorMsg: "income is a likely duplicate - set force to create it anyway",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "ErrorUnknownCard",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.CreateRequest{
+					Value:       mock.IncomeSalary.Value.String(),
+					Date:        timestamppb.New(mock.IncomeSalaryDate),
+					Category:    mock.IncomeSalaryCategoryName,
+					Card:        "Unknown",
+					Description: mock.IncomeSalary.Description,
+				},
+			},
+			want: want{
+				errorMsg: "could not get income card by name",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &Incomes{
+				Repository:         tt.fields.Repository,
+				CategoryRepository: tt.fields.CategoryRepository,
+				CardRepository:     tt.fields.CardRepository,
+				DuplicatesDetector: duplicates.NewDetector(),
+			}
+
+			got, err := s.Create(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Incomes.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("Incomes.Create() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
+
+func TestIncomes_CreateSeveral(t *testing.T) {
+
+	salaryGRPCIncomeCreateRequest := grpc.CreateRequest{
+		Value:       mock.IncomeSalary.Value.String(),
+		Date:        timestamppb.New(mock.IncomeSalaryDate),
+		Category:    mock.IncomeSalaryCategoryName,
+		Card:        mock.IncomeSalaryCard.Name,
+		Description: mock.IncomeSalary.Description,
+	}
+
+	type args struct {
+		ctx context.Context
+		req *grpc.CreateSeveralRequest
+	}
+
+	type want struct {
+		response *grpc.CreateSeveralResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		fields  incomesFields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name:   "Success",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.CreateSeveralRequest{
+					Incomes: []*grpc.CreateRequest{
+						&salaryGRPCIncomeCreateRequest,
+						&salaryGRPCIncomeCreateRequest,
+					},
+				},
+			},
+			want: want{
+				response: &grpc.CreateSeveralResponse{
+					Ids: []*grpc.CreateResponse{
+						{Id: mock.IncomeSalary.ID},
+						{Id: mock.IncomeSalary.ID},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "ErrorUnknownCategoryOnSecondIncome",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.CreateSeveralRequest{
+					Incomes: []*grpc.CreateRequest{
+						&salaryGRPCIncomeCreateRequest,
+						{
+							Value:       mock.IncomeSalary.Value.String(),
+							Date:        timestamppb.New(mock.IncomeSalaryDate),
+							Category:    "Unknown",
+							Card:        mock.IncomeSalaryCard.Name,
+							Description: mock.IncomeSalary.Description,
+						},
+					},
+				},
+			},
+			want: want{
+				errorMsg: "income 1: could not get income category by name",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "ErrorInsertingFirstIncome",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.CreateSeveralRequest{
+					Incomes: []*grpc.CreateRequest{
+						{
+							Value:       mock.IncomeSalary.Value.String(),
+							Date:        timestamppb.New(mock.IncomeSalaryDate),
+							Category:    mock.IncomeSalaryCategoryName,
+							Card:        mock.IncomeSalaryCard.Name,
+							Description: "Not insertable",
+						},
+						&salaryGRPCIncomeCreateRequest,
+					},
+				},
+			},
+			want: want{
+				errorMsg: "income 0: could not insert income",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &Incomes{
+				Repository:         tt.fields.Repository,
+				CategoryRepository: tt.fields.CategoryRepository,
+				CardRepository:     tt.fields.CardRepository,
+			}
+
+			got, err := s.CreateSeveral(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Incomes.CreateSeveral() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("Incomes.CreateSeveral() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
+
+func TestIncomes_Update(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		req *grpc.UpdateRequest
+	}
+
+	type want struct {
+		response *grpc.UpdateResponse
+		code     codes.Code
+	}
+
+	tests := []struct {
+		name    string
+		fields  incomesFields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name:   "Success",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.UpdateRequest{
+					Id:          mock.IncomeSalary.ID,
+					Value:       mock.IncomeSalary.Value.String(),
+					Date:        timestamppb.New(mock.IncomeSalaryDate),
+					Category:    mock.IncomeSalaryCategoryName,
+					Card:        mock.IncomeSalaryCard.Name,
+					Description: mock.IncomeSalary.Description,
+				},
+			},
+			want: want{
+				response: &grpc.UpdateResponse{
+					Id: mock.IncomeSalary.ID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "ErrorUnknownIncome",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.UpdateRequest{
+					Id:          99,
+					Value:       mock.IncomeSalary.Value.String(),
+					Date:        timestamppb.New(mock.IncomeSalaryDate),
+					Category:    mock.IncomeSalaryCategoryName,
+					Card:        mock.IncomeSalaryCard.Name,
+					Description: mock.IncomeSalary.Description,
+				},
+			},
+			want: want{
+				code: codes.NotFound,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &Incomes{
+				Repository:         tt.fields.Repository,
+				CategoryRepository: tt.fields.CategoryRepository,
+				CardRepository:     tt.fields.CardRepository,
+			}
+
+			got, err := s.Update(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Incomes.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("Incomes.Update() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Equal(t, tt.want.code, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestIncomes_GetByDate(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		req *grpc.GetRequestByDate
+	}
+
+	type want struct {
+		response *grpc.GetSeveralResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		fields  incomesFields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name:   "Success",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.GetRequestByDate{
+					MinDate: timestamppb.New(mock.IncomeSalaryDate.Add(-time.Hour)),
+					MaxDate: timestamppb.New(mock.IncomeSalaryDate.Add(time.Hour)),
+				},
+			},
+			want: want{
+				response: &grpc.GetSeveralResponse{
+					Incomes: []*grpc.GetResponse{&salaryGRPCIncomeGetResponse},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "ErrorNoIncomesInDates",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.GetRequestByDate{
+					MinDate: timestamppb.New(mock.IncomeSalaryDate.Add(time.Hour)),
+					MaxDate: timestamppb.New(mock.IncomeSalaryDate.Add(2 * time.Hour)),
+				},
+			},
+			want: want{
+				errorMsg: "could not get incomes by dates",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &Incomes{
+				Repository:         tt.fields.Repository,
+				CategoryRepository: tt.fields.CategoryRepository,
+				CardRepository:     tt.fields.CardRepository,
+			}
+
+			got, err := s.GetByDate(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Incomes.GetByDate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("Incomes.GetByDate() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
+
+func TestIncomes_GetByCategory(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		req *grpc.GetRequestByCategory
+	}
+
+	type want struct {
+		response *grpc.GetSeveralResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		fields  incomesFields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name:   "Success",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.GetRequestByCategory{
+					Category: mock.IncomeSalaryCategoryName,
+				},
+			},
+			want: want{
+				response: &grpc.GetSeveralResponse{
+					Incomes: []*grpc.GetResponse{&salaryGRPCIncomeGetResponse},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "ErrorUnknownCategory",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.GetRequestByCategory{
+					Category: "Unknown",
+				},
+			},
+			want: want{
+				errorMsg: "could not get incomes by category",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &Incomes{
+				Repository:         tt.fields.Repository,
+				CategoryRepository: tt.fields.CategoryRepository,
+				CardRepository:     tt.fields.CardRepository,
+			}
+
+			got, err := s.GetByCategory(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Incomes.GetByCategory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("Incomes.GetByCategory() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
+
+func TestIncomes_GetByCard(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		req *grpc.GetRequestByCard
+	}
+
+	type want struct {
+		response *grpc.GetSeveralResponse
+		errorMsg string
+	}
+
+	tests := []struct {
+		name    string
+		fields  incomesFields
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name:   "Success",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.GetRequestByCard{
+					Card: mock.IncomeSalaryCard.Name,
+				},
+			},
+			want: want{
+				response: &grpc.GetSeveralResponse{
+					Incomes: []*grpc.GetResponse{&salaryGRPCIncomeGetResponse},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "ErrorUnknownCard",
+			fields: incomesMockFields,
+			args: args{
+				ctx: context.Background(),
+				req: &grpc.GetRequestByCard{
+					Card: "Unknown",
+				},
+			},
+			want: want{
+				errorMsg: "could not get incomes by card",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &Incomes{
+				Repository:         tt.fields.Repository,
+				CategoryRepository: tt.fields.CategoryRepository,
+				CardRepository:     tt.fields.CardRepository,
+			}
+
+			got, err := s.GetByCard(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Incomes.GetByCard() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("Incomes.GetByCard() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Contains(t, err.Error(), tt.want.errorMsg)
+			}
+		})
+	}
+}
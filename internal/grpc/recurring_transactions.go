@@ -0,0 +1,256 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/pb/recurring"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RecurringTransactions implements recurring transactions ServiceServer methods
+type RecurringTransactions struct {
+	recurring.ServiceServer
+	Repository               repository.RecurringTransactionRepo
+	CardRepository           repository.CardRepo
+	SubCategoryRepository    repository.ExpenseSubCategoryRepo
+	IncomeCategoryRepository repository.IncomeCategoryRepo
+}
+
+// NewRecurringTransactions creates a new RecurringTransactions service
+func NewRecurringTransactions(
+	recurringRepo repository.RecurringTransactionRepo,
+	cardRepo repository.CardRepo,
+	expSubCatRepo repository.ExpenseSubCategoryRepo,
+	incCatRepo repository.IncomeCategoryRepo,
+) (RecurringTransactions, error) {
+	return RecurringTransactions{
+		Repository:               recurringRepo,
+		CardRepository:           cardRepo,
+		SubCategoryRepository:    expSubCatRepo,
+		IncomeCategoryRepository: incCatRepo,
+	}, nil
+}
+
+// Create creates a recurring transaction on the database. Its occurrences are created from its start date on.
+func (r RecurringTransactions) Create(
+	ctx context.Context,
+	req *recurring.RecurringTransaction,
+) (*recurring.CreateResponse, error) {
+	log.Printf("Create was invoked with %v\n", req)
+
+	recurringRecord, err := r.toRecurringTransactionRecord(ctx, userIDFromContext(ctx), req, time.Time{})
+	if err != nil {
+		log.Printf("grpc - could not get recurring transaction record: %v", err)
+		return &recurring.CreateResponse{}, status.Error(codes.InvalidArgument, recurringTransactionErrorMsg(err))
+	}
+
+	id, err := r.Repository.InsertRecurringTransaction(ctx, recurringRecord)
+	if err != nil {
+		log.Printf("grpc - could not insert recurring transaction: %v", err)
+		return &recurring.CreateResponse{}, fmt.Errorf("could not insert recurring transaction")
+	}
+
+	return &recurring.CreateResponse{
+		Id:      id,
+		NextRun: timestamppb.New(recurringRecord.NextRun),
+	}, nil
+}
+
+// Update updates a recurring transaction on the database. Its next run is rescheduled from today on.
+func (r RecurringTransactions) Update(
+	ctx context.Context,
+	req *recurring.RecurringTransaction,
+) (*recurring.UpdateResponse, error) {
+	log.Printf("Update was invoked with %v\n", req)
+
+	recurringRecord, err := r.toRecurringTransactionRecord(ctx, userIDFromContext(ctx), req, time.Now())
+	if err != nil {
+		log.Printf("grpc - could not get recurring transaction record: %v", err)
+		return &recurring.UpdateResponse{}, status.Error(codes.InvalidArgument, recurringTransactionErrorMsg(err))
+	}
+
+	recurringRecord.ID = req.Id
+
+	id, err := r.Repository.UpdateRecurringTransaction(ctx, recurringRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &recurring.UpdateResponse{}, status.Error(codes.NotFound, "recurring transaction with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not update recurring transaction: %v", err)
+		return &recurring.UpdateResponse{}, fmt.Errorf("could not update recurring transaction")
+	}
+
+	return &recurring.UpdateResponse{
+		Id: id,
+	}, nil
+}
+
+// Get gets a recurring transaction from the database that matches the id provided
+func (r RecurringTransactions) Get(
+	ctx context.Context,
+	req *recurring.GetRequest,
+) (*recurring.RecurringTransaction, error) {
+	log.Printf("Get was invoked with %v\n", req)
+
+	recurringView, err := r.Repository.GetRecurringTransactionByID(ctx, userIDFromContext(ctx), req.Id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &recurring.RecurringTransaction{}, status.Error(codes.NotFound, "recurring transaction with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not get recurring transaction by id: %v", err)
+		return &recurring.RecurringTransaction{}, fmt.Errorf("could not get recurring transaction by id")
+	}
+
+	return recurringTransactionViewToRecurringTransaction(recurringView), nil
+}
+
+// GetSeveral gets the recurring transactions from the database
+func (r RecurringTransactions) GetSeveral(
+	ctx context.Context,
+	req *recurring.GetSeveralRequest,
+) (*recurring.GetSeveralResponse, error) {
+	log.Printf("GetSeveral was invoked with %v\n", req)
+
+	recurringViews, err := r.Repository.GetRecurringTransactions(ctx, userIDFromContext(ctx))
+	if err != nil {
+		log.Printf("grpc - could not get recurring transactions: %v", err)
+		return &recurring.GetSeveralResponse{}, fmt.Errorf("could not get recurring transactions")
+	}
+
+	var responseRecurringTransactions []*recurring.RecurringTransaction
+	for _, recurringView := range recurringViews {
+		responseRecurringTransactions = append(
+			responseRecurringTransactions,
+			recurringTransactionViewToRecurringTransaction(recurringView),
+		)
+	}
+
+	return &recurring.GetSeveralResponse{
+		RecurringTransactions: responseRecurringTransactions,
+	}, nil
+}
+
+// Delete deletes a recurring transaction from the database that matches the id provided.
+// The expenses and incomes it created are kept.
+func (r RecurringTransactions) Delete(
+	ctx context.Context,
+	req *recurring.DeleteRequest,
+) (*recurring.DeleteResponse, error) {
+	log.Printf("Delete was invoked with %v\n", req)
+
+	err := r.Repository.DeleteRecurringTransaction(ctx, userIDFromContext(ctx), req.Id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &recurring.DeleteResponse{}, status.Error(codes.NotFound, "recurring transaction with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not delete recurring transaction: %v", err)
+		return &recurring.DeleteResponse{}, fmt.Errorf("could not delete recurring transaction")
+	}
+
+	return &recurring.DeleteResponse{}, nil
+}
+
+// toRecurringTransactionRecord resolves the card and category names of a recurring transaction, validates it
+// and schedules its next run on the first occurrence on or after the from date
+func (r RecurringTransactions) toRecurringTransactionRecord(
+	ctx context.Context,
+	userID int64,
+	req *recurring.RecurringTransaction,
+	from time.Time,
+) (models.RecurringTransactionTable, error) {
+
+	if req.GetStartDate() == nil {
+		return models.RecurringTransactionTable{}, fmt.Errorf("%w: missing start date", scheduler.ErrInvalidRecurringTransaction)
+	}
+
+	value, err := models.ParseMoney(req.GetValue())
+	if err != nil {
+		return models.RecurringTransactionTable{}, fmt.Errorf("%w: %v", scheduler.ErrInvalidRecurringTransaction, err)
+	}
+
+	recurringRecord := models.RecurringTransactionTable{
+		Value:          value,
+		Description:    req.GetDescription(),
+		Frequency:      req.GetFrequency(),
+		CronExpression: req.GetCron(),
+		StartDate:      scheduler.Date(req.GetStartDate().AsTime()),
+		UserID:         userID,
+	}
+
+	if req.GetEndDate() != nil {
+		recurringRecord.EndDate = scheduler.Date(req.GetEndDate().AsTime())
+	}
+
+	card, err := r.CardRepository.GetCardByName(ctx, userID, req.GetCard())
+	if err != nil {
+		return models.RecurringTransactionTable{}, fmt.Errorf("could not get card by name: %v", err)
+	}
+	recurringRecord.CardID = card.ID
+
+	if req.GetSubCategory() != "" {
+		subCategory, err := r.SubCategoryRepository.GetExpenseSubCategoryByName(ctx, req.GetSubCategory())
+		if err != nil {
+			return models.RecurringTransactionTable{}, fmt.Errorf("could not get expense sub category by name: %v", err)
+		}
+		recurringRecord.SubCategoryID = subCategory.ID
+	}
+
+	if req.GetCategory() != "" {
+		category, err := r.IncomeCategoryRepository.GetIncomeCategoryByName(ctx, req.GetCategory())
+		if err != nil {
+			return models.RecurringTransactionTable{}, fmt.Errorf("could not get income category by name: %v", err)
+		}
+		recurringRecord.IncomeCategoryID = category.ID
+	}
+
+	err = scheduler.Validate(recurringRecord)
+	if err != nil {
+		return models.RecurringTransactionTable{}, err
+	}
+
+	recurringRecord.NextRun, err = scheduler.FirstRun(recurringRecord, from)
+	if err != nil {
+		return models.RecurringTransactionTable{}, fmt.Errorf("%w: %v", scheduler.ErrInvalidRecurringTransaction, err)
+	}
+
+	return recurringRecord, nil
+}
+
+func recurringTransactionErrorMsg(err error) string {
+	if errors.Is(err, scheduler.ErrInvalidRecurringTransaction) {
+		return "recurring transaction must set either a sub_category or a category, a decimal value >= 0, " +
+			"a known frequency (with a valid cron expression for cron) and a start_date <= end_date"
+	}
+	return "card, subcategory or category does not exist"
+}
+
+func recurringTransactionViewToRecurringTransaction(recurringView models.RecurringTransactionView) *recurring.RecurringTransaction {
+
+	response := &recurring.RecurringTransaction{
+		Id:          recurringView.ID,
+		Value:       recurringView.Value.String(),
+		Description: recurringView.Description,
+		Frequency:   recurringView.Frequency,
+		Cron:        recurringView.CronExpression,
+		StartDate:   timestamppb.New(recurringView.StartDate),
+		NextRun:     timestamppb.New(recurringView.NextRun),
+		Card:        recurringView.Card,
+		SubCategory: recurringView.SubCategory,
+		Category:    recurringView.IncomeCategory,
+	}
+
+	if !recurringView.EndDate.IsZero() {
+		response.EndDate = timestamppb.New(recurringView.EndDate)
+	}
+
+	return response
+}
@@ -0,0 +1,173 @@
+package grpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	recurringpb "github.com/rubengomes8/golang-personal-finances/internal/pb/recurring"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRecurringTransactions_Create(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		req *recurringpb.RecurringTransaction
+	}
+
+	type want struct {
+		response *recurringpb.CreateResponse
+		code     codes.Code
+	}
+
+	recurringCache := cache.NewRecurringTransaction([]models.RecurringTransactionView{})
+
+	tests := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			args: args{
+				ctx: context.Background(),
+				req: &recurringpb.RecurringTransaction{
+					Value:       "500.00",
+					Description: "Rent",
+					Frequency:   scheduler.FrequencyMonthly,
+					StartDate:   timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+					Card:        "CGD",
+					SubCategory: "Rent",
+				},
+			},
+			want: want{
+				response: &recurringpb.CreateResponse{
+					Id:      1,
+					NextRun: timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorUnknownCard",
+			args: args{
+				ctx: context.Background(),
+				req: &recurringpb.RecurringTransaction{
+					Value:       "500.00",
+					Frequency:   scheduler.FrequencyMonthly,
+					StartDate:   timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+					Card:        "Unknown",
+					SubCategory: "Rent",
+				},
+			},
+			want: want{
+				code: codes.InvalidArgument,
+			},
+			wantErr: true,
+		},
+		{
+			name: "ErrorInvalidCron",
+			args: args{
+				ctx: context.Background(),
+				req: &recurringpb.RecurringTransaction{
+					Value:       "500.00",
+					Frequency:   scheduler.FrequencyCron,
+					Cron:        "0 0 32 * *",
+					StartDate:   timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+					Card:        "CGD",
+					SubCategory: "Rent",
+				},
+			},
+			want: want{
+				code: codes.InvalidArgument,
+			},
+			wantErr: true,
+		},
+		{
+			name: "ErrorMissingStartDate",
+			args: args{
+				ctx: context.Background(),
+				req: &recurringpb.RecurringTransaction{
+					Value:       "500.00",
+					Frequency:   scheduler.FrequencyMonthly,
+					Card:        "CGD",
+					SubCategory: "Rent",
+				},
+			},
+			want: want{
+				code: codes.InvalidArgument,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &RecurringTransactions{
+				Repository:            &recurringCache,
+				CardRepository:        &cardsCache,
+				SubCategoryRepository: &subCategoriesCache,
+			}
+
+			got, err := s.Create(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RecurringTransactions.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("RecurringTransactions.Create() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Equal(t, tt.want.code, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestRecurringTransactions_Get(t *testing.T) {
+
+	recurringCache := cache.NewRecurringTransaction([]models.RecurringTransactionView{
+		{
+			ID:          1,
+			Value:       models.MustParseMoney("500"),
+			Description: "Rent",
+			Frequency:   scheduler.FrequencyMonthly,
+			StartDate:   firstFebruary2020ZeroHoursUTCTime,
+			NextRun:     firstFebruary2020ZeroHoursUTCTime.AddDate(0, 1, 0),
+			CardID:      1,
+			Card:        "CGD",
+			SubCategory: "Rent",
+		},
+	})
+
+	s := &RecurringTransactions{
+		Repository: &recurringCache,
+	}
+
+	got, err := s.Get(context.Background(), &recurringpb.GetRequest{Id: 1})
+	assert.NoError(t, err)
+	assert.True(t, reflect.DeepEqual(got, &recurringpb.RecurringTransaction{
+		Id:          1,
+		Value:       "500.00",
+		Description: "Rent",
+		Frequency:   scheduler.FrequencyMonthly,
+		StartDate:   timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+		NextRun:     timestamppb.New(firstFebruary2020ZeroHoursUTCTime.AddDate(0, 1, 0)),
+		Card:        "CGD",
+		SubCategory: "Rent",
+	}))
+
+	_, err = s.Get(context.Background(), &recurringpb.GetRequest{Id: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
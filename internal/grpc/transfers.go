@@ -0,0 +1,209 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	transferspb "github.com/rubengomes8/golang-personal-finances/internal/pb/transfers"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/transfers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Transfers implements transfers ServiceServer methods
+type Transfers struct {
+	transferspb.ServiceServer
+	Repository     repository.TransferRepo
+	CardRepository repository.CardRepo
+}
+
+// NewTransfers creates a new Transfers service
+func NewTransfers(transferRepo repository.TransferRepo, cardRepo repository.CardRepo) (Transfers, error) {
+	return Transfers{
+		Repository:     transferRepo,
+		CardRepository: cardRepo,
+	}, nil
+}
+
+// Create creates a transfer between two cards on the database
+func (t Transfers) Create(ctx context.Context, req *transferspb.Transfer) (*transferspb.CreateResponse, error) {
+	log.Printf("Create was invoked with %v\n", req)
+
+	transferRecord, err := t.toTransferRecord(ctx, userIDFromContext(ctx), req)
+	if err != nil {
+		log.Printf("grpc - could not get transfer record: %v", err)
+		return &transferspb.CreateResponse{}, status.Error(codes.InvalidArgument, transferErrorMsg(err))
+	}
+
+	id, err := t.Repository.InsertTransfer(ctx, transferRecord)
+	if err != nil {
+		log.Printf("grpc - could not insert transfer: %v", err)
+		return &transferspb.CreateResponse{}, fmt.Errorf("could not insert transfer")
+	}
+
+	return &transferspb.CreateResponse{
+		Id: id,
+	}, nil
+}
+
+// Update updates a transfer on the database
+func (t Transfers) Update(ctx context.Context, req *transferspb.Transfer) (*transferspb.UpdateResponse, error) {
+	log.Printf("Update was invoked with %v\n", req)
+
+	transferRecord, err := t.toTransferRecord(ctx, userIDFromContext(ctx), req)
+	if err != nil {
+		log.Printf("grpc - could not get transfer record: %v", err)
+		return &transferspb.UpdateResponse{}, status.Error(codes.InvalidArgument, transferErrorMsg(err))
+	}
+
+	transferRecord.ID = req.Id
+
+	id, err := t.Repository.UpdateTransfer(ctx, transferRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &transferspb.UpdateResponse{}, status.Error(codes.NotFound, "transfer with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not update transfer: %v", err)
+		return &transferspb.UpdateResponse{}, fmt.Errorf("could not update transfer")
+	}
+
+	return &transferspb.UpdateResponse{
+		Id: id,
+	}, nil
+}
+
+// Get gets a transfer from the database that matches the id provided
+func (t Transfers) Get(ctx context.Context, req *transferspb.GetRequest) (*transferspb.Transfer, error) {
+	log.Printf("Get was invoked with %v\n", req)
+
+	transferView, err := t.Repository.GetTransferByID(ctx, userIDFromContext(ctx), req.Id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &transferspb.Transfer{}, status.Error(codes.NotFound, "transfer with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not get transfer by id: %v", err)
+		return &transferspb.Transfer{}, fmt.Errorf("could not get transfer by id")
+	}
+
+	return transferViewToTransfer(transferView), nil
+}
+
+// GetByDate gets the transfers from the database that are in the provided dates interval
+func (t Transfers) GetByDate(
+	ctx context.Context,
+	req *transferspb.GetRequestByDate,
+) (*transferspb.GetSeveralResponse, error) {
+	log.Printf("GetByDate was invoked with %v\n", req)
+
+	transferViews, err := t.Repository.GetTransfersByDates(
+		ctx,
+		userIDFromContext(ctx),
+		req.MinDate.AsTime(),
+		req.MaxDate.AsTime(),
+	)
+	if err != nil {
+		log.Printf("grpc - could not get transfers by dates: %v", err)
+		return &transferspb.GetSeveralResponse{}, fmt.Errorf("could not get transfers by dates")
+	}
+
+	var responseTransfers []*transferspb.Transfer
+	for _, transferView := range transferViews {
+		responseTransfers = append(responseTransfers, transferViewToTransfer(transferView))
+	}
+
+	return &transferspb.GetSeveralResponse{
+		Transfers: responseTransfers,
+	}, nil
+}
+
+// Delete deletes a transfer from the database that matches the id provided
+func (t Transfers) Delete(ctx context.Context, req *transferspb.DeleteRequest) (*transferspb.DeleteResponse, error) {
+	log.Printf("Delete was invoked with %v\n", req)
+
+	err := t.Repository.DeleteTransfer(ctx, userIDFromContext(ctx), req.Id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &transferspb.DeleteResponse{}, status.Error(codes.NotFound, "transfer with this id does not exist")
+	}
+	if err != nil {
+		log.Printf("grpc - could not delete transfer: %v", err)
+		return &transferspb.DeleteResponse{}, fmt.Errorf("could not delete transfer")
+	}
+
+	return &transferspb.DeleteResponse{}, nil
+}
+
+// toTransferRecord resolves the card names of a transfer and validates it
+func (t Transfers) toTransferRecord(
+	ctx context.Context,
+	userID int64,
+	req *transferspb.Transfer,
+) (models.TransferTable, error) {
+
+	if req.GetDate() == nil {
+		return models.TransferTable{}, fmt.Errorf("%w: missing date", transfers.ErrInvalidTransfer)
+	}
+
+	amount, err := models.ParseMoney(req.GetAmount())
+	if err != nil {
+		return models.TransferTable{}, fmt.Errorf("%w: %v", transfers.ErrInvalidTransfer, err)
+	}
+
+	var fee models.Money
+	if req.GetFee() != "" {
+		fee, err = models.ParseMoney(req.GetFee())
+		if err != nil {
+			return models.TransferTable{}, fmt.Errorf("%w: %v", transfers.ErrInvalidTransfer, err)
+		}
+	}
+
+	fromCard, err := t.CardRepository.GetCardByName(ctx, userID, req.GetFromCard())
+	if err != nil {
+		return models.TransferTable{}, fmt.Errorf("could not get source card by name: %v", err)
+	}
+
+	toCard, err := t.CardRepository.GetCardByName(ctx, userID, req.GetToCard())
+	if err != nil {
+		return models.TransferTable{}, fmt.Errorf("could not get destination card by name: %v", err)
+	}
+
+	transferRecord := models.TransferTable{
+		Amount:      amount,
+		Fee:         fee,
+		Date:        req.GetDate().AsTime(),
+		Description: req.GetDescription(),
+		FromCardID:  fromCard.ID,
+		ToCardID:    toCard.ID,
+		UserID:      userID,
+	}
+
+	err = transfers.Validate(transferRecord)
+	if err != nil {
+		return models.TransferTable{}, err
+	}
+
+	return transferRecord, nil
+}
+
+func transferErrorMsg(err error) string {
+	if errors.Is(err, transfers.ErrInvalidTransfer) {
+		return "transfer must set a date, a decimal amount > 0, a decimal fee >= 0 and two different cards"
+	}
+	return "from_card or to_card does not exist"
+}
+
+func transferViewToTransfer(transferView models.TransferView) *transferspb.Transfer {
+	return &transferspb.Transfer{
+		Id:          transferView.ID,
+		Amount:      transferView.Amount.String(),
+		Fee:         transferView.Fee.String(),
+		Date:        timestamppb.New(transferView.Date),
+		Description: transferView.Description,
+		FromCard:    transferView.FromCard,
+		ToCard:      transferView.ToCard,
+	}
+}
@@ -0,0 +1,161 @@
+package grpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	transferspb "github.com/rubengomes8/golang-personal-finances/internal/pb/transfers"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTransfers_Create(t *testing.T) {
+
+	type args struct {
+		ctx context.Context
+		req *transferspb.Transfer
+	}
+
+	type want struct {
+		response *transferspb.CreateResponse
+		code     codes.Code
+	}
+
+	transfersCache := cache.NewTransfer([]models.TransferView{})
+
+	tests := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			args: args{
+				ctx: context.Background(),
+				req: &transferspb.Transfer{
+					Amount:   "150.00",
+					Fee:      "0.50",
+					Date:     timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+					FromCard: "CGD",
+					ToCard:   "Food allowance",
+				},
+			},
+			want: want{
+				response: &transferspb.CreateResponse{
+					Id: 1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ErrorUnknownCard",
+			args: args{
+				ctx: context.Background(),
+				req: &transferspb.Transfer{
+					Amount:   "150.00",
+					Date:     timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+					FromCard: "CGD",
+					ToCard:   "Unknown",
+				},
+			},
+			want: want{
+				code: codes.InvalidArgument,
+			},
+			wantErr: true,
+		},
+		{
+			name: "ErrorSameCard",
+			args: args{
+				ctx: context.Background(),
+				req: &transferspb.Transfer{
+					Amount:   "150.00",
+					Date:     timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+					FromCard: "CGD",
+					ToCard:   "CGD",
+				},
+			},
+			want: want{
+				code: codes.InvalidArgument,
+			},
+			wantErr: true,
+		},
+		{
+			name: "ErrorMissingDate",
+			args: args{
+				ctx: context.Background(),
+				req: &transferspb.Transfer{
+					Amount:   "150.00",
+					FromCard: "CGD",
+					ToCard:   "Food allowance",
+				},
+			},
+			want: want{
+				code: codes.InvalidArgument,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			s := &Transfers{
+				Repository:     &transfersCache,
+				CardRepository: &cardsCache,
+			}
+
+			got, err := s.Create(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Transfers.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			switch {
+			case !tt.wantErr:
+				if !reflect.DeepEqual(got, tt.want.response) {
+					t.Errorf("Transfers.Create() = %v, want %v", got, tt.want.response)
+				}
+			case tt.wantErr:
+				assert.Equal(t, tt.want.code, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestTransfers_Get(t *testing.T) {
+
+	transfersCache := cache.NewTransfer([]models.TransferView{
+		{
+			ID:         1,
+			Amount:     models.MustParseMoney("150"),
+			Date:       firstFebruary2020ZeroHoursUTCTime,
+			FromCardID: 1,
+			FromCard:   "CGD",
+			ToCardID:   2,
+			ToCard:     "Food allowance",
+		},
+	})
+
+	s := &Transfers{
+		Repository: &transfersCache,
+	}
+
+	got, err := s.Get(context.Background(), &transferspb.GetRequest{Id: 1})
+	assert.NoError(t, err)
+	assert.True(t, reflect.DeepEqual(got, &transferspb.Transfer{
+		Id:       1,
+		Amount:   "150.00",
+		Fee:      "0.00",
+		Date:     timestamppb.New(firstFebruary2020ZeroHoursUTCTime),
+		FromCard: "CGD",
+		ToCard:   "Food allowance",
+	}))
+
+	_, err = s.Get(context.Background(), &transferspb.GetRequest{Id: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+// ErrNotAdmin is returned when a user who is not an admin changes what is shared by every user, such as the categories
+var ErrNotAdmin = errors.New("only admins can do this")
+
+// RequireAdmin returns ErrNotAdmin unless the user is an admin
+func RequireAdmin(ctx context.Context, userRepo repository.UserRepo, userID int64) error {
+
+	user, err := userRepo.GetUserByID(ctx, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrNotAdmin
+	}
+	if err != nil {
+		return fmt.Errorf("could not get user: %v", err)
+	}
+
+	if !user.Admin {
+		return ErrNotAdmin
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminMiddleware(t *testing.T) {
+
+	userRepo := cache.NewUser()
+	adminID, err := userRepo.InsertUser(context.Background(), models.UserTable{Username: "admin", Admin: true})
+	assert.NoError(t, err)
+	userID, err := userRepo.InsertUser(context.Background(), models.UserTable{Username: "alice"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		userID     int64
+		statusCode int
+	}{
+		{name: "Admin", userID: adminID, statusCode: http.StatusNoContent},
+		{name: "Not an admin", userID: userID, statusCode: http.StatusForbidden},
+		{name: "Unknown user", userID: 99, statusCode: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.POST("/merge",
+				func(ctx *gin.Context) { ctx.Set(userIDKey, tt.userID) },
+				AdminMiddleware(&userRepo),
+				func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) },
+			)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/merge", nil))
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -14,10 +15,21 @@ import (
 )
 
 const (
-	tokenLifespanInHours = 1
-	apiSecret            = "unsafeHere" // TODO
+	tokenLifespanInHours  = 1
+	sessionLifespanInDays = 30
+	userIDKey             = "user_id"
+	sessionIDKey          = "sid"
 )
 
+// ErrInvalidCredentials is returned when a password does not match, or can not be verified, such as with a malformed hash
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// AccessToken is the user and the session an access token was issued to
+type AccessToken struct {
+	UserID    int64
+	SessionID int64
+}
+
 func EncryptPassword(username, password string) (string, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -27,19 +39,42 @@ func EncryptPassword(username, password string) (string, error) {
 	return string(hash), nil
 }
 
-func LoginCheck(ctx context.Context, username, password string, user models.UserTable) (string, error) {
+// LoginResult is the outcome of a login whose password was verified: the tokens of a new session,
+// or the challenge of the second step when the user has 2FA enabled
+type LoginResult struct {
+	Tokens    Tokens
+	Challenge string
+}
+
+// LoginCheck starts a session of the user when the password matches its hash. Any error verifying the password fails the login.
+// With 2FA enabled, no session is started yet: a challenge is returned instead, to be completed with a code.
+func LoginCheck(
+	ctx context.Context,
+	sessions Sessions,
+	twoFactor TwoFactor,
+	username, password string,
+	user models.UserTable,
+) (LoginResult, error) {
 
 	err := verifyPassword(password, user.Passhash)
-	if err != nil && err == bcrypt.ErrMismatchedHashAndPassword {
-		return "", fmt.Errorf("invalid password: %v", err)
+	if err != nil {
+		return LoginResult{}, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
 	}
 
-	token, err := generateToken(uint(user.ID))
+	if twoFactorEnabled(user) {
+		challenge, err := twoFactor.Challenge(user.ID)
+		if err != nil {
+			return LoginResult{}, fmt.Errorf("could not sign login challenge: %v", err)
+		}
+		return LoginResult{Challenge: challenge}, nil
+	}
+
+	tokens, err := sessions.Start(ctx, user.ID)
 	if err != nil {
-		return "", err
+		return LoginResult{}, err
 	}
 
-	return token, nil
+	return LoginResult{Tokens: tokens}, nil
 
 }
 
@@ -47,30 +82,55 @@ func verifyPassword(password, hashedPwd string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPwd), []byte(password))
 }
 
-func generateToken(userID uint) (string, error) {
+func generateToken(keys *KeySet, userID int64, sessionID int64) (string, error) {
 
 	claims := jwt.MapClaims{}
 
 	claims["authorized"] = true
-	claims["user_id"] = userID
+	claims[userIDKey] = userID
+	claims[sessionIDKey] = sessionID
 	claims["exp"] = time.Now().Add(time.Hour * time.Duration(tokenLifespanInHours)).Unix()
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	return token.SignedString([]byte(apiSecret))
+	return keys.Sign(claims)
 
 }
 
-func validateToken(ctx *gin.Context) error {
+func validateToken(ctx *gin.Context, sessions Sessions) (int64, error) {
+	return sessions.Authenticate(ctx, extractToken(ctx))
+}
 
-	tokenString := extractToken(ctx)
-	_, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(apiSecret), nil
-	})
+// ParseToken validates a token signed by one of the keys and returns the user and the session it was issued to
+func (k *KeySet) ParseToken(tokenString string) (AccessToken, error) {
+
+	token, err := k.Parse(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return AccessToken{}, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return AccessToken{}, fmt.Errorf("invalid token claims")
+	}
+
+	userID, ok := claims[userIDKey].(float64)
+	if !ok {
+		return AccessToken{}, fmt.Errorf("token has no user id claim")
+	}
+
+	sessionID, ok := claims[sessionIDKey].(float64)
+	if !ok {
+		return AccessToken{}, fmt.Errorf("token has no session id claim")
+	}
+
+	return AccessToken{
+		UserID:    int64(userID),
+		SessionID: int64(sessionID),
+	}, nil
+}
 
-	return err
+// UserID returns the id of the authenticated user set by JwtAuthMiddleware
+func UserID(ctx *gin.Context) int64 {
+	return ctx.GetInt64(userIDKey)
 }
 
 func extractToken(ctx *gin.Context) string {
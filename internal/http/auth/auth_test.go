@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginCheck(t *testing.T) {
+
+	passhash, err := EncryptPassword("alice", "secret")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		password string
+		passhash string
+		wantErr  bool
+	}{
+		{name: "Matching password", password: "secret", passhash: passhash},
+		{name: "Wrong password", password: "guess", passhash: passhash, wantErr: true},
+		{name: "Empty hash", password: "secret", passhash: "", wantErr: true},
+		{name: "Malformed hash", password: "secret", passhash: "$2a$10$short", wantErr: true},
+		{name: "Plain text hash", password: "secret", passhash: "secret", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions := newTestSessions(t)
+			twoFactor := NewTwoFactor(nil, sessions.Keys, "")
+			user := models.UserTable{ID: 7, Username: "alice", Passhash: tt.passhash}
+
+			result, err := LoginCheck(context.Background(), sessions, twoFactor, "alice", tt.password, user)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidCredentials), err)
+				assert.Equal(t, LoginResult{}, result)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "", result.Challenge)
+
+			userID, err := sessions.Authenticate(context.Background(), result.Tokens.AccessToken)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(7), userID)
+		})
+	}
+}
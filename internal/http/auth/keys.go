@@ -0,0 +1,270 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	jwt "github.com/golang-jwt/jwt"
+)
+
+const (
+	// keysEnv lists the signing keys as comma separated kid=path pairs
+	keysEnv = "JWT_KEYS"
+	// signingKeyIDEnv is the kid of the key new tokens are signed with, the first one of JWT_KEYS by default
+	signingKeyIDEnv = "JWT_SIGNING_KEY_ID"
+	// devKeyEnv set to 1 opts into signing with a temporary key when JWT_KEYS is not set, for local development only
+	devKeyEnv = "JWT_DEV_KEY"
+	// minSecretLength is the shortest HMAC secret, as long as the SHA-256 output
+	minSecretLength = 32
+)
+
+var (
+	// ErrInvalidKey is returned when a key file is neither a PEM RSA or Ed25519 key nor a long enough HMAC secret
+	ErrInvalidKey = errors.New("key is not a valid RSA, Ed25519 or HMAC key")
+	// ErrUnknownKey is returned when a token is signed with a key that is not in the key set
+	ErrUnknownKey = errors.New("token is signed with an unknown key")
+	// ErrNoKeys is returned when JWT_KEYS is not set and the temporary development key was not opted into
+	ErrNoKeys = errors.New("JWT_KEYS must list the token keys shared by the http and grpc servers")
+)
+
+// Key is a key tokens are signed or verified with, identified by the kid header of the tokens.
+// A key made of a public key only verifies tokens, such as the key of a past rotation.
+type Key struct {
+	ID         string
+	Method     jwt.SigningMethod
+	signingKey interface{} // nil when the key only verifies
+	verifyKey  interface{}
+}
+
+// KeySet is the keys tokens are verified with, one of which signs the new tokens
+type KeySet struct {
+	signing Key
+	keys    map[string]Key
+}
+
+// JWKS is the JSON Web Key Set of the public keys of a key set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is a public key of a JSON Web Key Set
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA exponent
+	Curve     string `json:"crv,omitempty"` // OKP curve
+	X         string `json:"x,omitempty"`   // OKP public key
+}
+
+// ParseKey parses the content of a key file. PEM RSA keys sign with RS256 and Ed25519 keys with EdDSA,
+// or only verify if they are public keys; anything else is an HMAC secret, which signs with HS256.
+func ParseKey(id string, data []byte) (Key, error) {
+
+	if id == "" {
+		return Key{}, fmt.Errorf("%w: key has no id", ErrInvalidKey)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		secret := bytes.TrimSpace(data)
+		if len(secret) < minSecretLength {
+			return Key{}, fmt.Errorf("%w: HMAC secret %s is shorter than %d bytes", ErrInvalidKey, id, minSecretLength)
+		}
+		return Key{ID: id, Method: jwt.SigningMethodHS256, signingKey: secret, verifyKey: secret}, nil
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return Key{}, fmt.Errorf("%w: unsupported PEM block %s of key %s", ErrInvalidKey, block.Type, id)
+	}
+	if err != nil {
+		return Key{}, fmt.Errorf("%w: %s: %v", ErrInvalidKey, id, err)
+	}
+
+	return newKey(id, parsed)
+}
+
+// newKey creates a key out of a parsed RSA or Ed25519 private or public key
+func newKey(id string, parsed interface{}) (Key, error) {
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		return Key{ID: id, Method: jwt.SigningMethodRS256, signingKey: key, verifyKey: &key.PublicKey}, nil
+	case *rsa.PublicKey:
+		return Key{ID: id, Method: jwt.SigningMethodRS256, verifyKey: key}, nil
+	case ed25519.PrivateKey:
+		return Key{ID: id, Method: jwt.SigningMethodEdDSA, signingKey: key, verifyKey: key.Public()}, nil
+	case ed25519.PublicKey:
+		return Key{ID: id, Method: jwt.SigningMethodEdDSA, verifyKey: key}, nil
+	default:
+		return Key{}, fmt.Errorf("%w: key %s is of type %T", ErrInvalidKey, id, parsed)
+	}
+}
+
+// NewKeySet creates a key set of the keys, in which the key with the signing key id signs the new tokens
+func NewKeySet(signingKeyID string, keys ...Key) (*KeySet, error) {
+
+	keySet := &KeySet{
+		keys: map[string]Key{},
+	}
+
+	for _, key := range keys {
+		if _, ok := keySet.keys[key.ID]; ok {
+			return nil, fmt.Errorf("%w: key id %s is repeated", ErrInvalidKey, key.ID)
+		}
+		keySet.keys[key.ID] = key
+	}
+
+	signing, ok := keySet.keys[signingKeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: signing key %s is not one of the keys", ErrInvalidKey, signingKeyID)
+	}
+	if signing.signingKey == nil {
+		return nil, fmt.Errorf("%w: signing key %s is a public key", ErrInvalidKey, signingKeyID)
+	}
+	keySet.signing = signing
+
+	return keySet, nil
+}
+
+// KeysFromEnv reads the key files listed on JWT_KEYS, signing with the key of JWT_SIGNING_KEY_ID.
+// Without JWT_KEYS, it fails with ErrNoKeys unless JWT_DEV_KEY is 1: an Ed25519 key is then generated, so the tokens
+// are only valid on this server and until it restarts.
+func KeysFromEnv() (*KeySet, error) {
+
+	keysValue := strings.TrimSpace(os.Getenv(keysEnv))
+	if keysValue == "" {
+		if os.Getenv(devKeyEnv) != "1" {
+			return nil, ErrNoKeys
+		}
+		log.Printf("%s is not set and %s is 1 - signing tokens with a temporary key, for development only", keysEnv, devKeyEnv)
+		return GenerateKeySet()
+	}
+
+	keys := []Key{}
+	for _, entry := range strings.Split(keysValue, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%s entries must be kid=path, got %q", keysEnv, entry)
+		}
+
+		data, err := os.ReadFile(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("could not read key %s: %v", parts[0], err)
+		}
+
+		key, err := ParseKey(parts[0], data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	signingKeyID := os.Getenv(signingKeyIDEnv)
+	if signingKeyID == "" {
+		signingKeyID = keys[0].ID
+	}
+
+	return NewKeySet(signingKeyID, keys...)
+}
+
+// GenerateKeySet creates a key set of a new random Ed25519 key
+func GenerateKeySet() (*KeySet, error) {
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate key: %v", err)
+	}
+
+	key, err := newKey("generated", privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeySet(key.ID, key)
+}
+
+// Sign signs the claims with the signing key, setting its kid on the token header
+func (k *KeySet) Sign(claims jwt.Claims) (string, error) {
+
+	token := jwt.NewWithClaims(k.signing.Method, claims)
+	token.Header["kid"] = k.signing.ID
+
+	return token.SignedString(k.signing.signingKey)
+}
+
+// Parse validates a token signed by any of the keys, with the algorithm of the key of its kid
+func (k *KeySet) Parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+
+		kid, _ := token.Header["kid"].(string)
+		key, ok := k.keys[kid]
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return key.verifyKey, nil
+	})
+}
+
+// JWKS returns the public keys of the key set, sorted by kid. HMAC secrets are never published.
+func (k *KeySet) JWKS() JWKS {
+
+	jwks := JWKS{Keys: []JWK{}}
+	for _, key := range k.keys {
+		switch verifyKey := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwks.Keys = append(jwks.Keys, JWK{
+				KeyType:   "RSA",
+				KeyID:     key.ID,
+				Use:       "sig",
+				Algorithm: key.Method.Alg(),
+				N:         base64.RawURLEncoding.EncodeToString(verifyKey.N.Bytes()),
+				E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(verifyKey.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			jwks.Keys = append(jwks.Keys, JWK{
+				KeyType:   "OKP",
+				KeyID:     key.ID,
+				Use:       "sig",
+				Algorithm: key.Method.Alg(),
+				Curve:     "Ed25519",
+				X:         base64.RawURLEncoding.EncodeToString(verifyKey),
+			})
+		}
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].KeyID < jwks.Keys[j].KeyID
+	})
+
+	return jwks
+}
@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func pemKey(t *testing.T, blockType string, key interface{}) []byte {
+
+	var der []byte
+	var err error
+	switch blockType {
+	case "PRIVATE KEY":
+		der, err = x509.MarshalPKCS8PrivateKey(key)
+	case "PUBLIC KEY":
+		der, err = x509.MarshalPKIXPublicKey(key)
+	}
+	assert.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func claimsOf(userID int64) jwt.MapClaims {
+	return jwt.MapClaims{
+		userIDKey:    userID,
+		sessionIDKey: 1,
+		"exp":        time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestParseKey(t *testing.T) {
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	edPublicKey, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		data       []byte
+		wantMethod string
+		wantSigns  bool
+		wantErr    bool
+	}{
+		{name: "HMAC secret", data: []byte("0123456789abcdef0123456789abcdef\n"), wantMethod: "HS256", wantSigns: true},
+		{name: "Short HMAC secret", data: []byte("unsafeHere"), wantErr: true},
+		{name: "RSA private key", data: pemKey(t, "PRIVATE KEY", rsaKey), wantMethod: "RS256", wantSigns: true},
+		{name: "RSA public key", data: pemKey(t, "PUBLIC KEY", &rsaKey.PublicKey), wantMethod: "RS256"},
+		{name: "Ed25519 private key", data: pemKey(t, "PRIVATE KEY", edPrivateKey), wantMethod: "EdDSA", wantSigns: true},
+		{name: "Ed25519 public key", data: pemKey(t, "PUBLIC KEY", edPublicKey), wantMethod: "EdDSA"},
+		{name: "Unsupported PEM block", data: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1}}), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseKey("key", tt.data)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidKey), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMethod, key.Method.Alg())
+			assert.Equal(t, tt.wantSigns, key.signingKey != nil)
+		})
+	}
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+
+	_, oldPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	newRSAKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	oldKey, err := ParseKey("2026-01", pemKey(t, "PRIVATE KEY", oldPrivateKey))
+	assert.NoError(t, err)
+	oldPublicKey, err := ParseKey("2026-01", pemKey(t, "PUBLIC KEY", oldPrivateKey.Public()))
+	assert.NoError(t, err)
+	newKey, err := ParseKey("2026-07", pemKey(t, "PRIVATE KEY", newRSAKey))
+	assert.NoError(t, err)
+	secret, err := ParseKey("secret", []byte("0123456789abcdef0123456789abcdef"))
+	assert.NoError(t, err)
+
+	before, err := NewKeySet("2026-01", oldKey)
+	assert.NoError(t, err)
+	oldToken, err := before.Sign(claimsOf(7))
+	assert.NoError(t, err)
+
+	_, err = NewKeySet("2026-01", oldPublicKey, newKey)
+	assert.True(t, errors.Is(err, ErrInvalidKey))
+
+	after, err := NewKeySet("2026-07", oldPublicKey, newKey, secret)
+	assert.NoError(t, err)
+	newToken, err := after.Sign(claimsOf(8))
+	assert.NoError(t, err)
+
+	token, _, err := new(jwt.Parser).ParseUnverified(newToken, jwt.MapClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-07", token.Header["kid"])
+	assert.Equal(t, "RS256", token.Header["alg"])
+
+	token7, err := after.ParseToken(oldToken)
+	assert.NoError(t, err)
+	assert.Equal(t, AccessToken{UserID: 7, SessionID: 1}, token7)
+
+	token8, err := after.ParseToken(newToken)
+	assert.NoError(t, err)
+	assert.Equal(t, AccessToken{UserID: 8, SessionID: 1}, token8)
+
+	_, err = before.ParseToken(newToken)
+	assert.Error(t, err)
+
+	jwks := after.JWKS()
+	assert.Equal(t, 2, len(jwks.Keys))
+	assert.Equal(t, JWK{KeyType: "OKP", KeyID: "2026-01", Use: "sig", Algorithm: "EdDSA", Curve: "Ed25519", X: jwks.Keys[0].X}, jwks.Keys[0])
+	assert.Equal(t, "RSA", jwks.Keys[1].KeyType)
+	assert.Equal(t, "AQAB", jwks.Keys[1].E)
+}
+
+func TestKeySet_ParseToken_Rejected(t *testing.T) {
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	key, err := ParseKey("rsa", pemKey(t, "PRIVATE KEY", rsaKey))
+	assert.NoError(t, err)
+	keys, err := NewKeySet("rsa", key)
+	assert.NoError(t, err)
+
+	publicKeyPEM := pemKey(t, "PUBLIC KEY", &rsaKey.PublicKey)
+
+	tests := []struct {
+		name  string
+		token func() (string, error)
+	}{
+		{
+			name: "Without kid",
+			token: func() (string, error) {
+				return jwt.NewWithClaims(jwt.SigningMethodRS256, claimsOf(7)).SignedString(rsaKey)
+			},
+		},
+		{
+			name: "Unknown kid",
+			token: func() (string, error) {
+				token := jwt.NewWithClaims(jwt.SigningMethodRS256, claimsOf(7))
+				token.Header["kid"] = "other"
+				return token.SignedString(rsaKey)
+			},
+		},
+		{
+			name: "HMAC signed with the public key",
+			token: func() (string, error) {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claimsOf(7))
+				token.Header["kid"] = "rsa"
+				return token.SignedString(publicKeyPEM)
+			},
+		},
+		{
+			name: "Expired",
+			token: func() (string, error) {
+				return keys.Sign(jwt.MapClaims{userIDKey: 7, "exp": time.Now().Add(-time.Minute).Unix()})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tt.token()
+			assert.NoError(t, err)
+			_, err = keys.ParseToken(token)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestKeysFromEnv(t *testing.T) {
+
+	t.Setenv("JWT_KEYS", "")
+	t.Setenv("JWT_DEV_KEY", "")
+
+	_, err := KeysFromEnv()
+	assert.True(t, errors.Is(err, ErrNoKeys), err)
+
+	t.Setenv("JWT_DEV_KEY", "1")
+
+	keys, err := KeysFromEnv()
+	assert.NoError(t, err)
+	assert.NotNil(t, keys)
+}
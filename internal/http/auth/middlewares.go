@@ -1,16 +1,20 @@
 package auth
 
 import (
+	"errors"
+	"log"
 	"net/http"
 
 	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
-func JwtAuthMiddleware() gin.HandlerFunc {
+// JwtAuthMiddleware authenticates the requests with an access token of a session that was not revoked
+func JwtAuthMiddleware(sessions Sessions) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		err := validateToken(ctx)
+		userID, err := validateToken(ctx, sessions)
 		if err != nil {
 			ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{
 				ErrorMsg: "Unauthorized",
@@ -18,6 +22,30 @@ func JwtAuthMiddleware() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
+		ctx.Set(userIDKey, userID)
+		ctx.Next()
+	}
+}
+
+// AdminMiddleware only lets the admins through. It must follow JwtAuthMiddleware.
+func AdminMiddleware(userRepo repository.UserRepo) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		err := RequireAdmin(ctx, userRepo, UserID(ctx))
+		if errors.Is(err, ErrNotAdmin) {
+			ctx.JSON(http.StatusForbidden, models.ErrorResponse{
+				ErrorMsg: "Forbidden",
+			})
+			ctx.Abort()
+			return
+		}
+		if err != nil {
+			log.Printf("could not check if user is admin: %v", err)
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				ErrorMsg: "could not check permissions",
+			})
+			ctx.Abort()
+			return
+		}
 		ctx.Next()
 	}
 }
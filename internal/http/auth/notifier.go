@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// Notifier delivers the password reset tokens to the users, such as by email
+type Notifier interface {
+	NotifyPasswordReset(ctx context.Context, user models.UserTable, token string, expiresAt time.Time) error
+}
+
+// LogNotifier writes the password reset tokens to the server log, for local use
+type LogNotifier struct{}
+
+// NotifyPasswordReset writes the password reset token of the user to the server log
+func (LogNotifier) NotifyPasswordReset(ctx context.Context, user models.UserTable, token string, expiresAt time.Time) error {
+	log.Printf("password reset token of %s, valid until %s: %s", user.Username, expiresAt.Format(time.RFC3339), token)
+	return nil
+}
+
+// FileNotifier appends the password reset tokens to a file, one JSON object per line, for local use
+type FileNotifier struct {
+	Path string
+}
+
+// passwordResetNotification is a line of the file of a FileNotifier
+type passwordResetNotification struct {
+	Username  string    `json:"username"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NotifyPasswordReset appends the password reset token of the user to the file
+func (f FileNotifier) NotifyPasswordReset(ctx context.Context, user models.UserTable, token string, expiresAt time.Time) error {
+
+	line, err := json.Marshal(passwordResetNotification{
+		Username:  user.Username,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("could not marshal password reset notification: %v", err)
+	}
+
+	file, err := os.OpenFile(f.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("could not open password reset notifications file: %v", err)
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		return fmt.Errorf("could not write password reset notification: %v", err)
+	}
+
+	return nil
+}
+
+// NotifierFromEnv returns a FileNotifier of the PASSWORD_RESET_FILE env variable, or a LogNotifier when it is not set
+func NotifierFromEnv() Notifier {
+
+	if path := os.Getenv("PASSWORD_RESET_FILE"); path != "" {
+		return FileNotifier{Path: path}
+	}
+
+	return LogNotifier{}
+}
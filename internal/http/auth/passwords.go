@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// resetTokenLifespanInMinutes is how long a password reset token can be used for
+const resetTokenLifespanInMinutes = 30
+
+// ErrInvalidResetToken is returned when a password reset token does not exist, expired or was already used
+var ErrInvalidResetToken = errors.New("password reset token is not valid")
+
+// Passwords changes and resets the passwords of the users, following the password policy
+type Passwords struct {
+	UserRepo repository.UserRepo
+	Sessions Sessions
+	Policy   PasswordPolicy
+	Notifier Notifier
+}
+
+// NewPasswords creates a new Passwords
+func NewPasswords(userRepo repository.UserRepo, sessions Sessions, policy PasswordPolicy, notifier Notifier) Passwords {
+	return Passwords{
+		UserRepo: userRepo,
+		Sessions: sessions,
+		Policy:   policy,
+		Notifier: notifier,
+	}
+}
+
+// Change changes the password of the user, who must know the current one. Every session of the user is revoked
+// and a new one is started, whose tokens are returned.
+func (p Passwords) Change(ctx context.Context, userID int64, currentPassword, newPassword string) (Tokens, error) {
+
+	user, err := p.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return Tokens{}, fmt.Errorf("could not get user: %v", err)
+	}
+
+	err = verifyPassword(currentPassword, user.Passhash)
+	if err != nil {
+		return Tokens{}, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	passhash, err := p.hash(user.Username, newPassword)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	err = p.UserRepo.UpdatePassword(ctx, user.ID, passhash)
+	if err != nil {
+		return Tokens{}, fmt.Errorf("could not update password: %v", err)
+	}
+
+	err = p.Sessions.Repository.RevokeUserSessions(ctx, user.ID)
+	if err != nil {
+		return Tokens{}, fmt.Errorf("could not revoke sessions: %v", err)
+	}
+
+	return p.Sessions.Start(ctx, user.ID)
+}
+
+// RequestReset sends a single use password reset token to the user through the notifier.
+// Unknown usernames are ignored, so that the response does not tell which usernames exist.
+func (p Passwords) RequestReset(ctx context.Context, username string) error {
+
+	user, err := p.UserRepo.GetUserByUsername(ctx, username)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not get user: %v", err)
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return fmt.Errorf("could not generate password reset token: %v", err)
+	}
+
+	expiresAt := time.Now().Add(time.Minute * time.Duration(resetTokenLifespanInMinutes))
+	_, err = p.UserRepo.InsertPasswordReset(ctx, models.PasswordResetTable{
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+		UserID:    user.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("could not insert password reset: %v", err)
+	}
+
+	err = p.Notifier.NotifyPasswordReset(ctx, user, token, expiresAt)
+	if err != nil {
+		return fmt.Errorf("could not notify password reset: %v", err)
+	}
+
+	return nil
+}
+
+// Reset sets the password of the user of a password reset token, which is used up, and revokes every session of the user
+func (p Passwords) Reset(ctx context.Context, token, newPassword string) error {
+
+	reset, err := p.UserRepo.GetPasswordResetByHash(ctx, hashToken(token))
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return fmt.Errorf("could not get password reset: %v", err)
+	}
+
+	if !reset.UsedAt.IsZero() || !time.Now().Before(reset.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	user, err := p.UserRepo.GetUserByID(ctx, reset.UserID)
+	if err != nil {
+		return fmt.Errorf("could not get user: %v", err)
+	}
+
+	passhash, err := p.hash(user.Username, newPassword)
+	if err != nil {
+		return err
+	}
+
+	err = p.UserRepo.ResetPassword(ctx, reset.ID, passhash)
+	if errors.Is(err, repository.ErrAlreadyUsed) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return fmt.Errorf("could not reset password: %v", err)
+	}
+
+	err = p.Sessions.Repository.RevokeUserSessions(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("could not revoke sessions: %v", err)
+	}
+
+	return nil
+}
+
+// hash validates a new password of the user against the policy and hashes it
+func (p Passwords) hash(username, password string) (string, error) {
+
+	err := p.Policy.Validate(username, password)
+	if err != nil {
+		return "", err
+	}
+
+	return EncryptPassword(username, password)
+}
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// notifierMock keeps the password reset tokens it is sent
+type notifierMock struct {
+	tokens []string
+}
+
+func (n *notifierMock) NotifyPasswordReset(ctx context.Context, user models.UserTable, token string, expiresAt time.Time) error {
+	n.tokens = append(n.tokens, token)
+	return nil
+}
+
+func newTestPasswords(t *testing.T, notifier Notifier) (Passwords, int64) {
+
+	userCache := cache.NewUser()
+	passhash, err := EncryptPassword("alice", "first password")
+	assert.NoError(t, err)
+	userID, err := userCache.InsertUser(context.Background(), models.UserTable{Username: "alice", Passhash: passhash})
+	assert.NoError(t, err)
+
+	return NewPasswords(&userCache, newTestSessions(t), DefaultPasswordPolicy, notifier), userID
+}
+
+func TestPasswords_Change(t *testing.T) {
+
+	ctx := context.Background()
+	passwords, userID := newTestPasswords(t, &notifierMock{})
+
+	before, err := passwords.Sessions.Start(ctx, userID)
+	assert.NoError(t, err)
+
+	_, err = passwords.Change(ctx, userID, "wrong password", "second password")
+	assert.True(t, errors.Is(err, ErrInvalidCredentials), err)
+
+	_, err = passwords.Change(ctx, userID, "first password", "short")
+	assert.True(t, errors.Is(err, ErrWeakPassword), err)
+
+	after, err := passwords.Change(ctx, userID, "first password", "second password")
+	assert.NoError(t, err)
+
+	_, err = passwords.Sessions.Authenticate(ctx, before.AccessToken)
+	assert.True(t, errors.Is(err, ErrSessionEnded), err)
+	_, err = passwords.Sessions.Authenticate(ctx, after.AccessToken)
+	assert.NoError(t, err)
+
+	user, err := passwords.UserRepo.GetUserByID(ctx, userID)
+	assert.NoError(t, err)
+	assert.NoError(t, verifyPassword("second password", user.Passhash))
+}
+
+func TestPasswords_Reset(t *testing.T) {
+
+	ctx := context.Background()
+	notifier := &notifierMock{}
+	passwords, userID := newTestPasswords(t, notifier)
+
+	before, err := passwords.Sessions.Start(ctx, userID)
+	assert.NoError(t, err)
+
+	err = passwords.RequestReset(ctx, "bob")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(notifier.tokens))
+
+	err = passwords.RequestReset(ctx, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(notifier.tokens))
+	token := notifier.tokens[0]
+
+	err = passwords.Reset(ctx, token, "short")
+	assert.True(t, errors.Is(err, ErrWeakPassword), err)
+
+	err = passwords.Reset(ctx, "unknown", "second password")
+	assert.True(t, errors.Is(err, ErrInvalidResetToken), err)
+
+	err = passwords.Reset(ctx, token, "second password")
+	assert.NoError(t, err)
+
+	err = passwords.Reset(ctx, token, "third password!")
+	assert.True(t, errors.Is(err, ErrInvalidResetToken), err)
+
+	_, err = passwords.Sessions.Authenticate(ctx, before.AccessToken)
+	assert.True(t, errors.Is(err, ErrSessionEnded), err)
+
+	user, err := passwords.UserRepo.GetUserByID(ctx, userID)
+	assert.NoError(t, err)
+	assert.NoError(t, verifyPassword("second password", user.Passhash))
+}
+
+func TestPasswords_Reset_Expired(t *testing.T) {
+
+	ctx := context.Background()
+	passwords, userID := newTestPasswords(t, &notifierMock{})
+
+	_, err := passwords.UserRepo.InsertPasswordReset(ctx, models.PasswordResetTable{
+		TokenHash: hashToken("expired"),
+		ExpiresAt: time.Now().Add(-time.Minute),
+		UserID:    userID,
+	})
+	assert.NoError(t, err)
+
+	err = passwords.Reset(ctx, "expired", "second password")
+	assert.True(t, errors.Is(err, ErrInvalidResetToken), err)
+}
+
+func TestFileNotifier(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "resets.jsonl")
+	t.Setenv("PASSWORD_RESET_FILE", path)
+
+	notifier := NotifierFromEnv()
+	assert.Equal(t, FileNotifier{Path: path}, notifier)
+
+	expiresAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for _, token := range []string{"first", "second"} {
+		err := notifier.NotifyPasswordReset(context.Background(), models.UserTable{Username: "alice"}, token, expiresAt)
+		assert.NoError(t, err)
+	}
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	notifications := []passwordResetNotification{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var notification passwordResetNotification
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &notification))
+		notifications = append(notifications, notification)
+	}
+	assert.Equal(t, []passwordResetNotification{
+		{Username: "alice", Token: "first", ExpiresAt: expiresAt},
+		{Username: "alice", Token: "second", ExpiresAt: expiresAt},
+	}, notifications)
+}
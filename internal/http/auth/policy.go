@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxPasswordBytes is the longest password, since bcrypt only hashes the first 72 bytes
+const maxPasswordBytes = 72
+
+// ErrWeakPassword is returned when a password does not follow the password policy
+var ErrWeakPassword = errors.New("password does not follow the password policy")
+
+// PasswordPolicy is the rules new passwords must follow
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// DefaultPasswordPolicy requires passwords of at least 12 characters, of any kind
+var DefaultPasswordPolicy = PasswordPolicy{
+	MinLength: 12,
+}
+
+// PasswordPolicyError is returned when a password does not follow the password policy, listing the rules it breaks
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+// Error is the string representation of PasswordPolicyError
+func (ppe PasswordPolicyError) Error() string {
+	return fmt.Sprintf("password %s", strings.Join(ppe.Violations, ", "))
+}
+
+// Unwrap allows PasswordPolicyError to match ErrWeakPassword
+func (ppe PasswordPolicyError) Unwrap() error {
+	return ErrWeakPassword
+}
+
+// PasswordPolicyFromEnv reads the password policy from the PASSWORD_MIN_LENGTH env variable and the PASSWORD_REQUIRE one,
+// a comma separated list of upper, lower, digit and symbol. Unset variables keep the default policy.
+func PasswordPolicyFromEnv() (PasswordPolicy, error) {
+
+	policy := DefaultPasswordPolicy
+
+	if minLength := os.Getenv("PASSWORD_MIN_LENGTH"); minLength != "" {
+		length, err := strconv.Atoi(minLength)
+		if err != nil || length < 1 || length > maxPasswordBytes {
+			return PasswordPolicy{}, fmt.Errorf("PASSWORD_MIN_LENGTH must be between 1 and %d, got %q", maxPasswordBytes, minLength)
+		}
+		policy.MinLength = length
+	}
+
+	if require := strings.TrimSpace(os.Getenv("PASSWORD_REQUIRE")); require != "" {
+		for _, class := range strings.Split(require, ",") {
+			switch strings.TrimSpace(class) {
+			case "upper":
+				policy.RequireUpper = true
+			case "lower":
+				policy.RequireLower = true
+			case "digit":
+				policy.RequireDigit = true
+			case "symbol":
+				policy.RequireSymbol = true
+			default:
+				return PasswordPolicy{}, fmt.Errorf("PASSWORD_REQUIRE entries must be upper, lower, digit or symbol, got %q", class)
+			}
+		}
+	}
+
+	return policy, nil
+}
+
+// Validate returns a PasswordPolicyError when the password of the user breaks any of the rules of the policy
+func (p PasswordPolicy) Validate(username, password string) error {
+
+	violations := []string{}
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, fmt.Sprintf("must have at least %d characters", p.MinLength))
+	}
+	if len(password) > maxPasswordBytes {
+		violations = append(violations, fmt.Sprintf("must have at most %d bytes", maxPasswordBytes))
+	}
+	if username != "" && strings.EqualFold(password, username) {
+		violations = append(violations, "must not be the username")
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	if p.RequireUpper && !upper {
+		violations = append(violations, "must have an uppercase letter")
+	}
+	if p.RequireLower && !lower {
+		violations = append(violations, "must have a lowercase letter")
+	}
+	if p.RequireDigit && !digit {
+		violations = append(violations, "must have a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		violations = append(violations, "must have a symbol")
+	}
+
+	if len(violations) > 0 {
+		return PasswordPolicyError{Violations: violations}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+
+	strict := PasswordPolicy{MinLength: 8, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true}
+
+	tests := []struct {
+		name           string
+		policy         PasswordPolicy
+		password       string
+		wantViolations []string
+	}{
+		{name: "Long enough", policy: DefaultPasswordPolicy, password: "correct horse battery"},
+		{name: "Too short", policy: DefaultPasswordPolicy, password: "short", wantViolations: []string{"must have at least 12 characters"}},
+		{name: "Characters, not bytes", policy: DefaultPasswordPolicy, password: "çççççççççççç"},
+		{name: "Too long for bcrypt", policy: DefaultPasswordPolicy, password: strings.Repeat("a", 73), wantViolations: []string{"must have at most 72 bytes"}},
+		{name: "Username", policy: PasswordPolicy{MinLength: 4}, password: "ALICE", wantViolations: []string{"must not be the username"}},
+		{name: "Every class", policy: strict, password: "Tr0ub4dor&3"},
+		{
+			name:     "Missing classes",
+			policy:   strict,
+			password: "troubadour",
+			wantViolations: []string{
+				"must have an uppercase letter",
+				"must have a digit",
+				"must have a symbol",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate("alice", tt.password)
+			if tt.wantViolations == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, ErrWeakPassword))
+			assert.Equal(t, PasswordPolicyError{Violations: tt.wantViolations}, err)
+		})
+	}
+}
+
+func TestPasswordPolicyFromEnv(t *testing.T) {
+
+	t.Setenv("PASSWORD_MIN_LENGTH", "10")
+	t.Setenv("PASSWORD_REQUIRE", "upper, digit")
+
+	policy, err := PasswordPolicyFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, PasswordPolicy{MinLength: 10, RequireUpper: true, RequireDigit: true}, policy)
+
+	t.Setenv("PASSWORD_REQUIRE", "emoji")
+
+	_, err = PasswordPolicyFromEnv()
+	assert.Error(t, err)
+}
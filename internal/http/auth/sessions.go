@@ -0,0 +1,215 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// randomTokenSize is the number of random bytes of a refresh token or a password reset token
+const randomTokenSize = 32
+
+var (
+	// ErrInvalidRefreshToken is returned when a refresh token does not exist or its session ended
+	ErrInvalidRefreshToken = errors.New("refresh token is not valid")
+	// ErrRefreshTokenReused is returned when a refresh token is used twice, which revokes its session
+	ErrRefreshTokenReused = errors.New("refresh token was already used")
+	// ErrSessionEnded is returned when the session of an access token expired or was revoked
+	ErrSessionEnded = errors.New("session expired or was revoked")
+)
+
+// Tokens are the tokens of a session: a short lived access token and the single use refresh token
+// that gets the next access token
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// Sessions starts, refreshes and ends the login sessions of the users
+type Sessions struct {
+	Repository repository.SessionRepo
+	Keys       *KeySet
+}
+
+// NewSessions creates a new Sessions
+func NewSessions(sessionRepo repository.SessionRepo, keys *KeySet) Sessions {
+	return Sessions{
+		Repository: sessionRepo,
+		Keys:       keys,
+	}
+}
+
+// Start starts a session of the user, which lasts until it expires or it is revoked
+func (s Sessions) Start(ctx context.Context, userID int64) (Tokens, error) {
+
+	sessionID, err := s.Repository.InsertSession(ctx, models.SessionTable{
+		ExpiresAt: time.Now().Add(time.Hour * 24 * time.Duration(sessionLifespanInDays)),
+		UserID:    userID,
+	})
+	if err != nil {
+		return Tokens{}, fmt.Errorf("could not insert session: %v", err)
+	}
+
+	return s.issue(ctx, userID, sessionID)
+}
+
+// Refresh rotates a refresh token: it is used up and the session gets a new access token and refresh token.
+// A refresh token used twice was stolen, or the one who used it first was, so its session is revoked.
+func (s Sessions) Refresh(ctx context.Context, refreshToken string) (Tokens, error) {
+
+	stored, session, err := s.refreshTokenSession(ctx, refreshToken)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	if !stored.UsedAt.IsZero() {
+		return Tokens{}, s.revokeReused(ctx, session.ID)
+	}
+
+	if !active(session) {
+		return Tokens{}, ErrInvalidRefreshToken
+	}
+
+	err = s.Repository.UseRefreshToken(ctx, stored.ID)
+	if errors.Is(err, repository.ErrAlreadyUsed) {
+		return Tokens{}, s.revokeReused(ctx, session.ID)
+	}
+	if err != nil {
+		return Tokens{}, fmt.Errorf("could not use refresh token: %v", err)
+	}
+
+	return s.issue(ctx, session.UserID, session.ID)
+}
+
+// End revokes the session of a refresh token, so neither its access tokens nor its refresh tokens are valid anymore
+func (s Sessions) End(ctx context.Context, refreshToken string) error {
+
+	_, session, err := s.refreshTokenSession(ctx, refreshToken)
+	if err != nil {
+		return err
+	}
+
+	err = s.Repository.RevokeSession(ctx, session.ID)
+	if err != nil {
+		return fmt.Errorf("could not revoke session: %v", err)
+	}
+
+	return nil
+}
+
+// Authenticate validates an access token and its session, returning the id of the user it was issued to
+func (s Sessions) Authenticate(ctx context.Context, accessToken string) (int64, error) {
+
+	token, err := s.Keys.ParseToken(accessToken)
+	if err != nil {
+		return 0, err
+	}
+
+	session, err := s.Repository.GetSessionByID(ctx, token.SessionID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return 0, ErrSessionEnded
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not get session: %v", err)
+	}
+
+	if session.UserID != token.UserID || !active(session) {
+		return 0, ErrSessionEnded
+	}
+
+	return token.UserID, nil
+}
+
+// issue creates a new refresh token of the session and signs an access token of it
+func (s Sessions) issue(ctx context.Context, userID int64, sessionID int64) (Tokens, error) {
+
+	refreshToken, err := randomToken()
+	if err != nil {
+		return Tokens{}, fmt.Errorf("could not generate refresh token: %v", err)
+	}
+
+	_, err = s.Repository.InsertRefreshToken(ctx, models.RefreshTokenTable{
+		TokenHash: hashToken(refreshToken),
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return Tokens{}, fmt.Errorf("could not insert refresh token: %v", err)
+	}
+
+	accessToken, err := generateToken(s.Keys, userID, sessionID)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	return Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// refreshTokenSession gets a refresh token and its session
+func (s Sessions) refreshTokenSession(
+	ctx context.Context,
+	refreshToken string,
+) (models.RefreshTokenTable, models.SessionTable, error) {
+
+	stored, err := s.Repository.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.RefreshTokenTable{}, models.SessionTable{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return models.RefreshTokenTable{}, models.SessionTable{}, fmt.Errorf("could not get refresh token: %v", err)
+	}
+
+	session, err := s.Repository.GetSessionByID(ctx, stored.SessionID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.RefreshTokenTable{}, models.SessionTable{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return models.RefreshTokenTable{}, models.SessionTable{}, fmt.Errorf("could not get session: %v", err)
+	}
+
+	return stored, session, nil
+}
+
+// revokeReused revokes the session of a refresh token that was used twice
+func (s Sessions) revokeReused(ctx context.Context, sessionID int64) error {
+
+	err := s.Repository.RevokeSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("could not revoke session of a reused refresh token: %v", err)
+	}
+
+	return ErrRefreshTokenReused
+}
+
+// active tells if a session did not expire and was not revoked
+func active(session models.SessionTable) bool {
+	return session.RevokedAt.IsZero() && time.Now().Before(session.ExpiresAt)
+}
+
+// randomToken returns a random base64url token, such as a refresh token or a password reset token
+func randomToken() (string, error) {
+
+	random := make([]byte, randomTokenSize)
+	_, err := rand.Read(random)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(random), nil
+}
+
+// hashToken returns the hex SHA-256 hash random tokens are stored as
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSessions(t *testing.T) Sessions {
+
+	keys, err := GenerateKeySet()
+	assert.NoError(t, err)
+
+	sessionCache := cache.NewSession()
+	return NewSessions(&sessionCache, keys)
+}
+
+func TestSessions_Refresh(t *testing.T) {
+
+	ctx := context.Background()
+	sessions := newTestSessions(t)
+
+	first, err := sessions.Start(ctx, 7)
+	assert.NoError(t, err)
+
+	userID, err := sessions.Authenticate(ctx, first.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), userID)
+
+	second, err := sessions.Refresh(ctx, first.RefreshToken)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+
+	userID, err = sessions.Authenticate(ctx, second.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), userID)
+
+	third, err := sessions.Refresh(ctx, second.RefreshToken)
+	assert.NoError(t, err)
+
+	_, err = sessions.Refresh(ctx, "unknown")
+	assert.True(t, errors.Is(err, ErrInvalidRefreshToken), err)
+
+	// the first refresh token was rotated, so using it again revokes the whole session
+	_, err = sessions.Refresh(ctx, first.RefreshToken)
+	assert.True(t, errors.Is(err, ErrRefreshTokenReused), err)
+
+	_, err = sessions.Refresh(ctx, third.RefreshToken)
+	assert.True(t, errors.Is(err, ErrInvalidRefreshToken), err)
+
+	_, err = sessions.Authenticate(ctx, third.AccessToken)
+	assert.True(t, errors.Is(err, ErrSessionEnded), err)
+}
+
+func TestSessions_End(t *testing.T) {
+
+	ctx := context.Background()
+	sessions := newTestSessions(t)
+
+	loggedOut, err := sessions.Start(ctx, 7)
+	assert.NoError(t, err)
+	other, err := sessions.Start(ctx, 7)
+	assert.NoError(t, err)
+
+	err = sessions.End(ctx, loggedOut.RefreshToken)
+	assert.NoError(t, err)
+
+	_, err = sessions.Authenticate(ctx, loggedOut.AccessToken)
+	assert.True(t, errors.Is(err, ErrSessionEnded), err)
+
+	_, err = sessions.Refresh(ctx, loggedOut.RefreshToken)
+	assert.True(t, errors.Is(err, ErrInvalidRefreshToken), err)
+
+	userID, err := sessions.Authenticate(ctx, other.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), userID)
+
+	err = sessions.End(ctx, "unknown")
+	assert.True(t, errors.Is(err, ErrInvalidRefreshToken), err)
+}
+
+func TestSessions_Expired(t *testing.T) {
+
+	ctx := context.Background()
+	sessions := newTestSessions(t)
+
+	sessionID, err := sessions.Repository.InsertSession(ctx, models.SessionTable{
+		ExpiresAt: time.Now().Add(-time.Minute),
+		UserID:    7,
+	})
+	assert.NoError(t, err)
+
+	tokens, err := sessions.issue(ctx, 7, sessionID)
+	assert.NoError(t, err)
+
+	_, err = sessions.Authenticate(ctx, tokens.AccessToken)
+	assert.True(t, errors.Is(err, ErrSessionEnded), err)
+
+	_, err = sessions.Refresh(ctx, tokens.RefreshToken)
+	assert.True(t, errors.Is(err, ErrInvalidRefreshToken), err)
+}
@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/instrumentation"
+)
+
+const (
+	// FailureUnknownUser is the failed login reason of a username that does not exist
+	FailureUnknownUser = "unknown_user"
+	// FailureInvalidPassword is the failed login reason of a wrong password or a password that could not be verified
+	FailureInvalidPassword = "invalid_password"
+	// FailureInvalidCode is the failed login reason of a wrong two-factor code
+	FailureInvalidCode = "invalid_code"
+	// FailureLockedOut is the failed login reason of a username or an ip that is locked out
+	FailureLockedOut = "locked_out"
+
+	// maxTrackedLogins is the number of tracked usernames and ips above which the expired ones are dropped
+	maxTrackedLogins = 10000
+)
+
+// ErrLockedOut is returned when a login is attempted for a username or from an ip that is locked out
+var ErrLockedOut = errors.New("too many failed logins - try again later")
+
+// ThrottleLimits are the failed logins allowed per username and per ip within a window
+// before the username or the ip is locked out
+type ThrottleLimits struct {
+	UsernameAttempts int
+	IPAttempts       int
+	Window           time.Duration
+	Lockout          time.Duration
+}
+
+// DefaultThrottleLimits locks a username out after 5 failed logins and an ip after 20, within 15 minutes, for 15 minutes
+var DefaultThrottleLimits = ThrottleLimits{
+	UsernameAttempts: 5,
+	IPAttempts:       20,
+	Window:           15 * time.Minute,
+	Lockout:          15 * time.Minute,
+}
+
+// LoginThrottle counts the failed logins per username and per ip and temporarily locks them out
+type LoginThrottle struct {
+	limits   ThrottleLimits
+	mu       sync.Mutex
+	attempts map[string]*loginAttempts
+	now      func() time.Time
+}
+
+// loginAttempts are the failed logins of a username or an ip within the current window
+type loginAttempts struct {
+	failures    int
+	windowStart time.Time
+	lockedUntil time.Time
+}
+
+// NewLoginThrottle creates a new LoginThrottle
+func NewLoginThrottle(limits ThrottleLimits) *LoginThrottle {
+	return &LoginThrottle{
+		limits:   limits,
+		attempts: map[string]*loginAttempts{},
+		now:      time.Now,
+	}
+}
+
+// Check returns ErrLockedOut and how long until the lockout ends when the username or the ip is locked out
+func (t *LoginThrottle) Check(username, ip string) (time.Duration, error) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	var wait time.Duration
+	for _, key := range []string{usernameKey(username), ipKey(ip)} {
+		attempts, ok := t.attempts[key]
+		if ok && attempts.lockedUntil.Sub(now) > wait {
+			wait = attempts.lockedUntil.Sub(now)
+		}
+	}
+
+	if wait > 0 {
+		instrumentation.FailedLogins.WithLabelValues(FailureLockedOut).Inc()
+		return wait, ErrLockedOut
+	}
+
+	return 0, nil
+}
+
+// Fail records a failed login of the username from the ip, locking out either of them once it reaches its limit
+func (t *LoginThrottle) Fail(username, ip string, reason string) {
+
+	instrumentation.FailedLogins.WithLabelValues(reason).Inc()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.attempts) > maxTrackedLogins {
+		t.dropExpired()
+	}
+
+	if t.fail(usernameKey(username), t.limits.UsernameAttempts) {
+		instrumentation.LoginLockouts.WithLabelValues("username").Inc()
+	}
+	if t.fail(ipKey(ip), t.limits.IPAttempts) {
+		instrumentation.LoginLockouts.WithLabelValues("ip").Inc()
+	}
+}
+
+// Succeed forgets the failed logins of the username. The failed logins of the ip are kept,
+// so that logging into an account of one's own does not reset the guessing of other accounts.
+func (t *LoginThrottle) Succeed(username string) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.attempts, usernameKey(username))
+}
+
+// fail counts a failed login of the key and tells if it got the key locked out
+func (t *LoginThrottle) fail(key string, limit int) bool {
+
+	now := t.now()
+	attempts, ok := t.attempts[key]
+	if !ok || now.Sub(attempts.windowStart) > t.limits.Window {
+		attempts = &loginAttempts{windowStart: now, lockedUntil: attempts.lockedUntilOrZero()}
+		t.attempts[key] = attempts
+	}
+
+	attempts.failures++
+	if attempts.failures < limit {
+		return false
+	}
+
+	attempts.failures = 0
+	attempts.windowStart = now
+	attempts.lockedUntil = now.Add(t.limits.Lockout)
+
+	return true
+}
+
+// dropExpired drops the usernames and ips whose window and lockout are over
+func (t *LoginThrottle) dropExpired() {
+
+	now := t.now()
+	for key, attempts := range t.attempts {
+		if now.Sub(attempts.windowStart) > t.limits.Window && now.After(attempts.lockedUntil) {
+			delete(t.attempts, key)
+		}
+	}
+}
+
+// lockedUntilOrZero returns when the lockout of the attempts ends, or zero when there are no attempts
+func (a *loginAttempts) lockedUntilOrZero() time.Time {
+	if a == nil {
+		return time.Time{}
+	}
+	return a.lockedUntil
+}
+
+func usernameKey(username string) string {
+	return "username:" + username
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rubengomes8/golang-personal-finances/internal/instrumentation"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestThrottle(now *time.Time) *LoginThrottle {
+
+	throttle := NewLoginThrottle(ThrottleLimits{
+		UsernameAttempts: 3,
+		IPAttempts:       5,
+		Window:           10 * time.Minute,
+		Lockout:          15 * time.Minute,
+	})
+	throttle.now = func() time.Time { return *now }
+
+	return throttle
+}
+
+func TestLoginThrottle_Username(t *testing.T) {
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	throttle := newTestThrottle(&now)
+	lockouts := testutil.ToFloat64(instrumentation.LoginLockouts.WithLabelValues("username"))
+	lockedOut := testutil.ToFloat64(instrumentation.FailedLogins.WithLabelValues(FailureLockedOut))
+
+	for i := 0; i < 2; i++ {
+		throttle.Fail("alice", "10.0.0.1", FailureInvalidPassword)
+		_, err := throttle.Check("alice", "10.0.0.1")
+		assert.NoError(t, err)
+	}
+
+	throttle.Fail("alice", "10.0.0.2", FailureInvalidPassword)
+	assert.Equal(t, lockouts+1, testutil.ToFloat64(instrumentation.LoginLockouts.WithLabelValues("username")))
+
+	wait, err := throttle.Check("alice", "10.0.0.3")
+	assert.True(t, errors.Is(err, ErrLockedOut))
+	assert.Equal(t, 15*time.Minute, wait)
+	assert.Equal(t, lockedOut+1, testutil.ToFloat64(instrumentation.FailedLogins.WithLabelValues(FailureLockedOut)))
+
+	_, err = throttle.Check("bob", "10.0.0.1")
+	assert.NoError(t, err)
+
+	now = now.Add(15*time.Minute + time.Second)
+	_, err = throttle.Check("alice", "10.0.0.1")
+	assert.NoError(t, err)
+}
+
+func TestLoginThrottle_IP(t *testing.T) {
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	throttle := newTestThrottle(&now)
+
+	for _, username := range []string{"alice", "bob", "carol", "dave", "erin"} {
+		_, err := throttle.Check(username, "10.0.0.1")
+		assert.NoError(t, err)
+		throttle.Fail(username, "10.0.0.1", FailureUnknownUser)
+	}
+
+	_, err := throttle.Check("frank", "10.0.0.1")
+	assert.True(t, errors.Is(err, ErrLockedOut))
+
+	_, err = throttle.Check("frank", "10.0.0.2")
+	assert.NoError(t, err)
+}
+
+func TestLoginThrottle_Window(t *testing.T) {
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	throttle := newTestThrottle(&now)
+
+	throttle.Fail("alice", "10.0.0.1", FailureInvalidPassword)
+	throttle.Fail("alice", "10.0.0.1", FailureInvalidPassword)
+
+	// failed logins older than the window are forgotten
+	now = now.Add(11 * time.Minute)
+	throttle.Fail("alice", "10.0.0.1", FailureInvalidPassword)
+	_, err := throttle.Check("alice", "10.0.0.1")
+	assert.NoError(t, err)
+
+	// so are the failed logins of a username that logged in
+	throttle.Fail("alice", "10.0.0.1", FailureInvalidPassword)
+	throttle.Succeed("alice")
+	throttle.Fail("alice", "10.0.0.1", FailureInvalidPassword)
+	_, err = throttle.Check("alice", "10.0.0.1")
+	assert.NoError(t, err)
+}
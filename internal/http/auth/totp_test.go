@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238Secret is the SHA1 secret of the RFC 6238 test vectors, "12345678901234567890"
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTotpCode(t *testing.T) {
+
+	key, err := totpEncoding.DecodeString(rfc6238Secret)
+	assert.NoError(t, err)
+
+	// the RFC 6238 appendix B codes are of 8 digits, whose last 6 are the 6 digits codes
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, totpCode(key, totpStep(time.Unix(tt.unix, 0))))
+		})
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+
+	now := time.Unix(1111111111, 0)
+
+	step, ok := validateTOTP(rfc6238Secret, "050471", now)
+	assert.True(t, ok)
+	assert.Equal(t, totpStep(now), step)
+
+	// codes of the previous and the next steps are accepted for clock drift, but not older ones
+	_, ok = validateTOTP(rfc6238Secret, "050471", now.Add(totpPeriod))
+	assert.True(t, ok)
+	_, ok = validateTOTP(rfc6238Secret, "050471", now.Add(-totpPeriod))
+	assert.True(t, ok)
+	_, ok = validateTOTP(rfc6238Secret, "050471", now.Add(3*totpPeriod))
+	assert.False(t, ok)
+
+	_, ok = validateTOTP(rfc6238Secret, "050472", now)
+	assert.False(t, ok)
+	_, ok = validateTOTP("not base32!", "050471", now)
+	assert.False(t, ok)
+}
+
+func TestTotpURI(t *testing.T) {
+
+	uri, err := url.Parse(totpURI("Personal Finances", "alice", rfc6238Secret))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Personal Finances:alice", uri.Path)
+	assert.Equal(t, url.Values{
+		"secret":    {rfc6238Secret},
+		"issuer":    {"Personal Finances"},
+		"algorithm": {"SHA1"},
+		"digits":    {"6"},
+		"period":    {"30"},
+	}, uri.Query())
+}
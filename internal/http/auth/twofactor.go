@@ -0,0 +1,253 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const (
+	// challengeLifespanInMinutes is how long the second step of a login with 2FA can be completed for
+	challengeLifespanInMinutes = 5
+	// challengeType is the typ claim of the login challenges, which keeps them from being used as access tokens
+	challengeType = "2fa_challenge"
+	// recoveryCodesCount is the number of recovery codes given when 2FA is enabled
+	recoveryCodesCount = 10
+	// defaultTOTPIssuer is the issuer shown by the authenticator apps when TOTP_ISSUER is not set
+	defaultTOTPIssuer = "golang-personal-finances"
+)
+
+var (
+	// ErrTwoFactorEnabled is returned when enrolling or enabling a user who already has 2FA enabled
+	ErrTwoFactorEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrTwoFactorNotEnrolled is returned when enabling 2FA of a user who did not enrol
+	ErrTwoFactorNotEnrolled = errors.New("two-factor authentication enrolment was not started")
+	// ErrTwoFactorDisabled is returned when disabling 2FA of a user who does not have it enabled
+	ErrTwoFactorDisabled = errors.New("two-factor authentication is not enabled")
+	// ErrInvalidCode is returned when a TOTP code or a recovery code is wrong, expired or was already used
+	ErrInvalidCode = errors.New("two-factor code is not valid")
+	// ErrInvalidChallenge is returned when a login challenge is not valid or expired
+	ErrInvalidChallenge = errors.New("login challenge is not valid")
+)
+
+// Enrolment is the TOTP secret of a 2FA enrolment and its otpauth URI
+type Enrolment struct {
+	Secret string
+	URI    string
+}
+
+// TwoFactor enrols the users in RFC 6238 TOTP two-factor authentication and checks their codes
+type TwoFactor struct {
+	UserRepo repository.UserRepo
+	Keys     *KeySet
+	Issuer   string
+}
+
+// NewTwoFactor creates a new TwoFactor, whose otpauth URIs are of the issuer, or of the default one when it is empty
+func NewTwoFactor(userRepo repository.UserRepo, keys *KeySet, issuer string) TwoFactor {
+
+	if issuer == "" {
+		issuer = defaultTOTPIssuer
+	}
+
+	return TwoFactor{
+		UserRepo: userRepo,
+		Keys:     keys,
+		Issuer:   issuer,
+	}
+}
+
+// Enrol starts the 2FA enrolment of the user with a new TOTP secret. 2FA is only enabled once a code of it is verified.
+func (f TwoFactor) Enrol(ctx context.Context, userID int64) (Enrolment, error) {
+
+	user, err := f.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return Enrolment{}, fmt.Errorf("could not get user: %v", err)
+	}
+
+	if twoFactorEnabled(user) {
+		return Enrolment{}, ErrTwoFactorEnabled
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return Enrolment{}, fmt.Errorf("could not generate TOTP secret: %v", err)
+	}
+
+	err = f.UserRepo.SetTOTPSecret(ctx, user.ID, secret)
+	if err != nil {
+		return Enrolment{}, fmt.Errorf("could not set TOTP secret: %v", err)
+	}
+
+	return Enrolment{
+		Secret: secret,
+		URI:    totpURI(f.Issuer, user.Username, secret),
+	}, nil
+}
+
+// Enable verifies a TOTP code of the enrolment of the user and enables 2FA, returning the single use recovery codes
+func (f TwoFactor) Enable(ctx context.Context, userID int64, code string) ([]string, error) {
+
+	user, err := f.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get user: %v", err)
+	}
+
+	if twoFactorEnabled(user) {
+		return nil, ErrTwoFactorEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+
+	err = f.checkTOTP(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+	for i := 0; i < recoveryCodesCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("could not generate recovery code: %v", err)
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code)))
+	}
+
+	err = f.UserRepo.EnableTOTP(ctx, user.ID, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("could not enable TOTP: %v", err)
+	}
+
+	return codes, nil
+}
+
+// Disable disables the 2FA of the user, who must send a TOTP code or a recovery code
+func (f TwoFactor) Disable(ctx context.Context, userID int64, code string) error {
+
+	user, err := f.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("could not get user: %v", err)
+	}
+
+	if !twoFactorEnabled(user) {
+		return ErrTwoFactorDisabled
+	}
+
+	err = f.CheckCode(ctx, user, code)
+	if err != nil {
+		return err
+	}
+
+	err = f.UserRepo.DisableTOTP(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("could not disable TOTP: %v", err)
+	}
+
+	return nil
+}
+
+// Challenge signs the short lived challenge of a login of the user whose password was verified,
+// which gets the tokens of the login along with a code
+func (f TwoFactor) Challenge(userID int64) (string, error) {
+
+	claims := jwt.MapClaims{}
+
+	claims["typ"] = challengeType
+	claims[userIDKey] = userID
+	claims["exp"] = time.Now().Add(time.Minute * time.Duration(challengeLifespanInMinutes)).Unix()
+
+	return f.Keys.Sign(claims)
+}
+
+// ParseChallenge validates a login challenge and returns the id of the user it was issued to
+func (f TwoFactor) ParseChallenge(challenge string) (int64, error) {
+
+	token, err := f.Keys.Parse(challenge, jwt.MapClaims{})
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidChallenge, err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || claims["typ"] != challengeType {
+		return 0, ErrInvalidChallenge
+	}
+
+	userID, ok := claims[userIDKey].(float64)
+	if !ok {
+		return 0, ErrInvalidChallenge
+	}
+
+	return int64(userID), nil
+}
+
+// CheckCode checks a TOTP code or a recovery code of the user. Each code is accepted only once.
+func (f TwoFactor) CheckCode(ctx context.Context, user models.UserTable, code string) error {
+
+	code = strings.Join(strings.Fields(code), "")
+	if len(code) == totpDigits && strings.Trim(code, "0123456789") == "" {
+		return f.checkTOTP(ctx, user, code)
+	}
+
+	err := f.UserRepo.UseRecoveryCode(ctx, user.ID, hashToken(normalizeRecoveryCode(code)))
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrInvalidCode
+	}
+	if err != nil {
+		return fmt.Errorf("could not use recovery code: %v", err)
+	}
+
+	return nil
+}
+
+// checkTOTP checks a TOTP code of the secret of the user, rejecting the codes of a step that is not after the last accepted one
+func (f TwoFactor) checkTOTP(ctx context.Context, user models.UserTable, code string) error {
+
+	step, ok := validateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return ErrInvalidCode
+	}
+
+	err := f.UserRepo.UseTOTPStep(ctx, user.ID, step)
+	if errors.Is(err, repository.ErrAlreadyUsed) {
+		return ErrInvalidCode
+	}
+	if err != nil {
+		return fmt.Errorf("could not use TOTP step: %v", err)
+	}
+
+	return nil
+}
+
+// twoFactorEnabled tells if the user has 2FA enabled
+func twoFactorEnabled(user models.UserTable) bool {
+	return !user.TOTPEnabledAt.IsZero()
+}
+
+// generateRecoveryCode returns a random recovery code, such as "k3j9d-x7q2m"
+func generateRecoveryCode() (string, error) {
+
+	random := make([]byte, 8)
+	_, err := rand.Read(random)
+	if err != nil {
+		return "", err
+	}
+
+	code := strings.ToLower(totpEncoding.EncodeToString(random))[:10]
+
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeRecoveryCode returns a recovery code as it is hashed: lowercase, without dashes or spaces
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// codeAt returns the TOTP code of the secret at a number of steps from now
+func codeAt(t *testing.T, secret string, steps int64) string {
+
+	key, err := totpEncoding.DecodeString(secret)
+	assert.NoError(t, err)
+
+	return totpCode(key, totpStep(time.Now())+steps)
+}
+
+func TestTwoFactor(t *testing.T) {
+
+	ctx := context.Background()
+	userCache := cache.NewUser()
+	passhash, err := EncryptPassword("alice", "first password")
+	assert.NoError(t, err)
+	userID, err := userCache.InsertUser(ctx, models.UserTable{Username: "alice", Passhash: passhash})
+	assert.NoError(t, err)
+
+	sessions := newTestSessions(t)
+	twoFactor := NewTwoFactor(&userCache, sessions.Keys, "")
+
+	_, err = twoFactor.Enable(ctx, userID, "123456")
+	assert.True(t, errors.Is(err, ErrTwoFactorNotEnrolled), err)
+
+	enrolment, err := twoFactor.Enrol(ctx, userID)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(enrolment.URI, "otpauth://totp/golang-personal-finances:alice?"), enrolment.URI)
+
+	// the password still logs in until a code of the secret is verified
+	user, err := userCache.GetUserByID(ctx, userID)
+	assert.NoError(t, err)
+	result, err := LoginCheck(ctx, sessions, twoFactor, "alice", "first password", user)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "", result.Tokens.AccessToken)
+
+	_, err = twoFactor.Enable(ctx, userID, "000000")
+	assert.True(t, errors.Is(err, ErrInvalidCode), err)
+
+	recoveryCodes, err := twoFactor.Enable(ctx, userID, codeAt(t, enrolment.Secret, -1))
+	assert.NoError(t, err)
+	assert.Equal(t, recoveryCodesCount, len(recoveryCodes))
+
+	_, err = twoFactor.Enrol(ctx, userID)
+	assert.True(t, errors.Is(err, ErrTwoFactorEnabled), err)
+
+	// the password only gets a challenge now, which is not an access token
+	user, err = userCache.GetUserByID(ctx, userID)
+	assert.NoError(t, err)
+	result, err = LoginCheck(ctx, sessions, twoFactor, "alice", "first password", user)
+	assert.NoError(t, err)
+	assert.Equal(t, Tokens{}, result.Tokens)
+
+	_, err = sessions.Authenticate(ctx, result.Challenge)
+	assert.Error(t, err)
+	challengeUserID, err := twoFactor.ParseChallenge(result.Challenge)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, challengeUserID)
+
+	accessToken, err := sessions.Start(ctx, userID)
+	assert.NoError(t, err)
+	_, err = twoFactor.ParseChallenge(accessToken.AccessToken)
+	assert.True(t, errors.Is(err, ErrInvalidChallenge), err)
+
+	// codes are accepted once, and never the ones of a step before the last accepted one
+	code := codeAt(t, enrolment.Secret, 0)
+	assert.NoError(t, twoFactor.CheckCode(ctx, user, code))
+	assert.True(t, errors.Is(twoFactor.CheckCode(ctx, user, code), ErrInvalidCode))
+	assert.True(t, errors.Is(twoFactor.CheckCode(ctx, user, codeAt(t, enrolment.Secret, -1)), ErrInvalidCode))
+
+	assert.NoError(t, twoFactor.CheckCode(ctx, user, strings.ToUpper(recoveryCodes[0])))
+	assert.True(t, errors.Is(twoFactor.CheckCode(ctx, user, recoveryCodes[0]), ErrInvalidCode))
+	assert.True(t, errors.Is(twoFactor.CheckCode(ctx, user, "aaaaa-bbbbb"), ErrInvalidCode))
+
+	err = twoFactor.Disable(ctx, userID, "aaaaa-bbbbb")
+	assert.True(t, errors.Is(err, ErrInvalidCode), err)
+
+	err = twoFactor.Disable(ctx, userID, recoveryCodes[1])
+	assert.NoError(t, err)
+
+	user, err = userCache.GetUserByID(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, "", user.TOTPSecret)
+	assert.True(t, errors.Is(twoFactor.CheckCode(ctx, user, recoveryCodes[2]), ErrInvalidCode))
+
+	err = twoFactor.Disable(ctx, userID, recoveryCodes[2])
+	assert.True(t, errors.Is(err, ErrTwoFactorDisabled), err)
+}
+
+func TestTwoFactor_ExpiredChallenge(t *testing.T) {
+
+	twoFactor := NewTwoFactor(nil, newTestSessions(t).Keys, "")
+
+	challenge, err := twoFactor.Keys.Sign(jwt.MapClaims{
+		"typ":     challengeType,
+		userIDKey: 7,
+		"exp":     time.Now().Add(-time.Minute).Unix(),
+	})
+	assert.NoError(t, err)
+
+	_, err = twoFactor.ParseChallenge(challenge)
+	assert.True(t, errors.Is(err, ErrInvalidChallenge), err)
+}
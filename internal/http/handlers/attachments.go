@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/attachments"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// multipartOverhead is the room left on an upload request for the multipart boundaries and headers
+const multipartOverhead = 1 << 20
+
+// Attachments handles the attachments http requests
+type Attachments struct {
+	Manager attachments.Manager
+}
+
+// NewAttachments creates a new Attachments service
+func NewAttachments(manager attachments.Manager) Attachments {
+	return Attachments{
+		Manager: manager,
+	}
+}
+
+// UploadExpenseAttachment uploads a file to an expense.
+// ShowEntity godoc
+// @tags Attachments
+// @Summary Uploads a file to an expense.
+// @Description Endpoint to attach a file, such as a receipt, to an expense. The content type is detected from the content
+// @Description when the file part does not set one.
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The expense id"
+// @Param file formData file true "The file"
+// @Success 201 {object} models.Attachment
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense/{id}/attachments [post]
+func (a *Attachments) UploadExpenseAttachment(ctx *gin.Context) {
+	a.upload(ctx, "expense", attachments.ExpenseOwner)
+}
+
+// UploadIncomeAttachment uploads a file to an income.
+// ShowEntity godoc
+// @tags Attachments
+// @Summary Uploads a file to an income.
+// @Description Endpoint to attach a file, such as a payslip, to an income. The content type is detected from the content
+// @Description when the file part does not set one.
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The income id"
+// @Param file formData file true "The file"
+// @Success 201 {object} models.Attachment
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/income/{id}/attachments [post]
+func (a *Attachments) UploadIncomeAttachment(ctx *gin.Context) {
+	a.upload(ctx, "income", attachments.IncomeOwner)
+}
+
+// GetExpenseAttachments gets the attachments of an expense.
+// ShowEntity godoc
+// @tags Attachments
+// @Summary Gets the attachments of an expense.
+// @Description Endpoint to get the metadata of the files attached to an expense, oldest first.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The expense id"
+// @Success 200 {object} []models.Attachment
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense/{id}/attachments [get]
+func (a *Attachments) GetExpenseAttachments(ctx *gin.Context) {
+	a.list(ctx, "expense", attachments.ExpenseOwner)
+}
+
+// GetIncomeAttachments gets the attachments of an income.
+// ShowEntity godoc
+// @tags Attachments
+// @Summary Gets the attachments of an income.
+// @Description Endpoint to get the metadata of the files attached to an income, oldest first.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The income id"
+// @Success 200 {object} []models.Attachment
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/income/{id}/attachments [get]
+func (a *Attachments) GetIncomeAttachments(ctx *gin.Context) {
+	a.list(ctx, "income", attachments.IncomeOwner)
+}
+
+// DownloadAttachment downloads the content of an attachment.
+// ShowEntity godoc
+// @tags Attachments
+// @Summary Downloads an attachment by its id.
+// @Description Endpoint to download the file of an attachment, with its content type and file name.
+// @Description The ETag is the checksum of the content.
+// @Produce octet-stream
+// @Security ApiKeyAuth
+// @Param id query string true "The attachment id"
+// @Success 200 {file} file
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/attachment/{id} [get]
+func (a *Attachments) DownloadAttachment(ctx *gin.Context) {
+
+	attachmentID, ok := a.attachmentID(ctx)
+	if !ok {
+		return
+	}
+
+	attachment, content, err := a.Manager.Open(ctx, auth.UserID(ctx), attachmentID)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "attachment with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not open attachment with id %d: %v", attachmentID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not download attachment",
+		})
+		return
+	}
+	defer content.Close()
+
+	ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"ETag":                fmt.Sprintf("%q", attachment.Checksum),
+	})
+}
+
+// DeleteAttachment deletes an attachment and its content.
+// ShowEntity godoc
+// @tags Attachments
+// @Summary Deletes an attachment by its id.
+// @Description Endpoint to delete an attachment by id, along with its file.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The attachment id"
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/attachment/{id} [delete]
+func (a *Attachments) DeleteAttachment(ctx *gin.Context) {
+
+	attachmentID, ok := a.attachmentID(ctx)
+	if !ok {
+		return
+	}
+
+	err := a.Manager.Delete(ctx, auth.UserID(ctx), attachmentID)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "attachment with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not delete attachment with id %d: %v", attachmentID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not delete attachment",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// upload stores the file of a multipart request as an attachment of the expense or income on the id parameter
+func (a *Attachments) upload(ctx *gin.Context, ownerName string, owner func(int64) attachments.Owner) {
+
+	paramID := ctx.Param("id")
+
+	ownerID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting %s id to int - param id is %v - %v", ownerName, paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	maxRequestSize := a.Manager.MaxSize + multipartOverhead
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxRequestSize)
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil && ctx.Request.ContentLength > maxRequestSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("file must be up to %d bytes", a.Manager.MaxSize),
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not get attachment file: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not get file",
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Printf("could not open attachment file: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not open file",
+		})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := a.Manager.Upload(
+		ctx,
+		auth.UserID(ctx),
+		owner(int64(ownerID)),
+		fileHeader.Filename,
+		fileHeader.Header.Get("Content-Type"),
+		file,
+	)
+	switch {
+	case errors.Is(err, attachments.ErrInvalidAttachment):
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "file must have a name and a valid content type",
+		})
+		return
+	case errors.Is(err, attachments.ErrTooLarge):
+		ctx.JSON(http.StatusRequestEntityTooLarge, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("file must be up to %d bytes", a.Manager.MaxSize),
+		})
+		return
+	case errors.Is(err, repository.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("%s with this id does not exist", ownerName),
+		})
+		return
+	case err != nil:
+		log.Printf("could not upload attachment to %s with param id = %v: %v", ownerName, paramID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not upload attachment",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, attachmentTableToAttachment(attachment))
+	ctx.Writer.Flush()
+}
+
+// list gets the attachments of the expense or income on the id parameter
+func (a *Attachments) list(ctx *gin.Context, ownerName string, owner func(int64) attachments.Owner) {
+
+	paramID := ctx.Param("id")
+
+	ownerID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting %s id to int - param id is %v - %v", ownerName, paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	attachmentRecords, err := a.Manager.List(ctx, auth.UserID(ctx), owner(int64(ownerID)))
+	if errors.Is(err, attachments.ErrInvalidAttachment) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be a positive integer",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not get attachments of %s with param id = %v: %v", ownerName, paramID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get attachments",
+		})
+		return
+	}
+
+	response := []models.Attachment{}
+	for _, attachment := range attachmentRecords {
+		response = append(response, attachmentTableToAttachment(attachment))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+	ctx.Writer.Flush()
+}
+
+func (a *Attachments) attachmentID(ctx *gin.Context) (int64, bool) {
+
+	paramID := ctx.Param("id")
+
+	attachmentID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting attachment id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return 0, false
+	}
+
+	return int64(attachmentID), true
+}
+
+func attachmentTableToAttachment(attachment dbModels.AttachmentTable) models.Attachment {
+	return models.Attachment{
+		ID:          int(attachment.ID),
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Checksum:    attachment.Checksum,
+		CreatedAt:   attachment.CreatedAt.UTC().Format(time.RFC3339),
+		ExpenseID:   int(attachment.ExpenseID),
+		IncomeID:    int(attachment.IncomeID),
+	}
+}
@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
 	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
@@ -14,13 +17,27 @@ import (
 
 // Auth handles the authentication requests
 type Auth struct {
-	UserRepo repository.UserRepo
+	UserRepo  repository.UserRepo
+	Sessions  auth.Sessions
+	Throttle  *auth.LoginThrottle
+	Passwords auth.Passwords
+	TwoFactor auth.TwoFactor
 }
 
 // NewAuth creates a new Auth
-func NewAuth(userRepo repository.UserRepo) Auth {
+func NewAuth(
+	userRepo repository.UserRepo,
+	sessions auth.Sessions,
+	throttle *auth.LoginThrottle,
+	passwords auth.Passwords,
+	twoFactor auth.TwoFactor,
+) Auth {
 	return Auth{
-		UserRepo: userRepo,
+		UserRepo:  userRepo,
+		Sessions:  sessions,
+		Throttle:  throttle,
+		Passwords: passwords,
+		TwoFactor: twoFactor,
 	}
 }
 
@@ -37,6 +54,14 @@ func (a Auth) Register(ctx *gin.Context) {
 		return
 	}
 
+	err := a.Passwords.Policy.Validate(input.Username, input.Password)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+
 	hashedPwd, err := auth.EncryptPassword(input.Username, input.Password)
 	if err != nil {
 		log.Printf("could not encrypt user password: %v", err)
@@ -77,26 +102,426 @@ func (a Auth) Login(ctx *gin.Context) {
 		return
 	}
 
-	userTable, err := a.UserRepo.GetUserByUsername(ctx, input.Username)
+	wait, err := a.Throttle.Check(input.Username, ctx.ClientIP())
 	if err != nil {
+		log.Printf("login of %s from %s is locked out", input.Username, ctx.ClientIP())
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		ctx.JSON(http.StatusTooManyRequests, models.ErrorResponse{
+			ErrorMsg: "too many failed logins, try again later",
+		})
+		return
+	}
+
+	userTable, err := a.UserRepo.GetUserByUsername(ctx, input.Username)
+	if errors.Is(err, repository.ErrNotFound) {
 		log.Printf("error getting user by username: %v", err)
+		a.Throttle.Fail(input.Username, ctx.ClientIP(), auth.FailureUnknownUser)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
 			ErrorMsg: "users does not exist",
 		})
 		return
 	}
-
-	token, err := auth.LoginCheck(ctx, input.Username, input.Password, userTable)
 	if err != nil {
+		log.Printf("error getting user by username: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not login user",
+		})
+		return
+	}
+
+	result, err := auth.LoginCheck(ctx, a.Sessions, a.TwoFactor, input.Username, input.Password, userTable)
+	if errors.Is(err, auth.ErrInvalidCredentials) {
 		log.Printf("error validating login credentials: %v", err)
+		a.Throttle.Fail(input.Username, ctx.ClientIP(), auth.FailureInvalidPassword)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not login user",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("error starting session: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not login user",
+		})
+		return
+	}
+
+	if result.Challenge != "" {
+		ctx.JSON(http.StatusOK, models.ChallengeResponse{
+			Challenge: result.Challenge,
+		})
+		ctx.Writer.Flush()
+		return
+	}
+	a.Throttle.Succeed(input.Username)
+
+	ctx.JSON(http.StatusOK, models.TokenResponse{
+		Token:        result.Tokens.AccessToken,
+		RefreshToken: result.Tokens.RefreshToken,
+	})
+	ctx.Writer.Flush()
+}
+
+// LoginTwoFactor completes the login of a user with 2FA enabled, with the challenge of the first step
+// and a TOTP code or a recovery code
+func (a Auth) LoginTwoFactor(ctx *gin.Context) {
+
+	var input models.LoginTwoFactorInput
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("could not bind 2FA login json: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "wrong body format or incomplete data",
+		})
+		return
+	}
+
+	userID, err := a.TwoFactor.ParseChallenge(input.Challenge)
+	if err != nil {
+		log.Printf("could not parse login challenge: %v", err)
+		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			ErrorMsg: "invalid or expired login challenge",
+		})
+		return
+	}
+
+	userTable, err := a.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Printf("error getting user by id: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not login user",
+		})
+		return
+	}
+
+	wait, err := a.Throttle.Check(userTable.Username, ctx.ClientIP())
+	if err != nil {
+		log.Printf("login of %s from %s is locked out", userTable.Username, ctx.ClientIP())
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		ctx.JSON(http.StatusTooManyRequests, models.ErrorResponse{
+			ErrorMsg: "too many failed logins, try again later",
+		})
+		return
+	}
+
+	err = a.TwoFactor.CheckCode(ctx, userTable, input.Code)
+	if errors.Is(err, auth.ErrInvalidCode) {
+		a.Throttle.Fail(userTable.Username, ctx.ClientIP(), auth.FailureInvalidCode)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "invalid two-factor code",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not check 2FA code: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			ErrorMsg: "could not login user",
 		})
 		return
 	}
 
+	tokens, err := a.Sessions.Start(ctx, userTable.ID)
+	if err != nil {
+		log.Printf("error starting session: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not login user",
+		})
+		return
+	}
+	a.Throttle.Succeed(userTable.Username)
+
 	ctx.JSON(http.StatusOK, models.TokenResponse{
-		Token: token,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	})
 	ctx.Writer.Flush()
 }
+
+// Refresh exchanges a refresh token for a new access token and a new refresh token.
+// Reusing a refresh token revokes its session.
+func (a Auth) Refresh(ctx *gin.Context) {
+
+	var input models.RefreshInput
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("could not bind refresh json: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "wrong body format or incomplete data",
+		})
+		return
+	}
+
+	tokens, err := a.Sessions.Refresh(ctx, input.RefreshToken)
+	if errors.Is(err, auth.ErrInvalidRefreshToken) || errors.Is(err, auth.ErrRefreshTokenReused) {
+		log.Printf("could not refresh session: %v", err)
+		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			ErrorMsg: "invalid refresh token",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not refresh session: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not refresh session",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.TokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	})
+	ctx.Writer.Flush()
+}
+
+// Logout revokes the session of a refresh token, along with its access tokens
+func (a Auth) Logout(ctx *gin.Context) {
+
+	var input models.RefreshInput
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("could not bind logout json: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "wrong body format or incomplete data",
+		})
+		return
+	}
+
+	err := a.Sessions.End(ctx, input.RefreshToken)
+	if errors.Is(err, auth.ErrInvalidRefreshToken) {
+		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			ErrorMsg: "invalid refresh token",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not end session: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not logout",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// JWKS publishes the public keys other services verify the tokens with, by the kid header of the tokens.
+// HMAC keys are never published.
+func (a Auth) JWKS(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, a.Sessions.Keys.JWKS())
+	ctx.Writer.Flush()
+}
+
+// ChangePassword changes the password of the authenticated user, who must send the current one.
+// Every session of the user is revoked and the tokens of a new one are returned.
+func (a Auth) ChangePassword(ctx *gin.Context) {
+
+	var input models.ChangePasswordInput
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("could not bind change password json: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "wrong body format or incomplete data",
+		})
+		return
+	}
+
+	tokens, err := a.Passwords.Change(ctx, auth.UserID(ctx), input.CurrentPassword, input.NewPassword)
+	if errors.Is(err, auth.ErrInvalidCredentials) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "current password is not valid",
+		})
+		return
+	}
+	if errors.Is(err, auth.ErrWeakPassword) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not change password: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not change password",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.TokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	})
+	ctx.Writer.Flush()
+}
+
+// RequestPasswordReset sends a password reset token to the user through the notifier.
+// It answers the same whether the user exists or not.
+func (a Auth) RequestPasswordReset(ctx *gin.Context) {
+
+	var input models.PasswordResetRequestInput
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("could not bind password reset request json: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "wrong body format or incomplete data",
+		})
+		return
+	}
+
+	err := a.Passwords.RequestReset(ctx, input.Username)
+	if err != nil {
+		log.Printf("could not request password reset: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not request password reset",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// ResetPassword sets a new password with a password reset token, which can only be used once,
+// and revokes every session of the user
+func (a Auth) ResetPassword(ctx *gin.Context) {
+
+	var input models.PasswordResetInput
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("could not bind password reset json: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "wrong body format or incomplete data",
+		})
+		return
+	}
+
+	err := a.Passwords.Reset(ctx, input.Token, input.NewPassword)
+	if errors.Is(err, auth.ErrInvalidResetToken) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "invalid or expired password reset token",
+		})
+		return
+	}
+	if errors.Is(err, auth.ErrWeakPassword) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not reset password: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not reset password",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// EnrolTwoFactor starts the 2FA enrolment of the authenticated user, returning the TOTP secret and its otpauth URI.
+// 2FA is only enabled once a code of the secret is verified.
+func (a Auth) EnrolTwoFactor(ctx *gin.Context) {
+
+	enrolment, err := a.TwoFactor.Enrol(ctx, auth.UserID(ctx))
+	if errors.Is(err, auth.ErrTwoFactorEnabled) {
+		ctx.JSON(http.StatusConflict, models.ErrorResponse{
+			ErrorMsg: "two-factor authentication is already enabled",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not enrol 2FA: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not enrol two-factor authentication",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.TwoFactorEnrolmentResponse{
+		Secret: enrolment.Secret,
+		URI:    enrolment.URI,
+	})
+	ctx.Writer.Flush()
+}
+
+// EnableTwoFactor verifies a TOTP code of the enrolment of the authenticated user and enables 2FA,
+// returning the single use recovery codes
+func (a Auth) EnableTwoFactor(ctx *gin.Context) {
+
+	var input models.TwoFactorCodeInput
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("could not bind 2FA code json: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "wrong body format or incomplete data",
+		})
+		return
+	}
+
+	codes, err := a.TwoFactor.Enable(ctx, auth.UserID(ctx), input.Code)
+	if errors.Is(err, auth.ErrTwoFactorEnabled) || errors.Is(err, auth.ErrTwoFactorNotEnrolled) {
+		ctx.JSON(http.StatusConflict, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, auth.ErrInvalidCode) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "invalid two-factor code",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not enable 2FA: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not enable two-factor authentication",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	})
+	ctx.Writer.Flush()
+}
+
+// DisableTwoFactor disables the 2FA of the authenticated user, who must send a TOTP code or a recovery code
+func (a Auth) DisableTwoFactor(ctx *gin.Context) {
+
+	var input models.TwoFactorCodeInput
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		log.Printf("could not bind 2FA code json: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "wrong body format or incomplete data",
+		})
+		return
+	}
+
+	err := a.TwoFactor.Disable(ctx, auth.UserID(ctx), input.Code)
+	if errors.Is(err, auth.ErrTwoFactorDisabled) {
+		ctx.JSON(http.StatusConflict, models.ErrorResponse{
+			ErrorMsg: "two-factor authentication is not enabled",
+		})
+		return
+	}
+	if errors.Is(err, auth.ErrInvalidCode) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "invalid two-factor code",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not disable 2FA: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not disable two-factor authentication",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
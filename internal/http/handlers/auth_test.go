@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuth_Login_SpoofedForwardedFor(t *testing.T) {
+
+	// GIVEN
+	userRepo := cache.NewUser()
+	sessionRepo := cache.NewSession()
+	authHandlers := NewAuth(
+		&userRepo,
+		auth.NewSessions(&sessionRepo, nil),
+		auth.NewLoginThrottle(auth.ThrottleLimits{
+			UsernameAttempts: 10,
+			IPAttempts:       3,
+			Window:           10 * time.Minute,
+			Lockout:          15 * time.Minute,
+		}),
+		auth.Passwords{},
+		auth.TwoFactor{},
+	)
+
+	// as set up by routes.SetupRouter when TRUSTED_PROXIES is not set
+	r := gin.New()
+	err := r.SetTrustedProxies(nil)
+	if err != nil {
+		t.Fatalf("error setting trusted proxies: %v\n", err)
+	}
+	r.POST("/auth/login/", authHandlers.Login)
+
+	login := func(username, forwardedFor string) int {
+
+		data, err := json.Marshal(models.LoginInput{
+			Username: username,
+			Password: "not-the-password",
+		})
+		if err != nil {
+			t.Fatalf("error marshaling login: %v\n", err)
+		}
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/auth/login/", bytes.NewBuffer(data))
+		req.RemoteAddr = "203.0.113.7:40000"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+
+		r.ServeHTTP(w, req)
+
+		return w.Code
+	}
+
+	// WHEN
+	for i := 0; i < 3; i++ {
+		code := login(fmt.Sprintf("user-%d", i), fmt.Sprintf("198.51.100.%d", i))
+		assert.EqualValues(t, http.StatusBadRequest, code)
+	}
+
+	// THEN
+	assert.EqualValues(t, http.StatusTooManyRequests, login("user-3", "198.51.100.3"))
+}
@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/balances"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/utils"
+)
+
+// Balances handles the card balances and reconciliations http requests
+type Balances struct {
+	Calculator balances.Calculator
+}
+
+// NewBalances creates a new Balances service
+func NewBalances(calculator balances.Calculator) Balances {
+	return Balances{
+		Calculator: calculator,
+	}
+}
+
+// SetOpeningBalance sets the balance of a card on its opening date.
+// ShowEntity godoc
+// @tags Balances
+// @Summary Sets the opening balance of a card.
+// @Description Endpoint to set the balance of a card on its opening date. Its balance only counts the movements from that date on,
+// @Description or every movement without an opening date.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param card query string true "The card name"
+// @Param body body models.OpeningBalance true "Opening balance request"
+// @Success 204 "No content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/opening-balance/{card} [put]
+func (b *Balances) SetOpeningBalance(ctx *gin.Context) {
+
+	var opening models.OpeningBalance
+	err := json.NewDecoder(ctx.Request.Body).Decode(&opening)
+	if err != nil {
+		log.Printf("could not decode opening balance body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode opening balance",
+		})
+		return
+	}
+
+	var openingDate time.Time
+	if opening.OpeningDate != "" {
+		openingDate, err = utils.DateStringToTime(opening.OpeningDate)
+		if err != nil {
+			log.Printf("could not convert opening date string to time - opening date is %v - %v", opening.OpeningDate, err)
+			ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+				ErrorMsg: "could not parse opening date - must use YYYY-MM-DD date format",
+			})
+			return
+		}
+	}
+
+	err = b.Calculator.SetOpeningBalance(ctx, auth.UserID(ctx), ctx.Param("card"), opening.OpeningBalance, openingDate)
+	if err != nil {
+		log.Printf("could not set opening balance of card %v: %v", ctx.Param("card"), err)
+		balanceErrorResponse(ctx, err, "could not set opening balance")
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// GetBalance gets the balance of a card as of a date.
+// ShowEntity godoc
+// @tags Balances
+// @Summary Gets the balance of a card as of a date.
+// @Description Endpoint to get the balance of a card as of a date: its opening balance plus its incomes and transfers in, minus
+// @Description its expenses and transfers out (with their fees), with the running balance after each of them. Movements in another
+// @Description currency are converted to the one of the card at the exchange rate of their date.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param card query string true "The card name"
+// @Param date query string true "The date of the balance, YYYY-MM-DD"
+// @Success 200 {object} models.Balance
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/balance/{card}/{date} [get]
+func (b *Balances) GetBalance(ctx *gin.Context) {
+
+	paramDate := ctx.Param("date")
+
+	date, err := utils.DateStringToTime(paramDate)
+	if err != nil {
+		log.Printf("could not convert date string to time - date is %v - %v", paramDate, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not parse date - must use YYYY-MM-DD date format",
+		})
+		return
+	}
+
+	balance, err := b.Calculator.Balance(ctx, auth.UserID(ctx), ctx.Param("card"), date)
+	if err != nil {
+		log.Printf("could not get balance of card %v on %v: %v", ctx.Param("card"), paramDate, err)
+		balanceErrorResponse(ctx, err, "could not get balance")
+		return
+	}
+
+	response := models.Balance{
+		Card:           balance.Card.Name,
+		Currency:       balance.Card.Currency,
+		Date:           utils.TimeToStringDate(balance.Date),
+		OpeningBalance: balance.Card.OpeningBalance,
+		Balance:        balance.Balance,
+		ClearedBalance: balance.ClearedBalance,
+		Movements:      movementsToResponse(balance.Movements),
+	}
+
+	if !balance.Card.OpeningDate.IsZero() {
+		response.OpeningDate = utils.TimeToStringDate(balance.Card.OpeningDate)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+	ctx.Writer.Flush()
+}
+
+// Reconcile reconciles a card with a bank statement balance.
+// ShowEntity godoc
+// @tags Balances
+// @Summary Reconciles a card with a bank statement balance.
+// @Description Endpoint to mark the movements of a card matching a bank statement as cleared and check the statement balance of
+// @Description a date against the cleared balance of the card. The difference is what the cleared movements do not explain,
+// @Description and the movements up to the date that are not cleared yet are listed.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param card query string true "The card name"
+// @Param body body models.ReconcileRequest true "Reconcile request"
+// @Success 201 {object} models.Reconciliation
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/reconciliation/{card} [post]
+func (b *Balances) Reconcile(ctx *gin.Context) {
+
+	var request models.ReconcileRequest
+	err := json.NewDecoder(ctx.Request.Body).Decode(&request)
+	if err != nil {
+		log.Printf("could not decode reconcile body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode reconciliation",
+		})
+		return
+	}
+
+	date, err := utils.DateStringToTime(request.Date)
+	if err != nil {
+		log.Printf("could not convert date string to time - date is %v - %v", request.Date, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not parse date - must use YYYY-MM-DD date format",
+		})
+		return
+	}
+
+	cleared := []dbModels.MovementRef{}
+	for _, ref := range request.Cleared {
+		cleared = append(cleared, dbModels.MovementRef{
+			Kind: ref.Kind,
+			ID:   int64(ref.ID),
+		})
+	}
+
+	reconciliation, err := b.Calculator.Reconcile(ctx, auth.UserID(ctx), ctx.Param("card"), date, request.StatementBalance, cleared)
+	if err != nil {
+		log.Printf("could not reconcile card %v: %v", ctx.Param("card"), err)
+		balanceErrorResponse(ctx, err, "could not reconcile")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, reconciliationToResponse(reconciliation))
+	ctx.Writer.Flush()
+}
+
+// GetReconciliations gets the reconciliations of a card.
+// ShowEntity godoc
+// @tags Balances
+// @Summary Gets the reconciliations of a card.
+// @Description Endpoint to get the past reconciliations of a card, the latest first.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param card query string true "The card name"
+// @Success 200 {object} []models.Reconciliation
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/reconciliations/{card} [get]
+func (b *Balances) GetReconciliations(ctx *gin.Context) {
+
+	reconciliations, err := b.Calculator.Reconciliations(ctx, auth.UserID(ctx), ctx.Param("card"))
+	if err != nil {
+		log.Printf("could not get reconciliations of card %v: %v", ctx.Param("card"), err)
+		balanceErrorResponse(ctx, err, "could not get reconciliations")
+		return
+	}
+
+	response := []models.Reconciliation{}
+	for _, reconciliation := range reconciliations {
+		response = append(response, reconciliationToResponse(reconciliation))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+	ctx.Writer.Flush()
+}
+
+// balanceErrorResponse writes a not found response for an unknown card, a bad request response for an invalid
+// reconciliation or a missing exchange rate, and an internal server error response otherwise
+func balanceErrorResponse(ctx *gin.Context, err error, msg string) {
+
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "card with this name does not exist",
+		})
+		return
+	}
+
+	if errors.Is(err, balances.ErrInvalidReconciliation) || errors.Is(err, currency.ErrNoRate) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		ErrorMsg: msg,
+	})
+}
+
+func movementsToResponse(movements []balances.Movement) []models.Movement {
+
+	response := []models.Movement{}
+	for _, movement := range movements {
+		response = append(response, models.Movement{
+			Kind:        movement.Kind,
+			ID:          int(movement.ID),
+			Date:        utils.TimeToStringDate(movement.Date),
+			Amount:      movement.Amount,
+			Description: movement.Description,
+			Cleared:     movement.Cleared,
+			Balance:     movement.Balance,
+		})
+	}
+
+	return response
+}
+
+func reconciliationToResponse(reconciliation balances.Reconciliation) models.Reconciliation {
+	return models.Reconciliation{
+		ID:               int(reconciliation.ID),
+		Card:             reconciliation.Card.Name,
+		Date:             utils.TimeToStringDate(reconciliation.Date),
+		StatementBalance: reconciliation.StatementBalance,
+		ClearedBalance:   reconciliation.ClearedBalance,
+		Difference:       reconciliation.Difference,
+		Uncleared:        movementsToResponse(reconciliation.Uncleared),
+	}
+}
@@ -0,0 +1,405 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/budgets"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/utils"
+)
+
+// Budgets handles the budgets http requests
+type Budgets struct {
+	Repository            repository.BudgetRepo
+	CategoryRepository    repository.ExpenseCategoryRepo
+	SubCategoryRepository repository.ExpenseSubCategoryRepo
+	Reporter              budgets.Reporter
+}
+
+// NewBudgets creates a new Budgets service
+func NewBudgets(
+	budgetRepo repository.BudgetRepo,
+	expCatRepo repository.ExpenseCategoryRepo,
+	expSubCatRepo repository.ExpenseSubCategoryRepo,
+	rates currency.Rates,
+) Budgets {
+	return Budgets{
+		Repository:            budgetRepo,
+		CategoryRepository:    expCatRepo,
+		SubCategoryRepository: expSubCatRepo,
+		Reporter:              budgets.NewReporter(budgetRepo, rates),
+	}
+}
+
+// CreateBudget is used to create a new budget.
+// ShowEntity godoc
+// @tags Budgets
+// @Summary Creates a new budget.
+// @Description Endpoint to create a monthly budget of an expense category or of an expense subcategory.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body models.Budget true "Create budget request"
+// @Success 201 {object} models.BudgetCreateResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/budget [post]
+func (b *Budgets) CreateBudget(ctx *gin.Context) {
+
+	var budget models.Budget
+	err := json.NewDecoder(ctx.Request.Body).Decode(&budget)
+	if err != nil {
+		log.Printf("could not decode create budget body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode budget",
+		})
+		return
+	}
+
+	budgetRecord, err := b.toBudgetRecord(ctx, auth.UserID(ctx), budget)
+	if err != nil {
+		log.Printf("could not get budget record: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: budgetErrorMsg(err),
+		})
+		return
+	}
+
+	id, err := b.Repository.InsertBudget(ctx, budgetRecord)
+	if err != nil {
+		log.Printf("could not insert budget: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not create budget",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &models.BudgetCreateResponse{ID: int(id)})
+	ctx.Writer.Flush()
+}
+
+// UpdateBudget updates a budget on the database.
+// ShowEntity godoc
+// @tags Budgets
+// @Summary Updates an existing budget.
+// @Description Endpoint to update a budget.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The budget id"
+// @Param body body models.Budget true "Update budget request"
+// @Success 204 "No content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/budget/{id} [put]
+func (b *Budgets) UpdateBudget(ctx *gin.Context) {
+
+	var budget models.Budget
+	err := json.NewDecoder(ctx.Request.Body).Decode(&budget)
+	if err != nil {
+		log.Printf("could not decode update budget body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode budget",
+		})
+		return
+	}
+
+	paramID := ctx.Param("id")
+
+	budgetID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting budget id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	budgetRecord, err := b.toBudgetRecord(ctx, auth.UserID(ctx), budget)
+	if err != nil {
+		log.Printf("could not get budget record: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: budgetErrorMsg(err),
+		})
+		return
+	}
+
+	budgetRecord.ID = int64(budgetID)
+
+	_, err = b.Repository.UpdateBudget(ctx, budgetRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "budget with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not update budget with param id = %v: %v", paramID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not update budget",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// GetBudgetByID gets a budget from the database that match the id provided.
+// ShowEntity godoc
+// @tags Budgets
+// @Summary Gets a budget by its id.
+// @Description Endpoint to get a budget by id.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The budget id"
+// @Success 200 {object} models.Budget
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /v1/budget/{id} [get]
+func (b *Budgets) GetBudgetByID(ctx *gin.Context) {
+
+	paramID := ctx.Param("id")
+
+	budgetID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting budget id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	budgetView, err := b.Repository.GetBudgetByID(ctx, auth.UserID(ctx), int64(budgetID))
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "budget with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not get budget by id - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not get budget",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, budgetViewToBudget(budgetView))
+	ctx.Writer.Flush()
+}
+
+// GetBudgets gets the budgets from the database.
+// ShowEntity godoc
+// @tags Budgets
+// @Summary Gets the budgets.
+// @Description Endpoint to get the budgets.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.Budget
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/budgets [get]
+func (b *Budgets) GetBudgets(ctx *gin.Context) {
+
+	budgetViews, err := b.Repository.GetBudgets(ctx, auth.UserID(ctx))
+	if err != nil {
+		log.Printf("could not get budgets: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get budgets",
+		})
+		return
+	}
+
+	response := []models.Budget{}
+	for _, budgetView := range budgetViews {
+		response = append(response, budgetViewToBudget(budgetView))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+	ctx.Writer.Flush()
+}
+
+// DeleteBudget deletes a budget from the database that match the id provided.
+// ShowEntity godoc
+// @tags Budgets
+// @Summary Deletes a budget by its id.
+// @Description Endpoint to delete a budget by id.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The budget id"
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /v1/budget/{id} [delete]
+func (b *Budgets) DeleteBudget(ctx *gin.Context) {
+
+	paramID := ctx.Param("id")
+
+	budgetID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting budget id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	err = b.Repository.DeleteBudget(ctx, auth.UserID(ctx), int64(budgetID))
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "budget with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not delete budget with this id - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not delete budget",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// GetBudgetsReport gets the budgeted, spent and remaining amounts of the budgets in a month.
+// ShowEntity godoc
+// @tags Budgets
+// @Summary Gets the budgets report of a month.
+// @Description Endpoint to get the budgeted vs spent vs remaining amounts of the budgets that apply to a month,
+// @Description computed from the expenses of the month. Budgets with rollover add the unspent amounts of the previous months.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param month query string true "The month (YYYY-MM)"
+// @Success 200 {object} models.BudgetsReport
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/budgets/report/{month} [get]
+func (b *Budgets) GetBudgetsReport(ctx *gin.Context) {
+
+	paramMonth := ctx.Param("month")
+
+	month, err := utils.MonthStringToTime(paramMonth)
+	if err != nil {
+		log.Printf("could not convert month string to time - month is %v - %v", paramMonth, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not parse month - must use YYYY-MM month format",
+		})
+		return
+	}
+
+	statuses, err := b.Reporter.Report(ctx, auth.UserID(ctx), month)
+	if errors.Is(err, currency.ErrNoRate) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not get budgets report - month is %v - %v", paramMonth, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get budgets report",
+		})
+		return
+	}
+
+	report := models.BudgetsReport{
+		Month:   utils.TimeToStringMonth(month),
+		Budgets: []models.BudgetStatus{},
+	}
+	for _, status := range statuses {
+		report.Budgets = append(report.Budgets, models.BudgetStatus{
+			Budget:     budgetViewToBudget(status.Budget),
+			RolledOver: status.RolledOver,
+			Budgeted:   status.Budgeted,
+			Spent:      status.Spent,
+			Remaining:  status.Remaining,
+			Overspent:  status.Overspent,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, report)
+	ctx.Writer.Flush()
+}
+
+// toBudgetRecord resolves the category or subcategory name of a budget and validates it
+func (b *Budgets) toBudgetRecord(
+	ctx context.Context,
+	userID int64,
+	budget models.Budget,
+) (dbModels.BudgetTable, error) {
+
+	budgetRecord := dbModels.BudgetTable{
+		MonthLimit: budget.Limit,
+		Rollover:   budget.Rollover,
+		StartMonth: budgets.MonthStart(time.Now()),
+		UserID:     userID,
+	}
+
+	if budget.StartMonth != "" {
+		startMonth, err := utils.MonthStringToTime(budget.StartMonth)
+		if err != nil {
+			return dbModels.BudgetTable{}, fmt.Errorf("%w: could not parse start month: %v", budgets.ErrInvalidBudget, err)
+		}
+		budgetRecord.StartMonth = startMonth
+	}
+
+	if budget.Category != "" {
+		category, err := b.CategoryRepository.GetExpenseCategoryByName(ctx, budget.Category)
+		if err != nil {
+			return dbModels.BudgetTable{}, fmt.Errorf("could not get expense category by name: %v", err)
+		}
+		budgetRecord.CategoryID = category.ID
+	}
+
+	if budget.SubCategory != "" {
+		subCategory, err := b.SubCategoryRepository.GetExpenseSubCategoryByName(ctx, budget.SubCategory)
+		if err != nil {
+			return dbModels.BudgetTable{}, fmt.Errorf("could not get expense sub category by name: %v", err)
+		}
+		budgetRecord.SubCategoryID = subCategory.ID
+	}
+
+	err := budgets.Validate(budgetRecord)
+	if err != nil {
+		return dbModels.BudgetTable{}, err
+	}
+
+	return budgetRecord, nil
+}
+
+func budgetErrorMsg(err error) string {
+	if errors.Is(err, budgets.ErrInvalidBudget) {
+		return "budget must set either a category or a sub_category, a limit >= 0 and a YYYY-MM start_month"
+	}
+	return "category or subcategory does not exist"
+}
+
+func budgetViewToBudget(budgetView dbModels.BudgetView) models.Budget {
+	return models.Budget{
+		ID:          int(budgetView.ID),
+		Category:    budgetView.Category,
+		SubCategory: budgetView.SubCategory,
+		Limit:       budgetView.MonthLimit,
+		Rollover:    budgetView.Rollover,
+		StartMonth:  utils.TimeToStringMonth(budgetView.StartMonth),
+	}
+}
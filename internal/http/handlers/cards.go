@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/utils"
+)
+
+// Cards handles the cards http requests
+type Cards struct {
+	Repository repository.CardRepo
+}
+
+// NewCards creates a new Cards service
+func NewCards(cardRepo repository.CardRepo) Cards {
+	return Cards{
+		Repository: cardRepo,
+	}
+}
+
+// CreateCard is used to create a new card.
+// ShowEntity godoc
+// @tags Cards
+// @Summary Creates a new card.
+// @Description Endpoint to create a card, with an optional currency (EUR by default) and opening balance.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body models.Card true "Create card request"
+// @Success 201 {object} models.CardCreateResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/card [post]
+func (c *Cards) CreateCard(ctx *gin.Context) {
+
+	var card models.Card
+	err := json.NewDecoder(ctx.Request.Body).Decode(&card)
+	if err != nil {
+		log.Printf("could not decode create card body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode card",
+		})
+		return
+	}
+
+	cardRecord, ok := c.toCardRecord(ctx, auth.UserID(ctx), 0, card)
+	if !ok {
+		return
+	}
+
+	if cardRecord.Currency == "" {
+		cardRecord.Currency = dbModels.DefaultCurrency
+	}
+
+	cardRecord.OpeningBalance = card.OpeningBalance
+	if card.OpeningDate != "" {
+		cardRecord.OpeningDate, err = utils.DateStringToTime(card.OpeningDate)
+		if err != nil {
+			log.Printf("could not convert opening date string to time - opening date is %v - %v", card.OpeningDate, err)
+			ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+				ErrorMsg: "could not parse opening date - must use YYYY-MM-DD date format",
+			})
+			return
+		}
+	}
+
+	id, err := c.Repository.InsertCard(ctx, cardRecord)
+	if err != nil {
+		log.Printf("could not insert card: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not create card",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &models.CardCreateResponse{
+		ID: int(id),
+	})
+	ctx.Writer.Flush()
+}
+
+// UpdateCard updates a card on the database.
+// ShowEntity godoc
+// @tags Cards
+// @Summary Updates an existing card.
+// @Description Endpoint to rename a card or change its currency, which is kept if missing.
+// @Description The opening balance is set with the opening balance endpoint.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The card id"
+// @Param body body models.Card true "Update card request"
+// @Success 204 "No content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/card/{id} [put]
+func (c *Cards) UpdateCard(ctx *gin.Context) {
+
+	var card models.Card
+	err := json.NewDecoder(ctx.Request.Body).Decode(&card)
+	if err != nil {
+		log.Printf("could not decode update card body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode card",
+		})
+		return
+	}
+
+	cardID, ok := paramID(ctx, "card")
+	if !ok {
+		return
+	}
+
+	userID := auth.UserID(ctx)
+
+	existing, err := c.Repository.GetCardByID(ctx, userID, cardID)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "card with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not get card by id - id is %v - %v", cardID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not update card",
+		})
+		return
+	}
+
+	cardRecord, ok := c.toCardRecord(ctx, userID, cardID, card)
+	if !ok {
+		return
+	}
+
+	cardRecord.OpeningBalance = existing.OpeningBalance
+	cardRecord.OpeningDate = existing.OpeningDate
+
+	_, err = c.Repository.UpdateCard(ctx, cardRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "card with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not update card with id = %v: %v", cardID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not update card",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// GetCardByID gets a card from the database that match the id provided.
+// ShowEntity godoc
+// @tags Cards
+// @Summary Gets a card by its id.
+// @Description Endpoint to get a card by id.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The card id"
+// @Success 200 {object} models.Card
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/card/{id} [get]
+func (c *Cards) GetCardByID(ctx *gin.Context) {
+
+	cardID, ok := paramID(ctx, "card")
+	if !ok {
+		return
+	}
+
+	card, err := c.Repository.GetCardByID(ctx, auth.UserID(ctx), cardID)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "card with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not get card by id - id is %v - %v", cardID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get card",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cardTableToCard(card))
+	ctx.Writer.Flush()
+}
+
+// GetCards gets the cards of the user.
+// ShowEntity godoc
+// @tags Cards
+// @Summary Gets the cards.
+// @Description Endpoint to get the cards of the user, sorted by name.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.Card
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/cards [get]
+func (c *Cards) GetCards(ctx *gin.Context) {
+
+	cards, err := c.Repository.GetCards(ctx, auth.UserID(ctx))
+	if err != nil {
+		log.Printf("could not get cards: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get cards",
+		})
+		return
+	}
+
+	response := []models.Card{}
+	for _, card := range cards {
+		response = append(response, cardTableToCard(card))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+	ctx.Writer.Flush()
+}
+
+// DeleteCard deletes a card from the database that match the id provided.
+// ShowEntity godoc
+// @tags Cards
+// @Summary Deletes a card by its id.
+// @Description Endpoint to delete a card by id. A card that still has expenses, incomes, transfers, rules,
+// @Description recurring transactions or reconciliations can not be deleted.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The card id"
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/card/{id} [delete]
+func (c *Cards) DeleteCard(ctx *gin.Context) {
+
+	cardID, ok := paramID(ctx, "card")
+	if !ok {
+		return
+	}
+
+	err := c.Repository.DeleteCard(ctx, auth.UserID(ctx), cardID)
+	if errors.Is(err, repository.ErrInUse) {
+		ctx.JSON(http.StatusConflict, models.ErrorResponse{
+			ErrorMsg: "card still has expenses, incomes, transfers, rules, recurring transactions or reconciliations",
+		})
+		return
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "card with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not delete card with id %d: %v", cardID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not delete card",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// toCardRecord validates the name and currency of a card, responding with the error if they are not valid.
+// The name must not be taken by another card of the user.
+func (c *Cards) toCardRecord(ctx *gin.Context, userID int64, id int64, card models.Card) (dbModels.CardTable, bool) {
+
+	name, ok := validName(card.Name)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "card name must have between 1 and 20 characters",
+		})
+		return dbModels.CardTable{}, false
+	}
+
+	cardCurrency, err := optionalCurrency(card.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return dbModels.CardTable{}, false
+	}
+
+	existing, err := c.Repository.GetCardByName(ctx, userID, name)
+	if err == nil && existing.ID != id {
+		ctx.JSON(http.StatusConflict, models.ErrorResponse{
+			ErrorMsg: "card with this name already exists",
+		})
+		return dbModels.CardTable{}, false
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		log.Printf("could not get card by name - name is %v - %v", name, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get card by name",
+		})
+		return dbModels.CardTable{}, false
+	}
+
+	return dbModels.CardTable{
+		ID:       id,
+		Name:     name,
+		Currency: cardCurrency,
+		UserID:   userID,
+	}, true
+}
+
+func cardTableToCard(card dbModels.CardTable) models.Card {
+
+	response := models.Card{
+		ID:             int(card.ID),
+		Name:           card.Name,
+		Currency:       card.Currency,
+		OpeningBalance: card.OpeningBalance,
+	}
+
+	if !card.OpeningDate.IsZero() {
+		response.OpeningDate = utils.TimeToStringDate(card.OpeningDate)
+	}
+
+	return response
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// usedCards refuses to delete the cards that still have expenses, as the database does
+type usedCards struct {
+	cache.Card
+	withExpenses map[int64]bool
+}
+
+func (u usedCards) DeleteCard(ctx context.Context, userID int64, id int64) error {
+	if u.withExpenses[id] {
+		return repository.ErrInUse
+	}
+	return u.Card.DeleteCard(ctx, userID, id)
+}
+
+// newUsedCards has the CGD and Revolut cards of the user and the Other user card of another user.
+// CGD has expenses, Revolut is not used.
+func newUsedCards() usedCards {
+	return usedCards{
+		Card: cache.NewCard([]dbModels.CardTable{
+			{ID: 1, Name: "CGD", Currency: "EUR"},
+			{ID: 2, Name: "Revolut", Currency: "EUR"},
+			{ID: 3, Name: "Other user", Currency: "EUR", UserID: 2},
+		}),
+		withExpenses: map[int64]bool{1: true},
+	}
+}
+
+func TestCards_UpdateCard(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	type want struct {
+		statusCode int
+		errorMsg   string
+	}
+
+	tests := []struct {
+		name   string
+		card   models.Card
+		params map[string]string
+		want   want
+	}{
+		{
+			name:   "Success",
+			card:   models.Card{Name: "Revolut GBP", Currency: "GBP"},
+			params: map[string]string{"id": "2"},
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:   "SuccessKeepingItsOwnName",
+			card:   models.Card{Name: "Revolut", Currency: "EUR"},
+			params: map[string]string{"id": "2"},
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:   "ErrorNameOfAnotherCard",
+			card:   models.Card{Name: "CGD", Currency: "EUR"},
+			params: map[string]string{"id": "2"},
+			want: want{
+				statusCode: http.StatusConflict,
+				errorMsg:   "card with this name already exists",
+			},
+		},
+		{
+			name:   "ErrorEmptyName",
+			card:   models.Card{Name: "", Currency: "EUR"},
+			params: map[string]string{"id": "2"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "card name must have between 1 and 20 characters",
+			},
+		},
+		{
+			name:   "ErrorParameterIDNotInteger",
+			card:   models.Card{Name: "Revolut", Currency: "EUR"},
+			params: map[string]string{"id": "abc"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "id parameter must be an integer",
+			},
+		},
+		{
+			name:   "ErrorUnexistingCard",
+			card:   models.Card{Name: "Revolut", Currency: "EUR"},
+			params: map[string]string{"id": "9"},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "card with this id does not exist",
+			},
+		},
+		{
+			name:   "ErrorCardOwnedByAnotherUser",
+			card:   models.Card{Name: "Mine now", Currency: "EUR"},
+			params: map[string]string{"id": "3"},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "card with this id does not exist",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// GIVEN
+			cardsRepo := newUsedCards()
+			cardsHandlers := NewCards(cardsRepo)
+
+			data, err := json.Marshal(tt.card)
+			if err != nil {
+				t.Fatalf("error marshaling card: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+			ginCtx, _ := gin.CreateTestContext(w)
+			ginCtx.Request = &http.Request{
+				Method: http.MethodPut,
+				Body:   io.NopCloser(bytes.NewBuffer(data)),
+			}
+
+			for k, v := range tt.params {
+				ginCtx.Params = append(ginCtx.Params, gin.Param{Key: k, Value: v})
+			}
+
+			// WHEN
+			cardsHandlers.UpdateCard(ginCtx)
+
+			// THEN
+			assert.EqualValues(t, tt.want.statusCode, w.Code)
+
+			switch w.Code {
+			case http.StatusNoContent:
+				card, err := cardsRepo.GetCardByID(context.Background(), 0, 2)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.card.Name, card.Name)
+				assert.Equal(t, tt.card.Currency, card.Currency)
+			default:
+				var r models.ErrorResponse
+				err = json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.errorMsg, r.ErrorMsg)
+			}
+		})
+	}
+}
+
+func TestCards_DeleteCard(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	type want struct {
+		statusCode int
+		errorMsg   string
+	}
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   want
+	}{
+		{
+			name:   "Success",
+			params: map[string]string{"id": "2"},
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:   "ErrorInUse",
+			params: map[string]string{"id": "1"},
+			want: want{
+				statusCode: http.StatusConflict,
+				errorMsg:   "card still has expenses, incomes, transfers, rules, recurring transactions or reconciliations",
+			},
+		},
+		{
+			name:   "ErrorUnexistingCard",
+			params: map[string]string{"id": "9"},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "card with this id does not exist",
+			},
+		},
+		{
+			name:   "ErrorCardOwnedByAnotherUser",
+			params: map[string]string{"id": "3"},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "card with this id does not exist",
+			},
+		},
+		{
+			name:   "ErrorParameterIDNotInteger",
+			params: map[string]string{"id": "abc"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "id parameter must be an integer",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// GIVEN
+			cardsHandlers := NewCards(newUsedCards())
+
+			w := httptest.NewRecorder()
+			ginCtx, _ := gin.CreateTestContext(w)
+			ginCtx.Request = &http.Request{
+				Method: http.MethodDelete,
+			}
+
+			for k, v := range tt.params {
+				ginCtx.Params = append(ginCtx.Params, gin.Param{Key: k, Value: v})
+			}
+
+			// WHEN
+			cardsHandlers.DeleteCard(ginCtx)
+
+			// THEN
+			assert.EqualValues(t, tt.want.statusCode, w.Code)
+
+			switch w.Code {
+			case http.StatusNoContent:
+			default:
+				var r models.ErrorResponse
+				err := json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.errorMsg, r.ErrorMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// maxNameLength is the longest name of a card or category
+const maxNameLength = 20
+
+// validName trims the name of a card or category and tells if it is valid
+func validName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	return name, name != "" && utf8.RuneCountInString(name) <= maxNameLength
+}
+
+// paramID reads the id path parameter of a card or category, responding with a bad request if it is not an integer
+func paramID(ctx *gin.Context, kind string) (int64, bool) {
+
+	paramID := ctx.Param("id")
+
+	id, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting %s id to int - param id is %v - %v", kind, paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return 0, false
+	}
+
+	return int64(id), true
+}
+
+// categoryDeletion deletes a category, or replaces it by another one
+type categoryDeletion struct {
+	kind       string // such as expense category
+	references string // what is moved to the replacement of a category, such as its expenses
+	others     string // what else references a category and is not moved, such as its budgets
+	getByID    func(id int64) error
+	delete     func(id int64) error
+	replace    func(id int64, replacementID int64) error
+}
+
+// run deletes the category on the id parameter. Deleting a category in use is refused, unless the reassign_to query
+// parameter has the id of another category of the same kind: what references the deleted one is then moved to it.
+func (d categoryDeletion) run(ctx *gin.Context) {
+
+	id, ok := paramID(ctx, d.kind)
+	if !ok {
+		return
+	}
+
+	var err error
+	inUseMsg := fmt.Sprintf("%s still has %s or %s - set reassign_to to the id of the %s to move the %s to", d.kind, d.references, d.others, d.kind, d.references)
+
+	reassignTo := ctx.Query("reassign_to")
+	if reassignTo == "" {
+		err = d.delete(id)
+	} else {
+		replacementID, convErr := strconv.Atoi(reassignTo)
+		if convErr != nil || int64(replacementID) == id {
+			ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+				ErrorMsg: fmt.Sprintf("reassign_to must be the id of another %s", d.kind),
+			})
+			return
+		}
+
+		err = d.getByID(int64(replacementID))
+		if errors.Is(err, repository.ErrNotFound) {
+			ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+				ErrorMsg: fmt.Sprintf("%s to reassign to does not exist", d.kind),
+			})
+			return
+		}
+		if err == nil {
+			err = d.replace(id, int64(replacementID))
+		}
+		inUseMsg = fmt.Sprintf("%s still has %s, which are not reassigned", d.kind, d.others)
+	}
+
+	switch {
+	case errors.Is(err, repository.ErrInUse):
+		ctx.JSON(http.StatusConflict, models.ErrorResponse{
+			ErrorMsg: inUseMsg,
+		})
+		return
+	case errors.Is(err, repository.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("%s with this id does not exist", d.kind),
+		})
+		return
+	case err != nil:
+		log.Printf("could not delete %s with id %d: %v", d.kind, id, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("could not delete %s", d.kind),
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// nameAvailable tells if no card or category of the kind other than the one with the id has the name, responding with a
+// conflict otherwise. getIDByName returns the id of the one with the name.
+func nameAvailable(ctx *gin.Context, kind string, id int64, getIDByName func(context.Context) (int64, error)) bool {
+
+	existingID, err := getIDByName(ctx)
+	if err == nil && existingID != id {
+		ctx.JSON(http.StatusConflict, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("%s with this name already exists", kind),
+		})
+		return false
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		log.Printf("could not get %s by name: %v", kind, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("could not get %s by name", kind),
+		})
+		return false
+	}
+
+	return true
+}
+
+// categoryMerge merges a category into another one of the same kind
+type categoryMerge struct {
+	kind    string // such as expense category
+	getByID func(id int64) error
+	merge   func(id int64, targetID int64, dryRun bool) (dbModels.CategoryMerge, error)
+}
+
+// run merges the category on the id parameter into the one of the request, moving everything that references it
+// and deleting it, or only counts what would move on a dry run
+func (m categoryMerge) run(ctx *gin.Context) {
+
+	var request models.CategoryMergeRequest
+	err := json.NewDecoder(ctx.Request.Body).Decode(&request)
+	if err != nil {
+		log.Printf("could not decode merge %s body: %v", m.kind, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode merge request",
+		})
+		return
+	}
+
+	id, ok := paramID(ctx, m.kind)
+	if !ok {
+		return
+	}
+
+	targetID := int64(request.Into)
+	if targetID == id {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("into must be the id of another %s", m.kind),
+		})
+		return
+	}
+
+	err = m.getByID(targetID)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("%s to merge into does not exist", m.kind),
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not get %s by id - id is %v - %v", m.kind, targetID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("could not merge %s", m.kind),
+		})
+		return
+	}
+
+	merge, err := m.merge(id, targetID, request.DryRun)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("%s with this id does not exist", m.kind),
+		})
+		return
+	case err != nil:
+		log.Printf("could not merge %s with id %d into %d: %v", m.kind, id, targetID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("could not merge %s", m.kind),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.CategoryMergeResponse{
+		DryRun:                request.DryRun,
+		Transactions:          merge.Transactions,
+		SubCategories:         merge.SubCategories,
+		Budgets:               merge.Budgets,
+		Rules:                 merge.Rules,
+		RecurringTransactions: merge.RecurringTransactions,
+	})
+	ctx.Writer.Flush()
+}
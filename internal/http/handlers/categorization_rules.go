@@ -0,0 +1,450 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/categorization"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/utils"
+)
+
+// CategorizationRules handles the categorization rules http requests
+type CategorizationRules struct {
+	Repository               repository.CategorizationRuleRepo
+	ExpensesRepository       repository.ExpenseRepo
+	IncomesRepository        repository.IncomeRepo
+	CardRepository           repository.CardRepo
+	SubCategoryRepository    repository.ExpenseSubCategoryRepo
+	IncomeCategoryRepository repository.IncomeCategoryRepo
+}
+
+// NewCategorizationRules creates a new CategorizationRules service
+func NewCategorizationRules(
+	ruleRepo repository.CategorizationRuleRepo,
+	expRepo repository.ExpenseRepo,
+	incRepo repository.IncomeRepo,
+	cardRepo repository.CardRepo,
+	expSubCatRepo repository.ExpenseSubCategoryRepo,
+	incCatRepo repository.IncomeCategoryRepo,
+) CategorizationRules {
+	return CategorizationRules{
+		Repository:               ruleRepo,
+		ExpensesRepository:       expRepo,
+		IncomesRepository:        incRepo,
+		CardRepository:           cardRepo,
+		SubCategoryRepository:    expSubCatRepo,
+		IncomeCategoryRepository: incCatRepo,
+	}
+}
+
+// CreateCategorizationRule is used to create a new categorization rule.
+// ShowEntity godoc
+// @tags Categorization rules
+// @Summary Creates a new categorization rule.
+// @Description Endpoint to create a categorization rule. Expenses created without a subcategory and incomes created
+// @Description without a category get the ones of the first rule, by ascending priority, that matches them.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body models.CategorizationRule true "Create categorization rule request"
+// @Success 201 {object} models.CategorizationRuleCreateResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/rule [post]
+func (r *CategorizationRules) CreateCategorizationRule(ctx *gin.Context) {
+
+	var rule models.CategorizationRule
+	err := json.NewDecoder(ctx.Request.Body).Decode(&rule)
+	if err != nil {
+		log.Printf("could not decode create categorization rule body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode categorization rule",
+		})
+		return
+	}
+
+	ruleRecord, err := r.toRuleRecord(ctx, auth.UserID(ctx), rule)
+	if err != nil {
+		log.Printf("could not get categorization rule record: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: ruleErrorMsg(err),
+		})
+		return
+	}
+
+	id, err := r.Repository.InsertCategorizationRule(ctx, ruleRecord)
+	if err != nil {
+		log.Printf("could not insert categorization rule: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not create categorization rule",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &models.CategorizationRuleCreateResponse{ID: int(id)})
+	ctx.Writer.Flush()
+}
+
+// UpdateCategorizationRule updates a categorization rule on the database.
+// ShowEntity godoc
+// @tags Categorization rules
+// @Summary Updates an existing categorization rule.
+// @Description Endpoint to update a categorization rule.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The categorization rule id"
+// @Param body body models.CategorizationRule true "Update categorization rule request"
+// @Success 204 "No content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/rule/{id} [put]
+func (r *CategorizationRules) UpdateCategorizationRule(ctx *gin.Context) {
+
+	var rule models.CategorizationRule
+	err := json.NewDecoder(ctx.Request.Body).Decode(&rule)
+	if err != nil {
+		log.Printf("could not decode update categorization rule body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode categorization rule",
+		})
+		return
+	}
+
+	paramID := ctx.Param("id")
+
+	ruleID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting categorization rule id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	ruleRecord, err := r.toRuleRecord(ctx, auth.UserID(ctx), rule)
+	if err != nil {
+		log.Printf("could not get categorization rule record: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: ruleErrorMsg(err),
+		})
+		return
+	}
+
+	ruleRecord.ID = int64(ruleID)
+
+	_, err = r.Repository.UpdateCategorizationRule(ctx, ruleRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "categorization rule with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not update categorization rule with param id = %v: %v", paramID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not update categorization rule",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// GetCategorizationRuleByID gets a categorization rule from the database that match the id provided.
+// ShowEntity godoc
+// @tags Categorization rules
+// @Summary Gets a categorization rule by its id.
+// @Description Endpoint to get a categorization rule by id.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The categorization rule id"
+// @Success 200 {object} models.CategorizationRule
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /v1/rule/{id} [get]
+func (r *CategorizationRules) GetCategorizationRuleByID(ctx *gin.Context) {
+
+	paramID := ctx.Param("id")
+
+	ruleID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting categorization rule id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	ruleView, err := r.Repository.GetCategorizationRuleByID(ctx, auth.UserID(ctx), int64(ruleID))
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "categorization rule with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not get categorization rule by id - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not get categorization rule",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ruleViewToRule(ruleView))
+	ctx.Writer.Flush()
+}
+
+// GetCategorizationRules gets the categorization rules from the database, in the order they are tried.
+// ShowEntity godoc
+// @tags Categorization rules
+// @Summary Gets the categorization rules.
+// @Description Endpoint to get the categorization rules, by ascending priority.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.CategorizationRule
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/rules [get]
+func (r *CategorizationRules) GetCategorizationRules(ctx *gin.Context) {
+
+	ruleViews, err := r.Repository.GetCategorizationRules(ctx, auth.UserID(ctx))
+	if err != nil {
+		log.Printf("could not get categorization rules: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get categorization rules",
+		})
+		return
+	}
+
+	rules := []models.CategorizationRule{}
+	for _, ruleView := range ruleViews {
+		rules = append(rules, ruleViewToRule(ruleView))
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+	ctx.Writer.Flush()
+}
+
+// DeleteCategorizationRule deletes a categorization rule from the database that match the id provided.
+// ShowEntity godoc
+// @tags Categorization rules
+// @Summary Deletes a categorization rule by its id.
+// @Description Endpoint to delete a categorization rule by id.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The categorization rule id"
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /v1/rule/{id} [delete]
+func (r *CategorizationRules) DeleteCategorizationRule(ctx *gin.Context) {
+
+	paramID := ctx.Param("id")
+
+	ruleID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting categorization rule id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	err = r.Repository.DeleteCategorizationRule(ctx, auth.UserID(ctx), int64(ruleID))
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "categorization rule with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not delete categorization rule with this id - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not delete categorization rule",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// DryRunCategorizationRule tests a categorization rule against the existing expenses or incomes of a range of dates.
+// ShowEntity godoc
+// @tags Categorization rules
+// @Summary Tests a categorization rule against existing rows.
+// @Description Endpoint to list the expenses (for a rule with a subcategory) or the incomes (for a rule with a category)
+// @Description of the provided range of dates that a categorization rule matches. Nothing is stored or changed.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body models.CategorizationRuleDryRunRequest true "Dry run categorization rule request"
+// @Success 200 {object} models.CategorizationRuleDryRunResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/rules/dry-run [post]
+func (r *CategorizationRules) DryRunCategorizationRule(ctx *gin.Context) {
+
+	var request models.CategorizationRuleDryRunRequest
+	err := json.NewDecoder(ctx.Request.Body).Decode(&request)
+	if err != nil {
+		log.Printf("could not decode dry run categorization rule body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode categorization rule dry run",
+		})
+		return
+	}
+
+	minDate, err := utils.DateStringToTime(request.MinDate)
+	if err != nil {
+		log.Printf("could not convert min date string to time - min date is %v - %v", request.MinDate, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not parse min date - must use YYYY-MM-DD date format",
+		})
+		return
+	}
+
+	maxDate, err := utils.DateStringToTime(request.MaxDate)
+	if err != nil {
+		log.Printf("could not convert max date string to time - max date is %v - %v", request.MaxDate, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not parse max date - must use YYYY-MM-DD date format",
+		})
+		return
+	}
+
+	userID := auth.UserID(ctx)
+
+	ruleRecord, err := r.toRuleRecord(ctx, userID, request.Rule)
+	if err != nil {
+		log.Printf("could not get categorization rule record: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: ruleErrorMsg(err),
+		})
+		return
+	}
+
+	rule := categorization.RuleView(ruleRecord)
+	response := models.CategorizationRuleDryRunResponse{
+		Expenses: []models.ExpenseCreateRequest{},
+		Incomes:  []models.Income{},
+	}
+
+	if rule.SubCategoryID != 0 {
+		expenseViewRecords, err := r.ExpensesRepository.GetExpensesByDates(ctx, userID, minDate, maxDate)
+		if err != nil {
+			log.Printf("could not get expenses by dates to dry run categorization rule: %v", err)
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				ErrorMsg: "could not get expenses by dates",
+			})
+			return
+		}
+		response.Expenses = expensesViewToExpensesGetResponse(categorization.MatchingExpenses(rule, expenseViewRecords))
+	} else {
+		incomeViewRecords, err := r.IncomesRepository.GetIncomesByDates(ctx, userID, minDate, maxDate)
+		if err != nil {
+			log.Printf("could not get incomes by dates to dry run categorization rule: %v", err)
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				ErrorMsg: "could not get incomes by dates",
+			})
+			return
+		}
+		for _, incomeView := range categorization.MatchingIncomes(rule, incomeViewRecords) {
+			response.Incomes = append(response.Incomes, models.Income{
+				ID:          int(incomeView.ID),
+				Value:       incomeView.Value,
+				Date:        utils.TimeToStringDate(incomeView.Date),
+				Category:    incomeView.Category,
+				Card:        incomeView.Card,
+				Description: incomeView.Description,
+			})
+		}
+	}
+
+	ctx.JSON(http.StatusOK, response)
+	ctx.Writer.Flush()
+}
+
+// toRuleRecord resolves the card and category names of a rule and validates it
+func (r *CategorizationRules) toRuleRecord(
+	ctx context.Context,
+	userID int64,
+	rule models.CategorizationRule,
+) (dbModels.CategorizationRuleTable, error) {
+
+	ruleRecord := dbModels.CategorizationRuleTable{
+		Priority:           int64(rule.Priority),
+		DescriptionPattern: rule.Description,
+		DescriptionRegex:   rule.Regex,
+		MinValue:           rule.MinValue,
+		MaxValue:           rule.MaxValue,
+		UserID:             userID,
+	}
+
+	if rule.Card != "" {
+		card, err := r.CardRepository.GetCardByName(ctx, userID, rule.Card)
+		if err != nil {
+			return dbModels.CategorizationRuleTable{}, fmt.Errorf("could not get card by name: %v", err)
+		}
+		ruleRecord.CardID = card.ID
+	}
+
+	if rule.SubCategory != "" {
+		subCategory, err := r.SubCategoryRepository.GetExpenseSubCategoryByName(ctx, rule.SubCategory)
+		if err != nil {
+			return dbModels.CategorizationRuleTable{}, fmt.Errorf("could not get expense sub category by name: %v", err)
+		}
+		ruleRecord.SubCategoryID = subCategory.ID
+	}
+
+	if rule.Category != "" {
+		category, err := r.IncomeCategoryRepository.GetIncomeCategoryByName(ctx, rule.Category)
+		if err != nil {
+			return dbModels.CategorizationRuleTable{}, fmt.Errorf("could not get income category by name: %v", err)
+		}
+		ruleRecord.IncomeCategoryID = category.ID
+	}
+
+	err := categorization.Validate(ruleRecord)
+	if err != nil {
+		return dbModels.CategorizationRuleTable{}, err
+	}
+
+	return ruleRecord, nil
+}
+
+func ruleErrorMsg(err error) string {
+	if errors.Is(err, categorization.ErrInvalidRule) {
+		return "rule must set either a sub_category or a category, a valid regex and min_value <= max_value"
+	}
+	return "card, subcategory or category does not exist"
+}
+
+func ruleViewToRule(ruleView dbModels.CategorizationRuleView) models.CategorizationRule {
+	return models.CategorizationRule{
+		ID:          int(ruleView.ID),
+		Priority:    int(ruleView.Priority),
+		Description: ruleView.DescriptionPattern,
+		Regex:       ruleView.DescriptionRegex,
+		MinValue:    ruleView.MinValue,
+		MaxValue:    ruleView.MaxValue,
+		Card:        ruleView.Card,
+		SubCategory: ruleView.SubCategory,
+		Category:    ruleView.IncomeCategory,
+	}
+}
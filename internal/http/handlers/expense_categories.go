@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// ExpenseCategories handles the expense categories http requests
+type ExpenseCategories struct {
+	Repository repository.ExpenseCategoryRepo
+}
+
+// NewExpenseCategories creates a new ExpenseCategories service
+func NewExpenseCategories(categoryRepo repository.ExpenseCategoryRepo) ExpenseCategories {
+	return ExpenseCategories{
+		Repository: categoryRepo,
+	}
+}
+
+// CreateExpenseCategory is used to create a new expense category.
+// ShowEntity godoc
+// @tags Expense categories
+// @Summary Creates a new expense category.
+// @Description Endpoint to create an expense category.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body models.ExpenseCategory true "Create expense category request"
+// @Success 201 {object} models.CategoryCreateResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense-category [post]
+func (e *ExpenseCategories) CreateExpenseCategory(ctx *gin.Context) {
+
+	var category models.ExpenseCategory
+	err := json.NewDecoder(ctx.Request.Body).Decode(&category)
+	if err != nil {
+		log.Printf("could not decode create expense category body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode expense category",
+		})
+		return
+	}
+
+	name, ok := e.availableName(ctx, 0, category.Name)
+	if !ok {
+		return
+	}
+
+	id, err := e.Repository.InsertExpenseCategory(ctx, dbModels.ExpenseCategoryTable{
+		Name: name,
+	})
+	if err != nil {
+		log.Printf("could not insert expense category: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not create expense category",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &models.CategoryCreateResponse{
+		ID: int(id),
+	})
+	ctx.Writer.Flush()
+}
+
+// UpdateExpenseCategory updates an expense category on the database.
+// ShowEntity godoc
+// @tags Expense categories
+// @Summary Updates an existing expense category.
+// @Description Endpoint to rename an expense category.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The expense category id"
+// @Param body body models.ExpenseCategory true "Update expense category request"
+// @Success 204 "No content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense-category/{id} [put]
+func (e *ExpenseCategories) UpdateExpenseCategory(ctx *gin.Context) {
+
+	var category models.ExpenseCategory
+	err := json.NewDecoder(ctx.Request.Body).Decode(&category)
+	if err != nil {
+		log.Printf("could not decode update expense category body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode expense category",
+		})
+		return
+	}
+
+	categoryID, ok := paramID(ctx, "expense category")
+	if !ok {
+		return
+	}
+
+	name, ok := e.availableName(ctx, categoryID, category.Name)
+	if !ok {
+		return
+	}
+
+	_, err = e.Repository.UpdateExpenseCategory(ctx, dbModels.ExpenseCategoryTable{
+		ID:   categoryID,
+		Name: name,
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "expense category with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not update expense category with id = %v: %v", categoryID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not update expense category",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// GetExpenseCategoryByID gets an expense category from the database that match the id provided.
+// ShowEntity godoc
+// @tags Expense categories
+// @Summary Gets an expense category by its id.
+// @Description Endpoint to get an expense category by id.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The expense category id"
+// @Success 200 {object} models.ExpenseCategory
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense-category/{id} [get]
+func (e *ExpenseCategories) GetExpenseCategoryByID(ctx *gin.Context) {
+
+	categoryID, ok := paramID(ctx, "expense category")
+	if !ok {
+		return
+	}
+
+	category, err := e.Repository.GetExpenseCategoryByID(ctx, categoryID)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "expense category with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not get expense category by id - id is %v - %v", categoryID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get expense category",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.ExpenseCategory{
+		ID:   int(category.ID),
+		Name: category.Name,
+	})
+	ctx.Writer.Flush()
+}
+
+// GetExpenseCategories gets the expense categories.
+// ShowEntity godoc
+// @tags Expense categories
+// @Summary Gets the expense categories.
+// @Description Endpoint to get the expense categories, sorted by name.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.ExpenseCategory
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense-categories [get]
+func (e *ExpenseCategories) GetExpenseCategories(ctx *gin.Context) {
+
+	categories, err := e.Repository.GetExpenseCategories(ctx)
+	if err != nil {
+		log.Printf("could not get expense categories: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get expense categories",
+		})
+		return
+	}
+
+	response := []models.ExpenseCategory{}
+	for _, category := range categories {
+		response = append(response, models.ExpenseCategory{
+			ID:   int(category.ID),
+			Name: category.Name,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, response)
+	ctx.Writer.Flush()
+}
+
+// DeleteExpenseCategory deletes an expense category from the database that match the id provided.
+// ShowEntity godoc
+// @tags Expense categories
+// @Summary Deletes an expense category by its id.
+// @Description Endpoint to delete an expense category by id. A category that still has subcategories is only deleted
+// @Description with reassign_to, the id of the expense category its subcategories are moved to. A category with budgets
+// @Description can not be deleted.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The expense category id"
+// @Param reassign_to query string false "The id of the expense category to move the subcategories to"
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense-category/{id} [delete]
+func (e *ExpenseCategories) DeleteExpenseCategory(ctx *gin.Context) {
+	categoryDeletion{
+		kind:       "expense category",
+		references: "subcategories",
+		others:     "budgets",
+		getByID: func(id int64) error {
+			_, err := e.Repository.GetExpenseCategoryByID(ctx, id)
+			return err
+		},
+		delete: func(id int64) error {
+			return e.Repository.DeleteExpenseCategory(ctx, id)
+		},
+		replace: func(id int64, replacementID int64) error {
+			return e.Repository.ReplaceExpenseCategory(ctx, id, replacementID)
+		},
+	}.run(ctx)
+}
+
+// MergeExpenseCategory merges an expense category into another one.
+// ShowEntity godoc
+// @tags Expense categories
+// @Summary Merges an expense category into another one.
+// @Description Endpoint to merge an expense category into another expense category: its subcategories and budgets
+// @Description are moved to it and the merged expense category is deleted, all at once.
+// @Description Budgets the user also has on the target are folded into them, adding up their month limits. On a dry run nothing
+// @Description changes, and the response counts what would move.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The id of the expense category to merge"
+// @Param body body models.CategoryMergeRequest true "Merge expense category request"
+// @Success 200 {object} models.CategoryMergeResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense-category/{id}/merge [post]
+func (e *ExpenseCategories) MergeExpenseCategory(ctx *gin.Context) {
+	categoryMerge{
+		kind: "expense category",
+		getByID: func(id int64) error {
+			_, err := e.Repository.GetExpenseCategoryByID(ctx, id)
+			return err
+		},
+		merge: func(id int64, targetID int64, dryRun bool) (dbModels.CategoryMerge, error) {
+			return e.Repository.MergeExpenseCategory(ctx, id, targetID, dryRun)
+		},
+	}.run(ctx)
+}
+
+// availableName validates the name of an expense category, responding with the error if it is not valid
+// or if it is taken by another expense category
+func (e *ExpenseCategories) availableName(ctx *gin.Context, id int64, name string) (string, bool) {
+
+	name, ok := validName(name)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "expense category name must have between 1 and 20 characters",
+		})
+		return "", false
+	}
+
+	return name, nameAvailable(ctx, "expense category", id, func(c context.Context) (int64, error) {
+		existing, err := e.Repository.GetExpenseCategoryByName(c, name)
+		return existing.ID, err
+	})
+}
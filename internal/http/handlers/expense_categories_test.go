@@ -0,0 +1,455 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// usedExpenseCategories refuses to delete the expense categories that still have subcategories or budgets, as the
+// database does. Replacing an expense category moves its subcategories, but not its budgets.
+type usedExpenseCategories struct {
+	*cache.ExpenseCategory
+	withSubCategories map[int64]bool
+	withBudgets       map[int64]bool
+}
+
+func (u usedExpenseCategories) DeleteExpenseCategory(ctx context.Context, id int64) error {
+	if u.withSubCategories[id] || u.withBudgets[id] {
+		return repository.ErrInUse
+	}
+	return u.ExpenseCategory.DeleteExpenseCategory(ctx, id)
+}
+
+func (u usedExpenseCategories) ReplaceExpenseCategory(ctx context.Context, id int64, replacementID int64) error {
+
+	_, err := u.GetExpenseCategoryByID(ctx, replacementID)
+	if err != nil {
+		return err
+	}
+
+	if u.withBudgets[id] {
+		return repository.ErrInUse
+	}
+
+	return u.ExpenseCategory.DeleteExpenseCategory(ctx, id)
+}
+
+// newUsedExpenseCategories has the House, Leisure and Health expense categories. House has subcategories and
+// Leisure has budgets, Health is not used.
+func newUsedExpenseCategories() usedExpenseCategories {
+
+	categoriesCache := cache.NewExpenseCategory([]dbModels.ExpenseCategoryTable{
+		{ID: 1, Name: "House"},
+		{ID: 2, Name: "Leisure"},
+		{ID: 3, Name: "Health"},
+	})
+
+	return usedExpenseCategories{
+		ExpenseCategory:   &categoriesCache,
+		withSubCategories: map[int64]bool{1: true},
+		withBudgets:       map[int64]bool{2: true},
+	}
+}
+
+func TestExpenseCategories_UpdateExpenseCategory(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	type want struct {
+		statusCode int
+		errorMsg   string
+	}
+
+	tests := []struct {
+		name     string
+		category models.ExpenseCategory
+		params   map[string]string
+		want     want
+	}{
+		{
+			name:     "Success",
+			category: models.ExpenseCategory{Name: "Home"},
+			params:   map[string]string{"id": "1"},
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:     "SuccessKeepingItsOwnName",
+			category: models.ExpenseCategory{Name: " House "},
+			params:   map[string]string{"id": "1"},
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:     "ErrorNameOfAnotherCategory",
+			category: models.ExpenseCategory{Name: "Leisure"},
+			params:   map[string]string{"id": "1"},
+			want: want{
+				statusCode: http.StatusConflict,
+				errorMsg:   "expense category with this name already exists",
+			},
+		},
+		{
+			name:     "ErrorEmptyName",
+			category: models.ExpenseCategory{Name: " "},
+			params:   map[string]string{"id": "1"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "expense category name must have between 1 and 20 characters",
+			},
+		},
+		{
+			name:     "ErrorParameterIDNotInteger",
+			category: models.ExpenseCategory{Name: "Home"},
+			params:   map[string]string{"id": "abc"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "id parameter must be an integer",
+			},
+		},
+		{
+			name:     "ErrorUnexistingCategory",
+			category: models.ExpenseCategory{Name: "Home"},
+			params:   map[string]string{"id": "9"},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "expense category with this id does not exist",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// GIVEN
+			categoriesRepo := newUsedExpenseCategories()
+			categoriesHandlers := NewExpenseCategories(categoriesRepo)
+
+			data, err := json.Marshal(tt.category)
+			if err != nil {
+				t.Fatalf("error marshaling expense category: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+			ginCtx, _ := gin.CreateTestContext(w)
+			ginCtx.Request = &http.Request{
+				Method: http.MethodPut,
+				Body:   io.NopCloser(bytes.NewBuffer(data)),
+			}
+
+			for k, v := range tt.params {
+				ginCtx.Params = append(ginCtx.Params, gin.Param{Key: k, Value: v})
+			}
+
+			// WHEN
+			categoriesHandlers.UpdateExpenseCategory(ginCtx)
+
+			// THEN
+			assert.EqualValues(t, tt.want.statusCode, w.Code)
+
+			switch w.Code {
+			case http.StatusNoContent:
+				category, err := categoriesRepo.GetExpenseCategoryByID(context.Background(), 1)
+				assert.NoError(t, err)
+				assert.Equal(t, strings.TrimSpace(tt.category.Name), category.Name)
+			default:
+				var r models.ErrorResponse
+				err = json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.errorMsg, r.ErrorMsg)
+			}
+		})
+	}
+}
+
+func TestExpenseCategories_DeleteExpenseCategory(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	type want struct {
+		statusCode int
+		errorMsg   string
+		deleted    int64
+	}
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		query  url.Values
+		want   want
+	}{
+		{
+			name:   "Success",
+			params: map[string]string{"id": "3"},
+			want: want{
+				statusCode: http.StatusNoContent,
+				deleted:    3,
+			},
+		},
+		{
+			name:   "SuccessReassigningItsSubCategories",
+			params: map[string]string{"id": "1"},
+			query:  url.Values{"reassign_to": {"3"}},
+			want: want{
+				statusCode: http.StatusNoContent,
+				deleted:    1,
+			},
+		},
+		{
+			name:   "ErrorInUse",
+			params: map[string]string{"id": "1"},
+			want: want{
+				statusCode: http.StatusConflict,
+				errorMsg: "expense category still has subcategories or budgets - " +
+					"set reassign_to to the id of the expense category to move the subcategories to",
+			},
+		},
+		{
+			name:   "ErrorInUseAfterReassign",
+			params: map[string]string{"id": "2"},
+			query:  url.Values{"reassign_to": {"3"}},
+			want: want{
+				statusCode: http.StatusConflict,
+				errorMsg:   "expense category still has budgets, which are not reassigned",
+			},
+		},
+		{
+			name:   "ErrorReassignToItself",
+			params: map[string]string{"id": "1"},
+			query:  url.Values{"reassign_to": {"1"}},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "reassign_to must be the id of another expense category",
+			},
+		},
+		{
+			name:   "ErrorReassignToNotInteger",
+			params: map[string]string{"id": "1"},
+			query:  url.Values{"reassign_to": {"abc"}},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "reassign_to must be the id of another expense category",
+			},
+		},
+		{
+			name:   "ErrorUnexistingReplacement",
+			params: map[string]string{"id": "1"},
+			query:  url.Values{"reassign_to": {"9"}},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "expense category to reassign to does not exist",
+			},
+		},
+		{
+			name:   "ErrorUnexistingCategory",
+			params: map[string]string{"id": "9"},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "expense category with this id does not exist",
+			},
+		},
+		{
+			name:   "ErrorUnexistingCategoryReassigned",
+			params: map[string]string{"id": "9"},
+			query:  url.Values{"reassign_to": {"3"}},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "expense category with this id does not exist",
+			},
+		},
+		{
+			name:   "ErrorParameterIDNotInteger",
+			params: map[string]string{"id": "abc"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "id parameter must be an integer",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// GIVEN
+			categoriesRepo := newUsedExpenseCategories()
+			categoriesHandlers := NewExpenseCategories(categoriesRepo)
+
+			w := httptest.NewRecorder()
+			ginCtx, _ := gin.CreateTestContext(w)
+			ginCtx.Request = &http.Request{
+				Method: http.MethodDelete,
+				URL:    &url.URL{RawQuery: tt.query.Encode()},
+			}
+
+			for k, v := range tt.params {
+				ginCtx.Params = append(ginCtx.Params, gin.Param{Key: k, Value: v})
+			}
+
+			// WHEN
+			categoriesHandlers.DeleteExpenseCategory(ginCtx)
+
+			// THEN
+			assert.EqualValues(t, tt.want.statusCode, w.Code)
+
+			switch w.Code {
+			case http.StatusNoContent:
+				_, err := categoriesRepo.GetExpenseCategoryByID(context.Background(), tt.want.deleted)
+				assert.ErrorIs(t, err, repository.ErrNotFound)
+			default:
+				var r models.ErrorResponse
+				err := json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.errorMsg, r.ErrorMsg)
+
+				categories, err := categoriesRepo.GetExpenseCategories(context.Background())
+				assert.NoError(t, err)
+				assert.Len(t, categories, 3)
+			}
+		})
+	}
+}
+
+func TestExpenseCategories_MergeExpenseCategory(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	type want struct {
+		statusCode int
+		errorMsg   string
+		categories int
+	}
+
+	tests := []struct {
+		name    string
+		request models.CategoryMergeRequest
+		params  map[string]string
+		want    want
+	}{
+		{
+			name:    "Success",
+			request: models.CategoryMergeRequest{Into: 3},
+			params:  map[string]string{"id": "1"},
+			want: want{
+				statusCode: http.StatusOK,
+				categories: 2,
+			},
+		},
+		{
+			name:    "SuccessDryRun",
+			request: models.CategoryMergeRequest{Into: 3, DryRun: true},
+			params:  map[string]string{"id": "1"},
+			want: want{
+				statusCode: http.StatusOK,
+				categories: 3,
+			},
+		},
+		{
+			name:    "ErrorIntoItself",
+			request: models.CategoryMergeRequest{Into: 1},
+			params:  map[string]string{"id": "1"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "into must be the id of another expense category",
+			},
+		},
+		{
+			name:    "ErrorUnexistingTarget",
+			request: models.CategoryMergeRequest{Into: 9},
+			params:  map[string]string{"id": "1"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "expense category to merge into does not exist",
+			},
+		},
+		{
+			name:    "ErrorUnexistingCategory",
+			request: models.CategoryMergeRequest{Into: 3},
+			params:  map[string]string{"id": "9"},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "expense category with this id does not exist",
+			},
+		},
+		{
+			name:    "ErrorParameterIDNotInteger",
+			request: models.CategoryMergeRequest{Into: 3},
+			params:  map[string]string{"id": "abc"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "id parameter must be an integer",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// GIVEN
+			categoriesRepo := newUsedExpenseCategories()
+			categoriesHandlers := NewExpenseCategories(categoriesRepo)
+
+			data, err := json.Marshal(tt.request)
+			if err != nil {
+				t.Fatalf("error marshaling merge request: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+			ginCtx, _ := gin.CreateTestContext(w)
+			ginCtx.Request = &http.Request{
+				Method: http.MethodPost,
+				Body:   io.NopCloser(bytes.NewBuffer(data)),
+			}
+
+			for k, v := range tt.params {
+				ginCtx.Params = append(ginCtx.Params, gin.Param{Key: k, Value: v})
+			}
+
+			// WHEN
+			categoriesHandlers.MergeExpenseCategory(ginCtx)
+
+			// THEN
+			assert.EqualValues(t, tt.want.statusCode, w.Code)
+
+			switch w.Code {
+			case http.StatusOK:
+				var r models.CategoryMergeResponse
+				err = json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.request.DryRun, r.DryRun)
+
+				categories, err := categoriesRepo.GetExpenseCategories(context.Background())
+				assert.NoError(t, err)
+				assert.Len(t, categories, tt.want.categories)
+			default:
+				var r models.ErrorResponse
+				err = json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.errorMsg, r.ErrorMsg)
+			}
+		})
+	}
+}
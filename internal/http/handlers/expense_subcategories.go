@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// ExpenseSubCategories handles the expense subcategories http requests
+type ExpenseSubCategories struct {
+	Repository         repository.ExpenseSubCategoryRepo
+	CategoryRepository repository.ExpenseCategoryRepo
+}
+
+// NewExpenseSubCategories creates a new ExpenseSubCategories service
+func NewExpenseSubCategories(
+	subCategoryRepo repository.ExpenseSubCategoryRepo,
+	categoryRepo repository.ExpenseCategoryRepo,
+) ExpenseSubCategories {
+	return ExpenseSubCategories{
+		Repository:         subCategoryRepo,
+		CategoryRepository: categoryRepo,
+	}
+}
+
+// CreateExpenseSubCategory is used to create a new expense subcategory.
+// ShowEntity godoc
+// @tags Expense subcategories
+// @Summary Creates a new expense subcategory.
+// @Description Endpoint to create an expense subcategory of an existing expense category.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body models.ExpenseSubCategory true "Create expense subcategory request"
+// @Success 201 {object} models.CategoryCreateResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense-subcategory [post]
+func (e *ExpenseSubCategories) CreateExpenseSubCategory(ctx *gin.Context) {
+
+	var subCategory models.ExpenseSubCategory
+	err := json.NewDecoder(ctx.Request.Body).Decode(&subCategory)
+	if err != nil {
+		log.Printf("could not decode create expense subcategory body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode expense subcategory",
+		})
+		return
+	}
+
+	subCategoryRecord, ok := e.toSubCategoryRecord(ctx, 0, subCategory)
+	if !ok {
+		return
+	}
+
+	id, err := e.Repository.InsertExpenseSubCategory(ctx, subCategoryRecord)
+	if err != nil {
+		log.Printf("could not insert expense subcategory: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not create expense subcategory",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &models.CategoryCreateResponse{
+		ID: int(id),
+	})
+	ctx.Writer.Flush()
+}
+
+// UpdateExpenseSubCategory updates an expense subcategory on the database.
+// ShowEntity godoc
+// @tags Expense subcategories
+// @Summary Updates an existing expense subcategory.
+// @Description Endpoint to rename an expense subcategory or move it to another expense category.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The expense subcategory id"
+// @Param body body models.ExpenseSubCategory true "Update expense subcategory request"
+// @Success 204 "No content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense-subcategory/{id} [put]
+func (e *ExpenseSubCategories) UpdateExpenseSubCategory(ctx *gin.Context) {
+
+	var subCategory models.ExpenseSubCategory
+	err := json.NewDecoder(ctx.Request.Body).Decode(&subCategory)
+	if err != nil {
+		log.Printf("could not decode update expense subcategory body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode expense subcategory",
+		})
+		return
+	}
+
+	subCategoryID, ok := paramID(ctx, "expense subcategory")
+	if !ok {
+		return
+	}
+
+	subCategoryRecord, ok := e.toSubCategoryRecord(ctx, subCategoryID, subCategory)
+	if !ok {
+		return
+	}
+
+	_, err = e.Repository.UpdateExpenseSubCategory(ctx, subCategoryRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "expense subcategory with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not update expense subcategory with id = %v: %v", subCategoryID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not update expense subcategory",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// GetExpenseSubCategoryByID gets an expense subcategory from the database that match the id provided.
+// ShowEntity godoc
+// @tags Expense subcategories
+// @Summary Gets an expense subcategory by its id.
+// @Description Endpoint to get an expense subcategory by id.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The expense subcategory id"
+// @Success 200 {object} models.ExpenseSubCategory
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense-subcategory/{id} [get]
+func (e *ExpenseSubCategories) GetExpenseSubCategoryByID(ctx *gin.Context) {
+
+	subCategoryID, ok := paramID(ctx, "expense subcategory")
+	if !ok {
+		return
+	}
+
+	subCategory, err := e.Repository.GetExpenseSubCategoryByID(ctx, subCategoryID)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "expense subcategory with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not get expense subcategory by id - id is %v - %v", subCategoryID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get expense subcategory",
+		})
+		return
+	}
+
+	category, err := e.CategoryRepository.GetExpenseCategoryByID(ctx, subCategory.CategoryID)
+	if err != nil {
+		log.Printf("could not get expense category by id - id is %v - %v", subCategory.CategoryID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get expense subcategory",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.ExpenseSubCategory{
+		ID:       int(subCategory.ID),
+		Name:     subCategory.Name,
+		Category: category.Name,
+	})
+	ctx.Writer.Flush()
+}
+
+// GetExpenseSubCategories gets the expense subcategories.
+// ShowEntity godoc
+// @tags Expense subcategories
+// @Summary Gets the expense subcategories.
+// @Description Endpoint to get the expense subcategories, sorted by name.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.ExpenseSubCategory
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense-subcategories [get]
+func (e *ExpenseSubCategories) GetExpenseSubCategories(ctx *gin.Context) {
+
+	categories, err := e.CategoryRepository.GetExpenseCategories(ctx)
+	if err != nil {
+		log.Printf("could not get expense categories: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get expense subcategories",
+		})
+		return
+	}
+
+	categoryNames := map[int64]string{}
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	subCategories, err := e.Repository.GetExpenseSubCategories(ctx)
+	if err != nil {
+		log.Printf("could not get expense subcategories: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get expense subcategories",
+		})
+		return
+	}
+
+	response := []models.ExpenseSubCategory{}
+	for _, subCategory := range subCategories {
+		response = append(response, models.ExpenseSubCategory{
+			ID:       int(subCategory.ID),
+			Name:     subCategory.Name,
+			Category: categoryNames[subCategory.CategoryID],
+		})
+	}
+
+	ctx.JSON(http.StatusOK, response)
+	ctx.Writer.Flush()
+}
+
+// DeleteExpenseSubCategory deletes an expense subcategory from the database that match the id provided.
+// ShowEntity godoc
+// @tags Expense subcategories
+// @Summary Deletes an expense subcategory by its id.
+// @Description Endpoint to delete an expense subcategory by id. A subcategory that still has expenses is only deleted
+// @Description with reassign_to, the id of the expense subcategory its expenses are moved to. A subcategory with budgets,
+// @Description rules or recurring transactions can not be deleted.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The expense subcategory id"
+// @Param reassign_to query string false "The id of the expense subcategory to move the expenses to"
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense-subcategory/{id} [delete]
+func (e *ExpenseSubCategories) DeleteExpenseSubCategory(ctx *gin.Context) {
+	categoryDeletion{
+		kind:       "expense subcategory",
+		references: "expenses",
+		others:     "budgets, rules or recurring transactions",
+		getByID: func(id int64) error {
+			_, err := e.Repository.GetExpenseSubCategoryByID(ctx, id)
+			return err
+		},
+		delete: func(id int64) error {
+			return e.Repository.DeleteExpenseSubCategory(ctx, id)
+		},
+		replace: func(id int64, replacementID int64) error {
+			return e.Repository.ReplaceExpenseSubCategory(ctx, id, replacementID)
+		},
+	}.run(ctx)
+}
+
+// MergeExpenseSubCategory merges an expense subcategory into another one.
+// ShowEntity godoc
+// @tags Expense subcategories
+// @Summary Merges an expense subcategory into another one.
+// @Description Endpoint to merge an expense subcategory into another expense subcategory: its expenses, budgets, rules and recurring transactions
+// @Description are moved to it and the merged expense subcategory is deleted, all at once.
+// @Description Budgets the user also has on the target are folded into them, adding up their month limits. On a dry run nothing
+// @Description changes, and the response counts what would move.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The id of the expense subcategory to merge"
+// @Param body body models.CategoryMergeRequest true "Merge expense subcategory request"
+// @Success 200 {object} models.CategoryMergeResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expense-subcategory/{id}/merge [post]
+func (e *ExpenseSubCategories) MergeExpenseSubCategory(ctx *gin.Context) {
+	categoryMerge{
+		kind: "expense subcategory",
+		getByID: func(id int64) error {
+			_, err := e.Repository.GetExpenseSubCategoryByID(ctx, id)
+			return err
+		},
+		merge: func(id int64, targetID int64, dryRun bool) (dbModels.CategoryMerge, error) {
+			return e.Repository.MergeExpenseSubCategory(ctx, id, targetID, dryRun)
+		},
+	}.run(ctx)
+}
+
+// toSubCategoryRecord validates an expense subcategory and resolves its expense category,
+// responding with the error if any
+func (e *ExpenseSubCategories) toSubCategoryRecord(
+	ctx *gin.Context,
+	id int64,
+	subCategory models.ExpenseSubCategory,
+) (dbModels.ExpenseSubCategoryTable, bool) {
+
+	name, ok := validName(subCategory.Name)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "expense subcategory name must have between 1 and 20 characters",
+		})
+		return dbModels.ExpenseSubCategoryTable{}, false
+	}
+
+	category, err := e.CategoryRepository.GetExpenseCategoryByName(ctx, subCategory.Category)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "expense category does not exist",
+		})
+		return dbModels.ExpenseSubCategoryTable{}, false
+	}
+	if err != nil {
+		log.Printf("could not get expense category by name - name is %v - %v", subCategory.Category, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get expense category",
+		})
+		return dbModels.ExpenseSubCategoryTable{}, false
+	}
+
+	available := nameAvailable(ctx, "expense subcategory", id, func(c context.Context) (int64, error) {
+		existing, err := e.Repository.GetExpenseSubCategoryByName(c, name)
+		return existing.ID, err
+	})
+	if !available {
+		return dbModels.ExpenseSubCategoryTable{}, false
+	}
+
+	return dbModels.ExpenseSubCategoryTable{
+		ID:         id,
+		Name:       name,
+		CategoryID: category.ID,
+	}, true
+}
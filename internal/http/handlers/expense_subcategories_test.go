@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// usedExpenseSubCategories refuses to delete the expense subcategories that still have expenses or budgets, as the
+// database does. Replacing an expense subcategory moves its expenses, but not its budgets.
+type usedExpenseSubCategories struct {
+	*cache.ExpenseSubCategory
+	withExpenses map[int64]bool
+	withBudgets  map[int64]bool
+}
+
+func (u usedExpenseSubCategories) DeleteExpenseSubCategory(ctx context.Context, id int64) error {
+	if u.withExpenses[id] || u.withBudgets[id] {
+		return repository.ErrInUse
+	}
+	return u.ExpenseSubCategory.DeleteExpenseSubCategory(ctx, id)
+}
+
+func (u usedExpenseSubCategories) ReplaceExpenseSubCategory(ctx context.Context, id int64, replacementID int64) error {
+
+	_, err := u.GetExpenseSubCategoryByID(ctx, replacementID)
+	if err != nil {
+		return err
+	}
+
+	if u.withBudgets[id] {
+		return repository.ErrInUse
+	}
+
+	return u.ExpenseSubCategory.DeleteExpenseSubCategory(ctx, id)
+}
+
+// newUsedExpenseSubCategories has the Rent, Restaurants and Cinema expense subcategories. Rent has expenses and
+// Restaurants has budgets, Cinema is not used.
+func newUsedExpenseSubCategories() usedExpenseSubCategories {
+
+	subCategoriesCache := cache.NewExpenseSubCategory([]dbModels.ExpenseSubCategoryTable{
+		{ID: 1, Name: "Rent", CategoryID: 1},
+		{ID: 2, Name: "Restaurants", CategoryID: 2},
+		{ID: 3, Name: "Cinema", CategoryID: 2},
+	})
+
+	return usedExpenseSubCategories{
+		ExpenseSubCategory: &subCategoriesCache,
+		withExpenses:       map[int64]bool{1: true},
+		withBudgets:        map[int64]bool{2: true},
+	}
+}
+
+func TestExpenseSubCategories_UpdateExpenseSubCategory(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	type want struct {
+		statusCode int
+		errorMsg   string
+		categoryID int64
+	}
+
+	tests := []struct {
+		name        string
+		subCategory models.ExpenseSubCategory
+		params      map[string]string
+		want        want
+	}{
+		{
+			name:        "Success",
+			subCategory: models.ExpenseSubCategory{Name: "Movies", Category: "Leisure"},
+			params:      map[string]string{"id": "3"},
+			want: want{
+				statusCode: http.StatusNoContent,
+				categoryID: 2,
+			},
+		},
+		{
+			name:        "SuccessMovingItToAnotherCategory",
+			subCategory: models.ExpenseSubCategory{Name: "Cinema", Category: "House"},
+			params:      map[string]string{"id": "3"},
+			want: want{
+				statusCode: http.StatusNoContent,
+				categoryID: 1,
+			},
+		},
+		{
+			name:        "ErrorNameOfAnotherSubCategory",
+			subCategory: models.ExpenseSubCategory{Name: "Restaurants", Category: "Leisure"},
+			params:      map[string]string{"id": "3"},
+			want: want{
+				statusCode: http.StatusConflict,
+				errorMsg:   "expense subcategory with this name already exists",
+			},
+		},
+		{
+			name:        "ErrorUnexistingCategory",
+			subCategory: models.ExpenseSubCategory{Name: "Cinema", Category: "Unknown"},
+			params:      map[string]string{"id": "3"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "expense category does not exist",
+			},
+		},
+		{
+			name:        "ErrorNameTooLong",
+			subCategory: models.ExpenseSubCategory{Name: "Cinema and other shows", Category: "Leisure"},
+			params:      map[string]string{"id": "3"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "expense subcategory name must have between 1 and 20 characters",
+			},
+		},
+		{
+			name:        "ErrorParameterIDNotInteger",
+			subCategory: models.ExpenseSubCategory{Name: "Cinema", Category: "Leisure"},
+			params:      map[string]string{"id": "abc"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "id parameter must be an integer",
+			},
+		},
+		{
+			name:        "ErrorUnexistingSubCategory",
+			subCategory: models.ExpenseSubCategory{Name: "Theatre", Category: "Leisure"},
+			params:      map[string]string{"id": "9"},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "expense subcategory with this id does not exist",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// GIVEN
+			subCategoriesRepo := newUsedExpenseSubCategories()
+			subCategoriesHandlers := NewExpenseSubCategories(subCategoriesRepo, &categoriesCache)
+
+			data, err := json.Marshal(tt.subCategory)
+			if err != nil {
+				t.Fatalf("error marshaling expense subcategory: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+			ginCtx, _ := gin.CreateTestContext(w)
+			ginCtx.Request = &http.Request{
+				Method: http.MethodPut,
+				Body:   io.NopCloser(bytes.NewBuffer(data)),
+			}
+
+			for k, v := range tt.params {
+				ginCtx.Params = append(ginCtx.Params, gin.Param{Key: k, Value: v})
+			}
+
+			// WHEN
+			subCategoriesHandlers.UpdateExpenseSubCategory(ginCtx)
+
+			// THEN
+			assert.EqualValues(t, tt.want.statusCode, w.Code)
+
+			switch w.Code {
+			case http.StatusNoContent:
+				subCategory, err := subCategoriesRepo.GetExpenseSubCategoryByID(context.Background(), 3)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.subCategory.Name, subCategory.Name)
+				assert.Equal(t, tt.want.categoryID, subCategory.CategoryID)
+			default:
+				var r models.ErrorResponse
+				err = json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.errorMsg, r.ErrorMsg)
+			}
+		})
+	}
+}
+
+func TestExpenseSubCategories_DeleteExpenseSubCategory(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	type want struct {
+		statusCode int
+		errorMsg   string
+		deleted    int64
+	}
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		query  url.Values
+		want   want
+	}{
+		{
+			name:   "Success",
+			params: map[string]string{"id": "3"},
+			want: want{
+				statusCode: http.StatusNoContent,
+				deleted:    3,
+			},
+		},
+		{
+			name:   "SuccessReassigningItsExpenses",
+			params: map[string]string{"id": "1"},
+			query:  url.Values{"reassign_to": {"3"}},
+			want: want{
+				statusCode: http.StatusNoContent,
+				deleted:    1,
+			},
+		},
+		{
+			name:   "ErrorInUse",
+			params: map[string]string{"id": "1"},
+			want: want{
+				statusCode: http.StatusConflict,
+				errorMsg: "expense subcategory still has expenses or budgets, rules or recurring transactions - " +
+					"set reassign_to to the id of the expense subcategory to move the expenses to",
+			},
+		},
+		{
+			name:   "ErrorInUseAfterReassign",
+			params: map[string]string{"id": "2"},
+			query:  url.Values{"reassign_to": {"3"}},
+			want: want{
+				statusCode: http.StatusConflict,
+				errorMsg:   "expense subcategory still has budgets, rules or recurring transactions, which are not reassigned",
+			},
+		},
+		{
+			name:   "ErrorReassignToItself",
+			params: map[string]string{"id": "1"},
+			query:  url.Values{"reassign_to": {"1"}},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "reassign_to must be the id of another expense subcategory",
+			},
+		},
+		{
+			name:   "ErrorUnexistingReplacement",
+			params: map[string]string{"id": "1"},
+			query:  url.Values{"reassign_to": {"9"}},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "expense subcategory to reassign to does not exist",
+			},
+		},
+		{
+			name:   "ErrorUnexistingSubCategory",
+			params: map[string]string{"id": "9"},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "expense subcategory with this id does not exist",
+			},
+		},
+		{
+			name:   "ErrorParameterIDNotInteger",
+			params: map[string]string{"id": "abc"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "id parameter must be an integer",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// GIVEN
+			subCategoriesRepo := newUsedExpenseSubCategories()
+			subCategoriesHandlers := NewExpenseSubCategories(subCategoriesRepo, &categoriesCache)
+
+			w := httptest.NewRecorder()
+			ginCtx, _ := gin.CreateTestContext(w)
+			ginCtx.Request = &http.Request{
+				Method: http.MethodDelete,
+				URL:    &url.URL{RawQuery: tt.query.Encode()},
+			}
+
+			for k, v := range tt.params {
+				ginCtx.Params = append(ginCtx.Params, gin.Param{Key: k, Value: v})
+			}
+
+			// WHEN
+			subCategoriesHandlers.DeleteExpenseSubCategory(ginCtx)
+
+			// THEN
+			assert.EqualValues(t, tt.want.statusCode, w.Code)
+
+			switch w.Code {
+			case http.StatusNoContent:
+				_, err := subCategoriesRepo.GetExpenseSubCategoryByID(context.Background(), tt.want.deleted)
+				assert.ErrorIs(t, err, repository.ErrNotFound)
+			default:
+				var r models.ErrorResponse
+				err := json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.errorMsg, r.ErrorMsg)
+
+				subCategories, err := subCategoriesRepo.GetExpenseSubCategories(context.Background())
+				assert.NoError(t, err)
+				assert.Len(t, subCategories, 3)
+			}
+		})
+	}
+}
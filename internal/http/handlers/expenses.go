@@ -3,15 +3,23 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/categorization"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/duplicates"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
 	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository"
 	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/search"
+	"github.com/rubengomes8/golang-personal-finances/internal/splits"
+	"github.com/rubengomes8/golang-personal-finances/internal/tags"
 	"github.com/rubengomes8/golang-personal-finances/internal/utils"
 )
 
@@ -20,6 +28,9 @@ type Expenses struct {
 	Repository            repository.ExpenseRepo
 	SubCategoryRepository repository.ExpenseSubCategoryRepo
 	CardRepository        repository.CardRepo
+	DuplicatesDetector    duplicates.Detector
+	Categorizer           categorization.Categorizer
+	Rates                 currency.Rates
 }
 
 // NewExpenses creates a new Expenses service
@@ -32,6 +43,7 @@ func NewExpenses(
 		Repository:            expRepo,
 		SubCategoryRepository: expSubCatRepo,
 		CardRepository:        cardRepo,
+		DuplicatesDetector:    duplicates.NewDetector(),
 	}
 }
 
@@ -40,12 +52,18 @@ func NewExpenses(
 // @tags Expenses
 // @Summary Creates a new expense.
 // @Description Endpoint to create an expense.
+// @Description An expense of the same card and value, close in date and with a similar description, is a likely duplicate
+// @Description and is only created if force is set.
+// @Description Without a subcategory, the subcategory is picked by the first categorization rule matching the expense.
+// @Description An expense can be split in lines of their own subcategory whose values add up to its value.
+// @Description Tags are single words, stored lower cased.
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param body body models.ExpenseCreateRequest true "Create expense request"
 // @Success 201 {object} models.ExpenseCreateResponse
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ExpenseDuplicatesResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /v1/expense [post]
 func (e *Expenses) CreateExpense(ctx *gin.Context) {
@@ -60,11 +78,13 @@ func (e *Expenses) CreateExpense(ctx *gin.Context) {
 		return
 	}
 
-	expSubCategory, card, err := e.getExpenseSubcategoryAndCardIDByNames(ctx, expense.SubCategory, expense.Card)
+	userID := auth.UserID(ctx)
+
+	expSubCategory, card, err := e.getExpenseSubcategoryAndCardIDByNames(ctx, userID, expense)
 	if err != nil {
 		log.Printf("could not get expense subcategory and card ids by names: %v", err)
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
-			ErrorMsg: "subcategory or card does not exist",
+		ctx.JSON(subcategoryOrCardStatusCode(err), models.ErrorResponse{
+			ErrorMsg: subcategoryOrCardErrorMsg(err),
 		})
 		return
 	}
@@ -78,12 +98,64 @@ func (e *Expenses) CreateExpense(ctx *gin.Context) {
 		return
 	}
 
+	expenseCurrency, err := optionalCurrency(expense.Currency)
+	if err != nil {
+		log.Printf("invalid expense currency - %v: %v", expense.Currency, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: currency.ErrInvalidCurrency.Error(),
+		})
+		return
+	}
+
+	expenseSplits, err := e.getExpenseSplits(ctx, expense)
+	if err != nil {
+		log.Printf("could not get expense splits: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: splitErrorMsg(err),
+		})
+		return
+	}
+
+	expenseTags, err := tags.Normalize(expense.Tags)
+	if err != nil {
+		log.Printf("invalid expense tags - %v: %v", expense.Tags, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+
 	expenseRecord := dbModels.ExpenseTable{
 		Value:         expense.Value,
+		Currency:      expenseCurrency,
 		Date:          date,
 		SubCategoryID: expSubCategory.ID,
 		CardID:        card.ID,
 		Description:   expense.Description,
+		UserID:        userID,
+		Splits:        expenseSplits,
+		Tags:          expenseTags,
+	}
+
+	if !expense.Force {
+		minDate, maxDate := e.DuplicatesDetector.DateWindow(date)
+		candidates, err := e.Repository.GetExpensesByCardAndValue(ctx, userID, card.ID, expense.Value, minDate, maxDate)
+		if err != nil {
+			log.Printf("could not get expense duplicate candidates: %v", err)
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				ErrorMsg: "could not create expense",
+			})
+			return
+		}
+
+		likelyDuplicates := e.DuplicatesDetector.Expenses(candidates, expenseRecord)
+		if len(likelyDuplicates) > 0 {
+			ctx.JSON(http.StatusConflict, models.ExpenseDuplicatesResponse{
+				ErrorMsg:   "expense is a likely duplicate - set force to create it anyway",
+				Candidates: expensesViewToExpensesGetResponse(likelyDuplicates),
+			})
+			return
+		}
 	}
 
 	id, err := e.Repository.InsertExpense(ctx, expenseRecord)
@@ -99,6 +171,132 @@ func (e *Expenses) CreateExpense(ctx *gin.Context) {
 	ctx.Writer.Flush()
 }
 
+// CreateExpenses is used to create several expenses at once.
+// ShowEntity godoc
+// @tags Expenses
+// @Summary Creates several expenses.
+// @Description Endpoint to create several expenses at once. Either all expenses are created or none is.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body models.ExpensesCreateRequest true "Create expenses request"
+// @Success 201 {object} models.ExpensesCreateResponse
+// @Failure 400 {object} models.BatchErrorResponse
+// @Failure 500 {object} models.BatchErrorResponse
+// @Router /v1/expenses/batch [post]
+func (e *Expenses) CreateExpenses(ctx *gin.Context) {
+
+	var request models.ExpensesCreateRequest
+	err := json.NewDecoder(ctx.Request.Body).Decode(&request)
+	if err != nil {
+		log.Printf("could not decode create expenses body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode expenses",
+		})
+		return
+	}
+
+	if len(request.Expenses) == 0 {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "there are no expenses to create",
+		})
+		return
+	}
+
+	userID := auth.UserID(ctx)
+
+	expenseRecords := make([]dbModels.ExpenseTable, 0, len(request.Expenses))
+	for idx, expense := range request.Expenses {
+
+		expSubCategory, card, err := e.getExpenseSubcategoryAndCardIDByNames(ctx, userID, expense)
+		if err != nil {
+			log.Printf("could not get expense %d subcategory and card ids by names: %v", idx, err)
+			ctx.JSON(subcategoryOrCardStatusCode(err), models.BatchErrorResponse{
+				ErrorMsg: fmt.Sprintf("expense %d: %s", idx, subcategoryOrCardErrorMsg(err)),
+				Index:    idx,
+			})
+			return
+		}
+
+		date, err := utils.DateStringToTime(expense.Date)
+		if err != nil {
+			log.Printf("error converting expense %d date string to time - %v: %v", idx, expense.Date, err)
+			ctx.JSON(http.StatusBadRequest, models.BatchErrorResponse{
+				ErrorMsg: fmt.Sprintf("expense %d: could not parse date - must use YYYY-MM-DD date format", idx),
+				Index:    idx,
+			})
+			return
+		}
+
+		expenseCurrency, err := optionalCurrency(expense.Currency)
+		if err != nil {
+			log.Printf("invalid expense %d currency - %v: %v", idx, expense.Currency, err)
+			ctx.JSON(http.StatusBadRequest, models.BatchErrorResponse{
+				ErrorMsg: fmt.Sprintf("expense %d: %s", idx, currency.ErrInvalidCurrency),
+				Index:    idx,
+			})
+			return
+		}
+
+		expenseSplits, err := e.getExpenseSplits(ctx, expense)
+		if err != nil {
+			log.Printf("could not get expense %d splits: %v", idx, err)
+			ctx.JSON(http.StatusBadRequest, models.BatchErrorResponse{
+				ErrorMsg: fmt.Sprintf("expense %d: %s", idx, splitErrorMsg(err)),
+				Index:    idx,
+			})
+			return
+		}
+
+		expenseTags, err := tags.Normalize(expense.Tags)
+		if err != nil {
+			log.Printf("invalid expense %d tags - %v: %v", idx, expense.Tags, err)
+			ctx.JSON(http.StatusBadRequest, models.BatchErrorResponse{
+				ErrorMsg: fmt.Sprintf("expense %d: %s", idx, err),
+				Index:    idx,
+			})
+			return
+		}
+
+		expenseRecords = append(expenseRecords, dbModels.ExpenseTable{
+			Value:         expense.Value,
+			Currency:      expenseCurrency,
+			Date:          date,
+			SubCategoryID: expSubCategory.ID,
+			CardID:        card.ID,
+			Description:   expense.Description,
+			UserID:        userID,
+			Splits:        expenseSplits,
+			Tags:          expenseTags,
+		})
+	}
+
+	ids, err := e.Repository.InsertExpenses(ctx, expenseRecords)
+	if err != nil {
+		log.Printf("could not insert expenses: %v", err)
+		var batchErr repository.BatchItemError
+		if errors.As(err, &batchErr) {
+			ctx.JSON(http.StatusInternalServerError, models.BatchErrorResponse{
+				ErrorMsg: fmt.Sprintf("expense %d: could not create expense", batchErr.Index),
+				Index:    batchErr.Index,
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not create expenses",
+		})
+		return
+	}
+
+	response := models.ExpensesCreateResponse{IDs: make([]int, 0, len(ids))}
+	for _, id := range ids {
+		response.IDs = append(response.IDs, int(id))
+	}
+
+	ctx.JSON(http.StatusCreated, &response)
+	ctx.Writer.Flush()
+}
+
 // UpdateExpense updates an expense on the database.
 // ShowEntity godoc
 // @tags Expenses
@@ -111,6 +309,7 @@ func (e *Expenses) CreateExpense(ctx *gin.Context) {
 // @Param body body models.ExpenseCreateRequest true "Update expense request"
 // @Success 204 "No content"
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /v1/expense/{id} [put]
 func (e *Expenses) UpdateExpense(ctx *gin.Context) {
@@ -125,12 +324,15 @@ func (e *Expenses) UpdateExpense(ctx *gin.Context) {
 		return
 	}
 
-	expSubCategory, card, err := e.getExpenseSubcategoryAndCardIDByNames(ctx, expense.SubCategory, expense.Card)
+	userID := auth.UserID(ctx)
+
+	expSubCategory, card, err := e.getExpenseSubcategoryAndCardIDByNames(ctx, userID, expense)
 	if err != nil {
 		log.Printf("could not get expense subcategory and card ids by names: %v", err)
-		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
-			ErrorMsg: "subcategory or card does not exist",
+		ctx.JSON(subcategoryOrCardStatusCode(err), models.ErrorResponse{
+			ErrorMsg: subcategoryOrCardErrorMsg(err),
 		})
+		return
 	}
 
 	date, err := utils.DateStringToTime(expense.Date)
@@ -142,6 +344,33 @@ func (e *Expenses) UpdateExpense(ctx *gin.Context) {
 		return
 	}
 
+	expenseCurrency, err := optionalCurrency(expense.Currency)
+	if err != nil {
+		log.Printf("invalid expense currency - %v: %v", expense.Currency, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: currency.ErrInvalidCurrency.Error(),
+		})
+		return
+	}
+
+	expenseSplits, err := e.getExpenseSplits(ctx, expense)
+	if err != nil {
+		log.Printf("could not get expense splits: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: splitErrorMsg(err),
+		})
+		return
+	}
+
+	expenseTags, err := tags.Normalize(expense.Tags)
+	if err != nil {
+		log.Printf("invalid expense tags - %v: %v", expense.Tags, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+
 	paramID := ctx.Param("id")
 
 	expenseID, err := strconv.Atoi(paramID)
@@ -156,13 +385,23 @@ func (e *Expenses) UpdateExpense(ctx *gin.Context) {
 	expenseRecord := dbModels.ExpenseTable{
 		ID:            int64(expenseID),
 		Value:         expense.Value,
+		Currency:      expenseCurrency,
 		Date:          date,
 		SubCategoryID: expSubCategory.ID,
 		CardID:        card.ID,
 		Description:   expense.Description,
+		UserID:        userID,
+		Splits:        expenseSplits,
+		Tags:          expenseTags,
 	}
 
 	_, err = e.Repository.UpdateExpense(ctx, expenseRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "expense with this id does not exist",
+		})
+		return
+	}
 	if err != nil {
 		log.Printf("could not update expense with param id = %v: %v", paramID, err)
 		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -186,6 +425,7 @@ func (e *Expenses) UpdateExpense(ctx *gin.Context) {
 // @Param id query string true "The expense id"
 // @Success 201 {object} models.ExpenseCreateRequest
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Router /v1/expense/{id} [get]
 func (e *Expenses) GetExpenseByID(ctx *gin.Context) {
 
@@ -200,7 +440,13 @@ func (e *Expenses) GetExpenseByID(ctx *gin.Context) {
 		return
 	}
 
-	expenseViewRecord, err := e.Repository.GetExpenseByID(ctx, int64(expenseID))
+	expenseViewRecord, err := e.Repository.GetExpenseByID(ctx, auth.UserID(ctx), int64(expenseID))
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "expense with this id does not exist",
+		})
+		return
+	}
 	if err != nil {
 		log.Printf("could not get expense by id - param id is %v - %v", paramID, err)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -224,6 +470,7 @@ func (e *Expenses) GetExpenseByID(ctx *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param category query string true "The expense category"
+// @Param tag query string false "Only the expenses with this tag"
 // @Success 201 {object} []models.ExpenseCreateRequest
 // @Failure 400 {object} models.ErrorResponse
 // @Router /v1/expenses/category/{category} [get]
@@ -231,7 +478,7 @@ func (e *Expenses) GetExpensesByCategory(ctx *gin.Context) {
 
 	paramCategory := ctx.Param("category")
 
-	expenseViewRecords, err := e.Repository.GetExpensesByCategory(ctx, paramCategory)
+	expenseViewRecords, err := e.Repository.GetExpensesByCategory(ctx, auth.UserID(ctx), paramCategory)
 	if err != nil {
 		log.Printf("could not get expenses by category - category is %v - %v", paramCategory, err)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -240,7 +487,7 @@ func (e *Expenses) GetExpensesByCategory(ctx *gin.Context) {
 		return
 	}
 
-	responseExpenses := expensesViewToExpensesGetResponse(expenseViewRecords)
+	responseExpenses := expensesViewToExpensesGetResponse(tags.Expenses(expenseViewRecords, ctx.Query("tag")))
 
 	ctx.JSON(http.StatusOK, responseExpenses)
 	ctx.Writer.Flush()
@@ -255,6 +502,7 @@ func (e *Expenses) GetExpensesByCategory(ctx *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param category query string true "The expense subcategory"
+// @Param tag query string false "Only the expenses with this tag"
 // @Success 201 {object} []models.ExpenseCreateRequest
 // @Failure 400 {object} models.ErrorResponse
 // @Router /v1/expenses/subcategory/{sub_category} [get]
@@ -262,7 +510,7 @@ func (e *Expenses) GetExpensesBySubCategory(ctx *gin.Context) {
 
 	paramSubCategory := ctx.Param("sub_category")
 
-	expenseViewRecords, err := e.Repository.GetExpensesBySubCategory(ctx, paramSubCategory)
+	expenseViewRecords, err := e.Repository.GetExpensesBySubCategory(ctx, auth.UserID(ctx), paramSubCategory)
 	if err != nil {
 		log.Printf("could not get expenses by subcategory - category is %v - %v", paramSubCategory, err)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -271,7 +519,7 @@ func (e *Expenses) GetExpensesBySubCategory(ctx *gin.Context) {
 		return
 	}
 
-	responseExpenses := expensesViewToExpensesGetResponse(expenseViewRecords)
+	responseExpenses := expensesViewToExpensesGetResponse(tags.Expenses(expenseViewRecords, ctx.Query("tag")))
 
 	ctx.JSON(http.StatusOK, responseExpenses)
 	ctx.Writer.Flush()
@@ -286,6 +534,7 @@ func (e *Expenses) GetExpensesBySubCategory(ctx *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param category query string true "The card"
+// @Param tag query string false "Only the expenses with this tag"
 // @Success 201 {object} []models.ExpenseCreateRequest
 // @Failure 400 {object} models.ErrorResponse
 // @Router /v1/expenses/card/{card} [get]
@@ -293,7 +542,7 @@ func (e *Expenses) GetExpensesByCard(ctx *gin.Context) {
 
 	paramCard := ctx.Param("card")
 
-	expenseViewRecords, err := e.Repository.GetExpensesByCard(ctx, paramCard)
+	expenseViewRecords, err := e.Repository.GetExpensesByCard(ctx, auth.UserID(ctx), paramCard)
 	if err != nil {
 		log.Printf("could not get expenses by card - card is %v - %v", paramCard, err)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -302,7 +551,7 @@ func (e *Expenses) GetExpensesByCard(ctx *gin.Context) {
 		return
 	}
 
-	responseExpenses := expensesViewToExpensesGetResponse(expenseViewRecords)
+	responseExpenses := expensesViewToExpensesGetResponse(tags.Expenses(expenseViewRecords, ctx.Query("tag")))
 
 	ctx.JSON(http.StatusOK, responseExpenses)
 	ctx.Writer.Flush()
@@ -313,11 +562,14 @@ func (e *Expenses) GetExpensesByCard(ctx *gin.Context) {
 // @tags Expenses
 // @Summary Gets a list of expenses created on a range of dates.
 // @Description Endpoint to get a list of expenses created on the provided range of dates.
+// @Description With a reporting currency, each value is converted to it at the exchange rate of the expense date.
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param min_date query string true "The minimum date to consider"
 // @Param max_date query string true "The maximum date to consider"
+// @Param currency query string false "The reporting currency, such as EUR, the values are converted to at the rate of each expense date"
+// @Param tag query string false "Only the expenses with this tag"
 // @Success 201 {object} []models.ExpenseCreateRequest
 // @Failure 400 {object} models.ErrorResponse
 // @Router /v1/expenses/dates/{min_date}/{max_date} [get]
@@ -344,7 +596,16 @@ func (e *Expenses) GetExpensesByDates(ctx *gin.Context) {
 		return
 	}
 
-	expenseViewRecords, err := e.Repository.GetExpensesByDates(ctx, minDate, maxDate)
+	reportingCurrency, err := optionalCurrency(ctx.Query("currency"))
+	if err != nil {
+		log.Printf("invalid reporting currency - %v: %v", ctx.Query("currency"), err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: currency.ErrInvalidCurrency.Error(),
+		})
+		return
+	}
+
+	expenseViewRecords, err := e.Repository.GetExpensesByDates(ctx, auth.UserID(ctx), minDate, maxDate)
 	if err != nil {
 		log.Printf("could not get expenses by dates - min_date is %v | max_date is %v - err: %v", paramMinDate, paramMaxDate, err)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -353,12 +614,82 @@ func (e *Expenses) GetExpensesByDates(ctx *gin.Context) {
 		return
 	}
 
-	responseExpenses := expensesViewToExpensesGetResponse(expenseViewRecords)
+	if reportingCurrency != "" {
+		expenseViewRecords, err = e.Rates.ConvertExpenses(expenseViewRecords, reportingCurrency)
+		if err != nil {
+			log.Printf("could not convert expenses to %v: %v", reportingCurrency, err)
+			ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+				ErrorMsg: err.Error(),
+			})
+			return
+		}
+	}
+
+	responseExpenses := expensesViewToExpensesGetResponse(tags.Expenses(expenseViewRecords, ctx.Query("tag")))
 
 	ctx.JSON(http.StatusOK, responseExpenses)
 	ctx.Writer.Flush()
 }
 
+// SearchExpenses gets a page of the expenses that match the search filters
+// ShowEntity godoc
+// @tags Expenses
+// @Summary Searches the expenses.
+// @Description Endpoint to search the expenses by any combination of dates, category, subcategory, card, values, description and tag,
+// @Description sorted by date or value. Dates and values are inclusive bounds. Pages are linked by cursors: the next_cursor
+// @Description of a page is the cursor of the next one, with the same filters and sort order.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param min_date query string false "The minimum date to consider (YYYY-MM-DD)"
+// @Param max_date query string false "The maximum date to consider (YYYY-MM-DD)"
+// @Param category query string false "The category"
+// @Param sub_category query string false "The subcategory"
+// @Param card query string false "The card"
+// @Param min_value query string false "The minimum value, such as 12.30"
+// @Param max_value query string false "The maximum value, such as 12.30"
+// @Param description query string false "Text the description contains, case insensitive"
+// @Param tag query string false "A tag the expense has"
+// @Param sort_by query string false "date (default) or value"
+// @Param order query string false "desc (default) or asc"
+// @Param limit query int false "The page size, 50 by default and up to 500"
+// @Param cursor query string false "The next_cursor of the previous page"
+// @Success 200 {object} models.ExpensesPage
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expenses/search [get]
+func (e *Expenses) SearchExpenses(ctx *gin.Context) {
+
+	query, err := searchQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+
+	page, err := search.Expenses(ctx, e.Repository, auth.UserID(ctx), query)
+	if errors.Is(err, search.ErrInvalidSearch) || errors.Is(err, search.ErrInvalidCursor) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not search expenses - %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not search expenses",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.ExpensesPage{
+		Expenses:   expensesViewToExpensesGetResponse(page.Expenses),
+		NextCursor: page.NextCursor,
+	})
+	ctx.Writer.Flush()
+}
+
 // DeleteExpense deletes an expense from the database that match the id provided.
 // ShowEntity godoc
 // @tags Expenses
@@ -370,6 +701,7 @@ func (e *Expenses) GetExpensesByDates(ctx *gin.Context) {
 // @Param id query string true "The expense id"
 // @Success 204 "No Content"
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Router /v1/expense/{id} [delete]
 func (e *Expenses) DeleteExpense(ctx *gin.Context) {
 
@@ -384,7 +716,13 @@ func (e *Expenses) DeleteExpense(ctx *gin.Context) {
 		return
 	}
 
-	err = e.Repository.DeleteExpense(ctx, int64(expenseID))
+	err = e.Repository.DeleteExpense(ctx, auth.UserID(ctx), int64(expenseID))
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "expense with this id does not exist",
+		})
+		return
+	}
 	if err != nil {
 		log.Printf("could not delete expense with this id - param id is %v - %v", paramID, err)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -397,23 +735,140 @@ func (e *Expenses) DeleteExpense(ctx *gin.Context) {
 	ctx.Writer.Flush()
 }
 
+// getExpenseSubcategoryAndCardIDByNames resolves the subcategory and card names of the expense.
+// A split expense takes the subcategory of its first line.
+// Without a subcategory name, the subcategory is picked by the categorization rules.
 func (e *Expenses) getExpenseSubcategoryAndCardIDByNames(
 	ctx context.Context,
-	subCategory, card string,
+	userID int64,
+	expense models.ExpenseCreateRequest,
 ) (dbModels.ExpenseSubCategoryTable, dbModels.CardTable, error) {
-	subCategoryModel, err := e.SubCategoryRepository.GetExpenseSubCategoryByName(ctx, subCategory)
-	if err != nil {
-		return dbModels.ExpenseSubCategoryTable{}, dbModels.CardTable{}, fmt.Errorf("could not get expense sub category by name: %v", err)
+
+	if len(expense.Splits) > 0 {
+		expense.SubCategory = expense.Splits[0].SubCategory
 	}
 
-	cardModel, err := e.CardRepository.GetCardByName(ctx, card)
+	cardModel, err := e.CardRepository.GetCardByName(ctx, userID, expense.Card)
 	if err != nil {
 		return dbModels.ExpenseSubCategoryTable{}, dbModels.CardTable{}, fmt.Errorf("could not get expense card by name: %v", err)
 	}
 
+	if expense.SubCategory == "" {
+		subCategoryID, err := e.Categorizer.ExpenseSubCategoryID(ctx, userID, cardModel.ID, expense.Value, expense.Description)
+		if err != nil {
+			return dbModels.ExpenseSubCategoryTable{}, dbModels.CardTable{}, fmt.Errorf("could not categorize expense: %w", err)
+		}
+		return dbModels.ExpenseSubCategoryTable{ID: subCategoryID}, cardModel, nil
+	}
+
+	subCategoryModel, err := e.SubCategoryRepository.GetExpenseSubCategoryByName(ctx, expense.SubCategory)
+	if err != nil {
+		return dbModels.ExpenseSubCategoryTable{}, dbModels.CardTable{}, fmt.Errorf("could not get expense sub category by name: %v", err)
+	}
+
 	return subCategoryModel, cardModel, nil
 }
 
+// getExpenseSplits resolves the subcategory names of the split lines of the expense and validates them
+func (e *Expenses) getExpenseSplits(ctx context.Context, expense models.ExpenseCreateRequest) ([]dbModels.ExpenseSplitTable, error) {
+
+	var expenseSplits []dbModels.ExpenseSplitTable
+	for idx, line := range expense.Splits {
+
+		subCategoryModel, err := e.SubCategoryRepository.GetExpenseSubCategoryByName(ctx, line.SubCategory)
+		if err != nil {
+			return []dbModels.ExpenseSplitTable{}, fmt.Errorf("could not get split line %d sub category by name: %v", idx, err)
+		}
+
+		expenseSplits = append(expenseSplits, dbModels.ExpenseSplitTable{
+			SubCategoryID: subCategoryModel.ID,
+			Value:         line.Value,
+			Note:          line.Note,
+		})
+	}
+
+	err := splits.Validate(dbModels.ExpenseTable{Value: expense.Value, Splits: expenseSplits})
+	if err != nil {
+		return []dbModels.ExpenseSplitTable{}, err
+	}
+
+	return expenseSplits, nil
+}
+
+func splitErrorMsg(err error) string {
+	if errors.Is(err, splits.ErrInvalidSplit) {
+		return err.Error()
+	}
+	return "split subcategory does not exist"
+}
+
+func subcategoryOrCardErrorMsg(err error) string {
+	if errors.Is(err, categorization.ErrNoMatchingRule) {
+		return "subcategory is missing and no categorization rule matches the expense"
+	}
+	if errors.Is(err, categorization.ErrCouldNotGetRules) {
+		return "could not categorize expense"
+	}
+	return "subcategory or card does not exist"
+}
+
+// subcategoryOrCardStatusCode is a bad request unless the categorization rules could not be loaded
+func subcategoryOrCardStatusCode(err error) int {
+	if errors.Is(err, categorization.ErrCouldNotGetRules) {
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// optionalCurrency validates a currency that may be missing, which is left empty
+func optionalCurrency(code string) (string, error) {
+	if code == "" {
+		return "", nil
+	}
+	return currency.Normalize(code)
+}
+
+// searchQuery reads the filters, sort order and page of a search from the query string
+func searchQuery(ctx *gin.Context) (search.Query, error) {
+
+	query := search.Query{
+		Category:    ctx.Query("category"),
+		SubCategory: ctx.Query("sub_category"),
+		Card:        ctx.Query("card"),
+		MinValue:    ctx.Query("min_value"),
+		MaxValue:    ctx.Query("max_value"),
+		Description: ctx.Query("description"),
+		Tag:         ctx.Query("tag"),
+		SortBy:      ctx.Query("sort_by"),
+		Order:       ctx.Query("order"),
+		Cursor:      ctx.Query("cursor"),
+	}
+
+	var err error
+	if minDate := ctx.Query("min_date"); minDate != "" {
+		query.MinDate, err = utils.DateStringToTime(minDate)
+		if err != nil {
+			return search.Query{}, fmt.Errorf("%w: could not parse min date - must use YYYY-MM-DD date format", search.ErrInvalidSearch)
+		}
+	}
+
+	if maxDate := ctx.Query("max_date"); maxDate != "" {
+		query.MaxDate, err = utils.DateStringToTime(maxDate)
+		if err != nil {
+			return search.Query{}, fmt.Errorf("%w: could not parse max date - must use YYYY-MM-DD date format", search.ErrInvalidSearch)
+		}
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return search.Query{}, fmt.Errorf("%w: limit must be a number", search.ErrInvalidSearch)
+		}
+	}
+
+	return query, nil
+}
+
 func expenseViewToExpenseGetResponse(expenseView dbModels.ExpenseView) models.ExpenseCreateRequest {
 	return models.ExpenseCreateRequest{
 		ID:          int(expenseView.ID),
@@ -422,7 +877,22 @@ func expenseViewToExpenseGetResponse(expenseView dbModels.ExpenseView) models.Ex
 		SubCategory: expenseView.SubCategory,
 		Card:        expenseView.Card,
 		Description: expenseView.Description,
+		Currency:    expenseView.Currency,
+		Splits:      expenseSplitsToResponse(expenseView.Splits),
+		Tags:        expenseView.Tags,
+	}
+}
+
+func expenseSplitsToResponse(splitViews []dbModels.ExpenseSplitView) []models.ExpenseSplit {
+	var responseSplits []models.ExpenseSplit
+	for _, line := range splitViews {
+		responseSplits = append(responseSplits, models.ExpenseSplit{
+			Value:       line.Value,
+			SubCategory: line.SubCategory,
+			Note:        line.Note,
+		})
 	}
+	return responseSplits
 }
 
 func expensesViewToExpensesGetResponse(expenseViewRecords []dbModels.ExpenseView) []models.ExpenseCreateRequest {
@@ -2,19 +2,26 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/categorization"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
 	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
 	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/search"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,7 +31,7 @@ var (
 
 	houseRentExpenseView = dbModels.ExpenseView{
 		ID:            1,
-		Value:         10.0,
+		Value:         dbModels.MustParseMoney("10"),
 		Date:          firstFebruary2020ZeroHoursUTCTime,
 		Category:      "House",
 		SubCategory:   "Rent",
@@ -37,7 +44,7 @@ var (
 
 	houseRentExpenseTable = dbModels.ExpenseTable{
 		ID:            1,
-		Value:         10.0,
+		Value:         dbModels.MustParseMoney("10"),
 		Date:          firstFebruary2020ZeroHoursUTCTime,
 		SubCategoryID: 1,
 		CardID:        1,
@@ -46,7 +53,7 @@ var (
 
 	houseRentExpenseHTTPModel = models.ExpenseCreateRequest{
 		ID:          1,
-		Value:       10.0,
+		Value:       dbModels.MustParseMoney("10"),
 		Date:        firstFebruary2020String,
 		SubCategory: "Rent",
 		Card:        "CGD",
@@ -55,7 +62,7 @@ var (
 
 	restaurantExpenseView = dbModels.ExpenseView{
 		ID:            2,
-		Value:         20.0,
+		Value:         dbModels.MustParseMoney("20"),
 		Date:          firstFebruary2020ZeroHoursUTCTime,
 		Category:      "Leisure",
 		SubCategory:   "Restaurants",
@@ -68,7 +75,7 @@ var (
 
 	restaurantExpenseTable = dbModels.ExpenseTable{
 		ID:            2,
-		Value:         20.0,
+		Value:         dbModels.MustParseMoney("20"),
 		Date:          firstFebruary2020ZeroHoursUTCTime,
 		SubCategoryID: 2,
 		CardID:        2,
@@ -77,12 +84,22 @@ var (
 
 	restaurantExpenseHTTPModel = models.ExpenseCreateRequest{
 		ID:          2,
-		Value:       20.0,
+		Value:       dbModels.MustParseMoney("20"),
 		Date:        firstFebruary2020String,
 		SubCategory: "Restaurants",
 		Card:        "Food allowance",
 		Description: "Test",
 	}
+
+	otherUserExpenseTable = dbModels.ExpenseTable{
+		ID:            4,
+		Value:         dbModels.MustParseMoney("30"),
+		Date:          firstFebruary2020ZeroHoursUTCTime,
+		SubCategoryID: 1,
+		CardID:        1,
+		Description:   "Other user",
+		UserID:        2,
+	}
 )
 
 func Test_expenseViewToExpenseGetResponse(t *testing.T) {
@@ -161,6 +178,15 @@ var (
 	subCategoriesCache = cache.NewExpenseSubCategory(subCategories)
 )
 
+// unreachableCategorizationRules fails to load the categorization rules, as the database does when it is down
+type unreachableCategorizationRules struct {
+	*cache.CategorizationRule
+}
+
+func (u unreachableCategorizationRules) GetCategorizationRules(ctx context.Context, userID int64) ([]dbModels.CategorizationRuleView, error) {
+	return []dbModels.CategorizationRuleView{}, errors.New("connection refused")
+}
+
 func TestExpenses_CreateExpense(t *testing.T) {
 
 	expenses := []dbModels.ExpenseTable{
@@ -169,7 +195,12 @@ func TestExpenses_CreateExpense(t *testing.T) {
 	}
 	expensesCache := cache.NewExpense(expenses, cardsCache, categoriesCache, subCategoriesCache)
 
+	rulesCache := cache.NewCategorizationRule([]dbModels.CategorizationRuleView{
+		{ID: 1, DescriptionPattern: "pizza", SubCategoryID: 2},
+	})
+
 	expensesHandlers := NewExpenses(&expensesCache, &subCategoriesCache, &cardsCache)
+	expensesHandlers.Categorizer = categorization.NewCategorizer(&rulesCache)
 
 	gin.SetMode(gin.TestMode)
 
@@ -183,12 +214,14 @@ func TestExpenses_CreateExpense(t *testing.T) {
 		statusCode int
 		expenseID  int
 		errorMsg   string
+		candidates []models.ExpenseCreateRequest
 	}
 
 	tests := []struct {
 		name    string
 		expense models.ExpenseCreateRequest
 		fields  fields
+		rules   repository.CategorizationRuleRepo
 		want    want
 	}{
 		{
@@ -198,7 +231,53 @@ func TestExpenses_CreateExpense(t *testing.T) {
 				CardRepository:                &cardsCache,
 				ExpensesSubCategoryRepository: &subCategoriesCache,
 			},
-			expense: houseRentExpenseHTTPModel,
+			expense: models.ExpenseCreateRequest{
+				Value:       dbModels.MustParseMoney("200"),
+				Date:        "2020-02-01",
+				SubCategory: "Rent",
+				Card:        "CGD",
+				Description: "House Rent",
+			},
+			want: want{
+				statusCode: http.StatusCreated,
+				expenseID:  1,
+			},
+		},
+		{
+			name: "ErrorLikelyDuplicate",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				CardRepository:                &cardsCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+			},
+			expense: models.ExpenseCreateRequest{
+				Value:       houseRentExpenseHTTPModel.Value,
+				Date:        "2020-02-03",
+				SubCategory: houseRentExpenseHTTPModel.SubCategory,
+				Card:        houseRentExpenseHTTPModel.Card,
+				Description: "test.",
+			},
+			want: want{
+				statusCode: http.StatusConflict,
+				errorMsg:   "expense is a likely duplicate - set force to create it anyway",
+				candidates: []models.ExpenseCreateRequest{houseRentExpenseHTTPModel},
+			},
+		},
+		{
+			name: "SuccessForcedLikelyDuplicate",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				CardRepository:                &cardsCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+			},
+			expense: models.ExpenseCreateRequest{
+				Value:       restaurantExpenseHTTPModel.Value,
+				Date:        restaurantExpenseHTTPModel.Date,
+				SubCategory: restaurantExpenseHTTPModel.SubCategory,
+				Card:        restaurantExpenseHTTPModel.Card,
+				Description: restaurantExpenseHTTPModel.Description,
+				Force:       true,
+			},
 			want: want{
 				statusCode: http.StatusCreated,
 				expenseID:  1,
@@ -212,7 +291,7 @@ func TestExpenses_CreateExpense(t *testing.T) {
 				ExpensesSubCategoryRepository: &subCategoriesCache,
 			},
 			expense: models.ExpenseCreateRequest{
-				Value:       200.0,
+				Value:       dbModels.MustParseMoney("200"),
 				Date:        "2020-02-01",
 				SubCategory: "Rent",
 				Card:        "Unknown",
@@ -223,6 +302,61 @@ func TestExpenses_CreateExpense(t *testing.T) {
 				errorMsg:   "subcategory or card does not exist",
 			},
 		},
+		{
+			name: "SuccessSubCategoryFromCategorizationRule",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				CardRepository:                &cardsCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+			},
+			expense: models.ExpenseCreateRequest{
+				Value:       dbModels.MustParseMoney("30"),
+				Date:        "2020-02-01",
+				Card:        "CGD",
+				Description: "Pizza place",
+			},
+			want: want{
+				statusCode: http.StatusCreated,
+				expenseID:  1,
+			},
+		},
+		{
+			name: "ErrorNoMatchingCategorizationRule",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				CardRepository:                &cardsCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+			},
+			expense: models.ExpenseCreateRequest{
+				Value:       dbModels.MustParseMoney("30"),
+				Date:        "2020-02-01",
+				Card:        "CGD",
+				Description: "Books",
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "subcategory is missing and no categorization rule matches the expense",
+			},
+		},
+		{
+			name: "ErrorCategorizationRulesUnavailable",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				CardRepository:                &cardsCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+			},
+			rules: unreachableCategorizationRules{CategorizationRule: &rulesCache},
+			expense: models.ExpenseCreateRequest{
+				Value:       dbModels.MustParseMoney("30"),
+				Date:        "2020-02-01",
+				Card:        "CGD",
+				Description: "Pizza place",
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				errorMsg:   "could not categorize expense",
+			},
+		},
 		{
 			name: "ErrorUnexpectedDateFormat",
 			fields: fields{
@@ -231,7 +365,7 @@ func TestExpenses_CreateExpense(t *testing.T) {
 				ExpensesSubCategoryRepository: &subCategoriesCache,
 			},
 			expense: models.ExpenseCreateRequest{
-				Value:       200.0,
+				Value:       dbModels.MustParseMoney("200"),
 				Date:        "01-Feb-2020",
 				SubCategory: "Rent",
 				Card:        "CGD",
@@ -242,6 +376,92 @@ func TestExpenses_CreateExpense(t *testing.T) {
 				errorMsg:   "could not parse date - must use YYYY-MM-DD date format",
 			},
 		},
+		{
+			name: "SuccessSplit",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				CardRepository:                &cardsCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+			},
+			expense: models.ExpenseCreateRequest{
+				Value:       dbModels.MustParseMoney("80"),
+				Date:        "2020-03-01",
+				Card:        "CGD",
+				Description: "Dinner and rent share",
+				Splits: []models.ExpenseSplit{
+					{Value: dbModels.MustParseMoney("50"), SubCategory: "Restaurants"},
+					{Value: dbModels.MustParseMoney("30"), SubCategory: "Rent", Note: "Rent share"},
+				},
+			},
+			want: want{
+				statusCode: http.StatusCreated,
+				expenseID:  1,
+			},
+		},
+		{
+			name: "ErrorSplitDoesNotAddUp",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				CardRepository:                &cardsCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+			},
+			expense: models.ExpenseCreateRequest{
+				Value:       dbModels.MustParseMoney("80"),
+				Date:        "2020-03-01",
+				Card:        "CGD",
+				Description: "Dinner and rent share",
+				Splits: []models.ExpenseSplit{
+					{Value: dbModels.MustParseMoney("50"), SubCategory: "Restaurants"},
+					{Value: dbModels.MustParseMoney("20"), SubCategory: "Rent"},
+				},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "split is not valid: the lines add up to 70.00 instead of 80.00",
+			},
+		},
+		{
+			name: "ErrorUnknownSplitSubCategory",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				CardRepository:                &cardsCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+			},
+			expense: models.ExpenseCreateRequest{
+				Value:       dbModels.MustParseMoney("80"),
+				Date:        "2020-03-01",
+				Card:        "CGD",
+				Description: "Dinner and rent share",
+				Splits: []models.ExpenseSplit{
+					{Value: dbModels.MustParseMoney("50"), SubCategory: "Restaurants"},
+					{Value: dbModels.MustParseMoney("30"), SubCategory: "Unknown"},
+				},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "split subcategory does not exist",
+			},
+		},
+		{
+			name: "ErrorInvalidTag",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				CardRepository:                &cardsCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+			},
+			expense: models.ExpenseCreateRequest{
+				Value:       dbModels.MustParseMoney("80"),
+				Date:        "2020-03-01",
+				SubCategory: "Rent",
+				Card:        "CGD",
+				Description: "House Rent",
+				Tags:        []string{"trip lisbon"},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   `tag is not valid: "trip lisbon" must be a single word of up to 50 characters`,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -260,8 +480,13 @@ func TestExpenses_CreateExpense(t *testing.T) {
 				Body:   io.NopCloser(bytes.NewBuffer(data)),
 			}
 
+			handlers := expensesHandlers
+			if tt.rules != nil {
+				handlers.Categorizer = categorization.NewCategorizer(tt.rules)
+			}
+
 			// WHEN
-			expensesHandlers.CreateExpense(ginCtx)
+			handlers.CreateExpense(ginCtx)
 
 			// THEN
 			assert.EqualValues(t, tt.want.statusCode, w.Code)
@@ -274,7 +499,15 @@ func TestExpenses_CreateExpense(t *testing.T) {
 					t.Fatalf("error decoding response: %v\n", err)
 				}
 				assert.Equal(t, tt.want.expenseID, r.ID)
-			case http.StatusBadRequest:
+			case http.StatusConflict:
+				var r models.ExpenseDuplicatesResponse
+				err = json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.errorMsg, r.ErrorMsg)
+				assert.Equal(t, tt.want.candidates, r.Candidates)
+			case http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError:
 				var r models.ErrorResponse
 				err = json.NewDecoder(w.Body).Decode(&r)
 				if err != nil {
@@ -286,6 +519,128 @@ func TestExpenses_CreateExpense(t *testing.T) {
 	}
 }
 
+func TestExpenses_CreateExpenses(t *testing.T) {
+
+	expenses := []dbModels.ExpenseTable{
+		houseRentExpenseTable,
+		restaurantExpenseTable,
+	}
+	expensesCache := cache.NewExpense(expenses, cardsCache, categoriesCache, subCategoriesCache)
+
+	expensesHandlers := NewExpenses(&expensesCache, &subCategoriesCache, &cardsCache)
+
+	gin.SetMode(gin.TestMode)
+
+	type want struct {
+		statusCode int
+		expenseIDs []int
+		errorMsg   string
+		index      int
+	}
+
+	tests := []struct {
+		name     string
+		expenses models.ExpensesCreateRequest
+		want     want
+	}{
+		{
+			name: "Success",
+			expenses: models.ExpensesCreateRequest{
+				Expenses: []models.ExpenseCreateRequest{
+					houseRentExpenseHTTPModel,
+					restaurantExpenseHTTPModel,
+				},
+			},
+			want: want{
+				statusCode: http.StatusCreated,
+				expenseIDs: []int{1, 1},
+			},
+		},
+		{
+			name: "ErrorUnknownCardOnSecondExpense",
+			expenses: models.ExpensesCreateRequest{
+				Expenses: []models.ExpenseCreateRequest{
+					houseRentExpenseHTTPModel,
+					{
+						Value:       dbModels.MustParseMoney("200"),
+						Date:        "2020-02-01",
+						SubCategory: "Rent",
+						Card:        "Unknown",
+						Description: "House Rent",
+					},
+				},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "expense 1: subcategory or card does not exist",
+				index:      1,
+			},
+		},
+		{
+			name: "ErrorUnexpectedDateFormatOnFirstExpense",
+			expenses: models.ExpensesCreateRequest{
+				Expenses: []models.ExpenseCreateRequest{
+					{
+						Value:       dbModels.MustParseMoney("200"),
+						Date:        "01-Feb-2020",
+						SubCategory: "Rent",
+						Card:        "CGD",
+						Description: "House Rent",
+					},
+					houseRentExpenseHTTPModel,
+				},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "expense 0: could not parse date - must use YYYY-MM-DD date format",
+				index:      0,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// GIVEN
+			data, err := json.Marshal(tt.expenses)
+			if err != nil {
+				t.Fatalf("error marshaling expenses: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+			ginCtx, _ := gin.CreateTestContext(w)
+			ginCtx.Request = &http.Request{
+				Method: http.MethodPost,
+				Body:   io.NopCloser(bytes.NewBuffer(data)),
+			}
+
+			// WHEN
+			expensesHandlers.CreateExpenses(ginCtx)
+
+			// THEN
+			assert.EqualValues(t, tt.want.statusCode, w.Code)
+
+			switch w.Code {
+			case http.StatusCreated:
+				var r models.ExpensesCreateResponse
+				err = json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.expenseIDs, r.IDs)
+			case http.StatusBadRequest:
+				var r models.BatchErrorResponse
+				err = json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.errorMsg, r.ErrorMsg)
+				assert.Equal(t, tt.want.index, r.Index)
+			}
+		})
+	}
+}
+
 func TestExpenses_UpdateExpense(t *testing.T) {
 
 	expenses := []dbModels.ExpenseTable{
@@ -326,7 +681,7 @@ func TestExpenses_UpdateExpense(t *testing.T) {
 			},
 			expense: models.ExpenseCreateRequest{
 				ID:          1,
-				Value:       250.0,
+				Value:       dbModels.MustParseMoney("250"),
 				Date:        firstFebruary2020String,
 				SubCategory: "Rent",
 				Card:        "CGD",
@@ -347,7 +702,7 @@ func TestExpenses_UpdateExpense(t *testing.T) {
 			},
 			expense: models.ExpenseCreateRequest{
 				ID:          1,
-				Value:       250.0,
+				Value:       dbModels.MustParseMoney("250"),
 				Date:        "2020-02-01",
 				SubCategory: "Rent",
 				Card:        "CGD",
@@ -367,7 +722,7 @@ func TestExpenses_UpdateExpense(t *testing.T) {
 			},
 			expense: models.ExpenseCreateRequest{
 				ID:          1,
-				Value:       200.0,
+				Value:       dbModels.MustParseMoney("200"),
 				Date:        "2020-02-01",
 				SubCategory: "Rent",
 				Card:        "Unknown",
@@ -387,7 +742,7 @@ func TestExpenses_UpdateExpense(t *testing.T) {
 			},
 			expense: models.ExpenseCreateRequest{
 				ID:          1,
-				Value:       200.0,
+				Value:       dbModels.MustParseMoney("200"),
 				Date:        "01-Feb-2020",
 				SubCategory: "Rent",
 				Card:        "CGD",
@@ -428,7 +783,7 @@ func TestExpenses_UpdateExpense(t *testing.T) {
 
 			switch w.Code {
 			case http.StatusNoContent:
-			case http.StatusBadRequest:
+			case http.StatusBadRequest, http.StatusNotFound:
 				var r models.ErrorResponse
 				err = json.NewDecoder(w.Body).Decode(&r)
 				if err != nil {
@@ -447,12 +802,13 @@ func TestExpenses_GetExpenseByID(t *testing.T) {
 		restaurantExpenseTable,
 		{
 			ID:            3,
-			Value:         20.0,
+			Value:         dbModels.MustParseMoney("20"),
 			Date:          firstFebruary2020ZeroHoursUTCTime,
 			SubCategoryID: 2,
 			CardID:        3,
 			Description:   "Unknown card",
 		},
+		otherUserExpenseTable,
 	}
 	expensesCache := cache.NewExpense(expenses, cardsCache, categoriesCache, subCategoriesCache)
 
@@ -497,11 +853,24 @@ func TestExpenses_GetExpenseByID(t *testing.T) {
 				ExpensesSubCategoryRepository: &subCategoriesCache,
 			},
 			want: want{
-				statusCode: http.StatusBadRequest,
+				statusCode: http.StatusNotFound,
 				errorMsg:   "expense with this id does not exist",
 			},
 			params: map[string]string{"id": "99"},
 		},
+		{
+			name: "ErrorExpenseOwnedByAnotherUser",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				CardRepository:                &cardsCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "expense with this id does not exist",
+			},
+			params: map[string]string{"id": "4"},
+		},
 		{
 			name: "ErrorParameterIDNotInteger",
 			fields: fields{
@@ -547,7 +916,7 @@ func TestExpenses_GetExpenseByID(t *testing.T) {
 					t.Fatalf("error decoding response: %v\n", err)
 				}
 				assert.Equal(t, tt.want.expense, r)
-			case http.StatusBadRequest:
+			case http.StatusBadRequest, http.StatusNotFound:
 				var r models.ErrorResponse
 				err := json.NewDecoder(w.Body).Decode(&r)
 				if err != nil {
@@ -566,11 +935,12 @@ func TestExpenses_GetExpensesByCategory(t *testing.T) {
 		restaurantExpenseTable,
 		{
 			ID:            3,
-			Value:         250.0,
+			Value:         dbModels.MustParseMoney("250"),
 			Date:          firstFebruary2020ZeroHoursUTCTime,
 			SubCategoryID: 1,
 			CardID:        1,
 			Description:   "Other House expense",
+			Tags:          []string{"reimbursable"},
 		},
 	}
 	expensesCache := cache.NewExpense(expenses, cardsCache, categoriesCache, subCategoriesCache)
@@ -594,6 +964,7 @@ func TestExpenses_GetExpensesByCategory(t *testing.T) {
 		fields fields
 		want   want
 		params map[string]string
+		query  string
 	}{
 		{
 			name: "SuccessCategoryHouse",
@@ -608,16 +979,41 @@ func TestExpenses_GetExpensesByCategory(t *testing.T) {
 					houseRentExpenseHTTPModel,
 					{
 						ID:          3,
-						Value:       250.0,
+						Value:       dbModels.MustParseMoney("250"),
 						Date:        firstFebruary2020String,
 						SubCategory: "Rent",
 						Card:        "CGD",
 						Description: "Other House expense",
+						Tags:        []string{"reimbursable"},
 					},
 				},
 			},
 			params: map[string]string{"category": "House"},
 		},
+		{
+			name: "SuccessCategoryHouseWithTag",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				CardRepository:                &cardsCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				expenses: []models.ExpenseCreateRequest{
+					{
+						ID:          3,
+						Value:       dbModels.MustParseMoney("250"),
+						Date:        firstFebruary2020String,
+						SubCategory: "Rent",
+						Card:        "CGD",
+						Description: "Other House expense",
+						Tags:        []string{"reimbursable"},
+					},
+				},
+			},
+			params: map[string]string{"category": "House"},
+			query:  "tag=Reimbursable",
+		},
 		{
 			name: "ErrorUnknownCategory",
 			fields: fields{
@@ -643,6 +1039,7 @@ func TestExpenses_GetExpensesByCategory(t *testing.T) {
 			ginCtx, _ := gin.CreateTestContext(w)
 			ginCtx.Request = &http.Request{
 				Method: http.MethodGet,
+				URL:    &url.URL{RawQuery: tt.query},
 			}
 
 			for k, v := range tt.params {
@@ -663,7 +1060,7 @@ func TestExpenses_GetExpensesByCategory(t *testing.T) {
 					t.Fatalf("error decoding response: %v\n", err)
 				}
 				assert.Equal(t, tt.want.expenses, r)
-			case http.StatusBadRequest:
+			case http.StatusBadRequest, http.StatusNotFound:
 				var r models.ErrorResponse
 				err := json.NewDecoder(w.Body).Decode(&r)
 				if err != nil {
@@ -682,12 +1079,24 @@ func TestExpenses_GetExpensesBySubCategory(t *testing.T) {
 		restaurantExpenseTable,
 		{
 			ID:            3,
-			Value:         250.0,
+			Value:         dbModels.MustParseMoney("250"),
 			Date:          firstFebruary2020ZeroHoursUTCTime,
 			SubCategoryID: 1,
 			CardID:        1,
 			Description:   "Other Rent expense",
 		},
+		{
+			ID:            4,
+			Value:         dbModels.MustParseMoney("100"),
+			Date:          firstFebruary2020ZeroHoursUTCTime,
+			SubCategoryID: 2,
+			CardID:        1,
+			Description:   "Dinner and rent share",
+			Splits: []dbModels.ExpenseSplitTable{
+				{ID: 1, ExpenseID: 4, SubCategoryID: 2, Value: dbModels.MustParseMoney("60")},
+				{ID: 2, ExpenseID: 4, SubCategoryID: 1, Value: dbModels.MustParseMoney("40"), Note: "Rent share"},
+			},
+		},
 	}
 	expensesCache := cache.NewExpense(expenses, cardsCache, categoriesCache, subCategoriesCache)
 
@@ -724,12 +1133,24 @@ func TestExpenses_GetExpensesBySubCategory(t *testing.T) {
 					houseRentExpenseHTTPModel,
 					{
 						ID:          3,
-						Value:       250.0,
+						Value:       dbModels.MustParseMoney("250"),
 						Date:        firstFebruary2020String,
 						SubCategory: "Rent",
 						Card:        "CGD",
 						Description: "Other Rent expense",
 					},
+					{
+						ID:          4,
+						Value:       dbModels.MustParseMoney("100"),
+						Date:        firstFebruary2020String,
+						SubCategory: "Restaurants",
+						Card:        "CGD",
+						Description: "Dinner and rent share",
+						Splits: []models.ExpenseSplit{
+							{Value: dbModels.MustParseMoney("60"), SubCategory: "Restaurants"},
+							{Value: dbModels.MustParseMoney("40"), SubCategory: "Rent", Note: "Rent share"},
+						},
+					},
 				},
 			},
 			params: map[string]string{"sub_category": "Rent"},
@@ -759,6 +1180,7 @@ func TestExpenses_GetExpensesBySubCategory(t *testing.T) {
 			ginCtx, _ := gin.CreateTestContext(w)
 			ginCtx.Request = &http.Request{
 				Method: http.MethodGet,
+				URL:    &url.URL{},
 			}
 
 			for k, v := range tt.params {
@@ -779,7 +1201,7 @@ func TestExpenses_GetExpensesBySubCategory(t *testing.T) {
 					t.Fatalf("error decoding response: %v\n", err)
 				}
 				assert.Equal(t, tt.want.expenses, r)
-			case http.StatusBadRequest:
+			case http.StatusBadRequest, http.StatusNotFound:
 				var r models.ErrorResponse
 				err := json.NewDecoder(w.Body).Decode(&r)
 				if err != nil {
@@ -798,7 +1220,7 @@ func TestExpenses_GetExpensesByCard(t *testing.T) {
 		restaurantExpenseTable,
 		{
 			ID:            3,
-			Value:         250.0,
+			Value:         dbModels.MustParseMoney("250"),
 			Date:          firstFebruary2020ZeroHoursUTCTime,
 			SubCategoryID: 1,
 			CardID:        1,
@@ -840,7 +1262,7 @@ func TestExpenses_GetExpensesByCard(t *testing.T) {
 					houseRentExpenseHTTPModel,
 					{
 						ID:          3,
-						Value:       250.0,
+						Value:       dbModels.MustParseMoney("250"),
 						Date:        firstFebruary2020String,
 						SubCategory: "Rent",
 						Card:        "CGD",
@@ -875,6 +1297,7 @@ func TestExpenses_GetExpensesByCard(t *testing.T) {
 			ginCtx, _ := gin.CreateTestContext(w)
 			ginCtx.Request = &http.Request{
 				Method: http.MethodGet,
+				URL:    &url.URL{},
 			}
 
 			for k, v := range tt.params {
@@ -895,7 +1318,7 @@ func TestExpenses_GetExpensesByCard(t *testing.T) {
 					t.Fatalf("error decoding response: %v\n", err)
 				}
 				assert.Equal(t, tt.want.expenses, r)
-			case http.StatusBadRequest:
+			case http.StatusBadRequest, http.StatusNotFound:
 				var r models.ErrorResponse
 				err := json.NewDecoder(w.Body).Decode(&r)
 				if err != nil {
@@ -989,6 +1412,7 @@ func TestExpenses_GetExpensesByDates(t *testing.T) {
 			ginCtx, _ := gin.CreateTestContext(w)
 			ginCtx.Request = &http.Request{
 				Method: http.MethodGet,
+				URL:    &url.URL{},
 			}
 
 			for k, v := range tt.params {
@@ -1001,6 +1425,125 @@ func TestExpenses_GetExpensesByDates(t *testing.T) {
 			// THEN
 			assert.EqualValues(t, tt.want.statusCode, w.Code)
 
+			switch w.Code {
+			case http.StatusOK:
+				var r []models.ExpenseCreateRequest
+				err := json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.expenses, r)
+			case http.StatusBadRequest, http.StatusNotFound:
+				var r models.ErrorResponse
+				err := json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.errorMsg, r.ErrorMsg)
+			}
+		})
+	}
+}
+
+func TestExpenses_GetExpensesByDatesInReportingCurrency(t *testing.T) {
+
+	cardsCache := cache.NewCard([]dbModels.CardTable{
+		{ID: 1, Name: "CGD", Currency: "EUR"},
+		{ID: 2, Name: "Food allowance", Currency: "GBP"},
+	})
+	expensesCache := cache.NewExpense(
+		[]dbModels.ExpenseTable{houseRentExpenseTable, restaurantExpenseTable},
+		cardsCache,
+		categoriesCache,
+		subCategoriesCache,
+	)
+
+	rates, err := currency.ParseECBCSV(strings.NewReader("Date,USD,GBP,\n2020-01-31,1.1052,0.84183,\n"))
+	assert.NoError(t, err)
+
+	expensesHandlers := NewExpenses(&expensesCache, &subCategoriesCache, &cardsCache)
+	expensesHandlers.Rates = rates
+
+	houseRentInDollars := houseRentExpenseHTTPModel
+	houseRentInDollars.Value = dbModels.MustParseMoney("11.05")
+	houseRentInDollars.Currency = "USD"
+
+	restaurantInDollars := restaurantExpenseHTTPModel
+	restaurantInDollars.Value = dbModels.MustParseMoney("26.26")
+	restaurantInDollars.Currency = "USD"
+
+	houseRentInEuros := houseRentExpenseHTTPModel
+	houseRentInEuros.Currency = "EUR"
+
+	restaurantInEuros := restaurantExpenseHTTPModel
+	restaurantInEuros.Value = dbModels.MustParseMoney("23.76")
+	restaurantInEuros.Currency = "EUR"
+
+	type want struct {
+		statusCode int
+		expenses   []models.ExpenseCreateRequest
+		errorMsg   string
+	}
+
+	tests := []struct {
+		name     string
+		currency string
+		want     want
+	}{
+		{
+			name:     "SuccessInDollars",
+			currency: "usd",
+			want: want{
+				statusCode: http.StatusOK,
+				expenses:   []models.ExpenseCreateRequest{houseRentInDollars, restaurantInDollars},
+			},
+		},
+		{
+			name:     "SuccessInEuros",
+			currency: "EUR",
+			want: want{
+				statusCode: http.StatusOK,
+				expenses:   []models.ExpenseCreateRequest{houseRentInEuros, restaurantInEuros},
+			},
+		},
+		{
+			name:     "ErrorInvalidCurrency",
+			currency: "dollars",
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   currency.ErrInvalidCurrency.Error(),
+			},
+		},
+		{
+			name:     "ErrorNoRate",
+			currency: "JPY",
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "there is no exchange rate for the currency on or before the date: JPY on 2020-02-01",
+			},
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// GIVEN
+			w := httptest.NewRecorder()
+			ginCtx, _ := gin.CreateTestContext(w)
+			ginCtx.Request = &http.Request{
+				Method: http.MethodGet,
+				URL:    &url.URL{RawQuery: url.Values{"currency": {tt.currency}}.Encode()},
+			}
+			ginCtx.Params = gin.Params{{Key: "min_date", Value: "2020-01-31"}, {Key: "max_date", Value: "2020-02-02"}}
+
+			// WHEN
+			expensesHandlers.GetExpensesByDates(ginCtx)
+
+			// THEN
+			assert.EqualValues(t, tt.want.statusCode, w.Code)
+
 			switch w.Code {
 			case http.StatusOK:
 				var r []models.ExpenseCreateRequest
@@ -1021,11 +1564,82 @@ func TestExpenses_GetExpensesByDates(t *testing.T) {
 	}
 }
 
+func TestExpenses_SearchExpenses(t *testing.T) {
+
+	expensesCache := cache.NewExpense(
+		[]dbModels.ExpenseTable{houseRentExpenseTable, restaurantExpenseTable},
+		cardsCache,
+		categoriesCache,
+		subCategoriesCache,
+	)
+	expensesHandlers := NewExpenses(&expensesCache, &subCategoriesCache, &cardsCache)
+
+	gin.SetMode(gin.TestMode)
+
+	searchExpenses := func(query url.Values) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		ginCtx, _ := gin.CreateTestContext(w)
+		ginCtx.Request = &http.Request{
+			Method: http.MethodGet,
+			URL:    &url.URL{RawQuery: query.Encode()},
+		}
+		expensesHandlers.SearchExpenses(ginCtx)
+		return w
+	}
+
+	// first page
+	w := searchExpenses(url.Values{"sort_by": {"value"}, "order": {"asc"}, "limit": {"1"}})
+	assert.EqualValues(t, http.StatusOK, w.Code)
+
+	var page models.ExpensesPage
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	assert.Equal(t, 1, len(page.Expenses))
+	assert.Equal(t, houseRentExpenseHTTPModel.ID, page.Expenses[0].ID)
+	assert.NotEmpty(t, page.NextCursor)
+
+	// last page
+	w = searchExpenses(url.Values{"sort_by": {"value"}, "order": {"asc"}, "limit": {"1"}, "cursor": {page.NextCursor}})
+	assert.EqualValues(t, http.StatusOK, w.Code)
+
+	page = models.ExpensesPage{}
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	assert.Equal(t, 1, len(page.Expenses))
+	assert.Equal(t, restaurantExpenseHTTPModel.ID, page.Expenses[0].ID)
+	assert.Empty(t, page.NextCursor)
+
+	// filters
+	w = searchExpenses(url.Values{"min_date": {"2020-02-01"}, "max_date": {"2020-02-01"}, "card": {"CGD"}, "description": {"te"}})
+	assert.EqualValues(t, http.StatusOK, w.Code)
+
+	page = models.ExpensesPage{}
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	assert.Equal(t, 1, len(page.Expenses))
+	assert.Equal(t, houseRentExpenseHTTPModel.ID, page.Expenses[0].ID)
+
+	for query, errorMsg := range map[string]string{
+		"limit=ten":           "search is not valid: limit must be a number",
+		"min_date=01-02-2020": "search is not valid: could not parse min date - must use YYYY-MM-DD date format",
+		"min_value=1.234":     `search is not valid: amount must be a decimal number with up to 2 decimal places: "1.234"`,
+		"cursor=abc":          search.ErrInvalidCursor.Error(),
+	} {
+		values, err := url.ParseQuery(query)
+		assert.NoError(t, err)
+
+		w = searchExpenses(values)
+		assert.EqualValues(t, http.StatusBadRequest, w.Code, query)
+
+		var r models.ErrorResponse
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&r))
+		assert.Equal(t, errorMsg, r.ErrorMsg, query)
+	}
+}
+
 func TestExpenses_DeleteExpense(t *testing.T) {
 
 	expenses := []dbModels.ExpenseTable{
 		houseRentExpenseTable,
 		restaurantExpenseTable,
+		otherUserExpenseTable,
 	}
 	expensesCache := cache.NewExpense(expenses, cardsCache, categoriesCache, subCategoriesCache)
 
@@ -1068,11 +1682,24 @@ func TestExpenses_DeleteExpense(t *testing.T) {
 				ExpensesSubCategoryRepository: &subCategoriesCache,
 			},
 			want: want{
-				statusCode: http.StatusBadRequest,
+				statusCode: http.StatusNotFound,
 				errorMsg:   "expense with this id does not exist",
 			},
 			params: map[string]string{"id": "5"},
 		},
+		{
+			name: "ErrorExpenseOwnedByAnotherUser",
+			fields: fields{
+				ExpensesRepository:            &expensesCache,
+				CardRepository:                &cardsCache,
+				ExpensesSubCategoryRepository: &subCategoriesCache,
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "expense with this id does not exist",
+			},
+			params: map[string]string{"id": "4"},
+		},
 		{
 			name: "ErrorParameterIDNotInteger",
 			fields: fields{
@@ -1109,7 +1736,7 @@ func TestExpenses_DeleteExpense(t *testing.T) {
 
 			switch w.Code {
 			case http.StatusNoContent:
-			case http.StatusBadRequest:
+			case http.StatusBadRequest, http.StatusNotFound:
 				var r models.ErrorResponse
 				err := json.NewDecoder(w.Body).Decode(&r)
 				if err != nil {
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/importer"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+// Imports handles the bank statement import http requests
+type Imports struct {
+	importer importer.Importer
+	profiles importer.Profiles
+}
+
+// NewImports creates a new Imports handler
+func NewImports(
+	importer importer.Importer,
+	profiles importer.Profiles,
+) Imports {
+	return Imports{
+		importer: importer,
+		profiles: profiles,
+	}
+}
+
+// ImportCSV imports a bank CSV export.
+// ShowEntity godoc
+// @tags Imports
+// @Summary Imports a bank CSV export.
+// @Description Endpoint to import a bank CSV export. Debits are created as expenses and credits as incomes on the card.
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param file formData file true "The bank CSV export"
+// @Param profile formData string true "The bank profile name"
+// @Param card formData string true "The card the transactions belong to"
+// @Param sub_category formData string false "The subcategory of the created expenses - picked by the categorization rules if missing"
+// @Param category formData string false "The income category of the created incomes - picked by the categorization rules if missing"
+// @Success 201 {object} models.ImportResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ImportErrorResponse
+// @Router /v1/import/csv [post]
+func (i *Imports) ImportCSV(ctx *gin.Context) {
+
+	profile, err := i.profiles.Get(ctx.PostForm("profile"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("unknown profile %q", ctx.PostForm("profile")),
+		})
+		return
+	}
+
+	i.importStatement(ctx, func(r io.Reader) ([]importer.Transaction, error) {
+		return importer.ParseCSV(r, profile)
+	})
+}
+
+// ImportOFX imports an OFX bank statement.
+// ShowEntity godoc
+// @tags Imports
+// @Summary Imports an OFX bank statement.
+// @Description Endpoint to import an OFX 1.x or 2.x statement. Debits are created as expenses and credits as incomes on the card.
+// @Description Transactions whose FITID was already imported on the card are skipped.
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param file formData file true "The OFX statement"
+// @Param card formData string true "The card the transactions belong to"
+// @Param sub_category formData string false "The subcategory of the created expenses - picked by the categorization rules if missing"
+// @Param category formData string false "The income category of the created incomes - picked by the categorization rules if missing"
+// @Success 201 {object} models.ImportResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ImportErrorResponse
+// @Router /v1/import/ofx [post]
+func (i *Imports) ImportOFX(ctx *gin.Context) {
+	i.importStatement(ctx, importer.ParseOFX)
+}
+
+// ImportCAMT053 imports an ISO 20022 camt.053 bank statement.
+// ShowEntity godoc
+// @tags Imports
+// @Summary Imports a camt.053 bank statement.
+// @Description Endpoint to import an ISO 20022 camt.053 statement. Debits are created as expenses and credits as incomes on the card.
+// @Description Entries whose AcctSvcrRef was already imported on the card are skipped.
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param file formData file true "The camt.053 statement"
+// @Param card formData string true "The card the transactions belong to"
+// @Param sub_category formData string false "The subcategory of the created expenses - picked by the categorization rules if missing"
+// @Param category formData string false "The income category of the created incomes - picked by the categorization rules if missing"
+// @Success 201 {object} models.ImportResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ImportErrorResponse
+// @Router /v1/import/camt053 [post]
+func (i *Imports) ImportCAMT053(ctx *gin.Context) {
+	i.importStatement(ctx, importer.ParseCAMT053)
+}
+
+// importStatement parses the uploaded statement file and imports its transactions
+func (i *Imports) importStatement(ctx *gin.Context, parse func(io.Reader) ([]importer.Transaction, error)) {
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		log.Printf("could not get import file: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not get file",
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Printf("could not open import file: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not open file",
+		})
+		return
+	}
+	defer file.Close()
+
+	transactions, err := parse(file)
+	if err != nil {
+		log.Printf("could not parse import file: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: fmt.Sprintf("could not parse file: %v", err),
+		})
+		return
+	}
+
+	result, err := i.importer.Import(ctx, auth.UserID(ctx), transactions, importer.Target{
+		Card:           ctx.PostForm("card"),
+		SubCategory:    ctx.PostForm("sub_category"),
+		IncomeCategory: ctx.PostForm("category"),
+	})
+	if err != nil {
+		var batchErr repository.BatchItemError
+		if errors.As(err, &batchErr) {
+			statusCode := http.StatusInternalServerError
+			if errors.Is(batchErr.Err, importer.ErrNoMatchingRule) {
+				statusCode = http.StatusBadRequest
+			}
+			ctx.JSON(statusCode, models.ImportErrorResponse{
+				ErrorMsg:   fmt.Sprintf("transaction %d: %v", batchErr.Index, batchErr.Err),
+				Index:      batchErr.Index,
+				ExpenseIDs: importIDs(result.ExpenseIDs),
+				IncomeIDs:  importIDs(result.IncomeIDs),
+			})
+			return
+		}
+		statusCode := http.StatusBadRequest
+		if errors.Is(err, importer.ErrCouldNotGetRules) {
+			statusCode = http.StatusInternalServerError
+		}
+		ctx.JSON(statusCode, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+
+	response := models.ImportResponse{
+		ExpenseIDs:        importIDs(result.ExpenseIDs),
+		IncomeIDs:         importIDs(result.IncomeIDs),
+		SkippedReferences: result.SkippedReferences,
+	}
+
+	ctx.JSON(http.StatusCreated, &response)
+	ctx.Writer.Flush()
+}
+
+// importIDs converts the ids of the rows created by an import to the http model
+func importIDs(ids []int64) []int {
+	httpIDs := make([]int, 0, len(ids))
+	for _, id := range ids {
+		httpIDs = append(httpIDs, int(id))
+	}
+	return httpIDs
+}
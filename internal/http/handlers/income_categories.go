@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// IncomeCategories handles the income categories http requests
+type IncomeCategories struct {
+	Repository repository.IncomeCategoryRepo
+}
+
+// NewIncomeCategories creates a new IncomeCategories service
+func NewIncomeCategories(categoryRepo repository.IncomeCategoryRepo) IncomeCategories {
+	return IncomeCategories{
+		Repository: categoryRepo,
+	}
+}
+
+// CreateIncomeCategory is used to create a new income category.
+// ShowEntity godoc
+// @tags Income categories
+// @Summary Creates a new income category.
+// @Description Endpoint to create an income category.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body models.IncomeCategory true "Create income category request"
+// @Success 201 {object} models.CategoryCreateResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/income-category [post]
+func (i *IncomeCategories) CreateIncomeCategory(ctx *gin.Context) {
+
+	var category models.IncomeCategory
+	err := json.NewDecoder(ctx.Request.Body).Decode(&category)
+	if err != nil {
+		log.Printf("could not decode create income category body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode income category",
+		})
+		return
+	}
+
+	name, ok := i.availableName(ctx, 0, category.Name)
+	if !ok {
+		return
+	}
+
+	id, err := i.Repository.InsertIncomeCategory(ctx, dbModels.IncomeCategoryTable{
+		Name: name,
+	})
+	if err != nil {
+		log.Printf("could not insert income category: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not create income category",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &models.CategoryCreateResponse{
+		ID: int(id),
+	})
+	ctx.Writer.Flush()
+}
+
+// UpdateIncomeCategory updates an income category on the database.
+// ShowEntity godoc
+// @tags Income categories
+// @Summary Updates an existing income category.
+// @Description Endpoint to rename an income category.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The income category id"
+// @Param body body models.IncomeCategory true "Update income category request"
+// @Success 204 "No content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/income-category/{id} [put]
+func (i *IncomeCategories) UpdateIncomeCategory(ctx *gin.Context) {
+
+	var category models.IncomeCategory
+	err := json.NewDecoder(ctx.Request.Body).Decode(&category)
+	if err != nil {
+		log.Printf("could not decode update income category body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode income category",
+		})
+		return
+	}
+
+	categoryID, ok := paramID(ctx, "income category")
+	if !ok {
+		return
+	}
+
+	name, ok := i.availableName(ctx, categoryID, category.Name)
+	if !ok {
+		return
+	}
+
+	_, err = i.Repository.UpdateIncomeCategory(ctx, dbModels.IncomeCategoryTable{
+		ID:   categoryID,
+		Name: name,
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "income category with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not update income category with id = %v: %v", categoryID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not update income category",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// GetIncomeCategoryByID gets an income category from the database that match the id provided.
+// ShowEntity godoc
+// @tags Income categories
+// @Summary Gets an income category by its id.
+// @Description Endpoint to get an income category by id.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The income category id"
+// @Success 200 {object} models.IncomeCategory
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/income-category/{id} [get]
+func (i *IncomeCategories) GetIncomeCategoryByID(ctx *gin.Context) {
+
+	categoryID, ok := paramID(ctx, "income category")
+	if !ok {
+		return
+	}
+
+	category, err := i.Repository.GetIncomeCategoryByID(ctx, categoryID)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "income category with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not get income category by id - id is %v - %v", categoryID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get income category",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.IncomeCategory{
+		ID:   int(category.ID),
+		Name: category.Name,
+	})
+	ctx.Writer.Flush()
+}
+
+// GetIncomeCategories gets the income categories.
+// ShowEntity godoc
+// @tags Income categories
+// @Summary Gets the income categories.
+// @Description Endpoint to get the income categories, sorted by name.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.IncomeCategory
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/income-categories [get]
+func (i *IncomeCategories) GetIncomeCategories(ctx *gin.Context) {
+
+	categories, err := i.Repository.GetIncomeCategories(ctx)
+	if err != nil {
+		log.Printf("could not get income categories: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get income categories",
+		})
+		return
+	}
+
+	response := []models.IncomeCategory{}
+	for _, category := range categories {
+		response = append(response, models.IncomeCategory{
+			ID:   int(category.ID),
+			Name: category.Name,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, response)
+	ctx.Writer.Flush()
+}
+
+// DeleteIncomeCategory deletes an income category from the database that match the id provided.
+// ShowEntity godoc
+// @tags Income categories
+// @Summary Deletes an income category by its id.
+// @Description Endpoint to delete an income category by id. A category that still has incomes is only deleted
+// @Description with reassign_to, the id of the income category its incomes are moved to. A category with rules or
+// @Description recurring transactions can not be deleted.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The income category id"
+// @Param reassign_to query string false "The id of the income category to move the incomes to"
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/income-category/{id} [delete]
+func (i *IncomeCategories) DeleteIncomeCategory(ctx *gin.Context) {
+	categoryDeletion{
+		kind:       "income category",
+		references: "incomes",
+		others:     "rules or recurring transactions",
+		getByID: func(id int64) error {
+			_, err := i.Repository.GetIncomeCategoryByID(ctx, id)
+			return err
+		},
+		delete: func(id int64) error {
+			return i.Repository.DeleteIncomeCategory(ctx, id)
+		},
+		replace: func(id int64, replacementID int64) error {
+			return i.Repository.ReplaceIncomeCategory(ctx, id, replacementID)
+		},
+	}.run(ctx)
+}
+
+// MergeIncomeCategory merges an income category into another one.
+// ShowEntity godoc
+// @tags Income categories
+// @Summary Merges an income category into another one.
+// @Description Endpoint to merge an income category into another income category: its incomes, rules and recurring transactions
+// @Description are moved to it and the merged income category is deleted, all at once.
+// @Description On a dry run nothing changes, and the response counts what would move.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The id of the income category to merge"
+// @Param body body models.CategoryMergeRequest true "Merge income category request"
+// @Success 200 {object} models.CategoryMergeResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/income-category/{id}/merge [post]
+func (i *IncomeCategories) MergeIncomeCategory(ctx *gin.Context) {
+	categoryMerge{
+		kind: "income category",
+		getByID: func(id int64) error {
+			_, err := i.Repository.GetIncomeCategoryByID(ctx, id)
+			return err
+		},
+		merge: func(id int64, targetID int64, dryRun bool) (dbModels.CategoryMerge, error) {
+			return i.Repository.MergeIncomeCategory(ctx, id, targetID, dryRun)
+		},
+	}.run(ctx)
+}
+
+// availableName validates the name of an income category, responding with the error if it is not valid
+// or if it is taken by another income category
+func (i *IncomeCategories) availableName(ctx *gin.Context, id int64, name string) (string, bool) {
+
+	name, ok := validName(name)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "income category name must have between 1 and 20 characters",
+		})
+		return "", false
+	}
+
+	return name, nameAvailable(ctx, "income category", id, func(c context.Context) (int64, error) {
+		existing, err := i.Repository.GetIncomeCategoryByName(c, name)
+		return existing.ID, err
+	})
+}
@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// usedIncomeCategories refuses to delete the income categories that still have incomes or rules, as the
+// database does. Replacing an income category moves its incomes, but not its rules.
+type usedIncomeCategories struct {
+	*cache.IncomeCategory
+	withIncomes map[int64]bool
+	withRules   map[int64]bool
+}
+
+func (u usedIncomeCategories) DeleteIncomeCategory(ctx context.Context, id int64) error {
+	if u.withIncomes[id] || u.withRules[id] {
+		return repository.ErrInUse
+	}
+	return u.IncomeCategory.DeleteIncomeCategory(ctx, id)
+}
+
+func (u usedIncomeCategories) ReplaceIncomeCategory(ctx context.Context, id int64, replacementID int64) error {
+
+	_, err := u.GetIncomeCategoryByID(ctx, replacementID)
+	if err != nil {
+		return err
+	}
+
+	if u.withRules[id] {
+		return repository.ErrInUse
+	}
+
+	return u.IncomeCategory.DeleteIncomeCategory(ctx, id)
+}
+
+// newUsedIncomeCategories has the Salary, Refunds and Gifts income categories. Salary has incomes and
+// Refunds has rules, Gifts is not used.
+func newUsedIncomeCategories() usedIncomeCategories {
+
+	categoriesCache := cache.NewIncomeCategory([]dbModels.IncomeCategoryTable{
+		{ID: 1, Name: "Salary"},
+		{ID: 2, Name: "Refunds"},
+		{ID: 3, Name: "Gifts"},
+	})
+
+	return usedIncomeCategories{
+		IncomeCategory: &categoriesCache,
+		withIncomes:    map[int64]bool{1: true},
+		withRules:      map[int64]bool{2: true},
+	}
+}
+
+func TestIncomeCategories_UpdateIncomeCategory(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	type want struct {
+		statusCode int
+		errorMsg   string
+	}
+
+	tests := []struct {
+		name     string
+		category models.IncomeCategory
+		params   map[string]string
+		want     want
+	}{
+		{
+			name:     "Success",
+			category: models.IncomeCategory{Name: "Presents"},
+			params:   map[string]string{"id": "3"},
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:     "SuccessKeepingItsOwnName",
+			category: models.IncomeCategory{Name: "Gifts"},
+			params:   map[string]string{"id": "3"},
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:     "ErrorNameOfAnotherCategory",
+			category: models.IncomeCategory{Name: "Salary"},
+			params:   map[string]string{"id": "3"},
+			want: want{
+				statusCode: http.StatusConflict,
+				errorMsg:   "income category with this name already exists",
+			},
+		},
+		{
+			name:     "ErrorParameterIDNotInteger",
+			category: models.IncomeCategory{Name: "Presents"},
+			params:   map[string]string{"id": "abc"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "id parameter must be an integer",
+			},
+		},
+		{
+			name:     "ErrorUnexistingCategory",
+			category: models.IncomeCategory{Name: "Presents"},
+			params:   map[string]string{"id": "9"},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "income category with this id does not exist",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// GIVEN
+			categoriesRepo := newUsedIncomeCategories()
+			categoriesHandlers := NewIncomeCategories(categoriesRepo)
+
+			data, err := json.Marshal(tt.category)
+			if err != nil {
+				t.Fatalf("error marshaling income category: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+			ginCtx, _ := gin.CreateTestContext(w)
+			ginCtx.Request = &http.Request{
+				Method: http.MethodPut,
+				Body:   io.NopCloser(bytes.NewBuffer(data)),
+			}
+
+			for k, v := range tt.params {
+				ginCtx.Params = append(ginCtx.Params, gin.Param{Key: k, Value: v})
+			}
+
+			// WHEN
+			categoriesHandlers.UpdateIncomeCategory(ginCtx)
+
+			// THEN
+			assert.EqualValues(t, tt.want.statusCode, w.Code)
+
+			switch w.Code {
+			case http.StatusNoContent:
+				category, err := categoriesRepo.GetIncomeCategoryByID(context.Background(), 3)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.category.Name, category.Name)
+			default:
+				var r models.ErrorResponse
+				err = json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.errorMsg, r.ErrorMsg)
+			}
+		})
+	}
+}
+
+func TestIncomeCategories_DeleteIncomeCategory(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	type want struct {
+		statusCode int
+		errorMsg   string
+		deleted    int64
+	}
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		query  url.Values
+		want   want
+	}{
+		{
+			name:   "Success",
+			params: map[string]string{"id": "3"},
+			want: want{
+				statusCode: http.StatusNoContent,
+				deleted:    3,
+			},
+		},
+		{
+			name:   "SuccessReassigningItsIncomes",
+			params: map[string]string{"id": "1"},
+			query:  url.Values{"reassign_to": {"3"}},
+			want: want{
+				statusCode: http.StatusNoContent,
+				deleted:    1,
+			},
+		},
+		{
+			name:   "ErrorInUse",
+			params: map[string]string{"id": "1"},
+			want: want{
+				statusCode: http.StatusConflict,
+				errorMsg: "income category still has incomes or rules or recurring transactions - " +
+					"set reassign_to to the id of the income category to move the incomes to",
+			},
+		},
+		{
+			name:   "ErrorInUseAfterReassign",
+			params: map[string]string{"id": "2"},
+			query:  url.Values{"reassign_to": {"3"}},
+			want: want{
+				statusCode: http.StatusConflict,
+				errorMsg:   "income category still has rules or recurring transactions, which are not reassigned",
+			},
+		},
+		{
+			name:   "ErrorReassignToItself",
+			params: map[string]string{"id": "1"},
+			query:  url.Values{"reassign_to": {"1"}},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "reassign_to must be the id of another income category",
+			},
+		},
+		{
+			name:   "ErrorUnexistingReplacement",
+			params: map[string]string{"id": "1"},
+			query:  url.Values{"reassign_to": {"9"}},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "income category to reassign to does not exist",
+			},
+		},
+		{
+			name:   "ErrorUnexistingCategory",
+			params: map[string]string{"id": "9"},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errorMsg:   "income category with this id does not exist",
+			},
+		},
+		{
+			name:   "ErrorParameterIDNotInteger",
+			params: map[string]string{"id": "abc"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errorMsg:   "id parameter must be an integer",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// GIVEN
+			categoriesRepo := newUsedIncomeCategories()
+			categoriesHandlers := NewIncomeCategories(categoriesRepo)
+
+			w := httptest.NewRecorder()
+			ginCtx, _ := gin.CreateTestContext(w)
+			ginCtx.Request = &http.Request{
+				Method: http.MethodDelete,
+				URL:    &url.URL{RawQuery: tt.query.Encode()},
+			}
+
+			for k, v := range tt.params {
+				ginCtx.Params = append(ginCtx.Params, gin.Param{Key: k, Value: v})
+			}
+
+			// WHEN
+			categoriesHandlers.DeleteIncomeCategory(ginCtx)
+
+			// THEN
+			assert.EqualValues(t, tt.want.statusCode, w.Code)
+
+			switch w.Code {
+			case http.StatusNoContent:
+				_, err := categoriesRepo.GetIncomeCategoryByID(context.Background(), tt.want.deleted)
+				assert.ErrorIs(t, err, repository.ErrNotFound)
+			default:
+				var r models.ErrorResponse
+				err := json.NewDecoder(w.Body).Decode(&r)
+				if err != nil {
+					t.Fatalf("error decoding response: %v\n", err)
+				}
+				assert.Equal(t, tt.want.errorMsg, r.ErrorMsg)
+
+				categories, err := categoriesRepo.GetIncomeCategories(context.Background())
+				assert.NoError(t, err)
+				assert.Len(t, categories, 3)
+			}
+		})
+	}
+}
@@ -2,13 +2,20 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
 	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/search"
 	"github.com/rubengomes8/golang-personal-finances/internal/service"
+
+	incomesService "github.com/rubengomes8/golang-personal-finances/internal/service/incomes"
 )
 
 // Incomes handles the incomes http requests
@@ -30,12 +37,17 @@ func NewIncomes(
 // @tags Incomes
 // @Summary Creates a new income.
 // @Description Endpoint to create an income.
+// @Description An income of the same card and value, close in date and with a similar description, is a likely duplicate
+// @Description and is only created if force is set.
+// @Description Without a category, the category is picked by the first categorization rule matching the income.
+// @Description Tags are single words, stored lower cased.
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param body body models.Income true "Create income request"
 // @Success 201 {object} models.IncomeCreateResponse
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.IncomeDuplicatesResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /v1/income [post]
 func (i *Incomes) HandleCreateIncome(ctx *gin.Context) {
@@ -50,7 +62,33 @@ func (i *Incomes) HandleCreateIncome(ctx *gin.Context) {
 		return
 	}
 
-	incomeID, err := i.service.Create(ctx, income)
+	incomeID, err := i.service.Create(ctx, auth.UserID(ctx), income)
+	var duplicateErr incomesService.LikelyDuplicateError
+	if errors.As(err, &duplicateErr) {
+		ctx.JSON(http.StatusConflict, models.IncomeDuplicatesResponse{
+			ErrorMsg:   "income is a likely duplicate - set force to create it anyway",
+			Candidates: duplicateErr.Candidates,
+		})
+		return
+	}
+	if errors.Is(err, incomesService.ErrNoMatchingCategorizationRule) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "category is missing and no categorization rule matches the income",
+		})
+		return
+	}
+	if errors.Is(err, incomesService.ErrCouldNotCategorizeIncome) {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not categorize income",
+		})
+		return
+	}
+	if errors.Is(err, incomesService.ErrInvalidCurrency) || errors.Is(err, incomesService.ErrInvalidTags) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
 			ErrorMsg: "could not create income",
@@ -62,6 +100,72 @@ func (i *Incomes) HandleCreateIncome(ctx *gin.Context) {
 	ctx.Writer.Flush()
 }
 
+// HandleCreateIncomes handles a create several incomes request.
+// ShowEntity godoc
+// @tags Incomes
+// @Summary Creates several incomes.
+// @Description Endpoint to create several incomes at once. Either all incomes are created or none is.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body models.IncomesCreateRequest true "Create incomes request"
+// @Success 201 {object} models.IncomesCreateResponse
+// @Failure 400 {object} models.BatchErrorResponse
+// @Failure 500 {object} models.BatchErrorResponse
+// @Router /v1/incomes/batch [post]
+func (i *Incomes) HandleCreateIncomes(ctx *gin.Context) {
+
+	var request models.IncomesCreateRequest
+	err := json.NewDecoder(ctx.Request.Body).Decode(&request)
+	if err != nil {
+		log.Printf("could not decode create incomes body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode incomes",
+		})
+		return
+	}
+
+	if len(request.Incomes) == 0 {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "there are no incomes to create",
+		})
+		return
+	}
+
+	incomeIDs, err := i.service.CreateSeveral(ctx, auth.UserID(ctx), request.Incomes)
+	if err != nil {
+		var batchErr repository.BatchItemError
+		if errors.As(err, &batchErr) && errors.Is(batchErr.Err, incomesService.ErrCouldNotInsertIncome) {
+			ctx.JSON(http.StatusInternalServerError, models.BatchErrorResponse{
+				ErrorMsg: fmt.Sprintf("income %d: could not create income", batchErr.Index),
+				Index:    batchErr.Index,
+			})
+			return
+		}
+		if errors.As(err, &batchErr) && errors.Is(batchErr.Err, incomesService.ErrCouldNotCategorizeIncome) {
+			ctx.JSON(http.StatusInternalServerError, models.BatchErrorResponse{
+				ErrorMsg: fmt.Sprintf("income %d: could not categorize income", batchErr.Index),
+				Index:    batchErr.Index,
+			})
+			return
+		}
+		if errors.As(err, &batchErr) {
+			ctx.JSON(http.StatusBadRequest, models.BatchErrorResponse{
+				ErrorMsg: fmt.Sprintf("income %d: %v", batchErr.Index, batchErr.Err),
+				Index:    batchErr.Index,
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not create incomes",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &models.IncomesCreateResponse{IDs: incomeIDs})
+	ctx.Writer.Flush()
+}
+
 // HandleUpdateIncome handles an update income request.
 // ShowEntity godoc
 // @tags Incomes
@@ -74,11 +178,24 @@ func (i *Incomes) HandleCreateIncome(ctx *gin.Context) {
 // @Param id query string true "The income id"
 // @Success 204 "No content"
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
 // @Router /v1/income/{id} [put]
 func (i *Incomes) HandleUpdateIncome(ctx *gin.Context) {
 
+	paramID := ctx.Param("id")
+
+	incomeID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting income id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
 	var income models.Income
-	err := json.NewDecoder(ctx.Request.Body).Decode(&income)
+	err = json.NewDecoder(ctx.Request.Body).Decode(&income)
 	if err != nil {
 		log.Printf("could not decode update income body: %v", err)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -87,7 +204,27 @@ func (i *Incomes) HandleUpdateIncome(ctx *gin.Context) {
 		return
 	}
 
-	err = i.service.Update(ctx, income)
+	income.ID = incomeID
+
+	err = i.service.Update(ctx, auth.UserID(ctx), income)
+	if errors.Is(err, incomesService.ErrIncomeNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "income with this id does not exist",
+		})
+		return
+	}
+	if errors.Is(err, incomesService.ErrCouldNotCategorizeIncome) {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not categorize income",
+		})
+		return
+	}
+	if errors.Is(err, incomesService.ErrInvalidCurrency) || errors.Is(err, incomesService.ErrInvalidTags) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
 			ErrorMsg: "could not update income",
@@ -110,6 +247,7 @@ func (i *Incomes) HandleUpdateIncome(ctx *gin.Context) {
 // @Param id query string true "The income id"
 // @Success 204 "No content"
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Router /v1/income/{id} [delete]
 func (i *Incomes) HandleDeleteIncome(ctx *gin.Context) {
 
@@ -124,7 +262,13 @@ func (i *Incomes) HandleDeleteIncome(ctx *gin.Context) {
 		return
 	}
 
-	err = i.service.Delete(ctx, incomeID)
+	err = i.service.Delete(ctx, auth.UserID(ctx), incomeID)
+	if errors.Is(err, incomesService.ErrIncomeNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "income with this id does not exist",
+		})
+		return
+	}
 	if err != nil {
 		log.Printf("could not delete income with this id - param id is %v - %v", paramID, err)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -148,6 +292,7 @@ func (i *Incomes) HandleDeleteIncome(ctx *gin.Context) {
 // @Param id query string true "The income id"
 // @Success 200 {object} models.Income
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Router /v1/income/{id} [get]
 func (i *Incomes) HandleGetByID(ctx *gin.Context) {
 
@@ -162,7 +307,13 @@ func (i *Incomes) HandleGetByID(ctx *gin.Context) {
 		return
 	}
 
-	income, err := i.service.GetByID(ctx, incomeID)
+	income, err := i.service.GetByID(ctx, auth.UserID(ctx), incomeID)
+	if errors.Is(err, incomesService.ErrIncomeNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "income with this id does not exist",
+		})
+		return
+	}
 	if err != nil {
 		log.Printf("could not get income by id - param id is %v - %v", paramID, err)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -184,6 +335,7 @@ func (i *Incomes) HandleGetByID(ctx *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id query string true "The income category"
+// @Param tag query string false "Only the incomes with this tag"
 // @Success 200 {object} []models.Income
 // @Failure 400 {object} models.ErrorResponse
 // @Router /v1/incomes/category/{category} [get]
@@ -191,7 +343,7 @@ func (i *Incomes) HandleGetIncomesByCategory(ctx *gin.Context) {
 
 	paramCategory := ctx.Param("category")
 
-	incomes, err := i.service.GetAllByCategory(ctx, paramCategory)
+	incomes, err := i.service.GetAllByCategory(ctx, auth.UserID(ctx), paramCategory, ctx.Query("tag"))
 	if err != nil {
 		log.Printf("could not get incomes by category - category is %v - %v", paramCategory, err)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -213,6 +365,7 @@ func (i *Incomes) HandleGetIncomesByCategory(ctx *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id query string true "The payment card"
+// @Param tag query string false "Only the incomes with this tag"
 // @Success 200 {object} []models.Income
 // @Failure 400 {object} models.ErrorResponse
 // @Router /v1/incomes/card/{card} [get]
@@ -220,7 +373,7 @@ func (i *Incomes) HandleGetIncomesByCard(ctx *gin.Context) {
 
 	paramCard := ctx.Param("card")
 
-	incomes, err := i.service.GetAllByCard(ctx, paramCard)
+	incomes, err := i.service.GetAllByCard(ctx, auth.UserID(ctx), paramCard, ctx.Query("tag"))
 	if err != nil {
 		log.Printf("could not get incomes by card - card is %v - %v", paramCard, err)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -233,16 +386,73 @@ func (i *Incomes) HandleGetIncomesByCard(ctx *gin.Context) {
 	ctx.Writer.Flush()
 }
 
+// HandleSearchIncomes handles a search incomes request.
+// ShowEntity godoc
+// @tags Incomes
+// @Summary Searches the incomes.
+// @Description Endpoint to search the incomes by any combination of dates, category, card, values, description and tag,
+// @Description sorted by date or value. Dates and values are inclusive bounds. Pages are linked by cursors: the next_cursor
+// @Description of a page is the cursor of the next one, with the same filters and sort order.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param min_date query string false "The minimum date to consider (YYYY-MM-DD)"
+// @Param max_date query string false "The maximum date to consider (YYYY-MM-DD)"
+// @Param category query string false "The category"
+// @Param card query string false "The card"
+// @Param min_value query string false "The minimum value, such as 12.30"
+// @Param max_value query string false "The maximum value, such as 12.30"
+// @Param description query string false "Text the description contains, case insensitive"
+// @Param tag query string false "A tag the income has"
+// @Param sort_by query string false "date (default) or value"
+// @Param order query string false "desc (default) or asc"
+// @Param limit query int false "The page size, 50 by default and up to 500"
+// @Param cursor query string false "The next_cursor of the previous page"
+// @Success 200 {object} models.IncomesPage
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/incomes/search [get]
+func (i *Incomes) HandleSearchIncomes(ctx *gin.Context) {
+
+	query, err := searchQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+
+	page, err := i.service.Search(ctx, auth.UserID(ctx), query)
+	if errors.Is(err, search.ErrInvalidSearch) || errors.Is(err, search.ErrInvalidCursor) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+	ctx.Writer.Flush()
+}
+
 // HandleGetIncomesByDates handles a get incomes by dates request.
 // ShowEntity godoc
 // @tags Incomes
 // @Summary Gets a list of incomes by payment card.
 // @Description Endpoint to get a list of incomes created on the provided range of dates.
+// @Description With a reporting currency, each value is converted to it at the exchange rate of the income date.
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param min_date query string true "The minimum date to consider"
 // @Param max_date query string true "The maximum date to consider"
+// @Param currency query string false "The reporting currency, such as EUR, the values are converted to at the rate of each income date"
+// @Param tag query string false "Only the incomes with this tag"
 // @Success 200 {object} []models.Income
 // @Failure 400 {object} models.ErrorResponse
 // @Router /v1/incomes/dates/{min_date}/{max_date} [get]
@@ -251,7 +461,13 @@ func (i *Incomes) HandleGetIncomesByDates(ctx *gin.Context) {
 	paramMinDate := ctx.Param("min_date")
 	paramMaxDate := ctx.Param("max_date")
 
-	incomes, err := i.service.GetAllByDates(ctx, paramMinDate, paramMaxDate)
+	incomes, err := i.service.GetAllByDates(ctx, auth.UserID(ctx), paramMinDate, paramMaxDate, ctx.Query("currency"), ctx.Query("tag"))
+	if errors.Is(err, incomesService.ErrInvalidCurrency) || errors.Is(err, incomesService.ErrNoExchangeRate) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		log.Printf("could not get incomes by dates - min_date is %v | max_date is %v - err: %v", paramMinDate, paramMaxDate, err)
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
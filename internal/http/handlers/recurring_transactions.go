@@ -0,0 +1,380 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/scheduler"
+	"github.com/rubengomes8/golang-personal-finances/internal/utils"
+)
+
+// RecurringTransactions handles the recurring transactions http requests
+type RecurringTransactions struct {
+	Repository               repository.RecurringTransactionRepo
+	CardRepository           repository.CardRepo
+	SubCategoryRepository    repository.ExpenseSubCategoryRepo
+	IncomeCategoryRepository repository.IncomeCategoryRepo
+}
+
+// NewRecurringTransactions creates a new RecurringTransactions service
+func NewRecurringTransactions(
+	recurringRepo repository.RecurringTransactionRepo,
+	cardRepo repository.CardRepo,
+	expSubCatRepo repository.ExpenseSubCategoryRepo,
+	incCatRepo repository.IncomeCategoryRepo,
+) RecurringTransactions {
+	return RecurringTransactions{
+		Repository:               recurringRepo,
+		CardRepository:           cardRepo,
+		SubCategoryRepository:    expSubCatRepo,
+		IncomeCategoryRepository: incCatRepo,
+	}
+}
+
+// CreateRecurringTransaction is used to create a new recurring transaction.
+// ShowEntity godoc
+// @tags Recurring transactions
+// @Summary Creates a new recurring transaction.
+// @Description Endpoint to create a recurring transaction. Its expenses (with a subcategory) or incomes (with a category)
+// @Description are created by a background worker from its start date on, including past dates.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body models.RecurringTransaction true "Create recurring transaction request"
+// @Success 201 {object} models.RecurringTransactionCreateResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/recurring-transaction [post]
+func (r *RecurringTransactions) CreateRecurringTransaction(ctx *gin.Context) {
+
+	var recurring models.RecurringTransaction
+	err := json.NewDecoder(ctx.Request.Body).Decode(&recurring)
+	if err != nil {
+		log.Printf("could not decode create recurring transaction body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode recurring transaction",
+		})
+		return
+	}
+
+	recurringRecord, err := r.toRecurringTransactionRecord(ctx, auth.UserID(ctx), recurring, time.Time{})
+	if err != nil {
+		log.Printf("could not get recurring transaction record: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: recurringTransactionErrorMsg(err),
+		})
+		return
+	}
+
+	id, err := r.Repository.InsertRecurringTransaction(ctx, recurringRecord)
+	if err != nil {
+		log.Printf("could not insert recurring transaction: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not create recurring transaction",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &models.RecurringTransactionCreateResponse{
+		ID:      int(id),
+		NextRun: utils.TimeToStringDate(recurringRecord.NextRun),
+	})
+	ctx.Writer.Flush()
+}
+
+// UpdateRecurringTransaction updates a recurring transaction on the database.
+// ShowEntity godoc
+// @tags Recurring transactions
+// @Summary Updates an existing recurring transaction.
+// @Description Endpoint to update a recurring transaction. Its next run is rescheduled from today on.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The recurring transaction id"
+// @Param body body models.RecurringTransaction true "Update recurring transaction request"
+// @Success 204 "No content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/recurring-transaction/{id} [put]
+func (r *RecurringTransactions) UpdateRecurringTransaction(ctx *gin.Context) {
+
+	var recurring models.RecurringTransaction
+	err := json.NewDecoder(ctx.Request.Body).Decode(&recurring)
+	if err != nil {
+		log.Printf("could not decode update recurring transaction body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode recurring transaction",
+		})
+		return
+	}
+
+	paramID := ctx.Param("id")
+
+	recurringID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting recurring transaction id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	recurringRecord, err := r.toRecurringTransactionRecord(ctx, auth.UserID(ctx), recurring, time.Now())
+	if err != nil {
+		log.Printf("could not get recurring transaction record: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: recurringTransactionErrorMsg(err),
+		})
+		return
+	}
+
+	recurringRecord.ID = int64(recurringID)
+
+	_, err = r.Repository.UpdateRecurringTransaction(ctx, recurringRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "recurring transaction with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not update recurring transaction with param id = %v: %v", paramID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not update recurring transaction",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// GetRecurringTransactionByID gets a recurring transaction from the database that match the id provided.
+// ShowEntity godoc
+// @tags Recurring transactions
+// @Summary Gets a recurring transaction by its id.
+// @Description Endpoint to get a recurring transaction by id.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The recurring transaction id"
+// @Success 200 {object} models.RecurringTransaction
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /v1/recurring-transaction/{id} [get]
+func (r *RecurringTransactions) GetRecurringTransactionByID(ctx *gin.Context) {
+
+	paramID := ctx.Param("id")
+
+	recurringID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting recurring transaction id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	recurringView, err := r.Repository.GetRecurringTransactionByID(ctx, auth.UserID(ctx), int64(recurringID))
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "recurring transaction with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not get recurring transaction by id - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not get recurring transaction",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recurringTransactionViewToRecurringTransaction(recurringView))
+	ctx.Writer.Flush()
+}
+
+// GetRecurringTransactions gets the recurring transactions from the database.
+// ShowEntity godoc
+// @tags Recurring transactions
+// @Summary Gets the recurring transactions.
+// @Description Endpoint to get the recurring transactions.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.RecurringTransaction
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/recurring-transactions [get]
+func (r *RecurringTransactions) GetRecurringTransactions(ctx *gin.Context) {
+
+	recurringViews, err := r.Repository.GetRecurringTransactions(ctx, auth.UserID(ctx))
+	if err != nil {
+		log.Printf("could not get recurring transactions: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get recurring transactions",
+		})
+		return
+	}
+
+	response := []models.RecurringTransaction{}
+	for _, recurringView := range recurringViews {
+		response = append(response, recurringTransactionViewToRecurringTransaction(recurringView))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+	ctx.Writer.Flush()
+}
+
+// DeleteRecurringTransaction deletes a recurring transaction from the database that match the id provided.
+// ShowEntity godoc
+// @tags Recurring transactions
+// @Summary Deletes a recurring transaction by its id.
+// @Description Endpoint to delete a recurring transaction by id. The expenses and incomes it created are kept.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The recurring transaction id"
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /v1/recurring-transaction/{id} [delete]
+func (r *RecurringTransactions) DeleteRecurringTransaction(ctx *gin.Context) {
+
+	paramID := ctx.Param("id")
+
+	recurringID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting recurring transaction id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	err = r.Repository.DeleteRecurringTransaction(ctx, auth.UserID(ctx), int64(recurringID))
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "recurring transaction with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not delete recurring transaction with this id - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not delete recurring transaction",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// toRecurringTransactionRecord resolves the card and category names of a recurring transaction, validates it
+// and schedules its next run on the first occurrence on or after the from date
+func (r *RecurringTransactions) toRecurringTransactionRecord(
+	ctx context.Context,
+	userID int64,
+	recurring models.RecurringTransaction,
+	from time.Time,
+) (dbModels.RecurringTransactionTable, error) {
+
+	startDate, err := utils.DateStringToTime(recurring.StartDate)
+	if err != nil {
+		return dbModels.RecurringTransactionTable{},
+			fmt.Errorf("%w: could not parse start date: %v", scheduler.ErrInvalidRecurringTransaction, err)
+	}
+
+	recurringRecord := dbModels.RecurringTransactionTable{
+		Value:          recurring.Value,
+		Description:    recurring.Description,
+		Frequency:      recurring.Frequency,
+		CronExpression: recurring.Cron,
+		StartDate:      startDate,
+		UserID:         userID,
+	}
+
+	if recurring.EndDate != "" {
+		recurringRecord.EndDate, err = utils.DateStringToTime(recurring.EndDate)
+		if err != nil {
+			return dbModels.RecurringTransactionTable{},
+				fmt.Errorf("%w: could not parse end date: %v", scheduler.ErrInvalidRecurringTransaction, err)
+		}
+	}
+
+	card, err := r.CardRepository.GetCardByName(ctx, userID, recurring.Card)
+	if err != nil {
+		return dbModels.RecurringTransactionTable{}, fmt.Errorf("could not get card by name: %v", err)
+	}
+	recurringRecord.CardID = card.ID
+
+	if recurring.SubCategory != "" {
+		subCategory, err := r.SubCategoryRepository.GetExpenseSubCategoryByName(ctx, recurring.SubCategory)
+		if err != nil {
+			return dbModels.RecurringTransactionTable{}, fmt.Errorf("could not get expense sub category by name: %v", err)
+		}
+		recurringRecord.SubCategoryID = subCategory.ID
+	}
+
+	if recurring.Category != "" {
+		category, err := r.IncomeCategoryRepository.GetIncomeCategoryByName(ctx, recurring.Category)
+		if err != nil {
+			return dbModels.RecurringTransactionTable{}, fmt.Errorf("could not get income category by name: %v", err)
+		}
+		recurringRecord.IncomeCategoryID = category.ID
+	}
+
+	err = scheduler.Validate(recurringRecord)
+	if err != nil {
+		return dbModels.RecurringTransactionTable{}, err
+	}
+
+	recurringRecord.NextRun, err = scheduler.FirstRun(recurringRecord, from)
+	if err != nil {
+		return dbModels.RecurringTransactionTable{}, fmt.Errorf("%w: %v", scheduler.ErrInvalidRecurringTransaction, err)
+	}
+
+	return recurringRecord, nil
+}
+
+func recurringTransactionErrorMsg(err error) string {
+	if errors.Is(err, scheduler.ErrInvalidRecurringTransaction) {
+		return "recurring transaction must set either a sub_category or a category, a value >= 0, " +
+			"a known frequency (with a valid cron expression for cron) and YYYY-MM-DD start_date <= end_date"
+	}
+	return "card, subcategory or category does not exist"
+}
+
+func recurringTransactionViewToRecurringTransaction(recurringView dbModels.RecurringTransactionView) models.RecurringTransaction {
+
+	recurring := models.RecurringTransaction{
+		ID:          int(recurringView.ID),
+		Value:       recurringView.Value,
+		Description: recurringView.Description,
+		Frequency:   recurringView.Frequency,
+		Cron:        recurringView.CronExpression,
+		StartDate:   utils.TimeToStringDate(recurringView.StartDate),
+		NextRun:     utils.TimeToStringDate(recurringView.NextRun),
+		Card:        recurringView.Card,
+		SubCategory: recurringView.SubCategory,
+		Category:    recurringView.IncomeCategory,
+	}
+
+	if !recurringView.EndDate.IsZero() {
+		recurring.EndDate = utils.TimeToStringDate(recurringView.EndDate)
+	}
+
+	return recurring
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/summary"
+	"github.com/rubengomes8/golang-personal-finances/internal/utils"
+)
+
+// Summaries handles the expenses and incomes totals and the cash flow http requests
+type Summaries struct {
+	Summarizer summary.Summarizer
+}
+
+// NewSummaries creates a new Summaries service
+func NewSummaries(summarizer summary.Summarizer) Summaries {
+	return Summaries{
+		Summarizer: summarizer,
+	}
+}
+
+// GetExpensesTotals gets the totals of the expenses in a range of dates
+// ShowEntity godoc
+// @tags Summaries
+// @Summary Gets the totals of the expenses on a range of dates.
+// @Description Endpoint to sum the expenses created on the provided range of dates by category, subcategory, card, tag, day, week or month.
+// @Description Without a reporting currency the totals of each currency are kept apart; with one, each value is converted to it
+// @Description at the exchange rate of the expense date.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param min_date query string true "The minimum date to consider"
+// @Param max_date query string true "The maximum date to consider"
+// @Param group_by query string false "category (default), subcategory, card, tag, day, week or month"
+// @Param currency query string false "The reporting currency, such as EUR"
+// @Success 200 {object} []models.Total
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/expenses/totals/{min_date}/{max_date} [get]
+func (s *Summaries) GetExpensesTotals(ctx *gin.Context) {
+
+	minDate, maxDate, ok := datesRange(ctx)
+	if !ok {
+		return
+	}
+
+	groupBy, err := summary.ParseGroupBy(ctx.Query("group_by"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: summary.ErrInvalidGroupBy.Error(),
+		})
+		return
+	}
+
+	totals, err := s.Summarizer.ExpenseTotals(ctx, auth.UserID(ctx), groupBy, minDate, maxDate, ctx.Query("currency"))
+	if err != nil {
+		log.Printf("could not get expenses totals - min_date is %v | max_date is %v - err: %v", minDate, maxDate, err)
+		summaryErrorResponse(ctx, err, "could not get expenses totals")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, totalsToResponse(totals))
+	ctx.Writer.Flush()
+}
+
+// GetIncomesTotals gets the totals of the incomes in a range of dates
+// ShowEntity godoc
+// @tags Summaries
+// @Summary Gets the totals of the incomes on a range of dates.
+// @Description Endpoint to sum the incomes created on the provided range of dates by category, card, day, week or month.
+// @Description Without a reporting currency the totals of each currency are kept apart; with one, each value is converted to it
+// @Description at the exchange rate of the income date.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param min_date query string true "The minimum date to consider"
+// @Param max_date query string true "The maximum date to consider"
+// @Param group_by query string false "category (default), card, day, week or month"
+// @Param currency query string false "The reporting currency, such as EUR"
+// @Success 200 {object} []models.Total
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/incomes/totals/{min_date}/{max_date} [get]
+func (s *Summaries) GetIncomesTotals(ctx *gin.Context) {
+
+	minDate, maxDate, ok := datesRange(ctx)
+	if !ok {
+		return
+	}
+
+	groupBy, err := summary.ParseGroupBy(ctx.Query("group_by"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: summary.ErrInvalidGroupBy.Error(),
+		})
+		return
+	}
+
+	totals, err := s.Summarizer.IncomeTotals(ctx, auth.UserID(ctx), groupBy, minDate, maxDate, ctx.Query("currency"))
+	if err != nil {
+		log.Printf("could not get incomes totals - min_date is %v | max_date is %v - err: %v", minDate, maxDate, err)
+		summaryErrorResponse(ctx, err, "could not get incomes totals")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, totalsToResponse(totals))
+	ctx.Writer.Flush()
+}
+
+// GetCashFlow gets the incomes, expenses and net cash flow by period in a range of dates
+// ShowEntity godoc
+// @tags Summaries
+// @Summary Gets the cash flow by period on a range of dates.
+// @Description Endpoint to get the incomes, expenses and net cash flow (incomes minus expenses) by day, week or month
+// @Description on the provided range of dates. Weeks start on Monday.
+// @Description Without a reporting currency the cash flows of each currency are kept apart; with one, each value is converted to it
+// @Description at the exchange rate of its date.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param min_date query string true "The minimum date to consider"
+// @Param max_date query string true "The maximum date to consider"
+// @Param period query string false "day, week or month (default)"
+// @Param currency query string false "The reporting currency, such as EUR"
+// @Success 200 {object} []models.CashFlow
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/cash-flow/{min_date}/{max_date} [get]
+func (s *Summaries) GetCashFlow(ctx *gin.Context) {
+
+	minDate, maxDate, ok := datesRange(ctx)
+	if !ok {
+		return
+	}
+
+	period, err := summary.ParsePeriod(ctx.Query("period"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: summary.ErrInvalidPeriod.Error(),
+		})
+		return
+	}
+
+	cashFlows, err := s.Summarizer.CashFlow(ctx, auth.UserID(ctx), period, minDate, maxDate, ctx.Query("currency"))
+	if err != nil {
+		log.Printf("could not get cash flow - min_date is %v | max_date is %v - err: %v", minDate, maxDate, err)
+		summaryErrorResponse(ctx, err, "could not get cash flow")
+		return
+	}
+
+	response := []models.CashFlow{}
+	for _, cashFlow := range cashFlows {
+		response = append(response, models.CashFlow{
+			Period:   cashFlow.Period,
+			Currency: cashFlow.Currency,
+			Incomes:  cashFlow.Incomes,
+			Expenses: cashFlow.Expenses,
+			Net:      cashFlow.Net,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, response)
+	ctx.Writer.Flush()
+}
+
+// datesRange parses the min_date and max_date params, writing a bad request response if they are not valid
+func datesRange(ctx *gin.Context) (time.Time, time.Time, bool) {
+
+	paramMinDate := ctx.Param("min_date")
+	paramMaxDate := ctx.Param("max_date")
+
+	minDate, err := utils.DateStringToTime(paramMinDate)
+	if err != nil {
+		log.Printf("could not convert min date string to time - min date is %v - %v", paramMinDate, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not parse min date - must use YYYY-MM-DD date format",
+		})
+		return time.Time{}, time.Time{}, false
+	}
+
+	maxDate, err := utils.DateStringToTime(paramMaxDate)
+	if err != nil {
+		log.Printf("could not convert max date string to time - max date is %v - %v", paramMaxDate, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not parse max date - must use YYYY-MM-DD date format",
+		})
+		return time.Time{}, time.Time{}, false
+	}
+
+	return minDate, maxDate, true
+}
+
+// summaryErrorResponse writes a bad request response for an invalid grouping, period or currency, or a missing exchange rate,
+// and an internal server error response otherwise
+func summaryErrorResponse(ctx *gin.Context, err error, msg string) {
+
+	if errors.Is(err, summary.ErrInvalidGroupBy) || errors.Is(err, summary.ErrInvalidPeriod) ||
+		errors.Is(err, currency.ErrInvalidCurrency) || errors.Is(err, currency.ErrNoRate) {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		ErrorMsg: msg,
+	})
+}
+
+func totalsToResponse(totals []summary.Total) []models.Total {
+
+	response := []models.Total{}
+	for _, total := range totals {
+		response = append(response, models.Total{
+			Key:      total.Key,
+			Currency: total.Currency,
+			Value:    total.Value,
+			Count:    int(total.Count),
+		})
+	}
+
+	return response
+}
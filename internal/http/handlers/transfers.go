@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/transfers"
+	"github.com/rubengomes8/golang-personal-finances/internal/utils"
+)
+
+// Transfers handles the transfers http requests
+type Transfers struct {
+	Repository     repository.TransferRepo
+	CardRepository repository.CardRepo
+}
+
+// NewTransfers creates a new Transfers service
+func NewTransfers(transferRepo repository.TransferRepo, cardRepo repository.CardRepo) Transfers {
+	return Transfers{
+		Repository:     transferRepo,
+		CardRepository: cardRepo,
+	}
+}
+
+// CreateTransfer is used to create a new transfer.
+// ShowEntity godoc
+// @tags Transfers
+// @Summary Creates a new transfer between two cards.
+// @Description Endpoint to create a transfer. Transfers are neither expenses nor incomes, so they are left out of
+// @Description their totals and cash flow.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body models.Transfer true "Create transfer request"
+// @Success 201 {object} models.TransferCreateResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/transfer [post]
+func (t *Transfers) CreateTransfer(ctx *gin.Context) {
+
+	var transfer models.Transfer
+	err := json.NewDecoder(ctx.Request.Body).Decode(&transfer)
+	if err != nil {
+		log.Printf("could not decode create transfer body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode transfer",
+		})
+		return
+	}
+
+	transferRecord, err := t.toTransferRecord(ctx, auth.UserID(ctx), transfer)
+	if err != nil {
+		log.Printf("could not get transfer record: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: transferErrorMsg(err),
+		})
+		return
+	}
+
+	id, err := t.Repository.InsertTransfer(ctx, transferRecord)
+	if err != nil {
+		log.Printf("could not insert transfer: %v", err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not create transfer",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &models.TransferCreateResponse{
+		ID: int(id),
+	})
+	ctx.Writer.Flush()
+}
+
+// UpdateTransfer updates a transfer on the database.
+// ShowEntity godoc
+// @tags Transfers
+// @Summary Updates an existing transfer.
+// @Description Endpoint to update a transfer.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The transfer id"
+// @Param body body models.Transfer true "Update transfer request"
+// @Success 204 "No content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/transfer/{id} [put]
+func (t *Transfers) UpdateTransfer(ctx *gin.Context) {
+
+	var transfer models.Transfer
+	err := json.NewDecoder(ctx.Request.Body).Decode(&transfer)
+	if err != nil {
+		log.Printf("could not decode update transfer body: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not decode transfer",
+		})
+		return
+	}
+
+	paramID := ctx.Param("id")
+
+	transferID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting transfer id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	transferRecord, err := t.toTransferRecord(ctx, auth.UserID(ctx), transfer)
+	if err != nil {
+		log.Printf("could not get transfer record: %v", err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: transferErrorMsg(err),
+		})
+		return
+	}
+
+	transferRecord.ID = int64(transferID)
+
+	_, err = t.Repository.UpdateTransfer(ctx, transferRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "transfer with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not update transfer with param id = %v: %v", paramID, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not update transfer",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// GetTransferByID gets a transfer from the database that match the id provided.
+// ShowEntity godoc
+// @tags Transfers
+// @Summary Gets a transfer by its id.
+// @Description Endpoint to get a transfer by id.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The transfer id"
+// @Success 200 {object} models.Transfer
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /v1/transfer/{id} [get]
+func (t *Transfers) GetTransferByID(ctx *gin.Context) {
+
+	paramID := ctx.Param("id")
+
+	transferID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting transfer id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	transferView, err := t.Repository.GetTransferByID(ctx, auth.UserID(ctx), int64(transferID))
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "transfer with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not get transfer by id - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not get transfer",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transferViewToTransfer(transferView))
+	ctx.Writer.Flush()
+}
+
+// GetTransfersByDates gets the transfers from the database that match the dates' range provided.
+// ShowEntity godoc
+// @tags Transfers
+// @Summary Gets the transfers made on a range of dates.
+// @Description Endpoint to get the transfers made on the provided range of dates.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param min_date query string true "The minimum date to consider"
+// @Param max_date query string true "The maximum date to consider"
+// @Success 200 {object} []models.Transfer
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /v1/transfers/dates/{min_date}/{max_date} [get]
+func (t *Transfers) GetTransfersByDates(ctx *gin.Context) {
+
+	minDate, maxDate, ok := datesRange(ctx)
+	if !ok {
+		return
+	}
+
+	transferViews, err := t.Repository.GetTransfersByDates(ctx, auth.UserID(ctx), minDate, maxDate)
+	if err != nil {
+		log.Printf("could not get transfers by dates - min_date is %v | max_date is %v - err: %v", minDate, maxDate, err)
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			ErrorMsg: "could not get transfers by dates",
+		})
+		return
+	}
+
+	response := []models.Transfer{}
+	for _, transferView := range transferViews {
+		response = append(response, transferViewToTransfer(transferView))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+	ctx.Writer.Flush()
+}
+
+// DeleteTransfer deletes a transfer from the database that match the id provided.
+// ShowEntity godoc
+// @tags Transfers
+// @Summary Deletes a transfer by its id.
+// @Description Endpoint to delete a transfer by id.
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "The transfer id"
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /v1/transfer/{id} [delete]
+func (t *Transfers) DeleteTransfer(ctx *gin.Context) {
+
+	paramID := ctx.Param("id")
+
+	transferID, err := strconv.Atoi(paramID)
+	if err != nil {
+		log.Printf("error converting transfer id to int - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "id parameter must be an integer",
+		})
+		return
+	}
+
+	err = t.Repository.DeleteTransfer(ctx, auth.UserID(ctx), int64(transferID))
+	if errors.Is(err, repository.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			ErrorMsg: "transfer with this id does not exist",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("could not delete transfer with this id - param id is %v - %v", paramID, err)
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			ErrorMsg: "could not delete transfer",
+		})
+		return
+	}
+
+	ctx.Writer.WriteHeader(http.StatusNoContent)
+	ctx.Writer.Flush()
+}
+
+// toTransferRecord resolves the card names of a transfer and validates it
+func (t *Transfers) toTransferRecord(
+	ctx context.Context,
+	userID int64,
+	transfer models.Transfer,
+) (dbModels.TransferTable, error) {
+
+	date, err := utils.DateStringToTime(transfer.Date)
+	if err != nil {
+		return dbModels.TransferTable{}, fmt.Errorf("%w: could not parse date: %v", transfers.ErrInvalidTransfer, err)
+	}
+
+	fromCard, err := t.CardRepository.GetCardByName(ctx, userID, transfer.FromCard)
+	if err != nil {
+		return dbModels.TransferTable{}, fmt.Errorf("could not get source card by name: %v", err)
+	}
+
+	toCard, err := t.CardRepository.GetCardByName(ctx, userID, transfer.ToCard)
+	if err != nil {
+		return dbModels.TransferTable{}, fmt.Errorf("could not get destination card by name: %v", err)
+	}
+
+	transferRecord := dbModels.TransferTable{
+		Amount:      transfer.Amount,
+		Fee:         transfer.Fee,
+		Date:        date,
+		Description: transfer.Description,
+		FromCardID:  fromCard.ID,
+		ToCardID:    toCard.ID,
+		UserID:      userID,
+	}
+
+	err = transfers.Validate(transferRecord)
+	if err != nil {
+		return dbModels.TransferTable{}, err
+	}
+
+	return transferRecord, nil
+}
+
+func transferErrorMsg(err error) string {
+	if errors.Is(err, transfers.ErrInvalidTransfer) {
+		return "transfer must set a YYYY-MM-DD date, an amount > 0, a fee >= 0 and two different cards"
+	}
+	return "from_card or to_card does not exist"
+}
+
+func transferViewToTransfer(transferView dbModels.TransferView) models.Transfer {
+	return models.Transfer{
+		ID:          int(transferView.ID),
+		Amount:      transferView.Amount,
+		Fee:         transferView.Fee,
+		Date:        utils.TimeToStringDate(transferView.Date),
+		Description: transferView.Description,
+		FromCard:    transferView.FromCard,
+		ToCard:      transferView.ToCard,
+	}
+}
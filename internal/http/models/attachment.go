@@ -0,0 +1,13 @@
+package models
+
+// Attachment is the http attachment model: the metadata of a file kept for an expense or an income
+type Attachment struct {
+	ID          int    `json:"id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`     // in bytes
+	Checksum    string `json:"checksum"` // hex SHA-256 of the content
+	CreatedAt   string `json:"created_at"`
+	ExpenseID   int    `json:"expense_id,omitempty"`
+	IncomeID    int    `json:"income_id,omitempty"`
+}
@@ -12,7 +12,57 @@ type LoginInput struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// RefreshInput is the http refresh and logout model
+type RefreshInput struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// ChangePasswordInput is the http change password model
+type ChangePasswordInput struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// PasswordResetRequestInput is the http password reset request model
+type PasswordResetRequestInput struct {
+	Username string `json:"username" binding:"required"`
+}
+
+// PasswordResetInput is the http password reset model
+type PasswordResetInput struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// LoginTwoFactorInput is the http second login step model, of the users with 2FA enabled
+type LoginTwoFactorInput struct {
+	Challenge string `json:"challenge" binding:"required"`
+	Code      string `json:"code" binding:"required"`
+}
+
+// TwoFactorCodeInput is the http 2FA code model, a TOTP code or a recovery code
+type TwoFactorCodeInput struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// ChallengeResponse is the response model of the first login step of the users with 2FA enabled
+type ChallengeResponse struct {
+	Challenge string `json:"challenge"`
+}
+
+// TwoFactorEnrolmentResponse is the response model of a 2FA enrolment
+type TwoFactorEnrolmentResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// RecoveryCodesResponse is the response model of the recovery codes given when 2FA is enabled
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
 // TokenResponse is the response model for a token response
 type TokenResponse struct {
-	Token string `json:"token,omitempty"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
@@ -0,0 +1,57 @@
+package models
+
+import dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+
+// OpeningBalance is the http model of the balance of a card on its opening date
+type OpeningBalance struct {
+	OpeningBalance dbModels.Money `json:"opening_balance" swaggertype:"string" example:"1000.00"`
+	OpeningDate    string         `json:"opening_date,omitempty"` // Should be on this format YYYY-MM-DD, the balance counts every movement if missing
+}
+
+// Movement is the http model of an expense, income or side of a transfer of a card,
+// with the running balance of the card after it
+type Movement struct {
+	Kind        string         `json:"kind"` // expense, income, transfer_out or transfer_in
+	ID          int            `json:"id"`   // the id of the expense, income or transfer
+	Date        string         `json:"date"`
+	Amount      dbModels.Money `json:"amount" swaggertype:"string" example:"-12.30"` // in the currency of the card, positive in and negative out
+	Description string         `json:"description,omitempty"`
+	Cleared     bool           `json:"cleared"`
+	Balance     dbModels.Money `json:"balance" swaggertype:"string" example:"987.70"`
+}
+
+// Balance is the http model of the balance of a card as of a date
+type Balance struct {
+	Card           string         `json:"card"`
+	Currency       string         `json:"currency"`
+	Date           string         `json:"date"`
+	OpeningBalance dbModels.Money `json:"opening_balance" swaggertype:"string" example:"1000.00"`
+	OpeningDate    string         `json:"opening_date,omitempty"`
+	Balance        dbModels.Money `json:"balance" swaggertype:"string" example:"987.70"`
+	ClearedBalance dbModels.Money `json:"cleared_balance" swaggertype:"string" example:"1000.00"` // only counts the cleared movements
+	Movements      []Movement     `json:"movements"`
+}
+
+// MovementRef is the http model of a reference to a movement of a card
+type MovementRef struct {
+	Kind string `json:"kind"` // expense, income, transfer_out or transfer_in
+	ID   int    `json:"id"`
+}
+
+// ReconcileRequest is the http model of a bank statement balance to reconcile a card with
+type ReconcileRequest struct {
+	Date             string         `json:"date"` // Should be on this format YYYY-MM-DD
+	StatementBalance dbModels.Money `json:"statement_balance" swaggertype:"string" example:"987.70"`
+	Cleared          []MovementRef  `json:"cleared"` // movements on or before the date to mark as cleared
+}
+
+// Reconciliation is the http model of a bank statement balance checked against the cleared balance of a card
+type Reconciliation struct {
+	ID               int            `json:"id"`
+	Card             string         `json:"card"`
+	Date             string         `json:"date"`
+	StatementBalance dbModels.Money `json:"statement_balance" swaggertype:"string" example:"987.70"`
+	ClearedBalance   dbModels.Money `json:"cleared_balance" swaggertype:"string" example:"1000.00"`
+	Difference       dbModels.Money `json:"difference" swaggertype:"string" example:"-12.30"` // statement balance minus cleared balance
+	Uncleared        []Movement     `json:"uncleared,omitempty"`                              // only set when reconciling
+}
@@ -0,0 +1,34 @@
+package models
+
+import dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+
+// Budget is the http budget model: a monthly limit of the expenses of a category or of a subcategory
+type Budget struct {
+	ID          int            `json:"id,omitempty"`
+	Category    string         `json:"category,omitempty"`     // either a category
+	SubCategory string         `json:"sub_category,omitempty"` // or a subcategory
+	Limit       dbModels.Money `json:"limit" swaggertype:"string" example:"12.30"`
+	Rollover    bool           `json:"rollover,omitempty"`    // unspent amounts are carried to the next month
+	StartMonth  string         `json:"start_month,omitempty"` // Should be on this format YYYY-MM, defaults to the current month
+}
+
+// BudgetCreateResponse is the http create response model for budgets
+type BudgetCreateResponse struct {
+	ID int `json:"id,omitempty"`
+}
+
+// BudgetStatus is the http model of how a budget stands in a month
+type BudgetStatus struct {
+	Budget     Budget         `json:"budget"`
+	RolledOver dbModels.Money `json:"rolled_over" swaggertype:"string" example:"12.30"` // unspent amount carried from the previous months
+	Budgeted   dbModels.Money `json:"budgeted" swaggertype:"string" example:"12.30"`    // limit plus the rolled over amount
+	Spent      dbModels.Money `json:"spent" swaggertype:"string" example:"12.30"`
+	Remaining  dbModels.Money `json:"remaining" swaggertype:"string" example:"12.30"`
+	Overspent  bool           `json:"overspent"`
+}
+
+// BudgetsReport is the http response model of the budgets report of a month
+type BudgetsReport struct {
+	Month   string         `json:"month"` // YYYY-MM
+	Budgets []BudgetStatus `json:"budgets"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+
+// Card is the http card model. The opening balance and date are only set on create;
+// they are changed with the opening balance endpoint.
+type Card struct {
+	ID             int            `json:"id,omitempty"`
+	Name           string         `json:"name"`
+	Currency       string         `json:"currency,omitempty"` // ISO 4217 code, EUR if missing on create and kept if missing on update
+	OpeningBalance dbModels.Money `json:"opening_balance,omitempty" swaggertype:"string" example:"1000.00"`
+	OpeningDate    string         `json:"opening_date,omitempty"` // Should be on this format YYYY-MM-DD
+}
+
+// CardCreateResponse is the http create response model for cards
+type CardCreateResponse struct {
+	ID int `json:"id,omitempty"`
+}
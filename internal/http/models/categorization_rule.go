@@ -0,0 +1,36 @@
+package models
+
+import dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+
+// CategorizationRule is the http categorization rule model.
+// Expenses or incomes of the card, within the value range and with a description containing the pattern
+// (or matching it, as a regex) get the rule subcategory or category.
+type CategorizationRule struct {
+	ID          int            `json:"id,omitempty"`
+	Priority    int            `json:"priority,omitempty"`                                       // rules are tried by ascending priority
+	Description string         `json:"description,omitempty"`                                    // case insensitive substring, or regex, of the description
+	Regex       bool           `json:"regex,omitempty"`                                          // description is a regex
+	MinValue    dbModels.Money `json:"min_value,omitempty" swaggertype:"string" example:"12.30"` // no lower bound if missing
+	MaxValue    dbModels.Money `json:"max_value,omitempty" swaggertype:"string" example:"12.30"` // no upper bound if missing
+	Card        string         `json:"card,omitempty"`                                           // any card if missing
+	SubCategory string         `json:"sub_category,omitempty"`                                   // set on matching expenses
+	Category    string         `json:"category,omitempty"`                                       // set on matching incomes
+}
+
+// CategorizationRuleCreateResponse is the http create response model for categorization rules
+type CategorizationRuleCreateResponse struct {
+	ID int `json:"id,omitempty"`
+}
+
+// CategorizationRuleDryRunRequest is the http request model to test a categorization rule against existing rows
+type CategorizationRuleDryRunRequest struct {
+	Rule    CategorizationRule `json:"rule"`
+	MinDate string             `json:"min_date"` // Should be on this format YYYY-MM-DD
+	MaxDate string             `json:"max_date"` // Should be on this format YYYY-MM-DD
+}
+
+// CategorizationRuleDryRunResponse is the http response model with the existing rows a categorization rule matches
+type CategorizationRuleDryRunResponse struct {
+	Expenses []ExpenseCreateRequest `json:"expenses"`
+	Incomes  []Income               `json:"incomes"`
+}
@@ -0,0 +1,41 @@
+package models
+
+// ExpenseCategory is the http expense category model
+type ExpenseCategory struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// ExpenseSubCategory is the http expense subcategory model
+type ExpenseSubCategory struct {
+	ID       int    `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Category string `json:"category"` // the name of its expense category
+}
+
+// IncomeCategory is the http income category model
+type IncomeCategory struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// CategoryCreateResponse is the http create response model for expense categories, expense subcategories and income categories
+type CategoryCreateResponse struct {
+	ID int `json:"id,omitempty"`
+}
+
+// CategoryMergeRequest is the http request model to merge a category into another one of the same kind
+type CategoryMergeRequest struct {
+	Into   int  `json:"into"`    // the id of the category to merge into
+	DryRun bool `json:"dry_run"` // only count what would move
+}
+
+// CategoryMergeResponse is the http response model of a category merge, with how many rows moved, or would move on a dry run
+type CategoryMergeResponse struct {
+	DryRun                bool  `json:"dry_run"`
+	Transactions          int64 `json:"transactions"`
+	SubCategories         int64 `json:"sub_categories"`
+	Budgets               int64 `json:"budgets"`
+	Rules                 int64 `json:"rules"`
+	RecurringTransactions int64 `json:"recurring_transactions"`
+}
@@ -4,3 +4,9 @@ package models
 type ErrorResponse struct {
 	ErrorMsg string `json:"error,omitempty"`
 }
+
+// BatchErrorResponse is the error model for http batch responses, naming the item that failed
+type BatchErrorResponse struct {
+	ErrorMsg string `json:"error,omitempty"`
+	Index    int    `json:"index"`
+}
@@ -1,16 +1,51 @@
 package models
 
+import dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+
 // ExpenseCreateRequest is the http expense model
 type ExpenseCreateRequest struct {
-	ID          int     `json:"id,omitempty"`
-	Value       float64 `json:"value,omitempty"`
-	Date        string  `json:"date,omitempty"` // Should be on this format YYYY-MM-DD
-	SubCategory string  `json:"sub_category,omitempty"`
-	Card        string  `json:"card,omitempty"`
-	Description string  `json:"description,omitempty"`
+	ID          int            `json:"id,omitempty"`
+	Value       dbModels.Money `json:"value,omitempty" swaggertype:"string" example:"12.30"`
+	Date        string         `json:"date,omitempty"` // Should be on this format YYYY-MM-DD
+	SubCategory string         `json:"sub_category,omitempty"`
+	Card        string         `json:"card,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Currency    string         `json:"currency,omitempty"` // ISO 4217 code, such as EUR - defaults to the currency of the card
+	Force       bool           `json:"force,omitempty"`    // creates the expense even if it is a likely duplicate
+	Splits      []ExpenseSplit `json:"splits,omitempty"`   // lines adding up to the value - the expense takes the subcategory of the first one
+	Tags        []string       `json:"tags,omitempty"`     // single words, such as reimbursable - stored lower cased
+}
+
+// ExpenseSplit is the http model of a line of an expense split across several subcategories
+type ExpenseSplit struct {
+	Value       dbModels.Money `json:"value,omitempty" swaggertype:"string" example:"12.30"`
+	SubCategory string         `json:"sub_category,omitempty"`
+	Note        string         `json:"note,omitempty"`
 }
 
 // ExpenseCreateResponse is the http create response model for expense
 type ExpenseCreateResponse struct {
 	ID int `json:"id,omitempty"`
 }
+
+// ExpensesCreateRequest is the http batch create request model for expenses
+type ExpensesCreateRequest struct {
+	Expenses []ExpenseCreateRequest `json:"expenses"`
+}
+
+// ExpensesCreateResponse is the http batch create response model for expenses
+type ExpensesCreateResponse struct {
+	IDs []int `json:"ids"`
+}
+
+// ExpensesPage is the http response model of a page of an expenses search
+type ExpensesPage struct {
+	Expenses   []ExpenseCreateRequest `json:"expenses"`
+	NextCursor string                 `json:"next_cursor,omitempty"` // cursor of the next page, missing on the last page
+}
+
+// ExpenseDuplicatesResponse is the http conflict response model listing the likely duplicates of a new expense
+type ExpenseDuplicatesResponse struct {
+	ErrorMsg   string                 `json:"error,omitempty"`
+	Candidates []ExpenseCreateRequest `json:"candidates"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// ImportResponse is the http import response model, with the ids of the created expenses and incomes
+// and the bank references of the transactions skipped because they were already imported
+type ImportResponse struct {
+	ExpenseIDs        []int    `json:"expense_ids"`
+	IncomeIDs         []int    `json:"income_ids"`
+	SkippedReferences []string `json:"skipped_references"`
+}
+
+// ImportErrorResponse is the http import error model, naming the transaction that failed
+// and holding the ids of the expenses and incomes created before it
+type ImportErrorResponse struct {
+	ErrorMsg   string `json:"error,omitempty"`
+	Index      int    `json:"index"`
+	ExpenseIDs []int  `json:"expense_ids"`
+	IncomeIDs  []int  `json:"income_ids"`
+}
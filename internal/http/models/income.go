@@ -1,16 +1,43 @@
 package models
 
+import dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+
 // Income is the http expense model
 type Income struct {
-	ID          int     `json:"id,omitempty"`
-	Value       float64 `json:"value,omitempty"`
-	Date        string  `json:"date,omitempty"` // Should be on this format YYYY-MM-DD
-	Category    string  `json:"category,omitempty"`
-	Card        string  `json:"card,omitempty"`
-	Description string  `json:"description,omitempty"`
+	ID          int            `json:"id,omitempty"`
+	Value       dbModels.Money `json:"value,omitempty" swaggertype:"string" example:"12.30"`
+	Date        string         `json:"date,omitempty"` // Should be on this format YYYY-MM-DD
+	Category    string         `json:"category,omitempty"`
+	Card        string         `json:"card,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Currency    string         `json:"currency,omitempty"` // ISO 4217 code, such as EUR - defaults to the currency of the card
+	Force       bool           `json:"force,omitempty"`    // creates the income even if it is a likely duplicate
+	Tags        []string       `json:"tags,omitempty"`     // single words, such as bonus - stored lower cased
 }
 
 // IncomeCreateResponse is the http create response model for expense
 type IncomeCreateResponse struct {
 	ID int `json:"id,omitempty"`
 }
+
+// IncomesCreateRequest is the http batch create request model for incomes
+type IncomesCreateRequest struct {
+	Incomes []Income `json:"incomes"`
+}
+
+// IncomesCreateResponse is the http batch create response model for incomes
+type IncomesCreateResponse struct {
+	IDs []int `json:"ids"`
+}
+
+// IncomesPage is the http response model of a page of an incomes search
+type IncomesPage struct {
+	Incomes    []Income `json:"incomes"`
+	NextCursor string   `json:"next_cursor,omitempty"` // cursor of the next page, missing on the last page
+}
+
+// IncomeDuplicatesResponse is the http conflict response model listing the likely duplicates of a new income
+type IncomeDuplicatesResponse struct {
+	ErrorMsg   string   `json:"error,omitempty"`
+	Candidates []Income `json:"candidates"`
+}
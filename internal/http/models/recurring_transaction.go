@@ -0,0 +1,25 @@
+package models
+
+import dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+
+// RecurringTransaction is the http recurring transaction model: an expense (with a subcategory)
+// or an income (with a category) created on a schedule
+type RecurringTransaction struct {
+	ID          int            `json:"id,omitempty"`
+	Value       dbModels.Money `json:"value" swaggertype:"string" example:"12.30"`
+	Description string         `json:"description,omitempty"`
+	Frequency   string         `json:"frequency"`          // daily, weekly, monthly, yearly or cron
+	Cron        string         `json:"cron,omitempty"`     // five fields cron expression of cron schedules
+	StartDate   string         `json:"start_date"`         // Should be on this format YYYY-MM-DD
+	EndDate     string         `json:"end_date,omitempty"` // Should be on this format YYYY-MM-DD, never ends if missing
+	NextRun     string         `json:"next_run,omitempty"` // read only - date of the next occurrence to create
+	Card        string         `json:"card"`
+	SubCategory string         `json:"sub_category,omitempty"` // set to create expenses
+	Category    string         `json:"category,omitempty"`     // set to create incomes
+}
+
+// RecurringTransactionCreateResponse is the http create response model for recurring transactions
+type RecurringTransactionCreateResponse struct {
+	ID      int    `json:"id,omitempty"`
+	NextRun string `json:"next_run,omitempty"`
+}
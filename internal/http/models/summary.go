@@ -0,0 +1,20 @@
+package models
+
+import dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+
+// Total is the http model of the sum of the expenses or incomes of a group in a currency
+type Total struct {
+	Key      string         `json:"key"` // category, subcategory or card name, day or first day of the week (YYYY-MM-DD), or month (YYYY-MM)
+	Currency string         `json:"currency"`
+	Value    dbModels.Money `json:"value" swaggertype:"string" example:"12.30"`
+	Count    int            `json:"count"`
+}
+
+// CashFlow is the http model of the incomes, expenses and net cash flow of a period in a currency
+type CashFlow struct {
+	Period   string         `json:"period"` // day or first day of the week (YYYY-MM-DD), or month (YYYY-MM)
+	Currency string         `json:"currency"`
+	Incomes  dbModels.Money `json:"incomes" swaggertype:"string" example:"12.30"`
+	Expenses dbModels.Money `json:"expenses" swaggertype:"string" example:"12.30"`
+	Net      dbModels.Money `json:"net" swaggertype:"string" example:"12.30"` // incomes minus expenses
+}
@@ -0,0 +1,20 @@
+package models
+
+import dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+
+// Transfer is the http transfer model: money moved from a card to another one.
+// The amount is in the currency of the source card, which also pays the fee.
+type Transfer struct {
+	ID          int            `json:"id,omitempty"`
+	Amount      dbModels.Money `json:"amount" swaggertype:"string" example:"150.00"`
+	Fee         dbModels.Money `json:"fee,omitempty" swaggertype:"string" example:"0.50"`
+	Date        string         `json:"date"` // Should be on this format YYYY-MM-DD
+	Description string         `json:"description,omitempty"`
+	FromCard    string         `json:"from_card"`
+	ToCard      string         `json:"to_card"`
+}
+
+// TransferCreateResponse is the http create response model for transfers
+type TransferCreateResponse struct {
+	ID int `json:"id,omitempty"`
+}
@@ -1,7 +1,10 @@
 package routes
 
 import (
+	"fmt"
 	"net/http"
+	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rubengomes8/golang-personal-finances/internal/http/auth"
@@ -39,9 +42,29 @@ func SetupRouter(
 	expensesHandlers handlers.Expenses,
 	incomesHandlers handlers.Incomes,
 	authHandlers handlers.Auth,
-) *gin.Engine {
+	importsHandlers handlers.Imports,
+	rulesHandlers handlers.CategorizationRules,
+	budgetsHandlers handlers.Budgets,
+	recurringHandlers handlers.RecurringTransactions,
+	summariesHandlers handlers.Summaries,
+	transfersHandlers handlers.Transfers,
+	balancesHandlers handlers.Balances,
+	attachmentsHandlers handlers.Attachments,
+	cardsHandlers handlers.Cards,
+	expenseCategoriesHandlers handlers.ExpenseCategories,
+	expenseSubCategoriesHandlers handlers.ExpenseSubCategories,
+	incomeCategoriesHandlers handlers.IncomeCategories,
+	trustedProxies []string,
+) (*gin.Engine, error) {
 
 	r := gin.Default()
+
+	// the client ip of the login throttle is only taken from the X-Forwarded-For header of the trusted proxies
+	err := r.SetTrustedProxies(trustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("could not set trusted proxies: %v", err)
+	}
+
 	r.Handle(http.MethodGet, "/metrics", gin.WrapH(instrumentation.RegistryHandler()))
 
 	docs.SwaggerInfo.Title = "Finances API"
@@ -56,32 +79,145 @@ func SetupRouter(
 	{
 		authentication.POST("register/", authHandlers.Register)
 		authentication.POST("login/", authHandlers.Login)
+		authentication.POST("login/2fa/", authHandlers.LoginTwoFactor)
+		authentication.POST("refresh/", authHandlers.Refresh)
+		authentication.POST("logout/", authHandlers.Logout)
+		authentication.POST("password-reset/", authHandlers.RequestPasswordReset)
+		authentication.POST("password-reset/confirm/", authHandlers.ResetPassword)
 	}
+	r.GET("/.well-known/jwks.json", authHandlers.JWKS)
 
 	v1 := r.Group("/v1")
 	{
 		// Use authentication TODO: depending on environment, could be not set
-		v1.Use(auth.JwtAuthMiddleware())
+		v1.Use(auth.JwtAuthMiddleware(authHandlers.Sessions))
+		adminOnly := auth.AdminMiddleware(authHandlers.UserRepo)
+
+		// User
+		v1.PUT("user/password", authHandlers.ChangePassword)
+		v1.POST("user/2fa", authHandlers.EnrolTwoFactor)
+		v1.POST("user/2fa/verify", authHandlers.EnableTwoFactor)
+		v1.POST("user/2fa/disable", authHandlers.DisableTwoFactor)
 
 		// Expenses
 		v1.GET("expense/:id", expensesHandlers.GetExpenseByID)
 		v1.POST("expense", expensesHandlers.CreateExpense)
 		v1.PUT("expense/:id", expensesHandlers.UpdateExpense)
 		v1.DELETE("expense/:id", expensesHandlers.DeleteExpense)
+		v1.POST("expenses/batch", expensesHandlers.CreateExpenses)
 		v1.GET("expenses/dates/:min_date/:max_date", expensesHandlers.GetExpensesByDates)
 		v1.GET("expenses/category/:category", expensesHandlers.GetExpensesByCategory)
 		v1.GET("expenses/subcategory/:sub_category", expensesHandlers.GetExpensesBySubCategory)
 		v1.GET("expenses/card/:card", expensesHandlers.GetExpensesByCard)
+		v1.GET("expenses/search", expensesHandlers.SearchExpenses)
 
 		// Incomes
 		v1.GET("income/:id", incomesHandlers.HandleGetByID)
 		v1.POST("income", incomesHandlers.HandleCreateIncome)
 		v1.PUT("income/:id", incomesHandlers.HandleUpdateIncome)
 		v1.DELETE("income/:id", incomesHandlers.HandleDeleteIncome)
+		v1.POST("incomes/batch", incomesHandlers.HandleCreateIncomes)
 		v1.GET("incomes/category/:category", incomesHandlers.HandleGetIncomesByCategory)
 		v1.GET("incomes/card/:card", incomesHandlers.HandleGetIncomesByCard)
 		v1.GET("incomes/dates/:min_date/:max_date", incomesHandlers.HandleGetIncomesByDates)
+		v1.GET("incomes/search", incomesHandlers.HandleSearchIncomes)
+
+		// Imports
+		v1.POST("import/csv", importsHandlers.ImportCSV)
+		v1.POST("import/ofx", importsHandlers.ImportOFX)
+		v1.POST("import/camt053", importsHandlers.ImportCAMT053)
+
+		// Categorization rules
+		v1.GET("rule/:id", rulesHandlers.GetCategorizationRuleByID)
+		v1.POST("rule", rulesHandlers.CreateCategorizationRule)
+		v1.PUT("rule/:id", rulesHandlers.UpdateCategorizationRule)
+		v1.DELETE("rule/:id", rulesHandlers.DeleteCategorizationRule)
+		v1.GET("rules", rulesHandlers.GetCategorizationRules)
+		v1.POST("rules/dry-run", rulesHandlers.DryRunCategorizationRule)
+
+		// Budgets
+		v1.GET("budget/:id", budgetsHandlers.GetBudgetByID)
+		v1.POST("budget", budgetsHandlers.CreateBudget)
+		v1.PUT("budget/:id", budgetsHandlers.UpdateBudget)
+		v1.DELETE("budget/:id", budgetsHandlers.DeleteBudget)
+		v1.GET("budgets", budgetsHandlers.GetBudgets)
+		v1.GET("budgets/report/:month", budgetsHandlers.GetBudgetsReport)
+
+		// Recurring transactions
+		v1.GET("recurring-transaction/:id", recurringHandlers.GetRecurringTransactionByID)
+		v1.POST("recurring-transaction", recurringHandlers.CreateRecurringTransaction)
+		v1.PUT("recurring-transaction/:id", recurringHandlers.UpdateRecurringTransaction)
+		v1.DELETE("recurring-transaction/:id", recurringHandlers.DeleteRecurringTransaction)
+		v1.GET("recurring-transactions", recurringHandlers.GetRecurringTransactions)
+
+		// Totals and cash flow
+		v1.GET("expenses/totals/:min_date/:max_date", summariesHandlers.GetExpensesTotals)
+		v1.GET("incomes/totals/:min_date/:max_date", summariesHandlers.GetIncomesTotals)
+		v1.GET("cash-flow/:min_date/:max_date", summariesHandlers.GetCashFlow)
+
+		// Transfers
+		v1.GET("transfer/:id", transfersHandlers.GetTransferByID)
+		v1.POST("transfer", transfersHandlers.CreateTransfer)
+		v1.PUT("transfer/:id", transfersHandlers.UpdateTransfer)
+		v1.DELETE("transfer/:id", transfersHandlers.DeleteTransfer)
+		v1.GET("transfers/dates/:min_date/:max_date", transfersHandlers.GetTransfersByDates)
+
+		// Balances and reconciliations
+		v1.PUT("opening-balance/:card", balancesHandlers.SetOpeningBalance)
+		v1.GET("balance/:card/:date", balancesHandlers.GetBalance)
+		v1.POST("reconciliation/:card", balancesHandlers.Reconcile)
+		v1.GET("reconciliations/:card", balancesHandlers.GetReconciliations)
+
+		// Attachments
+		v1.POST("expense/:id/attachments", attachmentsHandlers.UploadExpenseAttachment)
+		v1.GET("expense/:id/attachments", attachmentsHandlers.GetExpenseAttachments)
+		v1.POST("income/:id/attachments", attachmentsHandlers.UploadIncomeAttachment)
+		v1.GET("income/:id/attachments", attachmentsHandlers.GetIncomeAttachments)
+		v1.GET("attachment/:id", attachmentsHandlers.DownloadAttachment)
+		v1.DELETE("attachment/:id", attachmentsHandlers.DeleteAttachment)
+
+		// Cards and categories
+		v1.GET("card/:id", cardsHandlers.GetCardByID)
+		v1.POST("card", cardsHandlers.CreateCard)
+		v1.PUT("card/:id", cardsHandlers.UpdateCard)
+		v1.DELETE("card/:id", cardsHandlers.DeleteCard)
+		v1.GET("cards", cardsHandlers.GetCards)
+
+		v1.GET("expense-category/:id", expenseCategoriesHandlers.GetExpenseCategoryByID)
+		v1.POST("expense-category", expenseCategoriesHandlers.CreateExpenseCategory)
+		v1.PUT("expense-category/:id", adminOnly, expenseCategoriesHandlers.UpdateExpenseCategory)
+		v1.DELETE("expense-category/:id", adminOnly, expenseCategoriesHandlers.DeleteExpenseCategory)
+		v1.POST("expense-category/:id/merge", adminOnly, expenseCategoriesHandlers.MergeExpenseCategory)
+		v1.GET("expense-categories", expenseCategoriesHandlers.GetExpenseCategories)
+
+		v1.GET("expense-subcategory/:id", expenseSubCategoriesHandlers.GetExpenseSubCategoryByID)
+		v1.POST("expense-subcategory", expenseSubCategoriesHandlers.CreateExpenseSubCategory)
+		v1.PUT("expense-subcategory/:id", adminOnly, expenseSubCategoriesHandlers.UpdateExpenseSubCategory)
+		v1.DELETE("expense-subcategory/:id", adminOnly, expenseSubCategoriesHandlers.DeleteExpenseSubCategory)
+		v1.POST("expense-subcategory/:id/merge", adminOnly, expenseSubCategoriesHandlers.MergeExpenseSubCategory)
+		v1.GET("expense-subcategories", expenseSubCategoriesHandlers.GetExpenseSubCategories)
+
+		v1.GET("income-category/:id", incomeCategoriesHandlers.GetIncomeCategoryByID)
+		v1.POST("income-category", incomeCategoriesHandlers.CreateIncomeCategory)
+		v1.PUT("income-category/:id", adminOnly, incomeCategoriesHandlers.UpdateIncomeCategory)
+		v1.DELETE("income-category/:id", adminOnly, incomeCategoriesHandlers.DeleteIncomeCategory)
+		v1.POST("income-category/:id/merge", adminOnly, incomeCategoriesHandlers.MergeIncomeCategory)
+		v1.GET("income-categories", incomeCategoriesHandlers.GetIncomeCategories)
+	}
+
+	return r, nil
+}
+
+// TrustedProxiesFromEnv reads the TRUSTED_PROXIES env variable, a comma separated list of the ips and CIDRs
+// of the reverse proxies in front of the server. No proxy is trusted when it is not set.
+func TrustedProxiesFromEnv() []string {
+
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
 	}
 
-	return r
+	return proxies
 }
@@ -0,0 +1,205 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const camtDateLayout = "2006-01-02"
+
+const (
+	camtDebit   = "DBIT"
+	camtCredit  = "CRDT"
+	camtPending = "PDNG"
+	camtInfo    = "INFO"
+)
+
+// camtEntry is a Ntry record. The struct tags have no namespace so every camt.053 version matches.
+type camtEntry struct {
+	Amount         string          `xml:"Amt"`
+	CreditDebit    string          `xml:"CdtDbtInd"`
+	Status         camtStatus      `xml:"Sts"`
+	BookingDate    camtDate        `xml:"BookgDt"`
+	ValueDate      camtDate        `xml:"ValDt"`
+	AcctSvcrRef    string          `xml:"AcctSvcrRef"`
+	AdditionalInfo string          `xml:"AddtlNtryInf"`
+	Details        []camtTxDetails `xml:"NtryDtls>TxDtls"`
+}
+
+// camtStatus is a plain code up to camt.053.001.07 and a Cd element since camt.053.001.08
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+type camtTxDetails struct {
+	AcctSvcrRef  string    `xml:"Refs>AcctSvcrRef"`
+	Unstructured []string  `xml:"RmtInf>Ustrd"`
+	Creditor     camtParty `xml:"RltdPties>Cdtr"`
+	Debtor       camtParty `xml:"RltdPties>Dbtr"`
+}
+
+// camtParty holds the name directly up to camt.053.001.07 and under Pty since camt.053.001.08
+type camtParty struct {
+	Name      string `xml:"Nm"`
+	PartyName string `xml:"Pty>Nm"`
+}
+
+// ParseCAMT053 parses an ISO 20022 camt.053 bank to customer statement.
+// Each booked Ntry record becomes a transaction referenced by its AcctSvcrRef, pending entries are skipped.
+func ParseCAMT053(r io.Reader) ([]Transaction, error) {
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return []Transaction{}, fmt.Errorf("could not read camt.053: %v", err)
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var transactions []Transaction
+	for {
+		offset := decoder.InputOffset()
+
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return []Transaction{}, fmt.Errorf("could not decode camt.053: %v", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "Ntry" {
+			continue
+		}
+
+		line := bytes.Count(data[:offset], []byte("\n")) + 1
+
+		var entry camtEntry
+		err = decoder.DecodeElement(&entry, &start)
+		if err != nil {
+			return []Transaction{}, ParseError{Line: line, Err: err}
+		}
+
+		status := strings.TrimSpace(entry.Status.Text)
+		if entry.Status.Code != "" {
+			status = entry.Status.Code
+		}
+		if status == camtPending || status == camtInfo {
+			continue
+		}
+
+		transaction, err := camtTransaction(entry)
+		if err != nil {
+			return []Transaction{}, ParseError{Line: line, Err: err}
+		}
+
+		if transaction.Value != 0 {
+			transactions = append(transactions, transaction)
+		}
+	}
+
+	return transactions, nil
+}
+
+func camtTransaction(entry camtEntry) (Transaction, error) {
+
+	date, err := entry.BookingDate.parse()
+	if err != nil {
+		date, err = entry.ValueDate.parse()
+		if err != nil {
+			return Transaction{}, err
+		}
+	}
+
+	amount := strings.TrimSpace(entry.Amount)
+	if amount == "" {
+		return Transaction{}, errEmptyValue
+	}
+
+	value, err := models.ParseMoney(amount)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("could not parse value %q: %v", amount, err)
+	}
+
+	switch entry.CreditDebit {
+	case camtDebit:
+		value = -value.Abs()
+	case camtCredit:
+		value = value.Abs()
+	default:
+		return Transaction{}, fmt.Errorf("unknown credit debit indicator %q", entry.CreditDebit)
+	}
+
+	reference := entry.AcctSvcrRef
+	if reference == "" && len(entry.Details) > 0 {
+		reference = entry.Details[0].AcctSvcrRef
+	}
+
+	return Transaction{
+		Date:        date,
+		Value:       value,
+		Description: entry.description(),
+		Reference:   strings.TrimSpace(reference),
+	}, nil
+}
+
+// description is the remittance information, falling back to the counterparty name and then to the entry information
+func (e camtEntry) description() string {
+
+	for _, details := range e.Details {
+		if len(details.Unstructured) > 0 {
+			return strings.TrimSpace(strings.Join(details.Unstructured, " "))
+		}
+	}
+
+	for _, details := range e.Details {
+		counterparty := details.Creditor
+		if e.CreditDebit == camtCredit {
+			counterparty = details.Debtor
+		}
+		if name := counterparty.name(); name != "" {
+			return name
+		}
+	}
+
+	return strings.TrimSpace(e.AdditionalInfo)
+}
+
+func (p camtParty) name() string {
+	if p.Name != "" {
+		return strings.TrimSpace(p.Name)
+	}
+	return strings.TrimSpace(p.PartyName)
+}
+
+func (d camtDate) parse() (time.Time, error) {
+
+	date := strings.TrimSpace(d.Date)
+	if date == "" {
+		date = strings.TrimSpace(d.DateTime)
+	}
+
+	if len(date) < len(camtDateLayout) {
+		return time.Time{}, fmt.Errorf("could not parse date %q", date)
+	}
+
+	parsed, err := time.Parse(camtDateLayout, date[:len(camtDateLayout)])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not parse date %q: %v", date, err)
+	}
+
+	return parsed, nil
+}
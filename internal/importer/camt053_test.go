@@ -0,0 +1,127 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	camt053v2Statement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Id>STMT-1</Id>
+      <Ntry>
+        <Amt Ccy="EUR">10.50</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2020-02-01</Dt></BookgDt>
+        <ValDt><Dt>2020-02-01</Dt></ValDt>
+        <AcctSvcrRef>REF-1</AcctSvcrRef>
+        <NtryDtls>
+          <TxDtls>
+            <RltdPties><Cdtr><Nm>Supermarket</Nm></Cdtr></RltdPties>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">1000.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><DtTm>2020-02-02T09:00:00+01:00</DtTm></BookgDt>
+        <NtryDtls>
+          <TxDtls>
+            <Refs><AcctSvcrRef>REF-2</AcctSvcrRef></Refs>
+            <RmtInf><Ustrd>Salary</Ustrd></RmtInf>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">5.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>PDNG</Sts>
+        <BookgDt><Dt>2020-02-03</Dt></BookgDt>
+        <AcctSvcrRef>REF-3</AcctSvcrRef>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+	camt053v8Statement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Ntry>
+        <Amt Ccy="EUR">10.50</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2020-02-01</Dt></BookgDt>
+        <AcctSvcrRef>REF-1</AcctSvcrRef>
+        <NtryDtls>
+          <TxDtls>
+            <RltdPties><Cdtr><Pty><Nm>Supermarket</Nm></Pty></Cdtr></RltdPties>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+)
+
+func TestParseCAMT053(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		document string
+		want     []Transaction
+		wantErr  error
+		wantLine int
+	}{
+		{
+			name:     "camt.053.001.02 skips pending entries",
+			document: camt053v2Statement,
+			want: []Transaction{
+				{Date: firstFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("-10.5"), Description: "Supermarket", Reference: "REF-1"},
+				{Date: secondFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("1000"), Description: "Salary", Reference: "REF-2"},
+			},
+		},
+		{
+			name:     "camt.053.001.08",
+			document: camt053v8Statement,
+			want: []Transaction{
+				{Date: firstFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("-10.5"), Description: "Supermarket", Reference: "REF-1"},
+			},
+		},
+		{
+			name: "Missing amount",
+			document: "<Document>\n<BkToCstmrStmt>\n<Stmt>\n" +
+				"<Ntry><CdtDbtInd>DBIT</CdtDbtInd><BookgDt><Dt>2020-02-01</Dt></BookgDt></Ntry>\n" +
+				"</Stmt>\n</BkToCstmrStmt>\n</Document>",
+			wantErr:  errEmptyValue,
+			wantLine: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			got, err := ParseCAMT053(strings.NewReader(tt.document))
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "ParseCAMT053() error = %v, wantErr %v", err, tt.wantErr)
+
+				var parseErr ParseError
+				assert.True(t, errors.As(err, &parseErr))
+				assert.Equal(t, tt.wantLine, parseErr.Line)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// ParseCSV parses a bank CSV export according to the provided profile.
+// Lines that do not move money are skipped.
+func ParseCSV(r io.Reader, profile Profile) ([]Transaction, error) {
+
+	err := profile.validate()
+	if err != nil {
+		return []Transaction{}, fmt.Errorf("invalid import profile %q: %v", profile.Name, err)
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = []rune(profile.Delimiter)[0]
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	var transactions []Transaction
+
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return []Transaction{}, fmt.Errorf("could not read csv: %v", err)
+		}
+
+		if row < profile.SkipRows {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		transaction, err := parseRecord(record, profile)
+		if err != nil {
+			return []Transaction{}, ParseError{Line: line, Err: err}
+		}
+
+		if transaction.Value == 0 {
+			continue
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+func parseRecord(record []string, profile Profile) (Transaction, error) {
+
+	dateField, err := column(record, profile.DateColumn)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	date, err := time.Parse(profile.DateLayout, dateField)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("could not parse date %q: %v", dateField, err)
+	}
+
+	description, err := column(record, profile.DescriptionColumn)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	value, err := parseSignedValue(record, profile)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	return Transaction{
+		Date:        time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		Value:       value,
+		Description: description,
+	}, nil
+}
+
+func parseSignedValue(record []string, profile Profile) (models.Money, error) {
+
+	if profile.SignConvention != DebitCreditColumns {
+
+		field, err := column(record, profile.ValueColumn)
+		if err != nil {
+			return 0, err
+		}
+
+		value, err := parseValue(field, profile.DecimalComma)
+		if err != nil {
+			return 0, err
+		}
+
+		if profile.SignConvention == PositiveDebits {
+			return -value, nil
+		}
+		return value, nil
+	}
+
+	debitField, err := column(record, profile.DebitColumn)
+	if err != nil {
+		return 0, err
+	}
+
+	creditField, err := column(record, profile.CreditColumn)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case debitField != "" && creditField == "":
+		debit, err := parseValue(debitField, profile.DecimalComma)
+		if err != nil {
+			return 0, err
+		}
+		return -debit.Abs(), nil
+	case debitField == "" && creditField != "":
+		credit, err := parseValue(creditField, profile.DecimalComma)
+		if err != nil {
+			return 0, err
+		}
+		return credit.Abs(), nil
+	default:
+		return 0, errDebitAndCreditBothFilledOrNot
+	}
+}
+
+// parseValue parses an amount, dropping the thousands separators
+func parseValue(field string, decimalComma bool) (models.Money, error) {
+
+	field = strings.ReplaceAll(field, " ", "")
+	if decimalComma {
+		field = strings.ReplaceAll(field, ".", "")
+		field = strings.ReplaceAll(field, ",", ".")
+	} else {
+		field = strings.ReplaceAll(field, ",", "")
+	}
+
+	if field == "" {
+		return 0, errEmptyValue
+	}
+
+	value, err := models.ParseMoney(field)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse value %q: %v", field, err)
+	}
+
+	return value, nil
+}
+
+func column(record []string, idx int) (string, error) {
+
+	if idx < 0 || idx >= len(record) {
+		return "", fmt.Errorf("%w %d", errMissingColumn, idx)
+	}
+
+	return strings.TrimSpace(record[idx]), nil
+}
@@ -0,0 +1,161 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	firstFebruary2020ZeroHoursUTCTime  = time.Date(2020, time.Month(2), 1, 0, 0, 0, 0, time.UTC)
+	secondFebruary2020ZeroHoursUTCTime = time.Date(2020, time.Month(2), 2, 0, 0, 0, 0, time.UTC)
+)
+
+func TestParseCSV(t *testing.T) {
+
+	profiles := DefaultProfiles()
+
+	tests := []struct {
+		name    string
+		profile string
+		csv     string
+		want    []Transaction
+		wantErr error
+	}{
+		{
+			name:    "Generic profile",
+			profile: "generic",
+			csv: "date,description,value\n" +
+				"2020-02-01,Supermarket,\"-1,234.50\"\n" +
+				"2020-02-02,Salary,1000\n",
+			want: []Transaction{
+				{Date: firstFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("-1234.5"), Description: "Supermarket"},
+				{Date: secondFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("1000"), Description: "Salary"},
+			},
+		},
+		{
+			name:    "CGD profile with decimal comma and debit credit columns",
+			profile: "cgd",
+			csv: "Data mov.;Data valor;Descrição;Débito;Crédito;Saldo\n" +
+				"01-02-2020;01-02-2020;Supermarket;1.234,50;;100,00\n" +
+				"02-02-2020;02-02-2020;Salary;;1.000,00;1.100,00\n",
+			want: []Transaction{
+				{Date: firstFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("-1234.5"), Description: "Supermarket"},
+				{Date: secondFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("1000"), Description: "Salary"},
+			},
+		},
+		{
+			name:    "Revolut profile skips zero values",
+			profile: "revolut",
+			csv: "Type,Product,Started Date,Completed Date,Description,Amount,Fee,Currency,State,Balance\n" +
+				"CARD_PAYMENT,Current,2020-02-01 10:00:00,2020-02-01 11:00:00,Uber,-5.20,0.00,EUR,COMPLETED,10\n" +
+				"CARD_PAYMENT,Current,2020-02-01 12:00:00,2020-02-01 12:00:00,Declined,0.00,0.00,EUR,DECLINED,10\n",
+			want: []Transaction{
+				{Date: firstFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("-5.2"), Description: "Uber"},
+			},
+		},
+		{
+			name:    "Missing column",
+			profile: "generic",
+			csv: "date,description,value\n" +
+				"2020-02-01,Supermarket\n",
+			wantErr: errMissingColumn,
+		},
+		{
+			name:    "Debit and credit both filled",
+			profile: "cgd",
+			csv: "header\n" +
+				"01-02-2020;01-02-2020;Supermarket;1,00;2,00;100,00\n",
+			wantErr: errDebitAndCreditBothFilledOrNot,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			profile, err := profiles.Get(tt.profile)
+			assert.NoError(t, err)
+
+			got, err := ParseCSV(strings.NewReader(tt.csv), profile)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "ParseCSV() error = %v, wantErr %v", err, tt.wantErr)
+
+				var parseErr ParseError
+				assert.True(t, errors.As(err, &parseErr))
+				assert.Equal(t, 2, parseErr.Line)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseCSVPositiveDebits(t *testing.T) {
+
+	profile := Profile{
+		Name:              "credit card",
+		Delimiter:         ";",
+		DateLayout:        "2006-01-02",
+		DateColumn:        0,
+		DescriptionColumn: 1,
+		ValueColumn:       2,
+		SignConvention:    PositiveDebits,
+	}
+
+	got, err := ParseCSV(strings.NewReader("2020-02-01;Books;12.5\n2020-02-02;Refund;-3\n"), profile)
+	assert.NoError(t, err)
+	assert.Equal(t, []Transaction{
+		{Date: firstFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("-12.5"), Description: "Books"},
+		{Date: secondFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("3"), Description: "Refund"},
+	}, got)
+}
+
+func TestLoadProfiles(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "Valid profile",
+			json: `[{"name":"mybank","delimiter":";","decimal_comma":true,"date_layout":"02/01/2006",` +
+				`"date_column":0,"description_column":1,"value_column":2,"sign_convention":"negative_debits"}]`,
+			wantErr: false,
+		},
+		{
+			name:    "Unknown sign convention",
+			json:    `[{"name":"mybank","delimiter":";","date_layout":"02/01/2006","sign_convention":"unknown"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid delimiter",
+			json:    `[{"name":"mybank","delimiter":";;","date_layout":"02/01/2006","sign_convention":"negative_debits"}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			profiles, err := LoadProfiles(strings.NewReader(tt.json))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			profile, err := profiles.Get("mybank")
+			assert.NoError(t, err)
+			assert.True(t, profile.DecimalComma)
+
+			_, err = profiles.Get("generic")
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownProfile                = errors.New("unknown import profile")
+	ErrCardNotFoundByName            = errors.New("could not get card by name")
+	ErrSubCategoryNotFoundByName     = errors.New("could not get expense subcategory by name")
+	ErrIncomeCategoryNotFoundByName  = errors.New("could not get income category by name")
+	ErrCouldNotInsertExpense         = errors.New("could not insert expense")
+	ErrCouldNotInsertIncome          = errors.New("could not insert income")
+	ErrCouldNotCheckReference        = errors.New("could not check if transaction was already imported")
+	ErrCouldNotGetRules              = errors.New("could not get categorization rules")
+	ErrNoMatchingRule                = errors.New("no categorization rule matches the transaction")
+	ErrNoTransactions                = errors.New("there are no transactions to import")
+	errMissingColumn                 = errors.New("missing column")
+	errEmptyValue                    = errors.New("empty value")
+	errDebitAndCreditBothFilledOrNot = errors.New("exactly one of debit and credit must be filled")
+)
+
+// ParseError is returned when a statement line can not be parsed
+type ParseError struct {
+	Line int
+	Err  error
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
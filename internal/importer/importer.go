@@ -0,0 +1,234 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/categorization"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// descriptionMaxLength is the size of the description column of the expenses and incomes tables
+const descriptionMaxLength = 50
+
+// Importer creates expenses and incomes out of bank statement transactions
+type Importer struct {
+	expenseRepo        repository.ExpenseRepo
+	incomeRepo         repository.IncomeRepo
+	cardRepo           repository.CardRepo
+	subCategoryRepo    repository.ExpenseSubCategoryRepo
+	incomeCategoryRepo repository.IncomeCategoryRepo
+	categorizer        categorization.Categorizer
+}
+
+// NewImporter creates a new Importer
+func NewImporter(
+	expenseRepo repository.ExpenseRepo,
+	incomeRepo repository.IncomeRepo,
+	cardRepo repository.CardRepo,
+	subCategoryRepo repository.ExpenseSubCategoryRepo,
+	incomeCategoryRepo repository.IncomeCategoryRepo,
+	categorizer categorization.Categorizer,
+) Importer {
+	return Importer{
+		expenseRepo:        expenseRepo,
+		incomeRepo:         incomeRepo,
+		cardRepo:           cardRepo,
+		subCategoryRepo:    subCategoryRepo,
+		incomeCategoryRepo: incomeCategoryRepo,
+		categorizer:        categorizer,
+	}
+}
+
+// Target names where imported transactions go: debits become expenses of the subcategory
+// and credits become incomes of the income category, all of them on the card.
+// Without a subcategory or income category, each transaction is categorized by the categorization rules.
+type Target struct {
+	Card           string
+	SubCategory    string
+	IncomeCategory string
+}
+
+// Result holds the ids of the rows created by an import
+// and the references of the transactions skipped because they were already imported
+type Result struct {
+	ExpenseIDs        []int64
+	IncomeIDs         []int64
+	SkippedReferences []string
+}
+
+// Import creates an expense for each debit and an income for each credit, owned by the user with the provided id.
+// Transactions with a reference already stored on the card are skipped, so overlapping statements can be re-imported.
+// Every transaction is categorized before anything is written, so a transaction no categorization rule matches fails
+// the import with nothing created. The expenses are then inserted in one transaction and the incomes in another one.
+// On failure a repository.BatchItemError names the failing transaction, and the result holds the rows created before it:
+// the expenses, when the incomes could not be inserted.
+func (i Importer) Import(ctx context.Context, userID int64, transactions []Transaction, target Target) (Result, error) {
+
+	result := Result{ExpenseIDs: []int64{}, IncomeIDs: []int64{}, SkippedReferences: []string{}}
+
+	if len(transactions) == 0 {
+		return result, ErrNoTransactions
+	}
+
+	card, err := i.cardRepo.GetCardByName(ctx, userID, target.Card)
+	if err != nil {
+		log.Printf("could not get card by name: %v", err)
+		return result, ErrCardNotFoundByName
+	}
+
+	var subCategory models.ExpenseSubCategoryTable
+	var incomeCategory models.IncomeCategoryTable
+	for _, transaction := range transactions {
+		if transaction.IsDebit() && subCategory.ID == 0 && target.SubCategory != "" {
+			subCategory, err = i.subCategoryRepo.GetExpenseSubCategoryByName(ctx, target.SubCategory)
+			if err != nil {
+				log.Printf("could not get expense subcategory by name: %v", err)
+				return result, ErrSubCategoryNotFoundByName
+			}
+		}
+		if !transaction.IsDebit() && incomeCategory.ID == 0 && target.IncomeCategory != "" {
+			incomeCategory, err = i.incomeCategoryRepo.GetIncomeCategoryByName(ctx, target.IncomeCategory)
+			if err != nil {
+				log.Printf("could not get income category by name: %v", err)
+				return result, ErrIncomeCategoryNotFoundByName
+			}
+		}
+	}
+
+	rules := []models.CategorizationRuleView{}
+	if target.SubCategory == "" || target.IncomeCategory == "" {
+		rules, err = i.categorizer.Rules(ctx, userID)
+		if err != nil {
+			log.Printf("could not get categorization rules: %v", err)
+			return result, ErrCouldNotGetRules
+		}
+	}
+
+	// the expenses and incomes to insert, with the index of their transaction
+	var expenses []models.ExpenseTable
+	var incomes []models.IncomeTable
+	var expenseIndexes, incomeIndexes []int
+
+	seenReferences := map[string]bool{}
+	for idx, transaction := range transactions {
+
+		if transaction.Reference != "" {
+			imported, err := i.isImported(ctx, userID, card.ID, transaction)
+			if err != nil {
+				log.Printf("could not check if transaction %d was imported: %v", idx, err)
+				return result, repository.BatchItemError{Index: idx, Err: ErrCouldNotCheckReference}
+			}
+			if imported || seenReferences[transaction.Reference] {
+				result.SkippedReferences = append(result.SkippedReferences, transaction.Reference)
+				continue
+			}
+			seenReferences[transaction.Reference] = true
+		}
+
+		if transaction.IsDebit() {
+			subCategoryID, ok := subCategory.ID, true
+			if subCategoryID == 0 {
+				subCategoryID, ok = categorization.ExpenseSubCategoryID(rules, card.ID, -transaction.Value, transaction.Description)
+			}
+			if !ok {
+				return result, repository.BatchItemError{Index: idx, Err: ErrNoMatchingRule}
+			}
+
+			expenses = append(expenses, models.ExpenseTable{
+				Value:             -transaction.Value,
+				Date:              transaction.Date,
+				SubCategoryID:     subCategoryID,
+				CardID:            card.ID,
+				Description:       truncate(transaction.Description),
+				ExternalReference: transaction.Reference,
+				UserID:            userID,
+			})
+			expenseIndexes = append(expenseIndexes, idx)
+			continue
+		}
+
+		incomeCategoryID, ok := incomeCategory.ID, true
+		if incomeCategoryID == 0 {
+			incomeCategoryID, ok = categorization.IncomeCategoryID(rules, card.ID, transaction.Value, transaction.Description)
+		}
+		if !ok {
+			return result, repository.BatchItemError{Index: idx, Err: ErrNoMatchingRule}
+		}
+
+		incomes = append(incomes, models.IncomeTable{
+			Value:             transaction.Value,
+			Date:              transaction.Date,
+			CategoryID:        incomeCategoryID,
+			CardID:            card.ID,
+			Description:       truncate(transaction.Description),
+			ExternalReference: transaction.Reference,
+			UserID:            userID,
+		})
+		incomeIndexes = append(incomeIndexes, idx)
+	}
+
+	if len(expenses) > 0 {
+		ids, err := i.expenseRepo.InsertExpenses(ctx, expenses)
+		if err != nil {
+			log.Printf("could not insert imported expenses: %v", err)
+			return result, batchInsertError(err, expenseIndexes, ErrCouldNotInsertExpense)
+		}
+		result.ExpenseIDs = ids
+	}
+
+	if len(incomes) > 0 {
+		ids, err := i.incomeRepo.InsertIncomes(ctx, incomes)
+		if err != nil {
+			log.Printf("could not insert imported incomes: %v", err)
+			return result, batchInsertError(err, incomeIndexes, ErrCouldNotInsertIncome)
+		}
+		result.IncomeIDs = ids
+	}
+
+	return result, nil
+}
+
+// batchInsertError points the error of a batch insert to the failing transaction, out of the transaction indexes
+// of the batch items. A failure of the whole batch, such as on commit, points to its first transaction.
+func batchInsertError(err error, indexes []int, insertErr error) error {
+
+	index := indexes[0]
+
+	var batchErr repository.BatchItemError
+	if errors.As(err, &batchErr) && batchErr.Index >= 0 && batchErr.Index < len(indexes) {
+		index = indexes[batchErr.Index]
+	}
+
+	return repository.BatchItemError{Index: index, Err: insertErr}
+}
+
+// isImported tells if the transaction reference is already stored on an expense or income of the card
+func (i Importer) isImported(ctx context.Context, userID int64, cardID int64, transaction Transaction) (bool, error) {
+
+	var err error
+	if transaction.IsDebit() {
+		_, err = i.expenseRepo.GetExpenseByExternalReference(ctx, userID, cardID, transaction.Reference)
+	} else {
+		_, err = i.incomeRepo.GetIncomeByExternalReference(ctx, userID, cardID, transaction.Reference)
+	}
+
+	if errors.Is(err, repository.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func truncate(description string) string {
+	runes := []rune(description)
+	if len(runes) > descriptionMaxLength {
+		return string(runes[:descriptionMaxLength])
+	}
+	return description
+}
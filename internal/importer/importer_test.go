@@ -0,0 +1,178 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/categorization"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/mock"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImporter_Import(t *testing.T) {
+
+	cardsCache := cache.NewCard([]models.CardTable{mock.IncomeSalaryCard})
+	categoriesCache := cache.NewExpenseCategory([]models.ExpenseCategoryTable{{ID: 1, Name: "House"}})
+	subCategoriesCache := cache.NewExpenseSubCategory([]models.ExpenseSubCategoryTable{{ID: 1, Name: "Supermarket", CategoryID: 1}})
+
+	supermarket := Transaction{Date: firstFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("-10.5"), Description: "Supermarket"}
+	salary := Transaction{Date: secondFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("1000"), Description: "Mock"}
+	importedSupermarket := models.ExpenseTable{
+		ID:                1,
+		Value:             models.MustParseMoney("10.5"),
+		Date:              firstFebruary2020ZeroHoursUTCTime,
+		SubCategoryID:     1,
+		CardID:            mock.IncomeSalaryCard.ID,
+		Description:       "Supermarket",
+		ExternalReference: "FIT-1",
+	}
+	referencedSupermarket := Transaction{Date: supermarket.Date, Value: supermarket.Value, Description: supermarket.Description, Reference: "FIT-1"}
+	referencedBooks := Transaction{Date: supermarket.Date, Value: models.MustParseMoney("-12"), Description: "Books", Reference: "FIT-3"}
+	referencedSalary := Transaction{Date: salary.Date, Value: salary.Value, Description: salary.Description, Reference: mock.IncomeSalaryExternalReference}
+
+	target := Target{Card: mock.IncomeSalaryCard.Name, SubCategory: "Supermarket", IncomeCategory: mock.IncomeSalaryCategoryName}
+	rules := []models.CategorizationRuleView{
+		{ID: 1, DescriptionPattern: "supermarket", SubCategoryID: 1},
+		{ID: 2, DescriptionPattern: "^Mock$", DescriptionRegex: true, IncomeCategoryID: mock.IncomeSalaryCategory.ID},
+	}
+
+	tests := []struct {
+		name         string
+		expenses     []models.ExpenseTable
+		rules        []models.CategorizationRuleView
+		transactions []Transaction
+		target       Target
+		want         Result
+		wantErr      error
+		wantIndex    int
+		notImported  []string
+	}{
+		{
+			name:         "Debits and credits",
+			transactions: []Transaction{supermarket, salary},
+			target:       target,
+			want:         Result{ExpenseIDs: []int64{1}, IncomeIDs: []int64{1}, SkippedReferences: []string{}},
+		},
+		{
+			name:         "Only debits do not need an income category",
+			transactions: []Transaction{supermarket},
+			target:       Target{Card: target.Card, SubCategory: target.SubCategory},
+			want:         Result{ExpenseIDs: []int64{1}, IncomeIDs: []int64{}, SkippedReferences: []string{}},
+		},
+		{
+			name:         "Categories missing on the target come from the categorization rules",
+			rules:        rules,
+			transactions: []Transaction{supermarket, salary},
+			target:       Target{Card: target.Card},
+			want:         Result{ExpenseIDs: []int64{1}, IncomeIDs: []int64{1}, SkippedReferences: []string{}},
+		},
+		{
+			name:         "No matching categorization rule",
+			rules:        rules,
+			transactions: []Transaction{supermarket, referencedBooks},
+			target:       Target{Card: target.Card},
+			want:         Result{ExpenseIDs: []int64{}, IncomeIDs: []int64{}, SkippedReferences: []string{}},
+			wantErr:      ErrNoMatchingRule,
+			wantIndex:    1,
+		},
+		{
+			name:         "A transaction failing in the middle of the statement creates nothing",
+			rules:        rules,
+			transactions: []Transaction{referencedSupermarket, referencedBooks, referencedSalary},
+			target:       Target{Card: target.Card},
+			want:         Result{ExpenseIDs: []int64{}, IncomeIDs: []int64{}, SkippedReferences: []string{}},
+			wantErr:      ErrNoMatchingRule,
+			wantIndex:    1,
+			notImported:  []string{"FIT-1"},
+		},
+		{
+			name:         "Already imported references are skipped",
+			expenses:     []models.ExpenseTable{importedSupermarket},
+			transactions: []Transaction{referencedSupermarket, referencedSalary, referencedBooks},
+			target:       target,
+			want: Result{
+				ExpenseIDs:        []int64{1},
+				IncomeIDs:         []int64{},
+				SkippedReferences: []string{"FIT-1", mock.IncomeSalaryExternalReference},
+			},
+		},
+		{
+			name:         "References repeated on the statement are imported once",
+			transactions: []Transaction{referencedBooks, referencedBooks},
+			target:       target,
+			want:         Result{ExpenseIDs: []int64{1}, IncomeIDs: []int64{}, SkippedReferences: []string{"FIT-3"}},
+		},
+		{
+			name:         "No transactions",
+			transactions: []Transaction{},
+			target:       target,
+			wantErr:      ErrNoTransactions,
+		},
+		{
+			name:         "Unknown card",
+			transactions: []Transaction{supermarket},
+			target:       Target{Card: "Unknown", SubCategory: target.SubCategory},
+			wantErr:      ErrCardNotFoundByName,
+		},
+		{
+			name:         "Unknown subcategory",
+			transactions: []Transaction{supermarket},
+			target:       Target{Card: target.Card, SubCategory: "Unknown"},
+			wantErr:      ErrSubCategoryNotFoundByName,
+		},
+		{
+			name:         "Unknown income category",
+			transactions: []Transaction{salary},
+			target:       Target{Card: target.Card, IncomeCategory: "Unknown"},
+			wantErr:      ErrIncomeCategoryNotFoundByName,
+		},
+		{
+			name:         "Income insert fails",
+			transactions: []Transaction{supermarket, {Date: salary.Date, Value: salary.Value, Description: "Fails"}},
+			target:       target,
+			want:         Result{ExpenseIDs: []int64{1}, IncomeIDs: []int64{}, SkippedReferences: []string{}},
+			wantErr:      ErrCouldNotInsertIncome,
+			wantIndex:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			expensesCache := cache.NewExpense(tt.expenses, cardsCache, categoriesCache, subCategoriesCache)
+			rulesCache := cache.NewCategorizationRule(tt.rules)
+			importer := NewImporter(
+				&expensesCache,
+				mock.NewIncome(),
+				cardsCache,
+				&subCategoriesCache,
+				mock.NewIncomeCategory(),
+				categorization.NewCategorizer(&rulesCache),
+			)
+
+			got, err := importer.Import(context.Background(), 0, tt.transactions, tt.target)
+
+			for _, reference := range tt.notImported {
+				_, err := expensesCache.GetExpenseByExternalReference(context.Background(), 0, mock.IncomeSalaryCard.ID, reference)
+				assert.ErrorIs(t, err, repository.ErrNotFound, "expense %s was imported", reference)
+			}
+
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "Import() error = %v, wantErr %v", err, tt.wantErr)
+
+				var batchErr repository.BatchItemError
+				if errors.As(err, &batchErr) {
+					assert.Equal(t, tt.wantIndex, batchErr.Index)
+					assert.Equal(t, tt.want, got)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const ofxDateLayout = "20060102"
+
+var errNotOFX = errors.New("not an ofx document")
+
+// ParseOFX parses an OFX 1.x (SGML) or 2.x (XML) bank or credit card statement.
+// Each STMTTRN record becomes a transaction referenced by its FITID.
+func ParseOFX(r io.Reader) ([]Transaction, error) {
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return []Transaction{}, fmt.Errorf("could not read ofx: %v", err)
+	}
+
+	document := string(data)
+	offset := strings.Index(strings.ToUpper(document), "<OFX>")
+	if offset < 0 {
+		return []Transaction{}, errNotOFX
+	}
+
+	var transactions []Transaction
+	var fields map[string]string
+	var line int
+
+	// OFX 1.x leaves the leaf elements unclosed, so the document is read as a flat sequence of tags and texts
+	for {
+		tagStart := strings.IndexByte(document[offset:], '<')
+		if tagStart < 0 {
+			break
+		}
+		tagStart += offset
+
+		tagEnd := strings.IndexByte(document[tagStart:], '>')
+		if tagEnd < 0 {
+			break
+		}
+		tagEnd += tagStart
+
+		tag := strings.ToUpper(strings.TrimSpace(document[tagStart+1 : tagEnd]))
+
+		offset = tagEnd + 1
+		textEnd := strings.IndexByte(document[offset:], '<')
+		if textEnd < 0 {
+			textEnd = len(document) - offset
+		}
+		text := strings.TrimSpace(document[offset : offset+textEnd])
+
+		switch {
+		case tag == "STMTTRN":
+			fields = map[string]string{}
+			line = strings.Count(document[:tagStart], "\n") + 1
+		case tag == "/STMTTRN" && fields != nil:
+			transaction, err := ofxTransaction(fields)
+			if err != nil {
+				return []Transaction{}, ParseError{Line: line, Err: err}
+			}
+			if transaction.Value != 0 {
+				transactions = append(transactions, transaction)
+			}
+			fields = nil
+		case fields != nil && !strings.HasPrefix(tag, "/") && text != "":
+			fields[tag] = html.UnescapeString(text)
+		}
+	}
+
+	return transactions, nil
+}
+
+func ofxTransaction(fields map[string]string) (Transaction, error) {
+
+	posted := fields["DTPOSTED"]
+	if len(posted) < len(ofxDateLayout) {
+		return Transaction{}, fmt.Errorf("could not parse date %q", posted)
+	}
+
+	date, err := time.Parse(ofxDateLayout, posted[:len(ofxDateLayout)])
+	if err != nil {
+		return Transaction{}, fmt.Errorf("could not parse date %q: %v", posted, err)
+	}
+
+	amount := strings.ReplaceAll(fields["TRNAMT"], ",", ".")
+	if amount == "" {
+		return Transaction{}, errEmptyValue
+	}
+
+	value, err := models.ParseMoney(amount)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("could not parse value %q: %v", amount, err)
+	}
+
+	description := fields["NAME"]
+	if description == "" {
+		description = fields["MEMO"]
+	}
+
+	return Transaction{
+		Date:        date,
+		Value:       value,
+		Description: description,
+		Reference:   fields["FITID"],
+	}, nil
+}
@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ofx1Statement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<STMTRS>
+<CURDEF>EUR
+<BANKACCTFROM>
+<BANKID>0035
+<ACCTID>123456789
+<ACCTTYPE>CHECKING
+</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20200201120000[0:GMT]
+<TRNAMT>-10.50
+<FITID>FIT-1
+<NAME>Supermarket
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20200202
+<TRNAMT>1000,00
+<FITID>FIT-2
+<MEMO>Salary &amp; bonus
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`
+
+	ofx2Statement = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <CCSTMTRS>
+        <BANKTRANLIST>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20200201</DTPOSTED>
+            <TRNAMT>-10.50</TRNAMT>
+            <FITID>FIT-1</FITID>
+            <NAME>Supermarket</NAME>
+          </STMTTRN>
+        </BANKTRANLIST>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+)
+
+func TestParseOFX(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		document string
+		want     []Transaction
+		wantErr  error
+		wantLine int
+	}{
+		{
+			name:     "OFX 1.x SGML",
+			document: ofx1Statement,
+			want: []Transaction{
+				{Date: firstFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("-10.5"), Description: "Supermarket", Reference: "FIT-1"},
+				{Date: secondFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("1000"), Description: "Salary & bonus", Reference: "FIT-2"},
+			},
+		},
+		{
+			name:     "OFX 2.x XML",
+			document: ofx2Statement,
+			want: []Transaction{
+				{Date: firstFebruary2020ZeroHoursUTCTime, Value: models.MustParseMoney("-10.5"), Description: "Supermarket", Reference: "FIT-1"},
+			},
+		},
+		{
+			name:     "Not an OFX document",
+			document: "date,description,value\n",
+			wantErr:  errNotOFX,
+		},
+		{
+			name:     "Missing amount",
+			document: "<OFX>\n<STMTTRN>\n<DTPOSTED>20200201\n<FITID>FIT-1\n</STMTTRN>\n</OFX>",
+			wantErr:  errEmptyValue,
+			wantLine: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			got, err := ParseOFX(strings.NewReader(tt.document))
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "ParseOFX() error = %v, wantErr %v", err, tt.wantErr)
+
+				var parseErr ParseError
+				if errors.As(err, &parseErr) {
+					assert.Equal(t, tt.wantLine, parseErr.Line)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// SignConvention tells how a bank export distinguishes debits from credits
+type SignConvention string
+
+const (
+	// NegativeDebits means a single value column where debits are negative
+	NegativeDebits SignConvention = "negative_debits"
+	// PositiveDebits means a single value column where debits are positive, as in most credit card statements
+	PositiveDebits SignConvention = "positive_debits"
+	// DebitCreditColumns means debits and credits come in two separate, unsigned columns
+	DebitCreditColumns SignConvention = "debit_credit_columns"
+)
+
+// Profile describes the CSV export of a bank.
+// Columns are zero based indexes.
+type Profile struct {
+	Name              string         `json:"name"`
+	Delimiter         string         `json:"delimiter"`
+	DecimalComma      bool           `json:"decimal_comma"`
+	DateLayout        string         `json:"date_layout"` // Go time layout, e.g. 02-01-2006
+	SkipRows          int            `json:"skip_rows"`   // header and preamble rows to ignore
+	DateColumn        int            `json:"date_column"`
+	DescriptionColumn int            `json:"description_column"`
+	ValueColumn       int            `json:"value_column"`
+	DebitColumn       int            `json:"debit_column"`
+	CreditColumn      int            `json:"credit_column"`
+	SignConvention    SignConvention `json:"sign_convention"`
+}
+
+// Profiles are bank profiles indexed by name
+type Profiles map[string]Profile
+
+// DefaultProfiles returns the built-in bank profiles
+func DefaultProfiles() Profiles {
+	return Profiles{
+		"generic": {
+			Name:              "generic",
+			Delimiter:         ",",
+			DateLayout:        "2006-01-02",
+			SkipRows:          1,
+			DateColumn:        0,
+			DescriptionColumn: 1,
+			ValueColumn:       2,
+			SignConvention:    NegativeDebits,
+		},
+		"cgd": {
+			Name:              "cgd",
+			Delimiter:         ";",
+			DecimalComma:      true,
+			DateLayout:        "02-01-2006",
+			SkipRows:          1,
+			DateColumn:        0,
+			DescriptionColumn: 2,
+			DebitColumn:       3,
+			CreditColumn:      4,
+			SignConvention:    DebitCreditColumns,
+		},
+		"revolut": {
+			Name:              "revolut",
+			Delimiter:         ",",
+			DateLayout:        "2006-01-02 15:04:05",
+			SkipRows:          1,
+			DateColumn:        2,
+			DescriptionColumn: 4,
+			ValueColumn:       5,
+			SignConvention:    NegativeDebits,
+		},
+	}
+}
+
+// LoadProfiles reads a JSON array of profiles and adds them to the built-in ones.
+// A loaded profile replaces a built-in profile with the same name.
+func LoadProfiles(r io.Reader) (Profiles, error) {
+
+	var loaded []Profile
+	err := json.NewDecoder(r).Decode(&loaded)
+	if err != nil {
+		return Profiles{}, fmt.Errorf("could not decode import profiles: %v", err)
+	}
+
+	profiles := DefaultProfiles()
+	for _, profile := range loaded {
+		err := profile.validate()
+		if err != nil {
+			return Profiles{}, fmt.Errorf("invalid import profile %q: %v", profile.Name, err)
+		}
+		profiles[profile.Name] = profile
+	}
+
+	return profiles, nil
+}
+
+// ProfilesFromFile loads the profiles of the JSON file on the provided path.
+// If path is empty the built-in profiles are returned.
+func ProfilesFromFile(path string) (Profiles, error) {
+
+	if path == "" {
+		return DefaultProfiles(), nil
+	}
+
+	file, err := os.Open(path) // nolint
+	if err != nil {
+		return Profiles{}, fmt.Errorf("could not open import profiles file: %v", err)
+	}
+	defer file.Close()
+
+	return LoadProfiles(file)
+}
+
+// Get returns the profile with the provided name
+func (p Profiles) Get(name string) (Profile, error) {
+
+	profile, ok := p[name]
+	if !ok {
+		return Profile{}, ErrUnknownProfile
+	}
+
+	return profile, nil
+}
+
+func (p Profile) validate() error {
+
+	if p.Name == "" {
+		return fmt.Errorf("name is missing")
+	}
+
+	if len([]rune(p.Delimiter)) != 1 {
+		return fmt.Errorf("delimiter must be a single character")
+	}
+
+	if p.DateLayout == "" {
+		return fmt.Errorf("date layout is missing")
+	}
+
+	switch p.SignConvention {
+	case NegativeDebits, PositiveDebits, DebitCreditColumns:
+	default:
+		return fmt.Errorf("unknown sign convention %q", p.SignConvention)
+	}
+
+	return nil
+}
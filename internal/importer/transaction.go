@@ -0,0 +1,22 @@
+package importer
+
+import (
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// Transaction is a bank statement line.
+// Value is signed: debits are negative and credits are positive.
+// Reference is the bank's unique id of the transaction, when the statement format has one.
+type Transaction struct {
+	Date        time.Time
+	Value       models.Money
+	Description string
+	Reference   string
+}
+
+// IsDebit tells if the transaction is a debit, which is imported as an expense
+func (t Transaction) IsDebit() bool {
+	return t.Value < 0
+}
@@ -13,6 +13,22 @@ var (
 	Registry *prometheus.Registry
 
 	Logger *zerolog.Logger
+
+	// FailedLogins counts the failed logins by reason: unknown_user, invalid_password, invalid_code or locked_out
+	FailedLogins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "system",
+		Subsystem: "auth",
+		Name:      "failed_logins_total",
+		Help:      "Failed logins by reason.",
+	}, []string{"reason"})
+
+	// LoginLockouts counts the temporary lockouts by scope: username or ip
+	LoginLockouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "system",
+		Subsystem: "auth",
+		Name:      "login_lockouts_total",
+		Help:      "Temporary login lockouts by scope.",
+	}, []string{"scope"})
 )
 
 func Init() {
@@ -21,6 +37,7 @@ func Init() {
 	zerolog.DefaultContextLogger = Logger
 
 	Registry = prometheus.NewRegistry()
+	Registry.MustRegister(FailedLogins, LoginLockouts)
 }
 
 func RegistryHandler() http.Handler {
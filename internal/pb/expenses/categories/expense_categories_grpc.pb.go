@@ -24,6 +24,7 @@ const _ = grpc.SupportPackageIsVersion7
 type ExpenseCategoryServiceClient interface {
 	CreateExpenseCategory(ctx context.Context, in *ExpenseCategoryCreateRequest, opts ...grpc.CallOption) (*ExpenseCategoryCreateResponse, error)
 	GetExpenseCategoryByName(ctx context.Context, in *ExpenseCategoryGetRequestByName, opts ...grpc.CallOption) (*ExpenseCategoryGetResponse, error)
+	MergeExpenseCategory(ctx context.Context, in *ExpenseCategoryMergeRequest, opts ...grpc.CallOption) (*ExpenseCategoryMergeResponse, error)
 }
 
 type expenseCategoryServiceClient struct {
@@ -52,12 +53,22 @@ func (c *expenseCategoryServiceClient) GetExpenseCategoryByName(ctx context.Cont
 	return out, nil
 }
 
+func (c *expenseCategoryServiceClient) MergeExpenseCategory(ctx context.Context, in *ExpenseCategoryMergeRequest, opts ...grpc.CallOption) (*ExpenseCategoryMergeResponse, error) {
+	out := new(ExpenseCategoryMergeResponse)
+	err := c.cc.Invoke(ctx, "/expense_categories.ExpenseCategoryService/MergeExpenseCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExpenseCategoryServiceServer is the server API for ExpenseCategoryService service.
 // All implementations must embed UnimplementedExpenseCategoryServiceServer
 // for forward compatibility
 type ExpenseCategoryServiceServer interface {
 	CreateExpenseCategory(context.Context, *ExpenseCategoryCreateRequest) (*ExpenseCategoryCreateResponse, error)
 	GetExpenseCategoryByName(context.Context, *ExpenseCategoryGetRequestByName) (*ExpenseCategoryGetResponse, error)
+	MergeExpenseCategory(context.Context, *ExpenseCategoryMergeRequest) (*ExpenseCategoryMergeResponse, error)
 	mustEmbedUnimplementedExpenseCategoryServiceServer()
 }
 
@@ -71,6 +82,9 @@ func (UnimplementedExpenseCategoryServiceServer) CreateExpenseCategory(context.C
 func (UnimplementedExpenseCategoryServiceServer) GetExpenseCategoryByName(context.Context, *ExpenseCategoryGetRequestByName) (*ExpenseCategoryGetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetExpenseCategoryByName not implemented")
 }
+func (UnimplementedExpenseCategoryServiceServer) MergeExpenseCategory(context.Context, *ExpenseCategoryMergeRequest) (*ExpenseCategoryMergeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeExpenseCategory not implemented")
+}
 func (UnimplementedExpenseCategoryServiceServer) mustEmbedUnimplementedExpenseCategoryServiceServer() {
 }
 
@@ -121,6 +135,24 @@ func _ExpenseCategoryService_GetExpenseCategoryByName_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExpenseCategoryService_MergeExpenseCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpenseCategoryMergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpenseCategoryServiceServer).MergeExpenseCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/expense_categories.ExpenseCategoryService/MergeExpenseCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpenseCategoryServiceServer).MergeExpenseCategory(ctx, req.(*ExpenseCategoryMergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ExpenseCategoryService_ServiceDesc is the grpc.ServiceDesc for ExpenseCategoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -136,6 +168,10 @@ var ExpenseCategoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetExpenseCategoryByName",
 			Handler:    _ExpenseCategoryService_GetExpenseCategoryByName_Handler,
 		},
+		{
+			MethodName: "MergeExpenseCategory",
+			Handler:    _ExpenseCategoryService_MergeExpenseCategory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "expense_categories.proto",
@@ -20,24 +20,100 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SPLIT EXPENSES
+type ExpenseSplit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value       string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"` // decimal string, such as "12.30"
+	SubCategory string `protobuf:"bytes,2,opt,name=sub_category,json=subCategory,proto3" json:"sub_category,omitempty"`
+	Note        string `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	Category    string `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"` // set on reads only
+}
+
+func (x *ExpenseSplit) Reset() {
+	*x = ExpenseSplit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_expenses_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExpenseSplit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpenseSplit) ProtoMessage() {}
+
+func (x *ExpenseSplit) ProtoReflect() protoreflect.Message {
+	mi := &file_expenses_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpenseSplit.ProtoReflect.Descriptor instead.
+func (*ExpenseSplit) Descriptor() ([]byte, []int) {
+	return file_expenses_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExpenseSplit) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ExpenseSplit) GetSubCategory() string {
+	if x != nil {
+		return x.SubCategory
+	}
+	return ""
+}
+
+func (x *ExpenseSplit) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ExpenseSplit) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
 // CREATE EXPENSES
 type ExpenseCreateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Value       float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
-	Date        int64   `protobuf:"varint,2,opt,name=date,proto3" json:"date,omitempty"`
-	Category    string  `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
-	SubCategory string  `protobuf:"bytes,4,opt,name=sub_category,json=subCategory,proto3" json:"sub_category,omitempty"`
-	Card        string  `protobuf:"bytes,5,opt,name=card,proto3" json:"card,omitempty"`
-	Description string  `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Value       string          `protobuf:"bytes,11,opt,name=value,proto3" json:"value,omitempty"` // decimal string, such as "12.30"
+	Date        int64           `protobuf:"varint,2,opt,name=date,proto3" json:"date,omitempty"`
+	Category    string          `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	SubCategory string          `protobuf:"bytes,4,opt,name=sub_category,json=subCategory,proto3" json:"sub_category,omitempty"`
+	Card        string          `protobuf:"bytes,5,opt,name=card,proto3" json:"card,omitempty"`
+	Description string          `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Force       bool            `protobuf:"varint,7,opt,name=force,proto3" json:"force,omitempty"`      // creates the expense even if it is a likely duplicate
+	Currency    string          `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"` // ISO 4217 code, such as "EUR"; defaults to the currency of the card
+	Splits      []*ExpenseSplit `protobuf:"bytes,9,rep,name=splits,proto3" json:"splits,omitempty"`     // lines adding up to the value; the expense takes the subcategory of the first one
+	Tags        []string        `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`        // single words, such as "reimbursable"; stored lower cased
 }
 
 func (x *ExpenseCreateRequest) Reset() {
 	*x = ExpenseCreateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[0]
+		mi := &file_expenses_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -50,7 +126,7 @@ func (x *ExpenseCreateRequest) String() string {
 func (*ExpenseCreateRequest) ProtoMessage() {}
 
 func (x *ExpenseCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[0]
+	mi := &file_expenses_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -63,14 +139,14 @@ func (x *ExpenseCreateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpenseCreateRequest.ProtoReflect.Descriptor instead.
 func (*ExpenseCreateRequest) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{0}
+	return file_expenses_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *ExpenseCreateRequest) GetValue() float64 {
+func (x *ExpenseCreateRequest) GetValue() string {
 	if x != nil {
 		return x.Value
 	}
-	return 0
+	return ""
 }
 
 func (x *ExpenseCreateRequest) GetDate() int64 {
@@ -108,6 +184,34 @@ func (x *ExpenseCreateRequest) GetDescription() string {
 	return ""
 }
 
+func (x *ExpenseCreateRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *ExpenseCreateRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ExpenseCreateRequest) GetSplits() []*ExpenseSplit {
+	if x != nil {
+		return x.Splits
+	}
+	return nil
+}
+
+func (x *ExpenseCreateRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type ExpenseCreateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -119,7 +223,7 @@ type ExpenseCreateResponse struct {
 func (x *ExpenseCreateResponse) Reset() {
 	*x = ExpenseCreateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[1]
+		mi := &file_expenses_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -132,7 +236,7 @@ func (x *ExpenseCreateResponse) String() string {
 func (*ExpenseCreateResponse) ProtoMessage() {}
 
 func (x *ExpenseCreateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[1]
+	mi := &file_expenses_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -145,7 +249,7 @@ func (x *ExpenseCreateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpenseCreateResponse.ProtoReflect.Descriptor instead.
 func (*ExpenseCreateResponse) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{1}
+	return file_expenses_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ExpenseCreateResponse) GetId() int64 {
@@ -166,7 +270,7 @@ type ExpensesCreateRequest struct {
 func (x *ExpensesCreateRequest) Reset() {
 	*x = ExpensesCreateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[2]
+		mi := &file_expenses_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -179,7 +283,7 @@ func (x *ExpensesCreateRequest) String() string {
 func (*ExpensesCreateRequest) ProtoMessage() {}
 
 func (x *ExpensesCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[2]
+	mi := &file_expenses_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -192,7 +296,7 @@ func (x *ExpensesCreateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpensesCreateRequest.ProtoReflect.Descriptor instead.
 func (*ExpensesCreateRequest) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{2}
+	return file_expenses_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ExpensesCreateRequest) GetExpenses() []*ExpenseCreateRequest {
@@ -213,7 +317,7 @@ type ExpensesCreateResponse struct {
 func (x *ExpensesCreateResponse) Reset() {
 	*x = ExpensesCreateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[3]
+		mi := &file_expenses_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -226,7 +330,7 @@ func (x *ExpensesCreateResponse) String() string {
 func (*ExpensesCreateResponse) ProtoMessage() {}
 
 func (x *ExpensesCreateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[3]
+	mi := &file_expenses_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -239,7 +343,7 @@ func (x *ExpensesCreateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpensesCreateResponse.ProtoReflect.Descriptor instead.
 func (*ExpensesCreateResponse) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{3}
+	return file_expenses_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ExpensesCreateResponse) GetIds() []*ExpenseCreateResponse {
@@ -255,19 +359,22 @@ type ExpenseGetResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id          int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Value       float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
-	Date        int64   `protobuf:"varint,3,opt,name=date,proto3" json:"date,omitempty"`
-	Category    string  `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
-	SubCategory string  `protobuf:"bytes,5,opt,name=sub_category,json=subCategory,proto3" json:"sub_category,omitempty"`
-	Card        string  `protobuf:"bytes,6,opt,name=card,proto3" json:"card,omitempty"`
-	Description string  `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Id          int64           `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value       string          `protobuf:"bytes,11,opt,name=value,proto3" json:"value,omitempty"` // decimal string, such as "12.30"
+	Date        int64           `protobuf:"varint,3,opt,name=date,proto3" json:"date,omitempty"`
+	Category    string          `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	SubCategory string          `protobuf:"bytes,5,opt,name=sub_category,json=subCategory,proto3" json:"sub_category,omitempty"`
+	Card        string          `protobuf:"bytes,6,opt,name=card,proto3" json:"card,omitempty"`
+	Description string          `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Currency    string          `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"` // ISO 4217 code, such as "EUR"
+	Splits      []*ExpenseSplit `protobuf:"bytes,9,rep,name=splits,proto3" json:"splits,omitempty"`     // empty if the expense is not split
+	Tags        []string        `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
 }
 
 func (x *ExpenseGetResponse) Reset() {
 	*x = ExpenseGetResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[4]
+		mi := &file_expenses_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -280,7 +387,7 @@ func (x *ExpenseGetResponse) String() string {
 func (*ExpenseGetResponse) ProtoMessage() {}
 
 func (x *ExpenseGetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[4]
+	mi := &file_expenses_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -293,7 +400,7 @@ func (x *ExpenseGetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpenseGetResponse.ProtoReflect.Descriptor instead.
 func (*ExpenseGetResponse) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{4}
+	return file_expenses_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ExpenseGetResponse) GetId() int64 {
@@ -303,11 +410,11 @@ func (x *ExpenseGetResponse) GetId() int64 {
 	return 0
 }
 
-func (x *ExpenseGetResponse) GetValue() float64 {
+func (x *ExpenseGetResponse) GetValue() string {
 	if x != nil {
 		return x.Value
 	}
-	return 0
+	return ""
 }
 
 func (x *ExpenseGetResponse) GetDate() int64 {
@@ -345,6 +452,27 @@ func (x *ExpenseGetResponse) GetDescription() string {
 	return ""
 }
 
+func (x *ExpenseGetResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ExpenseGetResponse) GetSplits() []*ExpenseSplit {
+	if x != nil {
+		return x.Splits
+	}
+	return nil
+}
+
+func (x *ExpenseGetResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type ExpensesGetResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -356,7 +484,7 @@ type ExpensesGetResponse struct {
 func (x *ExpensesGetResponse) Reset() {
 	*x = ExpensesGetResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[5]
+		mi := &file_expenses_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -369,7 +497,7 @@ func (x *ExpensesGetResponse) String() string {
 func (*ExpensesGetResponse) ProtoMessage() {}
 
 func (x *ExpensesGetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[5]
+	mi := &file_expenses_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -382,7 +510,7 @@ func (x *ExpensesGetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpensesGetResponse.ProtoReflect.Descriptor instead.
 func (*ExpensesGetResponse) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{5}
+	return file_expenses_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ExpensesGetResponse) GetExpenses() []*ExpenseGetResponse {
@@ -397,14 +525,16 @@ type ExpensesGetRequestByDate struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MinDate int64 `protobuf:"varint,1,opt,name=min_date,json=minDate,proto3" json:"min_date,omitempty"`
-	MaxDate int64 `protobuf:"varint,2,opt,name=max_date,json=maxDate,proto3" json:"max_date,omitempty"`
+	MinDate  int64  `protobuf:"varint,1,opt,name=min_date,json=minDate,proto3" json:"min_date,omitempty"`
+	MaxDate  int64  `protobuf:"varint,2,opt,name=max_date,json=maxDate,proto3" json:"max_date,omitempty"`
+	Currency string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"` // reporting currency the values are converted to, at the rate of each expense date
+	Tag      string `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`           // only the expenses with this tag, if set
 }
 
 func (x *ExpensesGetRequestByDate) Reset() {
 	*x = ExpensesGetRequestByDate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[6]
+		mi := &file_expenses_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -417,7 +547,7 @@ func (x *ExpensesGetRequestByDate) String() string {
 func (*ExpensesGetRequestByDate) ProtoMessage() {}
 
 func (x *ExpensesGetRequestByDate) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[6]
+	mi := &file_expenses_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -430,7 +560,7 @@ func (x *ExpensesGetRequestByDate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpensesGetRequestByDate.ProtoReflect.Descriptor instead.
 func (*ExpensesGetRequestByDate) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{6}
+	return file_expenses_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ExpensesGetRequestByDate) GetMinDate() int64 {
@@ -447,18 +577,33 @@ func (x *ExpensesGetRequestByDate) GetMaxDate() int64 {
 	return 0
 }
 
+func (x *ExpensesGetRequestByDate) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ExpensesGetRequestByDate) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
 type ExpensesGetRequestByCategory struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Category string `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Tag      string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"` // only the expenses with this tag, if set
 }
 
 func (x *ExpensesGetRequestByCategory) Reset() {
 	*x = ExpensesGetRequestByCategory{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[7]
+		mi := &file_expenses_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -471,7 +616,7 @@ func (x *ExpensesGetRequestByCategory) String() string {
 func (*ExpensesGetRequestByCategory) ProtoMessage() {}
 
 func (x *ExpensesGetRequestByCategory) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[7]
+	mi := &file_expenses_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -484,7 +629,7 @@ func (x *ExpensesGetRequestByCategory) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpensesGetRequestByCategory.ProtoReflect.Descriptor instead.
 func (*ExpensesGetRequestByCategory) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{7}
+	return file_expenses_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ExpensesGetRequestByCategory) GetCategory() string {
@@ -494,18 +639,26 @@ func (x *ExpensesGetRequestByCategory) GetCategory() string {
 	return ""
 }
 
+func (x *ExpensesGetRequestByCategory) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
 type ExpensesGetRequestBySubCategory struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	SubCategory string `protobuf:"bytes,1,opt,name=sub_category,json=subCategory,proto3" json:"sub_category,omitempty"`
+	Tag         string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"` // only the expenses with this tag, if set
 }
 
 func (x *ExpensesGetRequestBySubCategory) Reset() {
 	*x = ExpensesGetRequestBySubCategory{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[8]
+		mi := &file_expenses_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -518,7 +671,7 @@ func (x *ExpensesGetRequestBySubCategory) String() string {
 func (*ExpensesGetRequestBySubCategory) ProtoMessage() {}
 
 func (x *ExpensesGetRequestBySubCategory) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[8]
+	mi := &file_expenses_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -531,7 +684,7 @@ func (x *ExpensesGetRequestBySubCategory) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpensesGetRequestBySubCategory.ProtoReflect.Descriptor instead.
 func (*ExpensesGetRequestBySubCategory) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{8}
+	return file_expenses_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ExpensesGetRequestBySubCategory) GetSubCategory() string {
@@ -541,18 +694,26 @@ func (x *ExpensesGetRequestBySubCategory) GetSubCategory() string {
 	return ""
 }
 
+func (x *ExpensesGetRequestBySubCategory) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
 type ExpensesGetRequestByCard struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Card string `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	Tag  string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"` // only the expenses with this tag, if set
 }
 
 func (x *ExpensesGetRequestByCard) Reset() {
 	*x = ExpensesGetRequestByCard{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[9]
+		mi := &file_expenses_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -565,7 +726,7 @@ func (x *ExpensesGetRequestByCard) String() string {
 func (*ExpensesGetRequestByCard) ProtoMessage() {}
 
 func (x *ExpensesGetRequestByCard) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[9]
+	mi := &file_expenses_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -578,7 +739,7 @@ func (x *ExpensesGetRequestByCard) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpensesGetRequestByCard.ProtoReflect.Descriptor instead.
 func (*ExpensesGetRequestByCard) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{9}
+	return file_expenses_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ExpensesGetRequestByCard) GetCard() string {
@@ -588,25 +749,35 @@ func (x *ExpensesGetRequestByCard) GetCard() string {
 	return ""
 }
 
+func (x *ExpensesGetRequestByCard) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
 // UPDATE EXPENSES
 type ExpenseUpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id          int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Value       float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
-	Date        int64   `protobuf:"varint,3,opt,name=date,proto3" json:"date,omitempty"`
-	Category    string  `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
-	SubCategory string  `protobuf:"bytes,5,opt,name=sub_category,json=subCategory,proto3" json:"sub_category,omitempty"`
-	Card        string  `protobuf:"bytes,6,opt,name=card,proto3" json:"card,omitempty"`
-	Description string  `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Id          int64           `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value       string          `protobuf:"bytes,11,opt,name=value,proto3" json:"value,omitempty"` // decimal string, such as "12.30"
+	Date        int64           `protobuf:"varint,3,opt,name=date,proto3" json:"date,omitempty"`
+	Category    string          `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	SubCategory string          `protobuf:"bytes,5,opt,name=sub_category,json=subCategory,proto3" json:"sub_category,omitempty"`
+	Card        string          `protobuf:"bytes,6,opt,name=card,proto3" json:"card,omitempty"`
+	Description string          `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Currency    string          `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"` // ISO 4217 code, such as "EUR"; defaults to the currency of the card
+	Splits      []*ExpenseSplit `protobuf:"bytes,9,rep,name=splits,proto3" json:"splits,omitempty"`     // replace the lines of the expense; the expense takes the subcategory of the first one
+	Tags        []string        `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`        // replace the tags of the expense
 }
 
 func (x *ExpenseUpdateRequest) Reset() {
 	*x = ExpenseUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[10]
+		mi := &file_expenses_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -619,7 +790,7 @@ func (x *ExpenseUpdateRequest) String() string {
 func (*ExpenseUpdateRequest) ProtoMessage() {}
 
 func (x *ExpenseUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[10]
+	mi := &file_expenses_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -632,7 +803,7 @@ func (x *ExpenseUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpenseUpdateRequest.ProtoReflect.Descriptor instead.
 func (*ExpenseUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{10}
+	return file_expenses_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ExpenseUpdateRequest) GetId() int64 {
@@ -642,11 +813,11 @@ func (x *ExpenseUpdateRequest) GetId() int64 {
 	return 0
 }
 
-func (x *ExpenseUpdateRequest) GetValue() float64 {
+func (x *ExpenseUpdateRequest) GetValue() string {
 	if x != nil {
 		return x.Value
 	}
-	return 0
+	return ""
 }
 
 func (x *ExpenseUpdateRequest) GetDate() int64 {
@@ -684,6 +855,27 @@ func (x *ExpenseUpdateRequest) GetDescription() string {
 	return ""
 }
 
+func (x *ExpenseUpdateRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ExpenseUpdateRequest) GetSplits() []*ExpenseSplit {
+	if x != nil {
+		return x.Splits
+	}
+	return nil
+}
+
+func (x *ExpenseUpdateRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type ExpenseUpdateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -695,7 +887,7 @@ type ExpenseUpdateResponse struct {
 func (x *ExpenseUpdateResponse) Reset() {
 	*x = ExpenseUpdateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[11]
+		mi := &file_expenses_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -708,7 +900,7 @@ func (x *ExpenseUpdateResponse) String() string {
 func (*ExpenseUpdateResponse) ProtoMessage() {}
 
 func (x *ExpenseUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[11]
+	mi := &file_expenses_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -721,7 +913,7 @@ func (x *ExpenseUpdateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpenseUpdateResponse.ProtoReflect.Descriptor instead.
 func (*ExpenseUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{11}
+	return file_expenses_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ExpenseUpdateResponse) GetId() int64 {
@@ -742,7 +934,7 @@ type ExpensesUpdateRequest struct {
 func (x *ExpensesUpdateRequest) Reset() {
 	*x = ExpensesUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[12]
+		mi := &file_expenses_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -755,7 +947,7 @@ func (x *ExpensesUpdateRequest) String() string {
 func (*ExpensesUpdateRequest) ProtoMessage() {}
 
 func (x *ExpensesUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[12]
+	mi := &file_expenses_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -768,7 +960,7 @@ func (x *ExpensesUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpensesUpdateRequest.ProtoReflect.Descriptor instead.
 func (*ExpensesUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{12}
+	return file_expenses_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ExpensesUpdateRequest) GetExpenses() []*ExpenseUpdateRequest {
@@ -789,7 +981,7 @@ type ExpensesUpdateResponse struct {
 func (x *ExpensesUpdateResponse) Reset() {
 	*x = ExpensesUpdateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_expenses_proto_msgTypes[13]
+		mi := &file_expenses_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -802,7 +994,7 @@ func (x *ExpensesUpdateResponse) String() string {
 func (*ExpensesUpdateResponse) ProtoMessage() {}
 
 func (x *ExpensesUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_expenses_proto_msgTypes[13]
+	mi := &file_expenses_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -815,7 +1007,7 @@ func (x *ExpensesUpdateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpensesUpdateResponse.ProtoReflect.Descriptor instead.
 func (*ExpensesUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_expenses_proto_rawDescGZIP(), []int{13}
+	return file_expenses_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ExpensesUpdateResponse) GetIds() []*ExpenseUpdateResponse {
@@ -825,205 +1017,704 @@ func (x *ExpensesUpdateResponse) GetIds() []*ExpenseUpdateResponse {
 	return nil
 }
 
-var File_expenses_proto protoreflect.FileDescriptor
+// SEARCH EXPENSES
+type ExpensesSearchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_expenses_proto_rawDesc = []byte{
-	0x0a, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x08, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x22, 0xb5, 0x01, 0x0a, 0x14, 0x45,
-	0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75, 0x62,
-	0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x73, 0x75, 0x62, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04,
-	0x63, 0x61, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x61, 0x72, 0x64,
-	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x22, 0x27, 0x0a, 0x15, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x53, 0x0a, 0x15, 0x45,
-	0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65,
-	0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x08, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73,
-	0x22, 0x4b, 0x0a, 0x16, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x03, 0x69, 0x64,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73,
-	0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0xc3, 0x01,
-	0x0a, 0x12, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
-	0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a,
-	0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75,
-	0x62, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x73, 0x75, 0x62, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a,
-	0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x61, 0x72,
-	0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x22, 0x4f, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x47,
-	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x65, 0x78,
-	0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65,
-	0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x47,
-	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x65, 0x78, 0x70, 0x65,
-	0x6e, 0x73, 0x65, 0x73, 0x22, 0x50, 0x0a, 0x18, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73,
-	0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x44, 0x61, 0x74, 0x65,
-	0x12, 0x19, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x07, 0x6d, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d,
-	0x61, 0x78, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d,
-	0x61, 0x78, 0x44, 0x61, 0x74, 0x65, 0x22, 0x3a, 0x0a, 0x1c, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73,
-	0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x43, 0x61,
-	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
-	0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
-	0x72, 0x79, 0x22, 0x44, 0x0a, 0x1f, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x53, 0x75, 0x62, 0x43, 0x61, 0x74,
-	0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x5f, 0x63, 0x61, 0x74,
-	0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x62,
-	0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x22, 0x2e, 0x0a, 0x18, 0x45, 0x78, 0x70, 0x65,
-	0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79,
-	0x43, 0x61, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x63, 0x61, 0x72, 0x64, 0x22, 0xc5, 0x01, 0x0a, 0x14, 0x45, 0x78, 0x70,
-	0x65, 0x6e, 0x73, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63,
-	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
-	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x5f, 0x63,
-	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73,
-	0x75, 0x62, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x61,
-	0x72, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x61, 0x72, 0x64, 0x12, 0x20,
-	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x22, 0x27, 0x0a, 0x15, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x53, 0x0a, 0x15, 0x45, 0x78, 0x70,
-	0x65, 0x6e, 0x73, 0x65, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x3a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e,
-	0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x52, 0x08, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x22, 0x4b,
-	0x0a, 0x16, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73,
-	0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x03, 0x69, 0x64, 0x73, 0x32, 0x80, 0x05, 0x0a, 0x0f,
-	0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x50, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65,
-	0x12, 0x1e, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65,
-	0x6e, 0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1f, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65,
-	0x6e, 0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x53, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x70, 0x65, 0x6e,
-	0x73, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45,
-	0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e,
-	0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73,
-	0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73,
-	0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x45,
-	0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x42, 0x79, 0x44, 0x61, 0x74, 0x65, 0x12, 0x22, 0x2e,
-	0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65,
-	0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x44, 0x61, 0x74,
-	0x65, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70,
-	0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x5e, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x42,
-	0x79, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x26, 0x2e, 0x65, 0x78, 0x70, 0x65,
-	0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
-	0x79, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70,
-	0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x64, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x42,
-	0x79, 0x53, 0x75, 0x62, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x29, 0x2e, 0x65,
-	0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73,
-	0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x53, 0x75, 0x62, 0x43,
-	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73,
-	0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70,
-	0x65, 0x6e, 0x73, 0x65, 0x73, 0x42, 0x79, 0x43, 0x61, 0x72, 0x64, 0x12, 0x22, 0x2e, 0x65, 0x78,
-	0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x47,
-	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x43, 0x61, 0x72, 0x64, 0x1a,
-	0x1d, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e,
-	0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x46,
-	0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x75, 0x62,
-	0x65, 0x6e, 0x67, 0x6f, 0x6d, 0x65, 0x73, 0x38, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2d,
-	0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x6c, 0x2d, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65,
-	0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x2f, 0x65, 0x78,
-	0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	MinDate     int64  `protobuf:"varint,1,opt,name=min_date,json=minDate,proto3" json:"min_date,omitempty"` // not bounded if zero
+	MaxDate     int64  `protobuf:"varint,2,opt,name=max_date,json=maxDate,proto3" json:"max_date,omitempty"` // not bounded if zero
+	Category    string `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	SubCategory string `protobuf:"bytes,4,opt,name=sub_category,json=subCategory,proto3" json:"sub_category,omitempty"`
+	Card        string `protobuf:"bytes,5,opt,name=card,proto3" json:"card,omitempty"`
+	MinValue    string `protobuf:"bytes,6,opt,name=min_value,json=minValue,proto3" json:"min_value,omitempty"` // decimal string, such as "12.30"
+	MaxValue    string `protobuf:"bytes,7,opt,name=max_value,json=maxValue,proto3" json:"max_value,omitempty"` // decimal string, such as "12.30"
+	Description string `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`           // case insensitive text the description contains
+	SortBy      string `protobuf:"bytes,9,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`       // date (default) or value
+	Order       string `protobuf:"bytes,10,opt,name=order,proto3" json:"order,omitempty"`                      // desc (default) or asc
+	Limit       int32  `protobuf:"varint,11,opt,name=limit,proto3" json:"limit,omitempty"`                     // defaults to 50, up to 500
+	Cursor      string `protobuf:"bytes,12,opt,name=cursor,proto3" json:"cursor,omitempty"`                    // next_cursor of the previous page
+	Tag         string `protobuf:"bytes,13,opt,name=tag,proto3" json:"tag,omitempty"`
+}
+
+func (x *ExpensesSearchRequest) Reset() {
+	*x = ExpensesSearchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_expenses_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var (
-	file_expenses_proto_rawDescOnce sync.Once
-	file_expenses_proto_rawDescData = file_expenses_proto_rawDesc
-)
-
-func file_expenses_proto_rawDescGZIP() []byte {
-	file_expenses_proto_rawDescOnce.Do(func() {
-		file_expenses_proto_rawDescData = protoimpl.X.CompressGZIP(file_expenses_proto_rawDescData)
-	})
-	return file_expenses_proto_rawDescData
+func (x *ExpensesSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_expenses_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
-var file_expenses_proto_goTypes = []interface{}{
-	(*ExpenseCreateRequest)(nil),            // 0: expenses.ExpenseCreateRequest
-	(*ExpenseCreateResponse)(nil),           // 1: expenses.ExpenseCreateResponse
-	(*ExpensesCreateRequest)(nil),           // 2: expenses.ExpensesCreateRequest
-	(*ExpensesCreateResponse)(nil),          // 3: expenses.ExpensesCreateResponse
-	(*ExpenseGetResponse)(nil),              // 4: expenses.ExpenseGetResponse
-	(*ExpensesGetResponse)(nil),             // 5: expenses.ExpensesGetResponse
-	(*ExpensesGetRequestByDate)(nil),        // 6: expenses.ExpensesGetRequestByDate
-	(*ExpensesGetRequestByCategory)(nil),    // 7: expenses.ExpensesGetRequestByCategory
-	(*ExpensesGetRequestBySubCategory)(nil), // 8: expenses.ExpensesGetRequestBySubCategory
-	(*ExpensesGetRequestByCard)(nil),        // 9: expenses.ExpensesGetRequestByCard
-	(*ExpenseUpdateRequest)(nil),            // 10: expenses.ExpenseUpdateRequest
-	(*ExpenseUpdateResponse)(nil),           // 11: expenses.ExpenseUpdateResponse
-	(*ExpensesUpdateRequest)(nil),           // 12: expenses.ExpensesUpdateRequest
-	(*ExpensesUpdateResponse)(nil),          // 13: expenses.ExpensesUpdateResponse
+func (*ExpensesSearchRequest) ProtoMessage() {}
+
+func (x *ExpensesSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_expenses_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_expenses_proto_depIdxs = []int32{
-	0,  // 0: expenses.ExpensesCreateRequest.expenses:type_name -> expenses.ExpenseCreateRequest
-	1,  // 1: expenses.ExpensesCreateResponse.ids:type_name -> expenses.ExpenseCreateResponse
-	4,  // 2: expenses.ExpensesGetResponse.expenses:type_name -> expenses.ExpenseGetResponse
-	10, // 3: expenses.ExpensesUpdateRequest.expenses:type_name -> expenses.ExpenseUpdateRequest
-	11, // 4: expenses.ExpensesUpdateResponse.ids:type_name -> expenses.ExpenseUpdateResponse
-	0,  // 5: expenses.ExpensesService.CreateExpense:input_type -> expenses.ExpenseCreateRequest
-	2,  // 6: expenses.ExpensesService.CreateExpenses:input_type -> expenses.ExpensesCreateRequest
-	10, // 7: expenses.ExpensesService.UpdateExpense:input_type -> expenses.ExpenseUpdateRequest
-	6,  // 8: expenses.ExpensesService.GetExpensesByDate:input_type -> expenses.ExpensesGetRequestByDate
-	7,  // 9: expenses.ExpensesService.GetExpensesByCategory:input_type -> expenses.ExpensesGetRequestByCategory
-	8,  // 10: expenses.ExpensesService.GetExpensesBySubCategory:input_type -> expenses.ExpensesGetRequestBySubCategory
-	9,  // 11: expenses.ExpensesService.GetExpensesByCard:input_type -> expenses.ExpensesGetRequestByCard
-	1,  // 12: expenses.ExpensesService.CreateExpense:output_type -> expenses.ExpenseCreateResponse
-	3,  // 13: expenses.ExpensesService.CreateExpenses:output_type -> expenses.ExpensesCreateResponse
-	11, // 14: expenses.ExpensesService.UpdateExpense:output_type -> expenses.ExpenseUpdateResponse
-	5,  // 15: expenses.ExpensesService.GetExpensesByDate:output_type -> expenses.ExpensesGetResponse
-	5,  // 16: expenses.ExpensesService.GetExpensesByCategory:output_type -> expenses.ExpensesGetResponse
-	5,  // 17: expenses.ExpensesService.GetExpensesBySubCategory:output_type -> expenses.ExpensesGetResponse
-	5,  // 18: expenses.ExpensesService.GetExpensesByCard:output_type -> expenses.ExpensesGetResponse
-	12, // [12:19] is the sub-list for method output_type
-	5,  // [5:12] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+
+// Deprecated: Use ExpensesSearchRequest.ProtoReflect.Descriptor instead.
+func (*ExpensesSearchRequest) Descriptor() ([]byte, []int) {
+	return file_expenses_proto_rawDescGZIP(), []int{15}
 }
 
-func init() { file_expenses_proto_init() }
-func file_expenses_proto_init() {
-	if File_expenses_proto != nil {
+func (x *ExpensesSearchRequest) GetMinDate() int64 {
+	if x != nil {
+		return x.MinDate
+	}
+	return 0
+}
+
+func (x *ExpensesSearchRequest) GetMaxDate() int64 {
+	if x != nil {
+		return x.MaxDate
+	}
+	return 0
+}
+
+func (x *ExpensesSearchRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ExpensesSearchRequest) GetSubCategory() string {
+	if x != nil {
+		return x.SubCategory
+	}
+	return ""
+}
+
+func (x *ExpensesSearchRequest) GetCard() string {
+	if x != nil {
+		return x.Card
+	}
+	return ""
+}
+
+func (x *ExpensesSearchRequest) GetMinValue() string {
+	if x != nil {
+		return x.MinValue
+	}
+	return ""
+}
+
+func (x *ExpensesSearchRequest) GetMaxValue() string {
+	if x != nil {
+		return x.MaxValue
+	}
+	return ""
+}
+
+func (x *ExpensesSearchRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ExpensesSearchRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ExpensesSearchRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *ExpensesSearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ExpensesSearchRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ExpensesSearchRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type ExpensesSearchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Expenses   []*ExpenseGetResponse `protobuf:"bytes,1,rep,name=expenses,proto3" json:"expenses,omitempty"`
+	NextCursor string                `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // empty on the last page
+}
+
+func (x *ExpensesSearchResponse) Reset() {
+	*x = ExpensesSearchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_expenses_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExpensesSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpensesSearchResponse) ProtoMessage() {}
+
+func (x *ExpensesSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_expenses_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpensesSearchResponse.ProtoReflect.Descriptor instead.
+func (*ExpensesSearchResponse) Descriptor() ([]byte, []int) {
+	return file_expenses_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ExpensesSearchResponse) GetExpenses() []*ExpenseGetResponse {
+	if x != nil {
+		return x.Expenses
+	}
+	return nil
+}
+
+func (x *ExpensesSearchResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// EXPENSES TOTALS
+type ExpensesTotalsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinDate  int64  `protobuf:"varint,1,opt,name=min_date,json=minDate,proto3" json:"min_date,omitempty"`
+	MaxDate  int64  `protobuf:"varint,2,opt,name=max_date,json=maxDate,proto3" json:"max_date,omitempty"`
+	GroupBy  string `protobuf:"bytes,3,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"` // category, subcategory, card, tag, day, week or month; defaults to category
+	Currency string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`              // reporting currency the values are converted to; totals are kept per currency if empty
+}
+
+func (x *ExpensesTotalsRequest) Reset() {
+	*x = ExpensesTotalsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_expenses_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExpensesTotalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpensesTotalsRequest) ProtoMessage() {}
+
+func (x *ExpensesTotalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_expenses_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpensesTotalsRequest.ProtoReflect.Descriptor instead.
+func (*ExpensesTotalsRequest) Descriptor() ([]byte, []int) {
+	return file_expenses_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ExpensesTotalsRequest) GetMinDate() int64 {
+	if x != nil {
+		return x.MinDate
+	}
+	return 0
+}
+
+func (x *ExpensesTotalsRequest) GetMaxDate() int64 {
+	if x != nil {
+		return x.MaxDate
+	}
+	return 0
+}
+
+func (x *ExpensesTotalsRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+func (x *ExpensesTotalsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ExpensesTotal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // category, subcategory, card or tag name, day or first day of the week (YYYY-MM-DD), or month (YYYY-MM)
+	Currency string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Value    string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"` // decimal string, such as "12.30"
+	Count    int64  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *ExpensesTotal) Reset() {
+	*x = ExpensesTotal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_expenses_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExpensesTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpensesTotal) ProtoMessage() {}
+
+func (x *ExpensesTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_expenses_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpensesTotal.ProtoReflect.Descriptor instead.
+func (*ExpensesTotal) Descriptor() ([]byte, []int) {
+	return file_expenses_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExpensesTotal) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ExpensesTotal) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ExpensesTotal) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ExpensesTotal) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ExpensesTotalsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Totals []*ExpensesTotal `protobuf:"bytes,1,rep,name=totals,proto3" json:"totals,omitempty"`
+}
+
+func (x *ExpensesTotalsResponse) Reset() {
+	*x = ExpensesTotalsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_expenses_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExpensesTotalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpensesTotalsResponse) ProtoMessage() {}
+
+func (x *ExpensesTotalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_expenses_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpensesTotalsResponse.ProtoReflect.Descriptor instead.
+func (*ExpensesTotalsResponse) Descriptor() ([]byte, []int) {
+	return file_expenses_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExpensesTotalsResponse) GetTotals() []*ExpensesTotal {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+var File_expenses_proto protoreflect.FileDescriptor
+
+var file_expenses_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x08, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x22, 0x77, 0x0a, 0x0c, 0x45, 0x78,
+	0x70, 0x65, 0x6e, 0x73, 0x65, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x43, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x22, 0xb1, 0x02, 0x0a, 0x14, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x43, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x61, 0x72, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66,
+	0x6f, 0x72, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2e, 0x0a,
+	0x06, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65,
+	0x53, 0x70, 0x6c, 0x69, 0x74, 0x52, 0x06, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22, 0x27, 0x0a, 0x15, 0x45, 0x78, 0x70, 0x65, 0x6e,
+	0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x53, 0x0a, 0x15, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x08, 0x65, 0x78, 0x70,
+	0x65, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x78,
+	0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x08, 0x65, 0x78, 0x70,
+	0x65, 0x6e, 0x73, 0x65, 0x73, 0x22, 0x4b, 0x0a, 0x16, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65,
+	0x73, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x31, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x65,
+	0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x03, 0x69,
+	0x64, 0x73, 0x22, 0xa9, 0x02, 0x0a, 0x12, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12,
+	0x21, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x63, 0x61, 0x72, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x73, 0x18, 0x09,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e,
+	0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x52, 0x06, 0x73, 0x70,
+	0x6c, 0x69, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x4f,
+	0x0a, 0x13, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73,
+	0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x22,
+	0x7e, 0x0a, 0x18, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d,
+	0x69, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d,
+	0x69, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x44, 0x61, 0x74,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x74, 0x61, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22,
+	0x4c, 0x0a, 0x1c, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x74,
+	0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x56, 0x0a,
+	0x1f, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x42, 0x79, 0x53, 0x75, 0x62, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x43, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x40, 0x0a, 0x18, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65,
+	0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x43, 0x61, 0x72,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x63, 0x61, 0x72, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0xab, 0x02, 0x0a, 0x14, 0x45, 0x78, 0x70, 0x65,
+	0x6e, 0x73, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x5f, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75,
+	0x62, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x61, 0x72,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x61, 0x72, 0x64, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2e, 0x0a, 0x06, 0x73,
+	0x70, 0x6c, 0x69, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x65, 0x78,
+	0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x53, 0x70,
+	0x6c, 0x69, 0x74, 0x52, 0x06, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x61, 0x67, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x4a,
+	0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x27, 0x0a, 0x15, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x53,
+	0x0a, 0x15, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x65, 0x6e,
+	0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x78, 0x70, 0x65,
+	0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x08, 0x65, 0x78, 0x70, 0x65, 0x6e,
+	0x73, 0x65, 0x73, 0x22, 0x4b, 0x0a, 0x16, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a,
+	0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x65, 0x78, 0x70,
+	0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x03, 0x69, 0x64, 0x73,
+	0x22, 0xeb, 0x02, 0x0a, 0x15, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x69,
+	0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x69,
+	0x6e, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x61, 0x74,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c,
+	0x73, 0x75, 0x62, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63,
+	0x61, 0x72, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x17, 0x0a, 0x07, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x62, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03,
+	0x74, 0x61, 0x67, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x73,
+	0x0a, 0x16, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x65,
+	0x6e, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x78, 0x70,
+	0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73,
+	0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x22, 0x84, 0x01, 0x0a, 0x15, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73,
+	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x6d, 0x69, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x6d, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x62, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x69, 0x0a, 0x0d, 0x45, 0x78,
+	0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x49, 0x0a, 0x16, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65,
+	0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2f, 0x0a, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e,
+	0x73, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73,
+	0x32, 0xad, 0x06, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78,
+	0x70, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73,
+	0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73,
+	0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e,
+	0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x65, 0x78, 0x70, 0x65,
+	0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0d, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x2e, 0x65,
+	0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x65,
+	0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x42, 0x79, 0x44, 0x61,
+	0x74, 0x65, 0x12, 0x22, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78,
+	0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x42, 0x79, 0x44, 0x61, 0x74, 0x65, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65,
+	0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x65,
+	0x6e, 0x73, 0x65, 0x73, 0x42, 0x79, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x26,
+	0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73,
+	0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x43, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65,
+	0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x65,
+	0x6e, 0x73, 0x65, 0x73, 0x42, 0x79, 0x53, 0x75, 0x62, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x79, 0x12, 0x29, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70,
+	0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42,
+	0x79, 0x53, 0x75, 0x62, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x1a, 0x1d, 0x2e, 0x65,
+	0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x11, 0x47,
+	0x65, 0x74, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x42, 0x79, 0x43, 0x61, 0x72, 0x64,
+	0x12, 0x22, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65,
+	0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79,
+	0x43, 0x61, 0x72, 0x64, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e,
+	0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x45, 0x78, 0x70,
+	0x65, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73,
+	0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65,
+	0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x45,
+	0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x12, 0x1f, 0x2e,
+	0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65,
+	0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20,
+	0x2e, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73,
+	0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72,
+	0x75, 0x62, 0x65, 0x6e, 0x67, 0x6f, 0x6d, 0x65, 0x73, 0x38, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e,
+	0x67, 0x2d, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x6c, 0x2d, 0x66, 0x69, 0x6e, 0x61, 0x6e,
+	0x63, 0x65, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x2f,
+	0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_expenses_proto_rawDescOnce sync.Once
+	file_expenses_proto_rawDescData = file_expenses_proto_rawDesc
+)
+
+func file_expenses_proto_rawDescGZIP() []byte {
+	file_expenses_proto_rawDescOnce.Do(func() {
+		file_expenses_proto_rawDescData = protoimpl.X.CompressGZIP(file_expenses_proto_rawDescData)
+	})
+	return file_expenses_proto_rawDescData
+}
+
+var file_expenses_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_expenses_proto_goTypes = []interface{}{
+	(*ExpenseSplit)(nil),                    // 0: expenses.ExpenseSplit
+	(*ExpenseCreateRequest)(nil),            // 1: expenses.ExpenseCreateRequest
+	(*ExpenseCreateResponse)(nil),           // 2: expenses.ExpenseCreateResponse
+	(*ExpensesCreateRequest)(nil),           // 3: expenses.ExpensesCreateRequest
+	(*ExpensesCreateResponse)(nil),          // 4: expenses.ExpensesCreateResponse
+	(*ExpenseGetResponse)(nil),              // 5: expenses.ExpenseGetResponse
+	(*ExpensesGetResponse)(nil),             // 6: expenses.ExpensesGetResponse
+	(*ExpensesGetRequestByDate)(nil),        // 7: expenses.ExpensesGetRequestByDate
+	(*ExpensesGetRequestByCategory)(nil),    // 8: expenses.ExpensesGetRequestByCategory
+	(*ExpensesGetRequestBySubCategory)(nil), // 9: expenses.ExpensesGetRequestBySubCategory
+	(*ExpensesGetRequestByCard)(nil),        // 10: expenses.ExpensesGetRequestByCard
+	(*ExpenseUpdateRequest)(nil),            // 11: expenses.ExpenseUpdateRequest
+	(*ExpenseUpdateResponse)(nil),           // 12: expenses.ExpenseUpdateResponse
+	(*ExpensesUpdateRequest)(nil),           // 13: expenses.ExpensesUpdateRequest
+	(*ExpensesUpdateResponse)(nil),          // 14: expenses.ExpensesUpdateResponse
+	(*ExpensesSearchRequest)(nil),           // 15: expenses.ExpensesSearchRequest
+	(*ExpensesSearchResponse)(nil),          // 16: expenses.ExpensesSearchResponse
+	(*ExpensesTotalsRequest)(nil),           // 17: expenses.ExpensesTotalsRequest
+	(*ExpensesTotal)(nil),                   // 18: expenses.ExpensesTotal
+	(*ExpensesTotalsResponse)(nil),          // 19: expenses.ExpensesTotalsResponse
+}
+var file_expenses_proto_depIdxs = []int32{
+	0,  // 0: expenses.ExpenseCreateRequest.splits:type_name -> expenses.ExpenseSplit
+	1,  // 1: expenses.ExpensesCreateRequest.expenses:type_name -> expenses.ExpenseCreateRequest
+	2,  // 2: expenses.ExpensesCreateResponse.ids:type_name -> expenses.ExpenseCreateResponse
+	0,  // 3: expenses.ExpenseGetResponse.splits:type_name -> expenses.ExpenseSplit
+	5,  // 4: expenses.ExpensesGetResponse.expenses:type_name -> expenses.ExpenseGetResponse
+	0,  // 5: expenses.ExpenseUpdateRequest.splits:type_name -> expenses.ExpenseSplit
+	11, // 6: expenses.ExpensesUpdateRequest.expenses:type_name -> expenses.ExpenseUpdateRequest
+	12, // 7: expenses.ExpensesUpdateResponse.ids:type_name -> expenses.ExpenseUpdateResponse
+	5,  // 8: expenses.ExpensesSearchResponse.expenses:type_name -> expenses.ExpenseGetResponse
+	18, // 9: expenses.ExpensesTotalsResponse.totals:type_name -> expenses.ExpensesTotal
+	1,  // 10: expenses.ExpensesService.CreateExpense:input_type -> expenses.ExpenseCreateRequest
+	3,  // 11: expenses.ExpensesService.CreateExpenses:input_type -> expenses.ExpensesCreateRequest
+	11, // 12: expenses.ExpensesService.UpdateExpense:input_type -> expenses.ExpenseUpdateRequest
+	7,  // 13: expenses.ExpensesService.GetExpensesByDate:input_type -> expenses.ExpensesGetRequestByDate
+	8,  // 14: expenses.ExpensesService.GetExpensesByCategory:input_type -> expenses.ExpensesGetRequestByCategory
+	9,  // 15: expenses.ExpensesService.GetExpensesBySubCategory:input_type -> expenses.ExpensesGetRequestBySubCategory
+	10, // 16: expenses.ExpensesService.GetExpensesByCard:input_type -> expenses.ExpensesGetRequestByCard
+	15, // 17: expenses.ExpensesService.SearchExpenses:input_type -> expenses.ExpensesSearchRequest
+	17, // 18: expenses.ExpensesService.GetExpensesTotals:input_type -> expenses.ExpensesTotalsRequest
+	2,  // 19: expenses.ExpensesService.CreateExpense:output_type -> expenses.ExpenseCreateResponse
+	4,  // 20: expenses.ExpensesService.CreateExpenses:output_type -> expenses.ExpensesCreateResponse
+	12, // 21: expenses.ExpensesService.UpdateExpense:output_type -> expenses.ExpenseUpdateResponse
+	6,  // 22: expenses.ExpensesService.GetExpensesByDate:output_type -> expenses.ExpensesGetResponse
+	6,  // 23: expenses.ExpensesService.GetExpensesByCategory:output_type -> expenses.ExpensesGetResponse
+	6,  // 24: expenses.ExpensesService.GetExpensesBySubCategory:output_type -> expenses.ExpensesGetResponse
+	6,  // 25: expenses.ExpensesService.GetExpensesByCard:output_type -> expenses.ExpensesGetResponse
+	16, // 26: expenses.ExpensesService.SearchExpenses:output_type -> expenses.ExpensesSearchResponse
+	19, // 27: expenses.ExpensesService.GetExpensesTotals:output_type -> expenses.ExpensesTotalsResponse
+	19, // [19:28] is the sub-list for method output_type
+	10, // [10:19] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_expenses_proto_init() }
+func file_expenses_proto_init() {
+	if File_expenses_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_expenses_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpenseCreateRequest); i {
+			switch v := v.(*ExpenseSplit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1035,7 +1726,7 @@ func file_expenses_proto_init() {
 			}
 		}
 		file_expenses_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpenseCreateResponse); i {
+			switch v := v.(*ExpenseCreateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1047,7 +1738,7 @@ func file_expenses_proto_init() {
 			}
 		}
 		file_expenses_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpensesCreateRequest); i {
+			switch v := v.(*ExpenseCreateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1059,7 +1750,7 @@ func file_expenses_proto_init() {
 			}
 		}
 		file_expenses_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpensesCreateResponse); i {
+			switch v := v.(*ExpensesCreateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1071,7 +1762,7 @@ func file_expenses_proto_init() {
 			}
 		}
 		file_expenses_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpenseGetResponse); i {
+			switch v := v.(*ExpensesCreateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1083,7 +1774,7 @@ func file_expenses_proto_init() {
 			}
 		}
 		file_expenses_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpensesGetResponse); i {
+			switch v := v.(*ExpenseGetResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1095,7 +1786,7 @@ func file_expenses_proto_init() {
 			}
 		}
 		file_expenses_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpensesGetRequestByDate); i {
+			switch v := v.(*ExpensesGetResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1107,7 +1798,7 @@ func file_expenses_proto_init() {
 			}
 		}
 		file_expenses_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpensesGetRequestByCategory); i {
+			switch v := v.(*ExpensesGetRequestByDate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1119,7 +1810,7 @@ func file_expenses_proto_init() {
 			}
 		}
 		file_expenses_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpensesGetRequestBySubCategory); i {
+			switch v := v.(*ExpensesGetRequestByCategory); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1131,7 +1822,7 @@ func file_expenses_proto_init() {
 			}
 		}
 		file_expenses_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpensesGetRequestByCard); i {
+			switch v := v.(*ExpensesGetRequestBySubCategory); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1143,7 +1834,7 @@ func file_expenses_proto_init() {
 			}
 		}
 		file_expenses_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpenseUpdateRequest); i {
+			switch v := v.(*ExpensesGetRequestByCard); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1155,7 +1846,7 @@ func file_expenses_proto_init() {
 			}
 		}
 		file_expenses_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpenseUpdateResponse); i {
+			switch v := v.(*ExpenseUpdateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1167,7 +1858,7 @@ func file_expenses_proto_init() {
 			}
 		}
 		file_expenses_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpensesUpdateRequest); i {
+			switch v := v.(*ExpenseUpdateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1179,6 +1870,18 @@ func file_expenses_proto_init() {
 			}
 		}
 		file_expenses_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExpensesUpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_expenses_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ExpensesUpdateResponse); i {
 			case 0:
 				return &v.state
@@ -1190,6 +1893,66 @@ func file_expenses_proto_init() {
 				return nil
 			}
 		}
+		file_expenses_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExpensesSearchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_expenses_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExpensesSearchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_expenses_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExpensesTotalsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_expenses_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExpensesTotal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_expenses_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExpensesTotalsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1197,7 +1960,7 @@ func file_expenses_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_expenses_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
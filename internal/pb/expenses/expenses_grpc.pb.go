@@ -29,6 +29,8 @@ type ExpensesServiceClient interface {
 	GetExpensesByCategory(ctx context.Context, in *ExpensesGetRequestByCategory, opts ...grpc.CallOption) (*ExpensesGetResponse, error)
 	GetExpensesBySubCategory(ctx context.Context, in *ExpensesGetRequestBySubCategory, opts ...grpc.CallOption) (*ExpensesGetResponse, error)
 	GetExpensesByCard(ctx context.Context, in *ExpensesGetRequestByCard, opts ...grpc.CallOption) (*ExpensesGetResponse, error)
+	SearchExpenses(ctx context.Context, in *ExpensesSearchRequest, opts ...grpc.CallOption) (*ExpensesSearchResponse, error)
+	GetExpensesTotals(ctx context.Context, in *ExpensesTotalsRequest, opts ...grpc.CallOption) (*ExpensesTotalsResponse, error)
 }
 
 type expensesServiceClient struct {
@@ -102,6 +104,24 @@ func (c *expensesServiceClient) GetExpensesByCard(ctx context.Context, in *Expen
 	return out, nil
 }
 
+func (c *expensesServiceClient) SearchExpenses(ctx context.Context, in *ExpensesSearchRequest, opts ...grpc.CallOption) (*ExpensesSearchResponse, error) {
+	out := new(ExpensesSearchResponse)
+	err := c.cc.Invoke(ctx, "/expenses.ExpensesService/SearchExpenses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expensesServiceClient) GetExpensesTotals(ctx context.Context, in *ExpensesTotalsRequest, opts ...grpc.CallOption) (*ExpensesTotalsResponse, error) {
+	out := new(ExpensesTotalsResponse)
+	err := c.cc.Invoke(ctx, "/expenses.ExpensesService/GetExpensesTotals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExpensesServiceServer is the server API for ExpensesService service.
 // All implementations must embed UnimplementedExpensesServiceServer
 // for forward compatibility
@@ -113,6 +133,8 @@ type ExpensesServiceServer interface {
 	GetExpensesByCategory(context.Context, *ExpensesGetRequestByCategory) (*ExpensesGetResponse, error)
 	GetExpensesBySubCategory(context.Context, *ExpensesGetRequestBySubCategory) (*ExpensesGetResponse, error)
 	GetExpensesByCard(context.Context, *ExpensesGetRequestByCard) (*ExpensesGetResponse, error)
+	SearchExpenses(context.Context, *ExpensesSearchRequest) (*ExpensesSearchResponse, error)
+	GetExpensesTotals(context.Context, *ExpensesTotalsRequest) (*ExpensesTotalsResponse, error)
 	mustEmbedUnimplementedExpensesServiceServer()
 }
 
@@ -141,6 +163,12 @@ func (UnimplementedExpensesServiceServer) GetExpensesBySubCategory(context.Conte
 func (UnimplementedExpensesServiceServer) GetExpensesByCard(context.Context, *ExpensesGetRequestByCard) (*ExpensesGetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetExpensesByCard not implemented")
 }
+func (UnimplementedExpensesServiceServer) SearchExpenses(context.Context, *ExpensesSearchRequest) (*ExpensesSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchExpenses not implemented")
+}
+func (UnimplementedExpensesServiceServer) GetExpensesTotals(context.Context, *ExpensesTotalsRequest) (*ExpensesTotalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExpensesTotals not implemented")
+}
 func (UnimplementedExpensesServiceServer) mustEmbedUnimplementedExpensesServiceServer() {}
 
 // UnsafeExpensesServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -280,6 +308,42 @@ func _ExpensesService_GetExpensesByCard_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExpensesService_SearchExpenses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpensesSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpensesServiceServer).SearchExpenses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/expenses.ExpensesService/SearchExpenses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpensesServiceServer).SearchExpenses(ctx, req.(*ExpensesSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpensesService_GetExpensesTotals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpensesTotalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpensesServiceServer).GetExpensesTotals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/expenses.ExpensesService/GetExpensesTotals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpensesServiceServer).GetExpensesTotals(ctx, req.(*ExpensesTotalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ExpensesService_ServiceDesc is the grpc.ServiceDesc for ExpensesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -315,6 +379,14 @@ var ExpensesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetExpensesByCard",
 			Handler:    _ExpensesService_GetExpensesByCard_Handler,
 		},
+		{
+			MethodName: "SearchExpenses",
+			Handler:    _ExpensesService_SearchExpenses_Handler,
+		},
+		{
+			MethodName: "GetExpensesTotals",
+			Handler:    _ExpensesService_GetExpensesTotals_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "expenses.proto",
@@ -22,8 +22,9 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ExpenseSubCategoryServiceClient interface {
-	CreateExpenseCategory(ctx context.Context, in *ExpenseSubCategoryCreateRequest, opts ...grpc.CallOption) (*ExpenseSubCategoryCreateResponse, error)
-	GetExpenseCategoryByName(ctx context.Context, in *ExpenseSubCategoryGetRequestByName, opts ...grpc.CallOption) (*ExpenseSubCategoryGetResponse, error)
+	CreateExpenseSubCategory(ctx context.Context, in *ExpenseSubCategoryCreateRequest, opts ...grpc.CallOption) (*ExpenseSubCategoryCreateResponse, error)
+	GetExpenseSubCategoryByName(ctx context.Context, in *ExpenseSubCategoryGetRequestByName, opts ...grpc.CallOption) (*ExpenseSubCategoryGetResponse, error)
+	MergeExpenseSubCategory(ctx context.Context, in *ExpenseSubCategoryMergeRequest, opts ...grpc.CallOption) (*ExpenseSubCategoryMergeResponse, error)
 }
 
 type expenseSubCategoryServiceClient struct {
@@ -34,18 +35,27 @@ func NewExpenseSubCategoryServiceClient(cc grpc.ClientConnInterface) ExpenseSubC
 	return &expenseSubCategoryServiceClient{cc}
 }
 
-func (c *expenseSubCategoryServiceClient) CreateExpenseCategory(ctx context.Context, in *ExpenseSubCategoryCreateRequest, opts ...grpc.CallOption) (*ExpenseSubCategoryCreateResponse, error) {
+func (c *expenseSubCategoryServiceClient) CreateExpenseSubCategory(ctx context.Context, in *ExpenseSubCategoryCreateRequest, opts ...grpc.CallOption) (*ExpenseSubCategoryCreateResponse, error) {
 	out := new(ExpenseSubCategoryCreateResponse)
-	err := c.cc.Invoke(ctx, "/expense_subcategories.ExpenseSubCategoryService/CreateExpenseCategory", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/expense_subcategories.ExpenseSubCategoryService/CreateExpenseSubCategory", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *expenseSubCategoryServiceClient) GetExpenseCategoryByName(ctx context.Context, in *ExpenseSubCategoryGetRequestByName, opts ...grpc.CallOption) (*ExpenseSubCategoryGetResponse, error) {
+func (c *expenseSubCategoryServiceClient) GetExpenseSubCategoryByName(ctx context.Context, in *ExpenseSubCategoryGetRequestByName, opts ...grpc.CallOption) (*ExpenseSubCategoryGetResponse, error) {
 	out := new(ExpenseSubCategoryGetResponse)
-	err := c.cc.Invoke(ctx, "/expense_subcategories.ExpenseSubCategoryService/GetExpenseCategoryByName", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/expense_subcategories.ExpenseSubCategoryService/GetExpenseSubCategoryByName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expenseSubCategoryServiceClient) MergeExpenseSubCategory(ctx context.Context, in *ExpenseSubCategoryMergeRequest, opts ...grpc.CallOption) (*ExpenseSubCategoryMergeResponse, error) {
+	out := new(ExpenseSubCategoryMergeResponse)
+	err := c.cc.Invoke(ctx, "/expense_subcategories.ExpenseSubCategoryService/MergeExpenseSubCategory", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -56,8 +66,9 @@ func (c *expenseSubCategoryServiceClient) GetExpenseCategoryByName(ctx context.C
 // All implementations must embed UnimplementedExpenseSubCategoryServiceServer
 // for forward compatibility
 type ExpenseSubCategoryServiceServer interface {
-	CreateExpenseCategory(context.Context, *ExpenseSubCategoryCreateRequest) (*ExpenseSubCategoryCreateResponse, error)
-	GetExpenseCategoryByName(context.Context, *ExpenseSubCategoryGetRequestByName) (*ExpenseSubCategoryGetResponse, error)
+	CreateExpenseSubCategory(context.Context, *ExpenseSubCategoryCreateRequest) (*ExpenseSubCategoryCreateResponse, error)
+	GetExpenseSubCategoryByName(context.Context, *ExpenseSubCategoryGetRequestByName) (*ExpenseSubCategoryGetResponse, error)
+	MergeExpenseSubCategory(context.Context, *ExpenseSubCategoryMergeRequest) (*ExpenseSubCategoryMergeResponse, error)
 	mustEmbedUnimplementedExpenseSubCategoryServiceServer()
 }
 
@@ -65,11 +76,14 @@ type ExpenseSubCategoryServiceServer interface {
 type UnimplementedExpenseSubCategoryServiceServer struct {
 }
 
-func (UnimplementedExpenseSubCategoryServiceServer) CreateExpenseCategory(context.Context, *ExpenseSubCategoryCreateRequest) (*ExpenseSubCategoryCreateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateExpenseCategory not implemented")
+func (UnimplementedExpenseSubCategoryServiceServer) CreateExpenseSubCategory(context.Context, *ExpenseSubCategoryCreateRequest) (*ExpenseSubCategoryCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateExpenseSubCategory not implemented")
 }
-func (UnimplementedExpenseSubCategoryServiceServer) GetExpenseCategoryByName(context.Context, *ExpenseSubCategoryGetRequestByName) (*ExpenseSubCategoryGetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetExpenseCategoryByName not implemented")
+func (UnimplementedExpenseSubCategoryServiceServer) GetExpenseSubCategoryByName(context.Context, *ExpenseSubCategoryGetRequestByName) (*ExpenseSubCategoryGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExpenseSubCategoryByName not implemented")
+}
+func (UnimplementedExpenseSubCategoryServiceServer) MergeExpenseSubCategory(context.Context, *ExpenseSubCategoryMergeRequest) (*ExpenseSubCategoryMergeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeExpenseSubCategory not implemented")
 }
 func (UnimplementedExpenseSubCategoryServiceServer) mustEmbedUnimplementedExpenseSubCategoryServiceServer() {
 }
@@ -85,38 +99,56 @@ func RegisterExpenseSubCategoryServiceServer(s grpc.ServiceRegistrar, srv Expens
 	s.RegisterService(&ExpenseSubCategoryService_ServiceDesc, srv)
 }
 
-func _ExpenseSubCategoryService_CreateExpenseCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ExpenseSubCategoryService_CreateExpenseSubCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExpenseSubCategoryCreateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ExpenseSubCategoryServiceServer).CreateExpenseCategory(ctx, in)
+		return srv.(ExpenseSubCategoryServiceServer).CreateExpenseSubCategory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/expense_subcategories.ExpenseSubCategoryService/CreateExpenseCategory",
+		FullMethod: "/expense_subcategories.ExpenseSubCategoryService/CreateExpenseSubCategory",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExpenseSubCategoryServiceServer).CreateExpenseCategory(ctx, req.(*ExpenseSubCategoryCreateRequest))
+		return srv.(ExpenseSubCategoryServiceServer).CreateExpenseSubCategory(ctx, req.(*ExpenseSubCategoryCreateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ExpenseSubCategoryService_GetExpenseCategoryByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ExpenseSubCategoryService_GetExpenseSubCategoryByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExpenseSubCategoryGetRequestByName)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ExpenseSubCategoryServiceServer).GetExpenseCategoryByName(ctx, in)
+		return srv.(ExpenseSubCategoryServiceServer).GetExpenseSubCategoryByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/expense_subcategories.ExpenseSubCategoryService/GetExpenseSubCategoryByName",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpenseSubCategoryServiceServer).GetExpenseSubCategoryByName(ctx, req.(*ExpenseSubCategoryGetRequestByName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpenseSubCategoryService_MergeExpenseSubCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpenseSubCategoryMergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpenseSubCategoryServiceServer).MergeExpenseSubCategory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/expense_subcategories.ExpenseSubCategoryService/GetExpenseCategoryByName",
+		FullMethod: "/expense_subcategories.ExpenseSubCategoryService/MergeExpenseSubCategory",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExpenseSubCategoryServiceServer).GetExpenseCategoryByName(ctx, req.(*ExpenseSubCategoryGetRequestByName))
+		return srv.(ExpenseSubCategoryServiceServer).MergeExpenseSubCategory(ctx, req.(*ExpenseSubCategoryMergeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -129,12 +161,16 @@ var ExpenseSubCategoryService_ServiceDesc = grpc.ServiceDesc{
 	HandlerType: (*ExpenseSubCategoryServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "CreateExpenseCategory",
-			Handler:    _ExpenseSubCategoryService_CreateExpenseCategory_Handler,
+			MethodName: "CreateExpenseSubCategory",
+			Handler:    _ExpenseSubCategoryService_CreateExpenseSubCategory_Handler,
+		},
+		{
+			MethodName: "GetExpenseSubCategoryByName",
+			Handler:    _ExpenseSubCategoryService_GetExpenseSubCategoryByName_Handler,
 		},
 		{
-			MethodName: "GetExpenseCategoryByName",
-			Handler:    _ExpenseSubCategoryService_GetExpenseCategoryByName_Handler,
+			MethodName: "MergeExpenseSubCategory",
+			Handler:    _ExpenseSubCategoryService_MergeExpenseSubCategory_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -24,6 +24,7 @@ const _ = grpc.SupportPackageIsVersion7
 type ServiceClient interface {
 	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
 	GetByName(ctx context.Context, in *GetRequestByName, opts ...grpc.CallOption) (*GetResponse, error)
+	Merge(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*MergeResponse, error)
 }
 
 type serviceClient struct {
@@ -52,12 +53,22 @@ func (c *serviceClient) GetByName(ctx context.Context, in *GetRequestByName, opt
 	return out, nil
 }
 
+func (c *serviceClient) Merge(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*MergeResponse, error) {
+	out := new(MergeResponse)
+	err := c.cc.Invoke(ctx, "/income_categories.Service/Merge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceServer is the server API for Service service.
 // All implementations must embed UnimplementedServiceServer
 // for forward compatibility
 type ServiceServer interface {
 	Create(context.Context, *CreateRequest) (*CreateResponse, error)
 	GetByName(context.Context, *GetRequestByName) (*GetResponse, error)
+	Merge(context.Context, *MergeRequest) (*MergeResponse, error)
 	mustEmbedUnimplementedServiceServer()
 }
 
@@ -71,6 +82,9 @@ func (UnimplementedServiceServer) Create(context.Context, *CreateRequest) (*Crea
 func (UnimplementedServiceServer) GetByName(context.Context, *GetRequestByName) (*GetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetByName not implemented")
 }
+func (UnimplementedServiceServer) Merge(context.Context, *MergeRequest) (*MergeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Merge not implemented")
+}
 func (UnimplementedServiceServer) mustEmbedUnimplementedServiceServer() {}
 
 // UnsafeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -120,6 +134,24 @@ func _Service_GetByName_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_Merge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Merge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/income_categories.Service/Merge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Merge(ctx, req.(*MergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Service_ServiceDesc is the grpc.ServiceDesc for Service service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -135,6 +167,10 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetByName",
 			Handler:    _Service_GetByName_Handler,
 		},
+		{
+			MethodName: "Merge",
+			Handler:    _Service_Merge_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "income_categories.proto",
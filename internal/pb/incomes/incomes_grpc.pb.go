@@ -28,6 +28,9 @@ type ServiceClient interface {
 	GetByDate(ctx context.Context, in *GetRequestByDate, opts ...grpc.CallOption) (*GetSeveralResponse, error)
 	GetByCategory(ctx context.Context, in *GetRequestByCategory, opts ...grpc.CallOption) (*GetSeveralResponse, error)
 	GetByCard(ctx context.Context, in *GetRequestByCard, opts ...grpc.CallOption) (*GetSeveralResponse, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	GetTotals(ctx context.Context, in *TotalsRequest, opts ...grpc.CallOption) (*TotalsResponse, error)
+	GetCashFlow(ctx context.Context, in *CashFlowRequest, opts ...grpc.CallOption) (*CashFlowResponse, error)
 }
 
 type serviceClient struct {
@@ -92,6 +95,33 @@ func (c *serviceClient) GetByCard(ctx context.Context, in *GetRequestByCard, opt
 	return out, nil
 }
 
+func (c *serviceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/incomes.Service/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetTotals(ctx context.Context, in *TotalsRequest, opts ...grpc.CallOption) (*TotalsResponse, error) {
+	out := new(TotalsResponse)
+	err := c.cc.Invoke(ctx, "/incomes.Service/GetTotals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetCashFlow(ctx context.Context, in *CashFlowRequest, opts ...grpc.CallOption) (*CashFlowResponse, error) {
+	out := new(CashFlowResponse)
+	err := c.cc.Invoke(ctx, "/incomes.Service/GetCashFlow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceServer is the server API for Service service.
 // All implementations must embed UnimplementedServiceServer
 // for forward compatibility
@@ -102,6 +132,9 @@ type ServiceServer interface {
 	GetByDate(context.Context, *GetRequestByDate) (*GetSeveralResponse, error)
 	GetByCategory(context.Context, *GetRequestByCategory) (*GetSeveralResponse, error)
 	GetByCard(context.Context, *GetRequestByCard) (*GetSeveralResponse, error)
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	GetTotals(context.Context, *TotalsRequest) (*TotalsResponse, error)
+	GetCashFlow(context.Context, *CashFlowRequest) (*CashFlowResponse, error)
 	mustEmbedUnimplementedServiceServer()
 }
 
@@ -127,6 +160,15 @@ func (UnimplementedServiceServer) GetByCategory(context.Context, *GetRequestByCa
 func (UnimplementedServiceServer) GetByCard(context.Context, *GetRequestByCard) (*GetSeveralResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetByCard not implemented")
 }
+func (UnimplementedServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedServiceServer) GetTotals(context.Context, *TotalsRequest) (*TotalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTotals not implemented")
+}
+func (UnimplementedServiceServer) GetCashFlow(context.Context, *CashFlowRequest) (*CashFlowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCashFlow not implemented")
+}
 func (UnimplementedServiceServer) mustEmbedUnimplementedServiceServer() {}
 
 // UnsafeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -248,6 +290,60 @@ func _Service_GetByCard_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/incomes.Service/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetTotals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TotalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetTotals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/incomes.Service/GetTotals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetTotals(ctx, req.(*TotalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetCashFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CashFlowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetCashFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/incomes.Service/GetCashFlow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetCashFlow(ctx, req.(*CashFlowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Service_ServiceDesc is the grpc.ServiceDesc for Service service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -279,6 +375,18 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetByCard",
 			Handler:    _Service_GetByCard_Handler,
 		},
+		{
+			MethodName: "Search",
+			Handler:    _Service_Search_Handler,
+		},
+		{
+			MethodName: "GetTotals",
+			Handler:    _Service_GetTotals_Handler,
+		},
+		{
+			MethodName: "GetCashFlow",
+			Handler:    _Service_GetCashFlow_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "incomes.proto",
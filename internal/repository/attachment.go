@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+//go:generate gowrap gen -g -i AttachmentRepo -t ./templates/log_template.go.tmpl -o ./database/attachment/with_logs_by_template.go
+//go:generate gowrap gen -g -i AttachmentRepo -t ./templates/red_template.go.tmpl -o ./database/attachment/with_red_by_template.go
+// AttachmentRepo defines the attachment repository interface.
+// Attachments are owned by a user: lookups take the owner user id right after the context.
+// InsertAttachment returns ErrNotFound if the expense or income of the attachment is not owned by its user.
+type AttachmentRepo interface {
+	InsertAttachment(context.Context, models.AttachmentTable) (int64, error)
+	GetAttachmentByID(context.Context, int64, int64) (models.AttachmentTable, error)
+	GetAttachmentsByExpense(context.Context, int64, int64) ([]models.AttachmentTable, error)
+	GetAttachmentsByIncome(context.Context, int64, int64) ([]models.AttachmentTable, error)
+	DeleteAttachment(context.Context, int64, int64) error
+}
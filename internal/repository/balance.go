@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+//go:generate gowrap gen -g -i BalanceRepo -t ./templates/log_template.go.tmpl -o ./database/balance/with_logs_by_template.go
+//go:generate gowrap gen -g -i BalanceRepo -t ./templates/red_template.go.tmpl -o ./database/balance/with_red_by_template.go
+// BalanceRepo defines the card balance and reconciliation repository interface.
+// Lookups take the owner user id and the card id right after the context.
+// GetCardMovements returns the movements of the card with a date between the min and the max dates, both inclusive,
+// sorted by date. ClearCardMovements marks movements of the card as cleared.
+type BalanceRepo interface {
+	GetCardMovements(context.Context, int64, int64, time.Time, time.Time) ([]models.CardMovement, error)
+	ClearCardMovements(context.Context, int64, int64, []models.MovementRef) error
+	InsertReconciliation(context.Context, models.ReconciliationTable) (int64, error)
+	GetReconciliations(context.Context, int64, int64) ([]models.ReconciliationTable, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+//go:generate gowrap gen -g -i BudgetRepo -t ./templates/log_template.go.tmpl -o ./database/budget/with_logs_by_template.go
+//go:generate gowrap gen -g -i BudgetRepo -t ./templates/red_template.go.tmpl -o ./database/budget/with_red_by_template.go
+// BudgetRepo defines the budget repository interface.
+// Budgets are owned by a user: lookups take the owner user id right after the context.
+// GetMonthlySpending sums the expenses of the user by month, subcategory and currency, from the min date
+// up to, but not including, the max date.
+type BudgetRepo interface {
+	InsertBudget(context.Context, models.BudgetTable) (int64, error)
+	UpdateBudget(context.Context, models.BudgetTable) (int64, error)
+	GetBudgetByID(context.Context, int64, int64) (models.BudgetView, error)
+	GetBudgets(context.Context, int64) ([]models.BudgetView, error)
+	DeleteBudget(context.Context, int64, int64) error
+	GetMonthlySpending(context.Context, int64, time.Time, time.Time) ([]models.MonthlySpending, error)
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// Attachment implements the attachment repository methods
+type Attachment struct {
+	repository []models.AttachmentTable
+}
+
+// NewAttachment creates an Attachment cache
+func NewAttachment(repository []models.AttachmentTable) Attachment {
+	return Attachment{
+		repository: repository,
+	}
+}
+
+// InsertAttachment inserts an attachment on the cache and returns its id
+func (ac *Attachment) InsertAttachment(ctx context.Context, attachment models.AttachmentTable) (int64, error) {
+
+	var lastID int64
+	for _, existing := range ac.repository {
+		if existing.ID > lastID {
+			lastID = existing.ID
+		}
+	}
+
+	attachment.ID = lastID + 1
+	ac.repository = append(ac.repository, attachment)
+
+	return attachment.ID, nil
+}
+
+// GetAttachmentByID returns the attachment from the cache if one with that id exists
+func (ac *Attachment) GetAttachmentByID(ctx context.Context, userID int64, id int64) (models.AttachmentTable, error) {
+
+	for _, attachment := range ac.repository {
+		if attachment.ID == id && attachment.UserID == userID {
+			return attachment, nil
+		}
+	}
+
+	return models.AttachmentTable{}, AttachmentNotFoundByIDError{
+		id: id,
+	}
+}
+
+// GetAttachmentsByExpense returns the attachments of an expense of the user from the cache
+func (ac *Attachment) GetAttachmentsByExpense(ctx context.Context, userID int64, expenseID int64) ([]models.AttachmentTable, error) {
+
+	attachments := []models.AttachmentTable{}
+	for _, attachment := range ac.repository {
+		if attachment.ExpenseID == expenseID && attachment.UserID == userID {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	return attachments, nil
+}
+
+// GetAttachmentsByIncome returns the attachments of an income of the user from the cache
+func (ac *Attachment) GetAttachmentsByIncome(ctx context.Context, userID int64, incomeID int64) ([]models.AttachmentTable, error) {
+
+	attachments := []models.AttachmentTable{}
+	for _, attachment := range ac.repository {
+		if attachment.IncomeID == incomeID && attachment.UserID == userID {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	return attachments, nil
+}
+
+// DeleteAttachment deletes the attachment from the cache if it exists
+func (ac *Attachment) DeleteAttachment(ctx context.Context, userID int64, id int64) error {
+
+	for idx, attachment := range ac.repository {
+		if attachment.ID == id && attachment.UserID == userID {
+			ac.repository = append(ac.repository[:idx], ac.repository[idx+1:]...)
+			return nil
+		}
+	}
+
+	return AttachmentNotFoundByIDError{
+		id: id,
+	}
+}
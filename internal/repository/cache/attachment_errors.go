@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+// AttachmentNotFoundByIDError error when an attachment is not found by id on the cache
+type AttachmentNotFoundByIDError struct {
+	id int64
+}
+
+// Error is the string representation of AttachmentNotFoundByIDError
+func (anfe AttachmentNotFoundByIDError) Error() string {
+	return fmt.Sprintf("error: attachment with id: %d was not found by id in the repository", anfe.id)
+}
+
+// Unwrap allows AttachmentNotFoundByIDError to match repository.ErrNotFound
+func (anfe AttachmentNotFoundByIDError) Unwrap() error {
+	return repository.ErrNotFound
+}
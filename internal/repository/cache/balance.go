@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// Balance implements the balance repository methods.
+// Movements are kept by card id, as card ids are not shared between users.
+type Balance struct {
+	movements       map[int64][]models.CardMovement
+	reconciliations []models.ReconciliationTable
+}
+
+// NewBalance creates a Balance cache
+func NewBalance(movements map[int64][]models.CardMovement) Balance {
+	return Balance{
+		movements:       movements,
+		reconciliations: []models.ReconciliationTable{},
+	}
+}
+
+// GetCardMovements returns the movements of the card from the cache in the dates' range, sorted by date
+func (bc *Balance) GetCardMovements(
+	ctx context.Context,
+	userID int64,
+	cardID int64,
+	minDate time.Time,
+	maxDate time.Time,
+) ([]models.CardMovement, error) {
+
+	movements := []models.CardMovement{}
+	for _, movement := range bc.movements[cardID] {
+		if !movement.Date.Before(minDate) && !movement.Date.After(maxDate) {
+			movements = append(movements, movement)
+		}
+	}
+
+	sort.SliceStable(movements, func(i, j int) bool {
+		return movements[i].Date.Before(movements[j].Date)
+	})
+
+	return movements, nil
+}
+
+// ClearCardMovements marks the movements of the card on the cache as cleared, if all of them exist
+func (bc *Balance) ClearCardMovements(ctx context.Context, userID int64, cardID int64, refs []models.MovementRef) error {
+
+	indexes := []int{}
+	for _, ref := range refs {
+		found := false
+		for idx, movement := range bc.movements[cardID] {
+			if movement.Ref() == ref {
+				indexes = append(indexes, idx)
+				found = true
+			}
+		}
+		if !found {
+			return CardMovementNotFoundError{
+				kind:   ref.Kind,
+				id:     ref.ID,
+				cardID: cardID,
+			}
+		}
+	}
+
+	for _, idx := range indexes {
+		bc.movements[cardID][idx].Cleared = true
+	}
+
+	return nil
+}
+
+// InsertReconciliation inserts a reconciliation on the cache
+func (bc *Balance) InsertReconciliation(ctx context.Context, reconciliation models.ReconciliationTable) (int64, error) {
+
+	reconciliation.ID = int64(len(bc.reconciliations) + 1)
+	bc.reconciliations = append(bc.reconciliations, reconciliation)
+
+	return reconciliation.ID, nil
+}
+
+// GetReconciliations returns the reconciliations of the card from the cache, the latest first
+func (bc *Balance) GetReconciliations(ctx context.Context, userID int64, cardID int64) ([]models.ReconciliationTable, error) {
+
+	reconciliations := []models.ReconciliationTable{}
+	for idx := len(bc.reconciliations) - 1; idx >= 0; idx-- {
+		reconciliation := bc.reconciliations[idx]
+		if reconciliation.UserID == userID && reconciliation.CardID == cardID {
+			reconciliations = append(reconciliations, reconciliation)
+		}
+	}
+
+	sort.SliceStable(reconciliations, func(i, j int) bool {
+		return reconciliations[i].Date.After(reconciliations[j].Date)
+	})
+
+	return reconciliations, nil
+}
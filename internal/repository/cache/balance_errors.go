@@ -0,0 +1,24 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+// CardMovementNotFoundError error when a movement is not found on the movements of a card on the cache
+type CardMovementNotFoundError struct {
+	kind   string
+	id     int64
+	cardID int64
+}
+
+// Error is the string representation of CardMovementNotFoundError
+func (cmnfe CardMovementNotFoundError) Error() string {
+	return fmt.Sprintf("error: %s with id: %d was not found on the movements of card %d in the repository", cmnfe.kind, cmnfe.id, cmnfe.cardID)
+}
+
+// Unwrap allows CardMovementNotFoundError to match repository.ErrNotFound
+func (cmnfe CardMovementNotFoundError) Unwrap() error {
+	return repository.ErrNotFound
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// Budget implements the budget repository methods
+type Budget struct {
+	repository []models.BudgetView
+	spending   []models.MonthlySpending
+}
+
+// NewBudget creates a Budget cache with the monthly spending its reports are computed from
+func NewBudget(repository []models.BudgetView, spending []models.MonthlySpending) Budget {
+	return Budget{
+		repository: repository,
+		spending:   spending,
+	}
+}
+
+// InsertBudget inserts a budget on the cache
+func (bc *Budget) InsertBudget(ctx context.Context, budget models.BudgetTable) (int64, error) {
+
+	bc.repository = append(bc.repository, budgetTableToView(budget))
+
+	return 1, nil
+}
+
+// UpdateBudget updates a budget on the cache if it exists
+func (bc *Budget) UpdateBudget(ctx context.Context, budget models.BudgetTable) (int64, error) {
+
+	for idx, existing := range bc.repository {
+		if existing.ID == budget.ID && existing.UserID == budget.UserID {
+			bc.repository[idx] = budgetTableToView(budget)
+			return budget.ID, nil
+		}
+	}
+
+	return 0, BudgetNotFoundByIDError{
+		id: budget.ID,
+	}
+}
+
+// GetBudgetByID returns the budget from the cache if a budget with that id exists
+func (bc *Budget) GetBudgetByID(ctx context.Context, userID int64, id int64) (models.BudgetView, error) {
+
+	for _, budget := range bc.repository {
+		if budget.ID == id && budget.UserID == userID {
+			return budget, nil
+		}
+	}
+
+	return models.BudgetView{}, BudgetNotFoundByIDError{
+		id: id,
+	}
+}
+
+// GetBudgets returns the budgets of the user from the cache
+func (bc *Budget) GetBudgets(ctx context.Context, userID int64) ([]models.BudgetView, error) {
+
+	budgets := []models.BudgetView{}
+	for _, budget := range bc.repository {
+		if budget.UserID == userID {
+			budgets = append(budgets, budget)
+		}
+	}
+
+	return budgets, nil
+}
+
+// DeleteBudget deletes the budget from the cache if it exists
+func (bc *Budget) DeleteBudget(ctx context.Context, userID int64, id int64) error {
+
+	for idx, budget := range bc.repository {
+		if budget.ID == id && budget.UserID == userID {
+			bc.repository = append(bc.repository[:idx], bc.repository[idx+1:]...)
+			return nil
+		}
+	}
+
+	return BudgetNotFoundByIDError{
+		id: id,
+	}
+}
+
+// GetMonthlySpending returns the cached monthly spending of the months in the dates interval.
+// The cache does not keep spending per user.
+func (bc *Budget) GetMonthlySpending(ctx context.Context, userID int64, minDate time.Time, maxDate time.Time) ([]models.MonthlySpending, error) {
+
+	spending := []models.MonthlySpending{}
+	for _, monthSpending := range bc.spending {
+		if !monthSpending.Month.Before(minDate) && monthSpending.Month.Before(maxDate) {
+			spending = append(spending, monthSpending)
+		}
+	}
+
+	return spending, nil
+}
+
+func budgetTableToView(budget models.BudgetTable) models.BudgetView {
+	return models.BudgetView{
+		ID:            budget.ID,
+		CategoryID:    budget.CategoryID,
+		SubCategoryID: budget.SubCategoryID,
+		MonthLimit:    budget.MonthLimit,
+		Rollover:      budget.Rollover,
+		StartMonth:    budget.StartMonth,
+		UserID:        budget.UserID,
+	}
+}
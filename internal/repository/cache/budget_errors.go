@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+// BudgetNotFoundByIDError error when a budget is not found by id on the cache
+type BudgetNotFoundByIDError struct {
+	id int64
+}
+
+// Error is the string representation of BudgetNotFoundByIDError
+func (bnfe BudgetNotFoundByIDError) Error() string {
+	return fmt.Sprintf("error: budget with id: %d was not found by id in the repository", bnfe.id)
+}
+
+// Unwrap allows BudgetNotFoundByIDError to match repository.ErrNotFound
+func (bnfe BudgetNotFoundByIDError) Unwrap() error {
+	return repository.ErrNotFound
+}
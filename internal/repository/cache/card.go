@@ -21,7 +21,7 @@ func NewCard(repository []models.CardTable) Card {
 // InsertCard inserts a card on the cache if card does not exist
 func (c Card) InsertCard(ctx context.Context, card models.CardTable) (int64, error) {
 
-	existingCard, err := c.GetCardByID(ctx, card.ID)
+	existingCard, err := c.GetCardByID(ctx, card.UserID, card.ID)
 	if err == nil {
 		return 0, CardAlreadyExistsError{
 			id: existingCard.ID,
@@ -37,7 +37,7 @@ func (c Card) InsertCard(ctx context.Context, card models.CardTable) (int64, err
 func (c Card) UpdateCard(ctx context.Context, updatedCard models.CardTable) (int64, error) {
 
 	for idx, card := range c.repository {
-		if card.ID == updatedCard.ID {
+		if card.ID == updatedCard.ID && card.UserID == updatedCard.UserID {
 			c.repository[idx] = updatedCard
 			return updatedCard.ID, nil
 		}
@@ -49,10 +49,10 @@ func (c Card) UpdateCard(ctx context.Context, updatedCard models.CardTable) (int
 }
 
 // GetCardByID returns the card from the cache if card with that id exists
-func (c Card) GetCardByID(ctx context.Context, id int64) (models.CardTable, error) {
+func (c Card) GetCardByID(ctx context.Context, userID int64, id int64) (models.CardTable, error) {
 
 	for _, card := range c.repository {
-		if card.ID == id {
+		if card.ID == id && card.UserID == userID {
 			return card, nil
 		}
 	}
@@ -63,9 +63,9 @@ func (c Card) GetCardByID(ctx context.Context, id int64) (models.CardTable, erro
 }
 
 // GetCardByName returns the card from the cache if card with that name exists
-func (c Card) GetCardByName(ctx context.Context, name string) (models.CardTable, error) {
+func (c Card) GetCardByName(ctx context.Context, userID int64, name string) (models.CardTable, error) {
 	for _, card := range c.repository {
-		if card.Name == name {
+		if card.Name == name && card.UserID == userID {
 			return card, nil
 		}
 	}
@@ -75,11 +75,24 @@ func (c Card) GetCardByName(ctx context.Context, name string) (models.CardTable,
 	}
 }
 
+// GetCards returns the cards of the user from the cache
+func (c Card) GetCards(ctx context.Context, userID int64) ([]models.CardTable, error) {
+
+	cards := []models.CardTable{}
+	for _, card := range c.repository {
+		if card.UserID == userID {
+			cards = append(cards, card)
+		}
+	}
+
+	return cards, nil
+}
+
 // DeleteCard deletes the card from cache if it exists
-func (c Card) DeleteCard(ctx context.Context, id int64) error {
+func (c Card) DeleteCard(ctx context.Context, userID int64, id int64) error {
 
 	for _, card := range c.repository {
-		if card.ID == id {
+		if card.ID == id && card.UserID == userID {
 			// c.repository = append(c.repository[:idx], c.repository[idx+1:]...)
 			return nil
 		}
@@ -89,3 +102,11 @@ func (c Card) DeleteCard(ctx context.Context, id int64) error {
 		id: id,
 	}
 }
+
+// transactionCurrency is the currency of an expense or income, which is the currency of its card unless set
+func transactionCurrency(currency string, card models.CardTable) string {
+	if currency != "" {
+		return currency
+	}
+	return card.Currency
+}
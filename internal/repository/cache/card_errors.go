@@ -1,6 +1,10 @@
 package cache
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
 
 // CardNotFoundByIDError error when a card is not found by id on the cache
 type CardNotFoundByIDError struct {
@@ -12,6 +16,11 @@ func (cnfei CardNotFoundByIDError) Error() string {
 	return fmt.Sprintf("error: card with id: %d was not found by id in the repository", cnfei.id)
 }
 
+// Unwrap allows CardNotFoundByIDError to match repository.ErrNotFound
+func (cnfei CardNotFoundByIDError) Unwrap() error {
+	return repository.ErrNotFound
+}
+
 // CardNotFoundByNameError error when a card is not found by name on the cache
 type CardNotFoundByNameError struct {
 	name string
@@ -22,6 +31,11 @@ func (cnfen CardNotFoundByNameError) Error() string {
 	return fmt.Sprintf("error: card with id: %s was not found by name in the repository", cnfen.name)
 }
 
+// Unwrap allows CardNotFoundByNameError to match repository.ErrNotFound
+func (cnfen CardNotFoundByNameError) Unwrap() error {
+	return repository.ErrNotFound
+}
+
 // CardAlreadyExistsError error when a card already exists on the cache
 type CardAlreadyExistsError struct {
 	id int64
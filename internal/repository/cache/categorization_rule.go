@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"sort"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// CategorizationRule implements the categorization rule repository methods
+type CategorizationRule struct {
+	repository []models.CategorizationRuleView
+}
+
+// NewCategorizationRule creates a CategorizationRule cache
+func NewCategorizationRule(repository []models.CategorizationRuleView) CategorizationRule {
+	return CategorizationRule{
+		repository: repository,
+	}
+}
+
+// InsertCategorizationRule inserts a categorization rule on the cache
+func (cr *CategorizationRule) InsertCategorizationRule(ctx context.Context, rule models.CategorizationRuleTable) (int64, error) {
+
+	cr.repository = append(cr.repository, tableToView(rule))
+
+	return 1, nil
+}
+
+// UpdateCategorizationRule updates a categorization rule on the cache if it exists
+func (cr *CategorizationRule) UpdateCategorizationRule(ctx context.Context, rule models.CategorizationRuleTable) (int64, error) {
+
+	for idx, existing := range cr.repository {
+		if existing.ID == rule.ID && existing.UserID == rule.UserID {
+			cr.repository[idx] = tableToView(rule)
+			return rule.ID, nil
+		}
+	}
+
+	return 0, CategorizationRuleNotFoundByIDError{
+		id: rule.ID,
+	}
+}
+
+// GetCategorizationRuleByID returns the categorization rule from the cache if a rule with that id exists
+func (cr *CategorizationRule) GetCategorizationRuleByID(ctx context.Context, userID int64, id int64) (models.CategorizationRuleView, error) {
+
+	for _, rule := range cr.repository {
+		if rule.ID == id && rule.UserID == userID {
+			return rule, nil
+		}
+	}
+
+	return models.CategorizationRuleView{}, CategorizationRuleNotFoundByIDError{
+		id: id,
+	}
+}
+
+// GetCategorizationRules returns the categorization rules of the user from the cache, by ascending priority and id
+func (cr *CategorizationRule) GetCategorizationRules(ctx context.Context, userID int64) ([]models.CategorizationRuleView, error) {
+
+	rules := []models.CategorizationRuleView{}
+	for _, rule := range cr.repository {
+		if rule.UserID == userID {
+			rules = append(rules, rule)
+		}
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority < rules[j].Priority
+		}
+		return rules[i].ID < rules[j].ID
+	})
+
+	return rules, nil
+}
+
+// DeleteCategorizationRule deletes the categorization rule from the cache if it exists
+func (cr *CategorizationRule) DeleteCategorizationRule(ctx context.Context, userID int64, id int64) error {
+
+	for idx, rule := range cr.repository {
+		if rule.ID == id && rule.UserID == userID {
+			cr.repository = append(cr.repository[:idx], cr.repository[idx+1:]...)
+			return nil
+		}
+	}
+
+	return CategorizationRuleNotFoundByIDError{
+		id: id,
+	}
+}
+
+func tableToView(rule models.CategorizationRuleTable) models.CategorizationRuleView {
+	return models.CategorizationRuleView{
+		ID:                 rule.ID,
+		Priority:           rule.Priority,
+		DescriptionPattern: rule.DescriptionPattern,
+		DescriptionRegex:   rule.DescriptionRegex,
+		MinValue:           rule.MinValue,
+		MaxValue:           rule.MaxValue,
+		CardID:             rule.CardID,
+		SubCategoryID:      rule.SubCategoryID,
+		IncomeCategoryID:   rule.IncomeCategoryID,
+		UserID:             rule.UserID,
+	}
+}
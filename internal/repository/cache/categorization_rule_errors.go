@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+// CategorizationRuleNotFoundByIDError error when a categorization rule is not found by id on the cache
+type CategorizationRuleNotFoundByIDError struct {
+	id int64
+}
+
+// Error is the string representation of CategorizationRuleNotFoundByIDError
+func (crnfe CategorizationRuleNotFoundByIDError) Error() string {
+	return fmt.Sprintf("error: categorization rule with id: %d was not found by id in the repository", crnfe.id)
+}
+
+// Unwrap allows CategorizationRuleNotFoundByIDError to match repository.ErrNotFound
+func (crnfe CategorizationRuleNotFoundByIDError) Unwrap() error {
+	return repository.ErrNotFound
+}
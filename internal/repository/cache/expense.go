@@ -2,8 +2,10 @@ package cache
 
 import (
 	"context"
+	"sort"
 	"time"
 
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
 )
 
@@ -30,19 +32,45 @@ func NewExpense(
 	}
 }
 
-// InsertExpense inserts an expense on the cache if expense category does not exist
+// InsertExpense inserts an expense on the cache unless its card already has an expense with its external reference
 func (ec *Expense) InsertExpense(ctx context.Context, e models.ExpenseTable) (int64, error) {
 
+	for _, exp := range ec.repository {
+		if e.ExternalReference != "" && exp.CardID == e.CardID && exp.ExternalReference == e.ExternalReference {
+			return 0, ExpenseExternalReferenceExistsError{
+				reference: e.ExternalReference,
+			}
+		}
+	}
+
 	ec.repository = append(ec.repository, e)
 
 	return 1, nil
 }
 
+// InsertExpenses inserts several expenses on the cache, or none of them if one fails, as the database does
+func (ec *Expense) InsertExpenses(ctx context.Context, exps []models.ExpenseTable) ([]int64, error) {
+
+	inserted := len(ec.repository)
+
+	ids := make([]int64, 0, len(exps))
+	for idx, e := range exps {
+		id, err := ec.InsertExpense(ctx, e)
+		if err != nil {
+			ec.repository = ec.repository[:inserted]
+			return []int64{}, repository.BatchItemError{Index: idx, Err: err}
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
 // UpdateExpense updates an expense on the cache if it exists
 func (ec *Expense) UpdateExpense(ctx context.Context, e models.ExpenseTable) (int64, error) {
 
 	for idx, exp := range ec.repository {
-		if exp.ID == e.ID {
+		if exp.ID == e.ID && exp.UserID == e.UserID {
 			ec.repository[idx] = e
 			return e.ID, nil
 		}
@@ -54,17 +82,25 @@ func (ec *Expense) UpdateExpense(ctx context.Context, e models.ExpenseTable) (in
 }
 
 // GetExpenseByID returns the expense from the cache if expense with that id exists
-func (ec *Expense) GetExpenseByID(ctx context.Context, id int64) (models.ExpenseView, error) {
+func (ec *Expense) GetExpenseByID(ctx context.Context, userID int64, id int64) (models.ExpenseView, error) {
 
 	var expense models.ExpenseTable
+	var found bool
 	for _, exp := range ec.repository {
-		if exp.ID == id {
+		if exp.ID == id && exp.UserID == userID {
 			expense = exp
+			found = true
 			break
 		}
 	}
 
-	cardTable, err := ec.cardrepository.GetCardByID(ctx, expense.CardID)
+	if !found {
+		return models.ExpenseView{}, ExpenseNotFoundByIDError{
+			id: id,
+		}
+	}
+
+	cardTable, err := ec.cardrepository.GetCardByID(ctx, userID, expense.CardID)
 	if err != nil {
 		return models.ExpenseView{}, GettingCardByIDError{
 			id: expense.CardID,
@@ -85,9 +121,15 @@ func (ec *Expense) GetExpenseByID(ctx context.Context, id int64) (models.Expense
 		}
 	}
 
+	splits, err := ec.splitViews(ctx, expense.Splits)
+	if err != nil {
+		return models.ExpenseView{}, err
+	}
+
 	return models.ExpenseView{
 		ID:            expense.ID,
 		Value:         expense.Value,
+		Currency:      transactionCurrency(expense.Currency, cardTable),
 		Date:          expense.Date,
 		Category:      categoryTable.Name,
 		SubCategory:   subCategoryTable.Name,
@@ -96,59 +138,40 @@ func (ec *Expense) GetExpenseByID(ctx context.Context, id int64) (models.Expense
 		SubCategoryID: expense.SubCategoryID,
 		CardID:        expense.CardID,
 		Description:   expense.Description,
+		UserID:        expense.UserID,
+		Splits:        splits,
+		Tags:          expense.Tags,
 	}, nil
 }
 
 // GetExpensesByDates returns the expenses from the cache if expense with that dates' range exists
-func (ec *Expense) GetExpensesByDates(ctx context.Context, minDate time.Time, maxDate time.Time) ([]models.ExpenseView, error) {
+func (ec *Expense) GetExpensesByDates(
+	ctx context.Context,
+	userID int64,
+	minDate time.Time,
+	maxDate time.Time,
+) ([]models.ExpenseView, error) {
 
 	var expenseViews []models.ExpenseView
 	for _, exp := range ec.repository {
 
-		if exp.Date.After(minDate) && exp.Date.Before(maxDate) {
-
-			subCategoryTable, err := ec.subCategoryrepository.GetExpenseSubCategoryByID(ctx, exp.SubCategoryID)
-			if err != nil {
-				return []models.ExpenseView{}, GettingSubCategoryByIDError{
-					id: exp.SubCategoryID,
-				}
-			}
-
-			cardTable, err := ec.cardrepository.GetCardByID(ctx, exp.CardID)
-			if err != nil {
-				return []models.ExpenseView{}, GettingCardByIDError{
-					id: exp.CardID,
-				}
-			}
+		if exp.UserID == userID && exp.Date.After(minDate) && exp.Date.Before(maxDate) {
 
-			categoryTable, err := ec.categoryrepository.GetExpenseCategoryByID(ctx, subCategoryTable.CategoryID)
+			expenseView, err := ec.GetExpenseByID(ctx, userID, exp.ID)
 			if err != nil {
-				return []models.ExpenseView{}, GettingCategoryByIDError{
-					id: subCategoryTable.CategoryID,
-				}
+				return []models.ExpenseView{}, err
 			}
 
-			expenseViews = append(expenseViews,
-				models.ExpenseView{
-					ID:            exp.ID,
-					Value:         exp.Value,
-					Date:          exp.Date,
-					Category:      categoryTable.Name,
-					SubCategory:   subCategoryTable.Name,
-					Card:          cardTable.Name,
-					CategoryID:    categoryTable.ID,
-					SubCategoryID: subCategoryTable.ID,
-					CardID:        cardTable.ID,
-					Description:   exp.Description,
-				})
+			expenseViews = append(expenseViews, expenseView)
 		}
 	}
 
 	return expenseViews, nil
 }
 
-// GetExpensesByCategory returns the expenses from the cache if expense with that category exists
-func (ec *Expense) GetExpensesByCategory(ctx context.Context, cat string) ([]models.ExpenseView, error) {
+// GetExpensesByCategory returns the expenses from the cache if expense with that category exists,
+// which split expenses do if any of their lines does
+func (ec *Expense) GetExpensesByCategory(ctx context.Context, userID int64, cat string) ([]models.ExpenseView, error) {
 
 	categoryTable, err := ec.categoryrepository.GetExpenseCategoryByName(ctx, cat)
 	if err != nil {
@@ -160,43 +183,29 @@ func (ec *Expense) GetExpensesByCategory(ctx context.Context, cat string) ([]mod
 	var expenseViews []models.ExpenseView
 	for _, exp := range ec.repository {
 
-		subCategoryTable, err := ec.subCategoryrepository.GetExpenseSubCategoryByID(ctx, exp.SubCategoryID)
-		if err != nil {
-			return []models.ExpenseView{}, GettingSubCategoryByIDError{
-				id: exp.SubCategoryID,
-			}
+		if exp.UserID != userID {
+			continue
 		}
 
-		if categoryTable.ID == subCategoryTable.CategoryID {
+		expenseView, err := ec.GetExpenseByID(ctx, userID, exp.ID)
+		if err != nil {
+			return []models.ExpenseView{}, err
+		}
 
-			cardTable, err := ec.cardrepository.GetCardByID(ctx, exp.CardID)
-			if err != nil {
-				return []models.ExpenseView{}, GettingCardByIDError{
-					id: exp.CardID,
-				}
+		for _, line := range expenseLines(expenseView) {
+			if line.CategoryID == categoryTable.ID {
+				expenseViews = append(expenseViews, expenseView)
+				break
 			}
-
-			expenseViews = append(expenseViews,
-				models.ExpenseView{
-					ID:            exp.ID,
-					Value:         exp.Value,
-					Date:          exp.Date,
-					Category:      categoryTable.Name,
-					SubCategory:   subCategoryTable.Name,
-					Card:          cardTable.Name,
-					CategoryID:    categoryTable.ID,
-					SubCategoryID: subCategoryTable.ID,
-					CardID:        cardTable.ID,
-					Description:   exp.Description,
-				})
 		}
 	}
 
 	return expenseViews, nil
 }
 
-// GetExpensesBySubCategory returns the expenses from the cache if expense with that subcategory exists
-func (ec *Expense) GetExpensesBySubCategory(ctx context.Context, subCat string) ([]models.ExpenseView, error) {
+// GetExpensesBySubCategory returns the expenses from the cache if expense with that subcategory exists,
+// which split expenses do if any of their lines does
+func (ec *Expense) GetExpensesBySubCategory(ctx context.Context, userID int64, subCat string) ([]models.ExpenseView, error) {
 
 	subCategoryTable, err := ec.subCategoryrepository.GetExpenseSubCategoryByName(ctx, subCat)
 	if err != nil {
@@ -205,37 +214,23 @@ func (ec *Expense) GetExpensesBySubCategory(ctx context.Context, subCat string)
 		}
 	}
 
-	categoryTable, err := ec.categoryrepository.GetExpenseCategoryByID(ctx, subCategoryTable.CategoryID)
-	if err != nil {
-		return []models.ExpenseView{}, GettingCategoryByIDError{
-			id: subCategoryTable.CategoryID,
-		}
-	}
-
 	var expenseViews []models.ExpenseView
 	for _, exp := range ec.repository {
-		if exp.SubCategoryID == subCategoryTable.ID {
 
-			cardTable, err := ec.cardrepository.GetCardByID(ctx, exp.CardID)
-			if err != nil {
-				return []models.ExpenseView{}, GettingCardByIDError{
-					id: exp.CardID,
-				}
-			}
+		if exp.UserID != userID {
+			continue
+		}
 
-			expenseViews = append(expenseViews,
-				models.ExpenseView{
-					ID:            exp.ID,
-					Value:         exp.Value,
-					Date:          exp.Date,
-					Category:      categoryTable.Name,
-					SubCategory:   subCategoryTable.Name,
-					Card:          cardTable.Name,
-					CategoryID:    categoryTable.ID,
-					SubCategoryID: subCategoryTable.ID,
-					CardID:        cardTable.ID,
-					Description:   exp.Description,
-				})
+		expenseView, err := ec.GetExpenseByID(ctx, userID, exp.ID)
+		if err != nil {
+			return []models.ExpenseView{}, err
+		}
+
+		for _, line := range expenseLines(expenseView) {
+			if line.SubCategoryID == subCategoryTable.ID {
+				expenseViews = append(expenseViews, expenseView)
+				break
+			}
 		}
 	}
 
@@ -243,9 +238,9 @@ func (ec *Expense) GetExpensesBySubCategory(ctx context.Context, subCat string)
 }
 
 // GetExpensesByCard returns the expenses from the cache if expense with that card exists
-func (ec *Expense) GetExpensesByCard(ctx context.Context, card string) ([]models.ExpenseView, error) {
+func (ec *Expense) GetExpensesByCard(ctx context.Context, userID int64, card string) ([]models.ExpenseView, error) {
 
-	cardTable, err := ec.cardrepository.GetCardByName(ctx, card)
+	cardTable, err := ec.cardrepository.GetCardByName(ctx, userID, card)
 	if err != nil {
 		return []models.ExpenseView{}, GettingCardByNameError{
 			name: card,
@@ -254,46 +249,167 @@ func (ec *Expense) GetExpensesByCard(ctx context.Context, card string) ([]models
 
 	var expenseViews []models.ExpenseView
 	for _, exp := range ec.repository {
-		if cardTable.ID == exp.CardID {
+		if exp.UserID == userID && cardTable.ID == exp.CardID {
 
-			subCategoryTable, err := ec.subCategoryrepository.GetExpenseSubCategoryByID(ctx, exp.SubCategoryID)
+			expenseView, err := ec.GetExpenseByID(ctx, userID, exp.ID)
 			if err != nil {
-				return []models.ExpenseView{}, GettingSubCategoryByIDError{
-					id: exp.SubCategoryID,
-				}
+				return []models.ExpenseView{}, err
 			}
 
-			categoryTable, err := ec.categoryrepository.GetExpenseCategoryByID(ctx, subCategoryTable.CategoryID)
-			if err != nil {
-				return []models.ExpenseView{}, GettingCategoryByIDError{
-					id: subCategoryTable.CategoryID,
-				}
-			}
+			expenseViews = append(expenseViews, expenseView)
+		}
+	}
+
+	return expenseViews, nil
+}
+
+// GetExpensesByCardAndValue returns the expenses from the cache of the card with that value within the dates' range
+func (ec *Expense) GetExpensesByCardAndValue(
+	ctx context.Context,
+	userID int64,
+	cardID int64,
+	value models.Money,
+	minDate time.Time,
+	maxDate time.Time,
+) ([]models.ExpenseView, error) {
+
+	var expenseViews []models.ExpenseView
+	for _, exp := range ec.repository {
+
+		if exp.UserID != userID || exp.CardID != cardID || exp.Value != value ||
+			exp.Date.Before(minDate) || exp.Date.After(maxDate) {
+			continue
+		}
+
+		expenseView, err := ec.GetExpenseByID(ctx, userID, exp.ID)
+		if err != nil {
+			return []models.ExpenseView{}, err
+		}
+
+		expenseViews = append(expenseViews, expenseView)
+	}
+
+	return expenseViews, nil
+}
+
+// GetExpenseByExternalReference returns the expense from the cache if expense with that card and external reference exists
+func (ec *Expense) GetExpenseByExternalReference(ctx context.Context, userID int64, cardID int64, reference string) (models.ExpenseTable, error) {
+
+	for _, exp := range ec.repository {
+		if exp.UserID == userID && exp.CardID == cardID && exp.ExternalReference == reference {
+			return exp, nil
+		}
+	}
+
+	return models.ExpenseTable{}, ExpenseNotFoundByExternalReferenceError{
+		reference: reference,
+	}
+}
+
+// SearchExpenses returns a page of the expenses from the cache that match the filter, in its sort order
+func (ec *Expense) SearchExpenses(ctx context.Context, userID int64, filter models.SearchFilter) ([]models.ExpenseView, error) {
+
+	expenseViews := []models.ExpenseView{}
+	for _, exp := range ec.repository {
+
+		if exp.UserID != userID {
+			continue
+		}
+
+		expenseView, err := ec.GetExpenseByID(ctx, userID, exp.ID)
+		if err != nil {
+			return []models.ExpenseView{}, err
+		}
 
-			expenseViews = append(expenseViews,
-				models.ExpenseView{
-					ID:            exp.ID,
-					Value:         exp.Value,
-					Date:          exp.Date,
-					Category:      categoryTable.Name,
-					SubCategory:   subCategoryTable.Name,
-					Card:          cardTable.Name,
-					CategoryID:    categoryTable.ID,
-					SubCategoryID: subCategoryTable.ID,
-					CardID:        cardTable.ID,
-					Description:   exp.Description,
-				})
+		// a split expense matches if any of its lines does
+		for _, line := range expenseLines(expenseView) {
+			if filter.Matches(
+				expenseView.ID,
+				expenseView.Date,
+				expenseView.Value,
+				line.Category,
+				line.SubCategory,
+				expenseView.Card,
+				expenseView.Description,
+				expenseView.Tags,
+			) {
+				expenseViews = append(expenseViews, expenseView)
+				break
+			}
 		}
 	}
 
+	sort.Slice(expenseViews, func(i, j int) bool {
+		return filter.Less(
+			filter.CursorOf(expenseViews[i].ID, expenseViews[i].Date, expenseViews[i].Value),
+			filter.CursorOf(expenseViews[j].ID, expenseViews[j].Date, expenseViews[j].Value),
+		)
+	})
+
+	if filter.Limit > 0 && len(expenseViews) > filter.Limit {
+		expenseViews = expenseViews[:filter.Limit]
+	}
+
 	return expenseViews, nil
 }
 
+// GetExpenseDailyTotals sums the expense lines from the cache in the dates' range by day, category, subcategory, card and currency
+func (ec *Expense) GetExpenseDailyTotals(ctx context.Context, userID int64, minDate time.Time, maxDate time.Time) ([]models.DailyTotal, error) {
+
+	expenseViews, err := ec.GetExpensesByDates(ctx, userID, minDate, maxDate)
+	if err != nil {
+		return []models.DailyTotal{}, err
+	}
+
+	totals := []models.DailyTotal{}
+	for _, exp := range expenseViews {
+		day := time.Date(exp.Date.Year(), exp.Date.Month(), exp.Date.Day(), 0, 0, 0, 0, time.UTC)
+		for _, line := range expenseLines(exp) {
+			totals = addToDailyTotals(totals, models.DailyTotal{
+				Day:         day,
+				Category:    line.Category,
+				SubCategory: line.SubCategory,
+				Card:        exp.Card,
+				Currency:    exp.Currency,
+				Value:       line.Value,
+				Count:       1,
+			})
+		}
+	}
+
+	return totals, nil
+}
+
+// GetExpenseTagDailyTotals sums the tagged expenses from the cache in the dates' range by day, tag and currency
+func (ec *Expense) GetExpenseTagDailyTotals(ctx context.Context, userID int64, minDate time.Time, maxDate time.Time) ([]models.DailyTotal, error) {
+
+	expenseViews, err := ec.GetExpensesByDates(ctx, userID, minDate, maxDate)
+	if err != nil {
+		return []models.DailyTotal{}, err
+	}
+
+	totals := []models.DailyTotal{}
+	for _, exp := range expenseViews {
+		day := time.Date(exp.Date.Year(), exp.Date.Month(), exp.Date.Day(), 0, 0, 0, 0, time.UTC)
+		for _, tag := range exp.Tags {
+			totals = addToDailyTotals(totals, models.DailyTotal{
+				Day:      day,
+				Tag:      tag,
+				Currency: exp.Currency,
+				Value:    exp.Value,
+				Count:    1,
+			})
+		}
+	}
+
+	return totals, nil
+}
+
 // DeleteExpense deletes the expense from cache if it exists
-func (ec *Expense) DeleteExpense(ctx context.Context, id int64) error {
+func (ec *Expense) DeleteExpense(ctx context.Context, userID int64, id int64) error {
 
 	for idx, expense := range ec.repository {
-		if expense.ID == id {
+		if expense.ID == id && expense.UserID == userID {
 			ec.repository = append(ec.repository[:idx], ec.repository[idx+1:]...)
 			return nil
 		}
@@ -303,3 +419,68 @@ func (ec *Expense) DeleteExpense(ctx context.Context, id int64) error {
 		id: id,
 	}
 }
+
+// addToDailyTotals adds a total to the one of the same day, category, subcategory, card, tag and currency, if there is one
+func addToDailyTotals(totals []models.DailyTotal, total models.DailyTotal) []models.DailyTotal {
+
+	for idx, existing := range totals {
+		if existing.Day.Equal(total.Day) && existing.Category == total.Category && existing.SubCategory == total.SubCategory &&
+			existing.Card == total.Card && existing.Tag == total.Tag && existing.Currency == total.Currency {
+			totals[idx].Value += total.Value
+			totals[idx].Count += total.Count
+			return totals
+		}
+	}
+
+	return append(totals, total)
+}
+
+// splitViews resolves the category and subcategory of the split lines of an expense
+func (ec *Expense) splitViews(ctx context.Context, splits []models.ExpenseSplitTable) ([]models.ExpenseSplitView, error) {
+
+	var splitViews []models.ExpenseSplitView
+	for _, line := range splits {
+
+		subCategoryTable, err := ec.subCategoryrepository.GetExpenseSubCategoryByID(ctx, line.SubCategoryID)
+		if err != nil {
+			return []models.ExpenseSplitView{}, GettingSubCategoryByIDError{
+				id: line.SubCategoryID,
+			}
+		}
+
+		categoryTable, err := ec.categoryrepository.GetExpenseCategoryByID(ctx, subCategoryTable.CategoryID)
+		if err != nil {
+			return []models.ExpenseSplitView{}, GettingCategoryByIDError{
+				id: subCategoryTable.CategoryID,
+			}
+		}
+
+		splitViews = append(splitViews, models.ExpenseSplitView{
+			ID:            line.ID,
+			Value:         line.Value,
+			Category:      categoryTable.Name,
+			SubCategory:   subCategoryTable.Name,
+			CategoryID:    categoryTable.ID,
+			SubCategoryID: subCategoryTable.ID,
+			Note:          line.Note,
+		})
+	}
+
+	return splitViews, nil
+}
+
+// expenseLines returns the lines of an expense: its split lines, or the expense itself if it is not split
+func expenseLines(exp models.ExpenseView) []models.ExpenseSplitView {
+
+	if len(exp.Splits) > 0 {
+		return exp.Splits
+	}
+
+	return []models.ExpenseSplitView{{
+		Value:         exp.Value,
+		Category:      exp.Category,
+		SubCategory:   exp.SubCategory,
+		CategoryID:    exp.CategoryID,
+		SubCategoryID: exp.SubCategoryID,
+	}}
+}
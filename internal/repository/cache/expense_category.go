@@ -90,3 +90,46 @@ func (ecc *ExpenseCategory) DeleteExpenseCategory(ctx context.Context, id int64)
 		id: id,
 	}
 }
+
+// GetExpenseCategories returns the expense categories from the cache
+func (ecc *ExpenseCategory) GetExpenseCategories(ctx context.Context) ([]models.ExpenseCategoryTable, error) {
+	return append([]models.ExpenseCategoryTable{}, ecc.repository...), nil
+}
+
+// ReplaceExpenseCategory deletes the expense category from the cache if both it and its replacement exist.
+// The cache keeps no subcategories to move.
+func (ecc *ExpenseCategory) ReplaceExpenseCategory(ctx context.Context, id int64, replacementID int64) error {
+
+	_, err := ecc.GetExpenseCategoryByID(ctx, replacementID)
+	if err != nil {
+		return err
+	}
+
+	return ecc.DeleteExpenseCategory(ctx, id)
+}
+
+// MergeExpenseCategory deletes the expense category from the cache if both it and the target exist, unless on a dry run.
+// The cache keeps no subcategories or budgets to move.
+func (ecc *ExpenseCategory) MergeExpenseCategory(
+	ctx context.Context,
+	id int64,
+	targetID int64,
+	dryRun bool,
+) (models.CategoryMerge, error) {
+
+	_, err := ecc.GetExpenseCategoryByID(ctx, targetID)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	_, err = ecc.GetExpenseCategoryByID(ctx, id)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	if dryRun {
+		return models.CategoryMerge{}, nil
+	}
+
+	return models.CategoryMerge{}, ecc.DeleteExpenseCategory(ctx, id)
+}
@@ -1,6 +1,10 @@
 package cache
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
 
 /* CATEGORY */
 
@@ -14,6 +18,11 @@ func (cnfie CategoryNotFoundByIDError) Error() string {
 	return fmt.Sprintf("error: category with id: %d was not found by id in the repository", cnfie.id)
 }
 
+// Unwrap allows CategoryNotFoundByIDError to match repository.ErrNotFound
+func (cnfie CategoryNotFoundByIDError) Unwrap() error {
+	return repository.ErrNotFound
+}
+
 // CategoryNotFoundByNameError error when a category is not found by name on the cache
 type CategoryNotFoundByNameError struct {
 	name string
@@ -24,6 +33,11 @@ func (cnfne CategoryNotFoundByNameError) Error() string {
 	return fmt.Sprintf("error: category with id: %s was not found by name in the repository", cnfne.name)
 }
 
+// Unwrap allows CategoryNotFoundByNameError to match repository.ErrNotFound
+func (cnfne CategoryNotFoundByNameError) Unwrap() error {
+	return repository.ErrNotFound
+}
+
 // CategoryNotFoundByNameError error when a category already exists on the cache
 type CategoryAlreadyExistsError struct {
 	id int64
@@ -46,6 +60,11 @@ func (snfie SubCategoryNotFoundByIDError) Error() string {
 	return fmt.Sprintf("error: subcategory with id: %d was not found by id in the repository", snfie.id)
 }
 
+// Unwrap allows SubCategoryNotFoundByIDError to match repository.ErrNotFound
+func (snfie SubCategoryNotFoundByIDError) Unwrap() error {
+	return repository.ErrNotFound
+}
+
 // SubCategoryNotFoundByNameError error when a subcategory is not found by name on the cache
 type SubCategoryNotFoundByNameError struct {
 	name string
@@ -56,6 +75,11 @@ func (snfne SubCategoryNotFoundByNameError) Error() string {
 	return fmt.Sprintf("error: subcategory with id: %s was not found by name in the repository", snfne.name)
 }
 
+// Unwrap allows SubCategoryNotFoundByNameError to match repository.ErrNotFound
+func (snfne SubCategoryNotFoundByNameError) Unwrap() error {
+	return repository.ErrNotFound
+}
+
 // SubCategoryAlreadyExistsError error when a subcategory already exists on the cache
 type SubCategoryAlreadyExistsError struct {
 	id int64
@@ -78,6 +102,11 @@ func (nfie ExpenseNotFoundByIDError) Error() string {
 	return fmt.Sprintf("error: expense with id: %d was not found by id in the repository", nfie.id)
 }
 
+// Unwrap allows ExpenseNotFoundByIDError to match repository.ErrNotFound
+func (nfie ExpenseNotFoundByIDError) Unwrap() error {
+	return repository.ErrNotFound
+}
+
 // ExpenseNotFoundByNameError error when a expense is not found by name on the cache
 type ExpenseNotFoundByNameError struct {
 	name string
@@ -88,6 +117,36 @@ func (nfne ExpenseNotFoundByNameError) Error() string {
 	return fmt.Sprintf("error: expense with name: %s was not found by id in the repository", nfne.name)
 }
 
+// ExpenseNotFoundByExternalReferenceError error when an expense is not found by external reference on the cache
+type ExpenseNotFoundByExternalReferenceError struct {
+	reference string
+}
+
+// Error is the string representation of ExpenseNotFoundByExternalReferenceError
+func (nfere ExpenseNotFoundByExternalReferenceError) Error() string {
+	return fmt.Sprintf("error: expense with external reference: %s was not found in the repository", nfere.reference)
+}
+
+// Unwrap allows ExpenseNotFoundByExternalReferenceError to match repository.ErrNotFound
+func (nfere ExpenseNotFoundByExternalReferenceError) Unwrap() error {
+	return repository.ErrNotFound
+}
+
+// ExpenseExternalReferenceExistsError error when the card of an expense already has an expense with its external reference on the cache
+type ExpenseExternalReferenceExistsError struct {
+	reference string
+}
+
+// Error is the string representation of ExpenseExternalReferenceExistsError
+func (ree ExpenseExternalReferenceExistsError) Error() string {
+	return fmt.Sprintf("error: expense with external reference: %s already exists in the repository", ree.reference)
+}
+
+// Unwrap allows ExpenseExternalReferenceExistsError to match repository.ErrAlreadyExists
+func (ree ExpenseExternalReferenceExistsError) Unwrap() error {
+	return repository.ErrAlreadyExists
+}
+
 // GettingCardByIDError error when a trying to get a card
 type GettingCardByIDError struct {
 	id int64
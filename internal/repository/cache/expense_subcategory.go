@@ -90,3 +90,46 @@ func (ecc *ExpenseSubCategory) DeleteExpenseSubCategory(ctx context.Context, id
 		id: id,
 	}
 }
+
+// GetExpenseSubCategories returns the expense sub categories from the cache
+func (ecc *ExpenseSubCategory) GetExpenseSubCategories(ctx context.Context) ([]models.ExpenseSubCategoryTable, error) {
+	return append([]models.ExpenseSubCategoryTable{}, ecc.repository...), nil
+}
+
+// ReplaceExpenseSubCategory deletes the expense sub category from the cache if both it and its replacement exist.
+// The cache keeps no expenses to move.
+func (ecc *ExpenseSubCategory) ReplaceExpenseSubCategory(ctx context.Context, id int64, replacementID int64) error {
+
+	_, err := ecc.GetExpenseSubCategoryByID(ctx, replacementID)
+	if err != nil {
+		return err
+	}
+
+	return ecc.DeleteExpenseSubCategory(ctx, id)
+}
+
+// MergeExpenseSubCategory deletes the expense sub category from the cache if both it and the target exist, unless on a dry run.
+// The cache keeps no expenses, budgets, rules or recurring transactions to move.
+func (ecc *ExpenseSubCategory) MergeExpenseSubCategory(
+	ctx context.Context,
+	id int64,
+	targetID int64,
+	dryRun bool,
+) (models.CategoryMerge, error) {
+
+	_, err := ecc.GetExpenseSubCategoryByID(ctx, targetID)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	_, err = ecc.GetExpenseSubCategoryByID(ctx, id)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	if dryRun {
+		return models.CategoryMerge{}, nil
+	}
+
+	return models.CategoryMerge{}, ecc.DeleteExpenseSubCategory(ctx, id)
+}
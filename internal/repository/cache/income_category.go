@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// IncomeCategory implements the income category repository methods
+type IncomeCategory struct {
+	repository []models.IncomeCategoryTable
+}
+
+// NewIncomeCategory creates a IncomeCategory cache
+func NewIncomeCategory(repository []models.IncomeCategoryTable) IncomeCategory {
+	return IncomeCategory{
+		repository: repository,
+	}
+}
+
+// InsertIncomeCategory inserts an income category on the cache if income category does not exist
+func (icc *IncomeCategory) InsertIncomeCategory(ctx context.Context, incCategory models.IncomeCategoryTable) (int64, error) {
+
+	existingCategory, err := icc.GetIncomeCategoryByID(ctx, incCategory.ID)
+	if err == nil {
+		return 0, CategoryAlreadyExistsError{
+			id: existingCategory.ID,
+		}
+	}
+
+	icc.repository = append(icc.repository, incCategory)
+
+	return 1, nil
+}
+
+// UpdateIncomeCategory updates an income category on the cache if it exists
+func (icc *IncomeCategory) UpdateIncomeCategory(ctx context.Context, updatedIncCategory models.IncomeCategoryTable) (int64, error) {
+
+	for idx, category := range icc.repository {
+		if category.ID == updatedIncCategory.ID {
+			icc.repository[idx] = updatedIncCategory
+			return updatedIncCategory.ID, nil
+		}
+	}
+
+	return 0, CategoryNotFoundByIDError{
+		id: updatedIncCategory.ID,
+	}
+}
+
+// GetIncomeCategoryByID returns the income category from the cache if income category with that id exists
+func (icc *IncomeCategory) GetIncomeCategoryByID(ctx context.Context, id int64) (models.IncomeCategoryTable, error) {
+
+	for _, category := range icc.repository {
+		if category.ID == id {
+			return category, nil
+		}
+	}
+
+	return models.IncomeCategoryTable{}, CategoryNotFoundByIDError{
+		id: id,
+	}
+}
+
+// GetIncomeCategoryByName returns the income category from the cache if income category with that name exists
+func (icc *IncomeCategory) GetIncomeCategoryByName(ctx context.Context, name string) (models.IncomeCategoryTable, error) {
+
+	for _, category := range icc.repository {
+		if category.Name == name {
+			return category, nil
+		}
+	}
+
+	return models.IncomeCategoryTable{}, CategoryNotFoundByNameError{
+		name: name,
+	}
+}
+
+// DeleteIncomeCategory deletes the income category from cache if it exists
+func (icc *IncomeCategory) DeleteIncomeCategory(ctx context.Context, id int64) error {
+
+	for idx, category := range icc.repository {
+		if category.ID == id {
+			icc.repository = append(icc.repository[:idx], icc.repository[idx+1:]...)
+			return nil
+		}
+	}
+
+	return CategoryNotFoundByIDError{
+		id: id,
+	}
+}
+
+// GetIncomeCategories returns the income categories from the cache
+func (icc *IncomeCategory) GetIncomeCategories(ctx context.Context) ([]models.IncomeCategoryTable, error) {
+	return append([]models.IncomeCategoryTable{}, icc.repository...), nil
+}
+
+// ReplaceIncomeCategory deletes the income category from the cache if both it and its replacement exist.
+// The cache keeps no incomes to move.
+func (icc *IncomeCategory) ReplaceIncomeCategory(ctx context.Context, id int64, replacementID int64) error {
+
+	_, err := icc.GetIncomeCategoryByID(ctx, replacementID)
+	if err != nil {
+		return err
+	}
+
+	return icc.DeleteIncomeCategory(ctx, id)
+}
+
+// MergeIncomeCategory deletes the income category from the cache if both it and the target exist, unless on a dry run.
+// The cache keeps no incomes, rules or recurring transactions to move.
+func (icc *IncomeCategory) MergeIncomeCategory(
+	ctx context.Context,
+	id int64,
+	targetID int64,
+	dryRun bool,
+) (models.CategoryMerge, error) {
+
+	_, err := icc.GetIncomeCategoryByID(ctx, targetID)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	_, err = icc.GetIncomeCategoryByID(ctx, id)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	if dryRun {
+		return models.CategoryMerge{}, nil
+	}
+
+	return models.CategoryMerge{}, icc.DeleteIncomeCategory(ctx, id)
+}
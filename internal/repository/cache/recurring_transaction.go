@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// RecurringTransaction implements the recurring transaction repository methods
+type RecurringTransaction struct {
+	repository []models.RecurringTransactionView
+}
+
+// NewRecurringTransaction creates a RecurringTransaction cache
+func NewRecurringTransaction(repository []models.RecurringTransactionView) RecurringTransaction {
+	return RecurringTransaction{
+		repository: repository,
+	}
+}
+
+// InsertRecurringTransaction inserts a recurring transaction on the cache
+func (rtc *RecurringTransaction) InsertRecurringTransaction(ctx context.Context, recurring models.RecurringTransactionTable) (int64, error) {
+
+	rtc.repository = append(rtc.repository, recurringTransactionTableToView(recurring))
+
+	return 1, nil
+}
+
+// UpdateRecurringTransaction updates a recurring transaction on the cache if it exists
+func (rtc *RecurringTransaction) UpdateRecurringTransaction(ctx context.Context, recurring models.RecurringTransactionTable) (int64, error) {
+
+	for idx, existing := range rtc.repository {
+		if existing.ID == recurring.ID && existing.UserID == recurring.UserID {
+			rtc.repository[idx] = recurringTransactionTableToView(recurring)
+			return recurring.ID, nil
+		}
+	}
+
+	return 0, RecurringTransactionNotFoundByIDError{
+		id: recurring.ID,
+	}
+}
+
+// GetRecurringTransactionByID returns the recurring transaction from the cache if one with that id exists
+func (rtc *RecurringTransaction) GetRecurringTransactionByID(ctx context.Context, userID int64, id int64) (models.RecurringTransactionView, error) {
+
+	for _, recurring := range rtc.repository {
+		if recurring.ID == id && recurring.UserID == userID {
+			return recurring, nil
+		}
+	}
+
+	return models.RecurringTransactionView{}, RecurringTransactionNotFoundByIDError{
+		id: id,
+	}
+}
+
+// GetRecurringTransactions returns the recurring transactions of the user from the cache
+func (rtc *RecurringTransaction) GetRecurringTransactions(ctx context.Context, userID int64) ([]models.RecurringTransactionView, error) {
+
+	recurringTransactions := []models.RecurringTransactionView{}
+	for _, recurring := range rtc.repository {
+		if recurring.UserID == userID {
+			recurringTransactions = append(recurringTransactions, recurring)
+		}
+	}
+
+	return recurringTransactions, nil
+}
+
+// GetDueRecurringTransactions returns the recurring transactions of every user from the cache that are due on the date
+func (rtc *RecurringTransaction) GetDueRecurringTransactions(ctx context.Context, date time.Time) ([]models.RecurringTransactionView, error) {
+
+	recurringTransactions := []models.RecurringTransactionView{}
+	for _, recurring := range rtc.repository {
+		if !recurring.NextRun.After(date) && (recurring.EndDate.IsZero() || !recurring.NextRun.After(recurring.EndDate)) {
+			recurringTransactions = append(recurringTransactions, recurring)
+		}
+	}
+
+	return recurringTransactions, nil
+}
+
+// AdvanceRecurringTransaction moves the next run of the recurring transaction on the cache if it is still the from date
+func (rtc *RecurringTransaction) AdvanceRecurringTransaction(ctx context.Context, id int64, from time.Time, to time.Time) error {
+
+	for idx, recurring := range rtc.repository {
+		if recurring.ID == id && recurring.NextRun.Equal(from) {
+			rtc.repository[idx].NextRun = to
+			return nil
+		}
+	}
+
+	return RecurringTransactionNotFoundByIDError{
+		id: id,
+	}
+}
+
+// DeleteRecurringTransaction deletes the recurring transaction from the cache if it exists
+func (rtc *RecurringTransaction) DeleteRecurringTransaction(ctx context.Context, userID int64, id int64) error {
+
+	for idx, recurring := range rtc.repository {
+		if recurring.ID == id && recurring.UserID == userID {
+			rtc.repository = append(rtc.repository[:idx], rtc.repository[idx+1:]...)
+			return nil
+		}
+	}
+
+	return RecurringTransactionNotFoundByIDError{
+		id: id,
+	}
+}
+
+func recurringTransactionTableToView(recurring models.RecurringTransactionTable) models.RecurringTransactionView {
+	return models.RecurringTransactionView{
+		ID:               recurring.ID,
+		Value:            recurring.Value,
+		Description:      recurring.Description,
+		Frequency:        recurring.Frequency,
+		CronExpression:   recurring.CronExpression,
+		StartDate:        recurring.StartDate,
+		EndDate:          recurring.EndDate,
+		NextRun:          recurring.NextRun,
+		CardID:           recurring.CardID,
+		SubCategoryID:    recurring.SubCategoryID,
+		IncomeCategoryID: recurring.IncomeCategoryID,
+		UserID:           recurring.UserID,
+	}
+}
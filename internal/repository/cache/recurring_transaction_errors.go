@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+// RecurringTransactionNotFoundByIDError error when a recurring transaction is not found by id on the cache
+type RecurringTransactionNotFoundByIDError struct {
+	id int64
+}
+
+// Error is the string representation of RecurringTransactionNotFoundByIDError
+func (rtnfe RecurringTransactionNotFoundByIDError) Error() string {
+	return fmt.Sprintf("error: recurring transaction with id: %d was not found by id in the repository", rtnfe.id)
+}
+
+// Unwrap allows RecurringTransactionNotFoundByIDError to match repository.ErrNotFound
+func (rtnfe RecurringTransactionNotFoundByIDError) Unwrap() error {
+	return repository.ErrNotFound
+}
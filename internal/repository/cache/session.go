@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// Session implements the session repository methods
+type Session struct {
+	sessions      []models.SessionTable
+	refreshTokens []models.RefreshTokenTable
+}
+
+// NewSession creates a Session cache
+func NewSession() Session {
+	return Session{
+		sessions:      []models.SessionTable{},
+		refreshTokens: []models.RefreshTokenTable{},
+	}
+}
+
+// InsertSession inserts a session on the cache and returns its id
+func (sc *Session) InsertSession(ctx context.Context, session models.SessionTable) (int64, error) {
+
+	session.ID = int64(len(sc.sessions) + 1)
+	session.CreatedAt = time.Now()
+	sc.sessions = append(sc.sessions, session)
+
+	return session.ID, nil
+}
+
+// GetSessionByID returns the session from the cache if one with that id exists
+func (sc *Session) GetSessionByID(ctx context.Context, id int64) (models.SessionTable, error) {
+
+	for _, session := range sc.sessions {
+		if session.ID == id {
+			return session, nil
+		}
+	}
+
+	return models.SessionTable{}, SessionNotFoundByIDError{
+		id: id,
+	}
+}
+
+// RevokeSession revokes the session on the cache if one with that id exists
+func (sc *Session) RevokeSession(ctx context.Context, id int64) error {
+
+	for i, session := range sc.sessions {
+		if session.ID == id {
+			if session.RevokedAt.IsZero() {
+				sc.sessions[i].RevokedAt = time.Now()
+			}
+			return nil
+		}
+	}
+
+	return SessionNotFoundByIDError{
+		id: id,
+	}
+}
+
+// RevokeUserSessions revokes every session of the user on the cache that is not revoked yet
+func (sc *Session) RevokeUserSessions(ctx context.Context, userID int64) error {
+
+	for i, session := range sc.sessions {
+		if session.UserID == userID && session.RevokedAt.IsZero() {
+			sc.sessions[i].RevokedAt = time.Now()
+		}
+	}
+
+	return nil
+}
+
+// InsertRefreshToken inserts a refresh token on the cache and returns its id
+func (sc *Session) InsertRefreshToken(ctx context.Context, refreshToken models.RefreshTokenTable) (int64, error) {
+
+	refreshToken.ID = int64(len(sc.refreshTokens) + 1)
+	refreshToken.CreatedAt = time.Now()
+	sc.refreshTokens = append(sc.refreshTokens, refreshToken)
+
+	return refreshToken.ID, nil
+}
+
+// GetRefreshTokenByHash returns the refresh token from the cache if one with that hash exists
+func (sc *Session) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (models.RefreshTokenTable, error) {
+
+	for _, refreshToken := range sc.refreshTokens {
+		if refreshToken.TokenHash == tokenHash {
+			return refreshToken, nil
+		}
+	}
+
+	return models.RefreshTokenTable{}, RefreshTokenNotFoundError{}
+}
+
+// UseRefreshToken marks the refresh token on the cache as used, unless it already was
+func (sc *Session) UseRefreshToken(ctx context.Context, id int64) error {
+
+	for i, refreshToken := range sc.refreshTokens {
+		if refreshToken.ID == id {
+			if !refreshToken.UsedAt.IsZero() {
+				return RefreshTokenAlreadyUsedError{}
+			}
+			sc.refreshTokens[i].UsedAt = time.Now()
+			return nil
+		}
+	}
+
+	return RefreshTokenNotFoundError{}
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+// SessionNotFoundByIDError error when a session is not found by id on the cache
+type SessionNotFoundByIDError struct {
+	id int64
+}
+
+// Error is the string representation of SessionNotFoundByIDError
+func (snfe SessionNotFoundByIDError) Error() string {
+	return fmt.Sprintf("error: session with id: %d was not found by id in the repository", snfe.id)
+}
+
+// Unwrap allows SessionNotFoundByIDError to match repository.ErrNotFound
+func (snfe SessionNotFoundByIDError) Unwrap() error {
+	return repository.ErrNotFound
+}
+
+// RefreshTokenNotFoundError error when a refresh token is not found on the cache
+type RefreshTokenNotFoundError struct{}
+
+// Error is the string representation of RefreshTokenNotFoundError
+func (rtnfe RefreshTokenNotFoundError) Error() string {
+	return "error: refresh token was not found in the repository"
+}
+
+// Unwrap allows RefreshTokenNotFoundError to match repository.ErrNotFound
+func (rtnfe RefreshTokenNotFoundError) Unwrap() error {
+	return repository.ErrNotFound
+}
+
+// RefreshTokenAlreadyUsedError error when a refresh token on the cache was already used
+type RefreshTokenAlreadyUsedError struct{}
+
+// Error is the string representation of RefreshTokenAlreadyUsedError
+func (rtaue RefreshTokenAlreadyUsedError) Error() string {
+	return "error: refresh token was already used"
+}
+
+// Unwrap allows RefreshTokenAlreadyUsedError to match repository.ErrAlreadyUsed
+func (rtaue RefreshTokenAlreadyUsedError) Unwrap() error {
+	return repository.ErrAlreadyUsed
+}
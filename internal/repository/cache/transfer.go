@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// Transfer implements the transfer repository methods
+type Transfer struct {
+	repository []models.TransferView
+}
+
+// NewTransfer creates a Transfer cache
+func NewTransfer(repository []models.TransferView) Transfer {
+	return Transfer{
+		repository: repository,
+	}
+}
+
+// InsertTransfer inserts a transfer on the cache
+func (tc *Transfer) InsertTransfer(ctx context.Context, transfer models.TransferTable) (int64, error) {
+
+	tc.repository = append(tc.repository, transferTableToView(transfer))
+
+	return 1, nil
+}
+
+// UpdateTransfer updates a transfer on the cache if it exists
+func (tc *Transfer) UpdateTransfer(ctx context.Context, transfer models.TransferTable) (int64, error) {
+
+	for idx, existing := range tc.repository {
+		if existing.ID == transfer.ID && existing.UserID == transfer.UserID {
+			tc.repository[idx] = transferTableToView(transfer)
+			return transfer.ID, nil
+		}
+	}
+
+	return 0, TransferNotFoundByIDError{
+		id: transfer.ID,
+	}
+}
+
+// GetTransferByID returns the transfer from the cache if one with that id exists
+func (tc *Transfer) GetTransferByID(ctx context.Context, userID int64, id int64) (models.TransferView, error) {
+
+	for _, transfer := range tc.repository {
+		if transfer.ID == id && transfer.UserID == userID {
+			return transfer, nil
+		}
+	}
+
+	return models.TransferView{}, TransferNotFoundByIDError{
+		id: id,
+	}
+}
+
+// GetTransfersByDates returns the transfers of the user from the cache with a date between the min and the max dates
+func (tc *Transfer) GetTransfersByDates(
+	ctx context.Context,
+	userID int64,
+	minDate time.Time,
+	maxDate time.Time,
+) ([]models.TransferView, error) {
+
+	transfers := []models.TransferView{}
+	for _, transfer := range tc.repository {
+		if transfer.UserID == userID && !transfer.Date.Before(minDate) && !transfer.Date.After(maxDate) {
+			transfers = append(transfers, transfer)
+		}
+	}
+
+	return transfers, nil
+}
+
+// DeleteTransfer deletes the transfer from the cache if it exists
+func (tc *Transfer) DeleteTransfer(ctx context.Context, userID int64, id int64) error {
+
+	for idx, transfer := range tc.repository {
+		if transfer.ID == id && transfer.UserID == userID {
+			tc.repository = append(tc.repository[:idx], tc.repository[idx+1:]...)
+			return nil
+		}
+	}
+
+	return TransferNotFoundByIDError{
+		id: id,
+	}
+}
+
+func transferTableToView(transfer models.TransferTable) models.TransferView {
+	return models.TransferView{
+		ID:          transfer.ID,
+		Amount:      transfer.Amount,
+		Fee:         transfer.Fee,
+		Date:        transfer.Date,
+		Description: transfer.Description,
+		FromCardID:  transfer.FromCardID,
+		ToCardID:    transfer.ToCardID,
+		UserID:      transfer.UserID,
+	}
+}
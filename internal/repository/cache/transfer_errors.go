@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+// TransferNotFoundByIDError error when a transfer is not found by id on the cache
+type TransferNotFoundByIDError struct {
+	id int64
+}
+
+// Error is the string representation of TransferNotFoundByIDError
+func (tnfe TransferNotFoundByIDError) Error() string {
+	return fmt.Sprintf("error: transfer with id: %d was not found by id in the repository", tnfe.id)
+}
+
+// Unwrap allows TransferNotFoundByIDError to match repository.ErrNotFound
+func (tnfe TransferNotFoundByIDError) Unwrap() error {
+	return repository.ErrNotFound
+}
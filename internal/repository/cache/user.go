@@ -2,19 +2,24 @@ package cache
 
 import (
 	"context"
+	"time"
 
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
 )
 
 // User implements the user repository methods
 type User struct {
-	repository []models.UserTable
+	repository     []models.UserTable
+	passwordResets []models.PasswordResetTable
+	recoveryCodes  []models.RecoveryCodeTable
 }
 
 // NewUser creates a Card cache
 func NewUser() User {
 	return User{
-		repository: []models.UserTable{},
+		repository:     []models.UserTable{},
+		passwordResets: []models.PasswordResetTable{},
+		recoveryCodes:  []models.RecoveryCodeTable{},
 	}
 }
 
@@ -28,9 +33,10 @@ func (u *User) InsertUser(ctx context.Context, user models.UserTable) (int64, er
 		}
 	}
 
+	user.ID = int64(len(u.repository) + 1)
 	u.repository = append(u.repository, user)
 
-	return 1, nil
+	return user.ID, nil
 }
 
 // GetUserByUsername returns the user from the cache if user with that name exists
@@ -46,3 +52,174 @@ func (u *User) GetUserByUsername(ctx context.Context, username string) (models.U
 		username: username,
 	}
 }
+
+// GetUserByID returns the user from the cache if user with that id exists
+func (u *User) GetUserByID(ctx context.Context, id int64) (models.UserTable, error) {
+
+	for _, user := range u.repository {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+
+	return models.UserTable{}, UserNotFoundByIDError{
+		id: id,
+	}
+}
+
+// UpdatePassword sets the password hash of the user on the cache if user with that id exists
+func (u *User) UpdatePassword(ctx context.Context, id int64, passhash string) error {
+
+	return u.updateUser(id, func(user *models.UserTable) {
+		user.Passhash = passhash
+	})
+}
+
+// InsertPasswordReset inserts a password reset on the cache and returns its id
+func (u *User) InsertPasswordReset(ctx context.Context, reset models.PasswordResetTable) (int64, error) {
+
+	reset.ID = int64(len(u.passwordResets) + 1)
+	reset.CreatedAt = time.Now()
+	u.passwordResets = append(u.passwordResets, reset)
+
+	return reset.ID, nil
+}
+
+// GetPasswordResetByHash returns the password reset from the cache if one with that token hash exists
+func (u *User) GetPasswordResetByHash(ctx context.Context, tokenHash string) (models.PasswordResetTable, error) {
+
+	for _, reset := range u.passwordResets {
+		if reset.TokenHash == tokenHash {
+			return reset, nil
+		}
+	}
+
+	return models.PasswordResetTable{}, PasswordResetNotFoundError{}
+}
+
+// ResetPassword marks the password reset on the cache as used, unless it already was, and sets the password hash of its user
+func (u *User) ResetPassword(ctx context.Context, resetID int64, passhash string) error {
+
+	for i, reset := range u.passwordResets {
+		if reset.ID != resetID {
+			continue
+		}
+		if !reset.UsedAt.IsZero() {
+			return PasswordResetAlreadyUsedError{
+				id: resetID,
+			}
+		}
+		u.passwordResets[i].UsedAt = time.Now()
+		return u.UpdatePassword(ctx, reset.UserID, passhash)
+	}
+
+	return PasswordResetNotFoundError{}
+}
+
+// SetTOTPSecret sets the TOTP secret of the user on the cache, leaving 2FA disabled
+func (u *User) SetTOTPSecret(ctx context.Context, id int64, secret string) error {
+	return u.updateUser(id, func(user *models.UserTable) {
+		user.TOTPSecret = secret
+		user.TOTPEnabledAt = time.Time{}
+	})
+}
+
+// EnableTOTP enables the 2FA of the user on the cache and replaces its recovery codes
+func (u *User) EnableTOTP(ctx context.Context, id int64, recoveryCodeHashes []string) error {
+
+	err := u.updateUser(id, func(user *models.UserTable) {
+		user.TOTPEnabledAt = time.Now()
+	})
+	if err != nil {
+		return err
+	}
+
+	u.replaceRecoveryCodes(id, recoveryCodeHashes)
+
+	return nil
+}
+
+// DisableTOTP disables the 2FA of the user on the cache, dropping its secret and its recovery codes
+func (u *User) DisableTOTP(ctx context.Context, id int64) error {
+
+	err := u.updateUser(id, func(user *models.UserTable) {
+		user.TOTPSecret = ""
+		user.TOTPEnabledAt = time.Time{}
+	})
+	if err != nil {
+		return err
+	}
+
+	u.replaceRecoveryCodes(id, []string{})
+
+	return nil
+}
+
+// UseTOTPStep sets the time step of the last accepted TOTP code of the user on the cache, unless it is not after the current one
+func (u *User) UseTOTPStep(ctx context.Context, id int64, step int64) error {
+
+	user, err := u.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if step <= user.TOTPLastStep {
+		return TOTPStepAlreadyUsedError{
+			step: step,
+		}
+	}
+
+	return u.updateUser(id, func(user *models.UserTable) {
+		user.TOTPLastStep = step
+	})
+}
+
+// UseRecoveryCode marks an unused recovery code of the user on the cache as used
+func (u *User) UseRecoveryCode(ctx context.Context, id int64, codeHash string) error {
+
+	for i, code := range u.recoveryCodes {
+		if code.UserID == id && code.CodeHash == codeHash && code.UsedAt.IsZero() {
+			u.recoveryCodes[i].UsedAt = time.Now()
+			return nil
+		}
+	}
+
+	return RecoveryCodeNotFoundError{}
+}
+
+// updateUser applies the update to the user on the cache if user with that id exists
+func (u *User) updateUser(id int64, update func(*models.UserTable)) error {
+
+	for i := range u.repository {
+		if u.repository[i].ID == id {
+			update(&u.repository[i])
+			return nil
+		}
+	}
+
+	return UserNotFoundByIDError{
+		id: id,
+	}
+}
+
+// replaceRecoveryCodes drops the recovery codes of the user on the cache and adds the ones of the code hashes
+func (u *User) replaceRecoveryCodes(id int64, codeHashes []string) {
+
+	kept := []models.RecoveryCodeTable{}
+	for _, code := range u.recoveryCodes {
+		if code.UserID != id {
+			kept = append(kept, code)
+		}
+	}
+
+	for _, codeHash := range codeHashes {
+		kept = append(kept, models.RecoveryCodeTable{
+			ID:        int64(len(kept) + 1),
+			CodeHash:  codeHash,
+			CreatedAt: time.Now(),
+			UserID:    id,
+		})
+	}
+
+	u.recoveryCodes = kept
+}
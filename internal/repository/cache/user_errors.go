@@ -1,6 +1,10 @@
 package cache
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
 
 // UserNotFoundByNameError error when a user is not found by name on the cache
 type UserNotFoundByNameError struct {
@@ -12,6 +16,26 @@ func (unfe UserNotFoundByNameError) Error() string {
 	return fmt.Sprintf("error: user with username: %s was not found by username in the repository", unfe.username)
 }
 
+// Unwrap allows UserNotFoundByNameError to match repository.ErrNotFound
+func (unfe UserNotFoundByNameError) Unwrap() error {
+	return repository.ErrNotFound
+}
+
+// UserNotFoundByIDError error when a user is not found by id on the cache
+type UserNotFoundByIDError struct {
+	id int64
+}
+
+// Error is the string representation of UserNotFoundByIDError
+func (unfie UserNotFoundByIDError) Error() string {
+	return fmt.Sprintf("error: user with id: %d was not found by id in the repository", unfie.id)
+}
+
+// Unwrap allows UserNotFoundByIDError to match repository.ErrNotFound
+func (unfie UserNotFoundByIDError) Unwrap() error {
+	return repository.ErrNotFound
+}
+
 // UserAlreadyExistsError error when a user already exists on the cache
 type UserAlreadyExistsError struct {
 	username string
@@ -21,3 +45,59 @@ type UserAlreadyExistsError struct {
 func (uae UserAlreadyExistsError) Error() string {
 	return fmt.Sprintf("error: user with username: %s already exists in the repository", uae.username)
 }
+
+// PasswordResetNotFoundError error when a password reset is not found on the cache
+type PasswordResetNotFoundError struct{}
+
+// Error is the string representation of PasswordResetNotFoundError
+func (prnfe PasswordResetNotFoundError) Error() string {
+	return "error: password reset was not found in the repository"
+}
+
+// Unwrap allows PasswordResetNotFoundError to match repository.ErrNotFound
+func (prnfe PasswordResetNotFoundError) Unwrap() error {
+	return repository.ErrNotFound
+}
+
+// PasswordResetAlreadyUsedError error when a password reset on the cache was already used
+type PasswordResetAlreadyUsedError struct {
+	id int64
+}
+
+// Error is the string representation of PasswordResetAlreadyUsedError
+func (praue PasswordResetAlreadyUsedError) Error() string {
+	return fmt.Sprintf("error: password reset with id: %d was already used", praue.id)
+}
+
+// Unwrap allows PasswordResetAlreadyUsedError to match repository.ErrAlreadyUsed
+func (praue PasswordResetAlreadyUsedError) Unwrap() error {
+	return repository.ErrAlreadyUsed
+}
+
+// TOTPStepAlreadyUsedError error when a TOTP code of the same or a later step was already used on the cache
+type TOTPStepAlreadyUsedError struct {
+	step int64
+}
+
+// Error is the string representation of TOTPStepAlreadyUsedError
+func (tsaue TOTPStepAlreadyUsedError) Error() string {
+	return fmt.Sprintf("error: a TOTP code of step: %d or later was already used", tsaue.step)
+}
+
+// Unwrap allows TOTPStepAlreadyUsedError to match repository.ErrAlreadyUsed
+func (tsaue TOTPStepAlreadyUsedError) Unwrap() error {
+	return repository.ErrAlreadyUsed
+}
+
+// RecoveryCodeNotFoundError error when an unused recovery code is not found on the cache
+type RecoveryCodeNotFoundError struct{}
+
+// Error is the string representation of RecoveryCodeNotFoundError
+func (rcnfe RecoveryCodeNotFoundError) Error() string {
+	return "error: unused recovery code was not found in the repository"
+}
+
+// Unwrap allows RecoveryCodeNotFoundError to match repository.ErrNotFound
+func (rcnfe RecoveryCodeNotFoundError) Unwrap() error {
+	return repository.ErrNotFound
+}
@@ -9,10 +9,13 @@ import (
 //go:generate gowrap gen -g -i CardRepo -t ./templates/log_template.go.tmpl -o ./database/card/with_logs_by_template.go
 //go:generate gowrap gen -g -i CardRepo -t ./templates/red_template.go.tmpl -o ./database/card/with_red_by_template.go
 // CardRepo defines the card repository interface.
+// Cards are owned by a user: lookups take the owner user id right after the context.
+// DeleteCard returns ErrInUse if the card still has expenses, incomes, transfers or other records.
 type CardRepo interface {
 	InsertCard(context.Context, models.CardTable) (int64, error)
 	UpdateCard(context.Context, models.CardTable) (int64, error)
-	GetCardByID(context.Context, int64) (models.CardTable, error)
-	GetCardByName(context.Context, string) (models.CardTable, error)
-	DeleteCard(context.Context, int64) error
+	GetCardByID(context.Context, int64, int64) (models.CardTable, error)
+	GetCardByName(context.Context, int64, string) (models.CardTable, error)
+	GetCards(context.Context, int64) ([]models.CardTable, error)
+	DeleteCard(context.Context, int64, int64) error
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+//go:generate gowrap gen -g -i CategorizationRuleRepo -t ./templates/log_template.go.tmpl -o ./database/rule/with_logs_by_template.go
+//go:generate gowrap gen -g -i CategorizationRuleRepo -t ./templates/red_template.go.tmpl -o ./database/rule/with_red_by_template.go
+// CategorizationRuleRepo defines the categorization rule repository interface.
+// Rules are owned by a user: lookups take the owner user id right after the context.
+// Rules are listed in the order they are tried: by ascending priority, then by id.
+type CategorizationRuleRepo interface {
+	InsertCategorizationRule(context.Context, models.CategorizationRuleTable) (int64, error)
+	UpdateCategorizationRule(context.Context, models.CategorizationRuleTable) (int64, error)
+	GetCategorizationRuleByID(context.Context, int64, int64) (models.CategorizationRuleView, error)
+	GetCategorizationRules(context.Context, int64) ([]models.CategorizationRuleView, error)
+	DeleteCategorizationRule(context.Context, int64, int64) error
+}
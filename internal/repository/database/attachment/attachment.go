@@ -0,0 +1,170 @@
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const (
+	tableNameAttachments = "attachments"
+
+	selectAttachmentsStmt = `SELECT 
+	id, file_name, content_type, size, checksum, storage_key, created_at, 
+	COALESCE(expense_id, 0), COALESCE(income_id, 0), user_id
+	FROM attachments`
+)
+
+// DB implements the attachment repository methods
+type DB struct {
+	database *sql.DB
+}
+
+// NewDB creates a new AttachmentRepo
+func NewDB(database *sql.DB) DB {
+	return DB{
+		database: database,
+	}
+}
+
+// InsertAttachment inserts an attachment on the attachments db table
+// if its expense or income is owned by its user
+func (a DB) InsertAttachment(ctx context.Context, attachment models.AttachmentTable) (int64, error) {
+
+	insertStmt := fmt.Sprintf(`INSERT INTO %s 
+	(file_name, content_type, size, checksum, storage_key, expense_id, income_id, user_id) 
+	SELECT $1, $2, $3, $4, $5, NULLIF($6, 0), NULLIF($7, 0), $8 
+	WHERE EXISTS (SELECT 1 FROM expenses WHERE id = $6 AND user_id = $8) 
+	OR EXISTS (SELECT 1 FROM incomes WHERE id = $7 AND user_id = $8) 
+	RETURNING id`, tableNameAttachments)
+
+	var id int64
+
+	err := a.database.QueryRowContext(
+		ctx,
+		insertStmt,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.Checksum,
+		attachment.StorageKey,
+		attachment.ExpenseID,
+		attachment.IncomeID,
+		attachment.UserID,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrTransactionNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error scanning attachment id: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetAttachmentByID gets an attachment from the attachments db table by id
+func (a DB) GetAttachmentByID(ctx context.Context, userID int64, id int64) (models.AttachmentTable, error) {
+
+	selectStmt := selectAttachmentsStmt + " WHERE id = $1 AND user_id = $2"
+
+	row := a.database.QueryRowContext(ctx, selectStmt, id, userID)
+
+	attachment, err := scanAttachment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.AttachmentTable{}, repository.ErrNotFound
+	}
+	if err != nil {
+		return models.AttachmentTable{}, fmt.Errorf("error scanning attachment fields: %v", err)
+	}
+
+	return attachment, nil
+}
+
+// GetAttachmentsByExpense gets the attachments of the expense from the attachments db table, oldest first
+func (a DB) GetAttachmentsByExpense(ctx context.Context, userID int64, expenseID int64) ([]models.AttachmentTable, error) {
+	return a.getAttachments(ctx, "expense_id", userID, expenseID)
+}
+
+// GetAttachmentsByIncome gets the attachments of the income from the attachments db table, oldest first
+func (a DB) GetAttachmentsByIncome(ctx context.Context, userID int64, incomeID int64) ([]models.AttachmentTable, error) {
+	return a.getAttachments(ctx, "income_id", userID, incomeID)
+}
+
+// DeleteAttachment deletes an attachment from the attachments db table
+func (a DB) DeleteAttachment(ctx context.Context, userID int64, id int64) error {
+
+	deleteStmt := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", tableNameAttachments)
+
+	result, err := a.database.ExecContext(ctx, deleteStmt, id, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting attachment by id: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get number of rows affected in exec attachment delete statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return ErrNoRowsAffectedOnDelete
+	}
+
+	return nil
+}
+
+// getAttachments gets the attachments of the user linked to the transaction with the id on the column
+func (a DB) getAttachments(ctx context.Context, column string, userID int64, id int64) ([]models.AttachmentTable, error) {
+
+	selectStmt := selectAttachmentsStmt + fmt.Sprintf(" WHERE %s = $1 AND user_id = $2 ORDER BY created_at, id", column)
+
+	rows, err := a.database.QueryContext(ctx, selectStmt, id, userID)
+	if err != nil {
+		return []models.AttachmentTable{}, fmt.Errorf("could not query select attachments statement: %v", err)
+	}
+	defer rows.Close()
+
+	attachments := []models.AttachmentTable{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return []models.AttachmentTable{}, fmt.Errorf("could not scan attachment fields: %v", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.AttachmentTable{}, fmt.Errorf("found error after scanning all attachments fields: %v", err)
+	}
+
+	return attachments, nil
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAttachment(row scanner) (models.AttachmentTable, error) {
+
+	var attachment models.AttachmentTable
+
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.Checksum,
+		&attachment.StorageKey,
+		&attachment.CreatedAt,
+		&attachment.ExpenseID,
+		&attachment.IncomeID,
+		&attachment.UserID,
+	)
+
+	return attachment, err
+}
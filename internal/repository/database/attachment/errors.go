@@ -0,0 +1,12 @@
+package attachment
+
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+var (
+	ErrNoRowsAffectedOnDelete = fmt.Errorf("there were no rows affected in exec attachment delete statement: %w", repository.ErrNotFound)
+	ErrTransactionNotFound    = fmt.Errorf("the expense or income of the attachment does not exist: %w", repository.ErrNotFound)
+)
@@ -0,0 +1,148 @@
+// Code generated by gowrap. DO NOT EDIT.
+// template: ../../templates/log_template.go.tmpl
+// gowrap: http://github.com/hexdigest/gowrap
+
+package attachment
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// AttachmentRepoWithLogs implements repository.AttachmentRepo that is instrumented with zerolog logger
+type AttachmentRepoWithLogs struct {
+	base repository.AttachmentRepo
+}
+
+// DeleteAttachment implements repository.AttachmentRepo
+func (d AttachmentRepoWithLogs) DeleteAttachment(ctx context.Context, i1 int64, i2 int64) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "AttachmentRepoWithLogs").Str("method", "DeleteAttachment").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "AttachmentRepoWithLogs").Str("method", "DeleteAttachment").Msg("Finish")
+		}
+	}()
+	return d.base.DeleteAttachment(ctx, i1, i2)
+}
+
+// GetAttachmentByID implements repository.AttachmentRepo
+func (d AttachmentRepoWithLogs) GetAttachmentByID(ctx context.Context, i1 int64, i2 int64) (a1 models.AttachmentTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"a1":  a1,
+				"err": err}).Err(err).Str("decorator", "AttachmentRepoWithLogs").Str("method", "GetAttachmentByID").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"a1":  a1,
+				"err": err}).Str("decorator", "AttachmentRepoWithLogs").Str("method", "GetAttachmentByID").Msg("Finish")
+		}
+	}()
+	return d.base.GetAttachmentByID(ctx, i1, i2)
+}
+
+// GetAttachmentsByExpense implements repository.AttachmentRepo
+func (d AttachmentRepoWithLogs) GetAttachmentsByExpense(ctx context.Context, i1 int64, i2 int64) (aa1 []models.AttachmentTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"aa1": aa1,
+				"err": err}).Err(err).Str("decorator", "AttachmentRepoWithLogs").Str("method", "GetAttachmentsByExpense").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"aa1": aa1,
+				"err": err}).Str("decorator", "AttachmentRepoWithLogs").Str("method", "GetAttachmentsByExpense").Msg("Finish")
+		}
+	}()
+	return d.base.GetAttachmentsByExpense(ctx, i1, i2)
+}
+
+// GetAttachmentsByIncome implements repository.AttachmentRepo
+func (d AttachmentRepoWithLogs) GetAttachmentsByIncome(ctx context.Context, i1 int64, i2 int64) (aa1 []models.AttachmentTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"aa1": aa1,
+				"err": err}).Err(err).Str("decorator", "AttachmentRepoWithLogs").Str("method", "GetAttachmentsByIncome").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"aa1": aa1,
+				"err": err}).Str("decorator", "AttachmentRepoWithLogs").Str("method", "GetAttachmentsByIncome").Msg("Finish")
+		}
+	}()
+	return d.base.GetAttachmentsByIncome(ctx, i1, i2)
+}
+
+// InsertAttachment implements repository.AttachmentRepo
+func (d AttachmentRepoWithLogs) InsertAttachment(ctx context.Context, a1 models.AttachmentTable) (i1 int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"a1":  a1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Err(err).Str("decorator", "AttachmentRepoWithLogs").Str("method", "InsertAttachment").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Str("decorator", "AttachmentRepoWithLogs").Str("method", "InsertAttachment").Msg("Finish")
+		}
+	}()
+	return d.base.InsertAttachment(ctx, a1)
+}
+
+// NewAttachmentRepoWithLogs instruments an implementation of the repository.AttachmentRepo with simple logging
+func NewAttachmentRepoWithLogs(base repository.AttachmentRepo) repository.AttachmentRepo {
+	decorate := os.Getenv("DECORATE")
+	if decorate == "true" || decorate == "1" {
+		return AttachmentRepoWithLogs{
+			base: base,
+		}
+	}
+
+	return base
+}
@@ -0,0 +1,174 @@
+// Code generated by gowrap. DO NOT EDIT.
+// template: ../../templates/red_template.go.tmpl
+// gowrap: http://github.com/hexdigest/gowrap
+
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rubengomes8/golang-personal-finances/internal/instrumentation"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+type AttachmentRepoWithRED struct {
+	base         repository.AttachmentRepo
+	histogramVec *prometheus.HistogramVec
+}
+
+// DeleteAttachment implements repository.AttachmentRepo
+func (d AttachmentRepoWithRED) DeleteAttachment(ctx context.Context, i1 int64, i2 int64) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "DeleteAttachment",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.DeleteAttachment(ctx, i1, i2)
+}
+
+// GetAttachmentByID implements repository.AttachmentRepo
+func (d AttachmentRepoWithRED) GetAttachmentByID(ctx context.Context, i1 int64, i2 int64) (a1 models.AttachmentTable, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetAttachmentByID",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetAttachmentByID(ctx, i1, i2)
+}
+
+// GetAttachmentsByExpense implements repository.AttachmentRepo
+func (d AttachmentRepoWithRED) GetAttachmentsByExpense(ctx context.Context, i1 int64, i2 int64) (aa1 []models.AttachmentTable, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetAttachmentsByExpense",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetAttachmentsByExpense(ctx, i1, i2)
+}
+
+// GetAttachmentsByIncome implements repository.AttachmentRepo
+func (d AttachmentRepoWithRED) GetAttachmentsByIncome(ctx context.Context, i1 int64, i2 int64) (aa1 []models.AttachmentTable, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetAttachmentsByIncome",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetAttachmentsByIncome(ctx, i1, i2)
+}
+
+// InsertAttachment implements repository.AttachmentRepo
+func (d AttachmentRepoWithRED) InsertAttachment(ctx context.Context, a1 models.AttachmentTable) (i1 int64, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "InsertAttachment",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.InsertAttachment(ctx, a1)
+}
+
+// NewAttachmentRepoWithRED returns an instance of the repository.AttachmentRepo decorated with red histogram metric
+func NewAttachmentRepoWithRED(base repository.AttachmentRepo, constLabels prometheus.Labels) (decorator repository.AttachmentRepo, err error) {
+	decorate := os.Getenv("DECORATE")
+	if !(decorate == "true" || decorate == "1") {
+		return base, nil
+	}
+
+	subSystem := "attachment_repo"
+
+	metricConfig := prometheus.HistogramOpts{
+		Namespace:   strings.TrimSpace("system"),
+		Subsystem:   subSystem,
+		Name:        fmt.Sprintf("%s_red", subSystem),
+		Help:        "AttachmentRepo RED histogram (rate, errors and duration).",
+		ConstLabels: constLabels,
+		Buckets:     prometheus.ExponentialBuckets(100, 2, 5),
+	}
+
+	red := AttachmentRepoWithRED{
+		base:         base,
+		histogramVec: prometheus.NewHistogramVec(metricConfig, []string{"status", "method"}),
+	}
+
+	err = instrumentation.Registry.Register(red.histogramVec)
+	if err != nil {
+		return nil, err
+	}
+
+	return red, nil
+}
@@ -0,0 +1,196 @@
+package balance
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const (
+	tableNameReconciliations = "reconciliations"
+
+	// selectCardMovementsStmt gets the expenses, incomes and both sides of the transfers of a card in a dates' range.
+	// Transfers are in the currency of their source card, and a transfer out includes its fee.
+	selectCardMovementsStmt = `SELECT kind, id, date, amount, currency, description, cleared FROM (
+	SELECT 'expense' AS kind, id, date, -value AS amount, currency, COALESCE(description, '') AS description, cleared 
+	FROM expenses WHERE user_id = $1 AND card_id = $2 AND date BETWEEN $3 AND $4
+	UNION ALL
+	SELECT 'income', id, date, value, currency, COALESCE(description, ''), cleared 
+	FROM incomes WHERE user_id = $1 AND card_id = $2 AND date BETWEEN $3 AND $4
+	UNION ALL
+	SELECT 'transfer_out', t.id, t.date, -(t.amount + t.fee), c.currency, COALESCE(t.description, ''), t.from_cleared 
+	FROM transfers t JOIN cards c ON t.from_card_id = c.id 
+	WHERE t.user_id = $1 AND t.from_card_id = $2 AND t.date BETWEEN $3 AND $4
+	UNION ALL
+	SELECT 'transfer_in', t.id, t.date, t.amount, c.currency, COALESCE(t.description, ''), t.to_cleared 
+	FROM transfers t JOIN cards c ON t.from_card_id = c.id 
+	WHERE t.user_id = $1 AND t.to_card_id = $2 AND t.date BETWEEN $3 AND $4
+	) movements ORDER BY date, kind, id`
+)
+
+// clearStmts are the statements that clear each kind of movement of a card
+var clearStmts = map[string]string{
+	models.MovementExpense:     "UPDATE expenses SET cleared = TRUE WHERE id = $1 AND user_id = $2 AND card_id = $3",
+	models.MovementIncome:      "UPDATE incomes SET cleared = TRUE WHERE id = $1 AND user_id = $2 AND card_id = $3",
+	models.MovementTransferOut: "UPDATE transfers SET from_cleared = TRUE WHERE id = $1 AND user_id = $2 AND from_card_id = $3",
+	models.MovementTransferIn:  "UPDATE transfers SET to_cleared = TRUE WHERE id = $1 AND user_id = $2 AND to_card_id = $3",
+}
+
+// DB implements the balance repository methods
+type DB struct {
+	database *sql.DB
+}
+
+// NewDB creates a new BalanceRepo
+func NewDB(database *sql.DB) DB {
+	return DB{
+		database: database,
+	}
+}
+
+// GetCardMovements gets the expenses, incomes and transfers of a card in the dates' range provided
+func (b DB) GetCardMovements(
+	ctx context.Context,
+	userID int64,
+	cardID int64,
+	minDate time.Time,
+	maxDate time.Time,
+) ([]models.CardMovement, error) {
+
+	rows, err := b.database.QueryContext(ctx, selectCardMovementsStmt, userID, cardID, minDate, maxDate)
+	if err != nil {
+		return []models.CardMovement{}, fmt.Errorf("could not query select card movements statement: %v", err)
+	}
+	defer rows.Close()
+
+	movements := []models.CardMovement{}
+	for rows.Next() {
+		var movement models.CardMovement
+		err := rows.Scan(
+			&movement.Kind,
+			&movement.ID,
+			&movement.Date,
+			&movement.Amount,
+			&movement.Currency,
+			&movement.Description,
+			&movement.Cleared,
+		)
+		if err != nil {
+			return []models.CardMovement{}, fmt.Errorf("could not scan card movement fields: %v", err)
+		}
+		movements = append(movements, movement)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.CardMovement{}, fmt.Errorf("found error after scanning all card movements fields: %v", err)
+	}
+
+	return movements, nil
+}
+
+// ClearCardMovements marks the movements of a card as cleared in a single transaction.
+// If a movement is not of the card the transaction is rolled back.
+func (b DB) ClearCardMovements(ctx context.Context, userID int64, cardID int64, refs []models.MovementRef) error {
+
+	tx, err := b.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin card movements clear transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	for _, ref := range refs {
+
+		clearStmt, ok := clearStmts[ref.Kind]
+		if !ok {
+			return fmt.Errorf("unknown card movement kind: %s", ref.Kind)
+		}
+
+		result, err := tx.ExecContext(ctx, clearStmt, ref.ID, userID, cardID)
+		if err != nil {
+			return fmt.Errorf("error clearing card movement: %v", err)
+		}
+
+		numRowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("could not get number of rows affected in exec card movement clear statement: %v", err)
+		}
+
+		if numRowsAffected == 0 {
+			return ErrNoRowsAffectedOnClear
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("could not commit card movements clear transaction: %v", err)
+	}
+
+	return nil
+}
+
+// InsertReconciliation inserts a reconciliation on the reconciliations db table
+func (b DB) InsertReconciliation(ctx context.Context, reconciliation models.ReconciliationTable) (int64, error) {
+
+	insertStmt := fmt.Sprintf(`INSERT INTO %s 
+	(date, statement_balance, cleared_balance, card_id, user_id) 
+	VALUES ($1, $2, $3, $4, $5) 
+	RETURNING id`, tableNameReconciliations)
+
+	var id int64
+
+	err := b.database.QueryRowContext(
+		ctx,
+		insertStmt,
+		reconciliation.Date,
+		reconciliation.StatementBalance,
+		reconciliation.ClearedBalance,
+		reconciliation.CardID,
+		reconciliation.UserID,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("error scanning reconciliation id: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetReconciliations gets the reconciliations of a card, the latest first
+func (b DB) GetReconciliations(ctx context.Context, userID int64, cardID int64) ([]models.ReconciliationTable, error) {
+
+	selectStmt := fmt.Sprintf(`SELECT id, date, statement_balance, cleared_balance, card_id, user_id 
+	FROM %s WHERE user_id = $1 AND card_id = $2 ORDER BY date DESC, id DESC`, tableNameReconciliations)
+
+	rows, err := b.database.QueryContext(ctx, selectStmt, userID, cardID)
+	if err != nil {
+		return []models.ReconciliationTable{}, fmt.Errorf("could not query select reconciliations statement: %v", err)
+	}
+	defer rows.Close()
+
+	reconciliations := []models.ReconciliationTable{}
+	for rows.Next() {
+		var reconciliation models.ReconciliationTable
+		err := rows.Scan(
+			&reconciliation.ID,
+			&reconciliation.Date,
+			&reconciliation.StatementBalance,
+			&reconciliation.ClearedBalance,
+			&reconciliation.CardID,
+			&reconciliation.UserID,
+		)
+		if err != nil {
+			return []models.ReconciliationTable{}, fmt.Errorf("could not scan reconciliation fields: %v", err)
+		}
+		reconciliations = append(reconciliations, reconciliation)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.ReconciliationTable{}, fmt.Errorf("found error after scanning all reconciliations fields: %v", err)
+	}
+
+	return reconciliations, nil
+}
@@ -0,0 +1,11 @@
+package balance
+
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+var (
+	ErrNoRowsAffectedOnClear = fmt.Errorf("there were no rows affected in exec card movement clear statement: %w", repository.ErrNotFound)
+)
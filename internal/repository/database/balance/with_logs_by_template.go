@@ -0,0 +1,128 @@
+// Code generated by gowrap. DO NOT EDIT.
+// template: ../../templates/log_template.go.tmpl
+// gowrap: http://github.com/hexdigest/gowrap
+
+package balance
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// BalanceRepoWithLogs implements repository.BalanceRepo that is instrumented with zerolog logger
+type BalanceRepoWithLogs struct {
+	base repository.BalanceRepo
+}
+
+// ClearCardMovements implements repository.BalanceRepo
+func (d BalanceRepoWithLogs) ClearCardMovements(ctx context.Context, i1 int64, i2 int64, ma1 []models.MovementRef) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2,
+		"ma1": ma1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "BalanceRepoWithLogs").Str("method", "ClearCardMovements").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "BalanceRepoWithLogs").Str("method", "ClearCardMovements").Msg("Finish")
+		}
+	}()
+	return d.base.ClearCardMovements(ctx, i1, i2, ma1)
+}
+
+// GetCardMovements implements repository.BalanceRepo
+func (d BalanceRepoWithLogs) GetCardMovements(ctx context.Context, i1 int64, i2 int64, t1 time.Time, t2 time.Time) (ca1 []models.CardMovement, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2,
+		"t1":  t1,
+		"t2":  t2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ca1": ca1,
+				"err": err}).Err(err).Str("decorator", "BalanceRepoWithLogs").Str("method", "GetCardMovements").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ca1": ca1,
+				"err": err}).Str("decorator", "BalanceRepoWithLogs").Str("method", "GetCardMovements").Msg("Finish")
+		}
+	}()
+	return d.base.GetCardMovements(ctx, i1, i2, t1, t2)
+}
+
+// GetReconciliations implements repository.BalanceRepo
+func (d BalanceRepoWithLogs) GetReconciliations(ctx context.Context, i1 int64, i2 int64) (ra1 []models.ReconciliationTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ra1": ra1,
+				"err": err}).Err(err).Str("decorator", "BalanceRepoWithLogs").Str("method", "GetReconciliations").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ra1": ra1,
+				"err": err}).Str("decorator", "BalanceRepoWithLogs").Str("method", "GetReconciliations").Msg("Finish")
+		}
+	}()
+	return d.base.GetReconciliations(ctx, i1, i2)
+}
+
+// InsertReconciliation implements repository.BalanceRepo
+func (d BalanceRepoWithLogs) InsertReconciliation(ctx context.Context, r1 models.ReconciliationTable) (i1 int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"r1":  r1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Err(err).Str("decorator", "BalanceRepoWithLogs").Str("method", "InsertReconciliation").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Str("decorator", "BalanceRepoWithLogs").Str("method", "InsertReconciliation").Msg("Finish")
+		}
+	}()
+	return d.base.InsertReconciliation(ctx, r1)
+}
+
+// NewBalanceRepoWithLogs instruments an implementation of the repository.BalanceRepo with simple logging
+func NewBalanceRepoWithLogs(base repository.BalanceRepo) repository.BalanceRepo {
+	decorate := os.Getenv("DECORATE")
+	if decorate == "true" || decorate == "1" {
+		return BalanceRepoWithLogs{
+			base: base,
+		}
+	}
+
+	return base
+}
@@ -0,0 +1,150 @@
+// Code generated by gowrap. DO NOT EDIT.
+// template: ../../templates/red_template.go.tmpl
+// gowrap: http://github.com/hexdigest/gowrap
+
+package balance
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rubengomes8/golang-personal-finances/internal/instrumentation"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+type BalanceRepoWithRED struct {
+	base         repository.BalanceRepo
+	histogramVec *prometheus.HistogramVec
+}
+
+// ClearCardMovements implements repository.BalanceRepo
+func (d BalanceRepoWithRED) ClearCardMovements(ctx context.Context, i1 int64, i2 int64, ma1 []models.MovementRef) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "ClearCardMovements",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.ClearCardMovements(ctx, i1, i2, ma1)
+}
+
+// GetCardMovements implements repository.BalanceRepo
+func (d BalanceRepoWithRED) GetCardMovements(ctx context.Context, i1 int64, i2 int64, t1 time.Time, t2 time.Time) (ca1 []models.CardMovement, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetCardMovements",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetCardMovements(ctx, i1, i2, t1, t2)
+}
+
+// GetReconciliations implements repository.BalanceRepo
+func (d BalanceRepoWithRED) GetReconciliations(ctx context.Context, i1 int64, i2 int64) (ra1 []models.ReconciliationTable, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetReconciliations",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetReconciliations(ctx, i1, i2)
+}
+
+// InsertReconciliation implements repository.BalanceRepo
+func (d BalanceRepoWithRED) InsertReconciliation(ctx context.Context, r1 models.ReconciliationTable) (i1 int64, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "InsertReconciliation",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.InsertReconciliation(ctx, r1)
+}
+
+// NewBalanceRepoWithRED returns an instance of the repository.BalanceRepo decorated with red histogram metric
+func NewBalanceRepoWithRED(base repository.BalanceRepo, constLabels prometheus.Labels) (decorator repository.BalanceRepo, err error) {
+	decorate := os.Getenv("DECORATE")
+	if !(decorate == "true" || decorate == "1") {
+		return base, nil
+	}
+
+	subSystem := "balance_repo"
+
+	metricConfig := prometheus.HistogramOpts{
+		Namespace:   strings.TrimSpace("system"),
+		Subsystem:   subSystem,
+		Name:        fmt.Sprintf("%s_red", subSystem),
+		Help:        "BalanceRepo RED histogram (rate, errors and duration).",
+		ConstLabels: constLabels,
+		Buckets:     prometheus.ExponentialBuckets(100, 2, 5),
+	}
+
+	red := BalanceRepoWithRED{
+		base:         base,
+		histogramVec: prometheus.NewHistogramVec(metricConfig, []string{"status", "method"}),
+	}
+
+	err = instrumentation.Registry.Register(red.histogramVec)
+	if err != nil {
+		return nil, err
+	}
+
+	return red, nil
+}
@@ -0,0 +1,233 @@
+package budget
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const (
+	tableNameBudgets = "budgets"
+
+	// selectBudgetsStmt joins the budgets with the names of their category or subcategory.
+	// The unset target is read as zero values.
+	selectBudgetsStmt = `SELECT 
+	b.id, COALESCE(b.category_id, 0), COALESCE(ec.name, ''), 
+	COALESCE(b.subcategory_id, 0), COALESCE(es.name, ''), 
+	b.month_limit, b.rollover, b.start_month, b.user_id
+	FROM budgets b
+	LEFT JOIN expense_categories ec ON b.category_id = ec.id
+	LEFT JOIN expense_subcategories es ON b.subcategory_id = es.id`
+)
+
+// DB implements the budget repository methods
+type DB struct {
+	database *sql.DB
+}
+
+// NewDB creates a new BudgetRepo
+func NewDB(database *sql.DB) DB {
+	return DB{
+		database: database,
+	}
+}
+
+// InsertBudget inserts a budget on the budgets db table
+func (b DB) InsertBudget(ctx context.Context, budget models.BudgetTable) (int64, error) {
+
+	insertStmt := fmt.Sprintf(`INSERT INTO %s 
+	(category_id, subcategory_id, month_limit, rollover, start_month, user_id) 
+	VALUES (NULLIF($1::INTEGER, 0), NULLIF($2::INTEGER, 0), $3, $4, $5, $6) 
+	RETURNING id`, tableNameBudgets)
+
+	var id int64
+
+	err := b.database.QueryRowContext(
+		ctx,
+		insertStmt,
+		budget.CategoryID,
+		budget.SubCategoryID,
+		budget.MonthLimit,
+		budget.Rollover,
+		budget.StartMonth,
+		budget.UserID,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("error scanning budget id: %v", err)
+	}
+
+	return id, nil
+}
+
+// UpdateBudget updates a budget on the budgets db table
+func (b DB) UpdateBudget(ctx context.Context, budget models.BudgetTable) (int64, error) {
+
+	updateStmt := fmt.Sprintf(`UPDATE %s SET 
+	category_id = NULLIF($1::INTEGER, 0), subcategory_id = NULLIF($2::INTEGER, 0), 
+	month_limit = $3, rollover = $4, start_month = $5 
+	WHERE id = $6 AND user_id = $7`, tableNameBudgets)
+
+	result, err := b.database.ExecContext(
+		ctx,
+		updateStmt,
+		budget.CategoryID,
+		budget.SubCategoryID,
+		budget.MonthLimit,
+		budget.Rollover,
+		budget.StartMonth,
+		budget.ID,
+		budget.UserID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error updating budget: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not get number of rows affected in exec budget update statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return 0, ErrNoRowsAffectedOnUpdate
+	}
+
+	return budget.ID, nil
+}
+
+// GetBudgetByID gets a budget from the budgets db table by id
+func (b DB) GetBudgetByID(ctx context.Context, userID int64, id int64) (models.BudgetView, error) {
+
+	selectStmt := selectBudgetsStmt + " WHERE b.id = $1 AND b.user_id = $2"
+
+	row := b.database.QueryRowContext(ctx, selectStmt, id, userID)
+
+	budget, err := scanBudget(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.BudgetView{}, repository.ErrNotFound
+	}
+	if err != nil {
+		return models.BudgetView{}, fmt.Errorf("error scanning budget fields: %v", err)
+	}
+
+	return budget, nil
+}
+
+// GetBudgets gets the budgets of the user
+func (b DB) GetBudgets(ctx context.Context, userID int64) ([]models.BudgetView, error) {
+
+	selectStmt := selectBudgetsStmt + " WHERE b.user_id = $1 ORDER BY b.id"
+
+	rows, err := b.database.QueryContext(ctx, selectStmt, userID)
+	if err != nil {
+		return []models.BudgetView{}, fmt.Errorf("could not query select budgets statement: %v", err)
+	}
+	defer rows.Close()
+
+	budgets := []models.BudgetView{}
+	for rows.Next() {
+		budget, err := scanBudget(rows)
+		if err != nil {
+			return []models.BudgetView{}, fmt.Errorf("could not scan budget fields: %v", err)
+		}
+		budgets = append(budgets, budget)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.BudgetView{}, fmt.Errorf("found error after scanning all budgets fields: %v", err)
+	}
+
+	return budgets, nil
+}
+
+// DeleteBudget deletes a budget from the budgets db table
+func (b DB) DeleteBudget(ctx context.Context, userID int64, id int64) error {
+
+	deleteStmt := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", tableNameBudgets)
+
+	result, err := b.database.ExecContext(ctx, deleteStmt, id, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting budget by id: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get number of rows affected in exec budget delete statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return ErrNoRowsAffectedOnDelete
+	}
+
+	return nil
+}
+
+// GetMonthlySpending sums the expense lines of the user on the expense lines view by month, subcategory and currency,
+// so split expenses count towards the budgets of each of their subcategories
+func (b DB) GetMonthlySpending(ctx context.Context, userID int64, minDate time.Time, maxDate time.Time) ([]models.MonthlySpending, error) {
+
+	selectStmt := `SELECT 
+	DATE_TRUNC('month', date)::DATE AS month, category_id, subcategory_id, currency, SUM(value) 
+	FROM expense_lines_view 
+	WHERE user_id = $1 AND date >= $2 AND date < $3 
+	GROUP BY month, category_id, subcategory_id, currency 
+	ORDER BY month, category_id, subcategory_id, currency`
+
+	rows, err := b.database.QueryContext(ctx, selectStmt, userID, minDate, maxDate)
+	if err != nil {
+		return []models.MonthlySpending{}, fmt.Errorf("could not query select monthly spending statement: %v", err)
+	}
+	defer rows.Close()
+
+	spending := []models.MonthlySpending{}
+	for rows.Next() {
+		var monthSpending models.MonthlySpending
+		err := rows.Scan(
+			&monthSpending.Month,
+			&monthSpending.CategoryID,
+			&monthSpending.SubCategoryID,
+			&monthSpending.Currency,
+			&monthSpending.Value,
+		)
+		if err != nil {
+			return []models.MonthlySpending{}, fmt.Errorf("could not scan monthly spending fields: %v", err)
+		}
+		spending = append(spending, monthSpending)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.MonthlySpending{}, fmt.Errorf("found error after scanning all monthly spending fields: %v", err)
+	}
+
+	return spending, nil
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBudget(row scanner) (models.BudgetView, error) {
+
+	var budget models.BudgetView
+
+	err := row.Scan(
+		&budget.ID,
+		&budget.CategoryID,
+		&budget.Category,
+		&budget.SubCategoryID,
+		&budget.SubCategory,
+		&budget.MonthLimit,
+		&budget.Rollover,
+		&budget.StartMonth,
+		&budget.UserID,
+	)
+
+	return budget, err
+}
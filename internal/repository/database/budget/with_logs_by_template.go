@@ -0,0 +1,172 @@
+// Code generated by gowrap. DO NOT EDIT.
+// template: ../../templates/log_template.go.tmpl
+// gowrap: http://github.com/hexdigest/gowrap
+
+package budget
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// BudgetRepoWithLogs implements repository.BudgetRepo that is instrumented with zerolog logger
+type BudgetRepoWithLogs struct {
+	base repository.BudgetRepo
+}
+
+// DeleteBudget implements repository.BudgetRepo
+func (d BudgetRepoWithLogs) DeleteBudget(ctx context.Context, i1 int64, i2 int64) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "BudgetRepoWithLogs").Str("method", "DeleteBudget").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "BudgetRepoWithLogs").Str("method", "DeleteBudget").Msg("Finish")
+		}
+	}()
+	return d.base.DeleteBudget(ctx, i1, i2)
+}
+
+// GetBudgetByID implements repository.BudgetRepo
+func (d BudgetRepoWithLogs) GetBudgetByID(ctx context.Context, i1 int64, i2 int64) (b1 models.BudgetView, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"b1":  b1,
+				"err": err}).Err(err).Str("decorator", "BudgetRepoWithLogs").Str("method", "GetBudgetByID").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"b1":  b1,
+				"err": err}).Str("decorator", "BudgetRepoWithLogs").Str("method", "GetBudgetByID").Msg("Finish")
+		}
+	}()
+	return d.base.GetBudgetByID(ctx, i1, i2)
+}
+
+// GetBudgets implements repository.BudgetRepo
+func (d BudgetRepoWithLogs) GetBudgets(ctx context.Context, i1 int64) (ba1 []models.BudgetView, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ba1": ba1,
+				"err": err}).Err(err).Str("decorator", "BudgetRepoWithLogs").Str("method", "GetBudgets").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ba1": ba1,
+				"err": err}).Str("decorator", "BudgetRepoWithLogs").Str("method", "GetBudgets").Msg("Finish")
+		}
+	}()
+	return d.base.GetBudgets(ctx, i1)
+}
+
+// GetMonthlySpending implements repository.BudgetRepo
+func (d BudgetRepoWithLogs) GetMonthlySpending(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (ma1 []models.MonthlySpending, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"t1":  t1,
+		"t2":  t2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ma1": ma1,
+				"err": err}).Err(err).Str("decorator", "BudgetRepoWithLogs").Str("method", "GetMonthlySpending").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ma1": ma1,
+				"err": err}).Str("decorator", "BudgetRepoWithLogs").Str("method", "GetMonthlySpending").Msg("Finish")
+		}
+	}()
+	return d.base.GetMonthlySpending(ctx, i1, t1, t2)
+}
+
+// InsertBudget implements repository.BudgetRepo
+func (d BudgetRepoWithLogs) InsertBudget(ctx context.Context, b1 models.BudgetTable) (i1 int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"b1":  b1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Err(err).Str("decorator", "BudgetRepoWithLogs").Str("method", "InsertBudget").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Str("decorator", "BudgetRepoWithLogs").Str("method", "InsertBudget").Msg("Finish")
+		}
+	}()
+	return d.base.InsertBudget(ctx, b1)
+}
+
+// UpdateBudget implements repository.BudgetRepo
+func (d BudgetRepoWithLogs) UpdateBudget(ctx context.Context, b1 models.BudgetTable) (i1 int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"b1":  b1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Err(err).Str("decorator", "BudgetRepoWithLogs").Str("method", "UpdateBudget").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Str("decorator", "BudgetRepoWithLogs").Str("method", "UpdateBudget").Msg("Finish")
+		}
+	}()
+	return d.base.UpdateBudget(ctx, b1)
+}
+
+// NewBudgetRepoWithLogs instruments an implementation of the repository.BudgetRepo with simple logging
+func NewBudgetRepoWithLogs(base repository.BudgetRepo) repository.BudgetRepo {
+	decorate := os.Getenv("DECORATE")
+	if decorate == "true" || decorate == "1" {
+		return BudgetRepoWithLogs{
+			base: base,
+		}
+	}
+
+	return base
+}
@@ -0,0 +1,198 @@
+// Code generated by gowrap. DO NOT EDIT.
+// template: ../../templates/red_template.go.tmpl
+// gowrap: http://github.com/hexdigest/gowrap
+
+package budget
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rubengomes8/golang-personal-finances/internal/instrumentation"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+type BudgetRepoWithRED struct {
+	base         repository.BudgetRepo
+	histogramVec *prometheus.HistogramVec
+}
+
+// DeleteBudget implements repository.BudgetRepo
+func (d BudgetRepoWithRED) DeleteBudget(ctx context.Context, i1 int64, i2 int64) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "DeleteBudget",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.DeleteBudget(ctx, i1, i2)
+}
+
+// GetBudgetByID implements repository.BudgetRepo
+func (d BudgetRepoWithRED) GetBudgetByID(ctx context.Context, i1 int64, i2 int64) (b1 models.BudgetView, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetBudgetByID",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetBudgetByID(ctx, i1, i2)
+}
+
+// GetBudgets implements repository.BudgetRepo
+func (d BudgetRepoWithRED) GetBudgets(ctx context.Context, i1 int64) (ba1 []models.BudgetView, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetBudgets",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetBudgets(ctx, i1)
+}
+
+// GetMonthlySpending implements repository.BudgetRepo
+func (d BudgetRepoWithRED) GetMonthlySpending(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (ma1 []models.MonthlySpending, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetMonthlySpending",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetMonthlySpending(ctx, i1, t1, t2)
+}
+
+// InsertBudget implements repository.BudgetRepo
+func (d BudgetRepoWithRED) InsertBudget(ctx context.Context, b1 models.BudgetTable) (i1 int64, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "InsertBudget",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.InsertBudget(ctx, b1)
+}
+
+// UpdateBudget implements repository.BudgetRepo
+func (d BudgetRepoWithRED) UpdateBudget(ctx context.Context, b1 models.BudgetTable) (i1 int64, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "UpdateBudget",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.UpdateBudget(ctx, b1)
+}
+
+// NewBudgetRepoWithRED returns an instance of the repository.BudgetRepo decorated with red histogram metric
+func NewBudgetRepoWithRED(base repository.BudgetRepo, constLabels prometheus.Labels) (decorator repository.BudgetRepo, err error) {
+	decorate := os.Getenv("DECORATE")
+	if !(decorate == "true" || decorate == "1") {
+		return base, nil
+	}
+
+	subSystem := "budget_repo"
+
+	metricConfig := prometheus.HistogramOpts{
+		Namespace:   strings.TrimSpace("system"),
+		Subsystem:   subSystem,
+		Name:        fmt.Sprintf("%s_red", subSystem),
+		Help:        "BudgetRepo RED histogram (rate, errors and duration).",
+		ConstLabels: constLabels,
+		Buckets:     prometheus.ExponentialBuckets(100, 2, 5),
+	}
+
+	red := BudgetRepoWithRED{
+		base:         base,
+		histogramVec: prometheus.NewHistogramVec(metricConfig, []string{"status", "method"}),
+	}
+
+	err = instrumentation.Registry.Register(red.histogramVec)
+	if err != nil {
+		return nil, err
+	}
+
+	return red, nil
+}
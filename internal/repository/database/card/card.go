@@ -3,13 +3,19 @@ package card
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
 )
 
 const (
 	tableNameCards = "cards"
+
+	selectCardsStmt = "SELECT id, name, currency, opening_balance, opening_date, user_id FROM cards"
 )
 
 // Database implements the card repository methods
@@ -27,11 +33,21 @@ func NewDatabase(database *sql.DB) Database {
 // InsertCard inserts a card on the cards' db table
 func (c Database) InsertCard(ctx context.Context, card models.CardTable) (int64, error) {
 
-	insertStmt := fmt.Sprintf("INSERT INTO %s (name) VALUES ($1) RETURNING id", tableNameCards)
+	insertStmt := fmt.Sprintf(`INSERT INTO %s (name, currency, opening_balance, opening_date, user_id) 
+	VALUES ($1, COALESCE(NULLIF($2, ''), $3), $4, $5, $6) RETURNING id`, tableNameCards)
 
 	var id int64
 
-	err := c.database.QueryRowContext(ctx, insertStmt, card.Name).Scan(&id)
+	err := c.database.QueryRowContext(
+		ctx,
+		insertStmt,
+		card.Name,
+		card.Currency,
+		models.DefaultCurrency,
+		card.OpeningBalance,
+		nullTime(card.OpeningDate),
+		card.UserID,
+	).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("error scanning card id: %v", err)
 	}
@@ -41,26 +57,47 @@ func (c Database) InsertCard(ctx context.Context, card models.CardTable) (int64,
 
 // UpdateCard updates a card on the cards' db table
 func (c Database) UpdateCard(ctx context.Context, card models.CardTable) (int64, error) {
-	updateStmt := fmt.Sprintf("UPDATE %s SET name = $1 WHERE id = $2", tableNameCards)
-
-	_, err := c.database.ExecContext(ctx, updateStmt, card.Name, card.ID)
+	updateStmt := fmt.Sprintf(`UPDATE %s SET 
+	(name, currency, opening_balance, opening_date) = ($1, COALESCE(NULLIF($2, ''), currency), $3, $4) 
+	WHERE id = $5 AND user_id = $6`, tableNameCards)
+
+	result, err := c.database.ExecContext(
+		ctx,
+		updateStmt,
+		card.Name,
+		card.Currency,
+		card.OpeningBalance,
+		nullTime(card.OpeningDate),
+		card.ID,
+		card.UserID,
+	)
 	if err != nil {
 		return 0, fmt.Errorf("error updating card: %v", err)
 	}
 
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not get number of rows affected in exec card update statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return 0, ErrNoRowsAffectedOnUpdate
+	}
+
 	return card.ID, nil
 }
 
 // GetCardByID gets a card from the cards' db table by id
-func (c Database) GetCardByID(ctx context.Context, id int64) (models.CardTable, error) {
+func (c Database) GetCardByID(ctx context.Context, userID int64, id int64) (models.CardTable, error) {
 
-	selectStmt := fmt.Sprintf("SELECT * FROM %s WHERE id = $1", tableNameCards)
+	selectStmt := selectCardsStmt + " WHERE id = $1 AND user_id = $2"
 
-	row := c.database.QueryRowContext(ctx, selectStmt, id)
+	row := c.database.QueryRowContext(ctx, selectStmt, id, userID)
 
-	var card models.CardTable
-
-	err := row.Scan(&card.ID, &card.Name)
+	card, err := scanCard(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.CardTable{}, repository.ErrNotFound
+	}
 	if err != nil {
 		return models.CardTable{}, fmt.Errorf("error scanning card fields: %v", err)
 	}
@@ -69,14 +106,16 @@ func (c Database) GetCardByID(ctx context.Context, id int64) (models.CardTable,
 }
 
 // GetCardByName gets a card from the cards' db table by name
-func (c Database) GetCardByName(ctx context.Context, name string) (models.CardTable, error) {
+func (c Database) GetCardByName(ctx context.Context, userID int64, name string) (models.CardTable, error) {
 
-	selectStmt := fmt.Sprintf("SELECT * FROM %s WHERE name = $1", tableNameCards)
+	selectStmt := selectCardsStmt + " WHERE name = $1 AND user_id = $2"
 
-	row := c.database.QueryRowContext(ctx, selectStmt, name)
+	row := c.database.QueryRowContext(ctx, selectStmt, name, userID)
 
-	var card models.CardTable
-	err := row.Scan(&card.ID, &card.Name)
+	card, err := scanCard(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.CardTable{}, repository.ErrNotFound
+	}
 	if err != nil {
 		return models.CardTable{}, fmt.Errorf("error scanning card fields: %v", err)
 	}
@@ -84,11 +123,37 @@ func (c Database) GetCardByName(ctx context.Context, name string) (models.CardTa
 	return card, nil
 }
 
-// DeleteCardRepo deletes a card from the cards' db table
-func (c Database) DeleteCard(ctx context.Context, id int64) error {
-	deleteStmt := fmt.Sprintf("DELETE FROM %s WHERE id = $1", tableNameCards)
+// GetCards gets the cards of the user from the cards' db table, sorted by name
+func (c Database) GetCards(ctx context.Context, userID int64) ([]models.CardTable, error) {
 
-	result, err := c.database.ExecContext(ctx, deleteStmt, id)
+	selectStmt := selectCardsStmt + " WHERE user_id = $1 ORDER BY name"
+
+	rows, err := c.database.QueryContext(ctx, selectStmt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying cards: %v", err)
+	}
+	defer rows.Close()
+
+	cards := []models.CardTable{}
+	for rows.Next() {
+		card, err := scanCard(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning card fields: %v", err)
+		}
+		cards = append(cards, card)
+	}
+
+	return cards, rows.Err()
+}
+
+// DeleteCard deletes a card from the cards' db table
+func (c Database) DeleteCard(ctx context.Context, userID int64, id int64) error {
+	deleteStmt := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", tableNameCards)
+
+	result, err := c.database.ExecContext(ctx, deleteStmt, id, userID)
+	if database.IsForeignKeyViolation(err) {
+		return ErrCardInUse
+	}
 	if err != nil {
 		return fmt.Errorf("error deleting card by id: %v", err)
 	}
@@ -104,3 +169,28 @@ func (c Database) DeleteCard(ctx context.Context, id int64) error {
 
 	return nil
 }
+
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCard(row scanner) (models.CardTable, error) {
+
+	var card models.CardTable
+	var openingDate sql.NullTime
+
+	err := row.Scan(&card.ID, &card.Name, &card.Currency, &card.OpeningBalance, &openingDate, &card.UserID)
+
+	card.OpeningDate = openingDate.Time
+
+	return card, err
+}
+
+// nullTime stores the zero time as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  t,
+		Valid: !t.IsZero(),
+	}
+}
@@ -1,7 +1,13 @@
 package card
 
-import "errors"
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
 
 var (
-	ErrNoRowsAffectedOnDelete = errors.New("there were no rows affected in exec expense card delete statement")
+	ErrNoRowsAffectedOnDelete = fmt.Errorf("there were no rows affected in exec expense card delete statement: %w", repository.ErrNotFound)
+	ErrNoRowsAffectedOnUpdate = fmt.Errorf("there were no rows affected in exec card update statement: %w", repository.ErrNotFound)
+	ErrCardInUse              = fmt.Errorf("card is still referenced: %w", repository.ErrInUse)
 )
@@ -26,14 +26,18 @@ func (c DBWithLogs) UpdateCard(ctx context.Context, card models.CardTable) (int6
 	return c.repo.UpdateCard(ctx, card)
 }
 
-func (c DBWithLogs) GetCardByID(ctx context.Context, id int64) (models.CardTable, error) {
-	return c.repo.GetCardByID(ctx, id)
+func (c DBWithLogs) GetCardByID(ctx context.Context, userID int64, id int64) (models.CardTable, error) {
+	return c.repo.GetCardByID(ctx, userID, id)
 }
 
-func (c DBWithLogs) GetCardByName(ctx context.Context, card string) (models.CardTable, error) {
-	return c.repo.GetCardByName(ctx, card)
+func (c DBWithLogs) GetCardByName(ctx context.Context, userID int64, card string) (models.CardTable, error) {
+	return c.repo.GetCardByName(ctx, userID, card)
 }
 
-func (c DBWithLogs) DeleteCard(ctx context.Context, id int64) error {
-	return c.repo.DeleteCard(ctx, id)
+func (c DBWithLogs) GetCards(ctx context.Context, userID int64) ([]models.CardTable, error) {
+	return c.repo.GetCards(ctx, userID)
+}
+
+func (c DBWithLogs) DeleteCard(ctx context.Context, userID int64, id int64) error {
+	return c.repo.DeleteCard(ctx, userID, id)
 }
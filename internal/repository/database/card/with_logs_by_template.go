@@ -19,13 +19,14 @@ type CardRepoWithLogs struct {
 }
 
 // DeleteCard implements repository.CardRepo
-func (d CardRepoWithLogs) DeleteCard(ctx context.Context, i1 int64) (err error) {
+func (d CardRepoWithLogs) DeleteCard(ctx context.Context, i1 int64, i2 int64) (err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
-		"i1":  i1}).Logger()
+		"i1":  i1,
+		"i2":  i2}).Logger()
 
 	defer func() {
 		if err != nil {
@@ -36,17 +37,18 @@ func (d CardRepoWithLogs) DeleteCard(ctx context.Context, i1 int64) (err error)
 				"err": err}).Str("decorator", "CardRepoWithLogs").Str("method", "DeleteCard").Msg("Finish")
 		}
 	}()
-	return d.base.DeleteCard(ctx, i1)
+	return d.base.DeleteCard(ctx, i1, i2)
 }
 
 // GetCardByID implements repository.CardRepo
-func (d CardRepoWithLogs) GetCardByID(ctx context.Context, i1 int64) (c2 models.CardTable, err error) {
+func (d CardRepoWithLogs) GetCardByID(ctx context.Context, i1 int64, i2 int64) (c2 models.CardTable, err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
-		"i1":  i1}).Logger()
+		"i1":  i1,
+		"i2":  i2}).Logger()
 
 	defer func() {
 		if err != nil {
@@ -59,16 +61,17 @@ func (d CardRepoWithLogs) GetCardByID(ctx context.Context, i1 int64) (c2 models.
 				"err": err}).Str("decorator", "CardRepoWithLogs").Str("method", "GetCardByID").Msg("Finish")
 		}
 	}()
-	return d.base.GetCardByID(ctx, i1)
+	return d.base.GetCardByID(ctx, i1, i2)
 }
 
 // GetCardByName implements repository.CardRepo
-func (d CardRepoWithLogs) GetCardByName(ctx context.Context, s1 string) (c2 models.CardTable, err error) {
+func (d CardRepoWithLogs) GetCardByName(ctx context.Context, i1 int64, s1 string) (c2 models.CardTable, err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
+		"i1":  i1,
 		"s1":  s1}).Logger()
 
 	defer func() {
@@ -82,7 +85,30 @@ func (d CardRepoWithLogs) GetCardByName(ctx context.Context, s1 string) (c2 mode
 				"err": err}).Str("decorator", "CardRepoWithLogs").Str("method", "GetCardByName").Msg("Finish")
 		}
 	}()
-	return d.base.GetCardByName(ctx, s1)
+	return d.base.GetCardByName(ctx, i1, s1)
+}
+
+// GetCards implements repository.CardRepo
+func (d CardRepoWithLogs) GetCards(ctx context.Context, i1 int64) (ca1 []models.CardTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ca1": ca1,
+				"err": err}).Err(err).Str("decorator", "CardRepoWithLogs").Str("method", "GetCards").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ca1": ca1,
+				"err": err}).Str("decorator", "CardRepoWithLogs").Str("method", "GetCards").Msg("Finish")
+		}
+	}()
+	return d.base.GetCards(ctx, i1)
 }
 
 // InsertCard implements repository.CardRepo
@@ -23,7 +23,7 @@ type CardRepoWithRED struct {
 }
 
 // DeleteCard implements repository.CardRepo
-func (d CardRepoWithRED) DeleteCard(ctx context.Context, i1 int64) (err error) {
+func (d CardRepoWithRED) DeleteCard(ctx context.Context, i1 int64, i2 int64) (err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -43,11 +43,11 @@ func (d CardRepoWithRED) DeleteCard(ctx context.Context, i1 int64) (err error) {
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.DeleteCard(ctx, i1)
+	return d.base.DeleteCard(ctx, i1, i2)
 }
 
 // GetCardByID implements repository.CardRepo
-func (d CardRepoWithRED) GetCardByID(ctx context.Context, i1 int64) (c2 models.CardTable, err error) {
+func (d CardRepoWithRED) GetCardByID(ctx context.Context, i1 int64, i2 int64) (c2 models.CardTable, err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -67,11 +67,11 @@ func (d CardRepoWithRED) GetCardByID(ctx context.Context, i1 int64) (c2 models.C
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.GetCardByID(ctx, i1)
+	return d.base.GetCardByID(ctx, i1, i2)
 }
 
 // GetCardByName implements repository.CardRepo
-func (d CardRepoWithRED) GetCardByName(ctx context.Context, s1 string) (c2 models.CardTable, err error) {
+func (d CardRepoWithRED) GetCardByName(ctx context.Context, i1 int64, s1 string) (c2 models.CardTable, err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -91,7 +91,31 @@ func (d CardRepoWithRED) GetCardByName(ctx context.Context, s1 string) (c2 model
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.GetCardByName(ctx, s1)
+	return d.base.GetCardByName(ctx, i1, s1)
+}
+
+// GetCards implements repository.CardRepo
+func (d CardRepoWithRED) GetCards(ctx context.Context, i1 int64) (ca1 []models.CardTable, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetCards",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetCards(ctx, i1)
 }
 
 // InsertCard implements repository.CardRepo
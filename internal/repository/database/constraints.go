@@ -0,0 +1,28 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// foreignKeyViolation is the postgres error code of a statement that breaks a foreign key
+	foreignKeyViolation = "23503"
+	// uniqueViolation is the postgres error code of a statement that breaks a unique constraint
+	uniqueViolation = "23505"
+)
+
+// IsForeignKeyViolation tells if a statement failed because it breaks a foreign key,
+// such as a delete of a row other rows still reference
+func IsForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation
+}
+
+// IsUniqueViolation tells if a statement failed because it breaks a unique constraint,
+// such as an insert of a row whose unique columns are already taken
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
@@ -39,6 +39,28 @@ func (d ExpenseCategoryRepoWithLogs) DeleteExpenseCategory(ctx context.Context,
 	return d.base.DeleteExpenseCategory(ctx, i1)
 }
 
+// GetExpenseCategories implements repository.ExpenseCategoryRepo
+func (d ExpenseCategoryRepoWithLogs) GetExpenseCategories(ctx context.Context) (ea1 []models.ExpenseCategoryTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ea1": ea1,
+				"err": err}).Err(err).Str("decorator", "ExpenseCategoryRepoWithLogs").Str("method", "GetExpenseCategories").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ea1": ea1,
+				"err": err}).Str("decorator", "ExpenseCategoryRepoWithLogs").Str("method", "GetExpenseCategories").Msg("Finish")
+		}
+	}()
+	return d.base.GetExpenseCategories(ctx)
+}
+
 // GetExpenseCategoryByID implements repository.ExpenseCategoryRepo
 func (d ExpenseCategoryRepoWithLogs) GetExpenseCategoryByID(ctx context.Context, i1 int64) (e1 models.ExpenseCategoryTable, err error) {
 
@@ -108,6 +130,53 @@ func (d ExpenseCategoryRepoWithLogs) InsertExpenseCategory(ctx context.Context,
 	return d.base.InsertExpenseCategory(ctx, e1)
 }
 
+// MergeExpenseCategory implements repository.ExpenseCategoryRepo
+func (d ExpenseCategoryRepoWithLogs) MergeExpenseCategory(ctx context.Context, i1 int64, i2 int64, b1 bool) (c2 models.CategoryMerge, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2,
+		"b1":  b1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"c2":  c2,
+				"err": err}).Err(err).Str("decorator", "ExpenseCategoryRepoWithLogs").Str("method", "MergeExpenseCategory").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"c2":  c2,
+				"err": err}).Str("decorator", "ExpenseCategoryRepoWithLogs").Str("method", "MergeExpenseCategory").Msg("Finish")
+		}
+	}()
+	return d.base.MergeExpenseCategory(ctx, i1, i2, b1)
+}
+
+// ReplaceExpenseCategory implements repository.ExpenseCategoryRepo
+func (d ExpenseCategoryRepoWithLogs) ReplaceExpenseCategory(ctx context.Context, i1 int64, i2 int64) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "ExpenseCategoryRepoWithLogs").Str("method", "ReplaceExpenseCategory").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "ExpenseCategoryRepoWithLogs").Str("method", "ReplaceExpenseCategory").Msg("Finish")
+		}
+	}()
+	return d.base.ReplaceExpenseCategory(ctx, i1, i2)
+}
+
 // UpdateExpenseCategory implements repository.ExpenseCategoryRepo
 func (d ExpenseCategoryRepoWithLogs) UpdateExpenseCategory(ctx context.Context, e1 models.ExpenseCategoryTable) (i1 int64, err error) {
 
@@ -46,6 +46,30 @@ func (d ExpenseCategoryRepoWithRED) DeleteExpenseCategory(ctx context.Context, i
 	return d.base.DeleteExpenseCategory(ctx, i1)
 }
 
+// GetExpenseCategories implements repository.ExpenseCategoryRepo
+func (d ExpenseCategoryRepoWithRED) GetExpenseCategories(ctx context.Context) (ea1 []models.ExpenseCategoryTable, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetExpenseCategories",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetExpenseCategories(ctx)
+}
+
 // GetExpenseCategoryByID implements repository.ExpenseCategoryRepo
 func (d ExpenseCategoryRepoWithRED) GetExpenseCategoryByID(ctx context.Context, i1 int64) (e1 models.ExpenseCategoryTable, err error) {
 	since := time.Now()
@@ -118,6 +142,54 @@ func (d ExpenseCategoryRepoWithRED) InsertExpenseCategory(ctx context.Context, e
 	return d.base.InsertExpenseCategory(ctx, e1)
 }
 
+// MergeExpenseCategory implements repository.ExpenseCategoryRepo
+func (d ExpenseCategoryRepoWithRED) MergeExpenseCategory(ctx context.Context, i1 int64, i2 int64, b1 bool) (c2 models.CategoryMerge, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "MergeExpenseCategory",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.MergeExpenseCategory(ctx, i1, i2, b1)
+}
+
+// ReplaceExpenseCategory implements repository.ExpenseCategoryRepo
+func (d ExpenseCategoryRepoWithRED) ReplaceExpenseCategory(ctx context.Context, i1 int64, i2 int64) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "ReplaceExpenseCategory",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.ReplaceExpenseCategory(ctx, i1, i2)
+}
+
 // UpdateExpenseCategory implements repository.ExpenseCategoryRepo
 func (d ExpenseCategoryRepoWithRED) UpdateExpenseCategory(ctx context.Context, e1 models.ExpenseCategoryTable) (i1 int64, err error) {
 	since := time.Now()
@@ -1,10 +1,19 @@
 package expense
 
-import "errors"
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
 
 var (
-	ErrNoRowsAffectedOnDelete            = errors.New("there were no rows affected in exec expense delete statement")
-	ErrNoRowsAffectedOnUpdate            = errors.New("there were no rows affected in exec expense update statement")
-	ErrNoRowsAffectedOnCategoryDelete    = errors.New("there were no rows affected in exec expense category delete statement")
-	ErrNoRowsAffectedOnSubcategoryDelete = errors.New("there were no rows affected in exec expense subcategory delete statement")
+	ErrNoRowsAffectedOnDelete            = fmt.Errorf("there were no rows affected in exec expense delete statement: %w", repository.ErrNotFound)
+	ErrNoRowsAffectedOnUpdate            = fmt.Errorf("there were no rows affected in exec expense update statement: %w", repository.ErrNotFound)
+	ErrNoRowsAffectedOnCategoryDelete    = fmt.Errorf("there were no rows affected in exec expense category delete statement: %w", repository.ErrNotFound)
+	ErrNoRowsAffectedOnSubcategoryDelete = fmt.Errorf("there were no rows affected in exec expense subcategory delete statement: %w", repository.ErrNotFound)
+	ErrNoRowsAffectedOnCategoryUpdate    = fmt.Errorf("there were no rows affected in exec expense category update statement: %w", repository.ErrNotFound)
+	ErrNoRowsAffectedOnSubcategoryUpdate = fmt.Errorf("there were no rows affected in exec expense subcategory update statement: %w", repository.ErrNotFound)
+	ErrCategoryInUse                     = fmt.Errorf("expense category is still referenced: %w", repository.ErrInUse)
+	ErrSubcategoryInUse                  = fmt.Errorf("expense subcategory is still referenced: %w", repository.ErrInUse)
+	ErrExternalReferenceExists           = fmt.Errorf("expense card already has an expense with this external reference: %w", repository.ErrAlreadyExists)
 )
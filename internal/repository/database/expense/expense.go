@@ -3,16 +3,21 @@ package expense
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
 )
 
 const (
-	expensesTable = "expenses"
-	expensesView  = "expenses_view"
+	expensesTable      = "expenses"
+	expensesView       = "expenses_view"
+	expenseSplitsTable = "expense_splits"
+	expenseLinesView   = "expense_lines_view"
 )
 
 // DB implements the expense repository methods
@@ -38,39 +43,71 @@ func NewDB(
 	}
 }
 
-// InsertExpense inserts an expense on the expenses db table
+// InsertExpense inserts an expense on the expenses db table, with its split lines in the same transaction
 func (e DB) InsertExpense(ctx context.Context, exp models.ExpenseTable) (int64, error) {
 
-	insertStmt := fmt.Sprintf(`INSERT INTO %s 
-	(value, date, description, subcategory_id, card_id)
-	VALUES ($1, $2, $3, $4, $5) RETURNING id`, expensesTable)
+	tx, err := e.database.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not begin expense insert transaction: %v", err)
+	}
+	defer tx.Rollback()
 
-	var id int64
+	id, err := insertExpense(ctx, tx, exp)
+	if err != nil {
+		return 0, err
+	}
 
-	err := e.database.QueryRowContext(
-		ctx,
-		insertStmt,
-		exp.Value,
-		exp.Date,
-		exp.Description,
-		exp.SubCategoryID,
-		exp.CardID,
-	).Scan(&id)
+	err = tx.Commit()
 	if err != nil {
-		return 0, fmt.Errorf("could not exec expense insert statement: %v", err)
+		return 0, fmt.Errorf("could not commit expense insert transaction: %v", err)
 	}
 
 	return id, nil
 }
 
-// UpdateExpense updates an expense on the expenses db table
+// InsertExpenses inserts several expenses on the expenses db table in a single transaction.
+// If any insert fails the transaction is rolled back and a repository.BatchItemError is returned.
+func (e DB) InsertExpenses(ctx context.Context, expenses []models.ExpenseTable) ([]int64, error) {
+
+	tx, err := e.database.BeginTx(ctx, nil)
+	if err != nil {
+		return []int64{}, fmt.Errorf("could not begin expenses insert transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]int64, 0, len(expenses))
+	for idx, exp := range expenses {
+		id, err := insertExpense(ctx, tx, exp)
+		if err != nil {
+			return []int64{}, repository.BatchItemError{Index: idx, Err: err}
+		}
+		ids = append(ids, id)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return []int64{}, fmt.Errorf("could not commit expenses insert transaction: %v", err)
+	}
+
+	return ids, nil
+}
+
+// UpdateExpense updates an expense on the expenses db table.
+// Its split lines are replaced by the ones of the expense in the same transaction.
 func (e DB) UpdateExpense(ctx context.Context, exp models.ExpenseTable) (int64, error) {
 
+	tx, err := e.database.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not begin expense update transaction: %v", err)
+	}
+	defer tx.Rollback()
+
 	updateStmt := fmt.Sprintf(`UPDATE %s SET 
-	(value, date, description, subcategory_id, card_id) =
-	($1, $2, $3, $4, $5) WHERE id = $6`, expensesTable)
+	(value, date, description, subcategory_id, card_id, currency) =
+	($1, $2, $3, $4, $5, COALESCE(NULLIF($8, ''), (SELECT currency FROM cards WHERE id = $5)))
+	WHERE id = $6 AND user_id = $7`, expensesTable)
 
-	result, err := e.database.ExecContext(ctx,
+	result, err := tx.ExecContext(ctx,
 		updateStmt,
 		exp.Value,
 		exp.Date,
@@ -78,6 +115,8 @@ func (e DB) UpdateExpense(ctx context.Context, exp models.ExpenseTable) (int64,
 		exp.SubCategoryID,
 		exp.CardID,
 		exp.ID,
+		exp.UserID,
+		exp.Currency,
 	)
 	if err != nil {
 		return 0, fmt.Errorf("could not exec expense update statement: %v", err)
@@ -92,18 +131,40 @@ func (e DB) UpdateExpense(ctx context.Context, exp models.ExpenseTable) (int64,
 		return 0, ErrNoRowsAffectedOnUpdate
 	}
 
+	deleteStmt := fmt.Sprintf(`DELETE FROM %s WHERE expense_id = $1`, expenseSplitsTable)
+
+	_, err = tx.ExecContext(ctx, deleteStmt, exp.ID)
+	if err != nil {
+		return 0, fmt.Errorf("could not exec expense splits delete statement: %v", err)
+	}
+
+	err = insertExpenseSplits(ctx, tx, exp.ID, exp.Splits)
+	if err != nil {
+		return 0, err
+	}
+
+	err = database.ExpenseTagLinks.SetTags(ctx, tx, exp.UserID, exp.ID, exp.Tags)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, fmt.Errorf("could not commit expense update transaction: %v", err)
+	}
+
 	return exp.ID, nil
 }
 
 // GetExpenseByID gets an expense from the expenses db table by id
-func (e DB) GetExpenseByID(ctx context.Context, id int64) (models.ExpenseView, error) {
+func (e DB) GetExpenseByID(ctx context.Context, userID int64, id int64) (models.ExpenseView, error) {
 
 	selectStmt := fmt.Sprintf(`SELECT 
-	value, date, description, category_id, category_name, 
+	value, currency, date, description, category_id, category_name, 
 	subcategory_id, subcategory_name, card_id, card_name
-	FROM %s WHERE id = $1`, expensesView)
+	FROM %s WHERE id = $1 AND user_id = $2`, expensesView)
 
-	row := e.database.QueryRowContext(ctx, selectStmt, id)
+	row := e.database.QueryRowContext(ctx, selectStmt, id, userID)
 	if row.Err() != nil {
 		return models.ExpenseView{}, fmt.Errorf("could not query select expenses view by id statement: %v", row.Err())
 	}
@@ -111,6 +172,7 @@ func (e DB) GetExpenseByID(ctx context.Context, id int64) (models.ExpenseView, e
 	var exp models.ExpenseView
 	err := row.Scan(
 		&exp.Value,
+		&exp.Currency,
 		&exp.Date,
 		&exp.Description,
 		&exp.CategoryID,
@@ -120,28 +182,38 @@ func (e DB) GetExpenseByID(ctx context.Context, id int64) (models.ExpenseView, e
 		&exp.CardID,
 		&exp.Card,
 	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ExpenseView{}, repository.ErrNotFound
+	}
 	if err != nil {
 		return models.ExpenseView{}, fmt.Errorf("could not scan expense fields in get expense by id: %v", err)
 	}
 
 	exp.ID = id
+	exp.UserID = userID
 
-	return exp, nil
+	expenses, err := e.withDetails(ctx, []models.ExpenseView{exp})
+	if err != nil {
+		return models.ExpenseView{}, err
+	}
+
+	return expenses[0], nil
 }
 
 // GetExpensesByDates gets expenses from the expenses db table that matches the dates' range provided
 func (e DB) GetExpensesByDates(
 	ctx context.Context,
+	userID int64,
 	minDate time.Time,
 	maxDate time.Time,
 ) ([]models.ExpenseView, error) {
 
 	selectStmt := fmt.Sprintf(`SELECT 
-	value, date, description, category_id, category_name, 
+	id, value, currency, date, description, category_id, category_name, 
 	subcategory_id, subcategory_name, card_id, card_name
-	FROM %s WHERE date BETWEEN $1 AND $2`, expensesView)
+	FROM %s WHERE user_id = $1 AND date BETWEEN $2 AND $3`, expensesView)
 
-	rows, err := e.database.QueryContext(ctx, selectStmt, minDate, maxDate)
+	rows, err := e.database.QueryContext(ctx, selectStmt, userID, minDate, maxDate)
 	if err != nil {
 		return []models.ExpenseView{}, fmt.Errorf("could not query select expenses view by dates statement: %v", err)
 	}
@@ -153,7 +225,9 @@ func (e DB) GetExpensesByDates(
 
 	for rows.Next() {
 		err := rows.Scan(
+			&exp.ID,
 			&exp.Value,
+			&exp.Currency,
 			&exp.Date,
 			&exp.Description,
 			&exp.CategoryID,
@@ -167,6 +241,7 @@ func (e DB) GetExpensesByDates(
 			return []models.ExpenseView{}, fmt.Errorf("could not scan expense fields in get expenses by dates: %v", err)
 		}
 
+		exp.UserID = userID
 		expenses = append(expenses, exp)
 	}
 
@@ -176,18 +251,20 @@ func (e DB) GetExpensesByDates(
 			fmt.Errorf("found error after scanning all expenses fields in get expenses by dates: %v", err)
 	}
 
-	return expenses, nil
+	return e.withDetails(ctx, expenses)
 }
 
 // GetExpensesByCategory gets expenses from the expenses db table that matches the category provided
-func (e DB) GetExpensesByCategory(ctx context.Context, category string) ([]models.ExpenseView, error) {
+func (e DB) GetExpensesByCategory(ctx context.Context, userID int64, category string) ([]models.ExpenseView, error) {
 
 	selectStmt := fmt.Sprintf(`SELECT 
-	value, date, description, category_id, category_name, 
+	id, value, currency, date, description, category_id, category_name, 
 	subcategory_id, subcategory_name, card_id, card_name
-	FROM %s WHERE category_name = $1`, expensesView)
+	FROM %s WHERE user_id = $1 AND id IN (
+		SELECT expense_id FROM %s WHERE user_id = $1 AND category_name = $2
+	)`, expensesView, expenseLinesView)
 
-	rows, err := e.database.QueryContext(ctx, selectStmt, category)
+	rows, err := e.database.QueryContext(ctx, selectStmt, userID, category)
 	if err != nil {
 		return []models.ExpenseView{}, fmt.Errorf("could not query select expenses view by category statement: %v", err)
 	}
@@ -199,7 +276,9 @@ func (e DB) GetExpensesByCategory(ctx context.Context, category string) ([]model
 
 	for rows.Next() {
 		err := rows.Scan(
+			&exp.ID,
 			&exp.Value,
+			&exp.Currency,
 			&exp.Date,
 			&exp.Description,
 			&exp.CategoryID,
@@ -213,6 +292,7 @@ func (e DB) GetExpensesByCategory(ctx context.Context, category string) ([]model
 			return []models.ExpenseView{}, fmt.Errorf("could not scan expense fields in get expenses by category: %v", err)
 		}
 
+		exp.UserID = userID
 		expenses = append(expenses, exp)
 	}
 
@@ -222,18 +302,20 @@ func (e DB) GetExpensesByCategory(ctx context.Context, category string) ([]model
 			fmt.Errorf("found error after scanning all expenses fields in get expenses by category: %v", err)
 	}
 
-	return expenses, nil
+	return e.withDetails(ctx, expenses)
 }
 
 // GetExpensesBySubCategory gets expenses from the expenses db table that matches the subcategory provided
-func (e DB) GetExpensesBySubCategory(ctx context.Context, subCategory string) ([]models.ExpenseView, error) {
+func (e DB) GetExpensesBySubCategory(ctx context.Context, userID int64, subCategory string) ([]models.ExpenseView, error) {
 
 	selectStmt := fmt.Sprintf(`SELECT 
-	value, date, description, category_id, category_name, 
+	id, value, currency, date, description, category_id, category_name, 
 	subcategory_id, subcategory_name, card_id, card_name
-	FROM %s WHERE subcategory_name = $1`, expensesView)
+	FROM %s WHERE user_id = $1 AND id IN (
+		SELECT expense_id FROM %s WHERE user_id = $1 AND subcategory_name = $2
+	)`, expensesView, expenseLinesView)
 
-	rows, err := e.database.QueryContext(ctx, selectStmt, subCategory)
+	rows, err := e.database.QueryContext(ctx, selectStmt, userID, subCategory)
 	if err != nil {
 		return []models.ExpenseView{}, fmt.Errorf("could not query select expenses view by subcategory statement: %v", err)
 	}
@@ -245,7 +327,9 @@ func (e DB) GetExpensesBySubCategory(ctx context.Context, subCategory string) ([
 
 	for rows.Next() {
 		err := rows.Scan(
+			&exp.ID,
 			&exp.Value,
+			&exp.Currency,
 			&exp.Date,
 			&exp.Description,
 			&exp.CategoryID,
@@ -259,6 +343,7 @@ func (e DB) GetExpensesBySubCategory(ctx context.Context, subCategory string) ([
 			return []models.ExpenseView{}, fmt.Errorf("could not scan expense fields in get expenses by subategory: %v", err)
 		}
 
+		exp.UserID = userID
 		expenses = append(expenses, exp)
 	}
 
@@ -268,18 +353,18 @@ func (e DB) GetExpensesBySubCategory(ctx context.Context, subCategory string) ([
 			fmt.Errorf("found error after scanning all expenses fields in get expenses by subcategory: %v", err)
 	}
 
-	return expenses, nil
+	return e.withDetails(ctx, expenses)
 }
 
 // GetExpensesByCard gets expenses from the expenses db table that matches the card provided
-func (e DB) GetExpensesByCard(ctx context.Context, card string) ([]models.ExpenseView, error) {
+func (e DB) GetExpensesByCard(ctx context.Context, userID int64, card string) ([]models.ExpenseView, error) {
 
 	selectStmt := fmt.Sprintf(`SELECT 
-	value, date, description, category_id, category_name, 
+	id, value, currency, date, description, category_id, category_name, 
 	subcategory_id, subcategory_name, card_id, card_name
-	FROM %s WHERE card_name = $1`, expensesView)
+	FROM %s WHERE user_id = $1 AND card_name = $2`, expensesView)
 
-	rows, err := e.database.QueryContext(ctx, selectStmt, card)
+	rows, err := e.database.QueryContext(ctx, selectStmt, userID, card)
 	if err != nil {
 		return []models.ExpenseView{}, fmt.Errorf("could not query select expenses by card statement: %v", err)
 	}
@@ -291,7 +376,9 @@ func (e DB) GetExpensesByCard(ctx context.Context, card string) ([]models.Expens
 
 	for rows.Next() {
 		err := rows.Scan(
+			&exp.ID,
 			&exp.Value,
+			&exp.Currency,
 			&exp.Date,
 			&exp.Description,
 			&exp.CategoryID,
@@ -305,6 +392,7 @@ func (e DB) GetExpensesByCard(ctx context.Context, card string) ([]models.Expens
 			return []models.ExpenseView{}, fmt.Errorf("could not scan expense fields in get expenses by card: %v", err)
 		}
 
+		exp.UserID = userID
 		expenses = append(expenses, exp)
 	}
 
@@ -313,16 +401,243 @@ func (e DB) GetExpensesByCard(ctx context.Context, card string) ([]models.Expens
 		return []models.ExpenseView{}, fmt.Errorf("found error after scanning all expenses fields in get expenses by card: %v", err)
 	}
 
-	return expenses, nil
+	return e.withDetails(ctx, expenses)
+}
+
+// GetExpensesByCardAndValue gets expenses from the expenses db table of the card with the value provided within the dates' range provided
+func (e DB) GetExpensesByCardAndValue(
+	ctx context.Context,
+	userID int64,
+	cardID int64,
+	value models.Money,
+	minDate time.Time,
+	maxDate time.Time,
+) ([]models.ExpenseView, error) {
+
+	selectStmt := fmt.Sprintf(`SELECT 
+	id, value, currency, date, description, category_id, category_name, 
+	subcategory_id, subcategory_name, card_id, card_name
+	FROM %s WHERE user_id = $1 AND card_id = $2 AND value = $3 AND date BETWEEN $4 AND $5`, expensesView)
+
+	rows, err := e.database.QueryContext(ctx, selectStmt, userID, cardID, value, minDate, maxDate)
+	if err != nil {
+		return []models.ExpenseView{}, fmt.Errorf("could not query select expenses view by card and value statement: %v", err)
+	}
+	defer rows.Close()
+
+	var expenses []models.ExpenseView
+
+	var exp models.ExpenseView
+
+	for rows.Next() {
+		err := rows.Scan(
+			&exp.ID,
+			&exp.Value,
+			&exp.Currency,
+			&exp.Date,
+			&exp.Description,
+			&exp.CategoryID,
+			&exp.Category,
+			&exp.SubCategoryID,
+			&exp.SubCategory,
+			&exp.CardID,
+			&exp.Card,
+		)
+		if err != nil {
+			return []models.ExpenseView{}, fmt.Errorf("could not scan expense fields in get expenses by card and value: %v", err)
+		}
+
+		exp.UserID = userID
+		expenses = append(expenses, exp)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.ExpenseView{},
+			fmt.Errorf("found error after scanning all expenses fields in get expenses by card and value: %v", err)
+	}
+
+	return e.withDetails(ctx, expenses)
+}
+
+// GetExpenseByExternalReference gets an expense from the expenses db table by the bank reference it was imported with
+func (e DB) GetExpenseByExternalReference(ctx context.Context, userID int64, cardID int64, reference string) (models.ExpenseTable, error) {
+
+	selectStmt := fmt.Sprintf(`SELECT 
+	id, value, currency, date, description, subcategory_id, card_id, external_reference
+	FROM %s WHERE user_id = $1 AND card_id = $2 AND external_reference = $3`, expensesTable)
+
+	row := e.database.QueryRowContext(ctx, selectStmt, userID, cardID, reference)
+	if row.Err() != nil {
+		return models.ExpenseTable{}, fmt.Errorf("could not query select expenses by external reference statement: %v", row.Err())
+	}
+
+	var exp models.ExpenseTable
+	err := row.Scan(
+		&exp.ID,
+		&exp.Value,
+		&exp.Currency,
+		&exp.Date,
+		&exp.Description,
+		&exp.SubCategoryID,
+		&exp.CardID,
+		&exp.ExternalReference,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ExpenseTable{}, repository.ErrNotFound
+	}
+	if err != nil {
+		return models.ExpenseTable{}, fmt.Errorf("could not scan expense fields in get expense by external reference: %v", err)
+	}
+
+	exp.UserID = userID
+
+	return exp, nil
+}
+
+// SearchExpenses gets a page of the expenses from the expenses view that match the filter, in its sort order
+func (e DB) SearchExpenses(ctx context.Context, userID int64, filter models.SearchFilter) ([]models.ExpenseView, error) {
+
+	clauses, args := database.SearchClauses(userID, filter, expenseLinesView, database.ExpenseTagLinks)
+
+	selectStmt := fmt.Sprintf(`SELECT 
+	id, value, currency, date, description, category_id, category_name, 
+	subcategory_id, subcategory_name, card_id, card_name
+	FROM %s %s`, expensesView, clauses)
+
+	rows, err := e.database.QueryContext(ctx, selectStmt, args...)
+	if err != nil {
+		return []models.ExpenseView{}, fmt.Errorf("could not query search expenses view statement: %v", err)
+	}
+	defer rows.Close()
+
+	expenses := []models.ExpenseView{}
+
+	var exp models.ExpenseView
+
+	for rows.Next() {
+		err := rows.Scan(
+			&exp.ID,
+			&exp.Value,
+			&exp.Currency,
+			&exp.Date,
+			&exp.Description,
+			&exp.CategoryID,
+			&exp.Category,
+			&exp.SubCategoryID,
+			&exp.SubCategory,
+			&exp.CardID,
+			&exp.Card,
+		)
+		if err != nil {
+			return []models.ExpenseView{}, fmt.Errorf("could not scan expense fields in search expenses: %v", err)
+		}
+
+		exp.UserID = userID
+		expenses = append(expenses, exp)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.ExpenseView{},
+			fmt.Errorf("found error after scanning all expenses fields in search expenses: %v", err)
+	}
+
+	return e.withDetails(ctx, expenses)
+}
+
+// GetExpenseDailyTotals sums the expense lines of the user on the expense lines view that are in the dates' range provided
+// by day, category, subcategory, card and currency, so split expenses count towards each of their subcategories
+func (e DB) GetExpenseDailyTotals(ctx context.Context, userID int64, minDate time.Time, maxDate time.Time) ([]models.DailyTotal, error) {
+
+	selectStmt := fmt.Sprintf(`SELECT 
+	date::DATE AS day, category_name, subcategory_name, card_name, currency, SUM(value), COUNT(*)
+	FROM %s WHERE user_id = $1 AND date BETWEEN $2 AND $3
+	GROUP BY day, category_name, subcategory_name, card_name, currency
+	ORDER BY day, category_name, subcategory_name, card_name, currency`, expenseLinesView)
+
+	rows, err := e.database.QueryContext(ctx, selectStmt, userID, minDate, maxDate)
+	if err != nil {
+		return []models.DailyTotal{}, fmt.Errorf("could not query select expense daily totals statement: %v", err)
+	}
+	defer rows.Close()
+
+	totals := []models.DailyTotal{}
+	for rows.Next() {
+		var total models.DailyTotal
+		err := rows.Scan(
+			&total.Day,
+			&total.Category,
+			&total.SubCategory,
+			&total.Card,
+			&total.Currency,
+			&total.Value,
+			&total.Count,
+		)
+		if err != nil {
+			return []models.DailyTotal{}, fmt.Errorf("could not scan expense daily total fields: %v", err)
+		}
+		totals = append(totals, total)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.DailyTotal{}, fmt.Errorf("found error after scanning all expense daily total fields: %v", err)
+	}
+
+	return totals, nil
+}
+
+// GetExpenseTagDailyTotals sums the tagged expenses of the user that are in the dates' range provided
+// by day, tag and currency. An expense with several tags counts towards each of them.
+func (e DB) GetExpenseTagDailyTotals(ctx context.Context, userID int64, minDate time.Time, maxDate time.Time) ([]models.DailyTotal, error) {
+
+	selectStmt := fmt.Sprintf(`SELECT 
+	e.date::DATE AS day, t.name, e.currency, SUM(e.value), COUNT(*)
+	FROM %s e 
+	JOIN %s l ON l.%s = e.id 
+	JOIN tags t ON t.id = l.tag_id
+	WHERE e.user_id = $1 AND e.date BETWEEN $2 AND $3
+	GROUP BY day, t.name, e.currency
+	ORDER BY day, t.name, e.currency`, expensesTable, database.ExpenseTagLinks.Table, database.ExpenseTagLinks.Column)
+
+	rows, err := e.database.QueryContext(ctx, selectStmt, userID, minDate, maxDate)
+	if err != nil {
+		return []models.DailyTotal{}, fmt.Errorf("could not query select expense tag daily totals statement: %v", err)
+	}
+	defer rows.Close()
+
+	totals := []models.DailyTotal{}
+	for rows.Next() {
+		var total models.DailyTotal
+		err := rows.Scan(
+			&total.Day,
+			&total.Tag,
+			&total.Currency,
+			&total.Value,
+			&total.Count,
+		)
+		if err != nil {
+			return []models.DailyTotal{}, fmt.Errorf("could not scan expense tag daily total fields: %v", err)
+		}
+		totals = append(totals, total)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.DailyTotal{}, fmt.Errorf("found error after scanning all expense tag daily total fields: %v", err)
+	}
+
+	return totals, nil
 }
 
 // DeleteExpense deletes an expense from the expenses db table
-func (e DB) DeleteExpense(ctx context.Context, id int64) error {
+func (e DB) DeleteExpense(ctx context.Context, userID int64, id int64) error {
 
 	deleteStmt := fmt.Sprintf(`DELETE FROM %s 
-	WHERE id = $1`, expensesTable)
+	WHERE id = $1 AND user_id = $2`, expensesTable)
 
-	result, err := e.database.ExecContext(ctx, deleteStmt, id)
+	result, err := e.database.ExecContext(ctx, deleteStmt, id, userID)
 	if err != nil {
 		return fmt.Errorf("could not exec expense delete statement: %v", err)
 	}
@@ -338,3 +653,124 @@ func (e DB) DeleteExpense(ctx context.Context, id int64) error {
 
 	return nil
 }
+
+func insertExpense(ctx context.Context, querier database.Querier, exp models.ExpenseTable) (int64, error) {
+
+	insertStmt := fmt.Sprintf(`INSERT INTO %s 
+	(value, date, description, subcategory_id, card_id, user_id, external_reference, currency)
+	VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), COALESCE(NULLIF($8, ''), (SELECT currency FROM cards WHERE id = $5)))
+	RETURNING id`, expensesTable)
+
+	var id int64
+
+	err := querier.QueryRowContext(
+		ctx,
+		insertStmt,
+		exp.Value,
+		exp.Date,
+		exp.Description,
+		exp.SubCategoryID,
+		exp.CardID,
+		exp.UserID,
+		exp.ExternalReference,
+		exp.Currency,
+	).Scan(&id)
+	if database.IsUniqueViolation(err) {
+		return 0, ErrExternalReferenceExists
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not exec expense insert statement: %v", err)
+	}
+
+	err = insertExpenseSplits(ctx, querier, id, exp.Splits)
+	if err != nil {
+		return 0, err
+	}
+
+	err = database.ExpenseTagLinks.SetTags(ctx, querier, exp.UserID, id, exp.Tags)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func insertExpenseSplits(ctx context.Context, querier database.Querier, expenseID int64, splits []models.ExpenseSplitTable) error {
+
+	insertStmt := fmt.Sprintf(`INSERT INTO %s 
+	(expense_id, subcategory_id, value, note)
+	VALUES ($1, $2, $3, NULLIF($4, ''))`, expenseSplitsTable)
+
+	for _, line := range splits {
+		_, err := querier.ExecContext(ctx, insertStmt, expenseID, line.SubCategoryID, line.Value, line.Note)
+		if err != nil {
+			return fmt.Errorf("could not exec expense split insert statement: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// withDetails fills the tags of the expenses and the split lines of the ones that are split
+func (e DB) withDetails(ctx context.Context, expenses []models.ExpenseView) ([]models.ExpenseView, error) {
+
+	if len(expenses) == 0 {
+		return expenses, nil
+	}
+
+	ids := make([]int64, 0, len(expenses))
+	for _, exp := range expenses {
+		ids = append(ids, exp.ID)
+	}
+
+	selectStmt := fmt.Sprintf(`SELECT 
+	s.id, s.expense_id, s.value, COALESCE(s.note, ''), es.category_id, ec.name, s.subcategory_id, es.name
+	FROM %s s
+	JOIN expense_subcategories es ON s.subcategory_id = es.id
+	JOIN expense_categories ec ON es.category_id = ec.id
+	WHERE s.expense_id = ANY($1)
+	ORDER BY s.expense_id, s.id`, expenseSplitsTable)
+
+	rows, err := e.database.QueryContext(ctx, selectStmt, pq.Array(ids))
+	if err != nil {
+		return []models.ExpenseView{}, fmt.Errorf("could not query select expense splits statement: %v", err)
+	}
+	defer rows.Close()
+
+	splits := map[int64][]models.ExpenseSplitView{}
+	for rows.Next() {
+		var expenseID int64
+		var line models.ExpenseSplitView
+		err := rows.Scan(
+			&line.ID,
+			&expenseID,
+			&line.Value,
+			&line.Note,
+			&line.CategoryID,
+			&line.Category,
+			&line.SubCategoryID,
+			&line.SubCategory,
+		)
+		if err != nil {
+			return []models.ExpenseView{}, fmt.Errorf("could not scan expense split fields: %v", err)
+		}
+		splits[expenseID] = append(splits[expenseID], line)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.ExpenseView{}, fmt.Errorf("found error after scanning all expense split fields: %v", err)
+	}
+
+	tags, err := database.ExpenseTagLinks.GetTags(ctx, e.database, ids)
+	if err != nil {
+		return []models.ExpenseView{}, err
+	}
+
+	for idx := range expenses {
+		expenses[idx].Splits = splits[expenses[idx].ID]
+		expenses[idx].Tags = tags[expenses[idx].ID]
+	}
+
+	return expenses, nil
+}
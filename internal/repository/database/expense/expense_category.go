@@ -3,8 +3,11 @@ package expense
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
 )
 
@@ -50,11 +53,20 @@ func (ec CategoryDB) UpdateExpenseCategory(
 
 	updateStmt := fmt.Sprintf("UPDATE %s SET name = $1 WHERE id = $2", tableNameExpenseCategories)
 
-	_, err := ec.database.ExecContext(ctx, updateStmt, expenseCategory.Name, expenseCategory.ID)
+	result, err := ec.database.ExecContext(ctx, updateStmt, expenseCategory.Name, expenseCategory.ID)
 	if err != nil {
 		return 0, fmt.Errorf("error updating expense category: %v", err)
 	}
 
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not get number of rows affected in exec expense category update statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return 0, ErrNoRowsAffectedOnCategoryUpdate
+	}
+
 	return expenseCategory.ID, nil
 }
 
@@ -71,6 +83,9 @@ func (ec CategoryDB) GetExpenseCategoryByID(
 	var expenseCategory models.ExpenseCategoryTable
 
 	err := row.Scan(&expenseCategory.ID, &expenseCategory.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ExpenseCategoryTable{}, repository.ErrNotFound
+	}
 	if err != nil {
 		return models.ExpenseCategoryTable{}, fmt.Errorf("could not scan expense category fields: %v", err)
 	}
@@ -90,6 +105,9 @@ func (ec CategoryDB) GetExpenseCategoryByName(
 
 	var expenseCategory models.ExpenseCategoryTable
 	err := row.Scan(&expenseCategory.ID, &expenseCategory.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ExpenseCategoryTable{}, repository.ErrNotFound
+	}
 	if err != nil {
 		return models.ExpenseCategoryTable{}, fmt.Errorf("could not scan expense category fields: %v", err)
 	}
@@ -97,15 +115,116 @@ func (ec CategoryDB) GetExpenseCategoryByName(
 	return expenseCategory, nil
 }
 
+// GetExpenseCategories gets the expense categories from the expense categories db table, sorted by name
+func (ec CategoryDB) GetExpenseCategories(ctx context.Context) ([]models.ExpenseCategoryTable, error) {
+
+	selectStmt := fmt.Sprintf("SELECT id, name FROM %s ORDER BY name", tableNameExpenseCategories)
+
+	rows, err := ec.database.QueryContext(ctx, selectStmt)
+	if err != nil {
+		return nil, fmt.Errorf("could not query expense categories: %v", err)
+	}
+	defer rows.Close()
+
+	expenseCategories := []models.ExpenseCategoryTable{}
+	for rows.Next() {
+		var expenseCategory models.ExpenseCategoryTable
+		err := rows.Scan(&expenseCategory.ID, &expenseCategory.Name)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan expense category fields: %v", err)
+		}
+		expenseCategories = append(expenseCategories, expenseCategory)
+	}
+
+	return expenseCategories, rows.Err()
+}
+
 // DeleteExpenseCategory deletes an expense category from the expense categories db table
 func (ec CategoryDB) DeleteExpenseCategory(
 	ctx context.Context,
 	id int64,
 ) error {
+	return deleteExpenseCategory(ctx, ec.database, id)
+}
+
+// ReplaceExpenseCategory moves the subcategories of an expense category to its replacement and deletes it, in a transaction
+func (ec CategoryDB) ReplaceExpenseCategory(ctx context.Context, id int64, replacementID int64) error {
+
+	tx, err := ec.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin expense category replace transaction: %v", err)
+	}
+	defer tx.Rollback() // nolint
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET category_id = $1 WHERE category_id = $2", tableNameExpenseSubCategories)
+
+	_, err = tx.ExecContext(ctx, updateStmt, replacementID, id)
+	if err != nil {
+		return fmt.Errorf("could not move expense subcategories: %v", err)
+	}
+
+	err = deleteExpenseCategory(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// MergeExpenseCategory moves the subcategories and budgets of an expense category to the target and deletes it,
+// in a transaction that is rolled back on a dry run. It returns how many rows moved, or would move.
+func (ec CategoryDB) MergeExpenseCategory(
+	ctx context.Context,
+	id int64,
+	targetID int64,
+	dryRun bool,
+) (models.CategoryMerge, error) {
+
+	tx, err := ec.database.BeginTx(ctx, nil)
+	if err != nil {
+		return models.CategoryMerge{}, fmt.Errorf("could not begin expense category merge transaction: %v", err)
+	}
+	defer tx.Rollback() // nolint
+
+	var merge models.CategoryMerge
+
+	countStmt := fmt.Sprintf("SELECT COUNT(DISTINCT expense_id) FROM %s WHERE category_id = $1", expenseLinesView)
+
+	err = tx.QueryRowContext(ctx, countStmt, id).Scan(&merge.Transactions)
+	if err != nil {
+		return models.CategoryMerge{}, fmt.Errorf("could not count the expenses of the expense category: %v", err)
+	}
+
+	merge.SubCategories, err = database.MoveRows(ctx, tx, tableNameExpenseSubCategories, "category_id", id, targetID)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	merge.Budgets, err = moveBudgets(ctx, tx, "category_id", id, targetID)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	err = deleteExpenseCategory(ctx, tx, id)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	if dryRun {
+		return merge, nil
+	}
+
+	return merge, tx.Commit()
+}
+
+func deleteExpenseCategory(ctx context.Context, querier database.Querier, id int64) error {
 
 	deleteStmt := fmt.Sprintf("DELETE FROM %s WHERE id = $1", tableNameExpenseCategories)
 
-	result, err := ec.database.ExecContext(ctx, deleteStmt, id)
+	result, err := querier.ExecContext(ctx, deleteStmt, id)
+	if database.IsForeignKeyViolation(err) {
+		return ErrCategoryInUse
+	}
 	if err != nil {
 		return fmt.Errorf("error deleting expense category by id: %v", err)
 	}
@@ -3,8 +3,11 @@ package expense
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
 )
 
@@ -30,10 +33,10 @@ func (es SubCategoryDB) InsertExpenseSubCategory(
 	expenseSubCategory models.ExpenseSubCategoryTable,
 ) (int64, error) {
 
-	insertStmt := fmt.Sprintf("INSERT INTO %s (name) VALUES ($1) RETURNING id", tableNameExpenseSubCategories)
+	insertStmt := fmt.Sprintf("INSERT INTO %s (name, category_id) VALUES ($1, $2) RETURNING id", tableNameExpenseSubCategories)
 
 	var id int64
-	err := es.database.QueryRowContext(ctx, insertStmt, expenseSubCategory.Name).Scan(&id)
+	err := es.database.QueryRowContext(ctx, insertStmt, expenseSubCategory.Name, expenseSubCategory.CategoryID).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("could not scan expense subcategory id :%v", err)
 	}
@@ -49,11 +52,20 @@ func (es SubCategoryDB) UpdateExpenseSubCategory(
 
 	updateStmt := fmt.Sprintf("UPDATE %s SET name = $1, category_id = $2 WHERE id = $3", tableNameExpenseSubCategories)
 
-	_, err := es.database.ExecContext(ctx, updateStmt, expenseSubCategory.Name, expenseSubCategory.CategoryID, expenseSubCategory.ID)
+	result, err := es.database.ExecContext(ctx, updateStmt, expenseSubCategory.Name, expenseSubCategory.CategoryID, expenseSubCategory.ID)
 	if err != nil {
 		return 0, fmt.Errorf("error updating expense subcategory: %v", err)
 	}
 
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not get number of rows affected in exec expense subcategory update statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return 0, ErrNoRowsAffectedOnSubcategoryUpdate
+	}
+
 	return expenseSubCategory.ID, nil
 }
 
@@ -69,6 +81,9 @@ func (es SubCategoryDB) GetExpenseSubCategoryByID(
 
 	var expenseSubCategory models.ExpenseSubCategoryTable
 	err := row.Scan(&expenseSubCategory.ID, &expenseSubCategory.Name, &expenseSubCategory.CategoryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ExpenseSubCategoryTable{}, repository.ErrNotFound
+	}
 	if err != nil {
 		return models.ExpenseSubCategoryTable{}, fmt.Errorf("could not scan expense subcategory fields :%v", err)
 	}
@@ -88,6 +103,9 @@ func (es SubCategoryDB) GetExpenseSubCategoryByName(
 
 	var expenseSubCategory models.ExpenseSubCategoryTable
 	err := row.Scan(&expenseSubCategory.ID, &expenseSubCategory.Name, &expenseSubCategory.CategoryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ExpenseSubCategoryTable{}, repository.ErrNotFound
+	}
 	if err != nil {
 		return models.ExpenseSubCategoryTable{}, fmt.Errorf("could not scan expense subcategory fields :%v", err)
 	}
@@ -95,12 +113,149 @@ func (es SubCategoryDB) GetExpenseSubCategoryByName(
 	return expenseSubCategory, nil
 }
 
-// DeleteExpenseSubCategory deletes an expense category from the expense subcategories db table
+// GetExpenseSubCategories gets the expense subcategories from the expense subcategories db table, sorted by name
+func (es SubCategoryDB) GetExpenseSubCategories(ctx context.Context) ([]models.ExpenseSubCategoryTable, error) {
+
+	selectStmt := fmt.Sprintf("SELECT id, name, category_id FROM %s ORDER BY name, id", tableNameExpenseSubCategories)
+
+	rows, err := es.database.QueryContext(ctx, selectStmt)
+	if err != nil {
+		return nil, fmt.Errorf("could not query expense subcategories: %v", err)
+	}
+	defer rows.Close()
+
+	expenseSubCategories := []models.ExpenseSubCategoryTable{}
+	for rows.Next() {
+		var expenseSubCategory models.ExpenseSubCategoryTable
+		err := rows.Scan(&expenseSubCategory.ID, &expenseSubCategory.Name, &expenseSubCategory.CategoryID)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan expense subcategory fields: %v", err)
+		}
+		expenseSubCategories = append(expenseSubCategories, expenseSubCategory)
+	}
+
+	return expenseSubCategories, rows.Err()
+}
+
+// DeleteExpenseSubCategory deletes an expense subcategory from the expense subcategories db table
 func (es SubCategoryDB) DeleteExpenseSubCategory(ctx context.Context, id int64) error {
+	return deleteExpenseSubCategory(ctx, es.database, id)
+}
+
+// ReplaceExpenseSubCategory moves the expenses of an expense subcategory to its replacement and deletes it, in a transaction.
+// A split line of the subcategory is folded into the line of the replacement on the same expense, if there is one,
+// and expenses left with a single line are no longer split.
+func (es SubCategoryDB) ReplaceExpenseSubCategory(ctx context.Context, id int64, replacementID int64) error {
+
+	tx, err := es.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin expense subcategory replace transaction: %v", err)
+	}
+	defer tx.Rollback() // nolint
+
+	err = moveExpenseLines(ctx, tx, id, replacementID)
+	if err != nil {
+		return err
+	}
+
+	err = deleteExpenseSubCategory(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// MergeExpenseSubCategory moves the expenses, budgets, rules and recurring transactions of an expense subcategory to the target
+// and deletes it, in a transaction that is rolled back on a dry run. It returns how many rows moved, or would move.
+// Split lines are moved as on ReplaceExpenseSubCategory.
+func (es SubCategoryDB) MergeExpenseSubCategory(
+	ctx context.Context,
+	id int64,
+	targetID int64,
+	dryRun bool,
+) (models.CategoryMerge, error) {
+
+	tx, err := es.database.BeginTx(ctx, nil)
+	if err != nil {
+		return models.CategoryMerge{}, fmt.Errorf("could not begin expense subcategory merge transaction: %v", err)
+	}
+	defer tx.Rollback() // nolint
+
+	var merge models.CategoryMerge
+
+	countStmt := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE subcategory_id = $1 OR id IN (SELECT expense_id FROM %s WHERE subcategory_id = $1)",
+		expensesTable, expenseSplitsTable)
+
+	err = tx.QueryRowContext(ctx, countStmt, id).Scan(&merge.Transactions)
+	if err != nil {
+		return models.CategoryMerge{}, fmt.Errorf("could not count the expenses of the expense subcategory: %v", err)
+	}
+
+	err = moveExpenseLines(ctx, tx, id, targetID)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	merge.Budgets, err = moveBudgets(ctx, tx, "subcategory_id", id, targetID)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	merge.Rules, err = database.MoveRows(ctx, tx, categorizationRulesTable, "subcategory_id", id, targetID)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	merge.RecurringTransactions, err = database.MoveRows(ctx, tx, recurringTransactionsTable, "subcategory_id", id, targetID)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	err = deleteExpenseSubCategory(ctx, tx, id)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	if dryRun {
+		return merge, nil
+	}
+
+	return merge, tx.Commit()
+}
+
+// moveExpenseLines moves the expenses and split lines of a subcategory to another one
+func moveExpenseLines(ctx context.Context, querier database.Querier, id int64, replacementID int64) error {
+
+	statements := []string{
+		fmt.Sprintf(`UPDATE %[1]s r SET value = r.value + s.value FROM %[1]s s 
+		WHERE s.subcategory_id = $1 AND r.subcategory_id = $2 AND r.expense_id = s.expense_id`, expenseSplitsTable),
+		fmt.Sprintf(`DELETE FROM %[1]s s WHERE s.subcategory_id = $1 
+		AND EXISTS (SELECT 1 FROM %[1]s r WHERE r.subcategory_id = $2 AND r.expense_id = s.expense_id)`, expenseSplitsTable),
+		fmt.Sprintf("UPDATE %s SET subcategory_id = $2 WHERE subcategory_id = $1", expenseSplitsTable),
+		fmt.Sprintf(`DELETE FROM %[1]s WHERE subcategory_id = $2 AND expense_id IN 
+		(SELECT expense_id FROM %[1]s GROUP BY expense_id HAVING COUNT(*) = 1)`, expenseSplitsTable),
+		fmt.Sprintf("UPDATE %s SET subcategory_id = $2 WHERE subcategory_id = $1", expensesTable),
+	}
+
+	for _, stmt := range statements {
+		_, err := querier.ExecContext(ctx, stmt, id, replacementID)
+		if err != nil {
+			return fmt.Errorf("could not move expenses to the replacement subcategory: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func deleteExpenseSubCategory(ctx context.Context, querier database.Querier, id int64) error {
 
 	deleteStmt := fmt.Sprintf("DELETE FROM %s WHERE id = $1", tableNameExpenseSubCategories)
 
-	result, err := es.database.ExecContext(ctx, deleteStmt, id)
+	result, err := querier.ExecContext(ctx, deleteStmt, id)
+	if database.IsForeignKeyViolation(err) {
+		return ErrSubcategoryInUse
+	}
 	if err != nil {
 		return fmt.Errorf("error deleting expense subcategory by id: %v", err)
 	}
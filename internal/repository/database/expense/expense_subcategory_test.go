@@ -0,0 +1,218 @@
+package expense
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/tools"
+	"github.com/stretchr/testify/assert"
+)
+
+// line is a split line of an expense, by the name of its subcategory
+type line struct {
+	subCategory string
+	value       models.Money
+}
+
+func TestMoveExpenseLines(t *testing.T) {
+
+	host := os.Getenv("TEST_DB_HOST")
+	if host == "" {
+		t.Skip("TEST_DB_HOST is not set - moving expense lines runs against a migrated postgres database")
+	}
+
+	db, err := tools.InitPostgres(host)
+	if err != nil {
+		t.Fatalf("error connecting to the database: %v\n", err)
+	}
+	defer db.Close()
+
+	type want struct {
+		lines       []line
+		subCategory string
+	}
+
+	// every case moves the lines of Rent to Groceries
+	tests := []struct {
+		name        string
+		value       models.Money
+		subCategory string
+		lines       []line
+		want        want
+	}{
+		{
+			name:        "AddsUpWithTheLineOfTheTarget",
+			value:       models.MustParseMoney("20"),
+			subCategory: "Rent",
+			lines: []line{
+				{subCategory: "Rent", value: models.MustParseMoney("10")},
+				{subCategory: "Groceries", value: models.MustParseMoney("5.25")},
+				{subCategory: "Cleaning", value: models.MustParseMoney("4.75")},
+			},
+			want: want{
+				lines: []line{
+					{subCategory: "Cleaning", value: models.MustParseMoney("4.75")},
+					{subCategory: "Groceries", value: models.MustParseMoney("15.25")},
+				},
+				subCategory: "Groceries",
+			},
+		},
+		{
+			name:        "UnsplitsWhenOnlyTheTargetIsLeft",
+			value:       models.MustParseMoney("15"),
+			subCategory: "Rent",
+			lines: []line{
+				{subCategory: "Rent", value: models.MustParseMoney("10")},
+				{subCategory: "Groceries", value: models.MustParseMoney("5")},
+			},
+			want: want{
+				subCategory: "Groceries",
+			},
+		},
+		{
+			name:        "MovesWhenTheTargetIsNotALine",
+			value:       models.MustParseMoney("15"),
+			subCategory: "Cleaning",
+			lines: []line{
+				{subCategory: "Cleaning", value: models.MustParseMoney("5")},
+				{subCategory: "Rent", value: models.MustParseMoney("10")},
+			},
+			want: want{
+				lines: []line{
+					{subCategory: "Cleaning", value: models.MustParseMoney("5")},
+					{subCategory: "Groceries", value: models.MustParseMoney("10")},
+				},
+				subCategory: "Cleaning",
+			},
+		},
+		{
+			name:        "KeepsTheLinesOfOtherSubCategories",
+			value:       models.MustParseMoney("15"),
+			subCategory: "Cleaning",
+			lines: []line{
+				{subCategory: "Cleaning", value: models.MustParseMoney("5")},
+				{subCategory: "Groceries", value: models.MustParseMoney("10")},
+			},
+			want: want{
+				lines: []line{
+					{subCategory: "Cleaning", value: models.MustParseMoney("5")},
+					{subCategory: "Groceries", value: models.MustParseMoney("10")},
+				},
+				subCategory: "Cleaning",
+			},
+		},
+		{
+			name:        "MovesAnExpenseThatIsNotSplit",
+			value:       models.MustParseMoney("15"),
+			subCategory: "Rent",
+			want: want{
+				subCategory: "Groceries",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// GIVEN
+			ctx := context.Background()
+
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				t.Fatalf("error beginning transaction: %v\n", err)
+			}
+			defer tx.Rollback() // nolint
+
+			userID, cardID, subCategoryIDs := insertMoveLinesFixtures(ctx, t, tx)
+
+			var expenseID int64
+			err = tx.QueryRowContext(ctx, `INSERT INTO expenses (value, date, description, subcategory_id, card_id, user_id)
+			VALUES ($1, '2020-02-01', 'Move lines', $2, $3, $4) RETURNING id`,
+				tt.value, subCategoryIDs[tt.subCategory], cardID, userID).Scan(&expenseID)
+			if err != nil {
+				t.Fatalf("error inserting expense: %v\n", err)
+			}
+
+			for _, l := range tt.lines {
+				_, err = tx.ExecContext(ctx, `INSERT INTO expense_splits (value, expense_id, subcategory_id) VALUES ($1, $2, $3)`,
+					l.value, expenseID, subCategoryIDs[l.subCategory])
+				if err != nil {
+					t.Fatalf("error inserting expense split: %v\n", err)
+				}
+			}
+
+			// WHEN
+			err = moveExpenseLines(ctx, tx, subCategoryIDs["Rent"], subCategoryIDs["Groceries"])
+
+			// THEN
+			assert.NoError(t, err)
+
+			rows, err := tx.QueryContext(ctx, `SELECT es.name, s.value FROM expense_splits s
+			JOIN expense_subcategories es ON es.id = s.subcategory_id WHERE s.expense_id = $1 ORDER BY es.name`, expenseID)
+			if err != nil {
+				t.Fatalf("error querying expense splits: %v\n", err)
+			}
+			defer rows.Close()
+
+			var lines []line
+			for rows.Next() {
+				var l line
+				err = rows.Scan(&l.subCategory, &l.value)
+				if err != nil {
+					t.Fatalf("error scanning expense split: %v\n", err)
+				}
+				lines = append(lines, l)
+			}
+			assert.NoError(t, rows.Err())
+			assert.Equal(t, tt.want.lines, lines)
+
+			var subCategory string
+			var value models.Money
+			err = tx.QueryRowContext(ctx, `SELECT es.name, e.value FROM expenses e
+			JOIN expense_subcategories es ON es.id = e.subcategory_id WHERE e.id = $1`, expenseID).Scan(&subCategory, &value)
+			if err != nil {
+				t.Fatalf("error querying expense: %v\n", err)
+			}
+			assert.Equal(t, tt.want.subCategory, subCategory)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+}
+
+// insertMoveLinesFixtures inserts a user with a card, and the Rent, Groceries and Cleaning expense subcategories.
+// It returns the ids of the user and the card, and the ids of the subcategories by name.
+func insertMoveLinesFixtures(ctx context.Context, t *testing.T, tx *sql.Tx) (int64, int64, map[string]int64) {
+
+	var userID, cardID, categoryID int64
+
+	err := tx.QueryRowContext(ctx, `INSERT INTO users (username) VALUES ('move-lines-test') RETURNING id`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("error inserting user: %v\n", err)
+	}
+
+	err = tx.QueryRowContext(ctx, `INSERT INTO cards (name, user_id) VALUES ('Move lines', $1) RETURNING id`, userID).Scan(&cardID)
+	if err != nil {
+		t.Fatalf("error inserting card: %v\n", err)
+	}
+
+	err = tx.QueryRowContext(ctx, `INSERT INTO expense_categories (name) VALUES ('Move lines test') RETURNING id`).Scan(&categoryID)
+	if err != nil {
+		t.Fatalf("error inserting expense category: %v\n", err)
+	}
+
+	subCategoryIDs := map[string]int64{}
+	for _, name := range []string{"Rent", "Groceries", "Cleaning"} {
+		var id int64
+		err = tx.QueryRowContext(ctx, `INSERT INTO expense_subcategories (name, category_id) VALUES ($1, $2) RETURNING id`,
+			name, categoryID).Scan(&id)
+		if err != nil {
+			t.Fatalf("error inserting expense subcategory: %v\n", err)
+		}
+		subCategoryIDs[name] = id
+	}
+
+	return userID, cardID, subCategoryIDs
+}
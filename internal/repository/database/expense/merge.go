@@ -0,0 +1,48 @@
+package expense
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database"
+)
+
+const (
+	budgetsTable               = "budgets"
+	categorizationRulesTable   = "categorization_rules"
+	recurringTransactionsTable = "recurring_transactions"
+)
+
+// moveBudgets moves the budgets of an expense category or subcategory, referenced in the column, to the target,
+// returning how many budgets moved. The budget of a user who also has one on the target is folded into it,
+// adding up their month limits.
+func moveBudgets(ctx context.Context, querier database.Querier, column string, id int64, targetID int64) (int64, error) {
+
+	foldStmt := fmt.Sprintf(`UPDATE %[1]s t SET month_limit = t.month_limit + s.month_limit FROM %[1]s s 
+	WHERE s.%[2]s = $1 AND t.%[2]s = $2 AND t.user_id = s.user_id`, budgetsTable, column)
+
+	_, err := querier.ExecContext(ctx, foldStmt, id, targetID)
+	if err != nil {
+		return 0, fmt.Errorf("could not fold budgets into the target: %v", err)
+	}
+
+	deleteStmt := fmt.Sprintf(`DELETE FROM %[1]s s WHERE s.%[2]s = $1 
+	AND EXISTS (SELECT 1 FROM %[1]s t WHERE t.%[2]s = $2 AND t.user_id = s.user_id)`, budgetsTable, column)
+
+	result, err := querier.ExecContext(ctx, deleteStmt, id, targetID)
+	if err != nil {
+		return 0, fmt.Errorf("could not delete the folded budgets: %v", err)
+	}
+
+	folded, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not get number of rows affected in exec folded budgets delete statement: %v", err)
+	}
+
+	moved, err := database.MoveRows(ctx, querier, budgetsTable, column, id, targetID)
+	if err != nil {
+		return 0, err
+	}
+
+	return folded + moved, nil
+}
@@ -39,6 +39,28 @@ func (d ExpenseSubCategoryRepoWithLogs) DeleteExpenseSubCategory(ctx context.Con
 	return d.base.DeleteExpenseSubCategory(ctx, i1)
 }
 
+// GetExpenseSubCategories implements repository.ExpenseSubCategoryRepo
+func (d ExpenseSubCategoryRepoWithLogs) GetExpenseSubCategories(ctx context.Context) (ea1 []models.ExpenseSubCategoryTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ea1": ea1,
+				"err": err}).Err(err).Str("decorator", "ExpenseSubCategoryRepoWithLogs").Str("method", "GetExpenseSubCategories").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ea1": ea1,
+				"err": err}).Str("decorator", "ExpenseSubCategoryRepoWithLogs").Str("method", "GetExpenseSubCategories").Msg("Finish")
+		}
+	}()
+	return d.base.GetExpenseSubCategories(ctx)
+}
+
 // GetExpenseSubCategoryByID implements repository.ExpenseSubCategoryRepo
 func (d ExpenseSubCategoryRepoWithLogs) GetExpenseSubCategoryByID(ctx context.Context, i1 int64) (e1 models.ExpenseSubCategoryTable, err error) {
 
@@ -108,6 +130,53 @@ func (d ExpenseSubCategoryRepoWithLogs) InsertExpenseSubCategory(ctx context.Con
 	return d.base.InsertExpenseSubCategory(ctx, e1)
 }
 
+// MergeExpenseSubCategory implements repository.ExpenseSubCategoryRepo
+func (d ExpenseSubCategoryRepoWithLogs) MergeExpenseSubCategory(ctx context.Context, i1 int64, i2 int64, b1 bool) (c2 models.CategoryMerge, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2,
+		"b1":  b1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"c2":  c2,
+				"err": err}).Err(err).Str("decorator", "ExpenseSubCategoryRepoWithLogs").Str("method", "MergeExpenseSubCategory").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"c2":  c2,
+				"err": err}).Str("decorator", "ExpenseSubCategoryRepoWithLogs").Str("method", "MergeExpenseSubCategory").Msg("Finish")
+		}
+	}()
+	return d.base.MergeExpenseSubCategory(ctx, i1, i2, b1)
+}
+
+// ReplaceExpenseSubCategory implements repository.ExpenseSubCategoryRepo
+func (d ExpenseSubCategoryRepoWithLogs) ReplaceExpenseSubCategory(ctx context.Context, i1 int64, i2 int64) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "ExpenseSubCategoryRepoWithLogs").Str("method", "ReplaceExpenseSubCategory").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "ExpenseSubCategoryRepoWithLogs").Str("method", "ReplaceExpenseSubCategory").Msg("Finish")
+		}
+	}()
+	return d.base.ReplaceExpenseSubCategory(ctx, i1, i2)
+}
+
 // UpdateExpenseSubCategory implements repository.ExpenseSubCategoryRepo
 func (d ExpenseSubCategoryRepoWithLogs) UpdateExpenseSubCategory(ctx context.Context, e1 models.ExpenseSubCategoryTable) (i1 int64, err error) {
 
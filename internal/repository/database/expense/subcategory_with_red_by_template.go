@@ -46,6 +46,30 @@ func (d ExpenseSubCategoryRepoWithRED) DeleteExpenseSubCategory(ctx context.Cont
 	return d.base.DeleteExpenseSubCategory(ctx, i1)
 }
 
+// GetExpenseSubCategories implements repository.ExpenseSubCategoryRepo
+func (d ExpenseSubCategoryRepoWithRED) GetExpenseSubCategories(ctx context.Context) (ea1 []models.ExpenseSubCategoryTable, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetExpenseSubCategories",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetExpenseSubCategories(ctx)
+}
+
 // GetExpenseSubCategoryByID implements repository.ExpenseSubCategoryRepo
 func (d ExpenseSubCategoryRepoWithRED) GetExpenseSubCategoryByID(ctx context.Context, i1 int64) (e1 models.ExpenseSubCategoryTable, err error) {
 	since := time.Now()
@@ -118,6 +142,54 @@ func (d ExpenseSubCategoryRepoWithRED) InsertExpenseSubCategory(ctx context.Cont
 	return d.base.InsertExpenseSubCategory(ctx, e1)
 }
 
+// MergeExpenseSubCategory implements repository.ExpenseSubCategoryRepo
+func (d ExpenseSubCategoryRepoWithRED) MergeExpenseSubCategory(ctx context.Context, i1 int64, i2 int64, b1 bool) (c2 models.CategoryMerge, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "MergeExpenseSubCategory",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.MergeExpenseSubCategory(ctx, i1, i2, b1)
+}
+
+// ReplaceExpenseSubCategory implements repository.ExpenseSubCategoryRepo
+func (d ExpenseSubCategoryRepoWithRED) ReplaceExpenseSubCategory(ctx context.Context, i1 int64, i2 int64) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "ReplaceExpenseSubCategory",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.ReplaceExpenseSubCategory(ctx, i1, i2)
+}
+
 // UpdateExpenseSubCategory implements repository.ExpenseSubCategoryRepo
 func (d ExpenseSubCategoryRepoWithRED) UpdateExpenseSubCategory(ctx context.Context, e1 models.ExpenseSubCategoryTable) (i1 int64, err error) {
 	since := time.Now()
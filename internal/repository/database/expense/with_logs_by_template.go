@@ -20,13 +20,14 @@ type ExpenseRepoWithLogs struct {
 }
 
 // DeleteExpense implements repository.ExpenseRepo
-func (d ExpenseRepoWithLogs) DeleteExpense(ctx context.Context, i1 int64) (err error) {
+func (d ExpenseRepoWithLogs) DeleteExpense(ctx context.Context, i1 int64, i2 int64) (err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
-		"i1":  i1}).Logger()
+		"i1":  i1,
+		"i2":  i2}).Logger()
 
 	defer func() {
 		if err != nil {
@@ -37,17 +38,43 @@ func (d ExpenseRepoWithLogs) DeleteExpense(ctx context.Context, i1 int64) (err e
 				"err": err}).Str("decorator", "ExpenseRepoWithLogs").Str("method", "DeleteExpense").Msg("Finish")
 		}
 	}()
-	return d.base.DeleteExpense(ctx, i1)
+	return d.base.DeleteExpense(ctx, i1, i2)
+}
+
+// GetExpenseByExternalReference implements repository.ExpenseRepo
+func (d ExpenseRepoWithLogs) GetExpenseByExternalReference(ctx context.Context, i1 int64, i2 int64, s1 string) (e1 models.ExpenseTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2,
+		"s1":  s1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"e1":  e1,
+				"err": err}).Err(err).Str("decorator", "ExpenseRepoWithLogs").Str("method", "GetExpenseByExternalReference").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"e1":  e1,
+				"err": err}).Str("decorator", "ExpenseRepoWithLogs").Str("method", "GetExpenseByExternalReference").Msg("Finish")
+		}
+	}()
+	return d.base.GetExpenseByExternalReference(ctx, i1, i2, s1)
 }
 
 // GetExpenseByID implements repository.ExpenseRepo
-func (d ExpenseRepoWithLogs) GetExpenseByID(ctx context.Context, i1 int64) (e1 models.ExpenseView, err error) {
+func (d ExpenseRepoWithLogs) GetExpenseByID(ctx context.Context, i1 int64, i2 int64) (e1 models.ExpenseView, err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
-		"i1":  i1}).Logger()
+		"i1":  i1,
+		"i2":  i2}).Logger()
 
 	defer func() {
 		if err != nil {
@@ -60,16 +87,67 @@ func (d ExpenseRepoWithLogs) GetExpenseByID(ctx context.Context, i1 int64) (e1 m
 				"err": err}).Str("decorator", "ExpenseRepoWithLogs").Str("method", "GetExpenseByID").Msg("Finish")
 		}
 	}()
-	return d.base.GetExpenseByID(ctx, i1)
+	return d.base.GetExpenseByID(ctx, i1, i2)
+}
+
+// GetExpenseDailyTotals implements repository.ExpenseRepo
+func (d ExpenseRepoWithLogs) GetExpenseDailyTotals(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (da1 []models.DailyTotal, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"t1":  t1,
+		"t2":  t2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"da1": da1,
+				"err": err}).Err(err).Str("decorator", "ExpenseRepoWithLogs").Str("method", "GetExpenseDailyTotals").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"da1": da1,
+				"err": err}).Str("decorator", "ExpenseRepoWithLogs").Str("method", "GetExpenseDailyTotals").Msg("Finish")
+		}
+	}()
+	return d.base.GetExpenseDailyTotals(ctx, i1, t1, t2)
+}
+
+// GetExpenseTagDailyTotals implements repository.ExpenseRepo
+func (d ExpenseRepoWithLogs) GetExpenseTagDailyTotals(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (da1 []models.DailyTotal, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"t1":  t1,
+		"t2":  t2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"da1": da1,
+				"err": err}).Err(err).Str("decorator", "ExpenseRepoWithLogs").Str("method", "GetExpenseTagDailyTotals").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"da1": da1,
+				"err": err}).Str("decorator", "ExpenseRepoWithLogs").Str("method", "GetExpenseTagDailyTotals").Msg("Finish")
+		}
+	}()
+	return d.base.GetExpenseTagDailyTotals(ctx, i1, t1, t2)
 }
 
 // GetExpensesByCard implements repository.ExpenseRepo
-func (d ExpenseRepoWithLogs) GetExpensesByCard(ctx context.Context, s1 string) (ea1 []models.ExpenseView, err error) {
+func (d ExpenseRepoWithLogs) GetExpensesByCard(ctx context.Context, i1 int64, s1 string) (ea1 []models.ExpenseView, err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
+		"i1":  i1,
 		"s1":  s1}).Logger()
 
 	defer func() {
@@ -83,16 +161,44 @@ func (d ExpenseRepoWithLogs) GetExpensesByCard(ctx context.Context, s1 string) (
 				"err": err}).Str("decorator", "ExpenseRepoWithLogs").Str("method", "GetExpensesByCard").Msg("Finish")
 		}
 	}()
-	return d.base.GetExpensesByCard(ctx, s1)
+	return d.base.GetExpensesByCard(ctx, i1, s1)
+}
+
+// GetExpensesByCardAndValue implements repository.ExpenseRepo
+func (d ExpenseRepoWithLogs) GetExpensesByCardAndValue(ctx context.Context, i1 int64, i2 int64, m1 models.Money, t1 time.Time, t2 time.Time) (ea1 []models.ExpenseView, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2,
+		"m1":  m1,
+		"t1":  t1,
+		"t2":  t2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ea1": ea1,
+				"err": err}).Err(err).Str("decorator", "ExpenseRepoWithLogs").Str("method", "GetExpensesByCardAndValue").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ea1": ea1,
+				"err": err}).Str("decorator", "ExpenseRepoWithLogs").Str("method", "GetExpensesByCardAndValue").Msg("Finish")
+		}
+	}()
+	return d.base.GetExpensesByCardAndValue(ctx, i1, i2, m1, t1, t2)
 }
 
 // GetExpensesByCategory implements repository.ExpenseRepo
-func (d ExpenseRepoWithLogs) GetExpensesByCategory(ctx context.Context, s1 string) (ea1 []models.ExpenseView, err error) {
+func (d ExpenseRepoWithLogs) GetExpensesByCategory(ctx context.Context, i1 int64, s1 string) (ea1 []models.ExpenseView, err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
+		"i1":  i1,
 		"s1":  s1}).Logger()
 
 	defer func() {
@@ -106,16 +212,17 @@ func (d ExpenseRepoWithLogs) GetExpensesByCategory(ctx context.Context, s1 strin
 				"err": err}).Str("decorator", "ExpenseRepoWithLogs").Str("method", "GetExpensesByCategory").Msg("Finish")
 		}
 	}()
-	return d.base.GetExpensesByCategory(ctx, s1)
+	return d.base.GetExpensesByCategory(ctx, i1, s1)
 }
 
 // GetExpensesByDates implements repository.ExpenseRepo
-func (d ExpenseRepoWithLogs) GetExpensesByDates(ctx context.Context, t1 time.Time, t2 time.Time) (ea1 []models.ExpenseView, err error) {
+func (d ExpenseRepoWithLogs) GetExpensesByDates(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (ea1 []models.ExpenseView, err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
+		"i1":  i1,
 		"t1":  t1,
 		"t2":  t2}).Logger()
 
@@ -130,16 +237,17 @@ func (d ExpenseRepoWithLogs) GetExpensesByDates(ctx context.Context, t1 time.Tim
 				"err": err}).Str("decorator", "ExpenseRepoWithLogs").Str("method", "GetExpensesByDates").Msg("Finish")
 		}
 	}()
-	return d.base.GetExpensesByDates(ctx, t1, t2)
+	return d.base.GetExpensesByDates(ctx, i1, t1, t2)
 }
 
 // GetExpensesBySubCategory implements repository.ExpenseRepo
-func (d ExpenseRepoWithLogs) GetExpensesBySubCategory(ctx context.Context, s1 string) (ea1 []models.ExpenseView, err error) {
+func (d ExpenseRepoWithLogs) GetExpensesBySubCategory(ctx context.Context, i1 int64, s1 string) (ea1 []models.ExpenseView, err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
+		"i1":  i1,
 		"s1":  s1}).Logger()
 
 	defer func() {
@@ -153,7 +261,7 @@ func (d ExpenseRepoWithLogs) GetExpensesBySubCategory(ctx context.Context, s1 st
 				"err": err}).Str("decorator", "ExpenseRepoWithLogs").Str("method", "GetExpensesBySubCategory").Msg("Finish")
 		}
 	}()
-	return d.base.GetExpensesBySubCategory(ctx, s1)
+	return d.base.GetExpensesBySubCategory(ctx, i1, s1)
 }
 
 // InsertExpense implements repository.ExpenseRepo
@@ -179,6 +287,53 @@ func (d ExpenseRepoWithLogs) InsertExpense(ctx context.Context, e1 models.Expens
 	return d.base.InsertExpense(ctx, e1)
 }
 
+// InsertExpenses implements repository.ExpenseRepo
+func (d ExpenseRepoWithLogs) InsertExpenses(ctx context.Context, ea1 []models.ExpenseTable) (ia1 []int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"ea1": ea1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ia1": ia1,
+				"err": err}).Err(err).Str("decorator", "ExpenseRepoWithLogs").Str("method", "InsertExpenses").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ia1": ia1,
+				"err": err}).Str("decorator", "ExpenseRepoWithLogs").Str("method", "InsertExpenses").Msg("Finish")
+		}
+	}()
+	return d.base.InsertExpenses(ctx, ea1)
+}
+
+// SearchExpenses implements repository.ExpenseRepo
+func (d ExpenseRepoWithLogs) SearchExpenses(ctx context.Context, i1 int64, s1 models.SearchFilter) (ea1 []models.ExpenseView, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"s1":  s1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ea1": ea1,
+				"err": err}).Err(err).Str("decorator", "ExpenseRepoWithLogs").Str("method", "SearchExpenses").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ea1": ea1,
+				"err": err}).Str("decorator", "ExpenseRepoWithLogs").Str("method", "SearchExpenses").Msg("Finish")
+		}
+	}()
+	return d.base.SearchExpenses(ctx, i1, s1)
+}
+
 // UpdateExpense implements repository.ExpenseRepo
 func (d ExpenseRepoWithLogs) UpdateExpense(ctx context.Context, e1 models.ExpenseTable) (i1 int64, err error) {
 
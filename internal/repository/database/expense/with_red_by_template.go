@@ -23,7 +23,7 @@ type ExpenseRepoWithRED struct {
 }
 
 // DeleteExpense implements repository.ExpenseRepo
-func (d ExpenseRepoWithRED) DeleteExpense(ctx context.Context, i1 int64) (err error) {
+func (d ExpenseRepoWithRED) DeleteExpense(ctx context.Context, i1 int64, i2 int64) (err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -43,11 +43,35 @@ func (d ExpenseRepoWithRED) DeleteExpense(ctx context.Context, i1 int64) (err er
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.DeleteExpense(ctx, i1)
+	return d.base.DeleteExpense(ctx, i1, i2)
+}
+
+// GetExpenseByExternalReference implements repository.ExpenseRepo
+func (d ExpenseRepoWithRED) GetExpenseByExternalReference(ctx context.Context, i1 int64, i2 int64, s1 string) (e1 models.ExpenseTable, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetExpenseByExternalReference",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetExpenseByExternalReference(ctx, i1, i2, s1)
 }
 
 // GetExpenseByID implements repository.ExpenseRepo
-func (d ExpenseRepoWithRED) GetExpenseByID(ctx context.Context, i1 int64) (e1 models.ExpenseView, err error) {
+func (d ExpenseRepoWithRED) GetExpenseByID(ctx context.Context, i1 int64, i2 int64) (e1 models.ExpenseView, err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -67,11 +91,59 @@ func (d ExpenseRepoWithRED) GetExpenseByID(ctx context.Context, i1 int64) (e1 mo
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.GetExpenseByID(ctx, i1)
+	return d.base.GetExpenseByID(ctx, i1, i2)
+}
+
+// GetExpenseDailyTotals implements repository.ExpenseRepo
+func (d ExpenseRepoWithRED) GetExpenseDailyTotals(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (da1 []models.DailyTotal, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetExpenseDailyTotals",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetExpenseDailyTotals(ctx, i1, t1, t2)
+}
+
+// GetExpenseTagDailyTotals implements repository.ExpenseRepo
+func (d ExpenseRepoWithRED) GetExpenseTagDailyTotals(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (da1 []models.DailyTotal, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetExpenseTagDailyTotals",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetExpenseTagDailyTotals(ctx, i1, t1, t2)
 }
 
 // GetExpensesByCard implements repository.ExpenseRepo
-func (d ExpenseRepoWithRED) GetExpensesByCard(ctx context.Context, s1 string) (ea1 []models.ExpenseView, err error) {
+func (d ExpenseRepoWithRED) GetExpensesByCard(ctx context.Context, i1 int64, s1 string) (ea1 []models.ExpenseView, err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -91,11 +163,35 @@ func (d ExpenseRepoWithRED) GetExpensesByCard(ctx context.Context, s1 string) (e
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.GetExpensesByCard(ctx, s1)
+	return d.base.GetExpensesByCard(ctx, i1, s1)
+}
+
+// GetExpensesByCardAndValue implements repository.ExpenseRepo
+func (d ExpenseRepoWithRED) GetExpensesByCardAndValue(ctx context.Context, i1 int64, i2 int64, m1 models.Money, t1 time.Time, t2 time.Time) (ea1 []models.ExpenseView, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetExpensesByCardAndValue",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetExpensesByCardAndValue(ctx, i1, i2, m1, t1, t2)
 }
 
 // GetExpensesByCategory implements repository.ExpenseRepo
-func (d ExpenseRepoWithRED) GetExpensesByCategory(ctx context.Context, s1 string) (ea1 []models.ExpenseView, err error) {
+func (d ExpenseRepoWithRED) GetExpensesByCategory(ctx context.Context, i1 int64, s1 string) (ea1 []models.ExpenseView, err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -115,11 +211,11 @@ func (d ExpenseRepoWithRED) GetExpensesByCategory(ctx context.Context, s1 string
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.GetExpensesByCategory(ctx, s1)
+	return d.base.GetExpensesByCategory(ctx, i1, s1)
 }
 
 // GetExpensesByDates implements repository.ExpenseRepo
-func (d ExpenseRepoWithRED) GetExpensesByDates(ctx context.Context, t1 time.Time, t2 time.Time) (ea1 []models.ExpenseView, err error) {
+func (d ExpenseRepoWithRED) GetExpensesByDates(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (ea1 []models.ExpenseView, err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -139,11 +235,11 @@ func (d ExpenseRepoWithRED) GetExpensesByDates(ctx context.Context, t1 time.Time
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.GetExpensesByDates(ctx, t1, t2)
+	return d.base.GetExpensesByDates(ctx, i1, t1, t2)
 }
 
 // GetExpensesBySubCategory implements repository.ExpenseRepo
-func (d ExpenseRepoWithRED) GetExpensesBySubCategory(ctx context.Context, s1 string) (ea1 []models.ExpenseView, err error) {
+func (d ExpenseRepoWithRED) GetExpensesBySubCategory(ctx context.Context, i1 int64, s1 string) (ea1 []models.ExpenseView, err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -163,7 +259,7 @@ func (d ExpenseRepoWithRED) GetExpensesBySubCategory(ctx context.Context, s1 str
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.GetExpensesBySubCategory(ctx, s1)
+	return d.base.GetExpensesBySubCategory(ctx, i1, s1)
 }
 
 // InsertExpense implements repository.ExpenseRepo
@@ -190,6 +286,54 @@ func (d ExpenseRepoWithRED) InsertExpense(ctx context.Context, e1 models.Expense
 	return d.base.InsertExpense(ctx, e1)
 }
 
+// InsertExpenses implements repository.ExpenseRepo
+func (d ExpenseRepoWithRED) InsertExpenses(ctx context.Context, ea1 []models.ExpenseTable) (ia1 []int64, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "InsertExpenses",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.InsertExpenses(ctx, ea1)
+}
+
+// SearchExpenses implements repository.ExpenseRepo
+func (d ExpenseRepoWithRED) SearchExpenses(ctx context.Context, i1 int64, s1 models.SearchFilter) (ea1 []models.ExpenseView, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "SearchExpenses",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.SearchExpenses(ctx, i1, s1)
+}
+
 // UpdateExpense implements repository.ExpenseRepo
 func (d ExpenseRepoWithRED) UpdateExpense(ctx context.Context, e1 models.ExpenseTable) (i1 int64, err error) {
 	since := time.Now()
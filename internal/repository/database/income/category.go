@@ -3,13 +3,18 @@ package income
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
 )
 
 const (
-	tableNameIncomeCategories = "income_categories"
+	tableNameIncomeCategories  = "income_categories"
+	categorizationRulesTable   = "categorization_rules"
+	recurringTransactionsTable = "recurring_transactions"
 )
 
 // CategoryDB implements the income category repository methods
@@ -47,11 +52,20 @@ func (ic CategoryDB) UpdateIncomeCategory(
 
 	updateStmt := fmt.Sprintf("UPDATE %s SET name = $1 WHERE id = $2", tableNameIncomeCategories)
 
-	_, err := ic.database.ExecContext(ctx, updateStmt, incomeCategory.Name, incomeCategory.ID)
+	result, err := ic.database.ExecContext(ctx, updateStmt, incomeCategory.Name, incomeCategory.ID)
 	if err != nil {
 		return 0, fmt.Errorf("error updating income category: %v", err)
 	}
 
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not get number of rows affected in exec income category update statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return 0, ErrNoRowsAffectedOnCategoryUpdate
+	}
+
 	return incomeCategory.ID, nil
 }
 
@@ -68,6 +82,9 @@ func (ic CategoryDB) GetIncomeCategoryByID(
 	var incomeCategory models.IncomeCategoryTable
 
 	err := row.Scan(&incomeCategory.ID, &incomeCategory.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.IncomeCategoryTable{}, repository.ErrNotFound
+	}
 	if err != nil {
 		return models.IncomeCategoryTable{}, fmt.Errorf("could not scan income category fields: %v", err)
 	}
@@ -87,6 +104,9 @@ func (ic CategoryDB) GetIncomeCategoryByName(
 
 	var incomeCategory models.IncomeCategoryTable
 	err := row.Scan(&incomeCategory.ID, &incomeCategory.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.IncomeCategoryTable{}, repository.ErrNotFound
+	}
 	if err != nil {
 		return models.IncomeCategoryTable{}, fmt.Errorf("could not scan income category fields: %v", err)
 	}
@@ -94,15 +114,114 @@ func (ic CategoryDB) GetIncomeCategoryByName(
 	return incomeCategory, nil
 }
 
+// GetIncomeCategories gets the income categories from the income categories db table, sorted by name
+func (ic CategoryDB) GetIncomeCategories(ctx context.Context) ([]models.IncomeCategoryTable, error) {
+
+	selectStmt := fmt.Sprintf("SELECT id, name FROM %s ORDER BY name, id", tableNameIncomeCategories)
+
+	rows, err := ic.database.QueryContext(ctx, selectStmt)
+	if err != nil {
+		return nil, fmt.Errorf("could not query income categories: %v", err)
+	}
+	defer rows.Close()
+
+	incomeCategories := []models.IncomeCategoryTable{}
+	for rows.Next() {
+		var incomeCategory models.IncomeCategoryTable
+		err := rows.Scan(&incomeCategory.ID, &incomeCategory.Name)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan income category fields: %v", err)
+		}
+		incomeCategories = append(incomeCategories, incomeCategory)
+	}
+
+	return incomeCategories, rows.Err()
+}
+
 // DeleteIncomeCategory deletes an income category from the income categories db table
 func (ic CategoryDB) DeleteIncomeCategory(
 	ctx context.Context,
 	id int64,
 ) error {
+	return deleteIncomeCategory(ctx, ic.database, id)
+}
+
+// ReplaceIncomeCategory moves the incomes of an income category to its replacement and deletes it, in a transaction
+func (ic CategoryDB) ReplaceIncomeCategory(ctx context.Context, id int64, replacementID int64) error {
+
+	tx, err := ic.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin income category replace transaction: %v", err)
+	}
+	defer tx.Rollback() // nolint
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET category_id = $1 WHERE category_id = $2", incomesTable)
+
+	_, err = tx.ExecContext(ctx, updateStmt, replacementID, id)
+	if err != nil {
+		return fmt.Errorf("could not move incomes to the replacement category: %v", err)
+	}
+
+	err = deleteIncomeCategory(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// MergeIncomeCategory moves the incomes, rules and recurring transactions of an income category to the target and deletes it,
+// in a transaction that is rolled back on a dry run. It returns how many rows moved, or would move.
+func (ic CategoryDB) MergeIncomeCategory(
+	ctx context.Context,
+	id int64,
+	targetID int64,
+	dryRun bool,
+) (models.CategoryMerge, error) {
+
+	tx, err := ic.database.BeginTx(ctx, nil)
+	if err != nil {
+		return models.CategoryMerge{}, fmt.Errorf("could not begin income category merge transaction: %v", err)
+	}
+	defer tx.Rollback() // nolint
+
+	var merge models.CategoryMerge
+
+	merge.Transactions, err = database.MoveRows(ctx, tx, incomesTable, "category_id", id, targetID)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	merge.Rules, err = database.MoveRows(ctx, tx, categorizationRulesTable, "income_category_id", id, targetID)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	merge.RecurringTransactions, err = database.MoveRows(ctx, tx, recurringTransactionsTable, "income_category_id", id, targetID)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	err = deleteIncomeCategory(ctx, tx, id)
+	if err != nil {
+		return models.CategoryMerge{}, err
+	}
+
+	if dryRun {
+		return merge, nil
+	}
+
+	return merge, tx.Commit()
+}
+
+func deleteIncomeCategory(ctx context.Context, querier database.Querier, id int64) error {
 
 	deleteStmt := fmt.Sprintf("DELETE FROM %s WHERE id = $1", tableNameIncomeCategories)
 
-	result, err := ic.database.ExecContext(ctx, deleteStmt, id)
+	result, err := querier.ExecContext(ctx, deleteStmt, id)
+	if database.IsForeignKeyViolation(err) {
+		return ErrCategoryInUse
+	}
 	if err != nil {
 		return fmt.Errorf("error deleting income category by id: %v", err)
 	}
@@ -39,6 +39,28 @@ func (d IncomeCategoryRepoWithLogs) DeleteIncomeCategory(ctx context.Context, i1
 	return d.base.DeleteIncomeCategory(ctx, i1)
 }
 
+// GetIncomeCategories implements repository.IncomeCategoryRepo
+func (d IncomeCategoryRepoWithLogs) GetIncomeCategories(ctx context.Context) (ia1 []models.IncomeCategoryTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ia1": ia1,
+				"err": err}).Err(err).Str("decorator", "IncomeCategoryRepoWithLogs").Str("method", "GetIncomeCategories").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ia1": ia1,
+				"err": err}).Str("decorator", "IncomeCategoryRepoWithLogs").Str("method", "GetIncomeCategories").Msg("Finish")
+		}
+	}()
+	return d.base.GetIncomeCategories(ctx)
+}
+
 // GetIncomeCategoryByID implements repository.IncomeCategoryRepo
 func (d IncomeCategoryRepoWithLogs) GetIncomeCategoryByID(ctx context.Context, i1 int64) (i2 models.IncomeCategoryTable, err error) {
 
@@ -108,6 +130,53 @@ func (d IncomeCategoryRepoWithLogs) InsertIncomeCategory(ctx context.Context, i1
 	return d.base.InsertIncomeCategory(ctx, i1)
 }
 
+// MergeIncomeCategory implements repository.IncomeCategoryRepo
+func (d IncomeCategoryRepoWithLogs) MergeIncomeCategory(ctx context.Context, i1 int64, i2 int64, b1 bool) (c2 models.CategoryMerge, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2,
+		"b1":  b1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"c2":  c2,
+				"err": err}).Err(err).Str("decorator", "IncomeCategoryRepoWithLogs").Str("method", "MergeIncomeCategory").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"c2":  c2,
+				"err": err}).Str("decorator", "IncomeCategoryRepoWithLogs").Str("method", "MergeIncomeCategory").Msg("Finish")
+		}
+	}()
+	return d.base.MergeIncomeCategory(ctx, i1, i2, b1)
+}
+
+// ReplaceIncomeCategory implements repository.IncomeCategoryRepo
+func (d IncomeCategoryRepoWithLogs) ReplaceIncomeCategory(ctx context.Context, i1 int64, i2 int64) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "IncomeCategoryRepoWithLogs").Str("method", "ReplaceIncomeCategory").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "IncomeCategoryRepoWithLogs").Str("method", "ReplaceIncomeCategory").Msg("Finish")
+		}
+	}()
+	return d.base.ReplaceIncomeCategory(ctx, i1, i2)
+}
+
 // UpdateIncomeCategory implements repository.IncomeCategoryRepo
 func (d IncomeCategoryRepoWithLogs) UpdateIncomeCategory(ctx context.Context, i1 models.IncomeCategoryTable) (i2 int64, err error) {
 
@@ -46,6 +46,30 @@ func (d IncomeCategoryRepoWithRED) DeleteIncomeCategory(ctx context.Context, i1
 	return d.base.DeleteIncomeCategory(ctx, i1)
 }
 
+// GetIncomeCategories implements repository.IncomeCategoryRepo
+func (d IncomeCategoryRepoWithRED) GetIncomeCategories(ctx context.Context) (ia1 []models.IncomeCategoryTable, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetIncomeCategories",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetIncomeCategories(ctx)
+}
+
 // GetIncomeCategoryByID implements repository.IncomeCategoryRepo
 func (d IncomeCategoryRepoWithRED) GetIncomeCategoryByID(ctx context.Context, i1 int64) (i2 models.IncomeCategoryTable, err error) {
 	since := time.Now()
@@ -118,6 +142,54 @@ func (d IncomeCategoryRepoWithRED) InsertIncomeCategory(ctx context.Context, i1
 	return d.base.InsertIncomeCategory(ctx, i1)
 }
 
+// MergeIncomeCategory implements repository.IncomeCategoryRepo
+func (d IncomeCategoryRepoWithRED) MergeIncomeCategory(ctx context.Context, i1 int64, i2 int64, b1 bool) (c2 models.CategoryMerge, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "MergeIncomeCategory",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.MergeIncomeCategory(ctx, i1, i2, b1)
+}
+
+// ReplaceIncomeCategory implements repository.IncomeCategoryRepo
+func (d IncomeCategoryRepoWithRED) ReplaceIncomeCategory(ctx context.Context, i1 int64, i2 int64) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "ReplaceIncomeCategory",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.ReplaceIncomeCategory(ctx, i1, i2)
+}
+
 // UpdateIncomeCategory implements repository.IncomeCategoryRepo
 func (d IncomeCategoryRepoWithRED) UpdateIncomeCategory(ctx context.Context, i1 models.IncomeCategoryTable) (i2 int64, err error) {
 	since := time.Now()
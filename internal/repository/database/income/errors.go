@@ -1,9 +1,16 @@
 package income
 
-import "errors"
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
 
 var (
-	ErrNoRowsAffectedOnDelete         = errors.New("there were no rows affected in exec income delete statement")
-	ErrNoRowsAffectedOnUpdate         = errors.New("there were no rows affected in exec income update statement")
-	ErrNoRowsAffectedOnCategoryDelete = errors.New("there were no rows affected in exec income category delete statement")
+	ErrNoRowsAffectedOnDelete         = fmt.Errorf("there were no rows affected in exec income delete statement: %w", repository.ErrNotFound)
+	ErrNoRowsAffectedOnUpdate         = fmt.Errorf("there were no rows affected in exec income update statement: %w", repository.ErrNotFound)
+	ErrNoRowsAffectedOnCategoryDelete = fmt.Errorf("there were no rows affected in exec income category delete statement: %w", repository.ErrNotFound)
+	ErrNoRowsAffectedOnCategoryUpdate = fmt.Errorf("there were no rows affected in exec income category update statement: %w", repository.ErrNotFound)
+	ErrCategoryInUse                  = fmt.Errorf("income category is still referenced: %w", repository.ErrInUse)
+	ErrExternalReferenceExists        = fmt.Errorf("income card already has an income with this external reference: %w", repository.ErrAlreadyExists)
 )
@@ -3,10 +3,12 @@ package income
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
 )
 
@@ -35,39 +37,71 @@ func NewDB(
 	}
 }
 
-// InsertIncome inserts an income on the incomes db table
+// InsertIncome inserts an income on the incomes db table, with its tags in the same transaction
 func (e DB) InsertIncome(ctx context.Context, inc models.IncomeTable) (int64, error) {
 
-	insertStmt := fmt.Sprintf(`INSERT INTO %s 
-	(value, date, description, category_id, card_id)
-	VALUES ($1, $2, $3, $4, $5) RETURNING id`, incomesTable)
+	tx, err := e.database.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not begin income insert transaction: %v", err)
+	}
+	defer tx.Rollback()
 
-	var id int64
+	id, err := insertIncome(ctx, tx, inc)
+	if err != nil {
+		return 0, err
+	}
 
-	err := e.database.QueryRowContext(
-		ctx,
-		insertStmt,
-		inc.Value,
-		inc.Date,
-		inc.Description,
-		inc.CategoryID,
-		inc.CardID,
-	).Scan(&id)
+	err = tx.Commit()
 	if err != nil {
-		return 0, fmt.Errorf("could not exec income insert statement: %v", err)
+		return 0, fmt.Errorf("could not commit income insert transaction: %v", err)
 	}
 
 	return id, nil
 }
 
-// UpdateIncome updates an income on the incomes db table
+// InsertIncomes inserts several incomes on the incomes db table in a single transaction.
+// If any insert fails the transaction is rolled back and a repository.BatchItemError is returned.
+func (e DB) InsertIncomes(ctx context.Context, incomes []models.IncomeTable) ([]int64, error) {
+
+	tx, err := e.database.BeginTx(ctx, nil)
+	if err != nil {
+		return []int64{}, fmt.Errorf("could not begin incomes insert transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]int64, 0, len(incomes))
+	for idx, inc := range incomes {
+		id, err := insertIncome(ctx, tx, inc)
+		if err != nil {
+			return []int64{}, repository.BatchItemError{Index: idx, Err: err}
+		}
+		ids = append(ids, id)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return []int64{}, fmt.Errorf("could not commit incomes insert transaction: %v", err)
+	}
+
+	return ids, nil
+}
+
+// UpdateIncome updates an income on the incomes db table.
+// Its tags are replaced by the ones of the income in the same transaction.
 func (e DB) UpdateIncome(ctx context.Context, inc models.IncomeTable) (int64, error) {
 
+	tx, err := e.database.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not begin income update transaction: %v", err)
+	}
+	defer tx.Rollback()
+
 	updateStmt := fmt.Sprintf(`UPDATE %s SET 
-	(value, date, description, category_id, card_id) =
-	($1, $2, $3, $4, $5) WHERE id = $6`, incomesTable)
+	(value, date, description, category_id, card_id, currency) =
+	($1, $2, $3, $4, $5, COALESCE(NULLIF($8, ''), (SELECT currency FROM cards WHERE id = $5)))
+	WHERE id = $6 AND user_id = $7`, incomesTable)
 
-	result, err := e.database.ExecContext(ctx,
+	result, err := tx.ExecContext(ctx,
 		updateStmt,
 		inc.Value,
 		inc.Date,
@@ -75,6 +109,8 @@ func (e DB) UpdateIncome(ctx context.Context, inc models.IncomeTable) (int64, er
 		inc.CategoryID,
 		inc.CardID,
 		inc.ID,
+		inc.UserID,
+		inc.Currency,
 	)
 	if err != nil {
 		return 0, fmt.Errorf("could not exec income update statement: %v", err)
@@ -89,18 +125,28 @@ func (e DB) UpdateIncome(ctx context.Context, inc models.IncomeTable) (int64, er
 		return 0, ErrNoRowsAffectedOnUpdate
 	}
 
+	err = database.IncomeTagLinks.SetTags(ctx, tx, inc.UserID, inc.ID, inc.Tags)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, fmt.Errorf("could not commit income update transaction: %v", err)
+	}
+
 	return inc.ID, nil
 }
 
 // GetIncomeByID gets an income from the incomes db table by id
-func (e DB) GetIncomeByID(ctx context.Context, id int64) (models.IncomeView, error) {
+func (e DB) GetIncomeByID(ctx context.Context, userID int64, id int64) (models.IncomeView, error) {
 
 	selectStmt := fmt.Sprintf(`SELECT 
-	value, date, description, category_id, 
+	value, currency, date, description, category_id, 
 	category_name, card_id, card_name
-	FROM %s WHERE id = $1`, incomesView)
+	FROM %s WHERE id = $1 AND user_id = $2`, incomesView)
 
-	row := e.database.QueryRowContext(ctx, selectStmt, id)
+	row := e.database.QueryRowContext(ctx, selectStmt, id, userID)
 	if row.Err() != nil {
 		return models.IncomeView{}, fmt.Errorf("could not query select incomes view by id statement: %v", row.Err())
 	}
@@ -108,6 +154,7 @@ func (e DB) GetIncomeByID(ctx context.Context, id int64) (models.IncomeView, err
 	var inc models.IncomeView
 	err := row.Scan(
 		&inc.Value,
+		&inc.Currency,
 		&inc.Date,
 		&inc.Description,
 		&inc.CategoryID,
@@ -115,28 +162,38 @@ func (e DB) GetIncomeByID(ctx context.Context, id int64) (models.IncomeView, err
 		&inc.CardID,
 		&inc.Card,
 	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.IncomeView{}, repository.ErrNotFound
+	}
 	if err != nil {
 		return models.IncomeView{}, fmt.Errorf("could not scan income fields in get income by id: %v", err)
 	}
 
 	inc.ID = id
+	inc.UserID = userID
 
-	return inc, nil
+	incomes, err := e.withTags(ctx, []models.IncomeView{inc})
+	if err != nil {
+		return models.IncomeView{}, err
+	}
+
+	return incomes[0], nil
 }
 
 // GetIncomesByDates gets incomes from the incomes db table that matches the dates' range provided
 func (e DB) GetIncomesByDates(
 	ctx context.Context,
+	userID int64,
 	minDate time.Time,
 	maxDate time.Time,
 ) ([]models.IncomeView, error) {
 
 	selectStmt := fmt.Sprintf(`SELECT 
-	value, date, description, category_id,
+	id, value, currency, date, description, category_id,
 	category_name, card_id, card_name
-	FROM %s WHERE date BETWEEN $1 AND $2`, incomesView)
+	FROM %s WHERE user_id = $1 AND date BETWEEN $2 AND $3`, incomesView)
 
-	rows, err := e.database.QueryContext(ctx, selectStmt, minDate, maxDate)
+	rows, err := e.database.QueryContext(ctx, selectStmt, userID, minDate, maxDate)
 	if err != nil {
 		return []models.IncomeView{}, fmt.Errorf("could not query select incomes view by dates statement: %v", err)
 	}
@@ -148,7 +205,9 @@ func (e DB) GetIncomesByDates(
 
 	for rows.Next() {
 		err := rows.Scan(
+			&inc.ID,
 			&inc.Value,
+			&inc.Currency,
 			&inc.Date,
 			&inc.Description,
 			&inc.CategoryID,
@@ -160,6 +219,7 @@ func (e DB) GetIncomesByDates(
 			return []models.IncomeView{}, fmt.Errorf("could not scan income fields in get incomes by dates: %v", err)
 		}
 
+		inc.UserID = userID
 		incomes = append(incomes, inc)
 	}
 
@@ -169,18 +229,18 @@ func (e DB) GetIncomesByDates(
 			fmt.Errorf("found error after scanning all incomes fields in get incomes by dates: %v", err)
 	}
 
-	return incomes, nil
+	return e.withTags(ctx, incomes)
 }
 
 // GetIncomesByCategory gets incomes from the incomes db table that matches the category provided
-func (e DB) GetIncomesByCategory(ctx context.Context, category string) ([]models.IncomeView, error) {
+func (e DB) GetIncomesByCategory(ctx context.Context, userID int64, category string) ([]models.IncomeView, error) {
 
 	selectStmt := fmt.Sprintf(`SELECT 
-	value, date, description, category_id, 
+	id, value, currency, date, description, category_id, 
 	category_name, card_id, card_name
-	FROM %s WHERE category_name = $1`, incomesView)
+	FROM %s WHERE user_id = $1 AND category_name = $2`, incomesView)
 
-	rows, err := e.database.QueryContext(ctx, selectStmt, category)
+	rows, err := e.database.QueryContext(ctx, selectStmt, userID, category)
 	if err != nil {
 		return []models.IncomeView{}, fmt.Errorf("could not query select incomes view by category statement: %v", err)
 	}
@@ -192,7 +252,9 @@ func (e DB) GetIncomesByCategory(ctx context.Context, category string) ([]models
 
 	for rows.Next() {
 		err := rows.Scan(
+			&inc.ID,
 			&inc.Value,
+			&inc.Currency,
 			&inc.Date,
 			&inc.Description,
 			&inc.CategoryID,
@@ -204,6 +266,7 @@ func (e DB) GetIncomesByCategory(ctx context.Context, category string) ([]models
 			return []models.IncomeView{}, fmt.Errorf("could not scan income fields in get incomes by category: %v", err)
 		}
 
+		inc.UserID = userID
 		incomes = append(incomes, inc)
 	}
 
@@ -213,18 +276,18 @@ func (e DB) GetIncomesByCategory(ctx context.Context, category string) ([]models
 			fmt.Errorf("found error after scanning all incomes fields in get incomes by category: %v", err)
 	}
 
-	return incomes, nil
+	return e.withTags(ctx, incomes)
 }
 
 // GetIncomesByCard gets incomes from the incomes db table that matches the card provided
-func (e DB) GetIncomesByCard(ctx context.Context, card string) ([]models.IncomeView, error) {
+func (e DB) GetIncomesByCard(ctx context.Context, userID int64, card string) ([]models.IncomeView, error) {
 
 	selectStmt := fmt.Sprintf(`SELECT 
-	value, date, description, category_id, 
+	id, value, currency, date, description, category_id, 
 	category_name, card_id, card_name
-	FROM %s WHERE card_name = $1`, incomesView)
+	FROM %s WHERE user_id = $1 AND card_name = $2`, incomesView)
 
-	rows, err := e.database.QueryContext(ctx, selectStmt, card)
+	rows, err := e.database.QueryContext(ctx, selectStmt, userID, card)
 	if err != nil {
 		return []models.IncomeView{}, fmt.Errorf("could not query select incomes by card statement: %v", err)
 	}
@@ -236,7 +299,9 @@ func (e DB) GetIncomesByCard(ctx context.Context, card string) ([]models.IncomeV
 
 	for rows.Next() {
 		err := rows.Scan(
+			&inc.ID,
 			&inc.Value,
+			&inc.Currency,
 			&inc.Date,
 			&inc.Description,
 			&inc.CategoryID,
@@ -248,6 +313,7 @@ func (e DB) GetIncomesByCard(ctx context.Context, card string) ([]models.IncomeV
 			return []models.IncomeView{}, fmt.Errorf("could not scan income fields in get incomes by card: %v", err)
 		}
 
+		inc.UserID = userID
 		incomes = append(incomes, inc)
 	}
 
@@ -256,16 +322,195 @@ func (e DB) GetIncomesByCard(ctx context.Context, card string) ([]models.IncomeV
 		return []models.IncomeView{}, fmt.Errorf("found error after scanning all incomes fields in get incomes by card: %v", err)
 	}
 
-	return incomes, nil
+	return e.withTags(ctx, incomes)
+}
+
+// GetIncomesByCardAndValue gets incomes from the incomes db table of the card with the value provided within the dates' range provided
+func (e DB) GetIncomesByCardAndValue(
+	ctx context.Context,
+	userID int64,
+	cardID int64,
+	value models.Money,
+	minDate time.Time,
+	maxDate time.Time,
+) ([]models.IncomeView, error) {
+
+	selectStmt := fmt.Sprintf(`SELECT 
+	id, value, currency, date, description, category_id,
+	category_name, card_id, card_name
+	FROM %s WHERE user_id = $1 AND card_id = $2 AND value = $3 AND date BETWEEN $4 AND $5`, incomesView)
+
+	rows, err := e.database.QueryContext(ctx, selectStmt, userID, cardID, value, minDate, maxDate)
+	if err != nil {
+		return []models.IncomeView{}, fmt.Errorf("could not query select incomes view by card and value statement: %v", err)
+	}
+	defer rows.Close()
+
+	var incomes []models.IncomeView
+
+	var inc models.IncomeView
+
+	for rows.Next() {
+		err := rows.Scan(
+			&inc.ID,
+			&inc.Value,
+			&inc.Currency,
+			&inc.Date,
+			&inc.Description,
+			&inc.CategoryID,
+			&inc.Category,
+			&inc.CardID,
+			&inc.Card,
+		)
+		if err != nil {
+			return []models.IncomeView{}, fmt.Errorf("could not scan income fields in get incomes by card and value: %v", err)
+		}
+
+		inc.UserID = userID
+		incomes = append(incomes, inc)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.IncomeView{},
+			fmt.Errorf("found error after scanning all incomes fields in get incomes by card and value: %v", err)
+	}
+
+	return e.withTags(ctx, incomes)
+}
+
+// GetIncomeByExternalReference gets an income from the incomes db table by the bank reference it was imported with
+func (e DB) GetIncomeByExternalReference(ctx context.Context, userID int64, cardID int64, reference string) (models.IncomeTable, error) {
+
+	selectStmt := fmt.Sprintf(`SELECT 
+	id, value, currency, date, description, category_id, card_id, external_reference
+	FROM %s WHERE user_id = $1 AND card_id = $2 AND external_reference = $3`, incomesTable)
+
+	row := e.database.QueryRowContext(ctx, selectStmt, userID, cardID, reference)
+	if row.Err() != nil {
+		return models.IncomeTable{}, fmt.Errorf("could not query select incomes by external reference statement: %v", row.Err())
+	}
+
+	var inc models.IncomeTable
+	err := row.Scan(
+		&inc.ID,
+		&inc.Value,
+		&inc.Currency,
+		&inc.Date,
+		&inc.Description,
+		&inc.CategoryID,
+		&inc.CardID,
+		&inc.ExternalReference,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.IncomeTable{}, repository.ErrNotFound
+	}
+	if err != nil {
+		return models.IncomeTable{}, fmt.Errorf("could not scan income fields in get income by external reference: %v", err)
+	}
+
+	inc.UserID = userID
+
+	return inc, nil
+}
+
+// SearchIncomes gets a page of the incomes from the incomes view that match the filter, in its sort order
+func (e DB) SearchIncomes(ctx context.Context, userID int64, filter models.SearchFilter) ([]models.IncomeView, error) {
+
+	clauses, args := database.SearchClauses(userID, filter, "", database.IncomeTagLinks)
+
+	selectStmt := fmt.Sprintf(`SELECT 
+	id, value, currency, date, description, category_id,
+	category_name, card_id, card_name
+	FROM %s %s`, incomesView, clauses)
+
+	rows, err := e.database.QueryContext(ctx, selectStmt, args...)
+	if err != nil {
+		return []models.IncomeView{}, fmt.Errorf("could not query search incomes view statement: %v", err)
+	}
+	defer rows.Close()
+
+	incomes := []models.IncomeView{}
+
+	var inc models.IncomeView
+
+	for rows.Next() {
+		err := rows.Scan(
+			&inc.ID,
+			&inc.Value,
+			&inc.Currency,
+			&inc.Date,
+			&inc.Description,
+			&inc.CategoryID,
+			&inc.Category,
+			&inc.CardID,
+			&inc.Card,
+		)
+		if err != nil {
+			return []models.IncomeView{}, fmt.Errorf("could not scan income fields in search incomes: %v", err)
+		}
+
+		inc.UserID = userID
+		incomes = append(incomes, inc)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.IncomeView{},
+			fmt.Errorf("found error after scanning all incomes fields in search incomes: %v", err)
+	}
+
+	return e.withTags(ctx, incomes)
+}
+
+// GetIncomeDailyTotals sums the incomes of the user on the incomes view that are in the dates' range provided
+// by day, category, card and currency
+func (e DB) GetIncomeDailyTotals(ctx context.Context, userID int64, minDate time.Time, maxDate time.Time) ([]models.DailyTotal, error) {
+
+	selectStmt := fmt.Sprintf(`SELECT 
+	date::DATE AS day, category_name, card_name, currency, SUM(value), COUNT(*)
+	FROM %s WHERE user_id = $1 AND date BETWEEN $2 AND $3
+	GROUP BY day, category_name, card_name, currency
+	ORDER BY day, category_name, card_name, currency`, incomesView)
+
+	rows, err := e.database.QueryContext(ctx, selectStmt, userID, minDate, maxDate)
+	if err != nil {
+		return []models.DailyTotal{}, fmt.Errorf("could not query select income daily totals statement: %v", err)
+	}
+	defer rows.Close()
+
+	totals := []models.DailyTotal{}
+	for rows.Next() {
+		var total models.DailyTotal
+		err := rows.Scan(
+			&total.Day,
+			&total.Category,
+			&total.Card,
+			&total.Currency,
+			&total.Value,
+			&total.Count,
+		)
+		if err != nil {
+			return []models.DailyTotal{}, fmt.Errorf("could not scan income daily total fields: %v", err)
+		}
+		totals = append(totals, total)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.DailyTotal{}, fmt.Errorf("found error after scanning all income daily total fields: %v", err)
+	}
+
+	return totals, nil
 }
 
 // DeleteIncome deletes an income from the incomes db table
-func (e DB) DeleteIncome(ctx context.Context, id int64) error {
+func (e DB) DeleteIncome(ctx context.Context, userID int64, id int64) error {
 
 	deleteStmt := fmt.Sprintf(`DELETE FROM %s 
-	WHERE id = $1`, incomesTable)
+	WHERE id = $1 AND user_id = $2`, incomesTable)
 
-	result, err := e.database.ExecContext(ctx, deleteStmt, id)
+	result, err := e.database.ExecContext(ctx, deleteStmt, id, userID)
 	if err != nil {
 		return fmt.Errorf("could not exec income delete statement: %v", err)
 	}
@@ -281,3 +526,59 @@ func (e DB) DeleteIncome(ctx context.Context, id int64) error {
 
 	return nil
 }
+
+func insertIncome(ctx context.Context, querier database.Querier, inc models.IncomeTable) (int64, error) {
+
+	insertStmt := fmt.Sprintf(`INSERT INTO %s 
+	(value, date, description, category_id, card_id, user_id, external_reference, currency)
+	VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), COALESCE(NULLIF($8, ''), (SELECT currency FROM cards WHERE id = $5)))
+	RETURNING id`, incomesTable)
+
+	var id int64
+
+	err := querier.QueryRowContext(
+		ctx,
+		insertStmt,
+		inc.Value,
+		inc.Date,
+		inc.Description,
+		inc.CategoryID,
+		inc.CardID,
+		inc.UserID,
+		inc.ExternalReference,
+		inc.Currency,
+	).Scan(&id)
+	if database.IsUniqueViolation(err) {
+		return 0, ErrExternalReferenceExists
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not exec income insert statement: %v", err)
+	}
+
+	err = database.IncomeTagLinks.SetTags(ctx, querier, inc.UserID, id, inc.Tags)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// withTags fills the tags of the incomes
+func (e DB) withTags(ctx context.Context, incomes []models.IncomeView) ([]models.IncomeView, error) {
+
+	ids := make([]int64, 0, len(incomes))
+	for _, inc := range incomes {
+		ids = append(ids, inc.ID)
+	}
+
+	tags, err := database.IncomeTagLinks.GetTags(ctx, e.database, ids)
+	if err != nil {
+		return []models.IncomeView{}, err
+	}
+
+	for idx := range incomes {
+		incomes[idx].Tags = tags[incomes[idx].ID]
+	}
+
+	return incomes, nil
+}
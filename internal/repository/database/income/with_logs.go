@@ -24,32 +24,56 @@ func (i DBWithLogs) InsertIncome(ctx context.Context, income models.IncomeTable)
 	log.Printf("income: %+v", income)
 	return i.repo.InsertIncome(ctx, income)
 }
+func (i DBWithLogs) InsertIncomes(ctx context.Context, incomes []models.IncomeTable) ([]int64, error) {
+	log.Printf("incomes: %+v", incomes)
+	return i.repo.InsertIncomes(ctx, incomes)
+}
 func (i DBWithLogs) UpdateIncome(ctx context.Context, income models.IncomeTable) (int64, error) {
 	log.Printf("income: %+v", income)
 	return i.repo.UpdateIncome(ctx, income)
 }
 
-func (i DBWithLogs) GetIncomeByID(ctx context.Context, id int64) (models.IncomeView, error) {
-	log.Printf("income id: %+v", id)
-	return i.repo.GetIncomeByID(ctx, id)
+func (i DBWithLogs) GetIncomeByID(ctx context.Context, userID int64, id int64) (models.IncomeView, error) {
+	log.Printf("income user id: %+v | id: %+v", userID, id)
+	return i.repo.GetIncomeByID(ctx, userID, id)
+}
+
+func (i DBWithLogs) GetIncomesByDates(ctx context.Context, userID int64, minDate time.Time, maxDate time.Time) ([]models.IncomeView, error) {
+	log.Printf("income user id: %+v | dates: min_date: %+v | max_date: %+v", userID, minDate, maxDate)
+	return i.repo.GetIncomesByDates(ctx, userID, minDate, maxDate)
+}
+
+func (i DBWithLogs) GetIncomesByCategory(ctx context.Context, userID int64, category string) ([]models.IncomeView, error) {
+	log.Printf("income user id: %+v | category: %+v", userID, category)
+	return i.repo.GetIncomesByCategory(ctx, userID, category)
+}
+
+func (i DBWithLogs) GetIncomesByCard(ctx context.Context, userID int64, card string) ([]models.IncomeView, error) {
+	log.Printf("income user id: %+v | card: %+v", userID, card)
+	return i.repo.GetIncomesByCard(ctx, userID, card)
+}
+
+func (i DBWithLogs) GetIncomesByCardAndValue(ctx context.Context, userID int64, cardID int64, value models.Money, minDate time.Time, maxDate time.Time) ([]models.IncomeView, error) {
+	log.Printf("income user id: %+v | card id: %+v | value: %+v | dates: min_date: %+v | max_date: %+v", userID, cardID, value, minDate, maxDate)
+	return i.repo.GetIncomesByCardAndValue(ctx, userID, cardID, value, minDate, maxDate)
 }
 
-func (i DBWithLogs) GetIncomesByDates(ctx context.Context, minDate time.Time, maxDate time.Time) ([]models.IncomeView, error) {
-	log.Printf("income dates: min_date: %+v | max_date: %+v", minDate, maxDate)
-	return i.repo.GetIncomesByDates(ctx, minDate, maxDate)
+func (i DBWithLogs) GetIncomeByExternalReference(ctx context.Context, userID int64, cardID int64, reference string) (models.IncomeTable, error) {
+	log.Printf("income user id: %+v | card id: %+v | external reference: %+v", userID, cardID, reference)
+	return i.repo.GetIncomeByExternalReference(ctx, userID, cardID, reference)
 }
 
-func (i DBWithLogs) GetIncomesByCategory(ctx context.Context, category string) ([]models.IncomeView, error) {
-	log.Printf("income category: %+v", category)
-	return i.repo.GetIncomesByCategory(ctx, category)
+func (i DBWithLogs) SearchIncomes(ctx context.Context, userID int64, filter models.SearchFilter) ([]models.IncomeView, error) {
+	log.Printf("income user id: %+v | filter: %+v", userID, filter)
+	return i.repo.SearchIncomes(ctx, userID, filter)
 }
 
-func (i DBWithLogs) GetIncomesByCard(ctx context.Context, card string) ([]models.IncomeView, error) {
-	log.Printf("income card: %+v", card)
-	return i.repo.GetIncomesByCard(ctx, card)
+func (i DBWithLogs) GetIncomeDailyTotals(ctx context.Context, userID int64, minDate time.Time, maxDate time.Time) ([]models.DailyTotal, error) {
+	log.Printf("income user id: %+v | dates: min_date: %+v | max_date: %+v", userID, minDate, maxDate)
+	return i.repo.GetIncomeDailyTotals(ctx, userID, minDate, maxDate)
 }
 
-func (i DBWithLogs) DeleteIncome(ctx context.Context, id int64) error {
-	log.Printf("income id: %+v", id)
-	return i.repo.DeleteIncome(ctx, id)
+func (i DBWithLogs) DeleteIncome(ctx context.Context, userID int64, id int64) error {
+	log.Printf("income user id: %+v | id: %+v", userID, id)
+	return i.repo.DeleteIncome(ctx, userID, id)
 }
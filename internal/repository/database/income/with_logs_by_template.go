@@ -20,13 +20,14 @@ type IncomeRepoWithLogs struct {
 }
 
 // DeleteIncome implements repository.IncomeRepo
-func (d IncomeRepoWithLogs) DeleteIncome(ctx context.Context, i1 int64) (err error) {
+func (d IncomeRepoWithLogs) DeleteIncome(ctx context.Context, i1 int64, i2 int64) (err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
-		"i1":  i1}).Logger()
+		"i1":  i1,
+		"i2":  i2}).Logger()
 
 	defer func() {
 		if err != nil {
@@ -37,39 +38,91 @@ func (d IncomeRepoWithLogs) DeleteIncome(ctx context.Context, i1 int64) (err err
 				"err": err}).Str("decorator", "IncomeRepoWithLogs").Str("method", "DeleteIncome").Msg("Finish")
 		}
 	}()
-	return d.base.DeleteIncome(ctx, i1)
+	return d.base.DeleteIncome(ctx, i1, i2)
+}
+
+// GetIncomeByExternalReference implements repository.IncomeRepo
+func (d IncomeRepoWithLogs) GetIncomeByExternalReference(ctx context.Context, i1 int64, i2 int64, s1 string) (i3 models.IncomeTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2,
+		"s1":  s1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i3":  i3,
+				"err": err}).Err(err).Str("decorator", "IncomeRepoWithLogs").Str("method", "GetIncomeByExternalReference").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i3":  i3,
+				"err": err}).Str("decorator", "IncomeRepoWithLogs").Str("method", "GetIncomeByExternalReference").Msg("Finish")
+		}
+	}()
+	return d.base.GetIncomeByExternalReference(ctx, i1, i2, s1)
 }
 
 // GetIncomeByID implements repository.IncomeRepo
-func (d IncomeRepoWithLogs) GetIncomeByID(ctx context.Context, i1 int64) (i2 models.IncomeView, err error) {
+func (d IncomeRepoWithLogs) GetIncomeByID(ctx context.Context, i1 int64, i2 int64) (i3 models.IncomeView, err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
-		"i1":  i1}).Logger()
+		"i1":  i1,
+		"i2":  i2}).Logger()
 
 	defer func() {
 		if err != nil {
 			logger.Error().Fields(map[string]interface{}{
-				"i2":  i2,
+				"i3":  i3,
 				"err": err}).Err(err).Str("decorator", "IncomeRepoWithLogs").Str("method", "GetIncomeByID").Msg("Error detected")
 		} else {
 			logger.Debug().Fields(map[string]interface{}{
-				"i2":  i2,
+				"i3":  i3,
 				"err": err}).Str("decorator", "IncomeRepoWithLogs").Str("method", "GetIncomeByID").Msg("Finish")
 		}
 	}()
-	return d.base.GetIncomeByID(ctx, i1)
+	return d.base.GetIncomeByID(ctx, i1, i2)
+}
+
+// GetIncomeDailyTotals implements repository.IncomeRepo
+func (d IncomeRepoWithLogs) GetIncomeDailyTotals(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (da1 []models.DailyTotal, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"t1":  t1,
+		"t2":  t2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"da1": da1,
+				"err": err}).Err(err).Str("decorator", "IncomeRepoWithLogs").Str("method", "GetIncomeDailyTotals").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"da1": da1,
+				"err": err}).Str("decorator", "IncomeRepoWithLogs").Str("method", "GetIncomeDailyTotals").Msg("Finish")
+		}
+	}()
+	return d.base.GetIncomeDailyTotals(ctx, i1, t1, t2)
 }
 
 // GetIncomesByCard implements repository.IncomeRepo
-func (d IncomeRepoWithLogs) GetIncomesByCard(ctx context.Context, s1 string) (ia1 []models.IncomeView, err error) {
+func (d IncomeRepoWithLogs) GetIncomesByCard(ctx context.Context, i1 int64, s1 string) (ia1 []models.IncomeView, err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
+		"i1":  i1,
 		"s1":  s1}).Logger()
 
 	defer func() {
@@ -83,16 +136,44 @@ func (d IncomeRepoWithLogs) GetIncomesByCard(ctx context.Context, s1 string) (ia
 				"err": err}).Str("decorator", "IncomeRepoWithLogs").Str("method", "GetIncomesByCard").Msg("Finish")
 		}
 	}()
-	return d.base.GetIncomesByCard(ctx, s1)
+	return d.base.GetIncomesByCard(ctx, i1, s1)
+}
+
+// GetIncomesByCardAndValue implements repository.IncomeRepo
+func (d IncomeRepoWithLogs) GetIncomesByCardAndValue(ctx context.Context, i1 int64, i2 int64, m1 models.Money, t1 time.Time, t2 time.Time) (ia1 []models.IncomeView, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2,
+		"m1":  m1,
+		"t1":  t1,
+		"t2":  t2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ia1": ia1,
+				"err": err}).Err(err).Str("decorator", "IncomeRepoWithLogs").Str("method", "GetIncomesByCardAndValue").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ia1": ia1,
+				"err": err}).Str("decorator", "IncomeRepoWithLogs").Str("method", "GetIncomesByCardAndValue").Msg("Finish")
+		}
+	}()
+	return d.base.GetIncomesByCardAndValue(ctx, i1, i2, m1, t1, t2)
 }
 
 // GetIncomesByCategory implements repository.IncomeRepo
-func (d IncomeRepoWithLogs) GetIncomesByCategory(ctx context.Context, s1 string) (ia1 []models.IncomeView, err error) {
+func (d IncomeRepoWithLogs) GetIncomesByCategory(ctx context.Context, i1 int64, s1 string) (ia1 []models.IncomeView, err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
+		"i1":  i1,
 		"s1":  s1}).Logger()
 
 	defer func() {
@@ -106,16 +187,17 @@ func (d IncomeRepoWithLogs) GetIncomesByCategory(ctx context.Context, s1 string)
 				"err": err}).Str("decorator", "IncomeRepoWithLogs").Str("method", "GetIncomesByCategory").Msg("Finish")
 		}
 	}()
-	return d.base.GetIncomesByCategory(ctx, s1)
+	return d.base.GetIncomesByCategory(ctx, i1, s1)
 }
 
 // GetIncomesByDates implements repository.IncomeRepo
-func (d IncomeRepoWithLogs) GetIncomesByDates(ctx context.Context, t1 time.Time, t2 time.Time) (ia1 []models.IncomeView, err error) {
+func (d IncomeRepoWithLogs) GetIncomesByDates(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (ia1 []models.IncomeView, err error) {
 
 	nl := zerolog.Ctx(ctx)
 
 	logger := nl.With().Fields(map[string]interface{}{
 		"ctx": ctx,
+		"i1":  i1,
 		"t1":  t1,
 		"t2":  t2}).Logger()
 
@@ -130,7 +212,7 @@ func (d IncomeRepoWithLogs) GetIncomesByDates(ctx context.Context, t1 time.Time,
 				"err": err}).Str("decorator", "IncomeRepoWithLogs").Str("method", "GetIncomesByDates").Msg("Finish")
 		}
 	}()
-	return d.base.GetIncomesByDates(ctx, t1, t2)
+	return d.base.GetIncomesByDates(ctx, i1, t1, t2)
 }
 
 // InsertIncome implements repository.IncomeRepo
@@ -156,6 +238,53 @@ func (d IncomeRepoWithLogs) InsertIncome(ctx context.Context, i1 models.IncomeTa
 	return d.base.InsertIncome(ctx, i1)
 }
 
+// InsertIncomes implements repository.IncomeRepo
+func (d IncomeRepoWithLogs) InsertIncomes(ctx context.Context, ia1 []models.IncomeTable) (ia2 []int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"ia1": ia1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ia2": ia2,
+				"err": err}).Err(err).Str("decorator", "IncomeRepoWithLogs").Str("method", "InsertIncomes").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ia2": ia2,
+				"err": err}).Str("decorator", "IncomeRepoWithLogs").Str("method", "InsertIncomes").Msg("Finish")
+		}
+	}()
+	return d.base.InsertIncomes(ctx, ia1)
+}
+
+// SearchIncomes implements repository.IncomeRepo
+func (d IncomeRepoWithLogs) SearchIncomes(ctx context.Context, i1 int64, s1 models.SearchFilter) (ia1 []models.IncomeView, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"s1":  s1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ia1": ia1,
+				"err": err}).Err(err).Str("decorator", "IncomeRepoWithLogs").Str("method", "SearchIncomes").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ia1": ia1,
+				"err": err}).Str("decorator", "IncomeRepoWithLogs").Str("method", "SearchIncomes").Msg("Finish")
+		}
+	}()
+	return d.base.SearchIncomes(ctx, i1, s1)
+}
+
 // UpdateIncome implements repository.IncomeRepo
 func (d IncomeRepoWithLogs) UpdateIncome(ctx context.Context, i1 models.IncomeTable) (i2 int64, err error) {
 
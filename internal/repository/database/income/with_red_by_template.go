@@ -23,7 +23,7 @@ type IncomeRepoWithRED struct {
 }
 
 // DeleteIncome implements repository.IncomeRepo
-func (d IncomeRepoWithRED) DeleteIncome(ctx context.Context, i1 int64) (err error) {
+func (d IncomeRepoWithRED) DeleteIncome(ctx context.Context, i1 int64, i2 int64) (err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -43,11 +43,35 @@ func (d IncomeRepoWithRED) DeleteIncome(ctx context.Context, i1 int64) (err erro
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.DeleteIncome(ctx, i1)
+	return d.base.DeleteIncome(ctx, i1, i2)
+}
+
+// GetIncomeByExternalReference implements repository.IncomeRepo
+func (d IncomeRepoWithRED) GetIncomeByExternalReference(ctx context.Context, i1 int64, i2 int64, s1 string) (i3 models.IncomeTable, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetIncomeByExternalReference",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetIncomeByExternalReference(ctx, i1, i2, s1)
 }
 
 // GetIncomeByID implements repository.IncomeRepo
-func (d IncomeRepoWithRED) GetIncomeByID(ctx context.Context, i1 int64) (i2 models.IncomeView, err error) {
+func (d IncomeRepoWithRED) GetIncomeByID(ctx context.Context, i1 int64, i2 int64) (i3 models.IncomeView, err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -67,11 +91,35 @@ func (d IncomeRepoWithRED) GetIncomeByID(ctx context.Context, i1 int64) (i2 mode
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.GetIncomeByID(ctx, i1)
+	return d.base.GetIncomeByID(ctx, i1, i2)
+}
+
+// GetIncomeDailyTotals implements repository.IncomeRepo
+func (d IncomeRepoWithRED) GetIncomeDailyTotals(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (da1 []models.DailyTotal, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetIncomeDailyTotals",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetIncomeDailyTotals(ctx, i1, t1, t2)
 }
 
 // GetIncomesByCard implements repository.IncomeRepo
-func (d IncomeRepoWithRED) GetIncomesByCard(ctx context.Context, s1 string) (ia1 []models.IncomeView, err error) {
+func (d IncomeRepoWithRED) GetIncomesByCard(ctx context.Context, i1 int64, s1 string) (ia1 []models.IncomeView, err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -91,11 +139,35 @@ func (d IncomeRepoWithRED) GetIncomesByCard(ctx context.Context, s1 string) (ia1
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.GetIncomesByCard(ctx, s1)
+	return d.base.GetIncomesByCard(ctx, i1, s1)
+}
+
+// GetIncomesByCardAndValue implements repository.IncomeRepo
+func (d IncomeRepoWithRED) GetIncomesByCardAndValue(ctx context.Context, i1 int64, i2 int64, m1 models.Money, t1 time.Time, t2 time.Time) (ia1 []models.IncomeView, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetIncomesByCardAndValue",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetIncomesByCardAndValue(ctx, i1, i2, m1, t1, t2)
 }
 
 // GetIncomesByCategory implements repository.IncomeRepo
-func (d IncomeRepoWithRED) GetIncomesByCategory(ctx context.Context, s1 string) (ia1 []models.IncomeView, err error) {
+func (d IncomeRepoWithRED) GetIncomesByCategory(ctx context.Context, i1 int64, s1 string) (ia1 []models.IncomeView, err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -115,11 +187,11 @@ func (d IncomeRepoWithRED) GetIncomesByCategory(ctx context.Context, s1 string)
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.GetIncomesByCategory(ctx, s1)
+	return d.base.GetIncomesByCategory(ctx, i1, s1)
 }
 
 // GetIncomesByDates implements repository.IncomeRepo
-func (d IncomeRepoWithRED) GetIncomesByDates(ctx context.Context, t1 time.Time, t2 time.Time) (ia1 []models.IncomeView, err error) {
+func (d IncomeRepoWithRED) GetIncomesByDates(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (ia1 []models.IncomeView, err error) {
 	since := time.Now()
 	defer func() {
 		status := "ok"
@@ -139,7 +211,7 @@ func (d IncomeRepoWithRED) GetIncomesByDates(ctx context.Context, t1 time.Time,
 
 		observer.Observe(float64(time.Since(since).Milliseconds()))
 	}()
-	return d.base.GetIncomesByDates(ctx, t1, t2)
+	return d.base.GetIncomesByDates(ctx, i1, t1, t2)
 }
 
 // InsertIncome implements repository.IncomeRepo
@@ -166,6 +238,54 @@ func (d IncomeRepoWithRED) InsertIncome(ctx context.Context, i1 models.IncomeTab
 	return d.base.InsertIncome(ctx, i1)
 }
 
+// InsertIncomes implements repository.IncomeRepo
+func (d IncomeRepoWithRED) InsertIncomes(ctx context.Context, ia1 []models.IncomeTable) (ia2 []int64, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "InsertIncomes",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.InsertIncomes(ctx, ia1)
+}
+
+// SearchIncomes implements repository.IncomeRepo
+func (d IncomeRepoWithRED) SearchIncomes(ctx context.Context, i1 int64, s1 models.SearchFilter) (ia1 []models.IncomeView, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "SearchIncomes",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.SearchIncomes(ctx, i1, s1)
+}
+
 // UpdateIncome implements repository.IncomeRepo
 func (d IncomeRepoWithRED) UpdateIncome(ctx context.Context, i1 models.IncomeTable) (i2 int64, err error) {
 	since := time.Now()
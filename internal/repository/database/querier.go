@@ -0,0 +1,34 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Querier is implemented by both *sql.DB and *sql.Tx,
+// so the same statement can run standalone or as part of a transaction
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// MoveRows points the rows of a table that reference a category in the column to the target category,
+// returning how many rows moved
+func MoveRows(ctx context.Context, querier Querier, table string, column string, id int64, targetID int64) (int64, error) {
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET %s = $2 WHERE %s = $1", table, column, column)
+
+	result, err := querier.ExecContext(ctx, updateStmt, id, targetID)
+	if err != nil {
+		return 0, fmt.Errorf("could not move %s: %v", table, err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not get number of rows affected in exec %s move statement: %v", table, err)
+	}
+
+	return numRowsAffected, nil
+}
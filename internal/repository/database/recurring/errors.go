@@ -0,0 +1,13 @@
+package recurring
+
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+var (
+	ErrNoRowsAffectedOnDelete  = fmt.Errorf("there were no rows affected in exec recurring transaction delete statement: %w", repository.ErrNotFound)
+	ErrNoRowsAffectedOnUpdate  = fmt.Errorf("there were no rows affected in exec recurring transaction update statement: %w", repository.ErrNotFound)
+	ErrNoRowsAffectedOnAdvance = fmt.Errorf("there were no rows affected in exec recurring transaction advance statement: %w", repository.ErrNotFound)
+)
@@ -0,0 +1,261 @@
+package recurring
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const (
+	tableNameRecurringTransactions = "recurring_transactions"
+
+	// selectRecurringTransactionsStmt joins the recurring transactions with the names of their card and categories.
+	// The unset category is read as zero values.
+	selectRecurringTransactionsStmt = `SELECT 
+	r.id, r.value, COALESCE(r.description, ''), r.frequency, r.cron_expression, 
+	r.start_date, r.end_date, r.next_run, 
+	r.card_id, c.name, 
+	COALESCE(r.subcategory_id, 0), COALESCE(es.name, ''), 
+	COALESCE(r.income_category_id, 0), COALESCE(ic.name, ''), r.user_id
+	FROM recurring_transactions r
+	JOIN cards c ON r.card_id = c.id
+	LEFT JOIN expense_subcategories es ON r.subcategory_id = es.id
+	LEFT JOIN income_categories ic ON r.income_category_id = ic.id`
+)
+
+// DB implements the recurring transaction repository methods
+type DB struct {
+	database *sql.DB
+}
+
+// NewDB creates a new RecurringTransactionRepo
+func NewDB(database *sql.DB) DB {
+	return DB{
+		database: database,
+	}
+}
+
+// InsertRecurringTransaction inserts a recurring transaction on the recurring transactions db table
+func (r DB) InsertRecurringTransaction(ctx context.Context, recurring models.RecurringTransactionTable) (int64, error) {
+
+	insertStmt := fmt.Sprintf(`INSERT INTO %s 
+	(value, description, frequency, cron_expression, start_date, end_date, next_run, 
+	card_id, subcategory_id, income_category_id, user_id) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9::INTEGER, 0), NULLIF($10::INTEGER, 0), $11) 
+	RETURNING id`, tableNameRecurringTransactions)
+
+	var id int64
+
+	err := r.database.QueryRowContext(
+		ctx,
+		insertStmt,
+		recurring.Value,
+		recurring.Description,
+		recurring.Frequency,
+		recurring.CronExpression,
+		recurring.StartDate,
+		nullTime(recurring.EndDate),
+		recurring.NextRun,
+		recurring.CardID,
+		recurring.SubCategoryID,
+		recurring.IncomeCategoryID,
+		recurring.UserID,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("error scanning recurring transaction id: %v", err)
+	}
+
+	return id, nil
+}
+
+// UpdateRecurringTransaction updates a recurring transaction on the recurring transactions db table
+func (r DB) UpdateRecurringTransaction(ctx context.Context, recurring models.RecurringTransactionTable) (int64, error) {
+
+	updateStmt := fmt.Sprintf(`UPDATE %s SET 
+	value = $1, description = $2, frequency = $3, cron_expression = $4, 
+	start_date = $5, end_date = $6, next_run = $7, card_id = $8, 
+	subcategory_id = NULLIF($9::INTEGER, 0), income_category_id = NULLIF($10::INTEGER, 0) 
+	WHERE id = $11 AND user_id = $12`, tableNameRecurringTransactions)
+
+	result, err := r.database.ExecContext(
+		ctx,
+		updateStmt,
+		recurring.Value,
+		recurring.Description,
+		recurring.Frequency,
+		recurring.CronExpression,
+		recurring.StartDate,
+		nullTime(recurring.EndDate),
+		recurring.NextRun,
+		recurring.CardID,
+		recurring.SubCategoryID,
+		recurring.IncomeCategoryID,
+		recurring.ID,
+		recurring.UserID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error updating recurring transaction: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not get number of rows affected in exec recurring transaction update statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return 0, ErrNoRowsAffectedOnUpdate
+	}
+
+	return recurring.ID, nil
+}
+
+// GetRecurringTransactionByID gets a recurring transaction from the recurring transactions db table by id
+func (r DB) GetRecurringTransactionByID(ctx context.Context, userID int64, id int64) (models.RecurringTransactionView, error) {
+
+	selectStmt := selectRecurringTransactionsStmt + " WHERE r.id = $1 AND r.user_id = $2"
+
+	row := r.database.QueryRowContext(ctx, selectStmt, id, userID)
+
+	recurring, err := scanRecurringTransaction(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.RecurringTransactionView{}, repository.ErrNotFound
+	}
+	if err != nil {
+		return models.RecurringTransactionView{}, fmt.Errorf("error scanning recurring transaction fields: %v", err)
+	}
+
+	return recurring, nil
+}
+
+// GetRecurringTransactions gets the recurring transactions of the user
+func (r DB) GetRecurringTransactions(ctx context.Context, userID int64) ([]models.RecurringTransactionView, error) {
+
+	selectStmt := selectRecurringTransactionsStmt + " WHERE r.user_id = $1 ORDER BY r.id"
+
+	return r.queryRecurringTransactions(ctx, selectStmt, userID)
+}
+
+// GetDueRecurringTransactions gets the recurring transactions of every user that have an occurrence to materialize on or before the date
+func (r DB) GetDueRecurringTransactions(ctx context.Context, date time.Time) ([]models.RecurringTransactionView, error) {
+
+	selectStmt := selectRecurringTransactionsStmt +
+		" WHERE r.next_run <= $1 AND (r.end_date IS NULL OR r.next_run <= r.end_date) ORDER BY r.id"
+
+	return r.queryRecurringTransactions(ctx, selectStmt, date)
+}
+
+// AdvanceRecurringTransaction moves the next run of a recurring transaction, if it was not moved by someone else meanwhile
+func (r DB) AdvanceRecurringTransaction(ctx context.Context, id int64, from time.Time, to time.Time) error {
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET next_run = $1 WHERE id = $2 AND next_run = $3", tableNameRecurringTransactions)
+
+	result, err := r.database.ExecContext(ctx, updateStmt, to, id, from)
+	if err != nil {
+		return fmt.Errorf("error advancing recurring transaction: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get number of rows affected in exec recurring transaction advance statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return ErrNoRowsAffectedOnAdvance
+	}
+
+	return nil
+}
+
+// DeleteRecurringTransaction deletes a recurring transaction from the recurring transactions db table
+func (r DB) DeleteRecurringTransaction(ctx context.Context, userID int64, id int64) error {
+
+	deleteStmt := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", tableNameRecurringTransactions)
+
+	result, err := r.database.ExecContext(ctx, deleteStmt, id, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting recurring transaction by id: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get number of rows affected in exec recurring transaction delete statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return ErrNoRowsAffectedOnDelete
+	}
+
+	return nil
+}
+
+func (r DB) queryRecurringTransactions(ctx context.Context, selectStmt string, args ...interface{}) ([]models.RecurringTransactionView, error) {
+
+	rows, err := r.database.QueryContext(ctx, selectStmt, args...)
+	if err != nil {
+		return []models.RecurringTransactionView{}, fmt.Errorf("could not query select recurring transactions statement: %v", err)
+	}
+	defer rows.Close()
+
+	recurringTransactions := []models.RecurringTransactionView{}
+	for rows.Next() {
+		recurring, err := scanRecurringTransaction(rows)
+		if err != nil {
+			return []models.RecurringTransactionView{}, fmt.Errorf("could not scan recurring transaction fields: %v", err)
+		}
+		recurringTransactions = append(recurringTransactions, recurring)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.RecurringTransactionView{},
+			fmt.Errorf("found error after scanning all recurring transactions fields: %v", err)
+	}
+
+	return recurringTransactions, nil
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRecurringTransaction(row scanner) (models.RecurringTransactionView, error) {
+
+	var recurring models.RecurringTransactionView
+	var endDate sql.NullTime
+
+	err := row.Scan(
+		&recurring.ID,
+		&recurring.Value,
+		&recurring.Description,
+		&recurring.Frequency,
+		&recurring.CronExpression,
+		&recurring.StartDate,
+		&endDate,
+		&recurring.NextRun,
+		&recurring.CardID,
+		&recurring.Card,
+		&recurring.SubCategoryID,
+		&recurring.SubCategory,
+		&recurring.IncomeCategoryID,
+		&recurring.IncomeCategory,
+		&recurring.UserID,
+	)
+
+	recurring.EndDate = endDate.Time
+
+	return recurring, err
+}
+
+// nullTime stores the zero time as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  t,
+		Valid: !t.IsZero(),
+	}
+}
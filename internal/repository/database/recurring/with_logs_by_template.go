@@ -0,0 +1,193 @@
+// Code generated by gowrap. DO NOT EDIT.
+// template: ../../templates/log_template.go.tmpl
+// gowrap: http://github.com/hexdigest/gowrap
+
+package recurring
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// RecurringTransactionRepoWithLogs implements repository.RecurringTransactionRepo that is instrumented with zerolog logger
+type RecurringTransactionRepoWithLogs struct {
+	base repository.RecurringTransactionRepo
+}
+
+// AdvanceRecurringTransaction implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithLogs) AdvanceRecurringTransaction(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"t1":  t1,
+		"t2":  t2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "AdvanceRecurringTransaction").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "AdvanceRecurringTransaction").Msg("Finish")
+		}
+	}()
+	return d.base.AdvanceRecurringTransaction(ctx, i1, t1, t2)
+}
+
+// DeleteRecurringTransaction implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithLogs) DeleteRecurringTransaction(ctx context.Context, i1 int64, i2 int64) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "DeleteRecurringTransaction").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "DeleteRecurringTransaction").Msg("Finish")
+		}
+	}()
+	return d.base.DeleteRecurringTransaction(ctx, i1, i2)
+}
+
+// GetDueRecurringTransactions implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithLogs) GetDueRecurringTransactions(ctx context.Context, t1 time.Time) (ra1 []models.RecurringTransactionView, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"t1":  t1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ra1": ra1,
+				"err": err}).Err(err).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "GetDueRecurringTransactions").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ra1": ra1,
+				"err": err}).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "GetDueRecurringTransactions").Msg("Finish")
+		}
+	}()
+	return d.base.GetDueRecurringTransactions(ctx, t1)
+}
+
+// GetRecurringTransactionByID implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithLogs) GetRecurringTransactionByID(ctx context.Context, i1 int64, i2 int64) (r1 models.RecurringTransactionView, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"r1":  r1,
+				"err": err}).Err(err).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "GetRecurringTransactionByID").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"r1":  r1,
+				"err": err}).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "GetRecurringTransactionByID").Msg("Finish")
+		}
+	}()
+	return d.base.GetRecurringTransactionByID(ctx, i1, i2)
+}
+
+// GetRecurringTransactions implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithLogs) GetRecurringTransactions(ctx context.Context, i1 int64) (ra1 []models.RecurringTransactionView, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ra1": ra1,
+				"err": err}).Err(err).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "GetRecurringTransactions").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ra1": ra1,
+				"err": err}).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "GetRecurringTransactions").Msg("Finish")
+		}
+	}()
+	return d.base.GetRecurringTransactions(ctx, i1)
+}
+
+// InsertRecurringTransaction implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithLogs) InsertRecurringTransaction(ctx context.Context, r1 models.RecurringTransactionTable) (i1 int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"r1":  r1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Err(err).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "InsertRecurringTransaction").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "InsertRecurringTransaction").Msg("Finish")
+		}
+	}()
+	return d.base.InsertRecurringTransaction(ctx, r1)
+}
+
+// UpdateRecurringTransaction implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithLogs) UpdateRecurringTransaction(ctx context.Context, r1 models.RecurringTransactionTable) (i1 int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"r1":  r1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Err(err).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "UpdateRecurringTransaction").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Str("decorator", "RecurringTransactionRepoWithLogs").Str("method", "UpdateRecurringTransaction").Msg("Finish")
+		}
+	}()
+	return d.base.UpdateRecurringTransaction(ctx, r1)
+}
+
+// NewRecurringTransactionRepoWithLogs instruments an implementation of the repository.RecurringTransactionRepo with simple logging
+func NewRecurringTransactionRepoWithLogs(base repository.RecurringTransactionRepo) repository.RecurringTransactionRepo {
+	decorate := os.Getenv("DECORATE")
+	if decorate == "true" || decorate == "1" {
+		return RecurringTransactionRepoWithLogs{
+			base: base,
+		}
+	}
+
+	return base
+}
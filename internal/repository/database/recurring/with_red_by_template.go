@@ -0,0 +1,222 @@
+// Code generated by gowrap. DO NOT EDIT.
+// template: ../../templates/red_template.go.tmpl
+// gowrap: http://github.com/hexdigest/gowrap
+
+package recurring
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rubengomes8/golang-personal-finances/internal/instrumentation"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+type RecurringTransactionRepoWithRED struct {
+	base         repository.RecurringTransactionRepo
+	histogramVec *prometheus.HistogramVec
+}
+
+// AdvanceRecurringTransaction implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithRED) AdvanceRecurringTransaction(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "AdvanceRecurringTransaction",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.AdvanceRecurringTransaction(ctx, i1, t1, t2)
+}
+
+// DeleteRecurringTransaction implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithRED) DeleteRecurringTransaction(ctx context.Context, i1 int64, i2 int64) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "DeleteRecurringTransaction",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.DeleteRecurringTransaction(ctx, i1, i2)
+}
+
+// GetDueRecurringTransactions implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithRED) GetDueRecurringTransactions(ctx context.Context, t1 time.Time) (ra1 []models.RecurringTransactionView, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetDueRecurringTransactions",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetDueRecurringTransactions(ctx, t1)
+}
+
+// GetRecurringTransactionByID implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithRED) GetRecurringTransactionByID(ctx context.Context, i1 int64, i2 int64) (r1 models.RecurringTransactionView, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetRecurringTransactionByID",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetRecurringTransactionByID(ctx, i1, i2)
+}
+
+// GetRecurringTransactions implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithRED) GetRecurringTransactions(ctx context.Context, i1 int64) (ra1 []models.RecurringTransactionView, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetRecurringTransactions",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetRecurringTransactions(ctx, i1)
+}
+
+// InsertRecurringTransaction implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithRED) InsertRecurringTransaction(ctx context.Context, r1 models.RecurringTransactionTable) (i1 int64, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "InsertRecurringTransaction",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.InsertRecurringTransaction(ctx, r1)
+}
+
+// UpdateRecurringTransaction implements repository.RecurringTransactionRepo
+func (d RecurringTransactionRepoWithRED) UpdateRecurringTransaction(ctx context.Context, r1 models.RecurringTransactionTable) (i1 int64, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "UpdateRecurringTransaction",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.UpdateRecurringTransaction(ctx, r1)
+}
+
+// NewRecurringTransactionRepoWithRED returns an instance of the repository.RecurringTransactionRepo decorated with red histogram metric
+func NewRecurringTransactionRepoWithRED(base repository.RecurringTransactionRepo, constLabels prometheus.Labels) (decorator repository.RecurringTransactionRepo, err error) {
+	decorate := os.Getenv("DECORATE")
+	if !(decorate == "true" || decorate == "1") {
+		return base, nil
+	}
+
+	subSystem := "recurring_transaction_repo"
+
+	metricConfig := prometheus.HistogramOpts{
+		Namespace:   strings.TrimSpace("system"),
+		Subsystem:   subSystem,
+		Name:        fmt.Sprintf("%s_red", subSystem),
+		Help:        "RecurringTransactionRepo RED histogram (rate, errors and duration).",
+		ConstLabels: constLabels,
+		Buckets:     prometheus.ExponentialBuckets(100, 2, 5),
+	}
+
+	red := RecurringTransactionRepoWithRED{
+		base:         base,
+		histogramVec: prometheus.NewHistogramVec(metricConfig, []string{"status", "method"}),
+	}
+
+	err = instrumentation.Registry.Register(red.histogramVec)
+	if err != nil {
+		return nil, err
+	}
+
+	return red, nil
+}
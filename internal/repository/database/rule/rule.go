@@ -0,0 +1,206 @@
+package rule
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const (
+	tableNameCategorizationRules = "categorization_rules"
+
+	// selectRulesStmt joins the rules with the names of their card and categories.
+	// Unset bounds, card and categories are read as zero values.
+	selectRulesStmt = `SELECT 
+	r.id, r.priority, r.description_pattern, r.description_regex, 
+	COALESCE(r.min_value, 0), COALESCE(r.max_value, 0), 
+	COALESCE(r.card_id, 0), COALESCE(c.name, ''), 
+	COALESCE(r.subcategory_id, 0), COALESCE(es.name, ''), 
+	COALESCE(r.income_category_id, 0), COALESCE(ic.name, ''), r.user_id
+	FROM categorization_rules r
+	LEFT JOIN cards c ON r.card_id = c.id
+	LEFT JOIN expense_subcategories es ON r.subcategory_id = es.id
+	LEFT JOIN income_categories ic ON r.income_category_id = ic.id`
+)
+
+// DB implements the categorization rule repository methods
+type DB struct {
+	database *sql.DB
+}
+
+// NewDB creates a new CategorizationRuleRepo
+func NewDB(database *sql.DB) DB {
+	return DB{
+		database: database,
+	}
+}
+
+// InsertCategorizationRule inserts a categorization rule on the categorization rules db table
+func (r DB) InsertCategorizationRule(ctx context.Context, rule models.CategorizationRuleTable) (int64, error) {
+
+	insertStmt := fmt.Sprintf(`INSERT INTO %s 
+	(priority, description_pattern, description_regex, min_value, max_value, card_id, subcategory_id, income_category_id, user_id) 
+	VALUES ($1, $2, $3, NULLIF($4::NUMERIC, 0), NULLIF($5::NUMERIC, 0), NULLIF($6::INTEGER, 0), NULLIF($7::INTEGER, 0), NULLIF($8::INTEGER, 0), $9) 
+	RETURNING id`, tableNameCategorizationRules)
+
+	var id int64
+
+	err := r.database.QueryRowContext(
+		ctx,
+		insertStmt,
+		rule.Priority,
+		rule.DescriptionPattern,
+		rule.DescriptionRegex,
+		rule.MinValue,
+		rule.MaxValue,
+		rule.CardID,
+		rule.SubCategoryID,
+		rule.IncomeCategoryID,
+		rule.UserID,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("error scanning categorization rule id: %v", err)
+	}
+
+	return id, nil
+}
+
+// UpdateCategorizationRule updates a categorization rule on the categorization rules db table
+func (r DB) UpdateCategorizationRule(ctx context.Context, rule models.CategorizationRuleTable) (int64, error) {
+
+	updateStmt := fmt.Sprintf(`UPDATE %s SET 
+	priority = $1, description_pattern = $2, description_regex = $3, 
+	min_value = NULLIF($4::NUMERIC, 0), max_value = NULLIF($5::NUMERIC, 0), card_id = NULLIF($6::INTEGER, 0), 
+	subcategory_id = NULLIF($7::INTEGER, 0), income_category_id = NULLIF($8::INTEGER, 0) 
+	WHERE id = $9 AND user_id = $10`, tableNameCategorizationRules)
+
+	result, err := r.database.ExecContext(
+		ctx,
+		updateStmt,
+		rule.Priority,
+		rule.DescriptionPattern,
+		rule.DescriptionRegex,
+		rule.MinValue,
+		rule.MaxValue,
+		rule.CardID,
+		rule.SubCategoryID,
+		rule.IncomeCategoryID,
+		rule.ID,
+		rule.UserID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error updating categorization rule: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not get number of rows affected in exec categorization rule update statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return 0, ErrNoRowsAffectedOnUpdate
+	}
+
+	return rule.ID, nil
+}
+
+// GetCategorizationRuleByID gets a categorization rule from the categorization rules db table by id
+func (r DB) GetCategorizationRuleByID(ctx context.Context, userID int64, id int64) (models.CategorizationRuleView, error) {
+
+	selectStmt := selectRulesStmt + " WHERE r.id = $1 AND r.user_id = $2"
+
+	row := r.database.QueryRowContext(ctx, selectStmt, id, userID)
+
+	rule, err := scanRule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.CategorizationRuleView{}, repository.ErrNotFound
+	}
+	if err != nil {
+		return models.CategorizationRuleView{}, fmt.Errorf("error scanning categorization rule fields: %v", err)
+	}
+
+	return rule, nil
+}
+
+// GetCategorizationRules gets the categorization rules of the user, in the order they are tried
+func (r DB) GetCategorizationRules(ctx context.Context, userID int64) ([]models.CategorizationRuleView, error) {
+
+	selectStmt := selectRulesStmt + " WHERE r.user_id = $1 ORDER BY r.priority, r.id"
+
+	rows, err := r.database.QueryContext(ctx, selectStmt, userID)
+	if err != nil {
+		return []models.CategorizationRuleView{}, fmt.Errorf("could not query select categorization rules statement: %v", err)
+	}
+	defer rows.Close()
+
+	rules := []models.CategorizationRuleView{}
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return []models.CategorizationRuleView{}, fmt.Errorf("could not scan categorization rule fields: %v", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.CategorizationRuleView{},
+			fmt.Errorf("found error after scanning all categorization rules fields: %v", err)
+	}
+
+	return rules, nil
+}
+
+// DeleteCategorizationRule deletes a categorization rule from the categorization rules db table
+func (r DB) DeleteCategorizationRule(ctx context.Context, userID int64, id int64) error {
+
+	deleteStmt := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", tableNameCategorizationRules)
+
+	result, err := r.database.ExecContext(ctx, deleteStmt, id, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting categorization rule by id: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get number of rows affected in exec categorization rule delete statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return ErrNoRowsAffectedOnDelete
+	}
+
+	return nil
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRule(row scanner) (models.CategorizationRuleView, error) {
+
+	var rule models.CategorizationRuleView
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.Priority,
+		&rule.DescriptionPattern,
+		&rule.DescriptionRegex,
+		&rule.MinValue,
+		&rule.MaxValue,
+		&rule.CardID,
+		&rule.Card,
+		&rule.SubCategoryID,
+		&rule.SubCategory,
+		&rule.IncomeCategoryID,
+		&rule.IncomeCategory,
+		&rule.UserID,
+	)
+
+	return rule, err
+}
@@ -0,0 +1,146 @@
+// Code generated by gowrap. DO NOT EDIT.
+// template: ../../templates/log_template.go.tmpl
+// gowrap: http://github.com/hexdigest/gowrap
+
+package rule
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// CategorizationRuleRepoWithLogs implements repository.CategorizationRuleRepo that is instrumented with zerolog logger
+type CategorizationRuleRepoWithLogs struct {
+	base repository.CategorizationRuleRepo
+}
+
+// DeleteCategorizationRule implements repository.CategorizationRuleRepo
+func (d CategorizationRuleRepoWithLogs) DeleteCategorizationRule(ctx context.Context, i1 int64, i2 int64) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "CategorizationRuleRepoWithLogs").Str("method", "DeleteCategorizationRule").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "CategorizationRuleRepoWithLogs").Str("method", "DeleteCategorizationRule").Msg("Finish")
+		}
+	}()
+	return d.base.DeleteCategorizationRule(ctx, i1, i2)
+}
+
+// GetCategorizationRuleByID implements repository.CategorizationRuleRepo
+func (d CategorizationRuleRepoWithLogs) GetCategorizationRuleByID(ctx context.Context, i1 int64, i2 int64) (c2 models.CategorizationRuleView, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"c2":  c2,
+				"err": err}).Err(err).Str("decorator", "CategorizationRuleRepoWithLogs").Str("method", "GetCategorizationRuleByID").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"c2":  c2,
+				"err": err}).Str("decorator", "CategorizationRuleRepoWithLogs").Str("method", "GetCategorizationRuleByID").Msg("Finish")
+		}
+	}()
+	return d.base.GetCategorizationRuleByID(ctx, i1, i2)
+}
+
+// GetCategorizationRules implements repository.CategorizationRuleRepo
+func (d CategorizationRuleRepoWithLogs) GetCategorizationRules(ctx context.Context, i1 int64) (ca1 []models.CategorizationRuleView, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ca1": ca1,
+				"err": err}).Err(err).Str("decorator", "CategorizationRuleRepoWithLogs").Str("method", "GetCategorizationRules").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ca1": ca1,
+				"err": err}).Str("decorator", "CategorizationRuleRepoWithLogs").Str("method", "GetCategorizationRules").Msg("Finish")
+		}
+	}()
+	return d.base.GetCategorizationRules(ctx, i1)
+}
+
+// InsertCategorizationRule implements repository.CategorizationRuleRepo
+func (d CategorizationRuleRepoWithLogs) InsertCategorizationRule(ctx context.Context, c2 models.CategorizationRuleTable) (i1 int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"c2":  c2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Err(err).Str("decorator", "CategorizationRuleRepoWithLogs").Str("method", "InsertCategorizationRule").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Str("decorator", "CategorizationRuleRepoWithLogs").Str("method", "InsertCategorizationRule").Msg("Finish")
+		}
+	}()
+	return d.base.InsertCategorizationRule(ctx, c2)
+}
+
+// UpdateCategorizationRule implements repository.CategorizationRuleRepo
+func (d CategorizationRuleRepoWithLogs) UpdateCategorizationRule(ctx context.Context, c2 models.CategorizationRuleTable) (i1 int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"c2":  c2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Err(err).Str("decorator", "CategorizationRuleRepoWithLogs").Str("method", "UpdateCategorizationRule").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Str("decorator", "CategorizationRuleRepoWithLogs").Str("method", "UpdateCategorizationRule").Msg("Finish")
+		}
+	}()
+	return d.base.UpdateCategorizationRule(ctx, c2)
+}
+
+// NewCategorizationRuleRepoWithLogs instruments an implementation of the repository.CategorizationRuleRepo with simple logging
+func NewCategorizationRuleRepoWithLogs(base repository.CategorizationRuleRepo) repository.CategorizationRuleRepo {
+	decorate := os.Getenv("DECORATE")
+	if decorate == "true" || decorate == "1" {
+		return CategorizationRuleRepoWithLogs{
+			base: base,
+		}
+	}
+
+	return base
+}
@@ -0,0 +1,174 @@
+// Code generated by gowrap. DO NOT EDIT.
+// template: ../../templates/red_template.go.tmpl
+// gowrap: http://github.com/hexdigest/gowrap
+
+package rule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rubengomes8/golang-personal-finances/internal/instrumentation"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+type CategorizationRuleRepoWithRED struct {
+	base         repository.CategorizationRuleRepo
+	histogramVec *prometheus.HistogramVec
+}
+
+// DeleteCategorizationRule implements repository.CategorizationRuleRepo
+func (d CategorizationRuleRepoWithRED) DeleteCategorizationRule(ctx context.Context, i1 int64, i2 int64) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "DeleteCategorizationRule",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.DeleteCategorizationRule(ctx, i1, i2)
+}
+
+// GetCategorizationRuleByID implements repository.CategorizationRuleRepo
+func (d CategorizationRuleRepoWithRED) GetCategorizationRuleByID(ctx context.Context, i1 int64, i2 int64) (c2 models.CategorizationRuleView, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetCategorizationRuleByID",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetCategorizationRuleByID(ctx, i1, i2)
+}
+
+// GetCategorizationRules implements repository.CategorizationRuleRepo
+func (d CategorizationRuleRepoWithRED) GetCategorizationRules(ctx context.Context, i1 int64) (ca1 []models.CategorizationRuleView, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetCategorizationRules",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetCategorizationRules(ctx, i1)
+}
+
+// InsertCategorizationRule implements repository.CategorizationRuleRepo
+func (d CategorizationRuleRepoWithRED) InsertCategorizationRule(ctx context.Context, c2 models.CategorizationRuleTable) (i1 int64, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "InsertCategorizationRule",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.InsertCategorizationRule(ctx, c2)
+}
+
+// UpdateCategorizationRule implements repository.CategorizationRuleRepo
+func (d CategorizationRuleRepoWithRED) UpdateCategorizationRule(ctx context.Context, c2 models.CategorizationRuleTable) (i1 int64, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "UpdateCategorizationRule",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.UpdateCategorizationRule(ctx, c2)
+}
+
+// NewCategorizationRuleRepoWithRED returns an instance of the repository.CategorizationRuleRepo decorated with red histogram metric
+func NewCategorizationRuleRepoWithRED(base repository.CategorizationRuleRepo, constLabels prometheus.Labels) (decorator repository.CategorizationRuleRepo, err error) {
+	decorate := os.Getenv("DECORATE")
+	if !(decorate == "true" || decorate == "1") {
+		return base, nil
+	}
+
+	subSystem := "categorization_rule_repo"
+
+	metricConfig := prometheus.HistogramOpts{
+		Namespace:   strings.TrimSpace("system"),
+		Subsystem:   subSystem,
+		Name:        fmt.Sprintf("%s_red", subSystem),
+		Help:        "CategorizationRuleRepo RED histogram (rate, errors and duration).",
+		ConstLabels: constLabels,
+		Buckets:     prometheus.ExponentialBuckets(100, 2, 5),
+	}
+
+	red := CategorizationRuleRepoWithRED{
+		base:         base,
+		histogramVec: prometheus.NewHistogramVec(metricConfig, []string{"status", "method"}),
+	}
+
+	err = instrumentation.Registry.Register(red.histogramVec)
+	if err != nil {
+		return nil, err
+	}
+
+	return red, nil
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// SearchClauses builds the WHERE, ORDER BY and LIMIT clauses of a search of the expenses or incomes view of a user,
+// with keyset pagination on the sort field and the id. With a lines view, the category and subcategory are filtered on
+// the lines of the results, so a split expense matches any of its subcategories; otherwise the subcategory is not filtered on.
+// The tag is filtered on the tag links of the results.
+func SearchClauses(userID int64, filter models.SearchFilter, linesView string, tagLinks TagLinks) (string, []interface{}) {
+
+	args := []interface{}{userID}
+	conditions := []string{"user_id = $1"}
+
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if !filter.MinDate.IsZero() {
+		where("date >= $%d", filter.MinDate)
+	}
+	if !filter.MaxDate.IsZero() {
+		where("date <= $%d", filter.MaxDate)
+	}
+	if filter.Category != "" && linesView == "" {
+		where("category_name = $%d", filter.Category)
+	}
+	if filter.Category != "" && linesView != "" {
+		where("id IN (SELECT expense_id FROM "+linesView+" WHERE category_name = $%d)", filter.Category)
+	}
+	if filter.SubCategory != "" && linesView != "" {
+		where("id IN (SELECT expense_id FROM "+linesView+" WHERE subcategory_name = $%d)", filter.SubCategory)
+	}
+	if filter.Card != "" {
+		where("card_name = $%d", filter.Card)
+	}
+	if filter.MinValue != nil {
+		where("value >= $%d", *filter.MinValue)
+	}
+	if filter.MaxValue != nil {
+		where("value <= $%d", *filter.MaxValue)
+	}
+	if filter.Description != "" {
+		where(`description ILIKE '%%' || $%d || '%%'`, escapeLike(filter.Description))
+	}
+	if filter.Tag != "" {
+		where(tagLinks.condition(), filter.Tag)
+	}
+
+	sortColumn, direction, comparison := "date", "ASC", ">"
+	if filter.SortBy == models.SortByValue {
+		sortColumn = "value"
+	}
+	if filter.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		var after interface{} = filter.After.Date
+		if filter.SortBy == models.SortByValue {
+			after = filter.After.Value
+		}
+		args = append(args, after, filter.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s ($%d, $%d)", sortColumn, comparison, len(args)-1, len(args)))
+	}
+
+	clauses := fmt.Sprintf("WHERE %s ORDER BY %s %s, id %s", strings.Join(conditions, " AND "), sortColumn, direction, direction)
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		clauses += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return clauses, args
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern, so the text is matched literally
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+}
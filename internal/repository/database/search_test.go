@@ -0,0 +1,43 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchClauses(t *testing.T) {
+
+	minValue := models.MustParseMoney("10")
+	after := time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)
+
+	clauses, args := SearchClauses(7, models.SearchFilter{
+		Category:    "Leisure",
+		SubCategory: "Restaurants",
+		MinValue:    &minValue,
+		Description: "50%_off",
+		Tag:         "reimbursable",
+		SortBy:      models.SortByDate,
+		Descending:  true,
+		After:       &models.SearchCursor{Date: after, ID: 3},
+		Limit:       21,
+	}, "expense_lines_view", ExpenseTagLinks)
+
+	assert.Equal(t, "WHERE user_id = $1 AND id IN (SELECT expense_id FROM expense_lines_view WHERE category_name = $2) AND "+
+		"id IN (SELECT expense_id FROM expense_lines_view WHERE subcategory_name = $3) AND value >= $4 AND "+
+		"description ILIKE '%' || $5 || '%' AND id IN (SELECT l.expense_id FROM expense_tags l JOIN tags t ON t.id = l.tag_id WHERE t.name = $6) AND "+
+		"(date, id) < ($7, $8) ORDER BY date DESC, id DESC LIMIT $9", clauses)
+	assert.Equal(t, []interface{}{int64(7), "Leisure", "Restaurants", minValue, `50\%\_off`, "reimbursable", after, int64(3), 21}, args)
+
+	clauses, args = SearchClauses(7, models.SearchFilter{
+		Category:    "Salary",
+		SubCategory: "Restaurants",
+		SortBy:      models.SortByValue,
+		After:       &models.SearchCursor{Value: minValue, ID: 3},
+	}, "", IncomeTagLinks)
+
+	assert.Equal(t, "WHERE user_id = $1 AND category_name = $2 AND (value, id) > ($3, $4) ORDER BY value ASC, id ASC", clauses)
+	assert.Equal(t, []interface{}{int64(7), "Salary", minValue, int64(3)}, args)
+}
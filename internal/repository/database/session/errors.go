@@ -0,0 +1,12 @@
+package session
+
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+var (
+	ErrNoRowsAffectedOnRevoke  = fmt.Errorf("there were no rows affected in exec session revoke statement: %w", repository.ErrNotFound)
+	ErrRefreshTokenAlreadyUsed = fmt.Errorf("the refresh token was already used: %w", repository.ErrAlreadyUsed)
+)
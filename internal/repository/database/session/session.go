@@ -0,0 +1,167 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const (
+	tableNameSessions      = "sessions"
+	tableNameRefreshTokens = "refresh_tokens"
+)
+
+// DB implements the session repository methods
+type DB struct {
+	database *sql.DB
+}
+
+// NewDB creates a new SessionRepo
+func NewDB(database *sql.DB) DB {
+	return DB{
+		database: database,
+	}
+}
+
+// InsertSession inserts a session on the sessions db table
+func (s DB) InsertSession(ctx context.Context, session models.SessionTable) (int64, error) {
+
+	insertStmt := fmt.Sprintf("INSERT INTO %s (expires_at, user_id) VALUES ($1, $2) RETURNING id", tableNameSessions)
+
+	var id int64
+
+	err := s.database.QueryRowContext(ctx, insertStmt, session.ExpiresAt, session.UserID).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("error scanning session id: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetSessionByID gets a session from the sessions db table by id
+func (s DB) GetSessionByID(ctx context.Context, id int64) (models.SessionTable, error) {
+
+	selectStmt := fmt.Sprintf("SELECT id, created_at, expires_at, revoked_at, user_id FROM %s WHERE id = $1", tableNameSessions)
+
+	var session models.SessionTable
+	var revokedAt sql.NullTime
+
+	err := s.database.QueryRowContext(ctx, selectStmt, id).Scan(
+		&session.ID,
+		&session.CreatedAt,
+		&session.ExpiresAt,
+		&revokedAt,
+		&session.UserID,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.SessionTable{}, repository.ErrNotFound
+	}
+	if err != nil {
+		return models.SessionTable{}, fmt.Errorf("error scanning session fields: %v", err)
+	}
+	session.RevokedAt = revokedAt.Time
+
+	return session, nil
+}
+
+// RevokeSession revokes a session on the sessions db table. Revoking a revoked session keeps its revoke time.
+func (s DB) RevokeSession(ctx context.Context, id int64) error {
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET revoked_at = COALESCE(revoked_at, NOW()) WHERE id = $1", tableNameSessions)
+
+	result, err := s.database.ExecContext(ctx, updateStmt, id)
+	if err != nil {
+		return fmt.Errorf("error revoking session by id: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get number of rows affected in exec session revoke statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return ErrNoRowsAffectedOnRevoke
+	}
+
+	return nil
+}
+
+// RevokeUserSessions revokes every session of a user on the sessions db table that is not revoked yet
+func (s DB) RevokeUserSessions(ctx context.Context, userID int64) error {
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL", tableNameSessions)
+
+	_, err := s.database.ExecContext(ctx, updateStmt, userID)
+	if err != nil {
+		return fmt.Errorf("error revoking sessions by user id: %v", err)
+	}
+
+	return nil
+}
+
+// InsertRefreshToken inserts a refresh token on the refresh tokens db table
+func (s DB) InsertRefreshToken(ctx context.Context, refreshToken models.RefreshTokenTable) (int64, error) {
+
+	insertStmt := fmt.Sprintf("INSERT INTO %s (token_hash, session_id) VALUES ($1, $2) RETURNING id", tableNameRefreshTokens)
+
+	var id int64
+
+	err := s.database.QueryRowContext(ctx, insertStmt, refreshToken.TokenHash, refreshToken.SessionID).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("error scanning refresh token id: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetRefreshTokenByHash gets a refresh token from the refresh tokens db table by the hash of the token
+func (s DB) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (models.RefreshTokenTable, error) {
+
+	selectStmt := fmt.Sprintf("SELECT id, token_hash, created_at, used_at, session_id FROM %s WHERE token_hash = $1", tableNameRefreshTokens)
+
+	var refreshToken models.RefreshTokenTable
+	var usedAt sql.NullTime
+
+	err := s.database.QueryRowContext(ctx, selectStmt, tokenHash).Scan(
+		&refreshToken.ID,
+		&refreshToken.TokenHash,
+		&refreshToken.CreatedAt,
+		&usedAt,
+		&refreshToken.SessionID,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.RefreshTokenTable{}, repository.ErrNotFound
+	}
+	if err != nil {
+		return models.RefreshTokenTable{}, fmt.Errorf("error scanning refresh token fields: %v", err)
+	}
+	refreshToken.UsedAt = usedAt.Time
+
+	return refreshToken, nil
+}
+
+// UseRefreshToken marks a refresh token as used on the refresh tokens db table, unless it already was
+func (s DB) UseRefreshToken(ctx context.Context, id int64) error {
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET used_at = NOW() WHERE id = $1 AND used_at IS NULL", tableNameRefreshTokens)
+
+	result, err := s.database.ExecContext(ctx, updateStmt, id)
+	if err != nil {
+		return fmt.Errorf("error using refresh token by id: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get number of rows affected in exec refresh token use statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return ErrRefreshTokenAlreadyUsed
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+// Code generated by gowrap. DO NOT EDIT.
+// template: ../../templates/log_template.go.tmpl
+// gowrap: http://github.com/hexdigest/gowrap
+
+package session
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// SessionRepoWithLogs implements repository.SessionRepo that is instrumented with zerolog logger
+type SessionRepoWithLogs struct {
+	base repository.SessionRepo
+}
+
+// GetRefreshTokenByHash implements repository.SessionRepo
+func (d SessionRepoWithLogs) GetRefreshTokenByHash(ctx context.Context, s1 string) (r1 models.RefreshTokenTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"s1":  s1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"r1":  r1,
+				"err": err}).Err(err).Str("decorator", "SessionRepoWithLogs").Str("method", "GetRefreshTokenByHash").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"r1":  r1,
+				"err": err}).Str("decorator", "SessionRepoWithLogs").Str("method", "GetRefreshTokenByHash").Msg("Finish")
+		}
+	}()
+	return d.base.GetRefreshTokenByHash(ctx, s1)
+}
+
+// GetSessionByID implements repository.SessionRepo
+func (d SessionRepoWithLogs) GetSessionByID(ctx context.Context, i1 int64) (s1 models.SessionTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"s1":  s1,
+				"err": err}).Err(err).Str("decorator", "SessionRepoWithLogs").Str("method", "GetSessionByID").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"s1":  s1,
+				"err": err}).Str("decorator", "SessionRepoWithLogs").Str("method", "GetSessionByID").Msg("Finish")
+		}
+	}()
+	return d.base.GetSessionByID(ctx, i1)
+}
+
+// InsertRefreshToken implements repository.SessionRepo
+func (d SessionRepoWithLogs) InsertRefreshToken(ctx context.Context, r1 models.RefreshTokenTable) (i1 int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"r1":  r1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Err(err).Str("decorator", "SessionRepoWithLogs").Str("method", "InsertRefreshToken").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Str("decorator", "SessionRepoWithLogs").Str("method", "InsertRefreshToken").Msg("Finish")
+		}
+	}()
+	return d.base.InsertRefreshToken(ctx, r1)
+}
+
+// InsertSession implements repository.SessionRepo
+func (d SessionRepoWithLogs) InsertSession(ctx context.Context, s1 models.SessionTable) (i1 int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"s1":  s1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Err(err).Str("decorator", "SessionRepoWithLogs").Str("method", "InsertSession").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Str("decorator", "SessionRepoWithLogs").Str("method", "InsertSession").Msg("Finish")
+		}
+	}()
+	return d.base.InsertSession(ctx, s1)
+}
+
+// RevokeSession implements repository.SessionRepo
+func (d SessionRepoWithLogs) RevokeSession(ctx context.Context, i1 int64) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "SessionRepoWithLogs").Str("method", "RevokeSession").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "SessionRepoWithLogs").Str("method", "RevokeSession").Msg("Finish")
+		}
+	}()
+	return d.base.RevokeSession(ctx, i1)
+}
+
+// RevokeUserSessions implements repository.SessionRepo
+func (d SessionRepoWithLogs) RevokeUserSessions(ctx context.Context, i1 int64) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "SessionRepoWithLogs").Str("method", "RevokeUserSessions").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "SessionRepoWithLogs").Str("method", "RevokeUserSessions").Msg("Finish")
+		}
+	}()
+	return d.base.RevokeUserSessions(ctx, i1)
+}
+
+// UseRefreshToken implements repository.SessionRepo
+func (d SessionRepoWithLogs) UseRefreshToken(ctx context.Context, i1 int64) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "SessionRepoWithLogs").Str("method", "UseRefreshToken").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "SessionRepoWithLogs").Str("method", "UseRefreshToken").Msg("Finish")
+		}
+	}()
+	return d.base.UseRefreshToken(ctx, i1)
+}
+
+// NewSessionRepoWithLogs instruments an implementation of the repository.SessionRepo with simple logging
+func NewSessionRepoWithLogs(base repository.SessionRepo) repository.SessionRepo {
+	decorate := os.Getenv("DECORATE")
+	if decorate == "true" || decorate == "1" {
+		return SessionRepoWithLogs{
+			base: base,
+		}
+	}
+
+	return base
+}
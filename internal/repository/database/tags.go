@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+const tagsTable = "tags"
+
+// TagLinks is a table linking the rows of another table to the tags of their user
+type TagLinks struct {
+	Table  string // such as expense_tags
+	Column string // the column with the id of the linked row, such as expense_id
+}
+
+var (
+	// ExpenseTagLinks links the expenses to their tags
+	ExpenseTagLinks = TagLinks{Table: "expense_tags", Column: "expense_id"}
+	// IncomeTagLinks links the incomes to their tags
+	IncomeTagLinks = TagLinks{Table: "income_tags", Column: "income_id"}
+)
+
+// SetTags replaces the tags of the row with the id, creating the tags the user does not have yet
+func (l TagLinks) SetTags(ctx context.Context, querier Querier, userID int64, id int64, tags []string) error {
+
+	deleteStmt := fmt.Sprintf(`DELETE FROM %s WHERE %s = $1`, l.Table, l.Column)
+
+	_, err := querier.ExecContext(ctx, deleteStmt, id)
+	if err != nil {
+		return fmt.Errorf("could not exec %s delete statement: %v", l.Table, err)
+	}
+
+	insertStmt := fmt.Sprintf(`WITH tag AS (
+		INSERT INTO %s (name, user_id) VALUES ($1, $2)
+		ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id
+	)
+	INSERT INTO %s (%s, tag_id) SELECT $3, id FROM tag`, tagsTable, l.Table, l.Column)
+
+	for _, tag := range tags {
+		_, err := querier.ExecContext(ctx, insertStmt, tag, userID, id)
+		if err != nil {
+			return fmt.Errorf("could not exec %s insert statement: %v", l.Table, err)
+		}
+	}
+
+	return nil
+}
+
+// GetTags gets the tags of the rows with the ids by row id, sorted by name
+func (l TagLinks) GetTags(ctx context.Context, querier Querier, ids []int64) (map[int64][]string, error) {
+
+	tags := map[int64][]string{}
+	if len(ids) == 0 {
+		return tags, nil
+	}
+
+	selectStmt := fmt.Sprintf(`SELECT l.%s, t.name 
+	FROM %s l JOIN %s t ON t.id = l.tag_id 
+	WHERE l.%s = ANY($1) 
+	ORDER BY l.%s, t.name`, l.Column, l.Table, tagsTable, l.Column, l.Column)
+
+	rows, err := querier.QueryContext(ctx, selectStmt, pq.Array(ids))
+	if err != nil {
+		return map[int64][]string{}, fmt.Errorf("could not query select %s statement: %v", l.Table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var tag string
+		err := rows.Scan(&id, &tag)
+		if err != nil {
+			return map[int64][]string{}, fmt.Errorf("could not scan %s fields: %v", l.Table, err)
+		}
+		tags[id] = append(tags[id], tag)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return map[int64][]string{}, fmt.Errorf("found error after scanning all %s fields: %v", l.Table, err)
+	}
+
+	return tags, nil
+}
+
+// condition is the WHERE condition of a row having the tag of the placeholder, to format with its number
+func (l TagLinks) condition() string {
+	return fmt.Sprintf("id IN (SELECT l.%s FROM %s l JOIN %s t ON t.id = l.tag_id WHERE t.name = $%%d)",
+		l.Column, l.Table, tagsTable)
+}
@@ -0,0 +1,12 @@
+package transfer
+
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+var (
+	ErrNoRowsAffectedOnDelete = fmt.Errorf("there were no rows affected in exec transfer delete statement: %w", repository.ErrNotFound)
+	ErrNoRowsAffectedOnUpdate = fmt.Errorf("there were no rows affected in exec transfer update statement: %w", repository.ErrNotFound)
+)
@@ -0,0 +1,197 @@
+package transfer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const (
+	tableNameTransfers = "transfers"
+
+	// selectTransfersStmt joins the transfers with the names of their source and destination cards
+	selectTransfersStmt = `SELECT 
+	t.id, t.amount, t.fee, t.date, COALESCE(t.description, ''), 
+	t.from_card_id, fc.name, t.to_card_id, tc.name, t.user_id
+	FROM transfers t
+	JOIN cards fc ON t.from_card_id = fc.id
+	JOIN cards tc ON t.to_card_id = tc.id`
+)
+
+// DB implements the transfer repository methods
+type DB struct {
+	database *sql.DB
+}
+
+// NewDB creates a new TransferRepo
+func NewDB(database *sql.DB) DB {
+	return DB{
+		database: database,
+	}
+}
+
+// InsertTransfer inserts a transfer on the transfers db table
+func (t DB) InsertTransfer(ctx context.Context, transfer models.TransferTable) (int64, error) {
+
+	insertStmt := fmt.Sprintf(`INSERT INTO %s 
+	(amount, fee, date, description, from_card_id, to_card_id, user_id) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7) 
+	RETURNING id`, tableNameTransfers)
+
+	var id int64
+
+	err := t.database.QueryRowContext(
+		ctx,
+		insertStmt,
+		transfer.Amount,
+		transfer.Fee,
+		transfer.Date,
+		transfer.Description,
+		transfer.FromCardID,
+		transfer.ToCardID,
+		transfer.UserID,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("error scanning transfer id: %v", err)
+	}
+
+	return id, nil
+}
+
+// UpdateTransfer updates a transfer on the transfers db table
+func (t DB) UpdateTransfer(ctx context.Context, transfer models.TransferTable) (int64, error) {
+
+	updateStmt := fmt.Sprintf(`UPDATE %s SET 
+	amount = $1, fee = $2, date = $3, description = $4, from_card_id = $5, to_card_id = $6 
+	WHERE id = $7 AND user_id = $8`, tableNameTransfers)
+
+	result, err := t.database.ExecContext(
+		ctx,
+		updateStmt,
+		transfer.Amount,
+		transfer.Fee,
+		transfer.Date,
+		transfer.Description,
+		transfer.FromCardID,
+		transfer.ToCardID,
+		transfer.ID,
+		transfer.UserID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error updating transfer: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not get number of rows affected in exec transfer update statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return 0, ErrNoRowsAffectedOnUpdate
+	}
+
+	return transfer.ID, nil
+}
+
+// GetTransferByID gets a transfer from the transfers db table by id
+func (t DB) GetTransferByID(ctx context.Context, userID int64, id int64) (models.TransferView, error) {
+
+	selectStmt := selectTransfersStmt + " WHERE t.id = $1 AND t.user_id = $2"
+
+	row := t.database.QueryRowContext(ctx, selectStmt, id, userID)
+
+	transfer, err := scanTransfer(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.TransferView{}, repository.ErrNotFound
+	}
+	if err != nil {
+		return models.TransferView{}, fmt.Errorf("error scanning transfer fields: %v", err)
+	}
+
+	return transfer, nil
+}
+
+// GetTransfersByDates gets the transfers of the user from the transfers db table that match the dates' range provided
+func (t DB) GetTransfersByDates(
+	ctx context.Context,
+	userID int64,
+	minDate time.Time,
+	maxDate time.Time,
+) ([]models.TransferView, error) {
+
+	selectStmt := selectTransfersStmt + " WHERE t.user_id = $1 AND t.date BETWEEN $2 AND $3 ORDER BY t.date, t.id"
+
+	rows, err := t.database.QueryContext(ctx, selectStmt, userID, minDate, maxDate)
+	if err != nil {
+		return []models.TransferView{}, fmt.Errorf("could not query select transfers statement: %v", err)
+	}
+	defer rows.Close()
+
+	transfers := []models.TransferView{}
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return []models.TransferView{}, fmt.Errorf("could not scan transfer fields: %v", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return []models.TransferView{}, fmt.Errorf("found error after scanning all transfers fields: %v", err)
+	}
+
+	return transfers, nil
+}
+
+// DeleteTransfer deletes a transfer from the transfers db table
+func (t DB) DeleteTransfer(ctx context.Context, userID int64, id int64) error {
+
+	deleteStmt := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2", tableNameTransfers)
+
+	result, err := t.database.ExecContext(ctx, deleteStmt, id, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting transfer by id: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get number of rows affected in exec transfer delete statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return ErrNoRowsAffectedOnDelete
+	}
+
+	return nil
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTransfer(row scanner) (models.TransferView, error) {
+
+	var transfer models.TransferView
+
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.Amount,
+		&transfer.Fee,
+		&transfer.Date,
+		&transfer.Description,
+		&transfer.FromCardID,
+		&transfer.FromCard,
+		&transfer.ToCardID,
+		&transfer.ToCard,
+		&transfer.UserID,
+	)
+
+	return transfer, err
+}
@@ -0,0 +1,149 @@
+// Code generated by gowrap. DO NOT EDIT.
+// template: ../../templates/log_template.go.tmpl
+// gowrap: http://github.com/hexdigest/gowrap
+
+package transfer
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// TransferRepoWithLogs implements repository.TransferRepo that is instrumented with zerolog logger
+type TransferRepoWithLogs struct {
+	base repository.TransferRepo
+}
+
+// DeleteTransfer implements repository.TransferRepo
+func (d TransferRepoWithLogs) DeleteTransfer(ctx context.Context, i1 int64, i2 int64) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "TransferRepoWithLogs").Str("method", "DeleteTransfer").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "TransferRepoWithLogs").Str("method", "DeleteTransfer").Msg("Finish")
+		}
+	}()
+	return d.base.DeleteTransfer(ctx, i1, i2)
+}
+
+// GetTransferByID implements repository.TransferRepo
+func (d TransferRepoWithLogs) GetTransferByID(ctx context.Context, i1 int64, i2 int64) (t1 models.TransferView, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"t1":  t1,
+				"err": err}).Err(err).Str("decorator", "TransferRepoWithLogs").Str("method", "GetTransferByID").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"t1":  t1,
+				"err": err}).Str("decorator", "TransferRepoWithLogs").Str("method", "GetTransferByID").Msg("Finish")
+		}
+	}()
+	return d.base.GetTransferByID(ctx, i1, i2)
+}
+
+// GetTransfersByDates implements repository.TransferRepo
+func (d TransferRepoWithLogs) GetTransfersByDates(ctx context.Context, i1 int64, t1 time.Time, t2 time.Time) (ta1 []models.TransferView, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"t1":  t1,
+		"t2":  t2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"ta1": ta1,
+				"err": err}).Err(err).Str("decorator", "TransferRepoWithLogs").Str("method", "GetTransfersByDates").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"ta1": ta1,
+				"err": err}).Str("decorator", "TransferRepoWithLogs").Str("method", "GetTransfersByDates").Msg("Finish")
+		}
+	}()
+	return d.base.GetTransfersByDates(ctx, i1, t1, t2)
+}
+
+// InsertTransfer implements repository.TransferRepo
+func (d TransferRepoWithLogs) InsertTransfer(ctx context.Context, t1 models.TransferTable) (i1 int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"t1":  t1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Err(err).Str("decorator", "TransferRepoWithLogs").Str("method", "InsertTransfer").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Str("decorator", "TransferRepoWithLogs").Str("method", "InsertTransfer").Msg("Finish")
+		}
+	}()
+	return d.base.InsertTransfer(ctx, t1)
+}
+
+// UpdateTransfer implements repository.TransferRepo
+func (d TransferRepoWithLogs) UpdateTransfer(ctx context.Context, t1 models.TransferTable) (i1 int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"t1":  t1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Err(err).Str("decorator", "TransferRepoWithLogs").Str("method", "UpdateTransfer").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Str("decorator", "TransferRepoWithLogs").Str("method", "UpdateTransfer").Msg("Finish")
+		}
+	}()
+	return d.base.UpdateTransfer(ctx, t1)
+}
+
+// NewTransferRepoWithLogs instruments an implementation of the repository.TransferRepo with simple logging
+func NewTransferRepoWithLogs(base repository.TransferRepo) repository.TransferRepo {
+	decorate := os.Getenv("DECORATE")
+	if decorate == "true" || decorate == "1" {
+		return TransferRepoWithLogs{
+			base: base,
+		}
+	}
+
+	return base
+}
@@ -0,0 +1,15 @@
+package user
+
+import (
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
+
+var (
+	ErrNoRowsAffectedOnUpdate   = fmt.Errorf("there were no rows affected in exec user update statement: %w", repository.ErrNotFound)
+	ErrPasswordResetAlreadyUsed = fmt.Errorf("the password reset was already used: %w", repository.ErrAlreadyUsed)
+	ErrTOTPStepAlreadyUsed      = fmt.Errorf("a TOTP code of the same or a later step was already used: %w", repository.ErrAlreadyUsed)
+
+	ErrNoRowsAffectedOnRecoveryCodeUse = fmt.Errorf("there were no rows affected in exec recovery code update statement: %w", repository.ErrNotFound)
+)
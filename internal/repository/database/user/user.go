@@ -3,13 +3,20 @@ package user
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/database"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
 )
 
 const (
-	tableNameUsers = "users"
+	tableNameUsers          = "users"
+	tableNamePasswordResets = "password_resets"
+	tableNameRecoveryCodes  = "recovery_codes"
+
+	userColumns = "id, username, passhash, totp_secret, totp_enabled_at, totp_last_step, is_admin"
 )
 
 // DB implements the user repository methods
@@ -40,15 +47,290 @@ func (u DB) InsertUser(ctx context.Context, user models.UserTable) (int64, error
 
 func (u DB) GetUserByUsername(ctx context.Context, username string) (models.UserTable, error) {
 
-	selectStmt := fmt.Sprintf("SELECT id, username, passhash FROM %s WHERE username = $1", tableNameUsers)
+	selectStmt := fmt.Sprintf("SELECT %s FROM %s WHERE username = $1", userColumns, tableNameUsers)
 
-	row := u.database.QueryRowContext(ctx, selectStmt, username)
+	user, err := scanUser(u.database.QueryRowContext(ctx, selectStmt, username))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.UserTable{}, repository.ErrNotFound
+	}
+	if err != nil {
+		return models.UserTable{}, fmt.Errorf("error scanning user fields: %v", err)
+	}
 
-	var user models.UserTable
-	err := row.Scan(&user.ID, &user.Username, &user.Passhash)
+	return user, nil
+}
+
+// GetUserByID gets a user from the users db table by id
+func (u DB) GetUserByID(ctx context.Context, id int64) (models.UserTable, error) {
+
+	selectStmt := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1", userColumns, tableNameUsers)
+
+	user, err := scanUser(u.database.QueryRowContext(ctx, selectStmt, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.UserTable{}, repository.ErrNotFound
+	}
 	if err != nil {
 		return models.UserTable{}, fmt.Errorf("error scanning user fields: %v", err)
 	}
 
 	return user, nil
 }
+
+// UpdatePassword sets the password hash of a user on the users db table
+func (u DB) UpdatePassword(ctx context.Context, id int64, passhash string) error {
+	return updatePassword(ctx, u.database, id, passhash)
+}
+
+// InsertPasswordReset inserts a password reset on the password resets db table
+func (u DB) InsertPasswordReset(ctx context.Context, reset models.PasswordResetTable) (int64, error) {
+
+	insertStmt := fmt.Sprintf("INSERT INTO %s (token_hash, expires_at, user_id) VALUES ($1, $2, $3) RETURNING id", tableNamePasswordResets)
+
+	var id int64
+
+	err := u.database.QueryRowContext(ctx, insertStmt, reset.TokenHash, reset.ExpiresAt, reset.UserID).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("error scanning password reset id: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetPasswordResetByHash gets a password reset from the password resets db table by the hash of its token
+func (u DB) GetPasswordResetByHash(ctx context.Context, tokenHash string) (models.PasswordResetTable, error) {
+
+	selectStmt := fmt.Sprintf("SELECT id, token_hash, created_at, expires_at, used_at, user_id FROM %s WHERE token_hash = $1", tableNamePasswordResets)
+
+	var reset models.PasswordResetTable
+	var usedAt sql.NullTime
+
+	err := u.database.QueryRowContext(ctx, selectStmt, tokenHash).Scan(
+		&reset.ID,
+		&reset.TokenHash,
+		&reset.CreatedAt,
+		&reset.ExpiresAt,
+		&usedAt,
+		&reset.UserID,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.PasswordResetTable{}, repository.ErrNotFound
+	}
+	if err != nil {
+		return models.PasswordResetTable{}, fmt.Errorf("error scanning password reset fields: %v", err)
+	}
+	reset.UsedAt = usedAt.Time
+
+	return reset, nil
+}
+
+// ResetPassword marks a password reset as used, unless it already was, and sets the password hash of its user,
+// in a single transaction
+func (u DB) ResetPassword(ctx context.Context, resetID int64, passhash string) error {
+
+	tx, err := u.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin password reset transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET used_at = NOW() WHERE id = $1 AND used_at IS NULL RETURNING user_id", tableNamePasswordResets)
+
+	var userID int64
+	err = tx.QueryRowContext(ctx, updateStmt, resetID).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrPasswordResetAlreadyUsed
+	}
+	if err != nil {
+		return fmt.Errorf("error using password reset by id: %v", err)
+	}
+
+	err = updatePassword(ctx, tx, userID, passhash)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("could not commit password reset transaction: %v", err)
+	}
+
+	return nil
+}
+
+// updatePassword sets the password hash of a user
+func updatePassword(ctx context.Context, querier database.Querier, id int64, passhash string) error {
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET passhash = $1 WHERE id = $2", tableNameUsers)
+
+	return execUserUpdate(ctx, querier, updateStmt, passhash, id)
+}
+
+// SetTOTPSecret sets the TOTP secret of a user on the users db table, on 2FA enrolment, leaving 2FA disabled
+func (u DB) SetTOTPSecret(ctx context.Context, id int64, secret string) error {
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET totp_secret = $1, totp_enabled_at = NULL WHERE id = $2", tableNameUsers)
+
+	return execUserUpdate(ctx, u.database, updateStmt, secret, id)
+}
+
+// EnableTOTP enables the 2FA of a user on the users db table and replaces its recovery codes, in a single transaction
+func (u DB) EnableTOTP(ctx context.Context, id int64, recoveryCodeHashes []string) error {
+
+	tx, err := u.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin 2FA enable transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET totp_enabled_at = NOW() WHERE id = $1 AND totp_secret IS NOT NULL", tableNameUsers)
+
+	err = execUserUpdate(ctx, tx, updateStmt, id)
+	if err != nil {
+		return err
+	}
+
+	err = replaceRecoveryCodes(ctx, tx, id, recoveryCodeHashes)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("could not commit 2FA enable transaction: %v", err)
+	}
+
+	return nil
+}
+
+// DisableTOTP disables the 2FA of a user on the users db table, dropping its secret and its recovery codes
+func (u DB) DisableTOTP(ctx context.Context, id int64) error {
+
+	tx, err := u.database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin 2FA disable transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET totp_secret = NULL, totp_enabled_at = NULL WHERE id = $1", tableNameUsers)
+
+	err = execUserUpdate(ctx, tx, updateStmt, id)
+	if err != nil {
+		return err
+	}
+
+	err = replaceRecoveryCodes(ctx, tx, id, []string{})
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("could not commit 2FA disable transaction: %v", err)
+	}
+
+	return nil
+}
+
+// UseTOTPStep sets the time step of the last accepted TOTP code of a user on the users db table,
+// unless it is not after the current one
+func (u DB) UseTOTPStep(ctx context.Context, id int64, step int64) error {
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET totp_last_step = $1 WHERE id = $2 AND totp_last_step < $1", tableNameUsers)
+
+	result, err := u.database.ExecContext(ctx, updateStmt, step, id)
+	if err != nil {
+		return fmt.Errorf("error using TOTP step by user id: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get number of rows affected in exec TOTP step update statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return ErrTOTPStepAlreadyUsed
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks an unused recovery code of a user as used on the recovery codes db table
+func (u DB) UseRecoveryCode(ctx context.Context, id int64, codeHash string) error {
+
+	updateStmt := fmt.Sprintf("UPDATE %s SET used_at = NOW() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL", tableNameRecoveryCodes)
+
+	result, err := u.database.ExecContext(ctx, updateStmt, id, codeHash)
+	if err != nil {
+		return fmt.Errorf("error using recovery code by user id: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get number of rows affected in exec recovery code update statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return ErrNoRowsAffectedOnRecoveryCodeUse
+	}
+
+	return nil
+}
+
+// scanUser scans the user columns of a row
+func scanUser(row *sql.Row) (models.UserTable, error) {
+
+	var user models.UserTable
+	var totpSecret sql.NullString
+	var totpEnabledAt sql.NullTime
+
+	err := row.Scan(&user.ID, &user.Username, &user.Passhash, &totpSecret, &totpEnabledAt, &user.TOTPLastStep, &user.Admin)
+	if err != nil {
+		return models.UserTable{}, err
+	}
+	user.TOTPSecret = totpSecret.String
+	user.TOTPEnabledAt = totpEnabledAt.Time
+
+	return user, nil
+}
+
+// replaceRecoveryCodes deletes the recovery codes of a user and inserts the ones of the code hashes
+func replaceRecoveryCodes(ctx context.Context, querier database.Querier, id int64, codeHashes []string) error {
+
+	deleteStmt := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1", tableNameRecoveryCodes)
+
+	_, err := querier.ExecContext(ctx, deleteStmt, id)
+	if err != nil {
+		return fmt.Errorf("error deleting recovery codes by user id: %v", err)
+	}
+
+	insertStmt := fmt.Sprintf("INSERT INTO %s (code_hash, user_id) VALUES ($1, $2)", tableNameRecoveryCodes)
+
+	for _, codeHash := range codeHashes {
+		_, err = querier.ExecContext(ctx, insertStmt, codeHash, id)
+		if err != nil {
+			return fmt.Errorf("error inserting recovery code: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// execUserUpdate executes an update statement of a single user
+func execUserUpdate(ctx context.Context, querier database.Querier, updateStmt string, args ...interface{}) error {
+
+	result, err := querier.ExecContext(ctx, updateStmt, args...)
+	if err != nil {
+		return fmt.Errorf("error updating user by id: %v", err)
+	}
+
+	numRowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get number of rows affected in exec user update statement: %v", err)
+	}
+
+	if numRowsAffected == 0 {
+		return ErrNoRowsAffectedOnUpdate
+	}
+
+	return nil
+}
@@ -18,6 +18,95 @@ type UserRepoWithLogs struct {
 	base repository.UserRepo
 }
 
+// DisableTOTP implements repository.UserRepo
+func (d UserRepoWithLogs) DisableTOTP(ctx context.Context, i1 int64) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "UserRepoWithLogs").Str("method", "DisableTOTP").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "UserRepoWithLogs").Str("method", "DisableTOTP").Msg("Finish")
+		}
+	}()
+	return d.base.DisableTOTP(ctx, i1)
+}
+
+// EnableTOTP implements repository.UserRepo
+func (d UserRepoWithLogs) EnableTOTP(ctx context.Context, i1 int64, sa1 []string) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"sa1": sa1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "UserRepoWithLogs").Str("method", "EnableTOTP").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "UserRepoWithLogs").Str("method", "EnableTOTP").Msg("Finish")
+		}
+	}()
+	return d.base.EnableTOTP(ctx, i1, sa1)
+}
+
+// GetPasswordResetByHash implements repository.UserRepo
+func (d UserRepoWithLogs) GetPasswordResetByHash(ctx context.Context, s1 string) (p1 models.PasswordResetTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"s1":  s1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"p1":  p1,
+				"err": err}).Err(err).Str("decorator", "UserRepoWithLogs").Str("method", "GetPasswordResetByHash").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"p1":  p1,
+				"err": err}).Str("decorator", "UserRepoWithLogs").Str("method", "GetPasswordResetByHash").Msg("Finish")
+		}
+	}()
+	return d.base.GetPasswordResetByHash(ctx, s1)
+}
+
+// GetUserByID implements repository.UserRepo
+func (d UserRepoWithLogs) GetUserByID(ctx context.Context, i1 int64) (u1 models.UserTable, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"u1":  u1,
+				"err": err}).Err(err).Str("decorator", "UserRepoWithLogs").Str("method", "GetUserByID").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"u1":  u1,
+				"err": err}).Str("decorator", "UserRepoWithLogs").Str("method", "GetUserByID").Msg("Finish")
+		}
+	}()
+	return d.base.GetUserByID(ctx, i1)
+}
+
 // GetUserByUsername implements repository.UserRepo
 func (d UserRepoWithLogs) GetUserByUsername(ctx context.Context, s1 string) (u1 models.UserTable, err error) {
 
@@ -41,6 +130,29 @@ func (d UserRepoWithLogs) GetUserByUsername(ctx context.Context, s1 string) (u1
 	return d.base.GetUserByUsername(ctx, s1)
 }
 
+// InsertPasswordReset implements repository.UserRepo
+func (d UserRepoWithLogs) InsertPasswordReset(ctx context.Context, p1 models.PasswordResetTable) (i1 int64, err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"p1":  p1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Err(err).Str("decorator", "UserRepoWithLogs").Str("method", "InsertPasswordReset").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"i1":  i1,
+				"err": err}).Str("decorator", "UserRepoWithLogs").Str("method", "InsertPasswordReset").Msg("Finish")
+		}
+	}()
+	return d.base.InsertPasswordReset(ctx, p1)
+}
+
 // InsertUser implements repository.UserRepo
 func (d UserRepoWithLogs) InsertUser(ctx context.Context, u1 models.UserTable) (i1 int64, err error) {
 
@@ -64,6 +176,116 @@ func (d UserRepoWithLogs) InsertUser(ctx context.Context, u1 models.UserTable) (
 	return d.base.InsertUser(ctx, u1)
 }
 
+// ResetPassword implements repository.UserRepo
+func (d UserRepoWithLogs) ResetPassword(ctx context.Context, i1 int64, s1 string) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"s1":  s1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "UserRepoWithLogs").Str("method", "ResetPassword").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "UserRepoWithLogs").Str("method", "ResetPassword").Msg("Finish")
+		}
+	}()
+	return d.base.ResetPassword(ctx, i1, s1)
+}
+
+// SetTOTPSecret implements repository.UserRepo
+func (d UserRepoWithLogs) SetTOTPSecret(ctx context.Context, i1 int64, s1 string) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"s1":  s1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "UserRepoWithLogs").Str("method", "SetTOTPSecret").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "UserRepoWithLogs").Str("method", "SetTOTPSecret").Msg("Finish")
+		}
+	}()
+	return d.base.SetTOTPSecret(ctx, i1, s1)
+}
+
+// UpdatePassword implements repository.UserRepo
+func (d UserRepoWithLogs) UpdatePassword(ctx context.Context, i1 int64, s1 string) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"s1":  s1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "UserRepoWithLogs").Str("method", "UpdatePassword").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "UserRepoWithLogs").Str("method", "UpdatePassword").Msg("Finish")
+		}
+	}()
+	return d.base.UpdatePassword(ctx, i1, s1)
+}
+
+// UseRecoveryCode implements repository.UserRepo
+func (d UserRepoWithLogs) UseRecoveryCode(ctx context.Context, i1 int64, s1 string) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"s1":  s1}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "UserRepoWithLogs").Str("method", "UseRecoveryCode").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "UserRepoWithLogs").Str("method", "UseRecoveryCode").Msg("Finish")
+		}
+	}()
+	return d.base.UseRecoveryCode(ctx, i1, s1)
+}
+
+// UseTOTPStep implements repository.UserRepo
+func (d UserRepoWithLogs) UseTOTPStep(ctx context.Context, i1 int64, i2 int64) (err error) {
+
+	nl := zerolog.Ctx(ctx)
+
+	logger := nl.With().Fields(map[string]interface{}{
+		"ctx": ctx,
+		"i1":  i1,
+		"i2":  i2}).Logger()
+
+	defer func() {
+		if err != nil {
+			logger.Error().Fields(map[string]interface{}{
+				"err": err}).Err(err).Str("decorator", "UserRepoWithLogs").Str("method", "UseTOTPStep").Msg("Error detected")
+		} else {
+			logger.Debug().Fields(map[string]interface{}{
+				"err": err}).Str("decorator", "UserRepoWithLogs").Str("method", "UseTOTPStep").Msg("Finish")
+		}
+	}()
+	return d.base.UseTOTPStep(ctx, i1, i2)
+}
+
 // NewUserRepoWithLogs instruments an implementation of the repository.UserRepo with simple logging
 func NewUserRepoWithLogs(base repository.UserRepo) repository.UserRepo {
 	decorate := os.Getenv("DECORATE")
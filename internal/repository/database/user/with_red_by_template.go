@@ -22,6 +22,102 @@ type UserRepoWithRED struct {
 	histogramVec *prometheus.HistogramVec
 }
 
+// DisableTOTP implements repository.UserRepo
+func (d UserRepoWithRED) DisableTOTP(ctx context.Context, i1 int64) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "DisableTOTP",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.DisableTOTP(ctx, i1)
+}
+
+// EnableTOTP implements repository.UserRepo
+func (d UserRepoWithRED) EnableTOTP(ctx context.Context, i1 int64, sa1 []string) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "EnableTOTP",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.EnableTOTP(ctx, i1, sa1)
+}
+
+// GetPasswordResetByHash implements repository.UserRepo
+func (d UserRepoWithRED) GetPasswordResetByHash(ctx context.Context, s1 string) (p1 models.PasswordResetTable, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetPasswordResetByHash",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetPasswordResetByHash(ctx, s1)
+}
+
+// GetUserByID implements repository.UserRepo
+func (d UserRepoWithRED) GetUserByID(ctx context.Context, i1 int64) (u1 models.UserTable, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "GetUserByID",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.GetUserByID(ctx, i1)
+}
+
 // GetUserByUsername implements repository.UserRepo
 func (d UserRepoWithRED) GetUserByUsername(ctx context.Context, s1 string) (u1 models.UserTable, err error) {
 	since := time.Now()
@@ -46,6 +142,30 @@ func (d UserRepoWithRED) GetUserByUsername(ctx context.Context, s1 string) (u1 m
 	return d.base.GetUserByUsername(ctx, s1)
 }
 
+// InsertPasswordReset implements repository.UserRepo
+func (d UserRepoWithRED) InsertPasswordReset(ctx context.Context, p1 models.PasswordResetTable) (i1 int64, err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "InsertPasswordReset",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.InsertPasswordReset(ctx, p1)
+}
+
 // InsertUser implements repository.UserRepo
 func (d UserRepoWithRED) InsertUser(ctx context.Context, u1 models.UserTable) (i1 int64, err error) {
 	since := time.Now()
@@ -70,6 +190,126 @@ func (d UserRepoWithRED) InsertUser(ctx context.Context, u1 models.UserTable) (i
 	return d.base.InsertUser(ctx, u1)
 }
 
+// ResetPassword implements repository.UserRepo
+func (d UserRepoWithRED) ResetPassword(ctx context.Context, i1 int64, s1 string) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "ResetPassword",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.ResetPassword(ctx, i1, s1)
+}
+
+// SetTOTPSecret implements repository.UserRepo
+func (d UserRepoWithRED) SetTOTPSecret(ctx context.Context, i1 int64, s1 string) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "SetTOTPSecret",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.SetTOTPSecret(ctx, i1, s1)
+}
+
+// UpdatePassword implements repository.UserRepo
+func (d UserRepoWithRED) UpdatePassword(ctx context.Context, i1 int64, s1 string) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "UpdatePassword",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.UpdatePassword(ctx, i1, s1)
+}
+
+// UseRecoveryCode implements repository.UserRepo
+func (d UserRepoWithRED) UseRecoveryCode(ctx context.Context, i1 int64, s1 string) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "UseRecoveryCode",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.UseRecoveryCode(ctx, i1, s1)
+}
+
+// UseTOTPStep implements repository.UserRepo
+func (d UserRepoWithRED) UseTOTPStep(ctx context.Context, i1 int64, i2 int64) (err error) {
+	since := time.Now()
+	defer func() {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+
+		labels := prometheus.Labels{
+			"status": status,
+			"method": "UseTOTPStep",
+		}
+
+		observer, err := d.histogramVec.GetMetricWith(labels)
+		if err != nil {
+			fmt.Printf("Metric: Error to get metric with labels %v\n", labels)
+		}
+
+		observer.Observe(float64(time.Since(since).Milliseconds()))
+	}()
+	return d.base.UseTOTPStep(ctx, i1, i2)
+}
+
 // NewUserRepoWithRED returns an instance of the repository.UserRepo decorated with red histogram metric
 func NewUserRepoWithRED(base repository.UserRepo, constLabels prometheus.Labels) (decorator repository.UserRepo, err error) {
 	decorate := os.Getenv("DECORATE")
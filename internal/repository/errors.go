@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound is returned when a record does not exist or is not owned by the caller
+	ErrNotFound = errors.New("record not found")
+	// ErrInUse is returned when a record can not be deleted because other records still reference it
+	ErrInUse = errors.New("record is in use")
+	// ErrAlreadyUsed is returned when a single use record, such as a refresh token, was already used
+	ErrAlreadyUsed = errors.New("record was already used")
+	// ErrAlreadyExists is returned when a record can not be inserted because it breaks a unique constraint,
+	// such as an expense with the external reference of another expense of the card
+	ErrAlreadyExists = errors.New("record already exists")
+)
+
+// BatchItemError is returned when an item of a batch write fails.
+// The whole batch is rolled back and Index points to the failing item.
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+// Error is the string representation of BatchItemError
+func (bie BatchItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", bie.Index, bie.Err)
+}
+
+// Unwrap returns the error of the failing item
+func (bie BatchItemError) Unwrap() error {
+	return bie.Err
+}
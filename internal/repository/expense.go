@@ -10,13 +10,20 @@ import (
 //go:generate gowrap gen -g -i ExpenseRepo -t ./templates/log_template.go.tmpl -o ./database/expense/with_logs_by_template.go
 //go:generate gowrap gen -g -i ExpenseRepo -t ./templates/red_template.go.tmpl -o ./database/expense/with_red_by_template.go
 // ExpenseRepo defines the expense repository interface.
+// Expenses are owned by a user: lookups take the owner user id right after the context.
 type ExpenseRepo interface {
 	InsertExpense(context.Context, models.ExpenseTable) (int64, error)
+	InsertExpenses(context.Context, []models.ExpenseTable) ([]int64, error)
 	UpdateExpense(context.Context, models.ExpenseTable) (int64, error)
-	GetExpenseByID(context.Context, int64) (models.ExpenseView, error)
-	GetExpensesByDates(context.Context, time.Time, time.Time) ([]models.ExpenseView, error)
-	GetExpensesByCategory(context.Context, string) ([]models.ExpenseView, error)
-	GetExpensesBySubCategory(context.Context, string) ([]models.ExpenseView, error)
-	GetExpensesByCard(context.Context, string) ([]models.ExpenseView, error)
-	DeleteExpense(context.Context, int64) error
+	GetExpenseByID(context.Context, int64, int64) (models.ExpenseView, error)
+	GetExpensesByDates(context.Context, int64, time.Time, time.Time) ([]models.ExpenseView, error)
+	GetExpensesByCategory(context.Context, int64, string) ([]models.ExpenseView, error)
+	GetExpensesBySubCategory(context.Context, int64, string) ([]models.ExpenseView, error)
+	GetExpensesByCard(context.Context, int64, string) ([]models.ExpenseView, error)
+	GetExpensesByCardAndValue(context.Context, int64, int64, models.Money, time.Time, time.Time) ([]models.ExpenseView, error)
+	GetExpenseByExternalReference(context.Context, int64, int64, string) (models.ExpenseTable, error)
+	SearchExpenses(context.Context, int64, models.SearchFilter) ([]models.ExpenseView, error)
+	GetExpenseDailyTotals(context.Context, int64, time.Time, time.Time) ([]models.DailyTotal, error)
+	GetExpenseTagDailyTotals(context.Context, int64, time.Time, time.Time) ([]models.DailyTotal, error)
+	DeleteExpense(context.Context, int64, int64) error
 }
@@ -9,10 +9,16 @@ import (
 //go:generate gowrap gen -g -i ExpenseCategoryRepo -t ./templates/log_template.go.tmpl -o ./database/expense/category_with_logs_by_template.go
 //go:generate gowrap gen -g -i ExpenseCategoryRepo -t ./templates/red_template.go.tmpl -o ./database/expense/category_with_red_by_template.go
 // ExpenseCategoryRepo defines the expense category repository interface.
+// DeleteExpenseCategory returns ErrInUse if the category still has subcategories or budgets.
+// ReplaceExpenseCategory moves the subcategories of a category to its replacement and deletes it.
+// MergeExpenseCategory moves the subcategories and budgets of a category to the target and deletes it, or only counts them on a dry run.
 type ExpenseCategoryRepo interface {
 	InsertExpenseCategory(context.Context, models.ExpenseCategoryTable) (int64, error)
 	UpdateExpenseCategory(context.Context, models.ExpenseCategoryTable) (int64, error)
 	GetExpenseCategoryByID(context.Context, int64) (models.ExpenseCategoryTable, error)
 	GetExpenseCategoryByName(context.Context, string) (models.ExpenseCategoryTable, error)
+	GetExpenseCategories(context.Context) ([]models.ExpenseCategoryTable, error)
 	DeleteExpenseCategory(context.Context, int64) error
+	ReplaceExpenseCategory(context.Context, int64, int64) error
+	MergeExpenseCategory(context.Context, int64, int64, bool) (models.CategoryMerge, error)
 }
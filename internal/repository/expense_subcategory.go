@@ -8,11 +8,18 @@ import (
 
 //go:generate gowrap gen -g -i ExpenseSubCategoryRepo -t ./templates/log_template.go.tmpl -o ./database/expense/subcategory_with_logs_by_template.go
 //go:generate gowrap gen -g -i ExpenseSubCategoryRepo -t ./templates/red_template.go.tmpl -o ./database/expense/subcategory_with_red_by_template.go
-// ExpenseSubCategoryRepo defines the expense subcategory repository interface
+// ExpenseSubCategoryRepo defines the expense subcategory repository interface.
+// DeleteExpenseSubCategory returns ErrInUse if the subcategory still has expenses, budgets, rules or recurring transactions.
+// ReplaceExpenseSubCategory moves the expenses of a subcategory to its replacement and deletes it.
+// MergeExpenseSubCategory moves the expenses, budgets, rules and recurring transactions of a subcategory to the target
+// and deletes it, or only counts them on a dry run.
 type ExpenseSubCategoryRepo interface {
 	InsertExpenseSubCategory(context.Context, models.ExpenseSubCategoryTable) (int64, error)
 	UpdateExpenseSubCategory(context.Context, models.ExpenseSubCategoryTable) (int64, error)
 	GetExpenseSubCategoryByID(context.Context, int64) (models.ExpenseSubCategoryTable, error)
 	GetExpenseSubCategoryByName(context.Context, string) (models.ExpenseSubCategoryTable, error)
+	GetExpenseSubCategories(context.Context) ([]models.ExpenseSubCategoryTable, error)
 	DeleteExpenseSubCategory(context.Context, int64) error
+	ReplaceExpenseSubCategory(context.Context, int64, int64) error
+	MergeExpenseSubCategory(context.Context, int64, int64, bool) (models.CategoryMerge, error)
 }
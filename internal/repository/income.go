@@ -10,12 +10,18 @@ import (
 //go:generate gowrap gen -g -i IncomeRepo -t ./templates/log_template.go.tmpl -o ./database/income/with_logs_by_template.go
 //go:generate gowrap gen -g -i IncomeRepo -t ./templates/red_template.go.tmpl -o ./database/income/with_red_by_template.go
 // IncomeRepo defines the incomes repository interface.
+// Incomes are owned by a user: lookups take the owner user id right after the context.
 type IncomeRepo interface {
 	InsertIncome(context.Context, models.IncomeTable) (int64, error)
+	InsertIncomes(context.Context, []models.IncomeTable) ([]int64, error)
 	UpdateIncome(context.Context, models.IncomeTable) (int64, error)
-	GetIncomeByID(context.Context, int64) (models.IncomeView, error)
-	GetIncomesByDates(context.Context, time.Time, time.Time) ([]models.IncomeView, error)
-	GetIncomesByCategory(context.Context, string) ([]models.IncomeView, error)
-	GetIncomesByCard(context.Context, string) ([]models.IncomeView, error)
-	DeleteIncome(context.Context, int64) error
+	GetIncomeByID(context.Context, int64, int64) (models.IncomeView, error)
+	GetIncomesByDates(context.Context, int64, time.Time, time.Time) ([]models.IncomeView, error)
+	GetIncomesByCategory(context.Context, int64, string) ([]models.IncomeView, error)
+	GetIncomesByCard(context.Context, int64, string) ([]models.IncomeView, error)
+	GetIncomesByCardAndValue(context.Context, int64, int64, models.Money, time.Time, time.Time) ([]models.IncomeView, error)
+	GetIncomeByExternalReference(context.Context, int64, int64, string) (models.IncomeTable, error)
+	SearchIncomes(context.Context, int64, models.SearchFilter) ([]models.IncomeView, error)
+	GetIncomeDailyTotals(context.Context, int64, time.Time, time.Time) ([]models.DailyTotal, error)
+	DeleteIncome(context.Context, int64, int64) error
 }
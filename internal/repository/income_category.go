@@ -9,10 +9,17 @@ import (
 //go:generate gowrap gen -g -i IncomeCategoryRepo -t ./templates/log_template.go.tmpl -o ./database/income/category_with_logs_by_template.go
 //go:generate gowrap gen -g -i IncomeCategoryRepo -t ./templates/red_template.go.tmpl -o ./database/income/category_with_red_by_template.go
 // IncomeCategoryRepo defines the income category repository interface.
+// DeleteIncomeCategory returns ErrInUse if the category still has incomes, rules or recurring transactions.
+// ReplaceIncomeCategory moves the incomes of a category to its replacement and deletes it.
+// MergeIncomeCategory moves the incomes, rules and recurring transactions of a category to the target and deletes it,
+// or only counts them on a dry run.
 type IncomeCategoryRepo interface {
 	InsertIncomeCategory(context.Context, models.IncomeCategoryTable) (int64, error)
 	UpdateIncomeCategory(context.Context, models.IncomeCategoryTable) (int64, error)
 	GetIncomeCategoryByID(context.Context, int64) (models.IncomeCategoryTable, error)
 	GetIncomeCategoryByName(context.Context, string) (models.IncomeCategoryTable, error)
+	GetIncomeCategories(context.Context) ([]models.IncomeCategoryTable, error)
 	DeleteIncomeCategory(context.Context, int64) error
+	ReplaceIncomeCategory(context.Context, int64, int64) error
+	MergeIncomeCategory(context.Context, int64, int64, bool) (models.CategoryMerge, error)
 }
@@ -46,7 +46,7 @@ func (c Card) UpdateCard(ctx context.Context, card models.CardTable) (int64, err
 }
 
 // GetCardByID returns the card from the cache if card with that id exists
-func (c Card) GetCardByID(ctx context.Context, id int64) (models.CardTable, error) {
+func (c Card) GetCardByID(ctx context.Context, userID int64, id int64) (models.CardTable, error) {
 
 	switch id {
 	case IncomeSalaryCard.ID:
@@ -57,7 +57,7 @@ func (c Card) GetCardByID(ctx context.Context, id int64) (models.CardTable, erro
 }
 
 // GetCardByName returns the card from the cache if card with that name exists
-func (c Card) GetCardByName(ctx context.Context, name string) (models.CardTable, error) {
+func (c Card) GetCardByName(ctx context.Context, userID int64, name string) (models.CardTable, error) {
 
 	switch name {
 	case IncomeSalaryCard.Name:
@@ -67,8 +67,13 @@ func (c Card) GetCardByName(ctx context.Context, name string) (models.CardTable,
 	}
 }
 
+// GetCards mocks getting the cards of a user
+func (c Card) GetCards(ctx context.Context, userID int64) ([]models.CardTable, error) {
+	return []models.CardTable{IncomeSalaryCard}, nil
+}
+
 // DeleteCard deletes the card from cache if it exists
-func (c Card) DeleteCard(ctx context.Context, id int64) error {
+func (c Card) DeleteCard(ctx context.Context, userID int64, id int64) error {
 
 	switch id {
 	case IncomeSalaryCard.ID:
@@ -3,22 +3,40 @@ package mock
 import (
 	"context"
 	"errors"
+	"sort"
 	"time"
 
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
 )
 
+const (
+	IncomeSalaryExternalReference = "FITID-SALARY"
+)
+
 var (
 	IncomeSalaryDate = time.Now().UTC()
 	IncomeSalary     = models.IncomeTable{
 		ID:          1,
-		Value:       1000,
+		Value:       models.MustParseMoney("1000"),
 		Date:        IncomeSalaryDate,
 		CategoryID:  1,
 		CardID:      IncomeSalaryCard.ID,
 		Description: "Mock",
 	}
 
+	IncomeBonusDate = IncomeSalaryDate
+	IncomeBonusView = models.IncomeView{
+		ID:          2,
+		Value:       models.MustParseMoney("500"),
+		Date:        IncomeBonusDate,
+		Category:    IncomeSalaryCategory.Name,
+		Card:        IncomeSalaryCard.Name,
+		CategoryID:  IncomeSalaryCategory.ID,
+		CardID:      IncomeSalaryCard.ID,
+		Description: "Mock bonus",
+	}
+
 	IncomeSalaryView = models.IncomeView{
 		ID:          IncomeSalary.ID,
 		Value:       IncomeSalary.Value,
@@ -51,6 +69,21 @@ func (i Income) InsertIncome(ctx context.Context, income models.IncomeTable) (in
 	}
 }
 
+// InsertIncomes mocks a batch income insert
+func (i Income) InsertIncomes(ctx context.Context, incomes []models.IncomeTable) ([]int64, error) {
+
+	ids := make([]int64, 0, len(incomes))
+	for idx, income := range incomes {
+		id, err := i.InsertIncome(ctx, income)
+		if err != nil {
+			return []int64{}, repository.BatchItemError{Index: idx, Err: err}
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
 // InsertIncome mocks an income update
 func (i Income) UpdateIncome(ctx context.Context, income models.IncomeTable) (int64, error) {
 
@@ -58,23 +91,23 @@ func (i Income) UpdateIncome(ctx context.Context, income models.IncomeTable) (in
 	case 1:
 		return 1, nil
 	default:
-		return 0, errors.New("could not update income")
+		return 0, repository.ErrNotFound
 	}
 }
 
 // InsertIncome mocks an income get by id
-func (i Income) GetIncomeByID(ctx context.Context, id int64) (models.IncomeView, error) {
+func (i Income) GetIncomeByID(ctx context.Context, userID int64, id int64) (models.IncomeView, error) {
 
 	switch id {
 	case IncomeSalary.ID:
 		return IncomeSalaryView, nil
 	default:
-		return models.IncomeView{}, errors.New("could not get income view by id")
+		return models.IncomeView{}, repository.ErrNotFound
 	}
 }
 
 // GetIncomesByDates mocks an income get by dates
-func (i Income) GetIncomesByDates(ctx context.Context, min time.Time, max time.Time) ([]models.IncomeView, error) {
+func (i Income) GetIncomesByDates(ctx context.Context, userID int64, min time.Time, max time.Time) ([]models.IncomeView, error) {
 
 	if min.Before(IncomeSalaryDate) && max.After(IncomeSalaryDate) {
 		return []models.IncomeView{
@@ -86,7 +119,7 @@ func (i Income) GetIncomesByDates(ctx context.Context, min time.Time, max time.T
 }
 
 // GetIncomesByCategory mocks an income get by category
-func (i Income) GetIncomesByCategory(ctx context.Context, category string) ([]models.IncomeView, error) {
+func (i Income) GetIncomesByCategory(ctx context.Context, userID int64, category string) ([]models.IncomeView, error) {
 
 	if category == IncomeSalaryCategory.Name {
 		return []models.IncomeView{
@@ -98,7 +131,7 @@ func (i Income) GetIncomesByCategory(ctx context.Context, category string) ([]mo
 }
 
 // GetIncomesByCard mocks an income get by card
-func (i Income) GetIncomesByCard(ctx context.Context, card string) ([]models.IncomeView, error) {
+func (i Income) GetIncomesByCard(ctx context.Context, userID int64, card string) ([]models.IncomeView, error) {
 
 	if card == IncomeSalaryCard.Name {
 		return []models.IncomeView{
@@ -109,13 +142,88 @@ func (i Income) GetIncomesByCard(ctx context.Context, card string) ([]models.Inc
 	return []models.IncomeView{}, errors.New("could not get income view by card")
 }
 
+// GetIncomesByCardAndValue mocks an income get by card and value, where only the bonus income can be found
+func (i Income) GetIncomesByCardAndValue(
+	ctx context.Context,
+	userID int64,
+	cardID int64,
+	value models.Money,
+	min time.Time,
+	max time.Time,
+) ([]models.IncomeView, error) {
+
+	if cardID == IncomeBonusView.CardID && value == IncomeBonusView.Value &&
+		!min.After(IncomeBonusDate) && !max.Before(IncomeBonusDate) {
+		return []models.IncomeView{
+			IncomeBonusView,
+		}, nil
+	}
+
+	return []models.IncomeView{}, nil
+}
+
+// GetIncomeByExternalReference mocks an income get by external reference
+func (i Income) GetIncomeByExternalReference(ctx context.Context, userID int64, cardID int64, reference string) (models.IncomeTable, error) {
+
+	if cardID == IncomeSalaryCard.ID && reference == IncomeSalaryExternalReference {
+		income := IncomeSalary
+		income.ExternalReference = IncomeSalaryExternalReference
+		return income, nil
+	}
+
+	return models.IncomeTable{}, repository.ErrNotFound
+}
+
+// SearchIncomes mocks an income search over the salary and the bonus incomes
+func (i Income) SearchIncomes(ctx context.Context, userID int64, filter models.SearchFilter) ([]models.IncomeView, error) {
+
+	incomes := []models.IncomeView{}
+	for _, inc := range []models.IncomeView{IncomeSalaryView, IncomeBonusView} {
+		if filter.Matches(inc.ID, inc.Date, inc.Value, inc.Category, "", inc.Card, inc.Description, inc.Tags) {
+			incomes = append(incomes, inc)
+		}
+	}
+
+	sort.Slice(incomes, func(i, j int) bool {
+		return filter.Less(
+			filter.CursorOf(incomes[i].ID, incomes[i].Date, incomes[i].Value),
+			filter.CursorOf(incomes[j].ID, incomes[j].Date, incomes[j].Value),
+		)
+	})
+
+	if filter.Limit > 0 && len(incomes) > filter.Limit {
+		incomes = incomes[:filter.Limit]
+	}
+
+	return incomes, nil
+}
+
+// GetIncomeDailyTotals mocks an income daily totals get, where the salary and the bonus incomes are summed on their day
+func (i Income) GetIncomeDailyTotals(ctx context.Context, userID int64, min time.Time, max time.Time) ([]models.DailyTotal, error) {
+
+	if min.Before(IncomeSalaryDate) && max.After(IncomeSalaryDate) {
+		return []models.DailyTotal{
+			{
+				Day:      time.Date(IncomeSalaryDate.Year(), IncomeSalaryDate.Month(), IncomeSalaryDate.Day(), 0, 0, 0, 0, time.UTC),
+				Category: IncomeSalaryCategory.Name,
+				Card:     IncomeSalaryCard.Name,
+				Currency: models.DefaultCurrency,
+				Value:    IncomeSalaryView.Value + IncomeBonusView.Value,
+				Count:    2,
+			},
+		}, nil
+	}
+
+	return []models.DailyTotal{}, nil
+}
+
 // DeleteIncome mocks an income delete
-func (i Income) DeleteIncome(ctx context.Context, id int64) error {
+func (i Income) DeleteIncome(ctx context.Context, userID int64, id int64) error {
 
 	switch id {
 	case IncomeSalary.ID:
 		return nil
 	default:
-		return errors.New("income with this id does not exist")
+		return repository.ErrNotFound
 	}
 }
@@ -79,3 +79,33 @@ func (ic IncomeCategory) DeleteIncomeCategory(ctx context.Context, id int64) err
 		return errors.New("income category with this id does not exist")
 	}
 }
+
+// GetIncomeCategories mocks getting the income categories
+func (ic IncomeCategory) GetIncomeCategories(ctx context.Context) ([]models.IncomeCategoryTable, error) {
+	return []models.IncomeCategoryTable{IncomeSalaryCategory}, nil
+}
+
+// ReplaceIncomeCategory mocks moving the incomes of an income category to its replacement
+func (ic IncomeCategory) ReplaceIncomeCategory(ctx context.Context, id int64, replacementID int64) error {
+	switch replacementID {
+	case IncomeSalaryCategory.ID:
+		return ic.DeleteIncomeCategory(ctx, id)
+	default:
+		return errors.New("income category with this id does not exist")
+	}
+}
+
+// MergeIncomeCategory mocks merging an income category into the target
+func (ic IncomeCategory) MergeIncomeCategory(
+	ctx context.Context,
+	id int64,
+	targetID int64,
+	dryRun bool,
+) (models.CategoryMerge, error) {
+	switch targetID {
+	case IncomeSalaryCategory.ID:
+		return models.CategoryMerge{Transactions: 1, Rules: 1}, nil
+	default:
+		return models.CategoryMerge{}, errors.New("income category with this id does not exist")
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// AttachmentTable is the db attachment table model: the metadata of a file kept for an expense or an income.
+// Exactly one of the expense and income ids is set. The content is on the blob storage under the storage key.
+type AttachmentTable struct {
+	ID          int64     `json:"id,omitempty"`
+	FileName    string    `json:"file_name,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	Size        int64     `json:"size,omitempty"`
+	Checksum    string    `json:"checksum,omitempty"` // hex SHA-256 of the content
+	StorageKey  string    `json:"storage_key,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	ExpenseID   int64     `json:"expense_id,omitempty"`
+	IncomeID    int64     `json:"income_id,omitempty"`
+	UserID      int64     `json:"user_id,omitempty"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// The kinds of the movements of a card
+const (
+	MovementExpense     = "expense"
+	MovementIncome      = "income"
+	MovementTransferOut = "transfer_out"
+	MovementTransferIn  = "transfer_in"
+)
+
+// CardMovement is money in or out of a card: an expense, an income or a side of a transfer.
+// The amount is positive in and negative out, and a transfer out includes its fee.
+// The currency is the one of the expense or income, or of the source card of a transfer.
+type CardMovement struct {
+	Kind        string    `json:"kind,omitempty"`
+	ID          int64     `json:"id,omitempty"`
+	Date        time.Time `json:"date,omitempty"`
+	Amount      Money     `json:"amount,omitempty"`
+	Currency    string    `json:"currency,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Cleared     bool      `json:"cleared,omitempty"`
+}
+
+// MovementRef identifies a movement of a card: the id of an expense, an income or a transfer and its kind
+type MovementRef struct {
+	Kind string `json:"kind,omitempty"`
+	ID   int64  `json:"id,omitempty"`
+}
+
+// Ref returns the reference of the movement
+func (m CardMovement) Ref() MovementRef {
+	return MovementRef{
+		Kind: m.Kind,
+		ID:   m.ID,
+	}
+}
+
+// ReconciliationTable is the db reconciliation table model: the balance of a card on a bank statement
+// and the balance of its cleared movements on the same date
+type ReconciliationTable struct {
+	ID               int64     `json:"id,omitempty"`
+	Date             time.Time `json:"date,omitempty"`
+	StatementBalance Money     `json:"statement_balance,omitempty"`
+	ClearedBalance   Money     `json:"cleared_balance,omitempty"`
+	CardID           int64     `json:"card_id,omitempty"`
+	UserID           int64     `json:"user_id,omitempty"`
+}
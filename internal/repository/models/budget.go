@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// BudgetTable is the db budget table model.
+// Exactly one of the category id and the subcategory id is set.
+// The start month is the first day of the first month the budget applies to.
+type BudgetTable struct {
+	ID            int64     `json:"id,omitempty"`
+	CategoryID    int64     `json:"category_id,omitempty"`
+	SubCategoryID int64     `json:"sub_category_id,omitempty"`
+	MonthLimit    Money     `json:"month_limit,omitempty"`
+	Rollover      bool      `json:"rollover,omitempty"`
+	StartMonth    time.Time `json:"start_month,omitempty"`
+	UserID        int64     `json:"user_id,omitempty"`
+}
+
+// BudgetView is the db budget model joined with the names of its category or subcategory
+type BudgetView struct {
+	ID            int64     `json:"id,omitempty"`
+	CategoryID    int64     `json:"category_id,omitempty"`
+	Category      string    `json:"category,omitempty"`
+	SubCategoryID int64     `json:"sub_category_id,omitempty"`
+	SubCategory   string    `json:"sub_category,omitempty"`
+	MonthLimit    Money     `json:"month_limit,omitempty"`
+	Rollover      bool      `json:"rollover,omitempty"`
+	StartMonth    time.Time `json:"start_month,omitempty"`
+	UserID        int64     `json:"user_id,omitempty"`
+}
+
+// MonthlySpending is the sum of the expenses of a subcategory in a month and a currency
+type MonthlySpending struct {
+	Month         time.Time `json:"month,omitempty"`
+	CategoryID    int64     `json:"category_id,omitempty"`
+	SubCategoryID int64     `json:"sub_category_id,omitempty"`
+	Currency      string    `json:"currency,omitempty"`
+	Value         Money     `json:"value,omitempty"`
+}
@@ -1,7 +1,17 @@
 package models
 
-// CardTable is the rds card model
+import "time"
+
+// DefaultCurrency is the currency of cards created without one, and of the rows that existed before currencies
+const DefaultCurrency = "EUR"
+
+// CardTable is the rds card model.
+// The balance of a card starts at its opening balance on its opening date; a zero opening date counts every movement.
 type CardTable struct {
-	ID   int64  `json:"id,omitempty"`
-	Name string `json:"name,omitempty"`
+	ID             int64     `json:"id,omitempty"`
+	Name           string    `json:"name,omitempty"`
+	Currency       string    `json:"currency,omitempty"` // ISO 4217 code, such as EUR
+	OpeningBalance Money     `json:"opening_balance,omitempty"`
+	OpeningDate    time.Time `json:"opening_date,omitempty"`
+	UserID         int64     `json:"user_id,omitempty"`
 }
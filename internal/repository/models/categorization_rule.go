@@ -0,0 +1,34 @@
+package models
+
+// CategorizationRuleTable is the db categorization rule table model.
+// A zero card id, min value or max value matches any card or value.
+// Exactly one of the subcategory id and the income category id is set.
+type CategorizationRuleTable struct {
+	ID                 int64  `json:"id,omitempty"`
+	Priority           int64  `json:"priority,omitempty"`
+	DescriptionPattern string `json:"description_pattern,omitempty"`
+	DescriptionRegex   bool   `json:"description_regex,omitempty"`
+	MinValue           Money  `json:"min_value,omitempty"`
+	MaxValue           Money  `json:"max_value,omitempty"`
+	CardID             int64  `json:"card_id,omitempty"`
+	SubCategoryID      int64  `json:"sub_category_id,omitempty"`
+	IncomeCategoryID   int64  `json:"income_category_id,omitempty"`
+	UserID             int64  `json:"user_id,omitempty"`
+}
+
+// CategorizationRuleView is the db categorization rule model joined with the names of its card and categories
+type CategorizationRuleView struct {
+	ID                 int64  `json:"id,omitempty"`
+	Priority           int64  `json:"priority,omitempty"`
+	DescriptionPattern string `json:"description_pattern,omitempty"`
+	DescriptionRegex   bool   `json:"description_regex,omitempty"`
+	MinValue           Money  `json:"min_value,omitempty"`
+	MaxValue           Money  `json:"max_value,omitempty"`
+	CardID             int64  `json:"card_id,omitempty"`
+	Card               string `json:"card,omitempty"`
+	SubCategoryID      int64  `json:"sub_category_id,omitempty"`
+	SubCategory        string `json:"sub_category,omitempty"`
+	IncomeCategoryID   int64  `json:"income_category_id,omitempty"`
+	IncomeCategory     string `json:"income_category,omitempty"`
+	UserID             int64  `json:"user_id,omitempty"`
+}
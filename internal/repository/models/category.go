@@ -0,0 +1,11 @@
+package models
+
+// CategoryMerge counts the rows a merge of a category into another one moves, or would move on a dry run.
+// Budgets the user also has on the target are folded into them, adding up their month limits.
+type CategoryMerge struct {
+	Transactions          int64 `json:"transactions"`           // expenses or incomes
+	SubCategories         int64 `json:"sub_categories"`         // expense categories only
+	Budgets               int64 `json:"budgets"`                // expense categories and subcategories only
+	Rules                 int64 `json:"rules"`                  // expense subcategories and income categories only
+	RecurringTransactions int64 `json:"recurring_transactions"` // expense subcategories and income categories only
+}
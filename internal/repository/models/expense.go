@@ -4,26 +4,55 @@ import "time"
 
 // ExpenseView is the db expense view model
 type ExpenseView struct {
-	ID            int64     `json:"id,omitempty"`
-	Value         float64   `json:"value,omitempty"`
-	Date          time.Time `json:"date,omitempty"`
-	Category      string    `json:"category,omitempty"`
-	SubCategory   string    `json:"sub_category,omitempty"`
-	Card          string    `json:"card,omitempty"`
-	CategoryID    int64     `json:"category_id,omitempty"`
-	SubCategoryID int64     `json:"sub_category_id,omitempty"`
-	CardID        int64     `json:"card_id,omitempty"`
-	Description   string    `json:"description,omitempty"`
+	ID            int64              `json:"id,omitempty"`
+	Value         Money              `json:"value,omitempty"`
+	Currency      string             `json:"currency,omitempty"`
+	Date          time.Time          `json:"date,omitempty"`
+	Category      string             `json:"category,omitempty"`
+	SubCategory   string             `json:"sub_category,omitempty"`
+	Card          string             `json:"card,omitempty"`
+	CategoryID    int64              `json:"category_id,omitempty"`
+	SubCategoryID int64              `json:"sub_category_id,omitempty"`
+	CardID        int64              `json:"card_id,omitempty"`
+	Description   string             `json:"description,omitempty"`
+	UserID        int64              `json:"user_id,omitempty"`
+	Splits        []ExpenseSplitView `json:"splits,omitempty"` // empty if the expense is not split
+	Tags          []string           `json:"tags,omitempty"`
 }
 
 // ExpenseTable is the db expense table model
 type ExpenseTable struct {
-	ID            int64     `json:"id,omitempty"`
-	Value         float64   `json:"value,omitempty"`
-	Date          time.Time `json:"date,omitempty"`
-	SubCategoryID int64     `json:"sub_category_id,omitempty"`
-	CardID        int64     `json:"card_id,omitempty"`
-	Description   string    `json:"description,omitempty"`
+	ID                int64               `json:"id,omitempty"`
+	Value             Money               `json:"value,omitempty"`
+	Currency          string              `json:"currency,omitempty"` // defaults to the currency of the card
+	Date              time.Time           `json:"date,omitempty"`
+	SubCategoryID     int64               `json:"sub_category_id,omitempty"`
+	CardID            int64               `json:"card_id,omitempty"`
+	Description       string              `json:"description,omitempty"`
+	ExternalReference string              `json:"external_reference,omitempty"` // bank reference of imported rows, or occurrence of a recurring transaction
+	UserID            int64               `json:"user_id,omitempty"`
+	Splits            []ExpenseSplitTable `json:"splits,omitempty"` // lines of their own subcategory adding up to the value, if split
+	Tags              []string            `json:"tags,omitempty"`   // replace the tags of the expense, which are created on first use
+}
+
+// ExpenseSplitTable is the db expense split table model, a line of an expense split across several subcategories
+type ExpenseSplitTable struct {
+	ID            int64  `json:"id,omitempty"`
+	ExpenseID     int64  `json:"expense_id,omitempty"`
+	SubCategoryID int64  `json:"sub_category_id,omitempty"`
+	Value         Money  `json:"value,omitempty"`
+	Note          string `json:"note,omitempty"`
+}
+
+// ExpenseSplitView is the db expense split model with the names of its category and subcategory
+type ExpenseSplitView struct {
+	ID            int64  `json:"id,omitempty"`
+	Value         Money  `json:"value,omitempty"`
+	Category      string `json:"category,omitempty"`
+	SubCategory   string `json:"sub_category,omitempty"`
+	CategoryID    int64  `json:"category_id,omitempty"`
+	SubCategoryID int64  `json:"sub_category_id,omitempty"`
+	Note          string `json:"note,omitempty"`
 }
 
 // ExpenseCategoryTable is the db expense category table model
@@ -5,23 +5,30 @@ import "time"
 // IncomeView is the db expense view model
 type IncomeView struct {
 	ID          int64     `json:"id,omitempty"`
-	Value       float64   `json:"value,omitempty"`
+	Value       Money     `json:"value,omitempty"`
+	Currency    string    `json:"currency,omitempty"`
 	Date        time.Time `json:"date,omitempty"`
 	Category    string    `json:"category,omitempty"`
 	Card        string    `json:"card,omitempty"`
 	CategoryID  int64     `json:"category_id,omitempty"`
 	CardID      int64     `json:"card_id,omitempty"`
 	Description string    `json:"description,omitempty"`
+	UserID      int64     `json:"user_id,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
 }
 
 // IncomeTable is the db expense table model
 type IncomeTable struct {
-	ID          int64     `json:"id,omitempty"`
-	Value       float64   `json:"value,omitempty"`
-	Date        time.Time `json:"date,omitempty"`
-	CategoryID  int64     `json:"category_id,omitempty"`
-	CardID      int64     `json:"card_id,omitempty"`
-	Description string    `json:"description,omitempty"`
+	ID                int64     `json:"id,omitempty"`
+	Value             Money     `json:"value,omitempty"`
+	Currency          string    `json:"currency,omitempty"` // defaults to the currency of the card
+	Date              time.Time `json:"date,omitempty"`
+	CategoryID        int64     `json:"category_id,omitempty"`
+	CardID            int64     `json:"card_id,omitempty"`
+	Description       string    `json:"description,omitempty"`
+	ExternalReference string    `json:"external_reference,omitempty"` // bank reference of imported rows, or occurrence of a recurring transaction
+	UserID            int64     `json:"user_id,omitempty"`
+	Tags              []string  `json:"tags,omitempty"` // replace the tags of the income, which are created on first use
 }
 
 // IncomeCategoryTable is the db expense category table model
@@ -0,0 +1,146 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MoneyDecimals is the number of decimal places money is kept with
+	MoneyDecimals = 2
+
+	// maxMoneyDigits keeps the minor units of an amount inside an int64
+	maxMoneyDigits = 17
+)
+
+// ErrInvalidMoney is returned when an amount is not a decimal number with up to MoneyDecimals decimal places
+var ErrInvalidMoney = errors.New("amount must be a decimal number with up to 2 decimal places")
+
+// Money is an exact amount of money, in minor units (cents).
+// It is stored as NUMERIC(14,2) and written as a decimal string, such as "-12.30", on JSON.
+type Money int64
+
+// ParseMoney parses a decimal amount such as "12", "-12.3" or "12.30".
+// Amounts with more than MoneyDecimals decimal places are rejected rather than rounded.
+func ParseMoney(amount string) (Money, error) {
+
+	amount = strings.TrimSpace(amount)
+
+	negative := false
+	switch {
+	case strings.HasPrefix(amount, "-"):
+		negative = true
+		amount = amount[1:]
+	case strings.HasPrefix(amount, "+"):
+		amount = amount[1:]
+	}
+
+	units, decimals := amount, ""
+	if idx := strings.IndexByte(amount, '.'); idx >= 0 {
+		units, decimals = amount[:idx], amount[idx+1:]
+	}
+
+	if units == "" && decimals == "" || len(decimals) > MoneyDecimals || len(units)+MoneyDecimals > maxMoneyDigits {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, amount)
+	}
+
+	digits := units + decimals + strings.Repeat("0", MoneyDecimals-len(decimals))
+	for _, digit := range digits {
+		if digit < '0' || digit > '9' {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, amount)
+		}
+	}
+
+	cents, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, amount)
+	}
+
+	if negative {
+		return Money(-cents), nil
+	}
+	return Money(cents), nil
+}
+
+// MustParseMoney parses an amount that is known to be valid, such as a constant, and panics otherwise
+func MustParseMoney(amount string) Money {
+	money, err := ParseMoney(amount)
+	if err != nil {
+		panic(err)
+	}
+	return money
+}
+
+// String returns the amount as a decimal with MoneyDecimals decimal places
+func (m Money) String() string {
+
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// Abs returns the amount without its sign
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// Scan reads a NUMERIC column
+func (m *Money) Scan(src interface{}) error {
+
+	var err error
+	switch value := src.(type) {
+	case []byte:
+		*m, err = ParseMoney(string(value))
+	case string:
+		*m, err = ParseMoney(value)
+	case int64:
+		*m = Money(value * 100)
+	default:
+		err = fmt.Errorf("could not scan %T into money", src)
+	}
+
+	return err
+}
+
+// Value writes the amount to a NUMERIC column
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// MarshalJSON writes the amount as a decimal string
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.String())), nil
+}
+
+// UnmarshalJSON reads the amount from a decimal string or, for older clients, from a JSON number,
+// without going through floating point
+func (m *Money) UnmarshalJSON(data []byte) error {
+
+	amount := string(data)
+	if amount == "null" {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(amount); err == nil {
+		amount = unquoted
+	}
+
+	money, err := ParseMoney(amount)
+	if err != nil {
+		return err
+	}
+
+	*m = money
+	return nil
+}
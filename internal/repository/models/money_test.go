@@ -0,0 +1,84 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		amount  string
+		want    Money
+		wantErr bool
+	}{
+		{name: "Units", amount: "12", want: 1200},
+		{name: "One decimal place", amount: "12.3", want: 1230},
+		{name: "Two decimal places", amount: "12.30", want: 1230},
+		{name: "Negative", amount: "-0.05", want: -5},
+		{name: "Positive sign", amount: "+7.5", want: 750},
+		{name: "No units", amount: ".5", want: 50},
+		{name: "Not rounded to binary floating point", amount: "0.29", want: 29},
+		{name: "Too many decimal places", amount: "12.345", wantErr: true},
+		{name: "Empty", amount: "", wantErr: true},
+		{name: "Only a dot", amount: ".", wantErr: true},
+		{name: "Not a number", amount: "12a", wantErr: true},
+		{name: "Exponent", amount: "1e3", wantErr: true},
+		{name: "Too large", amount: "1000000000000000000", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.amount)
+			assert.Equal(t, tt.wantErr, errors.Is(err, ErrInvalidMoney))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	assert.Equal(t, "12.30", Money(1230).String())
+	assert.Equal(t, "-0.05", Money(-5).String())
+	assert.Equal(t, "0.00", Money(0).String())
+}
+
+func TestMoney_Scan(t *testing.T) {
+
+	var money Money
+
+	assert.NoError(t, money.Scan([]byte("1234.50")))
+	assert.Equal(t, Money(123450), money)
+
+	assert.NoError(t, money.Scan("-0.10"))
+	assert.Equal(t, Money(-10), money)
+
+	assert.NoError(t, money.Scan(int64(3)))
+	assert.Equal(t, Money(300), money)
+
+	assert.Error(t, money.Scan(0.1))
+}
+
+func TestMoney_JSON(t *testing.T) {
+
+	var amounts struct {
+		FromString Money `json:"from_string"`
+		FromNumber Money `json:"from_number"`
+		Missing    Money `json:"missing"`
+	}
+
+	err := json.Unmarshal([]byte(`{"from_string": "0.10", "from_number": 0.20, "missing": null}`), &amounts)
+	assert.NoError(t, err)
+	assert.Equal(t, Money(10), amounts.FromString)
+	assert.Equal(t, Money(20), amounts.FromNumber)
+	assert.Equal(t, Money(0), amounts.Missing)
+
+	data, err := json.Marshal(amounts)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"from_string": "0.10", "from_number": "0.20", "missing": "0.00"}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"from_string": "0.001"}`), &amounts)
+	assert.ErrorIs(t, err, ErrInvalidMoney)
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// RecurringTransactionTable is the db recurring transaction table model.
+// Exactly one of the subcategory id (expenses) and the income category id (incomes) is set.
+// A zero end date never ends. The next run is the date of the next occurrence to materialize.
+type RecurringTransactionTable struct {
+	ID               int64     `json:"id,omitempty"`
+	Value            Money     `json:"value,omitempty"`
+	Description      string    `json:"description,omitempty"`
+	Frequency        string    `json:"frequency,omitempty"`
+	CronExpression   string    `json:"cron_expression,omitempty"`
+	StartDate        time.Time `json:"start_date,omitempty"`
+	EndDate          time.Time `json:"end_date,omitempty"`
+	NextRun          time.Time `json:"next_run,omitempty"`
+	CardID           int64     `json:"card_id,omitempty"`
+	SubCategoryID    int64     `json:"sub_category_id,omitempty"`
+	IncomeCategoryID int64     `json:"income_category_id,omitempty"`
+	UserID           int64     `json:"user_id,omitempty"`
+}
+
+// RecurringTransactionView is the db recurring transaction model joined with the names of its card and categories
+type RecurringTransactionView struct {
+	ID               int64     `json:"id,omitempty"`
+	Value            Money     `json:"value,omitempty"`
+	Description      string    `json:"description,omitempty"`
+	Frequency        string    `json:"frequency,omitempty"`
+	CronExpression   string    `json:"cron_expression,omitempty"`
+	StartDate        time.Time `json:"start_date,omitempty"`
+	EndDate          time.Time `json:"end_date,omitempty"`
+	NextRun          time.Time `json:"next_run,omitempty"`
+	CardID           int64     `json:"card_id,omitempty"`
+	Card             string    `json:"card,omitempty"`
+	SubCategoryID    int64     `json:"sub_category_id,omitempty"`
+	SubCategory      string    `json:"sub_category,omitempty"`
+	IncomeCategoryID int64     `json:"income_category_id,omitempty"`
+	IncomeCategory   string    `json:"income_category,omitempty"`
+	UserID           int64     `json:"user_id,omitempty"`
+}
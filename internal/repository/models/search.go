@@ -0,0 +1,105 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// SortField is the field the results of a search are sorted by, with the id breaking ties
+type SortField string
+
+// The fields a search can be sorted by
+const (
+	SortByDate  SortField = "date"
+	SortByValue SortField = "value"
+)
+
+// SearchCursor is the position of the last expense or income of a page of results.
+// Only the field the search is sorted by is set, besides the id.
+type SearchCursor struct {
+	Date  time.Time `json:"date,omitempty"`
+	Value Money     `json:"value,omitempty"`
+	ID    int64     `json:"id"`
+}
+
+// SearchFilter is the criteria of a search of expenses or incomes.
+// Empty fields do not filter; dates and values are inclusive bounds.
+type SearchFilter struct {
+	MinDate     time.Time
+	MaxDate     time.Time
+	Category    string
+	SubCategory string // expenses only
+	Card        string
+	MinValue    *Money
+	MaxValue    *Money
+	Description string // case insensitive text the description contains
+	Tag         string
+	SortBy      SortField
+	Descending  bool
+	After       *SearchCursor // the last result of the previous page
+	Limit       int
+}
+
+// CursorOf returns the position of an expense or income in the sort order of the filter
+func (f SearchFilter) CursorOf(id int64, date time.Time, value Money) SearchCursor {
+	if f.SortBy == SortByValue {
+		return SearchCursor{Value: value, ID: id}
+	}
+	return SearchCursor{Date: date, ID: id}
+}
+
+// Less tells if the expense or income at a position comes before the one at the other position in the sort order of the filter
+func (f SearchFilter) Less(a SearchCursor, b SearchCursor) bool {
+
+	if f.Descending {
+		a, b = b, a
+	}
+
+	switch {
+	case f.SortBy == SortByValue && a.Value != b.Value:
+		return a.Value < b.Value
+	case f.SortBy != SortByValue && !a.Date.Equal(b.Date):
+		return a.Date.Before(b.Date)
+	default:
+		return a.ID < b.ID
+	}
+}
+
+// Matches tells if an expense or income with these fields matches the filter and comes after its cursor.
+// Incomes have no subcategory.
+func (f SearchFilter) Matches(
+	id int64,
+	date time.Time,
+	value Money,
+	category, subCategory, card, description string,
+	tags []string,
+) bool {
+
+	if f.Tag != "" && !hasTag(tags, f.Tag) {
+		return false
+	}
+
+	switch {
+	case !f.MinDate.IsZero() && date.Before(f.MinDate),
+		!f.MaxDate.IsZero() && date.After(f.MaxDate),
+		f.Category != "" && category != f.Category,
+		f.SubCategory != "" && subCategory != f.SubCategory,
+		f.Card != "" && card != f.Card,
+		f.MinValue != nil && value < *f.MinValue,
+		f.MaxValue != nil && value > *f.MaxValue,
+		f.Description != "" && !strings.Contains(strings.ToLower(description), strings.ToLower(f.Description)),
+		f.After != nil && !f.Less(*f.After, f.CursorOf(id, date, value)):
+		return false
+	}
+
+	return true
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// SessionTable is the db session table model, a login of a user.
+// A session is active until it expires or it is revoked; RevokedAt is zero while it is not.
+type SessionTable struct {
+	ID        int64     `json:"id,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	RevokedAt time.Time `json:"revoked_at,omitempty"`
+	UserID    int64     `json:"user_id,omitempty"`
+}
+
+// RefreshTokenTable is the db refresh token table model, a single use token of a session kept as its SHA-256 hash.
+// UsedAt is zero until the token is used.
+type RefreshTokenTable struct {
+	ID        int64     `json:"id,omitempty"`
+	TokenHash string    `json:"token_hash,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UsedAt    time.Time `json:"used_at,omitempty"`
+	SessionID int64     `json:"session_id,omitempty"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// DailyTotal is the sum of the values of the expenses or incomes of a day
+// with the same category, subcategory, card and currency
+type DailyTotal struct {
+	Day         time.Time `json:"day,omitempty"`
+	Category    string    `json:"category,omitempty"`
+	SubCategory string    `json:"sub_category,omitempty"` // empty on incomes
+	Card        string    `json:"card,omitempty"`
+	Tag         string    `json:"tag,omitempty"` // only set on the totals by tag, which have no category, subcategory nor card
+	Currency    string    `json:"currency,omitempty"`
+	Value       Money     `json:"value,omitempty"`
+	Count       int64     `json:"count,omitempty"`
+}
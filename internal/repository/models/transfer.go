@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// TransferTable is the db transfer table model: an amount moved from a card to another one of the same user.
+// The amount is in the currency of the source card, which also pays the fee.
+type TransferTable struct {
+	ID          int64     `json:"id,omitempty"`
+	Amount      Money     `json:"amount,omitempty"`
+	Fee         Money     `json:"fee,omitempty"`
+	Date        time.Time `json:"date,omitempty"`
+	Description string    `json:"description,omitempty"`
+	FromCardID  int64     `json:"from_card_id,omitempty"`
+	ToCardID    int64     `json:"to_card_id,omitempty"`
+	UserID      int64     `json:"user_id,omitempty"`
+}
+
+// TransferView is the db transfer model joined with the names of its cards
+type TransferView struct {
+	ID          int64     `json:"id,omitempty"`
+	Amount      Money     `json:"amount,omitempty"`
+	Fee         Money     `json:"fee,omitempty"`
+	Date        time.Time `json:"date,omitempty"`
+	Description string    `json:"description,omitempty"`
+	FromCardID  int64     `json:"from_card_id,omitempty"`
+	FromCard    string    `json:"from_card,omitempty"`
+	ToCardID    int64     `json:"to_card_id,omitempty"`
+	ToCard      string    `json:"to_card,omitempty"`
+	UserID      int64     `json:"user_id,omitempty"`
+}
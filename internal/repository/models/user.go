@@ -1,8 +1,38 @@
 package models
 
-// UserTable is the rds user model
+import "time"
+
+// UserTable is the rds user model.
+// TOTPSecret is set on 2FA enrolment and TOTPEnabledAt is zero until a code of it is verified.
+// TOTPLastStep is the time step of the last accepted TOTP code.
+// Admin users may change the categories, which are shared by every user.
 type UserTable struct {
-	ID       int64  `json:"id,omitempty"`
-	Username string `json:"username,omitempty"`
-	Passhash string `json:"passhash,omitempty"`
+	ID            int64     `json:"id,omitempty"`
+	Username      string    `json:"username,omitempty"`
+	Passhash      string    `json:"passhash,omitempty"`
+	TOTPSecret    string    `json:"totp_secret,omitempty"`
+	TOTPEnabledAt time.Time `json:"totp_enabled_at,omitempty"`
+	TOTPLastStep  int64     `json:"totp_last_step,omitempty"`
+	Admin         bool      `json:"admin,omitempty"`
+}
+
+// PasswordResetTable is the db password reset table model, a single use token of a user kept as its SHA-256 hash.
+// UsedAt is zero until the token is used.
+type PasswordResetTable struct {
+	ID        int64     `json:"id,omitempty"`
+	TokenHash string    `json:"token_hash,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	UsedAt    time.Time `json:"used_at,omitempty"`
+	UserID    int64     `json:"user_id,omitempty"`
+}
+
+// RecoveryCodeTable is the db recovery code table model, a single use 2FA code of a user kept as its SHA-256 hash.
+// UsedAt is zero until the code is used.
+type RecoveryCodeTable struct {
+	ID        int64     `json:"id,omitempty"`
+	CodeHash  string    `json:"code_hash,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UsedAt    time.Time `json:"used_at,omitempty"`
+	UserID    int64     `json:"user_id,omitempty"`
 }
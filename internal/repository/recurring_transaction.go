@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+//go:generate gowrap gen -g -i RecurringTransactionRepo -t ./templates/log_template.go.tmpl -o ./database/recurring/with_logs_by_template.go
+//go:generate gowrap gen -g -i RecurringTransactionRepo -t ./templates/red_template.go.tmpl -o ./database/recurring/with_red_by_template.go
+// RecurringTransactionRepo defines the recurring transaction repository interface.
+// Recurring transactions are owned by a user: lookups take the owner user id right after the context.
+// GetDueRecurringTransactions returns the recurring transactions of every user with a next run on or before the date
+// that did not end before it. AdvanceRecurringTransaction moves the next run of a recurring transaction from a date
+// to another one, and fails with ErrNotFound if the next run is no longer the from date.
+type RecurringTransactionRepo interface {
+	InsertRecurringTransaction(context.Context, models.RecurringTransactionTable) (int64, error)
+	UpdateRecurringTransaction(context.Context, models.RecurringTransactionTable) (int64, error)
+	GetRecurringTransactionByID(context.Context, int64, int64) (models.RecurringTransactionView, error)
+	GetRecurringTransactions(context.Context, int64) ([]models.RecurringTransactionView, error)
+	GetDueRecurringTransactions(context.Context, time.Time) ([]models.RecurringTransactionView, error)
+	AdvanceRecurringTransaction(context.Context, int64, time.Time, time.Time) error
+	DeleteRecurringTransaction(context.Context, int64, int64) error
+}
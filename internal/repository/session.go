@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+//go:generate gowrap gen -g -i SessionRepo -t ./templates/log_template.go.tmpl -o ./database/session/with_logs_by_template.go
+//go:generate gowrap gen -g -i SessionRepo -t ./templates/red_template.go.tmpl -o ./database/session/with_red_by_template.go
+// SessionRepo defines the login session repository interface.
+// UseRefreshToken marks a refresh token as used, and returns ErrAlreadyUsed if it already was.
+type SessionRepo interface {
+	InsertSession(context.Context, models.SessionTable) (int64, error)
+	GetSessionByID(context.Context, int64) (models.SessionTable, error)
+	RevokeSession(context.Context, int64) error
+	RevokeUserSessions(context.Context, int64) error
+	InsertRefreshToken(context.Context, models.RefreshTokenTable) (int64, error)
+	GetRefreshTokenByHash(context.Context, string) (models.RefreshTokenTable, error)
+	UseRefreshToken(context.Context, int64) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+//go:generate gowrap gen -g -i TransferRepo -t ./templates/log_template.go.tmpl -o ./database/transfer/with_logs_by_template.go
+//go:generate gowrap gen -g -i TransferRepo -t ./templates/red_template.go.tmpl -o ./database/transfer/with_red_by_template.go
+// TransferRepo defines the transfer repository interface.
+// Transfers are owned by a user: lookups take the owner user id right after the context.
+// GetTransfersByDates returns the transfers of the user with a date between the min and the max dates, both inclusive.
+type TransferRepo interface {
+	InsertTransfer(context.Context, models.TransferTable) (int64, error)
+	UpdateTransfer(context.Context, models.TransferTable) (int64, error)
+	GetTransferByID(context.Context, int64, int64) (models.TransferView, error)
+	GetTransfersByDates(context.Context, int64, time.Time, time.Time) ([]models.TransferView, error)
+	DeleteTransfer(context.Context, int64, int64) error
+}
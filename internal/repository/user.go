@@ -9,7 +9,21 @@ import (
 //go:generate gowrap gen -g -i UserRepo -t ./templates/log_template.go.tmpl -o ./database/user/with_logs_by_template.go
 //go:generate gowrap gen -g -i UserRepo -t ./templates/red_template.go.tmpl -o ./database/user/with_red_by_template.go
 // UserRepo defines the user repository interface.
+// ResetPassword uses a password reset and sets the password hash of its user, and returns ErrAlreadyUsed if it was already used.
+// EnableTOTP enables the 2FA of a user, replacing its recovery codes with the given code hashes.
+// UseTOTPStep returns ErrAlreadyUsed unless the step is after the last accepted one, and UseRecoveryCode
+// returns ErrNotFound unless the user has an unused recovery code of that hash.
 type UserRepo interface {
 	InsertUser(context.Context, models.UserTable) (int64, error)
 	GetUserByUsername(context.Context, string) (models.UserTable, error)
+	GetUserByID(context.Context, int64) (models.UserTable, error)
+	UpdatePassword(context.Context, int64, string) error
+	InsertPasswordReset(context.Context, models.PasswordResetTable) (int64, error)
+	GetPasswordResetByHash(context.Context, string) (models.PasswordResetTable, error)
+	ResetPassword(context.Context, int64, string) error
+	SetTOTPSecret(context.Context, int64, string) error
+	EnableTOTP(context.Context, int64, []string) error
+	DisableTOTP(context.Context, int64) error
+	UseTOTPStep(context.Context, int64, int64) error
+	UseRecoveryCode(context.Context, int64, string) error
 }
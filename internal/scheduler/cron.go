@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronFieldBounds are the bounds of the minute, hour, day of month, month and day of week fields
+var cronFieldBounds = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+// Cron is a parsed cron expression. Occurrences are dates, so only the day of month, month and day of week
+// fields pick them: the minute and hour fields are checked but do not matter.
+type Cron struct {
+	daysOfMonth   map[int]bool
+	months        map[int]bool
+	daysOfWeek    map[int]bool
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// ParseCron parses a standard five fields cron expression: minute, hour, day of month, month and day of week.
+// Fields are *, values, ranges (1-5), steps (*/2, 1-10/3) and lists of them (1,15). Sunday is 0 or 7.
+func ParseCron(expression string) (Cron, error) {
+
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFieldBounds) {
+		return Cron{}, fmt.Errorf("cron expression must have %d fields, got %d", len(cronFieldBounds), len(fields))
+	}
+
+	values := [5]map[int]bool{}
+	for idx, field := range fields {
+		fieldValues, err := parseCronField(field, cronFieldBounds[idx][0], cronFieldBounds[idx][1])
+		if err != nil {
+			return Cron{}, fmt.Errorf("cron field %q: %v", field, err)
+		}
+		values[idx] = fieldValues
+	}
+
+	if values[4][7] {
+		values[4][0] = true
+	}
+
+	return Cron{
+		daysOfMonth:   values[2],
+		months:        values[3],
+		daysOfWeek:    values[4],
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}, nil
+}
+
+// Matches tells if the date is an occurrence. As in cron, when both the day of month and the day of week
+// are restricted a date matches either of them.
+func (c Cron) Matches(date time.Time) bool {
+
+	if !c.months[int(date.Month())] {
+		return false
+	}
+
+	dayOfMonth := c.daysOfMonth[date.Day()]
+	dayOfWeek := c.daysOfWeek[int(date.Weekday())]
+
+	switch {
+	case c.anyDayOfMonth && c.anyDayOfWeek:
+		return true
+	case c.anyDayOfMonth:
+		return dayOfWeek
+	case c.anyDayOfWeek:
+		return dayOfMonth
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}
+
+func parseCronField(field string, min int, max int) (map[int]bool, error) {
+
+	values := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+
+		rangePart, step := part, 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			var err error
+			rangePart = part[:idx]
+			step, err = strconv.Atoi(part[idx+1:])
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %q", part[idx+1:])
+			}
+		}
+
+		from, to := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			from, err = strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid range %q", rangePart)
+			}
+			to, err = strconv.Atoi(bounds[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q", rangePart)
+			}
+			from, to = value, value
+			if strings.Contains(part, "/") {
+				to = max
+			}
+		}
+
+		if from < min || to > max || from > to {
+			return nil, fmt.Errorf("%q is out of the %d-%d range", rangePart, min, max)
+		}
+
+		for value := from; value <= to; value += step {
+			values[value] = true
+		}
+	}
+
+	return values, nil
+}
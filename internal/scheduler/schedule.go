@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const (
+	// FrequencyDaily and the others are the frequencies a recurring transaction can have
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+	FrequencyYearly  = "yearly"
+	FrequencyCron    = "cron"
+
+	// cronSearchDays bounds the search of the next cron occurrence: 8 years fit any 29th of February
+	cronSearchDays = 8 * 366
+)
+
+var (
+	// ErrInvalidRecurringTransaction is returned when a recurring transaction can not be stored
+	ErrInvalidRecurringTransaction = errors.New("recurring transaction is not valid")
+	// ErrNoNextRun is returned when a cron expression has no occurrences
+	ErrNoNextRun = errors.New("schedule has no next run")
+)
+
+// Schedule is when the occurrences of a recurring transaction happen
+type Schedule struct {
+	Frequency string
+	Cron      Cron
+	StartDate time.Time
+}
+
+// NewSchedule creates the schedule of a recurring transaction
+func NewSchedule(frequency string, cronExpression string, startDate time.Time) (Schedule, error) {
+
+	schedule := Schedule{
+		Frequency: frequency,
+		StartDate: Date(startDate),
+	}
+
+	switch frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+	case FrequencyCron:
+		cron, err := ParseCron(cronExpression)
+		if err != nil {
+			return Schedule{}, err
+		}
+		schedule.Cron = cron
+	default:
+		return Schedule{}, ErrInvalidRecurringTransaction
+	}
+
+	return schedule, nil
+}
+
+// First returns the first occurrence on or after the date
+func (s Schedule) First(from time.Time) (time.Time, error) {
+
+	date := s.StartDate
+	if s.Frequency == FrequencyCron && !s.Cron.Matches(date) {
+		var err error
+		date, err = s.Next(date)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	from = Date(from)
+	for date.Before(from) {
+		var err error
+		date, err = s.Next(date)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return date, nil
+}
+
+// Next returns the occurrence after the date, which must be an occurrence itself.
+// Monthly and yearly occurrences keep the day of the start date, or the last day of shorter months.
+func (s Schedule) Next(date time.Time) (time.Time, error) {
+
+	date = Date(date)
+
+	switch s.Frequency {
+	case FrequencyDaily:
+		return date.AddDate(0, 0, 1), nil
+	case FrequencyWeekly:
+		return date.AddDate(0, 0, 7), nil
+	case FrequencyMonthly:
+		return dayOfMonth(date.Year(), date.Month()+1, s.StartDate.Day()), nil
+	case FrequencyYearly:
+		return dayOfMonth(date.Year()+1, s.StartDate.Month(), s.StartDate.Day()), nil
+	case FrequencyCron:
+		for day := 1; day <= cronSearchDays; day++ {
+			next := date.AddDate(0, 0, day)
+			if s.Cron.Matches(next) {
+				return next, nil
+			}
+		}
+		return time.Time{}, ErrNoNextRun
+	default:
+		return time.Time{}, ErrInvalidRecurringTransaction
+	}
+}
+
+// FirstRun returns the first occurrence of the recurring transaction on or after the date,
+// which is where its next run pointer starts
+func FirstRun(recurring models.RecurringTransactionTable, from time.Time) (time.Time, error) {
+
+	schedule, err := NewSchedule(recurring.Frequency, recurring.CronExpression, recurring.StartDate)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return schedule.First(from)
+}
+
+// Date returns the date of the time, in UTC
+func Date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// dayOfMonth returns the day of the month, or its last day if the month is shorter
+func dayOfMonth(year int, month time.Month, day int) time.Time {
+
+	firstDay := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDay.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(firstDay.Year(), firstDay.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+// Validate checks a recurring transaction before it is stored: it must be of exactly one of an expense
+// subcategory or an income category, have a known frequency (and a valid cron expression for cron
+// schedules), a value that is not negative and an end date, if any, not before its start date.
+func Validate(recurring models.RecurringTransactionTable) error {
+
+	if (recurring.SubCategoryID == 0) == (recurring.IncomeCategoryID == 0) {
+		return ErrInvalidRecurringTransaction
+	}
+
+	if recurring.Value < 0 || recurring.CardID == 0 || recurring.StartDate.IsZero() {
+		return ErrInvalidRecurringTransaction
+	}
+
+	if !recurring.EndDate.IsZero() && recurring.EndDate.Before(recurring.StartDate) {
+		return ErrInvalidRecurringTransaction
+	}
+
+	_, err := NewSchedule(recurring.Frequency, recurring.CronExpression, recurring.StartDate)
+	if err != nil {
+		return ErrInvalidRecurringTransaction
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSchedule_Next(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		frequency string
+		cron      string
+		startDate time.Time
+		date      time.Time
+		want      time.Time
+	}{
+		{name: "Daily", frequency: FrequencyDaily, startDate: date(2020, 2, 28), date: date(2020, 2, 28), want: date(2020, 2, 29)},
+		{name: "Weekly", frequency: FrequencyWeekly, startDate: date(2020, 2, 26), date: date(2020, 2, 26), want: date(2020, 3, 4)},
+		{name: "Monthly on a shorter month", frequency: FrequencyMonthly, startDate: date(2020, 1, 31), date: date(2020, 1, 31), want: date(2020, 2, 29)},
+		{name: "Monthly keeps the start day", frequency: FrequencyMonthly, startDate: date(2020, 1, 31), date: date(2020, 2, 29), want: date(2020, 3, 31)},
+		{name: "Yearly on a leap day", frequency: FrequencyYearly, startDate: date(2020, 2, 29), date: date(2020, 2, 29), want: date(2021, 2, 28)},
+		{name: "Cron mondays", frequency: FrequencyCron, cron: "0 0 * * 1", startDate: date(2020, 2, 1), date: date(2020, 2, 1), want: date(2020, 2, 3)},
+		{name: "Cron day of month or day of week", frequency: FrequencyCron, cron: "0 0 15 * 1", startDate: date(2020, 2, 11), date: date(2020, 2, 11), want: date(2020, 2, 15)},
+		{name: "Cron last day of month", frequency: FrequencyCron, cron: "* * 31 */2 *", startDate: date(2020, 1, 1), date: date(2020, 1, 31), want: date(2020, 3, 31)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewSchedule(tt.frequency, tt.cron, tt.startDate)
+			assert.NoError(t, err)
+
+			got, err := schedule.Next(tt.date)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSchedule_First(t *testing.T) {
+
+	schedule, err := NewSchedule(FrequencyMonthly, "", date(2020, 1, 31))
+	assert.NoError(t, err)
+
+	got, err := schedule.First(date(2019, 12, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, date(2020, 1, 31), got)
+
+	got, err = schedule.First(date(2020, 4, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, date(2020, 4, 30), got)
+
+	schedule, err = NewSchedule(FrequencyCron, "0 0 * * 0", date(2020, 2, 1))
+	assert.NoError(t, err)
+
+	got, err = schedule.First(date(2020, 2, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, date(2020, 2, 2), got)
+
+	schedule, err = NewSchedule(FrequencyCron, "0 0 30 2 *", date(2020, 2, 1))
+	assert.NoError(t, err)
+
+	_, err = schedule.First(date(2020, 2, 1))
+	assert.ErrorIs(t, err, ErrNoNextRun)
+}
+
+func TestParseCron(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    bool
+	}{
+		{name: "Every day", expression: "* * * * *", wantErr: false},
+		{name: "Lists, ranges and steps", expression: "0 9 1,15 1-12/3 1-5", wantErr: false},
+		{name: "Sunday as 7", expression: "0 0 * * 7", wantErr: false},
+		{name: "Missing fields", expression: "0 0 1 *", wantErr: true},
+		{name: "Out of bounds", expression: "0 0 32 * *", wantErr: true},
+		{name: "Not a number", expression: "0 0 first * *", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCron(tt.expression)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+
+	rent := models.RecurringTransactionTable{
+		Value:         models.MustParseMoney("500"),
+		Frequency:     FrequencyMonthly,
+		StartDate:     date(2020, 1, 1),
+		CardID:        1,
+		SubCategoryID: 1,
+	}
+
+	noTarget := rent
+	noTarget.SubCategoryID = 0
+	bothTargets := rent
+	bothTargets.IncomeCategoryID = 1
+	unknownFrequency := rent
+	unknownFrequency.Frequency = "hourly"
+	invalidCron := rent
+	invalidCron.Frequency = FrequencyCron
+	invalidCron.CronExpression = "0 0 1"
+	endBeforeStart := rent
+	endBeforeStart.EndDate = date(2019, 12, 31)
+
+	tests := []struct {
+		name      string
+		recurring models.RecurringTransactionTable
+		wantErr   bool
+	}{
+		{name: "Valid", recurring: rent, wantErr: false},
+		{name: "No target", recurring: noTarget, wantErr: true},
+		{name: "Both targets", recurring: bothTargets, wantErr: true},
+		{name: "Unknown frequency", recurring: unknownFrequency, wantErr: true},
+		{name: "Invalid cron", recurring: invalidCron, wantErr: true},
+		{name: "End date before start date", recurring: endBeforeStart, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.recurring)
+			assert.Equal(t, tt.wantErr, errors.Is(err, ErrInvalidRecurringTransaction))
+		})
+	}
+}
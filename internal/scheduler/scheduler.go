@@ -0,0 +1,214 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/utils"
+)
+
+const defaultInterval = time.Hour
+
+// Runner materializes the due occurrences of the recurring transactions as expenses and incomes.
+// Every occurrence is stored with a reference of its recurring transaction and date, and occurrences
+// already stored are skipped, so running it again (or after a restart) never posts an occurrence twice.
+type Runner struct {
+	Repository         repository.RecurringTransactionRepo
+	ExpensesRepository repository.ExpenseRepo
+	IncomesRepository  repository.IncomeRepo
+}
+
+// Result holds the ids of the rows created by a run
+type Result struct {
+	ExpenseIDs []int64
+	IncomeIDs  []int64
+}
+
+// NewRunner creates a new Runner
+func NewRunner(
+	recurringRepo repository.RecurringTransactionRepo,
+	expRepo repository.ExpenseRepo,
+	incRepo repository.IncomeRepo,
+) Runner {
+	return Runner{
+		Repository:         recurringRepo,
+		ExpensesRepository: expRepo,
+		IncomesRepository:  incRepo,
+	}
+}
+
+// IntervalFromEnv reads the interval between the background runs from the RECURRING_INTERVAL env variable,
+// a duration such as 30m, defaulting to one hour. Zero disables the background runs.
+func IntervalFromEnv() (time.Duration, error) {
+
+	value := os.Getenv("RECURRING_INTERVAL")
+	if value == "" {
+		return defaultInterval, nil
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		return 0, fmt.Errorf("RECURRING_INTERVAL must be a duration that is not negative, got %q", value)
+	}
+
+	return interval, nil
+}
+
+// Start runs the runner now and then on every interval until the context is done
+func (r Runner) Start(ctx context.Context, interval time.Duration) {
+
+	if interval == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := r.Run(ctx, time.Now())
+		if err != nil {
+			log.Printf("could not run recurring transactions: %v", err)
+		} else {
+			log.Printf("recurring transactions created %d expenses and %d incomes", len(result.ExpenseIDs), len(result.IncomeIDs))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run materializes the occurrences of every recurring transaction up to the date, and moves their next run past it.
+// A recurring transaction that fails is retried on the next run and does not stop the others; the first error is returned.
+func (r Runner) Run(ctx context.Context, date time.Time) (Result, error) {
+
+	date = Date(date)
+	result := Result{
+		ExpenseIDs: []int64{},
+		IncomeIDs:  []int64{},
+	}
+
+	recurringTransactions, err := r.Repository.GetDueRecurringTransactions(ctx, date)
+	if err != nil {
+		return result, fmt.Errorf("could not get due recurring transactions: %v", err)
+	}
+
+	var firstErr error
+	for _, recurring := range recurringTransactions {
+		err := r.run(ctx, recurring, date, &result)
+		if err != nil {
+			log.Printf("could not run recurring transaction %d: %v", recurring.ID, err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("recurring transaction %d: %w", recurring.ID, err)
+			}
+		}
+	}
+
+	return result, firstErr
+}
+
+func (r Runner) run(ctx context.Context, recurring models.RecurringTransactionView, date time.Time, result *Result) error {
+
+	schedule, err := NewSchedule(recurring.Frequency, recurring.CronExpression, recurring.StartDate)
+	if err != nil {
+		return err
+	}
+
+	nextRun := Date(recurring.NextRun)
+	for !nextRun.After(date) && (recurring.EndDate.IsZero() || !nextRun.After(Date(recurring.EndDate))) {
+
+		err := r.post(ctx, recurring, nextRun, result)
+		if err != nil {
+			return err
+		}
+
+		nextRun, err = schedule.Next(nextRun)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = r.Repository.AdvanceRecurringTransaction(ctx, recurring.ID, recurring.NextRun, nextRun)
+	if errors.Is(err, repository.ErrNotFound) {
+		// another runner, or an update, moved the next run meanwhile
+		return nil
+	}
+
+	return err
+}
+
+// post stores the occurrence of the date unless it was already stored. An occurrence stored by another runner between
+// the check and the insert breaks the unique external reference of the card, and is as well already posted.
+func (r Runner) post(ctx context.Context, recurring models.RecurringTransactionView, date time.Time, result *Result) error {
+
+	reference := Reference(recurring.ID, date)
+
+	if recurring.SubCategoryID != 0 {
+		_, err := r.ExpensesRepository.GetExpenseByExternalReference(ctx, recurring.UserID, recurring.CardID, reference)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("could not check if the occurrence of %s was already posted: %v", utils.TimeToStringDate(date), err)
+		}
+
+		id, err := r.ExpensesRepository.InsertExpense(ctx, models.ExpenseTable{
+			Value:             recurring.Value,
+			Date:              date,
+			SubCategoryID:     recurring.SubCategoryID,
+			CardID:            recurring.CardID,
+			Description:       recurring.Description,
+			ExternalReference: reference,
+			UserID:            recurring.UserID,
+		})
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not insert the expense of %s: %v", utils.TimeToStringDate(date), err)
+		}
+		result.ExpenseIDs = append(result.ExpenseIDs, id)
+
+		return nil
+	}
+
+	_, err := r.IncomesRepository.GetIncomeByExternalReference(ctx, recurring.UserID, recurring.CardID, reference)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("could not check if the occurrence of %s was already posted: %v", utils.TimeToStringDate(date), err)
+	}
+
+	id, err := r.IncomesRepository.InsertIncome(ctx, models.IncomeTable{
+		Value:             recurring.Value,
+		Date:              date,
+		CategoryID:        recurring.IncomeCategoryID,
+		CardID:            recurring.CardID,
+		Description:       recurring.Description,
+		ExternalReference: reference,
+		UserID:            recurring.UserID,
+	})
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not insert the income of %s: %v", utils.TimeToStringDate(date), err)
+	}
+	result.IncomeIDs = append(result.IncomeIDs, id)
+
+	return nil
+}
+
+// Reference is the external reference of the occurrence of a recurring transaction on a date
+func Reference(recurringID int64, date time.Time) string {
+	return fmt.Sprintf("recurring-%d-%s", recurringID, utils.TimeToStringDate(date))
+}
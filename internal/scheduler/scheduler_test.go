@@ -0,0 +1,184 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunner_Run(t *testing.T) {
+
+	rent := models.RecurringTransactionView{
+		ID:            1,
+		Value:         models.MustParseMoney("500"),
+		Description:   "Rent",
+		Frequency:     FrequencyMonthly,
+		StartDate:     date(2020, 1, 31),
+		NextRun:       date(2020, 1, 31),
+		CardID:        1,
+		SubCategoryID: 1,
+		UserID:        1,
+	}
+
+	endingRent := rent
+	endingRent.EndDate = date(2020, 2, 29)
+
+	postedRent := models.ExpenseTable{
+		Value:             models.MustParseMoney("500"),
+		Date:              date(2020, 1, 31),
+		SubCategoryID:     1,
+		CardID:            1,
+		Description:       "Rent",
+		ExternalReference: Reference(1, date(2020, 1, 31)),
+		UserID:            1,
+	}
+
+	tests := []struct {
+		name         string
+		recurring    models.RecurringTransactionView
+		expenses     []models.ExpenseTable
+		date         time.Time
+		wantExpenses int
+		wantNextRun  time.Time
+	}{
+		{
+			name:         "Backfills every occurrence up to the date",
+			recurring:    rent,
+			date:         date(2020, 3, 31),
+			wantExpenses: 3,
+			wantNextRun:  date(2020, 4, 30),
+		},
+		{
+			name:         "Not due",
+			recurring:    rent,
+			date:         date(2020, 1, 30),
+			wantExpenses: 0,
+			wantNextRun:  date(2020, 1, 31),
+		},
+		{
+			name:         "Skips occurrences already posted",
+			recurring:    rent,
+			expenses:     []models.ExpenseTable{postedRent},
+			date:         date(2020, 2, 29),
+			wantExpenses: 1,
+			wantNextRun:  date(2020, 3, 31),
+		},
+		{
+			name:         "Stops on the end date",
+			recurring:    endingRent,
+			date:         date(2020, 12, 31),
+			wantExpenses: 2,
+			wantNextRun:  date(2020, 3, 31),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			recurringCache := cache.NewRecurringTransaction([]models.RecurringTransactionView{tt.recurring})
+			expensesCache := cache.NewExpense(tt.expenses, cache.Card{}, cache.ExpenseCategory{}, cache.ExpenseSubCategory{})
+			runner := NewRunner(&recurringCache, &expensesCache, nil)
+
+			result, err := runner.Run(context.Background(), tt.date)
+			assert.NoError(t, err)
+			assert.Len(t, result.ExpenseIDs, tt.wantExpenses)
+			assert.Empty(t, result.IncomeIDs)
+
+			recurring, err := recurringCache.GetRecurringTransactionByID(context.Background(), 1, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNextRun, recurring.NextRun)
+
+			result, err = runner.Run(context.Background(), tt.date)
+			assert.NoError(t, err)
+			assert.Empty(t, result.ExpenseIDs)
+		})
+	}
+}
+
+func TestRunner_RunIsIdempotent(t *testing.T) {
+
+	rent := models.RecurringTransactionView{
+		ID:            1,
+		Value:         models.MustParseMoney("500"),
+		Frequency:     FrequencyMonthly,
+		StartDate:     date(2020, 1, 31),
+		NextRun:       date(2020, 1, 31),
+		CardID:        1,
+		SubCategoryID: 1,
+		UserID:        1,
+	}
+
+	expensesCache := cache.NewExpense(
+		[]models.ExpenseTable{},
+		cache.NewCard([]models.CardTable{{ID: 1, Name: "CGD", UserID: 1}}),
+		cache.NewExpenseCategory([]models.ExpenseCategoryTable{{ID: 1, Name: "House"}}),
+		cache.NewExpenseSubCategory([]models.ExpenseSubCategoryTable{{ID: 1, Name: "Rent", CategoryID: 1}}),
+	)
+
+	// a run that stored the occurrences but crashed before moving the next run is repeated
+	for run := 0; run < 2; run++ {
+		recurringCache := cache.NewRecurringTransaction([]models.RecurringTransactionView{rent})
+		_, err := NewRunner(&recurringCache, &expensesCache, nil).Run(context.Background(), date(2020, 3, 31))
+		assert.NoError(t, err)
+	}
+
+	for _, day := range []time.Time{date(2020, 1, 31), date(2020, 2, 29), date(2020, 3, 31)} {
+		expense, err := expensesCache.GetExpenseByExternalReference(context.Background(), 1, 1, Reference(1, day))
+		assert.NoError(t, err)
+		assert.Equal(t, day, expense.Date)
+	}
+
+	expenses, err := expensesCache.GetExpensesByDates(context.Background(), 1, date(2020, 1, 1), date(2020, 12, 31))
+	assert.NoError(t, err)
+	assert.Len(t, expenses, 3)
+}
+
+// racingExpenses misses the expenses another runner stored between the check and the insert
+type racingExpenses struct {
+	*cache.Expense
+}
+
+func (re racingExpenses) GetExpenseByExternalReference(ctx context.Context, userID int64, cardID int64, reference string) (models.ExpenseTable, error) {
+	return models.ExpenseTable{}, repository.ErrNotFound
+}
+
+func TestRunner_RunRacesAnotherRunner(t *testing.T) {
+
+	rent := models.RecurringTransactionView{
+		ID:            1,
+		Value:         models.MustParseMoney("500"),
+		Frequency:     FrequencyMonthly,
+		StartDate:     date(2020, 1, 31),
+		NextRun:       date(2020, 1, 31),
+		CardID:        1,
+		SubCategoryID: 1,
+		UserID:        1,
+	}
+
+	expensesCache := cache.NewExpense(
+		[]models.ExpenseTable{},
+		cache.NewCard([]models.CardTable{{ID: 1, Name: "CGD", UserID: 1}}),
+		cache.NewExpenseCategory([]models.ExpenseCategoryTable{{ID: 1, Name: "House"}}),
+		cache.NewExpenseSubCategory([]models.ExpenseSubCategoryTable{{ID: 1, Name: "Rent", CategoryID: 1}}),
+	)
+
+	// the second runner inserts the occurrences the first one already stored
+	results := make([]Result, 2)
+	for run := range results {
+		recurringCache := cache.NewRecurringTransaction([]models.RecurringTransactionView{rent})
+		result, err := NewRunner(&recurringCache, racingExpenses{&expensesCache}, nil).Run(context.Background(), date(2020, 3, 31))
+		assert.NoError(t, err)
+		results[run] = result
+	}
+
+	assert.Len(t, results[0].ExpenseIDs, 3)
+	assert.Empty(t, results[1].ExpenseIDs)
+
+	expenses, err := expensesCache.GetExpensesByDates(context.Background(), 1, date(2020, 1, 1), date(2020, 12, 31))
+	assert.NoError(t, err)
+	assert.Len(t, expenses, 3)
+}
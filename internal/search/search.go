@@ -0,0 +1,238 @@
+package search
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+const (
+	// DefaultLimit is the number of results of a page when the search sets none
+	DefaultLimit = 50
+	// MaxLimit is the largest number of results of a page
+	MaxLimit = 500
+)
+
+var (
+	// ErrInvalidSearch is returned when the criteria of a search are not valid
+	ErrInvalidSearch = errors.New("search is not valid")
+	// ErrInvalidCursor is returned when a cursor was not returned by a search with the same sort order
+	ErrInvalidCursor = errors.New("cursor is not valid for this search")
+)
+
+// Query is a search of expenses or incomes as requested by a client. Every field is optional.
+// Results are sorted by date, newest first, unless sorted otherwise.
+type Query struct {
+	MinDate     time.Time
+	MaxDate     time.Time
+	Category    string
+	SubCategory string // expenses only
+	Card        string
+	MinValue    string // decimal string, such as "12.30"
+	MaxValue    string // decimal string, such as "12.30"
+	Description string
+	Tag         string
+	SortBy      string // date or value
+	Order       string // asc or desc
+	Limit       int
+	Cursor      string // the next cursor of the previous page
+}
+
+// ExpensesPage is a page of the expenses that match a search.
+// NextCursor is empty on the last page.
+type ExpensesPage struct {
+	Expenses   []models.ExpenseView
+	NextCursor string
+}
+
+// IncomesPage is a page of the incomes that match a search.
+// NextCursor is empty on the last page.
+type IncomesPage struct {
+	Incomes    []models.IncomeView
+	NextCursor string
+}
+
+// cursor is what a cursor encodes: the position of the last result of a page and the sort order it is a position in
+type cursor struct {
+	SortBy     models.SortField    `json:"sort_by"`
+	Descending bool                `json:"descending"`
+	After      models.SearchCursor `json:"after"`
+}
+
+// Expenses gets a page of the expenses of the user that match the query
+func Expenses(ctx context.Context, repo repository.ExpenseRepo, userID int64, query Query) (ExpensesPage, error) {
+
+	filter, err := query.Filter()
+	if err != nil {
+		return ExpensesPage{}, err
+	}
+
+	// one more than the page tells if there is a next page
+	limit := filter.Limit
+	filter.Limit++
+
+	expenses, err := repo.SearchExpenses(ctx, userID, filter)
+	if err != nil {
+		return ExpensesPage{}, fmt.Errorf("could not search expenses: %v", err)
+	}
+
+	if len(expenses) <= limit {
+		return ExpensesPage{Expenses: expenses}, nil
+	}
+
+	expenses = expenses[:limit]
+	last := expenses[limit-1]
+
+	return ExpensesPage{
+		Expenses:   expenses,
+		NextCursor: encodeCursor(filter, filter.CursorOf(last.ID, last.Date, last.Value)),
+	}, nil
+}
+
+// Incomes gets a page of the incomes of the user that match the query
+func Incomes(ctx context.Context, repo repository.IncomeRepo, userID int64, query Query) (IncomesPage, error) {
+
+	if query.SubCategory != "" {
+		return IncomesPage{}, fmt.Errorf("%w: incomes have no subcategories", ErrInvalidSearch)
+	}
+
+	filter, err := query.Filter()
+	if err != nil {
+		return IncomesPage{}, err
+	}
+
+	// one more than the page tells if there is a next page
+	limit := filter.Limit
+	filter.Limit++
+
+	incomes, err := repo.SearchIncomes(ctx, userID, filter)
+	if err != nil {
+		return IncomesPage{}, fmt.Errorf("could not search incomes: %v", err)
+	}
+
+	if len(incomes) <= limit {
+		return IncomesPage{Incomes: incomes}, nil
+	}
+
+	incomes = incomes[:limit]
+	last := incomes[limit-1]
+
+	return IncomesPage{
+		Incomes:    incomes,
+		NextCursor: encodeCursor(filter, filter.CursorOf(last.ID, last.Date, last.Value)),
+	}, nil
+}
+
+// Filter validates the query and turns it into the filter of the repositories
+func (q Query) Filter() (models.SearchFilter, error) {
+
+	filter := models.SearchFilter{
+		MinDate:     q.MinDate,
+		MaxDate:     q.MaxDate,
+		Category:    strings.TrimSpace(q.Category),
+		SubCategory: strings.TrimSpace(q.SubCategory),
+		Card:        strings.TrimSpace(q.Card),
+		Description: strings.TrimSpace(q.Description),
+		Tag:         strings.ToLower(strings.TrimSpace(q.Tag)),
+		Limit:       q.Limit,
+	}
+
+	if !q.MinDate.IsZero() && !q.MaxDate.IsZero() && q.MaxDate.Before(q.MinDate) {
+		return models.SearchFilter{}, fmt.Errorf("%w: max date is before min date", ErrInvalidSearch)
+	}
+
+	for _, bound := range []struct {
+		value string
+		dst   **models.Money
+	}{
+		{value: q.MinValue, dst: &filter.MinValue},
+		{value: q.MaxValue, dst: &filter.MaxValue},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		value, err := models.ParseMoney(bound.value)
+		if err != nil {
+			return models.SearchFilter{}, fmt.Errorf("%w: %v", ErrInvalidSearch, err)
+		}
+		*bound.dst = &value
+	}
+
+	if filter.MinValue != nil && filter.MaxValue != nil && *filter.MaxValue < *filter.MinValue {
+		return models.SearchFilter{}, fmt.Errorf("%w: max value is below min value", ErrInvalidSearch)
+	}
+
+	switch models.SortField(strings.ToLower(q.SortBy)) {
+	case "", models.SortByDate:
+		filter.SortBy = models.SortByDate
+	case models.SortByValue:
+		filter.SortBy = models.SortByValue
+	default:
+		return models.SearchFilter{}, fmt.Errorf("%w: sort by must be date or value", ErrInvalidSearch)
+	}
+
+	switch strings.ToLower(q.Order) {
+	case "", "desc":
+		filter.Descending = true
+	case "asc":
+		filter.Descending = false
+	default:
+		return models.SearchFilter{}, fmt.Errorf("%w: order must be asc or desc", ErrInvalidSearch)
+	}
+
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = DefaultLimit
+	case filter.Limit < 0 || filter.Limit > MaxLimit:
+		return models.SearchFilter{}, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidSearch, MaxLimit)
+	}
+
+	if q.Cursor != "" {
+		after, err := decodeCursor(filter, q.Cursor)
+		if err != nil {
+			return models.SearchFilter{}, err
+		}
+		filter.After = &after
+	}
+
+	return filter, nil
+}
+
+func encodeCursor(filter models.SearchFilter, after models.SearchCursor) string {
+
+	// the fields of a cursor always marshal
+	data, _ := json.Marshal(cursor{ // nolint
+		SortBy:     filter.SortBy,
+		Descending: filter.Descending,
+		After:      after,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(filter models.SearchFilter, encoded string) (models.SearchCursor, error) {
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return models.SearchCursor{}, ErrInvalidCursor
+	}
+
+	var decoded cursor
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		return models.SearchCursor{}, ErrInvalidCursor
+	}
+
+	if decoded.SortBy != filter.SortBy || decoded.Descending != filter.Descending {
+		return models.SearchCursor{}, fmt.Errorf("%w: the cursor is of a search with another sort order", ErrInvalidCursor)
+	}
+
+	return decoded.After, nil
+}
@@ -0,0 +1,142 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/mock"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func expensesCache() cache.Expense {
+
+	cardsCache := cache.NewCard([]models.CardTable{
+		{ID: 1, Name: "CGD"},
+		{ID: 2, Name: "Food allowance"},
+	})
+	categoriesCache := cache.NewExpenseCategory([]models.ExpenseCategoryTable{
+		{ID: 1, Name: "House"},
+		{ID: 2, Name: "Leisure"},
+	})
+	subCategoriesCache := cache.NewExpenseSubCategory([]models.ExpenseSubCategoryTable{
+		{ID: 1, Name: "Rent", CategoryID: 1},
+		{ID: 2, Name: "Restaurants", CategoryID: 2},
+	})
+
+	return cache.NewExpense([]models.ExpenseTable{
+		{ID: 1, Value: models.MustParseMoney("500"), Date: date(2024, time.January, 1), SubCategoryID: 1, CardID: 1, Description: "January rent"},
+		{ID: 2, Value: models.MustParseMoney("25.50"), Date: date(2024, time.January, 5), SubCategoryID: 2, CardID: 2, Description: "Pizza"},
+		{ID: 3, Value: models.MustParseMoney("12"), Date: date(2024, time.January, 5), SubCategoryID: 2, CardID: 1, Description: "Sushi"},
+		{ID: 4, Value: models.MustParseMoney("500"), Date: date(2024, time.February, 1), SubCategoryID: 1, CardID: 1, Description: "February rent"},
+		{ID: 5, Value: models.MustParseMoney("40"), Date: date(2024, time.February, 3), SubCategoryID: 2, CardID: 2, Description: "Pizza night"},
+		{ID: 6, Value: models.MustParseMoney("40"), Date: date(2024, time.February, 3), SubCategoryID: 2, CardID: 2, Description: "Other user", UserID: 2},
+	}, cardsCache, categoriesCache, subCategoriesCache)
+}
+
+func expenseIDs(expenses []models.ExpenseView) []int64 {
+	ids := []int64{}
+	for _, exp := range expenses {
+		ids = append(ids, exp.ID)
+	}
+	return ids
+}
+
+func TestExpenses(t *testing.T) {
+
+	repo := expensesCache()
+
+	tests := []struct {
+		name    string
+		query   Query
+		want    []int64
+		wantErr error
+	}{
+		{name: "Newest first by default", query: Query{}, want: []int64{5, 4, 3, 2, 1}},
+		{name: "Dates are inclusive", query: Query{MinDate: date(2024, time.January, 5), MaxDate: date(2024, time.February, 1), Order: "asc"}, want: []int64{2, 3, 4}},
+		{name: "Category and card", query: Query{Category: "Leisure", Card: "Food allowance"}, want: []int64{5, 2}},
+		{name: "Subcategory", query: Query{SubCategory: "Rent", Order: "asc"}, want: []int64{1, 4}},
+		{name: "Value range", query: Query{MinValue: "12", MaxValue: "40", SortBy: "value", Order: "asc"}, want: []int64{3, 2, 5}},
+		{name: "Description is case insensitive", query: Query{Description: "RENT"}, want: []int64{4, 1}},
+		{name: "Value ties are sorted by id", query: Query{SortBy: "value"}, want: []int64{4, 1, 5, 2, 3}},
+		{name: "Invalid value", query: Query{MinValue: "12.345"}, wantErr: ErrInvalidSearch},
+		{name: "Inverted value range", query: Query{MinValue: "40", MaxValue: "12"}, wantErr: ErrInvalidSearch},
+		{name: "Inverted dates", query: Query{MinDate: date(2024, time.February, 1), MaxDate: date(2024, time.January, 1)}, wantErr: ErrInvalidSearch},
+		{name: "Invalid sort", query: Query{SortBy: "description"}, wantErr: ErrInvalidSearch},
+		{name: "Invalid order", query: Query{Order: "up"}, wantErr: ErrInvalidSearch},
+		{name: "Limit above max", query: Query{Limit: MaxLimit + 1}, wantErr: ErrInvalidSearch},
+		{name: "Invalid cursor", query: Query{Cursor: "not a cursor"}, wantErr: ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := Expenses(context.Background(), &repo, 0, tt.query)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, expenseIDs(page.Expenses))
+			assert.Empty(t, page.NextCursor)
+		})
+	}
+}
+
+func TestExpenses_Pages(t *testing.T) {
+
+	repo := expensesCache()
+
+	for _, sortBy := range []string{"date", "value"} {
+		for _, order := range []string{"asc", "desc"} {
+
+			all, err := Expenses(context.Background(), &repo, 0, Query{SortBy: sortBy, Order: order})
+			assert.NoError(t, err)
+
+			paged := []models.ExpenseView{}
+			query := Query{SortBy: sortBy, Order: order, Limit: 2}
+			for pages := 1; ; pages++ {
+				page, err := Expenses(context.Background(), &repo, 0, query)
+				assert.NoError(t, err)
+				paged = append(paged, page.Expenses...)
+
+				if page.NextCursor == "" {
+					assert.Equal(t, 3, pages)
+					break
+				}
+				query.Cursor = page.NextCursor
+			}
+
+			assert.Equal(t, expenseIDs(all.Expenses), expenseIDs(paged), "%s %s", sortBy, order)
+		}
+	}
+
+	page, err := Expenses(context.Background(), &repo, 0, Query{Limit: 2})
+	assert.NoError(t, err)
+
+	_, err = Expenses(context.Background(), &repo, 0, Query{Limit: 2, Order: "asc", Cursor: page.NextCursor})
+	assert.True(t, errors.Is(err, ErrInvalidCursor))
+}
+
+func TestIncomes(t *testing.T) {
+
+	page, err := Incomes(context.Background(), mock.Income{}, 0, Query{Card: mock.IncomeSalaryCard.Name, Limit: 1, Order: "asc"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(page.Incomes))
+	assert.Equal(t, mock.IncomeSalaryView.ID, page.Incomes[0].ID)
+	assert.NotEmpty(t, page.NextCursor)
+
+	page, err = Incomes(context.Background(), mock.Income{}, 0, Query{Card: mock.IncomeSalaryCard.Name, Limit: 1, Order: "asc", Cursor: page.NextCursor})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(page.Incomes))
+	assert.Equal(t, mock.IncomeBonusView.ID, page.Incomes[0].ID)
+	assert.Empty(t, page.NextCursor)
+
+	_, err = Incomes(context.Background(), mock.Income{}, 0, Query{SubCategory: "Rent"})
+	assert.True(t, errors.Is(err, ErrInvalidSearch))
+}
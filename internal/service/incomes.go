@@ -4,14 +4,18 @@ import (
 	"context"
 
 	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+	"github.com/rubengomes8/golang-personal-finances/internal/search"
 )
 
+// Incomes are the income use cases, scoped to the user with the provided id
 type Incomes interface {
-	Create(context.Context, models.Income) (int, error)
-	Update(context.Context, models.Income) error
-	Delete(context.Context, int) error
-	GetByID(context.Context, int) (models.Income, error)
-	GetAllByCard(context.Context, string) ([]models.Income, error)
-	GetAllByCategory(context.Context, string) ([]models.Income, error)
-	GetAllByDates(context.Context, string, string) ([]models.Income, error)
+	Create(context.Context, int64, models.Income) (int, error)
+	CreateSeveral(context.Context, int64, []models.Income) ([]int, error)
+	Update(context.Context, int64, models.Income) error
+	Delete(context.Context, int64, int) error
+	GetByID(context.Context, int64, int) (models.Income, error)
+	GetAllByCard(context.Context, int64, string, string) ([]models.Income, error)
+	GetAllByCategory(context.Context, int64, string, string) ([]models.Income, error)
+	GetAllByDates(context.Context, int64, string, string, string, string) ([]models.Income, error)
+	Search(context.Context, int64, search.Query) (models.IncomesPage, error)
 }
@@ -1,11 +1,16 @@
 package service
 
-import "github.com/rubengomes8/golang-personal-finances/internal/repository"
+import (
+	"github.com/rubengomes8/golang-personal-finances/internal/categorization"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/duplicates"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+)
 
 type IncomeConfiguration func(service *Incomes) error
 
 func NewIncomesWithConfiguration(cfgs ...IncomeConfiguration) (*Incomes, error) {
-	service := &Incomes{}
+	service := &Incomes{duplicates: duplicates.NewDetector()}
 	for _, cfg := range cfgs {
 		err := cfg(service)
 		if err != nil {
@@ -35,3 +40,24 @@ func WithCardRepository(cardrepo repository.CardRepo) IncomeConfiguration {
 		return nil
 	}
 }
+
+func WithDuplicatesDetector(detector duplicates.Detector) IncomeConfiguration {
+	return func(service *Incomes) error {
+		service.duplicates = detector
+		return nil
+	}
+}
+
+func WithCategorizer(categorizer categorization.Categorizer) IncomeConfiguration {
+	return func(service *Incomes) error {
+		service.categorizer = categorizer
+		return nil
+	}
+}
+
+func WithExchangeRates(rates currency.Rates) IncomeConfiguration {
+	return func(service *Incomes) error {
+		service.rates = rates
+		return nil
+	}
+}
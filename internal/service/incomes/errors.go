@@ -1,15 +1,41 @@
 package service
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
+)
 
 var (
 	ErrInvalidIncome                = errors.New("income is not valid")
+	ErrIncomeNotFound               = errors.New("income not found")
+	ErrLikelyDuplicateIncome        = errors.New("income is a likely duplicate")
 	ErrCardNotFoundByName           = errors.New("could not get card by name")
 	ErrIncomeCategoryNotFoundByName = errors.New("could not get income category by name")
+	ErrNoMatchingCategorizationRule = errors.New("income has no category and no categorization rule matches it")
+	ErrCouldNotCategorizeIncome     = errors.New("could not categorize income")
 	ErrCouldNotParseDate            = errors.New("could not parse date")
 	ErrCouldNotInsertIncome         = errors.New("could not insert income")
 	ErrCouldNotUpdateIncome         = errors.New("could not update income")
 	ErrCouldNotDeleteIncome         = errors.New("could not delete income")
 	ErrCouldNotGetIncome            = errors.New("could not get income")
 	ErrCouldNotGetIncomesByDates    = errors.New("could not get incomes by dates")
+	ErrCouldNotSearchIncomes        = errors.New("could not search incomes")
+	ErrInvalidCurrency              = errors.New("currency must be a 3 letter ISO 4217 code, such as EUR")
+	ErrInvalidTags                  = errors.New("tags must be single words of up to 50 characters")
+	ErrNoExchangeRate               = errors.New("there is no exchange rate to convert the incomes to the reporting currency")
 )
+
+// LikelyDuplicateError is returned when a new income is a likely duplicate of the candidates
+type LikelyDuplicateError struct {
+	Candidates []models.Income
+}
+
+func (e LikelyDuplicateError) Error() string {
+	return fmt.Sprintf("%v of %d incomes", ErrLikelyDuplicateIncome, len(e.Candidates))
+}
+
+func (e LikelyDuplicateError) Unwrap() error {
+	return ErrLikelyDuplicateIncome
+}
@@ -2,10 +2,16 @@ package service
 
 import (
 	"context"
+	"errors"
 	"log"
 
+	"github.com/rubengomes8/golang-personal-finances/internal/categorization"
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/duplicates"
 	"github.com/rubengomes8/golang-personal-finances/internal/http/models"
 	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/search"
+	"github.com/rubengomes8/golang-personal-finances/internal/tags"
 	"github.com/rubengomes8/golang-personal-finances/internal/utils"
 
 	dbModels "github.com/rubengomes8/golang-personal-finances/internal/repository/models"
@@ -15,6 +21,9 @@ type Incomes struct {
 	repo         repository.IncomeRepo
 	categoryRepo repository.IncomeCategoryRepo
 	cardRepo     repository.CardRepo
+	duplicates   duplicates.Detector
+	categorizer  categorization.Categorizer
+	rates        currency.Rates
 }
 
 // NewIncomes creates a new Incomes service
@@ -27,41 +36,31 @@ func NewIncomes(
 		repo:         repo,
 		categoryRepo: categoryRepo,
 		cardRepo:     cardRepo,
+		duplicates:   duplicates.NewDetector(),
 	}
 }
 
-// Create is the create income usecase
-func (i Incomes) Create(ctx context.Context, income models.Income) (int, error) {
+// Create is the create income usecase.
+// A likely duplicate of an existing income is refused with a LikelyDuplicateError unless the income is forced.
+func (i Incomes) Create(ctx context.Context, userID int64, income models.Income) (int, error) {
 
-	err := validateNewIncome(income)
-	if err != nil {
-		return 0, ErrInvalidIncome
-	}
-
-	card, err := i.cardRepo.GetCardByName(ctx, income.Card)
-	if err != nil {
-		log.Printf("could not get card by name: %v", err)
-		return 0, ErrCardNotFoundByName
-	}
-
-	category, err := i.categoryRepo.GetIncomeCategoryByName(ctx, income.Category)
-	if err != nil {
-		log.Printf("could not get income category by name: %v", err)
-		return 0, ErrIncomeCategoryNotFoundByName
-	}
-
-	date, err := utils.DateStringToTime(income.Date)
+	incomeRecord, err := i.toIncomeRecord(ctx, userID, income)
 	if err != nil {
-		log.Printf("error converting income date string to time - %v: %v", income.Date, err)
-		return 0, ErrCouldNotParseDate
+		return 0, err
 	}
 
-	incomeRecord := dbModels.IncomeTable{
-		Value:       income.Value,
-		Date:        date,
-		CategoryID:  category.ID,
-		CardID:      card.ID,
-		Description: income.Description,
+	if !income.Force {
+		minDate, maxDate := i.duplicates.DateWindow(incomeRecord.Date)
+		candidates, err := i.repo.GetIncomesByCardAndValue(ctx, userID, incomeRecord.CardID, incomeRecord.Value, minDate, maxDate)
+		if err != nil {
+			log.Printf("could not get income duplicate candidates: %v", err)
+			return 0, ErrCouldNotInsertIncome
+		}
+
+		likelyDuplicates := i.duplicates.Incomes(candidates, incomeRecord)
+		if len(likelyDuplicates) > 0 {
+			return 0, LikelyDuplicateError{Candidates: mapIncomeViewsToIncomes(likelyDuplicates)}
+		}
 	}
 
 	id, err := i.repo.InsertIncome(ctx, incomeRecord)
@@ -73,52 +72,65 @@ func (i Incomes) Create(ctx context.Context, income models.Income) (int, error)
 	return int(id), nil
 }
 
-// Update is the update income usecase
-func (i Incomes) Update(ctx context.Context, income models.Income) error {
-
-	err := validateNewIncome(income)
-	if err != nil {
-		return ErrInvalidIncome
+// CreateSeveral is the create several incomes usecase.
+// The incomes are created all at once or none is, in which case a repository.BatchItemError names the failing income.
+func (i Incomes) CreateSeveral(ctx context.Context, userID int64, incomes []models.Income) ([]int, error) {
+
+	incomeRecords := make([]dbModels.IncomeTable, 0, len(incomes))
+	for idx, income := range incomes {
+		incomeRecord, err := i.toIncomeRecord(ctx, userID, income)
+		if err != nil {
+			return []int{}, repository.BatchItemError{Index: idx, Err: err}
+		}
+		incomeRecords = append(incomeRecords, incomeRecord)
 	}
 
-	card, err := i.cardRepo.GetCardByName(ctx, income.Card)
+	ids, err := i.repo.InsertIncomes(ctx, incomeRecords)
 	if err != nil {
-		log.Printf("could not get card by name: %v", err)
-		return ErrCardNotFoundByName
+		log.Printf("could not insert incomes: %v", err)
+		var batchErr repository.BatchItemError
+		if errors.As(err, &batchErr) {
+			return []int{}, repository.BatchItemError{Index: batchErr.Index, Err: ErrCouldNotInsertIncome}
+		}
+		return []int{}, ErrCouldNotInsertIncome
 	}
 
-	category, err := i.categoryRepo.GetIncomeCategoryByName(ctx, income.Category)
-	if err != nil {
-		log.Printf("could not get income category by name: %v", err)
-		return ErrIncomeCategoryNotFoundByName
+	incomeIDs := make([]int, 0, len(ids))
+	for _, id := range ids {
+		incomeIDs = append(incomeIDs, int(id))
 	}
 
-	date, err := utils.DateStringToTime(income.Date)
+	return incomeIDs, nil
+}
+
+// Update is the update income usecase
+func (i Incomes) Update(ctx context.Context, userID int64, income models.Income) error {
+
+	incomeRecord, err := i.toIncomeRecord(ctx, userID, income)
 	if err != nil {
-		log.Printf("error converting income date string to time - %v: %v", income.Date, err)
-		return ErrCouldNotParseDate
+		return err
 	}
 
-	incomeRecord := dbModels.IncomeTable{
-		Value:       income.Value,
-		Date:        date,
-		CategoryID:  category.ID,
-		CardID:      card.ID,
-		Description: income.Description,
-	}
+	incomeRecord.ID = int64(income.ID)
 
 	_, err = i.repo.UpdateIncome(ctx, incomeRecord)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrIncomeNotFound
+	}
 	if err != nil {
 		log.Printf("could not update income: %v", err)
-		return ErrCouldNotInsertIncome
+		return ErrCouldNotUpdateIncome
 	}
 
 	return nil
 }
 
-func (i Incomes) Delete(ctx context.Context, id int) error {
+func (i Incomes) Delete(ctx context.Context, userID int64, id int) error {
 
-	err := i.repo.DeleteIncome(ctx, int64(id))
+	err := i.repo.DeleteIncome(ctx, userID, int64(id))
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrIncomeNotFound
+	}
 	if err != nil {
 		log.Printf("could not delete income with this id - param id is %v - %v", id, err)
 		return ErrCouldNotDeleteIncome
@@ -127,9 +139,12 @@ func (i Incomes) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
-func (i Incomes) GetByID(ctx context.Context, id int) (models.Income, error) {
+func (i Incomes) GetByID(ctx context.Context, userID int64, id int) (models.Income, error) {
 
-	incomeViewRecord, err := i.repo.GetIncomeByID(ctx, int64(id))
+	incomeViewRecord, err := i.repo.GetIncomeByID(ctx, userID, int64(id))
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.Income{}, ErrIncomeNotFound
+	}
 	if err != nil {
 		log.Printf("could not get income by id - param id is %v - %v", id, err)
 		return models.Income{}, ErrCouldNotGetIncome
@@ -139,29 +154,40 @@ func (i Incomes) GetByID(ctx context.Context, id int) (models.Income, error) {
 
 }
 
-func (i Incomes) GetAllByCard(ctx context.Context, card string) ([]models.Income, error) {
+// GetAllByCard is the get incomes by card usecase, keeping only the incomes with the tag if there is one
+func (i Incomes) GetAllByCard(ctx context.Context, userID int64, card string, tag string) ([]models.Income, error) {
 
-	incomeViewRecords, err := i.repo.GetIncomesByCard(ctx, card)
+	incomeViewRecords, err := i.repo.GetIncomesByCard(ctx, userID, card)
 	if err != nil {
 		log.Printf("could not get incomes by card - card is %v - %v", card, err)
 		return []models.Income{}, ErrCardNotFoundByName
 	}
 
-	return mapIncomeViewsToIncomes(incomeViewRecords), nil
+	return mapIncomeViewsToIncomes(tags.Incomes(incomeViewRecords, tag)), nil
 }
 
-func (i Incomes) GetAllByCategory(ctx context.Context, category string) ([]models.Income, error) {
+// GetAllByCategory is the get incomes by category usecase, keeping only the incomes with the tag if there is one
+func (i Incomes) GetAllByCategory(ctx context.Context, userID int64, category string, tag string) ([]models.Income, error) {
 
-	incomeViewRecords, err := i.repo.GetIncomesByCategory(ctx, category)
+	incomeViewRecords, err := i.repo.GetIncomesByCategory(ctx, userID, category)
 	if err != nil {
 		log.Printf("could not get incomes by category - category is %v - %v", category, err)
 		return []models.Income{}, ErrIncomeCategoryNotFoundByName
 	}
 
-	return mapIncomeViewsToIncomes(incomeViewRecords), nil
+	return mapIncomeViewsToIncomes(tags.Incomes(incomeViewRecords, tag)), nil
 }
 
-func (i Incomes) GetAllByDates(ctx context.Context, paramMinDate, paramMaxDate string) ([]models.Income, error) {
+// GetAllByDates is the get incomes by dates usecase.
+// With a reporting currency, the value of each income is converted to it at the exchange rate of the income date.
+// With a tag, only the incomes with it are kept.
+func (i Incomes) GetAllByDates(
+	ctx context.Context,
+	userID int64,
+	paramMinDate, paramMaxDate string,
+	reportingCurrency string,
+	tag string,
+) ([]models.Income, error) {
 
 	minDate, err := utils.DateStringToTime(paramMinDate)
 	if err != nil {
@@ -175,16 +201,130 @@ func (i Incomes) GetAllByDates(ctx context.Context, paramMinDate, paramMaxDate s
 		return []models.Income{}, ErrCouldNotGetIncomesByDates
 	}
 
-	incomeViewRecords, err := i.repo.GetIncomesByDates(ctx, minDate, maxDate)
+	if reportingCurrency != "" {
+		reportingCurrency, err = currency.Normalize(reportingCurrency)
+		if err != nil {
+			log.Printf("invalid reporting currency: %v", err)
+			return []models.Income{}, ErrInvalidCurrency
+		}
+	}
+
+	incomeViewRecords, err := i.repo.GetIncomesByDates(ctx, userID, minDate, maxDate)
 	if err != nil {
 		log.Printf("could not get incomes by dates - min_date is %v | max_date is %v - err: %v", paramMinDate, paramMaxDate, err)
 		return []models.Income{}, ErrCouldNotGetIncomesByDates
 	}
 
+	incomeViewRecords = tags.Incomes(incomeViewRecords, tag)
+
+	if reportingCurrency != "" {
+		incomeViewRecords, err = i.rates.ConvertIncomes(incomeViewRecords, reportingCurrency)
+		if err != nil {
+			log.Printf("could not convert incomes to %v: %v", reportingCurrency, err)
+			return []models.Income{}, ErrNoExchangeRate
+		}
+	}
+
 	return mapIncomeViewsToIncomes(incomeViewRecords), nil
 
 }
 
+// Search is the search incomes usecase.
+// Invalid filters, sort orders and cursors are returned as search.ErrInvalidSearch and search.ErrInvalidCursor errors.
+func (i Incomes) Search(ctx context.Context, userID int64, query search.Query) (models.IncomesPage, error) {
+
+	page, err := search.Incomes(ctx, i.repo, userID, query)
+	if errors.Is(err, search.ErrInvalidSearch) || errors.Is(err, search.ErrInvalidCursor) {
+		return models.IncomesPage{}, err
+	}
+	if err != nil {
+		log.Printf("could not search incomes - %v", err)
+		return models.IncomesPage{}, ErrCouldNotSearchIncomes
+	}
+
+	return models.IncomesPage{
+		Incomes:    mapIncomeViewsToIncomes(page.Incomes),
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// toIncomeRecord validates an income and resolves its card and category into an incomes table record
+func (i Incomes) toIncomeRecord(ctx context.Context, userID int64, income models.Income) (dbModels.IncomeTable, error) {
+
+	err := validateNewIncome(income)
+	if err != nil {
+		return dbModels.IncomeTable{}, ErrInvalidIncome
+	}
+
+	card, err := i.cardRepo.GetCardByName(ctx, userID, income.Card)
+	if err != nil {
+		log.Printf("could not get card by name: %v", err)
+		return dbModels.IncomeTable{}, ErrCardNotFoundByName
+	}
+
+	categoryID, err := i.getIncomeCategoryID(ctx, userID, card.ID, income)
+	if err != nil {
+		return dbModels.IncomeTable{}, err
+	}
+
+	date, err := utils.DateStringToTime(income.Date)
+	if err != nil {
+		log.Printf("error converting income date string to time - %v: %v", income.Date, err)
+		return dbModels.IncomeTable{}, ErrCouldNotParseDate
+	}
+
+	incomeCurrency := income.Currency
+	if incomeCurrency != "" {
+		incomeCurrency, err = currency.Normalize(incomeCurrency)
+		if err != nil {
+			log.Printf("invalid income currency: %v", err)
+			return dbModels.IncomeTable{}, ErrInvalidCurrency
+		}
+	}
+
+	incomeTags, err := tags.Normalize(income.Tags)
+	if err != nil {
+		log.Printf("invalid income tags: %v", err)
+		return dbModels.IncomeTable{}, ErrInvalidTags
+	}
+
+	return dbModels.IncomeTable{
+		Value:       income.Value,
+		Currency:    incomeCurrency,
+		Date:        date,
+		CategoryID:  categoryID,
+		CardID:      card.ID,
+		Description: income.Description,
+		UserID:      userID,
+		Tags:        incomeTags,
+	}, nil
+}
+
+// getIncomeCategoryID resolves the category name of an income.
+// Without a category name, the category is picked by the categorization rules.
+func (i Incomes) getIncomeCategoryID(ctx context.Context, userID int64, cardID int64, income models.Income) (int64, error) {
+
+	if income.Category == "" {
+		categoryID, err := i.categorizer.IncomeCategoryID(ctx, userID, cardID, income.Value, income.Description)
+		if errors.Is(err, categorization.ErrNoMatchingRule) {
+			return 0, ErrNoMatchingCategorizationRule
+		}
+		if err != nil {
+			log.Printf("could not categorize income: %v", err)
+			return 0, ErrCouldNotCategorizeIncome
+		}
+		return categoryID, nil
+	}
+
+	category, err := i.categoryRepo.GetIncomeCategoryByName(ctx, income.Category)
+	if err != nil {
+		log.Printf("could not get income category by name: %v", err)
+		return 0, ErrIncomeCategoryNotFoundByName
+	}
+
+	return category.ID, nil
+}
+
 func mapIncomeViewToIncome(incomeView dbModels.IncomeView) models.Income {
 	return models.Income{
 		ID:          int(incomeView.ID),
@@ -193,6 +333,8 @@ func mapIncomeViewToIncome(incomeView dbModels.IncomeView) models.Income {
 		Category:    incomeView.Category,
 		Card:        incomeView.Card,
 		Description: incomeView.Description,
+		Currency:    incomeView.Currency,
+		Tags:        incomeView.Tags,
 	}
 }
 
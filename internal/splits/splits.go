@@ -0,0 +1,58 @@
+package splits
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// maxNoteLength is the length of the note column of the expense splits table
+const maxNoteLength = 50
+
+// ErrInvalidSplit is returned when the split of an expense can not be stored
+var ErrInvalidSplit = errors.New("split is not valid")
+
+// Validate checks the split of an expense before it is stored. An expense that is not split is valid.
+// A split expense has at least two lines, each of a positive value and of a different subcategory,
+// and the values of its lines add up to the value of the expense.
+func Validate(expense models.ExpenseTable) error {
+
+	if len(expense.Splits) == 0 {
+		return nil
+	}
+
+	if len(expense.Splits) == 1 {
+		return fmt.Errorf("%w: an expense is split in at least two lines", ErrInvalidSplit)
+	}
+
+	var total models.Money
+	subCategories := map[int64]bool{}
+	for idx, line := range expense.Splits {
+
+		if line.SubCategoryID == 0 {
+			return fmt.Errorf("%w: line %d has no subcategory", ErrInvalidSplit, idx)
+		}
+
+		if subCategories[line.SubCategoryID] {
+			return fmt.Errorf("%w: line %d repeats the subcategory of another line", ErrInvalidSplit, idx)
+		}
+		subCategories[line.SubCategoryID] = true
+
+		if line.Value <= 0 {
+			return fmt.Errorf("%w: line %d must have a positive value", ErrInvalidSplit, idx)
+		}
+
+		if len([]rune(line.Note)) > maxNoteLength {
+			return fmt.Errorf("%w: line %d note is longer than %d characters", ErrInvalidSplit, idx, maxNoteLength)
+		}
+
+		total += line.Value
+	}
+
+	if total != expense.Value {
+		return fmt.Errorf("%w: the lines add up to %s instead of %s", ErrInvalidSplit, total, expense.Value)
+	}
+
+	return nil
+}
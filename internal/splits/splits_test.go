@@ -0,0 +1,41 @@
+package splits
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+
+	line := func(subCategoryID int64, value string) models.ExpenseSplitTable {
+		return models.ExpenseSplitTable{SubCategoryID: subCategoryID, Value: models.MustParseMoney(value)}
+	}
+
+	tests := []struct {
+		name    string
+		expense models.ExpenseTable
+		wantErr bool
+	}{
+		{name: "Not split", expense: models.ExpenseTable{Value: models.MustParseMoney("50"), SubCategoryID: 3}, wantErr: false},
+		{name: "Split", expense: models.ExpenseTable{Value: models.MustParseMoney("50"), Splits: []models.ExpenseSplitTable{line(3, "30.50"), line(22, "19.50")}}, wantErr: false},
+		{name: "Single line", expense: models.ExpenseTable{Value: models.MustParseMoney("50"), Splits: []models.ExpenseSplitTable{line(3, "50")}}, wantErr: true},
+		{name: "Lines do not add up", expense: models.ExpenseTable{Value: models.MustParseMoney("50"), Splits: []models.ExpenseSplitTable{line(3, "30"), line(22, "19.99")}}, wantErr: true},
+		{name: "Line without subcategory", expense: models.ExpenseTable{Value: models.MustParseMoney("50"), Splits: []models.ExpenseSplitTable{line(3, "30"), line(0, "20")}}, wantErr: true},
+		{name: "Repeated subcategory", expense: models.ExpenseTable{Value: models.MustParseMoney("50"), Splits: []models.ExpenseSplitTable{line(3, "30"), line(3, "20")}}, wantErr: true},
+		{name: "Zero line", expense: models.ExpenseTable{Value: models.MustParseMoney("50"), Splits: []models.ExpenseSplitTable{line(3, "50"), line(22, "0")}}, wantErr: true},
+		{name: "Long note", expense: models.ExpenseTable{Value: models.MustParseMoney("50"), Splits: []models.ExpenseSplitTable{
+			line(3, "30"),
+			{SubCategoryID: 22, Value: models.MustParseMoney("20"), Note: strings.Repeat("a", 51)},
+		}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.expense)
+			assert.Equal(t, tt.wantErr, errors.Is(err, ErrInvalidSplit))
+		})
+	}
+}
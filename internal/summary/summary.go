@@ -0,0 +1,371 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// GroupBy is what the totals of the expenses or incomes are grouped by
+type GroupBy string
+
+// The groupings of the totals. Day, week and month are also the periods of the cash flow.
+// Only expenses are grouped by tag.
+const (
+	ByCategory    GroupBy = "category"
+	BySubCategory GroupBy = "subcategory"
+	ByCard        GroupBy = "card"
+	ByTag         GroupBy = "tag"
+	ByDay         GroupBy = "day"
+	ByWeek        GroupBy = "week"
+	ByMonth       GroupBy = "month"
+)
+
+var (
+	// ErrInvalidGroupBy is returned when the totals can not be grouped by the provided value
+	ErrInvalidGroupBy = errors.New("totals must be grouped by category, subcategory, card, tag, day, week or month")
+	// ErrInvalidPeriod is returned when the cash flow can not be reported by the provided period
+	ErrInvalidPeriod = errors.New("cash flow period must be day, week or month")
+)
+
+// ParseGroupBy parses the grouping of the totals, which is by category if empty
+func ParseGroupBy(value string) (GroupBy, error) {
+
+	groupBy := GroupBy(strings.ToLower(strings.TrimSpace(value)))
+	switch groupBy {
+	case "":
+		return ByCategory, nil
+	case ByCategory, BySubCategory, ByCard, ByTag, ByDay, ByWeek, ByMonth:
+		return groupBy, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidGroupBy, value)
+	}
+}
+
+// ParsePeriod parses the period of the cash flow, which is the month if empty
+func ParsePeriod(value string) (GroupBy, error) {
+
+	period := GroupBy(strings.ToLower(strings.TrimSpace(value)))
+	switch period {
+	case "":
+		return ByMonth, nil
+	case ByDay, ByWeek, ByMonth:
+		return period, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidPeriod, value)
+	}
+}
+
+// Total is the sum of the values of the expenses or incomes of a group in a currency.
+// The key is the category, subcategory, card or tag name, the day or the first day of the week
+// as YYYY-MM-DD, or the month as YYYY-MM.
+type Total struct {
+	Key      string
+	Currency string
+	Value    models.Money
+	Count    int64
+}
+
+// CashFlow is the incomes minus the expenses of a period in a currency
+type CashFlow struct {
+	Period   string
+	Currency string
+	Incomes  models.Money
+	Expenses models.Money
+	Net      models.Money
+}
+
+// Summarizer sums the expenses and incomes of a user.
+// Without a reporting currency the totals of each currency are kept apart;
+// with one, every amount is converted at the exchange rate of its day before being summed.
+type Summarizer struct {
+	ExpensesRepository repository.ExpenseRepo
+	IncomesRepository  repository.IncomeRepo
+	Rates              currency.Rates
+}
+
+// NewSummarizer creates a new Summarizer
+func NewSummarizer(expRepo repository.ExpenseRepo, incRepo repository.IncomeRepo, rates currency.Rates) Summarizer {
+	return Summarizer{
+		ExpensesRepository: expRepo,
+		IncomesRepository:  incRepo,
+		Rates:              rates,
+	}
+}
+
+// ExpenseTotals returns the totals of the expenses of the user in the dates' range, sorted by key and currency.
+// An expense with several tags counts towards each of them, and untagged expenses are left out of the tag totals.
+func (s Summarizer) ExpenseTotals(
+	ctx context.Context,
+	userID int64,
+	groupBy GroupBy,
+	minDate time.Time,
+	maxDate time.Time,
+	reportingCurrency string,
+) ([]Total, error) {
+
+	dailyTotals, err := s.expenseDailyTotals(ctx, userID, groupBy, minDate, maxDate, reportingCurrency)
+	if err != nil {
+		return []Total{}, err
+	}
+
+	return Totals(dailyTotals, groupBy)
+}
+
+// IncomeTotals returns the totals of the incomes of the user in the dates' range, sorted by key and currency.
+// Incomes have no subcategories and no tag totals.
+func (s Summarizer) IncomeTotals(
+	ctx context.Context,
+	userID int64,
+	groupBy GroupBy,
+	minDate time.Time,
+	maxDate time.Time,
+	reportingCurrency string,
+) ([]Total, error) {
+
+	if groupBy == BySubCategory {
+		return []Total{}, fmt.Errorf("%w: incomes have no subcategories", ErrInvalidGroupBy)
+	}
+
+	if groupBy == ByTag {
+		return []Total{}, fmt.Errorf("%w: incomes have no tag totals", ErrInvalidGroupBy)
+	}
+
+	dailyTotals, err := s.incomeDailyTotals(ctx, userID, minDate, maxDate, reportingCurrency)
+	if err != nil {
+		return []Total{}, err
+	}
+
+	return Totals(dailyTotals, groupBy)
+}
+
+// CashFlow returns the incomes, expenses and net cash flow of the user by period in the dates' range,
+// sorted by period and currency
+func (s Summarizer) CashFlow(
+	ctx context.Context,
+	userID int64,
+	period GroupBy,
+	minDate time.Time,
+	maxDate time.Time,
+	reportingCurrency string,
+) ([]CashFlow, error) {
+
+	if period != ByDay && period != ByWeek && period != ByMonth {
+		return []CashFlow{}, fmt.Errorf("%w: %q", ErrInvalidPeriod, period)
+	}
+
+	incomes, err := s.incomeDailyTotals(ctx, userID, minDate, maxDate, reportingCurrency)
+	if err != nil {
+		return []CashFlow{}, err
+	}
+
+	expenses, err := s.expenseDailyTotals(ctx, userID, period, minDate, maxDate, reportingCurrency)
+	if err != nil {
+		return []CashFlow{}, err
+	}
+
+	incomeTotals, err := Totals(incomes, period)
+	if err != nil {
+		return []CashFlow{}, err
+	}
+
+	expenseTotals, err := Totals(expenses, period)
+	if err != nil {
+		return []CashFlow{}, err
+	}
+
+	return Flows(incomeTotals, expenseTotals), nil
+}
+
+// expenseDailyTotals gets the daily totals of the expenses, by tag when they are grouped by tag
+func (s Summarizer) expenseDailyTotals(
+	ctx context.Context,
+	userID int64,
+	groupBy GroupBy,
+	minDate time.Time,
+	maxDate time.Time,
+	reportingCurrency string,
+) ([]models.DailyTotal, error) {
+
+	reportingCurrency, err := normalizeCurrency(reportingCurrency)
+	if err != nil {
+		return []models.DailyTotal{}, err
+	}
+
+	if groupBy == ByTag {
+		dailyTotals, err := s.ExpensesRepository.GetExpenseTagDailyTotals(ctx, userID, minDate, maxDate)
+		if err != nil {
+			return []models.DailyTotal{}, fmt.Errorf("could not get expense tag daily totals: %v", err)
+		}
+		return s.convert(dailyTotals, reportingCurrency)
+	}
+
+	dailyTotals, err := s.ExpensesRepository.GetExpenseDailyTotals(ctx, userID, minDate, maxDate)
+	if err != nil {
+		return []models.DailyTotal{}, fmt.Errorf("could not get expense daily totals: %v", err)
+	}
+
+	return s.convert(dailyTotals, reportingCurrency)
+}
+
+func (s Summarizer) incomeDailyTotals(
+	ctx context.Context,
+	userID int64,
+	minDate time.Time,
+	maxDate time.Time,
+	reportingCurrency string,
+) ([]models.DailyTotal, error) {
+
+	reportingCurrency, err := normalizeCurrency(reportingCurrency)
+	if err != nil {
+		return []models.DailyTotal{}, err
+	}
+
+	dailyTotals, err := s.IncomesRepository.GetIncomeDailyTotals(ctx, userID, minDate, maxDate)
+	if err != nil {
+		return []models.DailyTotal{}, fmt.Errorf("could not get income daily totals: %v", err)
+	}
+
+	return s.convert(dailyTotals, reportingCurrency)
+}
+
+// convert converts the daily totals to the reporting currency, if there is one
+func (s Summarizer) convert(dailyTotals []models.DailyTotal, reportingCurrency string) ([]models.DailyTotal, error) {
+
+	if reportingCurrency == "" {
+		return dailyTotals, nil
+	}
+
+	converted := make([]models.DailyTotal, 0, len(dailyTotals))
+	for _, total := range dailyTotals {
+		value, err := s.Rates.Convert(total.Value, total.Currency, reportingCurrency, total.Day)
+		if err != nil {
+			return []models.DailyTotal{}, err
+		}
+
+		total.Value = value
+		total.Currency = reportingCurrency
+		converted = append(converted, total)
+	}
+
+	return converted, nil
+}
+
+// Totals groups the daily totals, keeping the totals of each currency apart
+func Totals(dailyTotals []models.DailyTotal, groupBy GroupBy) ([]Total, error) {
+
+	type groupKey struct {
+		key      string
+		currency string
+	}
+
+	groups := map[groupKey]*Total{}
+	for _, daily := range dailyTotals {
+
+		var key string
+		switch groupBy {
+		case ByCategory:
+			key = daily.Category
+		case BySubCategory:
+			key = daily.SubCategory
+		case ByCard:
+			key = daily.Card
+		case ByTag:
+			key = daily.Tag
+		case ByDay:
+			key = daily.Day.Format("2006-01-02")
+		case ByWeek:
+			key = WeekStart(daily.Day).Format("2006-01-02")
+		case ByMonth:
+			key = daily.Day.Format("2006-01")
+		default:
+			return []Total{}, fmt.Errorf("%w: %q", ErrInvalidGroupBy, groupBy)
+		}
+
+		group, ok := groups[groupKey{key: key, currency: daily.Currency}]
+		if !ok {
+			group = &Total{Key: key, Currency: daily.Currency}
+			groups[groupKey{key: key, currency: daily.Currency}] = group
+		}
+
+		group.Value += daily.Value
+		group.Count += daily.Count
+	}
+
+	totals := make([]Total, 0, len(groups))
+	for _, group := range groups {
+		totals = append(totals, *group)
+	}
+
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Key != totals[j].Key {
+			return totals[i].Key < totals[j].Key
+		}
+		return totals[i].Currency < totals[j].Currency
+	})
+
+	return totals, nil
+}
+
+// Flows merges the income and expense totals of the same period and currency into cash flows
+func Flows(incomes []Total, expenses []Total) []CashFlow {
+
+	type flowKey struct {
+		period   string
+		currency string
+	}
+
+	flows := map[flowKey]*CashFlow{}
+	flowOf := func(total Total) *CashFlow {
+		key := flowKey{period: total.Key, currency: total.Currency}
+		flow, ok := flows[key]
+		if !ok {
+			flow = &CashFlow{Period: total.Key, Currency: total.Currency}
+			flows[key] = flow
+		}
+		return flow
+	}
+
+	for _, total := range incomes {
+		flowOf(total).Incomes += total.Value
+	}
+
+	for _, total := range expenses {
+		flowOf(total).Expenses += total.Value
+	}
+
+	cashFlows := make([]CashFlow, 0, len(flows))
+	for _, flow := range flows {
+		flow.Net = flow.Incomes - flow.Expenses
+		cashFlows = append(cashFlows, *flow)
+	}
+
+	sort.Slice(cashFlows, func(i, j int) bool {
+		if cashFlows[i].Period != cashFlows[j].Period {
+			return cashFlows[i].Period < cashFlows[j].Period
+		}
+		return cashFlows[i].Currency < cashFlows[j].Currency
+	})
+
+	return cashFlows
+}
+
+// WeekStart returns the Monday of the week of the date
+func WeekStart(date time.Time) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+func normalizeCurrency(code string) (string, error) {
+	if strings.TrimSpace(code) == "" {
+		return "", nil
+	}
+	return currency.Normalize(code)
+}
@@ -0,0 +1,200 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/currency"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/cache"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/mock"
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+var dailyTotals = []models.DailyTotal{
+	{Day: date(2024, time.January, 30), Category: "House", SubCategory: "Rent", Card: "CGD", Currency: "EUR", Value: models.MustParseMoney("500"), Count: 1},
+	{Day: date(2024, time.January, 31), Category: "Leisure", SubCategory: "Restaurants", Card: "CGD", Currency: "EUR", Value: models.MustParseMoney("20.50"), Count: 2},
+	{Day: date(2024, time.February, 1), Category: "Leisure", SubCategory: "Restaurants", Card: "Revolut", Currency: "GBP", Value: models.MustParseMoney("10"), Count: 1},
+	{Day: date(2024, time.February, 5), Category: "House", SubCategory: "Electricity", Card: "CGD", Currency: "EUR", Value: models.MustParseMoney("40"), Count: 1},
+}
+
+func TestParseGroupBy(t *testing.T) {
+
+	groupBy, err := ParseGroupBy("")
+	assert.NoError(t, err)
+	assert.Equal(t, ByCategory, groupBy)
+
+	groupBy, err = ParseGroupBy(" Week ")
+	assert.NoError(t, err)
+	assert.Equal(t, ByWeek, groupBy)
+
+	_, err = ParseGroupBy("year")
+	assert.True(t, errors.Is(err, ErrInvalidGroupBy))
+
+	period, err := ParsePeriod("")
+	assert.NoError(t, err)
+	assert.Equal(t, ByMonth, period)
+
+	_, err = ParsePeriod("category")
+	assert.True(t, errors.Is(err, ErrInvalidPeriod))
+}
+
+func TestWeekStart(t *testing.T) {
+	assert.Equal(t, date(2024, time.January, 29), WeekStart(date(2024, time.January, 29)))
+	assert.Equal(t, date(2024, time.January, 29), WeekStart(date(2024, time.February, 4).Add(23*time.Hour)))
+	assert.Equal(t, date(2024, time.February, 5), WeekStart(date(2024, time.February, 5)))
+}
+
+func TestTotals(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		groupBy GroupBy
+		want    []Total
+	}{
+		{
+			name:    "By category keeps currencies apart",
+			groupBy: ByCategory,
+			want: []Total{
+				{Key: "House", Currency: "EUR", Value: models.MustParseMoney("540"), Count: 2},
+				{Key: "Leisure", Currency: "EUR", Value: models.MustParseMoney("20.50"), Count: 2},
+				{Key: "Leisure", Currency: "GBP", Value: models.MustParseMoney("10"), Count: 1},
+			},
+		},
+		{
+			name:    "By subcategory",
+			groupBy: BySubCategory,
+			want: []Total{
+				{Key: "Electricity", Currency: "EUR", Value: models.MustParseMoney("40"), Count: 1},
+				{Key: "Rent", Currency: "EUR", Value: models.MustParseMoney("500"), Count: 1},
+				{Key: "Restaurants", Currency: "EUR", Value: models.MustParseMoney("20.50"), Count: 2},
+				{Key: "Restaurants", Currency: "GBP", Value: models.MustParseMoney("10"), Count: 1},
+			},
+		},
+		{
+			name:    "By card",
+			groupBy: ByCard,
+			want: []Total{
+				{Key: "CGD", Currency: "EUR", Value: models.MustParseMoney("560.50"), Count: 4},
+				{Key: "Revolut", Currency: "GBP", Value: models.MustParseMoney("10"), Count: 1},
+			},
+		},
+		{
+			name:    "By week starting on Monday",
+			groupBy: ByWeek,
+			want: []Total{
+				{Key: "2024-01-29", Currency: "EUR", Value: models.MustParseMoney("520.50"), Count: 3},
+				{Key: "2024-01-29", Currency: "GBP", Value: models.MustParseMoney("10"), Count: 1},
+				{Key: "2024-02-05", Currency: "EUR", Value: models.MustParseMoney("40"), Count: 1},
+			},
+		},
+		{
+			name:    "By month",
+			groupBy: ByMonth,
+			want: []Total{
+				{Key: "2024-01", Currency: "EUR", Value: models.MustParseMoney("520.50"), Count: 3},
+				{Key: "2024-02", Currency: "EUR", Value: models.MustParseMoney("40"), Count: 1},
+				{Key: "2024-02", Currency: "GBP", Value: models.MustParseMoney("10"), Count: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Totals(dailyTotals, tt.groupBy)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := Totals(dailyTotals, "year")
+	assert.True(t, errors.Is(err, ErrInvalidGroupBy))
+}
+
+func TestFlows(t *testing.T) {
+
+	incomes := []Total{
+		{Key: "2024-01", Currency: "EUR", Value: models.MustParseMoney("1000")},
+		{Key: "2024-02", Currency: "GBP", Value: models.MustParseMoney("5")},
+	}
+	expenses := []Total{
+		{Key: "2024-01", Currency: "EUR", Value: models.MustParseMoney("520.50")},
+		{Key: "2024-02", Currency: "EUR", Value: models.MustParseMoney("40")},
+	}
+
+	assert.Equal(t, []CashFlow{
+		{Period: "2024-01", Currency: "EUR", Incomes: models.MustParseMoney("1000"), Expenses: models.MustParseMoney("520.50"), Net: models.MustParseMoney("479.50")},
+		{Period: "2024-02", Currency: "EUR", Expenses: models.MustParseMoney("40"), Net: models.MustParseMoney("-40")},
+		{Period: "2024-02", Currency: "GBP", Incomes: models.MustParseMoney("5"), Net: models.MustParseMoney("5")},
+	}, Flows(incomes, expenses))
+}
+
+func TestSummarizer_CashFlow(t *testing.T) {
+
+	cardsCache := cache.NewCard([]models.CardTable{
+		{ID: 1, Name: "CGD", Currency: "EUR"},
+		{ID: 2, Name: "Revolut", Currency: "GBP"},
+	})
+	categoriesCache := cache.NewExpenseCategory([]models.ExpenseCategoryTable{{ID: 1, Name: "Leisure"}})
+	subCategoriesCache := cache.NewExpenseSubCategory([]models.ExpenseSubCategoryTable{{ID: 1, Name: "Restaurants", CategoryID: 1}})
+	expensesCache := cache.NewExpense([]models.ExpenseTable{
+		{ID: 1, Value: models.MustParseMoney("10"), Date: date(2024, time.January, 2), SubCategoryID: 1, CardID: 1},
+		{ID: 2, Value: models.MustParseMoney("10"), Date: date(2024, time.January, 2), SubCategoryID: 1, CardID: 2},
+	}, cardsCache, categoriesCache, subCategoriesCache)
+
+	rates := currency.NewRates()
+	assert.NoError(t, rates.Add("GBP", date(2024, time.January, 2), "0.8"))
+
+	summarizer := NewSummarizer(&expensesCache, mock.Income{}, rates)
+
+	cashFlow, err := summarizer.CashFlow(context.Background(), 0, ByMonth, date(2024, time.January, 1), date(2024, time.February, 1), "")
+	assert.NoError(t, err)
+	assert.Equal(t, []CashFlow{
+		{Period: "2024-01", Currency: "EUR", Expenses: models.MustParseMoney("10"), Net: models.MustParseMoney("-10")},
+		{Period: "2024-01", Currency: "GBP", Expenses: models.MustParseMoney("10"), Net: models.MustParseMoney("-10")},
+	}, cashFlow)
+
+	cashFlow, err = summarizer.CashFlow(context.Background(), 0, ByMonth, date(2024, time.January, 1), date(2024, time.February, 1), "eur")
+	assert.NoError(t, err)
+	assert.Equal(t, []CashFlow{
+		{Period: "2024-01", Currency: "EUR", Expenses: models.MustParseMoney("22.50"), Net: models.MustParseMoney("-22.50")},
+	}, cashFlow)
+
+	_, err = summarizer.CashFlow(context.Background(), 0, ByMonth, date(2024, time.January, 1), date(2024, time.February, 1), "USD")
+	assert.True(t, errors.Is(err, currency.ErrNoRate))
+
+	_, err = summarizer.CashFlow(context.Background(), 0, ByCard, date(2024, time.January, 1), date(2024, time.February, 1), "")
+	assert.True(t, errors.Is(err, ErrInvalidPeriod))
+
+	_, err = summarizer.IncomeTotals(context.Background(), 0, BySubCategory, date(2024, time.January, 1), date(2024, time.February, 1), "")
+	assert.True(t, errors.Is(err, ErrInvalidGroupBy))
+}
+
+func TestSummarizer_ExpenseTotalsByTag(t *testing.T) {
+
+	cardsCache := cache.NewCard([]models.CardTable{{ID: 1, Name: "CGD", Currency: "EUR"}})
+	categoriesCache := cache.NewExpenseCategory([]models.ExpenseCategoryTable{{ID: 1, Name: "Leisure"}})
+	subCategoriesCache := cache.NewExpenseSubCategory([]models.ExpenseSubCategoryTable{{ID: 1, Name: "Restaurants", CategoryID: 1}})
+	expensesCache := cache.NewExpense([]models.ExpenseTable{
+		{ID: 1, Value: models.MustParseMoney("10"), Date: date(2024, time.January, 2), SubCategoryID: 1, CardID: 1, Tags: []string{"reimbursable", "work"}},
+		{ID: 2, Value: models.MustParseMoney("15"), Date: date(2024, time.January, 3), SubCategoryID: 1, CardID: 1, Tags: []string{"work"}},
+		{ID: 3, Value: models.MustParseMoney("20"), Date: date(2024, time.January, 4), SubCategoryID: 1, CardID: 1},
+	}, cardsCache, categoriesCache, subCategoriesCache)
+
+	summarizer := NewSummarizer(&expensesCache, mock.Income{}, currency.NewRates())
+
+	totals, err := summarizer.ExpenseTotals(context.Background(), 0, ByTag, date(2024, time.January, 1), date(2024, time.February, 1), "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Total{
+		{Key: "reimbursable", Currency: "EUR", Value: models.MustParseMoney("10"), Count: 1},
+		{Key: "work", Currency: "EUR", Value: models.MustParseMoney("25"), Count: 2},
+	}, totals)
+
+	_, err = summarizer.IncomeTotals(context.Background(), 0, ByTag, date(2024, time.January, 1), date(2024, time.February, 1), "")
+	assert.True(t, errors.Is(err, ErrInvalidGroupBy))
+}
@@ -0,0 +1,91 @@
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// maxLength is the length of the name column of the tags table
+const maxLength = 50
+
+// ErrInvalidTag is returned when a tag can not be stored
+var ErrInvalidTag = errors.New("tag is not valid")
+
+// Normalize checks the tags of an expense or income before they are stored and returns them lower cased,
+// sorted and without repetitions. A tag is a single word of up to 50 characters, such as "trip-lisbon-2026".
+func Normalize(tags []string) ([]string, error) {
+
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, tag := range tags {
+
+		tag = Name(tag)
+		if tag == "" || len([]rune(tag)) > maxLength || strings.IndexFunc(tag, unicode.IsSpace) >= 0 || strings.Contains(tag, ",") {
+			return []string{}, fmt.Errorf("%w: %q must be a single word of up to %d characters", ErrInvalidTag, tag, maxLength)
+		}
+
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+
+	sort.Strings(normalized)
+
+	return normalized, nil
+}
+
+// Name returns the stored name of a tag, which is lower cased
+func Name(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// Has tells if the tags include the tag, in any case
+func Has(tags []string, tag string) bool {
+	tag = Name(tag)
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Expenses returns the expenses with the tag, or all of them if the tag is empty
+func Expenses(expenses []models.ExpenseView, tag string) []models.ExpenseView {
+
+	if Name(tag) == "" {
+		return expenses
+	}
+
+	tagged := []models.ExpenseView{}
+	for _, exp := range expenses {
+		if Has(exp.Tags, tag) {
+			tagged = append(tagged, exp)
+		}
+	}
+
+	return tagged
+}
+
+// Incomes returns the incomes with the tag, or all of them if the tag is empty
+func Incomes(incomes []models.IncomeView, tag string) []models.IncomeView {
+
+	if Name(tag) == "" {
+		return incomes
+	}
+
+	tagged := []models.IncomeView{}
+	for _, inc := range incomes {
+		if Has(inc.Tags, tag) {
+			tagged = append(tagged, inc)
+		}
+	}
+
+	return tagged
+}
@@ -0,0 +1,49 @@
+package tags
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "No tags", tags: nil, want: []string{}},
+		{name: "Lower cased, sorted and without repetitions", tags: []string{" Trip-Lisbon-2026", "reimbursable", "trip-lisbon-2026"}, want: []string{"reimbursable", "trip-lisbon-2026"}},
+		{name: "Empty tag", tags: []string{" "}, wantErr: true},
+		{name: "Several words", tags: []string{"trip lisbon"}, wantErr: true},
+		{name: "Comma", tags: []string{"trip,lisbon"}, wantErr: true},
+		{name: "Too long", tags: []string{strings.Repeat("a", 51)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.tags)
+			assert.Equal(t, tt.wantErr, errors.Is(err, ErrInvalidTag))
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExpenses(t *testing.T) {
+
+	expenses := []models.ExpenseView{
+		{ID: 1, Tags: []string{"reimbursable", "trip-lisbon-2026"}},
+		{ID: 2},
+		{ID: 3, Tags: []string{"trip-lisbon-2026"}},
+	}
+
+	assert.Equal(t, expenses, Expenses(expenses, ""))
+	assert.Equal(t, []models.ExpenseView{expenses[0], expenses[2]}, Expenses(expenses, "Trip-Lisbon-2026"))
+	assert.Equal(t, []models.ExpenseView{}, Expenses(expenses, "work"))
+}
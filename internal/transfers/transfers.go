@@ -0,0 +1,25 @@
+package transfers
+
+import (
+	"errors"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+)
+
+// ErrInvalidTransfer is returned when a transfer can not be stored
+var ErrInvalidTransfer = errors.New("transfer is not valid")
+
+// Validate checks a transfer before it is stored: it must move a positive amount between two different cards
+// and its fee can not be negative.
+func Validate(transfer models.TransferTable) error {
+
+	if transfer.FromCardID == transfer.ToCardID {
+		return ErrInvalidTransfer
+	}
+
+	if transfer.Amount <= 0 || transfer.Fee < 0 {
+		return ErrInvalidTransfer
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package transfers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rubengomes8/golang-personal-finances/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		transfer models.TransferTable
+		wantErr  bool
+	}{
+		{name: "Transfer", transfer: models.TransferTable{FromCardID: 1, ToCardID: 2, Amount: models.MustParseMoney("100")}, wantErr: false},
+		{name: "Transfer with fee", transfer: models.TransferTable{FromCardID: 1, ToCardID: 2, Amount: models.MustParseMoney("100"), Fee: models.MustParseMoney("0.50")}, wantErr: false},
+		{name: "Same card", transfer: models.TransferTable{FromCardID: 1, ToCardID: 1, Amount: models.MustParseMoney("100")}, wantErr: true},
+		{name: "Zero amount", transfer: models.TransferTable{FromCardID: 1, ToCardID: 2}, wantErr: true},
+		{name: "Negative fee", transfer: models.TransferTable{FromCardID: 1, ToCardID: 2, Amount: models.MustParseMoney("100"), Fee: models.MustParseMoney("-1")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.transfer)
+			assert.Equal(t, tt.wantErr, errors.Is(err, ErrInvalidTransfer))
+		})
+	}
+}
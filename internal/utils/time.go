@@ -9,3 +9,11 @@ func DateStringToTime(date string) (time.Time, error) {
 func TimeToStringDate(t time.Time) string {
 	return t.Format("2006-01-02")
 }
+
+func MonthStringToTime(month string) (time.Time, error) {
+	return time.Parse("2006-01", month)
+}
+
+func TimeToStringMonth(t time.Time) string {
+	return t.Format("2006-01")
+}
@@ -77,3 +77,43 @@ func Test_dateStringToTime(t *testing.T) {
 		})
 	}
 }
+
+func Test_monthStringToTime(t *testing.T) {
+	type args struct {
+		month string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			args: args{
+				month: "2020-02",
+			},
+			want:    firstFebruary2020ZeroHoursUTCTime,
+			wantErr: false,
+		},
+		{
+			name: "ErrorDateInsteadOfMonth",
+			args: args{
+				month: firstFebruary2020String,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MonthStringToTime(tt.args.month)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("monthStringToTime() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("monthStringToTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}